@@ -19,123 +19,241 @@ package cmd
 
 import (
 	"bufio"
-	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
 	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
 	"github.com/spf13/cobra"
 )
 
+const DEFAULT_ACTION_RUNTIME = "nodejs:6"
+
+// flags of the `add` commands, values which are not provided are prompted for
+// unless the name of the entity is passed as an argument
+var addFlags struct {
+	pkg      string
+	function string
+	runtime  string
+	main     string
+	web      bool
+	feed     string
+	trigger  string
+	action   string
+}
+
 // addCmd represents the add command
 var addCmd = &cobra.Command{
 	Use:   "add",
 	SuggestFor: []string {"increase"},
-	Short: "Add an action, feed, trigger or rule to the manifest",
+	Short: "Add an action, trigger or rule to the manifest",
+	Long: `Add an action, trigger or rule to the manifest file, the manifest file is
+created if it does not exist. Existing comments and entries of the manifest are
+preserved. When the name of the entity is not given, its values are prompted for.`,
 }
 
 // action represents the `add action` command
 var actionCmd = &cobra.Command{
-	Use:   "action",
+	Use:   "action [name]",
 	Short: "add action to the manifest file and create default directory structure.",
+	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		maniyaml, err := parsers.ReadOrCreateManifest()
-        if err != nil {
-            return err
-        }
-
-	reader := bufio.NewReader(os.Stdin)
-	action := parsers.Action{}
-
-	for {
-		action.Name = utils.Ask(reader, "Name", "")
+		reader := bufio.NewReader(os.Stdin)
+		interactive := len(args) == 0
+		manifestPath := getAddManifestPath()
 
-		// Check action name is unique
-		if _, ok := maniyaml.Package.Actions[action.Name]; !ok {
-			break
+		editor, pkg, err := openManifest(reader, manifestPath, interactive)
+		if err != nil {
+			return err
 		}
-		fmt.Print(action.Name + " is already used. Pick another action name\n")
-	}
 
-	action.Runtime = utils.Ask(reader, "Runtime", "nodejs:6")
-	maniyaml.Package.Actions[action.Name] = action
+		name := askIfMissing(reader, "Name", argOrEmpty(args), "", true)
+		function := askIfMissing(reader, "Function", addFlags.function, filepath.ToSlash(filepath.Join("actions", name+".js")), interactive)
+		runtime := askIfMissing(reader, "Runtime", addFlags.runtime, DEFAULT_ACTION_RUNTIME, interactive)
 
-	// Create directory structure before update manifest, as a way
-	// to check the action name is a valid path name
-	err = os.MkdirAll("actions/"+action.Name, 0777)
+		action := map[string]interface{}{"function": function}
+		if len(runtime) > 0 {
+			action["runtime"] = runtime
+		}
+		if len(addFlags.main) > 0 {
+			action["main"] = addFlags.main
+		}
+		if addFlags.web {
+			action["web"] = true
+		}
 
-        if err != nil {
-            return err
-        }
+		if err := addToManifest(editor, pkg, parsers.YAML_KEY_ACTIONS, name, action); err != nil {
+			return err
+		}
 
-		return parsers.Write(maniyaml, utils.ManifestFileNameYaml)
+		// Create the directory of the action's function, relative to the manifest
+		return os.MkdirAll(filepath.Dir(filepath.Join(filepath.Dir(manifestPath), function)), 0777)
 	},
 }
 
 // trigger represents the `add trigger` command
 var triggerCmd = &cobra.Command{
-	Use:   "trigger",
+	Use:   "trigger [name]",
 	Short: "add trigger to the manifest file.",
+	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		maniyaml, err := parsers.ReadOrCreateManifest()
-        if err != nil {
-            return err
-        }
-
 		reader := bufio.NewReader(os.Stdin)
-		trigger := parsers.Trigger{}
-
-		for {
-			trigger.Name = utils.Ask(reader, "Name", "")
+		interactive := len(args) == 0
 
-			// Check trigger name is unique
-			if _, ok := maniyaml.Package.Triggers[trigger.Name]; !ok {
-				break
-			}
-			fmt.Print(trigger.Name + " is already used. Pick another trigger name\n")
+		editor, pkg, err := openManifest(reader, getAddManifestPath(), interactive)
+		if err != nil {
+			return err
 		}
 
-		trigger.Feed = utils.Ask(reader, "Feed", "")
-		maniyaml.Package.Triggers[trigger.Name] = trigger
+		name := askIfMissing(reader, "Name", argOrEmpty(args), "", true)
+		feed := askIfMissing(reader, "Feed", addFlags.feed, "", interactive)
+
+		trigger := map[string]interface{}{}
+		if len(feed) > 0 {
+			trigger["feed"] = feed
+		}
 
-		return parsers.Write(maniyaml, utils.ManifestFileNameYaml)
+		return addToManifest(editor, pkg, parsers.YAML_KEY_TRIGGERS, name, trigger)
 	},
 }
 
 // rule represents the `add rule` command
 var ruleCmd = &cobra.Command{
-	Use:   "rule",
+	Use:   "rule [name]",
 	Short: "add rule to the manifest file.",
+	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		maniyaml, err := parsers.ReadOrCreateManifest()
-        if err != nil {
-            return err
-        }
-
 		reader := bufio.NewReader(os.Stdin)
-		rule := parsers.Rule{}
+		interactive := len(args) == 0
 
-		for {
-			rule.Rule = utils.Ask(reader, "Rule Name", "")
+		editor, pkg, err := openManifest(reader, getAddManifestPath(), interactive)
+		if err != nil {
+			return err
+		}
 
-			// Check rule name is unique
-			if _, ok := maniyaml.Package.Triggers[rule.Rule]; !ok {
-				break
+		name := askIfMissing(reader, "Rule Name", argOrEmpty(args), "", true)
+		trigger := askIfMissing(reader, "Trigger", addFlags.trigger, "", interactive)
+		action := askIfMissing(reader, "Action", addFlags.action, "", interactive)
+		for key, value := range map[string]string{parsers.YAML_KEY_TRIGGER: trigger, parsers.YAML_KEY_ACTION: action} {
+			if len(value) == 0 {
+				return missingValueError(key)
 			}
-			fmt.Print(rule.Rule + " is already used. Pick another rule name\n")
 		}
 
-		rule.Action = utils.Ask(reader, "Action", "")
-		rule.Trigger = utils.Ask(reader, "Trigger", "")
-		maniyaml.Package.Rules[rule.Rule] = rule
+		rule := map[string]interface{}{
+			"trigger": trigger,
+			"action":  action,
+		}
 
-		return parsers.Write(maniyaml, utils.ManifestFileNameYaml)
+		return addToManifest(editor, pkg, parsers.YAML_KEY_RULES, name, rule)
 	},
 }
 
+func getAddManifestPath() string {
+	if len(utils.Flags.ManifestPath) > 0 {
+		return utils.Flags.ManifestPath
+	}
+	return utils.ManifestFileNameYaml
+}
+
+// openManifest reads the manifest and selects the package entities are added to,
+// a manifest which declares a single package does not require the package name
+func openManifest(reader *bufio.Reader, manifestPath string, interactive bool) (*parsers.ManifestEditor, string, error) {
+	editor, err := parsers.NewManifestEditor(manifestPath)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(addFlags.pkg) > 0 {
+		return editor, addFlags.pkg, nil
+	}
+
+	packages := editor.PackageNames()
+	switch {
+	case len(packages) == 1:
+		return editor, packages[0], nil
+	case interactive && len(packages) > 1:
+		return editor, utils.Ask(reader, "Package", packages[0]), nil
+	case interactive:
+		return editor, askName(reader, ""), nil
+	case len(packages) == 0:
+		return editor, defaultProjectName(), nil
+	}
+
+	return nil, "", wskderrors.NewCommandError(addCmd.Name(),
+		wski18n.T(wski18n.ID_ERR_ADD_PACKAGE_REQUIRED_X_path_X,
+			map[string]interface{}{wski18n.KEY_PATH: manifestPath}))
+}
+
+func addToManifest(editor *parsers.ManifestEditor, pkg string, section string, name string, entity map[string]interface{}) error {
+	if len(name) == 0 {
+		return missingValueError("name")
+	}
+
+	if err := editor.Add(pkg, section, name, entity); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(editor.Filename), 0777); err != nil {
+		return err
+	}
+	if err := editor.Write(); err != nil {
+		return err
+	}
+
+	wskprint.PrintlnOpenWhiskSuccess(wski18n.T(wski18n.ID_MSG_MANIFEST_ENTITY_ADDED_X_key_X_name_X_path_X,
+		map[string]interface{}{
+			wski18n.KEY_KEY:  strings.TrimSuffix(section, "s"),
+			wski18n.KEY_NAME: name,
+			wski18n.KEY_PATH: editor.Filename}))
+	return nil
+}
+
+func missingValueError(key string) error {
+	return wskderrors.NewCommandError(addCmd.Name(),
+		wski18n.T(wski18n.ID_ERR_MISSING_MANDATORY_KEY_X_key_X,
+			map[string]interface{}{wski18n.KEY_KEY: key}))
+}
+
+// askIfMissing returns the value if it was provided, otherwise it prompts for it
+// in interactive mode or falls back to the default value
+func askIfMissing(reader *bufio.Reader, question string, value string, def string, interactive bool) string {
+	if len(value) > 0 {
+		return value
+	}
+	if interactive {
+		return strings.TrimSpace(utils.Ask(reader, question, def))
+	}
+	return def
+}
+
+func argOrEmpty(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+	return ""
+}
+
 func init() {
 	RootCmd.AddCommand(addCmd)
 	addCmd.AddCommand(actionCmd)
 	addCmd.AddCommand(triggerCmd)
 	addCmd.AddCommand(ruleCmd)
+
+	addCmd.PersistentFlags().StringVarP(&utils.Flags.ManifestPath, "manifest", "m", "", wski18n.T(wski18n.ID_CMD_FLAG_ADD_MANIFEST))
+	addCmd.PersistentFlags().StringVarP(&addFlags.pkg, "package", "", "", wski18n.T(wski18n.ID_CMD_FLAG_ADD_PACKAGE))
+
+	actionCmd.Flags().StringVarP(&addFlags.function, "function", "f", "", wski18n.T(wski18n.ID_CMD_FLAG_ADD_FUNCTION))
+	actionCmd.Flags().StringVarP(&addFlags.runtime, "runtime", "r", "", wski18n.T(wski18n.ID_CMD_FLAG_ADD_RUNTIME_X_runtime_X,
+		map[string]interface{}{wski18n.KEY_RUNTIME: DEFAULT_ACTION_RUNTIME}))
+	actionCmd.Flags().StringVarP(&addFlags.main, "main", "", "", wski18n.T(wski18n.ID_CMD_FLAG_ADD_MAIN))
+	actionCmd.Flags().BoolVarP(&addFlags.web, "web", "", false, wski18n.T(wski18n.ID_CMD_FLAG_ADD_WEB))
+
+	triggerCmd.Flags().StringVarP(&addFlags.feed, "feed", "", "", wski18n.T(wski18n.ID_CMD_FLAG_ADD_FEED))
+
+	ruleCmd.Flags().StringVarP(&addFlags.trigger, "trigger", "", "", wski18n.T(wski18n.ID_CMD_FLAG_ADD_TRIGGER))
+	ruleCmd.Flags().StringVarP(&addFlags.action, "action", "", "", wski18n.T(wski18n.ID_CMD_FLAG_ADD_ACTION))
 }
@@ -0,0 +1,81 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package cmd
+
+import (
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/deployers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskdeploy"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+	"github.com/spf13/cobra"
+)
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Print the API host, auth key and namespace and where they come from",
+	Long: `Config resolves the API host, auth key, namespace, client key and cert the way
+a deployment does and prints each of them along with where it was read from,
+the first one set in this order: the command line (--apihost, --auth,
+--namespace, --key, --cert), the environment variables WHISK_APIHOST,
+WHISK_AUTH, WHISK_NAMESPACE, WHISK_CLIENT_KEY and WHISK_CLIENT_CERT, the
+deployment file, the manifest file, the provider (--provider), the profile
+(--profile) or .wskprops. The key of the auth key is masked.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		values, err := wskdeploy.ResolveClientConfig()
+		if err != nil {
+			return err
+		}
+		// the values are named after the flags which set them
+		printConfigValue("apihost", values.ApiHost)
+		printConfigValue("auth", maskAuth(values.Credential))
+		printConfigValue("namespace", values.Namespace)
+		printConfigValue("key", values.Key)
+		printConfigValue("cert", values.Cert)
+		return nil
+	},
+}
+
+func printConfigValue(key string, value deployers.PropertyValue) {
+	if len(value.Value) == 0 {
+		wskprint.PrintlnOpenWhiskOutput(wski18n.T(wski18n.ID_MSG_CONFIG_VALUE_NOT_SET_X_key_X,
+			map[string]interface{}{wski18n.KEY_KEY: key}))
+		return
+	}
+	wskprint.PrintlnOpenWhiskOutput(wski18n.T(wski18n.ID_MSG_CONFIG_VALUE_X_key_X_value_X_source_X,
+		map[string]interface{}{wski18n.KEY_KEY: key, wski18n.KEY_VALUE: value.Value, wski18n.KEY_SOURCE: value.Source}))
+}
+
+// maskAuth keeps the UUID of an auth key, i.e. <uuid>:<key>, and masks its key
+func maskAuth(auth deployers.PropertyValue) deployers.PropertyValue {
+	if len(auth.Value) == 0 {
+		return auth
+	}
+	auth.Value = strings.SplitN(auth.Value, ":", 2)[0] + ":" + wskprint.STR_SECRET_MASK
+	return auth
+}
+
+func init() {
+	RootCmd.AddCommand(configCmd)
+
+	configCmd.Flags().StringVarP(&utils.Flags.ProjectPath, "project", "p", ".", "path to serverless project")
+	configCmd.Flags().StringVarP(&utils.Flags.ManifestPath, "manifest", "m", "", "path to manifest file")
+	configCmd.Flags().StringVarP(&utils.Flags.DeploymentPath, "deployment", "d", "", "path to deployment file")
+}
@@ -0,0 +1,151 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/deployers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskdeploy"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+var exportFlags struct {
+	format string
+	output string
+}
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the entities of the project as wsk CLI commands or a serverless.yml",
+	Long: `Export composes the entities of the project from its manifest and deployment
+files, as a deployment would, and writes them in another format instead of
+deploying them. With --format ` + deployers.EXPORT_FORMAT_WSK_SCRIPT + `, the project is written as a shell script
+of wsk CLI commands which creates its packages, actions, sequences, triggers,
+rules and APIs with their parameters and annotations.
+
+With --format ` + deployers.EXPORT_FORMAT_SERVERLESS + `, the manifest is converted to a serverless.yml of the
+serverless-openwhisk plugin of the Serverless Framework, to be written next to
+the manifest. What has no equivalent in serverless.yml is reported and left
+out. See "wskdeploy import" for the other way around.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if exportFlags.format != deployers.EXPORT_FORMAT_WSK_SCRIPT && exportFlags.format != deployers.EXPORT_FORMAT_SERVERLESS {
+			return wskderrors.NewCommandError("export",
+				wski18n.T(wski18n.ID_ERR_EXPORT_FORMAT_UNKNOWN_X_format_X_formats_X,
+					map[string]interface{}{wski18n.KEY_FORMAT: exportFlags.format,
+						wski18n.KEY_FORMATS: deployers.EXPORT_FORMAT_WSK_SCRIPT + ", " + deployers.EXPORT_FORMAT_SERVERLESS}))
+		}
+
+		projectPath, _ := filepath.Abs(utils.Flags.ProjectPath)
+		manifestPath := findProjectFile(utils.Flags.ManifestPath, projectPath, utils.ManifestFileNameYaml, utils.ManifestFileNameYml)
+		if len(manifestPath) == 0 {
+			return wskderrors.NewErrorManifestFileNotFound(projectPath,
+				wski18n.T(wski18n.ID_MSG_MANIFEST_FILE_NOT_FOUND_X_path_X,
+					map[string]interface{}{wski18n.KEY_PATH: projectPath}))
+		}
+		utils.Flags.ManifestPath = manifestPath
+		if err := wskdeploy.LoadEnvFile(projectPath, &utils.Flags); err != nil {
+			return err
+		}
+
+		whisk.SetVerbose(utils.Flags.Verbose)
+		whisk.SetDebug(utils.Flags.Verbose)
+		defer parsers.Deprecations.Print()
+
+		if exportFlags.format == deployers.EXPORT_FORMAT_SERVERLESS {
+			content, err := exportServerless(manifestPath)
+			if err != nil {
+				return err
+			}
+			return writeExport(content, 0644)
+		}
+
+		deployer := deployers.NewServiceDeployer()
+		deployer.ProjectPath = projectPath
+		deployer.ManifestPath = manifestPath
+		deployer.DeploymentPath = findProjectFile(utils.Flags.DeploymentPath, projectPath, utils.DeploymentFileNameYaml, utils.DeploymentFileNameYml)
+		deployer.IsDefault = utils.Flags.UseDefaults
+		// the namespace and the runtimes of the project are the ones of its credentials
+		if err := wskdeploy.SetDeployerClient(deployer); err != nil {
+			return err
+		}
+		if err := deployer.ConstructDeploymentPlan(); err != nil {
+			return err
+		}
+
+		script := new(bytes.Buffer)
+		if err := deployer.ExportWskScript(script); err != nil {
+			return err
+		}
+		return writeExport(script.Bytes(), 0755)
+	},
+}
+
+// exportServerless converts the manifest to a serverless.yml, the warnings
+// about what is left out are printed
+func exportServerless(manifestPath string) ([]byte, error) {
+	manifest, err := parsers.NewYAMLParser().ParseManifest(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	serverless, warnings := parsers.ManifestToServerless(manifest)
+	for _, warning := range warnings {
+		wskprint.PrintlnOpenWhiskWarning(warning)
+	}
+	content, err := yaml.Marshal(serverless)
+	if err != nil {
+		return nil, wskderrors.NewYAMLParserErr(manifestPath, err)
+	}
+	return content, nil
+}
+
+// writeExport writes the exported project to --output, or to the standard
+// output
+func writeExport(content []byte, mode os.FileMode) error {
+	if len(exportFlags.output) == 0 {
+		wskprint.PrintlnOpenWhiskOutput(string(content))
+		return nil
+	}
+	if err := ioutil.WriteFile(exportFlags.output, content, mode); err != nil {
+		return wskderrors.NewFileReadError(exportFlags.output, err.Error())
+	}
+	wskprint.PrintlnOpenWhiskSuccess(wski18n.T(wski18n.ID_MSG_EXPORT_WRITTEN_X_path_X,
+		map[string]interface{}{wski18n.KEY_PATH: exportFlags.output}))
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(exportCmd)
+
+	exportCmd.Flags().StringVarP(&utils.Flags.ProjectPath, "project", "p", ".", "path to serverless project")
+	exportCmd.Flags().StringVarP(&utils.Flags.ManifestPath, "manifest", "m", "", "path to manifest file")
+	exportCmd.Flags().StringVarP(&utils.Flags.DeploymentPath, "deployment", "d", "", "path to deployment file")
+	exportCmd.Flags().StringVarP(&exportFlags.format, "format", "", deployers.EXPORT_FORMAT_WSK_SCRIPT, "format the project is exported to, "+deployers.EXPORT_FORMAT_WSK_SCRIPT+" or "+deployers.EXPORT_FORMAT_SERVERLESS)
+	exportCmd.Flags().StringVarP(&exportFlags.output, "output", "o", "", "file the project is exported to (default is the standard output)")
+}
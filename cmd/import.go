@@ -0,0 +1,90 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v2"
+)
+
+var importFlags struct {
+	projectPath string
+	file        string
+	output      string
+}
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Convert a serverless.yml of the Serverless Framework to a manifest",
+	Long: `Import converts a serverless.yml of the serverless-openwhisk plugin of the
+Serverless Framework to a manifest. Functions become actions of the package
+their name starts with, e.g. hello/greeting, or of the package named after the
+service, and sequences, http, trigger and schedule events become sequences,
+APIs, triggers and rules. The files of the handlers are looked up in the
+directory of the serverless.yml, the manifest is to be written next to it.
+What has no equivalent in the manifest is reported and left out. See
+"wskdeploy export --format serverless" for the other way around.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectPath, _ := filepath.Abs(importFlags.projectPath)
+		filePath := findProjectFile(importFlags.file, projectPath, parsers.SERVERLESS_FILE_NAME)
+		if len(filePath) == 0 {
+			filePath = filepath.Join(projectPath, parsers.SERVERLESS_FILE_NAME)
+			return wskderrors.NewFileReadError(filePath, wski18n.T(wski18n.ID_ERR_SERVERLESS_NOT_FOUND_X_path_X,
+				map[string]interface{}{wski18n.KEY_PATH: filePath}))
+		}
+		serverless, err := parsers.ReadServerless(filePath)
+		if err != nil {
+			return err
+		}
+		manifest, warnings := parsers.ServerlessToManifest(serverless, filepath.Dir(filePath))
+		for _, warning := range warnings {
+			wskprint.PrintlnOpenWhiskWarning(warning)
+		}
+		content, err := yaml.Marshal(manifest)
+		if err != nil {
+			return wskderrors.NewYAMLParserErr(filePath, err)
+		}
+
+		if len(importFlags.output) == 0 {
+			wskprint.PrintlnOpenWhiskOutput(string(content))
+			return nil
+		}
+		if err := ioutil.WriteFile(importFlags.output, content, 0644); err != nil {
+			return wskderrors.NewFileReadError(importFlags.output, err.Error())
+		}
+		wskprint.PrintlnOpenWhiskSuccess(wski18n.T(wski18n.ID_MSG_IMPORT_WRITTEN_X_path_X_source_X,
+			map[string]interface{}{wski18n.KEY_PATH: importFlags.output, wski18n.KEY_SOURCE: filePath}))
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(importCmd)
+
+	importCmd.Flags().StringVarP(&importFlags.projectPath, "project", "p", ".", "path to the directory of the serverless.yml")
+	importCmd.Flags().StringVarP(&importFlags.file, "file", "f", "", "path to the serverless.yml (default is the serverless.yml of the project)")
+	importCmd.Flags().StringVarP(&importFlags.output, "output", "o", "", "file the manifest is written to (default is the standard output)")
+}
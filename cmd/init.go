@@ -56,16 +56,21 @@ var initCmd = &cobra.Command{
 
 func askName(reader *bufio.Reader, def string) string {
 	if len(def) == 0 {
-        path := strings.TrimSpace(utils.Flags.ProjectPath)
-        if (len(path) == 0) {
-            path = utils.DEFAULT_PROJECT_PATH
-        }
-		abspath, _ := filepath.Abs(path)
-		def = filepath.Base(abspath)
+		def = defaultProjectName()
 	}
 	return utils.Ask(reader, "Name", def)
 }
 
+// defaultProjectName returns the name of the project directory
+func defaultProjectName() string {
+    path := strings.TrimSpace(utils.Flags.ProjectPath)
+    if (len(path) == 0) {
+        path = utils.DEFAULT_PROJECT_PATH
+    }
+	abspath, _ := filepath.Abs(path)
+	return filepath.Base(abspath)
+}
+
 func askVersion(reader *bufio.Reader, def string) string {
 	if len(def) == 0 {
 		def = "0.0.1"
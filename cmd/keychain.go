@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskdeploy"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+	"github.com/spf13/cobra"
+)
+
+// keychainCmd represents the keychain command
+var keychainCmd = &cobra.Command{
+	Use:   "keychain",
+	Short: "Store auth keys in the keychain of the OS rather than in .wskprops",
+	Long: `Keychain stores and removes the auth keys read from the keychain of the OS, the
+macOS Keychain, the Windows Credential Manager or the secret service of Linux
+(secret-tool of libsecret). An auth key of the form keychain:<account>, e.g.
+AUTH=keychain:default in .wskprops, WHISK_AUTH=keychain:default or
+--auth keychain:default, is replaced with the key stored for the account.`,
+}
+
+var keychainSetCmd = &cobra.Command{
+	Use:   "set [account]",
+	Short: "Store an auth key in the keychain, for the account default if not given",
+	Long: `Set reads an auth key from the standard input and stores it in the keychain
+for the account, default if not given. If the line read is empty, the auth key
+currently configured is stored, see wskdeploy config, e.g. the one of .wskprops
+before it is replaced with keychain:<account>.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		account := keychainAccountArg(args)
+		fmt.Print(wski18n.T(wski18n.ID_MSG_KEYCHAIN_PROMPT_AUTHKEY))
+		line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		secret := strings.TrimSpace(line)
+		if len(secret) == 0 {
+			values, err := wskdeploy.ResolveClientConfig()
+			if err != nil {
+				return err
+			}
+			secret = values.Credential.Value
+		}
+		if err := utils.WriteKeychain(account, secret); err != nil {
+			return err
+		}
+		wskprint.PrintlnOpenWhiskSuccess(wski18n.T(wski18n.ID_MSG_KEYCHAIN_STORED_X_account_X,
+			map[string]interface{}{wski18n.KEY_ACCOUNT: account}))
+		return nil
+	},
+}
+
+var keychainDeleteCmd = &cobra.Command{
+	Use:   "delete [account]",
+	Short: "Remove an auth key from the keychain, the one of the account default if not given",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		account := keychainAccountArg(args)
+		if err := utils.DeleteKeychain(account); err != nil {
+			return err
+		}
+		wskprint.PrintlnOpenWhiskSuccess(wski18n.T(wski18n.ID_MSG_KEYCHAIN_DELETED_X_account_X,
+			map[string]interface{}{wski18n.KEY_ACCOUNT: account}))
+		return nil
+	},
+}
+
+func keychainAccountArg(args []string) string {
+	if len(args) == 0 {
+		return utils.KEYCHAIN_DEFAULT_ACCOUNT
+	}
+	return args[0]
+}
+
+func init() {
+	RootCmd.AddCommand(keychainCmd)
+	keychainCmd.AddCommand(keychainSetCmd)
+	keychainCmd.AddCommand(keychainDeleteCmd)
+
+	keychainSetCmd.Flags().StringVarP(&utils.Flags.ProjectPath, "project", "p", ".", "path to serverless project, whose deployment and manifest files may set the auth key")
+}
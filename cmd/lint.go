@@ -0,0 +1,97 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+	"github.com/spf13/cobra"
+)
+
+var lintFlags struct {
+	rules []string
+}
+
+// lintCmd represents the lint command
+var lintCmd = &cobra.Command{
+	Use:        "lint",
+	SuggestFor: []string{"check"},
+	Short:      "Check the manifest file against a set of rules",
+	Long: `Lint reports the keys declared more than once, the inputs which the code of
+their action does not use, the actions which are neither web actions nor
+referenced by rules, sequences or APIs, the timeouts longer than the default
+and the packages and actions without a description. The severity of a rule is
+changed with --rule, e.g. --rule unused-input=error or --rule
+missing-description=off. Lint fails if any finding is an error, so that it may
+gate a build.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectPath := utils.Flags.ProjectPath
+		manifestPath := findProjectFile(utils.Flags.ManifestPath, projectPath, utils.ManifestFileNameYaml, utils.ManifestFileNameYml)
+		if len(manifestPath) == 0 {
+			return wskderrors.NewErrorManifestFileNotFound(projectPath,
+				wski18n.T(wski18n.ID_MSG_MANIFEST_FILE_NOT_FOUND_X_path_X,
+					map[string]interface{}{wski18n.KEY_PATH: projectPath}))
+		}
+		return lintManifest(manifestPath, lintFlags.rules)
+	},
+}
+
+func lintManifest(manifestPath string, rules []string) error {
+	linter := parsers.NewLinter()
+	for _, rule := range rules {
+		if err := linter.SetSeverity(rule); err != nil {
+			return err
+		}
+	}
+	findings, err := linter.Lint(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	errors := 0
+	for _, finding := range findings {
+		switch finding.Severity {
+		case parsers.LINT_SEVERITY_ERROR:
+			errors++
+			wskprint.PrintlnOpenWhiskError(finding.String())
+		case parsers.LINT_SEVERITY_WARNING:
+			wskprint.PrintlnOpenWhiskWarning(finding.String())
+		default:
+			wskprint.PrintlnOpenWhiskStatus(finding.String())
+		}
+	}
+	wskprint.PrintlnOpenWhiskOutput(wski18n.T(wski18n.ID_MSG_LINT_SUMMARY_X_path_X_count_X_errors_X,
+		map[string]interface{}{wski18n.KEY_PATH: manifestPath, wski18n.KEY_COUNT: len(findings), wski18n.KEY_ERRORS: errors}))
+
+	if errors > 0 {
+		return wskderrors.NewCommandError("lint", wski18n.T(wski18n.ID_ERR_LINT_FAILED_X_path_X_errors_X,
+			map[string]interface{}{wski18n.KEY_PATH: manifestPath, wski18n.KEY_ERRORS: errors}))
+	}
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(lintCmd)
+
+	lintCmd.Flags().StringVarP(&utils.Flags.ProjectPath, "project", "p", ".", "path to serverless project")
+	lintCmd.Flags().StringVarP(&utils.Flags.ManifestPath, "manifest", "m", "", "path to manifest file")
+	lintCmd.Flags().StringArrayVarP(&lintFlags.rules, "rule", "", []string{}, "severity of a rule, i.e. <rule>=error|warning|info|off")
+}
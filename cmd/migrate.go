@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"io/ioutil"
+	"path"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+	"github.com/spf13/cobra"
+)
+
+// suffix of the copy of a file made before it is migrated
+const MIGRATE_BACKUP_SUFFIX = ".bak"
+
+var migrateFlags struct {
+	dryRun bool
+}
+
+// migrateCmd represents the migrate command
+var migrateCmd = &cobra.Command{
+	Use:        "migrate",
+	SuggestFor: []string{"upgrade"},
+	Short:      "Rewrite the manifest and deployment files to the latest schema",
+	Long: `Migrate replaces the deprecated keys of the manifest and deployment files with
+the keys of the latest schema, e.g. a single "package" is declared under
+"packages", "location" is replaced with "function", "web-export" with "web"
+and "source" with "feed". Comments and the order of the entries are preserved,
+the original files are saved with a ` + MIGRATE_BACKUP_SUFFIX + ` extension.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectPath := utils.Flags.ProjectPath
+		manifestPath := findProjectFile(utils.Flags.ManifestPath, projectPath, utils.ManifestFileNameYaml, utils.ManifestFileNameYml)
+		if len(manifestPath) == 0 {
+			return wskderrors.NewErrorManifestFileNotFound(projectPath,
+				wski18n.T(wski18n.ID_MSG_MANIFEST_FILE_NOT_FOUND_X_path_X,
+					map[string]interface{}{wski18n.KEY_PATH: projectPath}))
+		}
+		if err := migrateFile(manifestPath, parsers.FILE_TYPE_MANIFEST); err != nil {
+			return err
+		}
+
+		deploymentPath := findProjectFile(utils.Flags.DeploymentPath, projectPath, utils.DeploymentFileNameYaml, utils.DeploymentFileNameYml)
+		if len(deploymentPath) > 0 {
+			return migrateFile(deploymentPath, parsers.FILE_TYPE_DEPLOYMENT)
+		}
+		return nil
+	},
+}
+
+// findProjectFile returns the path of the file if it was given, otherwise the
+// first of the default file names which exists in the project
+func findProjectFile(filePath string, projectPath string, names ...string) string {
+	if len(filePath) > 0 {
+		return filePath
+	}
+	for _, name := range names {
+		if candidate := path.Join(projectPath, name); utils.FileExists(candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+func migrateFile(filePath string, fileType string) error {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return wskderrors.NewFileReadError(filePath, err.Error())
+	}
+
+	migrated, keys, err := parsers.MigrateYAML(content, filePath, fileType)
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_MIGRATE_UP_TO_DATE_X_path_X,
+			map[string]interface{}{wski18n.KEY_PATH: filePath}))
+		return nil
+	}
+
+	wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_MIGRATE_FILE_X_path_X_count_X,
+		map[string]interface{}{wski18n.KEY_PATH: filePath, wski18n.KEY_COUNT: len(keys)}))
+	for _, key := range keys {
+		wskprint.PrintlnOpenWhiskOutput(key.String())
+	}
+
+	if migrateFlags.dryRun {
+		wskprint.PrintlnOpenWhiskOutput(string(migrated))
+		return nil
+	}
+
+	backupPath := filePath + MIGRATE_BACKUP_SUFFIX
+	if err := ioutil.WriteFile(backupPath, content, 0644); err != nil {
+		return wskderrors.NewFileReadError(backupPath, err.Error())
+	}
+	if err := ioutil.WriteFile(filePath, migrated, 0644); err != nil {
+		return wskderrors.NewFileReadError(filePath, err.Error())
+	}
+
+	wskprint.PrintlnOpenWhiskSuccess(wski18n.T(wski18n.ID_MSG_MIGRATE_BACKUP_X_path_X_backup_X,
+		map[string]interface{}{wski18n.KEY_PATH: filePath, wski18n.KEY_BACKUP: backupPath}))
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(migrateCmd)
+
+	migrateCmd.Flags().StringVarP(&utils.Flags.ProjectPath, "project", "p", ".", "path to serverless project")
+	migrateCmd.Flags().StringVarP(&utils.Flags.ManifestPath, "manifest", "m", "", "path to manifest file")
+	migrateCmd.Flags().StringVarP(&utils.Flags.DeploymentPath, "deployment", "d", "", "path to deployment file")
+	migrateCmd.Flags().BoolVarP(&migrateFlags.dryRun, "dry-run", "", false, "display the migrated files without writing them")
+}
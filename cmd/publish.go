@@ -18,17 +18,18 @@
 package cmd
 
 import (
-	"fmt"
-
 	"bufio"
-	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
-	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
-	"github.com/spf13/cobra"
+	"fmt"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
 	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/spf13/cobra"
 )
 
 // publishCmd represents the publish command
@@ -51,7 +52,7 @@ var publishCmd = &cobra.Command{
 		registry, ok := configs["REGISTRY"]
 		if !ok {
 			reader := bufio.NewReader(os.Stdin)
-			fmt.Print("Registry URL not found in ~./wskprops. Must be set before publishing.\n")
+			fmt.Println(wski18n.T(wski18n.ID_MSG_REGISTRY_URL_NOT_FOUND))
 			for {
 				registry = utils.Ask(reader, "Registry URL", "")
 
@@ -60,7 +61,7 @@ var publishCmd = &cobra.Command{
 					// TODO: send request to registry to check it exists.
 					break
 				}
-				fmt.Print("Malformed repository URL. Try again")
+				fmt.Print(wski18n.T(wski18n.ID_ERR_REGISTRY_URL_MALFORMED))
 
 			}
 			configs["REGISTRY"] = registry
@@ -79,7 +80,8 @@ var publishCmd = &cobra.Command{
 			paths := strings.Split(repoURL, "/")
 			l := len(paths)
 			if l < 2 {
-				fmt.Print("Fatal error: malformed repository URL in manifest file :" + repoURL)
+				fmt.Print(wski18n.T(wski18n.ID_ERR_MANIFEST_REPOSITORY_URL_MALFORMED_X_url_X,
+					map[string]interface{}{wski18n.KEY_URL: repoURL}))
 				return nil
 			}
 
@@ -98,7 +100,7 @@ var publishCmd = &cobra.Command{
             }
 
 		} else {
-			fmt.Print("Fatal error: missing repository URL in manifest file.")
+			fmt.Print(wski18n.T(wski18n.ID_ERR_MANIFEST_REPOSITORY_URL_MISSING))
 		}
         return nil
 	},
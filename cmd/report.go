@@ -21,12 +21,15 @@ import (
 	"fmt"
 	"github.com/apache/incubator-openwhisk-client-go/whisk"
 	"github.com/apache/incubator-openwhisk-wskdeploy/deployers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
 	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"path"
+	"strings"
 	"sync"
+	"time"
     "os"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
 )
@@ -36,6 +39,9 @@ var wskpropsPath string
 var client *whisk.Client
 var wg sync.WaitGroup
 
+// last deployment recorded in the history of the project, with --history
+var reportHistory *deployers.HistoryDeployment
+
 // reportCmd represents the report command
 var reportCmd = &cobra.Command{
 	Use:   "report",
@@ -43,17 +49,29 @@ var reportCmd = &cobra.Command{
 	Short: "Returns summary of what's been deployed on OpenWhisk in specific namespace",
 	Long: `Command helps user get an overall report about what's been deployed
 on OpenWhisk with specific OpenWhisk namespace. By default it will read the wsk property file
-located under current user home.`,
+located under current user home. With --history, the time each entity was last
+updated by wskdeploy, the changes between the last two deployments and the
+trends of the durations, failures and entities of the deployments are read from
+the history of the project given by --project, see wskdeploy --history.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if utils.Flags.History {
+			defer printMetrics(utils.Flags.ProjectPath)
+			defer printChangelog(utils.Flags.ProjectPath)
+			history, err := deployers.ReadHistory(deployers.GetHistoryFilePath(utils.Flags.ProjectPath))
+			if err != nil {
+				return err
+			}
+			reportHistory = history.Last()
+		}
 		if wskpropsPath != "" {
-			config, _ := deployers.NewWhiskConfig(wskpropsPath, utils.Flags.DeploymentPath, utils.Flags.ManifestPath, false)
+			config, _ := deployers.NewWhiskConfig(wskpropsPath, utils.Flags.DeploymentPath, utils.Flags.ManifestPath, false, &utils.Flags)
             client, _ := deployers.CreateNewClient(config)
             return printDeploymentInfo(client)
 		} else {
             //default to ~/.wskprops
             userHome := utils.GetHomeDirectory()
             propPath := path.Join(userHome, ".wskprops")
-            config, _ := deployers.NewWhiskConfig(propPath, utils.Flags.DeploymentPath, utils.Flags.ManifestPath, false)
+            config, _ := deployers.NewWhiskConfig(propPath, utils.Flags.DeploymentPath, utils.Flags.ManifestPath, false, &utils.Flags)
             client, _ := deployers.CreateNewClient(config)
             return printDeploymentInfo(client)
         }
@@ -63,6 +81,8 @@ located under current user home.`,
 func init() {
 	RootCmd.AddCommand(reportCmd)
 	reportCmd.Flags().StringVarP(&wskpropsPath, "wskproppath", "w", path.Join(os.Getenv("HOME"), ".wskprops"), "path to wsk property file, default is to ~/.wskprops")
+	reportCmd.Flags().BoolVarP(&utils.Flags.History, "history", "", false, "print when the entities were last updated and the changes between the last two deployments and the trends of the deployments recorded in the history of the project")
+	reportCmd.Flags().StringVarP(&utils.Flags.ProjectPath, "project", "p", ".", "path to the serverless project whose history is read")
 
 	// Here you will define your flags and configuration settings.
 
@@ -82,8 +102,7 @@ func printDeploymentInfo(client *whisk.Client) error {
 	//We currently list packages, actions, triggers, rules.
 	wg.Add(4)
 
-	// TODO() i18n
-	wskprint.PrintlnOpenWhiskStatus("----==== OpenWhisk Deployment Status ====----")
+	wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_DEPLOYMENT_STATUS_HEADER))
 	// we set the default package list options
 	pkgoptions := &whisk.PackageListOptions{false, 0, 0, 0, false}
 	packages, _, err := client.Packages.List(pkgoptions)
@@ -91,6 +110,18 @@ func printDeploymentInfo(client *whisk.Client) error {
         return err
     }
 
+	// packages left out with --packages or --exclude-package
+	if _, err := utils.Flags.ValidatePackagePatterns(); err != nil {
+		return err
+	}
+	selected := make([]whisk.Package, 0, len(packages))
+	for _, pkg := range packages {
+		if utils.Flags.IsPackageSelected(pkg.Name) {
+			selected = append(selected, pkg)
+		}
+	}
+	packages = selected
+
 	// list all packages under current namespace.
 	go func() {
 		defer wg.Done()
@@ -166,7 +197,7 @@ func printRuleList(rules []whisk.Rule) {
 		if *rule.Publish {
 			publishState = wski18n.T("shared")
 		}
-		fmt.Printf("%-70s %s\n", fmt.Sprintf("/%s/%s", rule.Namespace, rule.Name), publishState)
+		fmt.Printf("%-70s %s%s%s%s%s\n", fmt.Sprintf("/%s/%s", rule.Namespace, rule.Name), publishState, versionString(rule.Version), updatedString(parsers.YAML_KEY_RULE, rule.Name), ownerString(rule.Annotations), revisionString(rule.Annotations))
 	}
 }
 
@@ -177,7 +208,10 @@ func printPackageList(packages []whisk.Package) {
 		if *xPackage.Publish {
 			publishState = wski18n.T("shared")
 		}
-		fmt.Printf("%-70s %s\n", fmt.Sprintf("/%s/%s", xPackage.Namespace, xPackage.Name), publishState)
+		fmt.Printf("%-70s %s%s%s%s%s\n", fmt.Sprintf("/%s/%s", xPackage.Namespace, xPackage.Name), publishState, versionString(xPackage.Version), updatedString(parsers.YAML_KEY_PACKAGE, xPackage.Name), ownerString(xPackage.Annotations), revisionString(xPackage.Annotations))
+		if provenance := utils.GetManagedProvenance(xPackage.Annotations).String(); len(provenance) > 0 {
+			fmt.Printf("    %s\n", provenance)
+		}
 	}
 }
 
@@ -189,7 +223,126 @@ func printActionList(actions []whisk.Action) {
 			publishState = wski18n.T("shared")
 		}
 		kind := getValueString(action.Annotations, "exec")
-		fmt.Printf("%-70s %s %s\n", fmt.Sprintf("/%s/%s", action.Namespace, action.Name), publishState, kind)
+		// actions of packages are listed with the namespace "<namespace>/<package>"
+		name := action.Name
+		if parts := strings.SplitN(action.Namespace, "/", 2); len(parts) == 2 {
+			name = parts[1] + "/" + action.Name
+		}
+		fmt.Printf("%-70s %s %s%s%s%s%s\n", fmt.Sprintf("/%s/%s", action.Namespace, action.Name), publishState, kind, versionString(action.Version), updatedString(parsers.YAML_KEY_ACTION, name), ownerString(action.Annotations), revisionString(action.Annotations))
+	}
+}
+
+// ownerString returns the owner and contact of an entity deployed with
+// --managed, e.g. " jdoe <jdoe@example.com>", or an empty string
+func ownerString(annotations whisk.KeyValueArr) string {
+	owner, contact := utils.GetManagedOwner(annotations)
+	if len(contact) > 0 {
+		contact = "<" + contact + ">"
+	}
+	ownership := strings.TrimSpace(owner + " " + contact)
+	if len(ownership) == 0 {
+		return ""
+	}
+	return " " + ownership
+}
+
+// revisionString returns the version and git revision of the project of an
+// entity deployed with --managed, e.g. " [1.2.0 3fabe37 (main)]", or an empty
+// string
+func revisionString(annotations whisk.KeyValueArr) string {
+	revision := utils.GetManagedRevision(annotations).String()
+	if len(revision) == 0 {
+		return ""
+	}
+	return " [" + revision + "]"
+}
+
+// versionString returns the version the server assigned to an entity, e.g.
+// " v0.0.3", which is incremented each time the entity is updated
+func versionString(version string) string {
+	if len(version) == 0 {
+		return ""
+	}
+	return " v" + version
+}
+
+// updatedString returns the time the entity was last updated according to
+// the history of the project, or an empty string
+func updatedString(entity string, name string) string {
+	if reportHistory == nil {
+		return ""
+	}
+	e, ok := reportHistory.Entities[entity][name]
+	if !ok && entity == parsers.YAML_KEY_ACTION {
+		e, ok = reportHistory.Entities[parsers.YAML_KEY_SEQUENCE][name]
+	}
+	if !ok {
+		return ""
+	}
+	return " " + e.Updated.Format(time.RFC3339)
+}
+
+// printChangelog prints the entities added, changed and removed between the
+// last two deployments recorded in the history of the project
+func printChangelog(projectPath string) {
+	historyPath := deployers.GetHistoryFilePath(projectPath)
+	history, err := deployers.ReadHistory(historyPath)
+	if err != nil {
+		wskprint.PrintlnOpenWhiskWarning(err.Error())
+		return
+	}
+	last := history.Last()
+	if last == nil {
+		wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_MSG_HISTORY_NOT_FOUND_X_path_X,
+			map[string]interface{}{wski18n.KEY_PATH: historyPath}))
+		return
+	}
+	previous := "-"
+	if count := len(history.Deployments); count > 1 {
+		previous = history.Deployments[count-2].Time.Format(time.RFC3339)
+	}
+	fmt.Fprintf(color.Output, "%s\n", boldString("changelog"))
+	fmt.Println(wski18n.T(wski18n.ID_MSG_HISTORY_CHANGELOG_X_old_X_new_X,
+		map[string]interface{}{wski18n.KEY_OLD: previous, wski18n.KEY_NEW: last.Time.Format(time.RFC3339)}))
+	changes := history.Changelog()
+	if len(changes) == 0 {
+		fmt.Println(wski18n.T(wski18n.ID_MSG_HISTORY_NO_CHANGES))
+	}
+	for _, change := range changes {
+		fmt.Println(change.String())
+	}
+}
+
+// printMetrics prints the trends of the deployments recorded in the metrics of
+// the project: their failures, durations and entities and the slowest entities
+func printMetrics(projectPath string) {
+	metricsPath := deployers.GetMetricsFilePath(projectPath)
+	metrics, err := deployers.ReadMetrics(metricsPath)
+	if err != nil {
+		wskprint.PrintlnOpenWhiskWarning(err.Error())
+		return
+	}
+	summary := metrics.Summarize(5)
+	if summary == nil {
+		wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_MSG_METRICS_NOT_FOUND_X_path_X,
+			map[string]interface{}{wski18n.KEY_PATH: metricsPath}))
+		return
+	}
+	fmt.Fprintf(color.Output, "%s\n", boldString("metrics"))
+	fmt.Println(wski18n.T(wski18n.ID_MSG_METRICS_DEPLOYMENTS_X_count_X_failures_X_rate_X,
+		map[string]interface{}{wski18n.KEY_COUNT: summary.Deployments, wski18n.KEY_FAILURES: summary.Failures,
+			wski18n.KEY_RATE: summary.FailureRate()}))
+	fmt.Println(wski18n.T(wski18n.ID_MSG_METRICS_DURATIONS_X_last_X_average_X_min_X_max_X,
+		map[string]interface{}{wski18n.KEY_LAST: summary.Last.String(), wski18n.KEY_AVERAGE: summary.Average.String(),
+			wski18n.KEY_MIN: summary.Min.String(), wski18n.KEY_MAX: summary.Max.String()}))
+	fmt.Println(wski18n.T(wski18n.ID_MSG_METRICS_ENTITIES_X_old_X_new_X,
+		map[string]interface{}{wski18n.KEY_OLD: summary.FirstEntities, wski18n.KEY_NEW: summary.LastEntities}))
+	if len(summary.Slowest) == 0 {
+		return
+	}
+	fmt.Println(wski18n.T(wski18n.ID_MSG_METRICS_SLOWEST))
+	for _, entity := range summary.Slowest {
+		fmt.Printf("  %s %s %s\n", entity.Entity, entity.Name, time.Duration(entity.Duration)*time.Millisecond)
 	}
 }
 
@@ -18,6 +18,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -28,16 +29,13 @@ import (
 	"github.com/spf13/cobra"
 	"os"
 	"path"
-	"path/filepath"
 	"regexp"
 	"strings"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskdeploy"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
 )
 
-var stderr = ""
-var stdout = ""
-
 // TODO(#683) short and long desc. should be translated for i18n
 var RootCmd = &cobra.Command{
 	Use:           "wskdeploy",
@@ -70,16 +68,39 @@ func Execute() {
 	}
 
 	if err := RootCmd.Execute(); err != nil {
-		wskprint.PrintOpenWhiskFromError(err)
-		os.Exit(-1)
+		printError(err)
+		os.Exit(wskderrors.ExitCode(err))
 	} else {
 		if utils.Flags.WithinOpenWhisk {
-			// TODO() i18n
-			fmt.Print(`{"deploy":"success"}`) // maybe return report of what has been deployed.
+			// maybe return report of what has been deployed.
+			content, _ := json.Marshal(map[string]interface{}{"deploy": "success"})
+			fmt.Print(string(content))
 		}
 	}
 }
 
+// values of --error-format
+const (
+	ERROR_FORMAT_TEXT = "text"
+	ERROR_FORMAT_JSON = "json"
+)
+
+// printError prints the error, along with its code and category in JSON with
+// --error-format json or when wskdeploy runs as an action, see
+// wskderrors.ErrorReport
+func printError(err error) {
+	if utils.Flags.ErrorFormat != ERROR_FORMAT_JSON && !utils.Flags.WithinOpenWhisk {
+		wskprint.PrintOpenWhiskFromError(err)
+		return
+	}
+	output := map[string]interface{}{"error": wskderrors.NewErrorReport(err)}
+	if utils.Flags.WithinOpenWhisk {
+		output["deploy"] = "failure"
+	}
+	content, _ := json.Marshal(output)
+	fmt.Println(string(content))
+}
+
 // This function is only used when wskdeploy is being called as an Action and its input
 // (i.e., command and arguments) is JSON data (map).
 func substCmdArgs() error {
@@ -89,7 +110,6 @@ func substCmdArgs() error {
 
 	arg := os.Args[1]
 
-	fmt.Println("arg is " + arg)
 	// unmarshal the string to a JSON object
 	var obj map[string]interface{}
 	json.Unmarshal([]byte(arg), &obj)
@@ -117,9 +137,9 @@ func init() {
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
 	RootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
-	RootCmd.Flags().StringVarP(&utils.Flags.ProjectPath, "project", "p", ".", "path to serverless project")
-	RootCmd.Flags().StringVarP(&utils.Flags.ManifestPath, "manifest", "m", "", "path to manifest file")
-	RootCmd.Flags().StringVarP(&utils.Flags.DeploymentPath, "deployment", "d", "", "path to deployment file")
+	RootCmd.Flags().StringVarP(&utils.Flags.ProjectPath, "project", "p", ".", "path to serverless project, or git+https URL of a repository (git+https://host/repo#ref/path) or URL of a zip file")
+	RootCmd.Flags().StringVarP(&utils.Flags.ManifestPath, "manifest", "m", "", "path or URL to manifest file, see --project for the URLs of repositories and zip files")
+	RootCmd.Flags().StringVarP(&utils.Flags.DeploymentPath, "deployment", "d", "", "path or URL to deployment file, see --project for the URLs of repositories and zip files")
 	RootCmd.PersistentFlags().BoolVarP(&utils.Flags.Strict, "strict", "s", false, "allow user defined runtime version")
 	RootCmd.PersistentFlags().BoolVarP(&utils.Flags.UseInteractive, "allow-interactive", "i", false, "allow interactive prompts")
 	RootCmd.PersistentFlags().BoolVarP(&utils.Flags.UseDefaults, "allow-defaults", "a", false, "allow defaults")
@@ -130,7 +150,53 @@ func init() {
 	RootCmd.PersistentFlags().StringVar(&utils.Flags.ApiVersion, "apiversion", "", wski18n.T(wski18n.ID_CMD_FLAG_API_VERSION))
 	RootCmd.PersistentFlags().StringVarP(&utils.Flags.Key, "key", "k", "", wski18n.T(wski18n.ID_CMD_FLAG_KEY_FILE))
 	RootCmd.PersistentFlags().StringVarP(&utils.Flags.Cert, "cert", "c", "", wski18n.T(wski18n.ID_CMD_FLAG_CERT_FILE))
+	RootCmd.PersistentFlags().StringVarP(&utils.Flags.Profile, "profile", "", "", "profile of ~/"+deployers.PROFILES_FILE_NAME+" to read the API host, auth key and namespace from instead of .wskprops")
+	RootCmd.PersistentFlags().StringVarP(&utils.Flags.ApigwAccessToken, "apigw-access-token", "", "", "API gateway access token, when the API gateway is authenticated separately from the API host")
+	RootCmd.PersistentFlags().StringVarP(&utils.Flags.ApigwHost, "apigw-host", "", "", "API gateway host, if the APIs are not created through the API host")
+	RootCmd.PersistentFlags().StringVarP(&utils.Flags.IamApiKey, "iam-api-key", "", "", "IBM Cloud IAM API key, exchanged for the API gateway access token when none is set")
+	RootCmd.PersistentFlags().StringVarP(&utils.Flags.LicenseAllowList, "license-allowlist", "", "", "file or URL of the SPDX license identifiers allowed for packages, one per line, disallowed licenses fail the deployment with --strict")
+	RootCmd.PersistentFlags().StringVarP(&utils.Flags.NamingConventions, "naming-conventions", "", "", "YAML file or URL of the regular expressions the names of packages, actions, sequences, triggers and rules must match, by entity type, with \"severity: warning\" names which do not match are only reported")
+	RootCmd.PersistentFlags().StringSliceVarP(&utils.Flags.Packages, "packages", "", []string{}, "names or globs of the packages to deploy, undeploy or report, e.g. \"api-*\", all packages by default")
+	RootCmd.PersistentFlags().StringArrayVarP(&utils.Flags.ExcludePackages, "exclude-package", "", []string{}, "name or glob of a package to leave out, may be repeated")
+	RootCmd.PersistentFlags().StringVarP(&utils.Flags.ProjectName, "project-name", "", "", "name of the project to deploy or undeploy when the manifest defines several projects")
 	RootCmd.PersistentFlags().BoolVarP(&utils.Flags.Managed, "managed", "", false, "mark project entities as managed")
+	RootCmd.PersistentFlags().StringVarP(&utils.Flags.EnvFile, "env-file", "", "", "path to a .env file of variables used in the manifest and deployment files (default is the .env file of the project)")
+	RootCmd.PersistentFlags().StringVarP(&utils.Flags.Env, "env", "", "", "environment to deploy to, e.g. prod, the variables of its .env.<env> file replace the ones of the .env file")
+	RootCmd.PersistentFlags().StringVarP(&utils.Flags.SecretsFile, "secrets-file", "", "", "path to a .env file of variables like --env-file, their values are masked in the output and reports")
+	RootCmd.PersistentFlags().BoolVarP(&utils.Flags.SecretsFromEnv, "secrets-from-env", "", false, "fail when the value of an input marked as secret is written in the manifest or deployment file rather than set from a variable")
+	RootCmd.PersistentFlags().BoolVarP(&utils.Flags.OverrideTarget, "override-target", "", false, "deploy or undeploy even if the API host or namespace of the credentials does not match the expected-target of the project")
+	RootCmd.PersistentFlags().BoolVarP(&utils.Flags.SkipPreflight, "skip-preflight", "", false, "do not check that the API host is reachable and serves a supported version of the OpenWhisk API before deploying or undeploying")
+	RootCmd.PersistentFlags().StringVarP(&utils.Flags.ReportTemplate, "report-template", "", "", "file or URL of a Go text/template rendered with the deployed entities once the project is deployed or undeployed")
+	RootCmd.PersistentFlags().StringVarP(&utils.Flags.ReportOutput, "report-output", "", "", "file the --report-template is rendered to (default is the standard output)")
+	RootCmd.PersistentFlags().StringVarP(&utils.Flags.Scanner, "scanner", "", "", "command run with the zip or source file of each action before it is deployed, exit code 1 warns and other non-zero exit codes fail the deployment")
+	RootCmd.PersistentFlags().StringVarP(&utils.Flags.Provider, "provider", "", "", "distribution of OpenWhisk deployed to, i.e. openwhisk, adobe-io-runtime, nimbella or a YAML file, which adapts runtime kinds, annotations, APIs and credentials")
+	RootCmd.PersistentFlags().StringVarP(&utils.Flags.ErrorFormat, "error-format", "", ERROR_FORMAT_TEXT, "format errors are printed in, text or json, which gives their code and category along with the exit code")
+	RootCmd.PersistentFlags().StringArrayVarP(&utils.Flags.Overlays, "overlay", "", []string{}, "YAML file deep-merged over the manifest before it is parsed, e.g. --overlay prod.yaml, its keys add or override the ones of the manifest and a null value removes them, may be repeated and applied in order")
+	RootCmd.PersistentFlags().StringArrayVarP(&utils.Flags.Set, "set", "", []string{}, "value set at a path of the manifest before it is parsed, e.g. --set packages.hello.actions.world.limits.memorySize=512, may be repeated")
+	RootCmd.PersistentFlags().StringArrayVarP(&utils.Flags.Params, "param", "", []string{}, "parameter set on a package, package/action, sequence or trigger once the manifest and deployment files are bound, which takes precedence over both, e.g. --param hello/greeting.name=Bernie, may be repeated")
+	RootCmd.PersistentFlags().StringArrayVarP(&utils.Flags.Annotations, "annotation", "", []string{}, "annotation set on a package, package/action, sequence, trigger or rule once the manifest and deployment files are bound, which takes precedence over both, e.g. --annotation hello/greeting.final=true, may be repeated")
+	RootCmd.PersistentFlags().StringVarP(&utils.Flags.DeployAs, "deploy-as", "", "", "suffix of the packages and triggers to deploy next to the live ones, e.g. green deploys the package hello as hello-green, the rules and APIs are switched to them once they are all deployed, with undeploy only the entities of the suffix are removed")
+	RootCmd.PersistentFlags().StringVarP(&utils.Flags.BuildImage, "build-image", "", "", "docker image the virtualenv of Python actions with a requirements.txt is built in, e.g. openwhisk/python3action, instead of the virtualenv and pip installed locally")
+	RootCmd.PersistentFlags().IntVarP(&utils.Flags.ParallelFetches, "parallel-fetches", "", utils.DEFAULT_PARALLEL_FETCHES, "number of dependencies fetched concurrently")
+	RootCmd.PersistentFlags().Float64VarP(&utils.Flags.RateLimit, "rate-limit", "", 0, "requests per second sent to the OpenWhisk server, shared by the actions deployed in parallel and the dependencies, no limit by default")
+	RootCmd.PersistentFlags().IntVarP(&utils.Flags.Burst, "burst", "", 0, "requests sent at once within --rate-limit after a pause (default is the rate rounded up)")
+	RootCmd.PersistentFlags().IntVarP(&utils.Flags.MaxConcurrentRequests, "max-concurrent-requests", "", 0, "requests in flight per namespace, no limit by default, the requests in flight are halved when the server throttles the namespace")
+	RootCmd.PersistentFlags().Int64VarP(&utils.Flags.MaxCodeSize, "max-code-size", "", utils.DEFAULT_MAX_ACTION_CODE_SIZE, "largest code of an action, in bytes once zip and jar files are base64 encoded, the default is the limit of OpenWhisk")
+	RootCmd.Flags().BoolVarP(&utils.Flags.Preview, "preview", "", false, "write the entities of the project as YAML, with their final parameters, annotations and code sizes, instead of deploying them, no credentials are needed")
+	RootCmd.Flags().BoolVarP(&utils.Flags.AllowDepSideEffects, "allow-dep-side-effects", "", false, "allow the projects of dependencies to deploy triggers, rules, APIs, plugins and hooks, which are refused by default")
+	RootCmd.Flags().StringVarP(&utils.Flags.OutputsFile, "outputs-file", "", "", "JSON file the names of the deployed entities, the URLs of web actions and APIs and the generated annotations are written to once the project is deployed")
+	RootCmd.Flags().StringVarP(&utils.Flags.ResultsFile, "results-file", "", "", "JUnit XML file the outcome of the deployment is written to, with a test case per entity, for CI pipelines to report the entities which failed to deploy")
+	RootCmd.Flags().BoolVarP(&utils.Flags.History, "history", "", false, "record the entities deployed in the .wskdeploy.history file of the project and the metrics of the deployments in .wskdeploy/metrics.json, see wskdeploy report --history")
+	RootCmd.Flags().BoolVarP(&utils.Flags.ImmutableVersions, "immutable-versions", "", false, "fail when the version of a package is already deployed with another content, so that each change of a package requires a new version in the manifest")
+	RootCmd.Flags().BoolVarP(&utils.Flags.ReuseDependencies, "reuse-dependencies", "", false, "neither fetch nor deploy the dependencies already deployed from the same location and version, dependencies on the master branch are always deployed")
+	RootCmd.Flags().StringVarP(&utils.Flags.EncryptionProvider, "encryption-provider", "", "", "provider the inputs declared \"encrypted: true\" are encrypted by before they are deployed, aes-gcm with the base64 key of WSKDEPLOY_ENCRYPTION_KEY, or command:<command> which encrypts its standard input, e.g. a KMS client")
+	RootCmd.Flags().BoolVarP(&utils.Flags.AllowEmpty, "allow-empty", "", false, "deploy a manifest without packages, i.e. nothing, or packages without actions, sequences, triggers, rules, APIs or dependencies, which are refused by default since they are often entities indented at the wrong level")
+	RootCmd.Flags().IntVarP(&utils.Flags.Parallel, "parallel", "", 1, "number of actions deployed concurrently, the output of each action is printed once it is deployed")
+	RootCmd.Flags().DurationVarP(&utils.Flags.EntityTimeout, "entity-timeout", "", 0, "time allowed to deploy an entity, e.g. 2m, an entity which is not deployed in time fails")
+	RootCmd.Flags().BoolVarP(&utils.Flags.ContinueOnError, "continue-on-error", "", false, "deploy the other entities when an entity fails, the failed entities are reported at the end")
+	RootCmd.Flags().BoolVarP(&utils.Flags.Resume, "resume", "", false, "resume a failed deployment, skipping entities which were already deployed")
+	RootCmd.Flags().BoolVarP(&utils.Flags.Rollback, "rollback", "", false, "switch the rules and APIs back to the entities of the previous --deploy-as, or of the suffix given with --deploy-as, without deploying anything else")
+	RootCmd.Flags().BoolVarP(&utils.Flags.Tail, "tail", "", false, "once the project is deployed, print the logs and results of the activations of its actions and sequences as they complete, with --managed of all the actions of the project, until interrupted")
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -143,8 +209,8 @@ func initConfig() {
 		_, err := whisk.ReadProps(utils.Flags.CfgFile)
 		if err != nil {
 			utils.Flags.CfgFile = defaultPath
-			// TODO() i18n
-			wskprint.PrintOpenWhiskWarning("Invalid config file detected, so by default it is set to " + utils.Flags.CfgFile + "\n")
+			wskprint.PrintOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_CONFIG_FILE_INVALID_X_path_X,
+				map[string]interface{}{wski18n.KEY_PATH: utils.Flags.CfgFile}) + "\n")
 		}
 
 	} else {
@@ -152,186 +218,12 @@ func initConfig() {
 	}
 }
 
-func setSupportedRuntimes(apiHost string) {
-	op, error := utils.ParseOpenWhisk(apiHost)
-	if error == nil {
-		utils.SupportedRunTimes = utils.ConvertToMap(op)
-		utils.DefaultRunTimes = utils.DefaultRuntimes(op)
-		utils.FileExtensionRuntimeKindMap = utils.FileExtensionRuntimes(op)
-	}
-}
-
 func Deploy() error {
-
-	whisk.SetVerbose(utils.Flags.Verbose)
-	// Verbose mode is the only mode for wskdeploy to turn on all the debug messages,
-	// so set Verbose mode (and also debug mode) to true.
-	whisk.SetDebug(utils.Flags.Verbose)
-
-	project_Path := strings.TrimSpace(utils.Flags.ProjectPath)
-	if len(project_Path) == 0 {
-		project_Path = utils.DEFAULT_PROJECT_PATH
-	}
-	projectPath, _ := filepath.Abs(project_Path)
-
-	if utils.Flags.ManifestPath == "" {
-		if _, err := os.Stat(path.Join(projectPath, utils.ManifestFileNameYaml)); err == nil {
-			utils.Flags.ManifestPath = path.Join(projectPath, utils.ManifestFileNameYaml)
-			stdout = wski18n.T(wski18n.ID_MSG_MANIFEST_DEPLOY_X_path_X,
-				map[string]interface{}{"path": utils.Flags.ManifestPath})
-		} else if _, err := os.Stat(path.Join(projectPath, utils.ManifestFileNameYml)); err == nil {
-			utils.Flags.ManifestPath = path.Join(projectPath, utils.ManifestFileNameYml)
-			stdout = wski18n.T(wski18n.ID_MSG_MANIFEST_DEPLOY_X_path_X,
-				map[string]interface{}{"path": utils.Flags.ManifestPath})
-		} else {
-			stderr = wski18n.T(wski18n.ID_MSG_MANIFEST_FILE_NOT_FOUND_X_path_X,
-				map[string]interface{}{"path": projectPath})
-			return wskderrors.NewErrorManifestFileNotFound(projectPath, stderr)
-		}
-		whisk.Debug(whisk.DbgInfo, stdout)
-	}
-
-	if utils.Flags.DeploymentPath == "" {
-		if _, err := os.Stat(path.Join(projectPath, utils.DeploymentFileNameYaml)); err == nil {
-			utils.Flags.DeploymentPath = path.Join(projectPath, utils.DeploymentFileNameYaml)
-		} else if _, err := os.Stat(path.Join(projectPath, utils.DeploymentFileNameYml)); err == nil {
-			utils.Flags.DeploymentPath = path.Join(projectPath, utils.DeploymentFileNameYml)
-		}
-	}
-
-	if utils.MayExists(utils.Flags.ManifestPath) {
-
-		var deployer = deployers.NewServiceDeployer()
-		deployer.ProjectPath = projectPath
-		deployer.ManifestPath = utils.Flags.ManifestPath
-		deployer.DeploymentPath = utils.Flags.DeploymentPath
-		deployer.IsDefault = utils.Flags.UseDefaults
-
-		deployer.IsInteractive = utils.Flags.UseInteractive
-
-		// master record of any dependency that has been downloaded
-		deployer.DependencyMaster = make(map[string]utils.DependencyRecord)
-
-		clientConfig, error := deployers.NewWhiskConfig(utils.Flags.CfgFile, utils.Flags.DeploymentPath, utils.Flags.ManifestPath, deployer.IsInteractive)
-		if error != nil {
-			return error
-		}
-
-		whiskClient, error := deployers.CreateNewClient(clientConfig)
-		if error != nil {
-			return error
-		}
-
-		deployer.Client = whiskClient
-		deployer.ClientConfig = clientConfig
-
-		// The auth, apihost and namespace have been chosen, so that we can check the supported runtimes here.
-		setSupportedRuntimes(clientConfig.Host)
-
-		err := deployer.ConstructDeploymentPlan()
-
-		if err != nil {
-			return err
-		}
-
-		err = deployer.Deploy()
-
-		if err != nil {
-			return err
-		} else {
-			return nil
-		}
-
-	} else {
-		errString := wski18n.T(wski18n.ID_MSG_MANIFEST_FILE_NOT_FOUND_X_path_X,
-			map[string]interface{}{"path": utils.Flags.ManifestPath})
-		whisk.Debug(whisk.DbgError, errString)
-		return wskderrors.NewErrorManifestFileNotFound(utils.Flags.ManifestPath, errString)
-	}
-
+	_, err := wskdeploy.Deploy(context.Background())
+	return err
 }
 
 func Undeploy() error {
-
-	whisk.SetVerbose(utils.Flags.Verbose)
-	// Verbose mode is the only mode for wskdeploy to turn on all the debug messages, so the currenty Verbose mode
-	// also set debug mode to true.
-	whisk.SetDebug(utils.Flags.Verbose)
-
-	project_Path := strings.TrimSpace(utils.Flags.ProjectPath)
-	if len(project_Path) == 0 {
-		project_Path = utils.DEFAULT_PROJECT_PATH
-	}
-	projectPath, _ := filepath.Abs(project_Path)
-
-	if utils.Flags.ManifestPath == "" {
-		if _, err := os.Stat(path.Join(projectPath, utils.ManifestFileNameYaml)); err == nil {
-			utils.Flags.ManifestPath = path.Join(projectPath, utils.ManifestFileNameYaml)
-			stdout = wski18n.T(wski18n.ID_MSG_MANIFEST_UNDEPLOY_X_path_X,
-				map[string]interface{}{"path": utils.Flags.ManifestPath})
-		} else if _, err := os.Stat(path.Join(projectPath, utils.ManifestFileNameYml)); err == nil {
-			utils.Flags.ManifestPath = path.Join(projectPath, utils.ManifestFileNameYml)
-			stdout = wski18n.T(wski18n.ID_MSG_MANIFEST_UNDEPLOY_X_path_X,
-				map[string]interface{}{"path": utils.Flags.ManifestPath})
-		} else {
-			stderr = wski18n.T(wski18n.ID_MSG_MANIFEST_FILE_NOT_FOUND_X_path_X,
-				map[string]interface{}{"path": projectPath})
-			return wskderrors.NewErrorManifestFileNotFound(projectPath, stderr)
-		}
-		whisk.Debug(whisk.DbgInfo, stdout)
-	}
-
-	if utils.Flags.DeploymentPath == "" {
-		if _, err := os.Stat(path.Join(projectPath, utils.DeploymentFileNameYaml)); err == nil {
-			utils.Flags.DeploymentPath = path.Join(projectPath, utils.DeploymentFileNameYaml)
-			fmt.Printf("Using %s for undeployment \n", utils.Flags.DeploymentPath)
-		} else if _, err := os.Stat(path.Join(projectPath, utils.DeploymentFileNameYml)); err == nil {
-			utils.Flags.DeploymentPath = path.Join(projectPath, utils.DeploymentFileNameYml)
-			fmt.Printf("Using %s for undeployment \n", utils.Flags.DeploymentPath)
-		}
-	}
-
-	if utils.FileExists(utils.Flags.ManifestPath) {
-
-		var deployer = deployers.NewServiceDeployer()
-		deployer.ProjectPath = utils.Flags.ProjectPath
-		deployer.ManifestPath = utils.Flags.ManifestPath
-		deployer.DeploymentPath = utils.Flags.DeploymentPath
-
-		deployer.IsInteractive = utils.Flags.UseInteractive
-		deployer.IsDefault = utils.Flags.UseDefaults
-
-		clientConfig, error := deployers.NewWhiskConfig(utils.Flags.CfgFile, utils.Flags.DeploymentPath, utils.Flags.ManifestPath, deployer.IsInteractive)
-		if error != nil {
-			return error
-		}
-
-		whiskClient, error := deployers.CreateNewClient(clientConfig)
-		if error != nil {
-			return error
-		}
-
-		deployer.Client = whiskClient
-		deployer.ClientConfig = clientConfig
-
-		// The auth, apihost and namespace have been chosen, so that we can check the supported runtimes here.
-		setSupportedRuntimes(clientConfig.Host)
-
-		verifiedPlan, err := deployer.ConstructUnDeploymentPlan()
-		if err != nil {
-			return err
-		}
-
-		err = deployer.UnDeploy(verifiedPlan)
-		if err != nil {
-			return err
-		} else {
-			return nil
-		}
-
-	} else {
-		errString := wski18n.T(wski18n.ID_MSG_MANIFEST_FILE_NOT_FOUND_X_path_X,
-			map[string]interface{}{"path": utils.Flags.ManifestPath})
-		return wskderrors.NewErrorManifestFileNotFound(utils.Flags.ManifestPath, errString)
-	}
+	_, err := wskdeploy.Undeploy(context.Background())
+	return err
 }
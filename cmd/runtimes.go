@@ -0,0 +1,161 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/deployers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/spf13/cobra"
+)
+
+var runtimesFlags struct {
+	kind string
+}
+
+// runtimesCmd represents the runtimes command
+var runtimesCmd = &cobra.Command{
+	Use:        "runtimes",
+	SuggestFor: []string{"kinds", "languages"},
+	Short:      "List the runtimes supported by OpenWhisk",
+	Long: `Runtimes lists the kinds supported by the OpenWhisk deployment at the API host,
+i.e. the values accepted by the "runtime" of an action, along with the default
+kind of each language, the deprecated kinds and the kind wskdeploy uses for an
+action without "runtime", according to the extension of its function.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiHost := getApiHost()
+		op, err := utils.ParseOpenWhisk(apiHost)
+		if err != nil {
+			return err
+		}
+		if len(apiHost) == 0 {
+			fmt.Println(wski18n.T(wski18n.ID_MSG_RUNTIMES_BUILTIN))
+		} else {
+			fmt.Println(wski18n.T(wski18n.ID_MSG_RUNTIMES_X_host_X,
+				map[string]interface{}{wski18n.KEY_HOST: apiHost}))
+		}
+		return printRuntimes(os.Stdout, op, runtimesFlags.kind)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(runtimesCmd)
+	runtimesCmd.Flags().StringVarP(&runtimesFlags.kind, "kind", "", "", "only list the runtimes of a language (e.g. nodejs) or a kind (e.g. nodejs:8)")
+}
+
+// getApiHost returns the API host of the command line or of the wsk property
+// file, the runtimes can be listed without credentials
+func getApiHost() string {
+	if len(utils.Flags.ApiHost) > 0 {
+		return utils.Flags.ApiHost
+	}
+	if len(utils.Flags.Profile) > 0 {
+		if profile, err := deployers.GetProfile(utils.Flags.Profile); err == nil {
+			return profile.APIHost
+		}
+		return ""
+	}
+	pi := whisk.PropertiesImp{
+		OsPackage: whisk.OSPackageImp{},
+	}
+	if wskprops, err := deployers.GetWskPropFromWskprops(pi, utils.Flags.CfgFile); err == nil {
+		return wskprops.APIHost
+	}
+	return ""
+}
+
+// printRuntimes writes the kinds of every language (or of the language or kind
+// given) followed by the file extensions which select a language
+func printRuntimes(w io.Writer, op utils.OpenWhiskInfo, kind string) error {
+	languages := make([]string, 0, len(op.Runtimes))
+	for language, runtimes := range op.Runtimes {
+		if len(kind) == 0 || kind == language {
+			languages = append(languages, language)
+			continue
+		}
+		for _, runtime := range runtimes {
+			if runtime.Kind == kind {
+				languages = append(languages, language)
+				break
+			}
+		}
+	}
+	if len(languages) == 0 {
+		return wskderrors.NewCommandError("runtimes",
+			wski18n.T(wski18n.ID_ERR_RUNTIME_KIND_UNKNOWN_X_runtime_X_runtimes_X,
+				map[string]interface{}{
+					wski18n.KEY_RUNTIME:  kind,
+					wski18n.KEY_RUNTIMES: strings.Join(utils.ListOfSupportedRuntimes(utils.ConvertToMap(op)), ", ")}))
+	}
+	sort.Strings(languages)
+
+	defaults := utils.DefaultRuntimes(op)
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, wski18n.T(wski18n.ID_MSG_RUNTIMES_HEADER))
+	for _, language := range languages {
+		for _, runtime := range op.Runtimes[language] {
+			if len(kind) > 0 && kind != language && kind != runtime.Kind {
+				continue
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", language, runtime.Kind,
+				yesNo(runtime.Default && !runtime.Deprecated), yesNo(runtime.Deprecated), runtime.Image)
+		}
+	}
+
+	extensions := utils.FileExtensionRuntimes(op)
+	names := make([]string, 0, len(extensions))
+	for ext, language := range extensions {
+		if containsString(languages, language) {
+			names = append(names, ext)
+		}
+	}
+	sort.Strings(names)
+	if len(names) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, wski18n.T(wski18n.ID_MSG_RUNTIMES_EXTENSIONS_HEADER))
+		for _, ext := range names {
+			fmt.Fprintf(tw, ".%s\t%s\t%s\n", ext, extensions[ext], defaults[extensions[ext]])
+		}
+	}
+	return tw.Flush()
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintRuntimes(t *testing.T) {
+	op := utils.OpenWhiskInfo{}
+	assert.Nil(t, json.Unmarshal(utils.RUNTIME_DETAILS, &op))
+
+	var out bytes.Buffer
+	assert.Nil(t, printRuntimes(&out, op, ""))
+	lines := strings.Split(out.String(), "\n")
+	assert.Equal(t, []string{"LANGUAGE", "KIND", "DEFAULT", "DEPRECATED", "IMAGE"}, strings.Fields(lines[0]))
+	assert.Contains(t, out.String(), "nodejs:6")
+	assert.Contains(t, out.String(), "swift:3.1.1")
+	assert.Equal(t, []string{".js", "nodejs", "nodejs:6"}, strings.Fields(findLine(lines, ".js")))
+	assert.Equal(t, []string{".jar", "java", "java"}, strings.Fields(findLine(lines, ".jar")))
+
+	// a language lists all its kinds, a kind only itself
+	out.Reset()
+	assert.Nil(t, printRuntimes(&out, op, "nodejs"))
+	assert.Equal(t, []string{"nodejs", "nodejs", "no", "yes", "openwhisk/nodejsaction:latest"},
+		strings.Fields(findLine(strings.Split(out.String(), "\n"), "nodejs ")))
+	assert.NotContains(t, out.String(), "python")
+
+	out.Reset()
+	assert.Nil(t, printRuntimes(&out, op, "python:3"))
+	lines = strings.Split(out.String(), "\n")
+	assert.Equal(t, []string{"python", "python:3", "no", "no", "openwhisk/python3action:latest"}, strings.Fields(lines[1]))
+	assert.Equal(t, "", lines[2])
+	// the extension is still mapped to the default kind of the language
+	assert.Equal(t, []string{".py", "python", "python:2"}, strings.Fields(findLine(lines, ".py")))
+
+	assert.NotNil(t, printRuntimes(&out, op, "cobol"))
+}
+
+func findLine(lines []string, prefix string) string {
+	for _, line := range lines {
+		if strings.HasPrefix(line, prefix) {
+			return line
+		}
+	}
+	return ""
+}
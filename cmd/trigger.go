@@ -0,0 +1,128 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/deployers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskdeploy"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+	"github.com/spf13/cobra"
+)
+
+var triggerFlags struct {
+	params []string
+}
+
+// triggerRootCmd represents the trigger command
+var triggerRootCmd = &cobra.Command{
+	Use:   "trigger",
+	Short: "Work with the triggers of the project",
+}
+
+var triggerFireCmd = &cobra.Command{
+	Use:   "fire TRIGGER",
+	Short: "Fire a trigger of the project",
+	Long: `Fire fires a deployed trigger of the project, e.g. to exercise its rules once
+the project is deployed. The trigger is the name of the trigger in the manifest,
+it is fired in the namespace the project deploys it to. Parameters of the event
+are given as --param key value (or --param key=value), values which are valid
+JSON are sent as JSON.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		payload, err := parseTriggerParams(triggerFlags.params, args[1:])
+		if err != nil {
+			return err
+		}
+
+		projectPath, _ := filepath.Abs(utils.Flags.ProjectPath)
+		manifestPath := findProjectFile(utils.Flags.ManifestPath, projectPath, utils.ManifestFileNameYaml, utils.ManifestFileNameYml)
+		if len(manifestPath) == 0 {
+			return wskderrors.NewErrorManifestFileNotFound(projectPath,
+				wski18n.T(wski18n.ID_MSG_MANIFEST_FILE_NOT_FOUND_X_path_X,
+					map[string]interface{}{wski18n.KEY_PATH: projectPath}))
+		}
+		utils.Flags.ManifestPath = manifestPath
+		if err := wskdeploy.LoadEnvFile(projectPath, &utils.Flags); err != nil {
+			return err
+		}
+
+		whisk.SetVerbose(utils.Flags.Verbose)
+		whisk.SetDebug(utils.Flags.Verbose)
+
+		deployer := deployers.NewServiceDeployer()
+		deployer.ProjectPath = projectPath
+		deployer.ManifestPath = manifestPath
+		deployer.DeploymentPath = findProjectFile(utils.Flags.DeploymentPath, projectPath, utils.DeploymentFileNameYaml, utils.DeploymentFileNameYml)
+		if err := wskdeploy.SetDeployerClient(deployer); err != nil {
+			return err
+		}
+
+		name, activationId, err := deployer.FireTrigger(args[0], payload)
+		if err != nil {
+			return err
+		}
+		wskprint.PrintlnOpenWhiskSuccess(wski18n.T(wski18n.ID_MSG_TRIGGER_FIRED_X_name_X_id_X,
+			map[string]interface{}{wski18n.KEY_NAME: name, wski18n.KEY_ID: activationId}))
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(triggerRootCmd)
+	triggerRootCmd.AddCommand(triggerFireCmd)
+
+	triggerFireCmd.Flags().StringVarP(&utils.Flags.ProjectPath, "project", "p", ".", "path to serverless project")
+	triggerFireCmd.Flags().StringVarP(&utils.Flags.ManifestPath, "manifest", "m", "", "path to manifest file")
+	triggerFireCmd.Flags().StringVarP(&utils.Flags.DeploymentPath, "deployment", "d", "", "path to deployment file")
+	triggerFireCmd.Flags().StringArrayVar(&triggerFlags.params, "param", []string{}, "parameter of the event, as key value or key=value")
+}
+
+// parseTriggerParams builds the payload of the event from the --param flags,
+// a flag without "=" takes its value from the next of the positional arguments
+func parseTriggerParams(params []string, values []string) (map[string]interface{}, error) {
+	payload := make(map[string]interface{})
+	for _, param := range params {
+		key, value := param, ""
+		if i := strings.Index(param, "="); i > 0 {
+			key, value = param[:i], param[i+1:]
+		} else {
+			if len(values) == 0 {
+				return nil, wskderrors.NewCommandError("trigger fire",
+					wski18n.T(wski18n.ID_ERR_TRIGGER_PARAM_VALUE_MISSING_X_key_X,
+						map[string]interface{}{wski18n.KEY_KEY: param}))
+			}
+			value, values = values[0], values[1:]
+		}
+
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(value), &parsed); err == nil {
+			payload[key] = parsed
+		} else {
+			payload[key] = value
+		}
+	}
+	return payload, nil
+}
@@ -0,0 +1,40 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTriggerParams(t *testing.T) {
+	// wskdeploy trigger fire TRIGGER --param name Frodo --param count=3 --param place '{"town": "Hobbiton"}'
+	payload, err := parseTriggerParams([]string{"name", "count=3", "place"}, []string{"Frodo", `{"town": "Hobbiton"}`})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name":  "Frodo",
+		"count": float64(3),
+		"place": map[string]interface{}{"town": "Hobbiton"},
+	}, payload)
+
+	_, err = parseTriggerParams([]string{"name"}, []string{})
+	assert.NotNil(t, err)
+}
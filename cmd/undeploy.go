@@ -51,4 +51,6 @@ func init() {
 	undeployCmd.Flags().StringVarP(&utils.Flags.ProjectPath, "pathpath", "p", ".", "path to serverless project")
 	undeployCmd.Flags().StringVarP(&utils.Flags.ManifestPath, "manifest", "m", "", "path to manifest file")
 	undeployCmd.Flags().StringVarP(&utils.Flags.DeploymentPath, "deployment", "d", "", "path to deployment file")
+	undeployCmd.Flags().StringVarP(&utils.Flags.Snapshot, "snapshot", "", "", "undeploy the entities of the last successful deployment, recorded in .wskdeploy/last-deployed.yaml of the project or in the file given, rather than the ones of the manifest")
+	undeployCmd.Flags().Lookup("snapshot").NoOptDefVal = utils.SNAPSHOT_DEFAULT
 }
@@ -0,0 +1,68 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskdeploy"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+	"github.com/spf13/cobra"
+)
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:        "validate",
+	SuggestFor: []string{"check"},
+	Short:      "Check the manifest and deployment files without OpenWhisk",
+	Long: `Validate parses the manifest and deployment files and composes every entity of
+the manifest on its own, the way a deployment does, without contacting
+OpenWhisk. The packages, actions, sequences and triggers of the deployment file
+must be defined in the manifest, the files of actions must exist and their
+runtimes must be supported, see "wskdeploy runtimes". Actions are neither
+built nor read and dependencies are not fetched. All the problems found are
+reported at once and validate fails if there is any, e.g. to check a pull
+request.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		problems, err := wskdeploy.Validate(context.Background())
+		if err != nil {
+			return err
+		}
+		for _, problem := range problems {
+			wskprint.PrintlnOpenWhiskError(problem.Error())
+		}
+		if len(problems) > 0 {
+			return wskderrors.NewCommandError("validate", wski18n.T(wski18n.ID_ERR_VALIDATE_FAILED_X_path_X_count_X,
+				map[string]interface{}{wski18n.KEY_PATH: utils.Flags.ManifestPath, wski18n.KEY_COUNT: len(problems)}))
+		}
+		wskprint.PrintlnOpenWhiskSuccess(wski18n.T(wski18n.ID_MSG_VALIDATE_VALID_X_path_X,
+			map[string]interface{}{wski18n.KEY_PATH: utils.Flags.ManifestPath}))
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(validateCmd)
+
+	validateCmd.Flags().StringVarP(&utils.Flags.ProjectPath, "project", "p", ".", "path to serverless project")
+	validateCmd.Flags().StringVarP(&utils.Flags.ManifestPath, "manifest", "m", "", "path to manifest file")
+	validateCmd.Flags().StringVarP(&utils.Flags.DeploymentPath, "deployment", "d", "", "path to deployment file")
+}
@@ -0,0 +1,66 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskdeploy"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+	"github.com/spf13/cobra"
+)
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:        "verify",
+	SuggestFor: []string{"diff", "check"},
+	Short:      "Check that the deployed entities match the project",
+	Long: `Verify composes the project the way a deployment does and fetches its packages,
+actions, sequences, triggers and rules from OpenWhisk, nothing is deployed.
+The hash of the code, the kind, main, limits and web flag of actions and the
+parameters and annotations of every entity must match the project. Verify
+fails if an entity differs or is not deployed, e.g. to check a deployment in
+a pipeline.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		mismatches, err := wskdeploy.Verify(context.Background())
+		if err != nil {
+			return err
+		}
+		for _, mismatch := range mismatches {
+			wskprint.PrintlnOpenWhiskError(mismatch.String())
+		}
+		if len(mismatches) > 0 {
+			return wskderrors.NewCommandError("verify", wski18n.T(wski18n.ID_ERR_VERIFY_FAILED_X_path_X_mismatches_X,
+				map[string]interface{}{wski18n.KEY_PATH: utils.Flags.ManifestPath, wski18n.KEY_MISMATCHES: len(mismatches)}))
+		}
+		wskprint.PrintlnOpenWhiskSuccess(wski18n.T(wski18n.ID_MSG_VERIFY_MATCH_X_path_X,
+			map[string]interface{}{wski18n.KEY_PATH: utils.Flags.ManifestPath}))
+		return nil
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(verifyCmd)
+
+	verifyCmd.Flags().StringVarP(&utils.Flags.ProjectPath, "project", "p", ".", "path to serverless project")
+	verifyCmd.Flags().StringVarP(&utils.Flags.ManifestPath, "manifest", "m", "", "path to manifest file")
+	verifyCmd.Flags().StringVarP(&utils.Flags.DeploymentPath, "deployment", "d", "", "path to deployment file")
+}
@@ -20,6 +20,7 @@ package cmd
 import (
 	"fmt"
 	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
 	"github.com/spf13/cobra"
 )
 
@@ -33,6 +34,9 @@ var versionCmd = &cobra.Command{
 	Short: "Print the version number of openwhisk-wskdeploy",
 	Long:  `Print the version number of openwhisk-wskdeploy`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Printf("openwhisk-wskdeploy version is %s--%s\n", utils.Flags.CliBuild, utils.Flags.CliVersion)
+		fmt.Println(wski18n.T(wski18n.ID_MSG_VERSION_X_build_X_version_X,
+			map[string]interface{}{wski18n.KEY_BUILD: utils.Flags.CliBuild, wski18n.KEY_VERSION: utils.Flags.CliVersion}))
+		fmt.Println(wski18n.T(wski18n.ID_MSG_VERSION_CHECK_X_version_X_spec_X,
+			map[string]interface{}{wski18n.KEY_VERSION: utils.GetWskdeployVersion(), wski18n.KEY_SPEC: utils.SPEC_VERSION}))
 	},
 }
@@ -0,0 +1,55 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskdeploy"
+	"github.com/spf13/cobra"
+)
+
+var watchFlags struct {
+	debounce time.Duration
+}
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:        "watch",
+	SuggestFor: []string{"dev"},
+	Short:      "Deploy the project and redeploy it whenever its files change",
+	Long: `Watch deploys the project, then watches its manifest, deployment and .env files
+and the files and directories of the code of its actions. Once the files stop
+changing for --debounce, the project is composed again and only the entities
+which changed are deployed. Entities removed from the project stay deployed,
+unless --managed is given in which case the whole project is redeployed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return wskdeploy.Watch(context.Background(), watchFlags.debounce)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().StringVarP(&utils.Flags.ProjectPath, "project", "p", ".", "path to serverless project")
+	watchCmd.Flags().StringVarP(&utils.Flags.ManifestPath, "manifest", "m", "", "path to manifest file")
+	watchCmd.Flags().StringVarP(&utils.Flags.DeploymentPath, "deployment", "d", "", "path to deployment file")
+	watchCmd.Flags().DurationVarP(&watchFlags.debounce, "debounce", "", wskdeploy.DEFAULT_WATCH_DEBOUNCE, "time the files must stop changing for before the project is redeployed")
+}
@@ -0,0 +1,149 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// IBM Cloud IAM, which exchanges an API key for the bearer token the API
+// gateway of IBM Cloud Functions is authenticated with
+const (
+	IAM_TOKEN_URL         = "https://iam.cloud.ibm.com/identity/token"
+	IAM_GRANT_TYPE_APIKEY = "urn:ibm:params:oauth:grant-type:apikey"
+	IAM_API_KEY_ENV       = "IBMCLOUD_API_KEY"
+	// a token is exchanged again this long before it expires
+	IAM_TOKEN_REFRESH_MARGIN = 60 * time.Second
+	IAM_REQUEST_TIMEOUT      = 30 * time.Second
+)
+
+// ApigwAuthProvider provides the access token of the API gateway when it is
+// not configured as such, see NewApigwConfig(). The token is asked for before
+// each API is deployed, so that the provider may refresh it.
+type ApigwAuthProvider interface {
+	AccessToken() (string, error)
+}
+
+// IAMTokenProvider exchanges an IBM Cloud IAM API key for a bearer token, and
+// exchanges it again once the token is about to expire
+type IAMTokenProvider struct {
+	ApiKey string
+	URL    string
+	Client *http.Client
+
+	mt         sync.Mutex
+	token      string
+	expiration time.Time
+}
+
+func NewIAMTokenProvider(apiKey string) *IAMTokenProvider {
+	return &IAMTokenProvider{ApiKey: apiKey, URL: IAM_TOKEN_URL, Client: &http.Client{Timeout: IAM_REQUEST_TIMEOUT}}
+}
+
+// NewApigwAuthProvider returns the provider of the access token of the API
+// gateway when the configuration has no access token and an IBM Cloud IAM API
+// key is found, in the following precedence order:
+// (1) wskdeploy command line `wskdeploy --iam-api-key`
+// (2) the profile selected with --profile, "IAM_API_KEY"
+// (3) the environment variable IBMCLOUD_API_KEY
+// It returns nil otherwise.
+func NewApigwAuthProvider(apigwConfig *whisk.Config, flags *utils.WskdeployFlags) (ApigwAuthProvider, error) {
+	if apigwConfig != nil && len(apigwConfig.ApigwAccessToken) > 0 {
+		return nil, nil
+	}
+	apiKey := PropertyValue{}
+	apiKey = GetPropertyValue(apiKey, flags.IamApiKey, COMMANDLINE)
+	if len(flags.Profile) > 0 {
+		profile, err := GetProfile(flags.Profile)
+		if err != nil {
+			return nil, err
+		}
+		apiKey = GetPropertyValue(apiKey, profile.IamApiKey, wski18n.T(wski18n.ID_MSG_PROFILE_SOURCE_X_name_X,
+			map[string]interface{}{wski18n.KEY_NAME: profile.Name}))
+	}
+	apiKey = getEnvPropertyValue(apiKey, IAM_API_KEY_ENV)
+	if len(apiKey.Value) == 0 {
+		return nil, nil
+	}
+	wskprint.AddSecret(apiKey.Value)
+	wskprint.PrintOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_CONFIG_INFO_IAM_API_KEY_X_source_X,
+		map[string]interface{}{wski18n.KEY_SOURCE: apiKey.Source}))
+	return NewIAMTokenProvider(apiKey.Value), nil
+}
+
+// AccessToken returns the bearer token, it is exchanged for the API key the
+// first time and whenever it is about to expire
+func (provider *IAMTokenProvider) AccessToken() (string, error) {
+	provider.mt.Lock()
+	defer provider.mt.Unlock()
+	if len(provider.token) > 0 && time.Now().Add(IAM_TOKEN_REFRESH_MARGIN).Before(provider.expiration) {
+		return provider.token, nil
+	}
+
+	form := url.Values{"grant_type": {IAM_GRANT_TYPE_APIKEY}, "apikey": {provider.ApiKey}}
+	request, err := http.NewRequest("POST", provider.URL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", provider.error(err.Error(), -1, nil)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	response, err := provider.Client.Do(request)
+	if err != nil {
+		return "", provider.error(err.Error(), -1, nil)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", provider.error(response.Status, response.StatusCode, response)
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+		Expiration  int64  `json:"expiration"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&token); err != nil {
+		return "", provider.error(err.Error(), response.StatusCode, nil)
+	}
+	if len(token.AccessToken) == 0 {
+		return "", provider.error("no access_token", response.StatusCode, nil)
+	}
+	provider.token = token.AccessToken
+	if token.Expiration > 0 {
+		provider.expiration = time.Unix(token.Expiration, 0)
+	} else {
+		provider.expiration = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	}
+	wskprint.AddSecret(provider.token)
+	return provider.token, nil
+}
+
+func (provider *IAMTokenProvider) error(message string, code int, response *http.Response) error {
+	return wskderrors.NewWhiskClientError(wski18n.T(wski18n.ID_ERR_IAM_TOKEN_X_url_X_err_X,
+		map[string]interface{}{wski18n.KEY_URL: provider.URL, wski18n.KEY_ERR: message}), code, response)
+}
@@ -0,0 +1,125 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newIAMServer(t *testing.T, expiresIn int, exchanges *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Nil(t, r.ParseForm())
+		assert.Equal(t, IAM_GRANT_TYPE_APIKEY, r.PostForm.Get("grant_type"))
+		if r.PostForm.Get("apikey") != "my-api-key" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		n := atomic.AddInt32(exchanges, 1)
+		fmt.Fprintf(w, `{"access_token":"token-%d","expires_in":%d,"token_type":"Bearer"}`, n, expiresIn)
+	}))
+}
+
+func TestIAMTokenProvider_AccessToken(t *testing.T) {
+	var exchanges int32
+	server := newIAMServer(t, 3600, &exchanges)
+	defer server.Close()
+
+	provider := NewIAMTokenProvider("my-api-key")
+	provider.URL = server.URL
+	token, err := provider.AccessToken()
+	assert.Nil(t, err)
+	assert.Equal(t, "token-1", token)
+
+	// the token is kept until it is about to expire
+	token, err = provider.AccessToken()
+	assert.Nil(t, err)
+	assert.Equal(t, "token-1", token)
+	assert.Equal(t, int32(1), exchanges)
+
+	provider.expiration = time.Now().Add(IAM_TOKEN_REFRESH_MARGIN / 2)
+	token, err = provider.AccessToken()
+	assert.Nil(t, err)
+	assert.Equal(t, "token-2", token)
+	assert.Equal(t, int32(2), exchanges)
+}
+
+func TestIAMTokenProvider_AccessTokenRejected(t *testing.T) {
+	var exchanges int32
+	server := newIAMServer(t, 3600, &exchanges)
+	defer server.Close()
+
+	provider := NewIAMTokenProvider("wrong-api-key")
+	provider.URL = server.URL
+	_, err := provider.AccessToken()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), server.URL)
+}
+
+func TestNewApigwAuthProvider(t *testing.T) {
+	defer os.Setenv(IAM_API_KEY_ENV, os.Getenv(IAM_API_KEY_ENV))
+
+	flags := &utils.WskdeployFlags{}
+	os.Unsetenv(IAM_API_KEY_ENV)
+	provider, err := NewApigwAuthProvider(&whisk.Config{}, flags)
+	assert.Nil(t, err)
+	assert.Nil(t, provider)
+
+	os.Setenv(IAM_API_KEY_ENV, "env-api-key")
+	provider, err = NewApigwAuthProvider(&whisk.Config{}, flags)
+	assert.Nil(t, err)
+	assert.Equal(t, "env-api-key", provider.(*IAMTokenProvider).ApiKey)
+
+	flags.IamApiKey = "flag-api-key"
+	provider, err = NewApigwAuthProvider(&whisk.Config{}, flags)
+	assert.Nil(t, err)
+	assert.Equal(t, "flag-api-key", provider.(*IAMTokenProvider).ApiKey)
+
+	// the access token of .wskprops is used as is
+	provider, err = NewApigwAuthProvider(&whisk.Config{ApigwAccessToken: "access-token"}, flags)
+	assert.Nil(t, err)
+	assert.Nil(t, provider)
+}
+
+func TestServiceDeployer_ApigwClientWithAuthProvider(t *testing.T) {
+	var exchanges int32
+	server := newIAMServer(t, 3600, &exchanges)
+	defer server.Close()
+
+	provider := NewIAMTokenProvider("my-api-key")
+	provider.URL = server.URL
+	deployer := NewServiceDeployer()
+	deployer.Client = &whisk.Client{Config: &whisk.Config{AuthToken: "uuid:key"}}
+	deployer.ApigwAuth = provider
+	_, options, err := deployer.apigwClient()
+	assert.Nil(t, err)
+	assert.Equal(t, "uuid", options.SpaceGuid)
+	assert.Equal(t, "token-1", options.AccessToken)
+}
@@ -0,0 +1,169 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"sort"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskenv"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// binding is what the deployment file binds to an entity of the deployment
+// plan: inputs, which override the parameters of the manifest or add new ones,
+// annotations, which override the ones of the manifest, the inputs and
+// annotations of the manifest left out with del_inputs and del_annotations,
+// and a hook run once they are bound, e.g. to set the feed of a trigger
+type binding struct {
+	// YAML key and name of the entity, for error reporting
+	key            string
+	name           string
+	inputs         map[string]parsers.Parameter
+	annotations    map[string]interface{}
+	delInputs      []string
+	delAnnotations []string
+	hook           func() error
+}
+
+// bindable is the parameters and annotations of an entity of the deployment
+// plan, the parameters are nil for entities which have none, e.g. rules
+type bindable struct {
+	parameters  *whisk.KeyValueArr
+	annotations *whisk.KeyValueArr
+}
+
+// mergeBindings binds the layers of the deployment file to an entity in order
+// of precedence, an input or annotation of a layer overrides the ones of the
+// manifest and of the layers before it, e.g. the inputs of a package override
+// the ones of the project. The values of inputs are merged with the ones they
+// override, see parsers.MergeInputValue(), and converted to their type, see
+// parsers.CoerceInputValue().
+func (reader *DeploymentReader) mergeBindings(target bindable, layers ...binding) error {
+	for _, layer := range layers {
+		if target.parameters != nil {
+			if err := reader.mergeInputs(target.parameters, layer.inputs); err != nil {
+				return err
+			}
+			*target.parameters = removeKeys(*target.parameters, layer.delInputs)
+		}
+		if target.annotations != nil {
+			if err := reader.mergeAnnotations(target.annotations, layer); err != nil {
+				return err
+			}
+			*target.annotations = removeKeys(*target.annotations, layer.delAnnotations)
+			if target.parameters != nil {
+				*target.annotations = parsers.AddEncryptedInputs(*target.annotations, layer.inputs)
+			}
+		}
+		if layer.hook != nil {
+			if err := layer.hook(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (reader *DeploymentReader) mergeInputs(parameters *whisk.KeyValueArr, inputs map[string]parsers.Parameter) error {
+	filePath := reader.DeploymentDescriptor.Filepath
+	for _, name := range sortedInputNames(inputs) {
+		input := inputs[name]
+		value := wskenv.GetEnvVar(input.Value)
+		if err := parsers.RegisterSecretParameter(filePath, name, &input, value, reader.serviceDeployer.Flags.SecretsFromEnv); err != nil {
+			return err
+		}
+		index := indexOfKey(*parameters, name)
+		var base interface{}
+		if index >= 0 {
+			base = (*parameters)[index].Value
+		}
+		value, err := parsers.MergeInputValue(filePath, name, input.Merge, base, value)
+		if err != nil {
+			return err
+		}
+		if value, err = parsers.CoerceInputValue(filePath, name, input.Type, base, value); err != nil {
+			return err
+		}
+		if index >= 0 {
+			(*parameters)[index].Value = value
+		} else {
+			*parameters = append(*parameters, whisk.KeyValue{Key: name, Value: value})
+		}
+	}
+	return nil
+}
+
+// mergeAnnotations overrides the annotations of the manifest, the deployment
+// file does not add annotations
+func (reader *DeploymentReader) mergeAnnotations(annotations *whisk.KeyValueArr, layer binding) error {
+	names := make([]string, 0, len(layer.annotations))
+	for name := range layer.annotations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		index := indexOfKey(*annotations, name)
+		if index < 0 {
+			return wskderrors.NewYAMLFileFormatError(reader.DeploymentDescriptor.Filepath,
+				wski18n.T(wski18n.ID_ERR_DEPLOYMENT_ANNOTATION_NOT_IN_MANIFEST_X_key_X_name_X_annotation_X,
+					map[string]interface{}{wski18n.KEY_KEY: layer.key, wski18n.KEY_NAME: layer.name,
+						wski18n.KEY_ANNOTATION: name}))
+		}
+		(*annotations)[index].Value = parsers.ResolveAnnotation(layer.annotations[name])
+	}
+	return nil
+}
+
+func sortedInputNames(inputs map[string]parsers.Parameter) []string {
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func indexOfKey(keyValues whisk.KeyValueArr, key string) int {
+	for i, keyValue := range keyValues {
+		if keyValue.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeKeys returns the key values without the given keys
+func removeKeys(keyValues whisk.KeyValueArr, keys []string) whisk.KeyValueArr {
+	if len(keys) == 0 {
+		return keyValues
+	}
+	removed := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		removed[key] = true
+	}
+	kept := make(whisk.KeyValueArr, 0, len(keyValues))
+	for _, keyValue := range keyValues {
+		if !removed[keyValue.Key] {
+			kept = append(kept, keyValue)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,98 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBindingReader() *DeploymentReader {
+	reader := NewDeploymentReader(NewServiceDeployer())
+	reader.DeploymentDescriptor = &parsers.YAML{Filepath: "deployment.yaml"}
+	return reader
+}
+
+func TestDeploymentReader_mergeBindings(t *testing.T) {
+	reader := newBindingReader()
+	parameters := whisk.KeyValueArr{{Key: "port", Value: 80}, {Key: "host", Value: "localhost"},
+		{Key: "debug", Value: true}, {Key: "tags", Value: []interface{}{"prod"}}}
+	annotations := whisk.KeyValueArr{{Key: "final", Value: true}, {Key: "description", Value: "hello"}}
+
+	hooked := false
+	project := binding{key: parsers.YAML_KEY_PROJECT, name: "hello", inputs: map[string]parsers.Parameter{
+		"host":   {Value: "project.example.com"},
+		"region": {Value: "eu"},
+	}}
+	pack := binding{key: parsers.YAML_KEY_PACKAGE, name: "hello",
+		inputs: map[string]parsers.Parameter{
+			// the package takes precedence over the project
+			"host": {Value: "package.example.com"},
+			// strings are converted to the type of the value they override
+			"port": {Value: "8080"},
+			// or to the type they declare
+			"retries": {Type: "integer", Value: "3"},
+			"tags":    {Value: []interface{}{"eu"}, Merge: parsers.INPUT_MERGE_APPEND},
+		},
+		annotations:    map[string]interface{}{"description": "hello from the deployment file"},
+		delInputs:      []string{"debug"},
+		delAnnotations: []string{"final"},
+		hook: func() error {
+			hooked = true
+			return nil
+		},
+	}
+	assert.Nil(t, reader.mergeBindings(bindable{&parameters, &annotations}, project, pack))
+
+	assert.Equal(t, whisk.KeyValueArr{
+		{Key: "port", Value: 8080}, {Key: "host", Value: "package.example.com"},
+		{Key: "tags", Value: []interface{}{"prod", "eu"}}, {Key: "region", Value: "eu"},
+		{Key: "retries", Value: 3}}, parameters)
+	assert.Equal(t, whisk.KeyValueArr{{Key: "description", Value: "hello from the deployment file"}}, annotations)
+	assert.True(t, hooked)
+}
+
+func TestDeploymentReader_mergeBindings_Errors(t *testing.T) {
+	reader := newBindingReader()
+	parameters := whisk.KeyValueArr{{Key: "port", Value: 80}}
+	annotations := whisk.KeyValueArr{}
+
+	// the value cannot be converted to the type of the manifest
+	err := reader.mergeBindings(bindable{&parameters, &annotations}, binding{key: parsers.YAML_KEY_ACTION, name: "hello",
+		inputs: map[string]parsers.Parameter{"port": {Value: "eighty"}}})
+	assert.IsType(t, &wskderrors.ParameterTypeMismatchError{}, err)
+
+	// the deployment file does not add annotations
+	err = reader.mergeBindings(bindable{&parameters, &annotations}, binding{key: parsers.YAML_KEY_ACTION, name: "hello",
+		annotations: map[string]interface{}{"final": true}})
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err)
+	assert.Contains(t, err.Error(), "final")
+
+	// the entities without parameters only bind annotations
+	rule := &whisk.Rule{Name: "hello", Annotations: whisk.KeyValueArr{{Key: "owner", Value: "ops"}}}
+	assert.Nil(t, reader.mergeBindings(bindable{annotations: &rule.Annotations}, binding{key: parsers.YAML_KEY_RULE,
+		name: "hello", inputs: map[string]parsers.Parameter{"port": {Value: 8080}},
+		annotations: map[string]interface{}{"owner": "dev"}}))
+	assert.Equal(t, whisk.KeyValueArr{{Key: "owner", Value: "dev"}}, rule.Annotations)
+}
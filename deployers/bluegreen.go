@@ -0,0 +1,343 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// name of the file (relative to the project path) which records the suffix
+// the rules and APIs point to, see --deploy-as
+const BLUE_GREEN_FILE_NAME = ".wskdeploy.bluegreen"
+
+// separator of the name of a package or trigger and its --deploy-as suffix
+const DEPLOY_AS_SEPARATOR = "-"
+
+var deployAsSuffixRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// BlueGreenState records the --deploy-as suffix of the entities the rules and
+// APIs point to, and the one they pointed to before, for --rollback
+type BlueGreenState struct {
+	Live     string `json:"live"`
+	Previous string `json:"previous,omitempty"`
+}
+
+func GetBlueGreenFilePath(projectPath string) string {
+	return path.Join(projectPath, BLUE_GREEN_FILE_NAME)
+}
+
+// ReadBlueGreenState reads the state of the project, an empty state if the
+// project was never deployed with --deploy-as
+func ReadBlueGreenState(filePath string) (*BlueGreenState, error) {
+	state := &BlueGreenState{}
+	if !utils.FileExists(filePath) {
+		return state, nil
+	}
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	if err := json.Unmarshal(content, state); err != nil {
+		return nil, wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	return state, nil
+}
+
+func (state *BlueGreenState) Write(filePath string) error {
+	content, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filePath, content, 0644)
+}
+
+// Switch records the suffix as the live one, the live one becomes the previous
+func (state *BlueGreenState) Switch(suffix string) {
+	if state.Live != suffix {
+		state.Previous = state.Live
+	}
+	state.Live = suffix
+}
+
+// ApplyDeployAs deploys the packages and triggers of the plan side by side
+// with the live ones: they are suffixed with --deploy-as, e.g. hello is
+// deployed as hello-green, while the rules, APIs and dependencies keep their
+// names and are re-pointed to the suffixed entities. With --rollback and no
+// --deploy-as, the suffix is the previous one of the blue/green state.
+func (deployer *ServiceDeployer) ApplyDeployAs() error {
+	if deployer.IsDependency || (len(deployer.Flags.DeployAs) == 0 && !deployer.Flags.Rollback) {
+		return nil
+	}
+	suffix := deployer.Flags.DeployAs
+	if len(suffix) == 0 {
+		statePath := GetBlueGreenFilePath(deployer.ProjectPath)
+		state, err := ReadBlueGreenState(statePath)
+		if err != nil {
+			return err
+		}
+		if len(state.Previous) == 0 {
+			return wskderrors.NewCommandError("--rollback", wski18n.T(wski18n.ID_ERR_ROLLBACK_NO_PREVIOUS_X_path_X,
+				map[string]interface{}{wski18n.KEY_PATH: statePath}))
+		}
+		suffix = state.Previous
+	}
+	if !deployAsSuffixRegex.MatchString(suffix) {
+		return wskderrors.NewCommandError("--deploy-as", wski18n.T(wski18n.ID_ERR_DEPLOY_AS_INVALID_X_suffix_X,
+			map[string]interface{}{wski18n.KEY_SUFFIX: suffix}))
+	}
+	deployer.DeployAs = suffix
+	return deployer.suffixEntities(suffix)
+}
+
+// suffixEntities renames the packages and triggers of the plan, and the
+// references of sequences, rules and OpenAPI documents to them. Packages of
+// other namespaces and dependencies are left unchanged.
+func (deployer *ServiceDeployer) suffixEntities(suffix string) error {
+	namespace := ""
+	if deployer.ClientConfig != nil {
+		namespace = deployer.ClientConfig.Namespace
+	}
+	dependencies := make(map[string]bool)
+	for _, pack := range deployer.Deployment.Packages {
+		for name := range pack.Dependencies {
+			dependencies[name] = true
+		}
+	}
+	// the new names of the packages and triggers, and their namespaces
+	renamed := make(map[string]string)
+	packageNamespaces := make(map[string]string)
+	packages := make(map[string]*DeploymentPackage, len(deployer.Deployment.Packages))
+	for name, pack := range deployer.Deployment.Packages {
+		if dependencies[name] {
+			packages[name] = pack
+			continue
+		}
+		renamed[name] = name + DEPLOY_AS_SEPARATOR + suffix
+		packageNamespaces[name] = namespace
+		if pack.Package != nil && len(pack.Package.Namespace) > 0 {
+			packageNamespaces[name] = strings.Trim(pack.Package.Namespace, "/")
+		}
+		packages[renamed[name]] = pack
+	}
+	// rename renames the entity, or the package of the action given the number
+	// of parents after it, of a name which may be qualified by a namespace
+	rename := func(name string, names map[string]string, namespaces map[string]string, parent int) string {
+		parts := strings.Split(name, "/")
+		i := len(parts) - 1 - parent
+		if i < 0 || (i >= 2 && parts[i-1] != namespaces[parts[i]] && parts[i-1] != "_") {
+			return name
+		}
+		if newName, ok := names[parts[i]]; ok {
+			parts[i] = newName
+		}
+		return strings.Join(parts, "/")
+	}
+
+	for name, pack := range packages {
+		if pack.Package != nil && renamed[pack.Package.Name] == name {
+			pack.Package.Name = name
+		}
+		for _, records := range []map[string]utils.ActionRecord{pack.Actions, pack.Sequences} {
+			for actionName, record := range records {
+				if newName, ok := renamed[record.Packagename]; ok {
+					record.Packagename = newName
+				}
+				if record.Action.Exec != nil {
+					for i, component := range record.Action.Exec.Components {
+						record.Action.Exec.Components[i] = rename(component, renamed, packageNamespaces, 1)
+					}
+				}
+				records[actionName] = record
+			}
+		}
+	}
+	deployer.Deployment.Packages = packages
+
+	triggers := make(map[string]string)
+	triggerNamespaces := make(map[string]string)
+	suffixedTriggers := make(map[string]*whisk.Trigger, len(deployer.Deployment.Triggers))
+	for name, trigger := range deployer.Deployment.Triggers {
+		triggers[name] = name + DEPLOY_AS_SEPARATOR + suffix
+		triggerNamespaces[name] = namespace
+		if len(trigger.Namespace) > 0 {
+			triggerNamespaces[name] = strings.Trim(trigger.Namespace, "/")
+		}
+		trigger.Name = triggers[name]
+		suffixedTriggers[triggers[name]] = trigger
+	}
+	deployer.Deployment.Triggers = suffixedTriggers
+	for _, rule := range deployer.Deployment.Rules {
+		action := fmt.Sprint(rule.Action)
+		// an action without package is one of the root package
+		if !strings.Contains(action, "/") && len(deployer.RootPackageName) > 0 {
+			action = path.Join(deployer.RootPackageName, action)
+		}
+		rule.Trigger = rename(fmt.Sprint(rule.Trigger), triggers, triggerNamespaces, 0)
+		rule.Action = rename(action, renamed, packageNamespaces, 1)
+	}
+
+	for name, api := range deployer.Deployment.Apis {
+		if len(api.ApiDoc.Swagger) == 0 {
+			continue
+		}
+		document, err := parsers.ParseSwaggerDocument([]byte(api.ApiDoc.Swagger), name, "")
+		if err != nil {
+			return err
+		}
+		for _, operation := range document.Operations {
+			if newName, ok := renamed[operation.Package]; ok && operation.Namespace == parsers.SWAGGER_DEFAULT_NAMESPACE {
+				operation.SetPackage(newName)
+			}
+		}
+		if api.ApiDoc.Swagger, err = document.JSON(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// VerifyDeployAs checks that the actions, sequences and triggers of the
+// --deploy-as suffix are all deployed before the rules and APIs are switched
+// to them
+func (deployer *ServiceDeployer) VerifyDeployAs() error {
+	missing := make([]string, 0)
+	count := 0
+	for name, pack := range deployer.Deployment.Packages {
+		if pack.Package == nil || pack.Package.Name != name {
+			continue
+		}
+		for _, records := range []map[string]utils.ActionRecord{pack.Actions, pack.Sequences} {
+			for actionName, record := range records {
+				count++
+				qualifiedName := path.Join(name, actionName)
+				if deployer.ClientConfig != nil {
+					qualifiedName = deployer.getQualifiedName(qualifiedName, record.Action.Namespace)
+				}
+				if !deployer.actionExists(qualifiedName) {
+					missing = append(missing, path.Join(name, actionName))
+				}
+			}
+		}
+	}
+	for name, trigger := range deployer.Deployment.Triggers {
+		count++
+		err := deployer.inNamespace(trigger.Namespace, func() error {
+			if deployer.Client == nil {
+				return fmt.Errorf("%s", name)
+			}
+			_, _, err := deployer.Client.Triggers.Get(name)
+			return err
+		})
+		if err != nil {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return wskderrors.NewCommandError("--deploy-as", wski18n.T(wski18n.ID_ERR_DEPLOY_AS_NOT_DEPLOYED_X_suffix_X_names_X,
+			map[string]interface{}{wski18n.KEY_SUFFIX: deployer.DeployAs, wski18n.KEY_NAMES: strings.Join(missing, ", ")}))
+	}
+	wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_DEPLOY_AS_VERIFIED_X_suffix_X_count_X,
+		map[string]interface{}{wski18n.KEY_SUFFIX: deployer.DeployAs, wski18n.KEY_COUNT: count}))
+	return nil
+}
+
+// deployBlueGreen deploys the packages, actions, sequences and triggers of
+// the --deploy-as suffix, verifies that they are all deployed and only then
+// switches the rules and APIs to them. The entities of the previous suffix are
+// kept, for --rollback, so managed entities are not refreshed. With --rollback
+// only the rules and APIs are switched.
+func (deployer *ServiceDeployer) deployBlueGreen() error {
+	if !deployer.Flags.Rollback {
+		steps := []func() error{deployer.DeployPackages, deployer.DeployDependencies, deployer.DeployBindings,
+			deployer.DeployActions, deployer.DeploySequences, deployer.RunPostDeployHooks, deployer.DeployTriggers,
+			deployer.Failures.Error}
+		for _, step := range steps {
+			if err := step(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := deployer.VerifyDeployAs(); err != nil {
+		return err
+	}
+	for _, step := range []func() error{deployer.DeployRules, deployer.DeployApis, deployer.DeployOutputBindings} {
+		if err := step(); err != nil {
+			return err
+		}
+	}
+	return deployer.Failures.Error()
+}
+
+// recordBlueGreen records the --deploy-as suffix the rules and APIs point to
+// once they are switched
+func (deployer *ServiceDeployer) recordBlueGreen() {
+	if len(deployer.DeployAs) == 0 {
+		return
+	}
+	statePath := GetBlueGreenFilePath(deployer.ProjectPath)
+	state, err := ReadBlueGreenState(statePath)
+	if err != nil {
+		wskprint.PrintlnOpenWhiskWarning(err.Error())
+		return
+	}
+	state.Switch(deployer.DeployAs)
+	if err := state.Write(statePath); err != nil {
+		wskprint.PrintlnOpenWhiskWarning(err.Error())
+		return
+	}
+	wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_DEPLOY_AS_SWITCHED_X_suffix_X_previous_X,
+		map[string]interface{}{wski18n.KEY_SUFFIX: state.Live, wski18n.KEY_PREVIOUS: state.Previous}))
+}
+
+// applyUnDeployAs restricts the undeployment to the packages and triggers of
+// the --deploy-as suffix, the rules and APIs are left to the live suffix,
+// which cannot be undeployed
+func (deployer *ServiceDeployer) applyUnDeployAs() error {
+	if len(deployer.Flags.DeployAs) == 0 || deployer.IsDependency {
+		return nil
+	}
+	statePath := GetBlueGreenFilePath(deployer.ProjectPath)
+	state, err := ReadBlueGreenState(statePath)
+	if err != nil {
+		return err
+	}
+	if state.Live == deployer.Flags.DeployAs {
+		return wskderrors.NewCommandError("--deploy-as", wski18n.T(wski18n.ID_ERR_DEPLOY_AS_LIVE_X_suffix_X_path_X,
+			map[string]interface{}{wski18n.KEY_SUFFIX: state.Live, wski18n.KEY_PATH: statePath}))
+	}
+	if err := deployer.ApplyDeployAs(); err != nil {
+		return err
+	}
+	deployer.Deployment.Rules = make(map[string]*whisk.Rule)
+	deployer.Deployment.Apis = make(map[string]*whisk.ApiCreateRequest)
+	return nil
+}
@@ -0,0 +1,139 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBlueGreenDeployer(projectPath string) *ServiceDeployer {
+	deployer := NewServiceDeployer()
+	deployer.ProjectPath = projectPath
+	deployer.ClientConfig = &whisk.Config{Namespace: "guest"}
+
+	pack := NewDeploymentPackage()
+	pack.Package = &whisk.Package{Name: "hello"}
+	pack.Dependencies["mydb"] = utils.DependencyRecord{Location: "/whisk.system/cloudant", IsBinding: true}
+	pack.Actions["greeting"] = utils.ActionRecord{Action: &whisk.Action{Name: "greeting"}, Packagename: "hello"}
+	pack.Sequences["welcome"] = utils.ActionRecord{Packagename: "hello", Action: &whisk.Action{Name: "welcome",
+		Exec: &whisk.Exec{Kind: "sequence", Components: []string{"/guest/hello/greeting", "/guest/mydb/read", "/other/hello/greeting"}}}}
+	deployer.Deployment.Packages["hello"] = pack
+
+	deployer.Deployment.Triggers["locationUpdate"] = &whisk.Trigger{Name: "locationUpdate"}
+	deployer.Deployment.Rules["myRule"] = &whisk.Rule{Name: "myRule", Trigger: "locationUpdate", Action: "hello/greeting"}
+	deployer.Deployment.Rules["dbRule"] = &whisk.Rule{Name: "dbRule", Trigger: "/other/changes", Action: "mydb/read"}
+	swagger := `{"info":{"title":"hello"},"basePath":"/hello","paths":{"/greeting":{` +
+		`"get":{"x-openwhisk":{"namespace":"_","package":"hello","action":"greeting"}},` +
+		`"post":{"x-openwhisk":{"namespace":"other","package":"hello","action":"greeting","url":"https://example.com/greeting"}}}}}`
+	deployer.Deployment.Apis["hello"] = &whisk.ApiCreateRequest{ApiDoc: &whisk.Api{ApiName: "hello", Swagger: swagger}}
+	return deployer
+}
+
+func TestServiceDeployer_ApplyDeployAs(t *testing.T) {
+	deployer := newBlueGreenDeployer("")
+	utils.Flags.DeployAs = "green"
+	defer func() { utils.Flags.DeployAs = "" }()
+	assert.Nil(t, deployer.ApplyDeployAs())
+	assert.Equal(t, "green", deployer.DeployAs)
+
+	pack, ok := deployer.Deployment.Packages["hello-green"]
+	assert.True(t, ok)
+	assert.Equal(t, "hello-green", pack.Package.Name)
+	assert.Equal(t, "hello-green", pack.Actions["greeting"].Packagename)
+	// dependencies and the packages of other namespaces keep their names
+	assert.Equal(t, []string{"/guest/hello-green/greeting", "/guest/mydb/read", "/other/hello/greeting"},
+		pack.Sequences["welcome"].Action.Exec.Components)
+
+	trigger, ok := deployer.Deployment.Triggers["locationUpdate-green"]
+	assert.True(t, ok)
+	assert.Equal(t, "locationUpdate-green", trigger.Name)
+	// the rules keep their names and are re-pointed
+	assert.Equal(t, "locationUpdate-green", deployer.Deployment.Rules["myRule"].Trigger)
+	assert.Equal(t, "hello-green/greeting", deployer.Deployment.Rules["myRule"].Action)
+	assert.Equal(t, "/other/changes", deployer.Deployment.Rules["dbRule"].Trigger)
+	assert.Equal(t, "mydb/read", deployer.Deployment.Rules["dbRule"].Action)
+
+	swagger := deployer.Deployment.Apis["hello"].ApiDoc.Swagger
+	assert.Contains(t, swagger, `{"action":"greeting","namespace":"_","package":"hello-green"}`)
+	assert.Contains(t, swagger, `"package":"hello","url":"https://example.com/greeting"`)
+
+	utils.Flags.DeployAs = "blue green"
+	err := newBlueGreenDeployer("").ApplyDeployAs()
+	assert.IsType(t, &wskderrors.CommandError{}, err)
+}
+
+func TestServiceDeployer_ApplyDeployAs_Rollback(t *testing.T) {
+	projectPath, err := ioutil.TempDir("", "wskdeploy-bluegreen")
+	assert.Nil(t, err)
+	defer os.RemoveAll(projectPath)
+	utils.Flags.Rollback = true
+	defer func() { utils.Flags.Rollback = false }()
+
+	// there is nothing to roll back to
+	err = newBlueGreenDeployer(projectPath).ApplyDeployAs()
+	assert.IsType(t, &wskderrors.CommandError{}, err)
+
+	statePath := GetBlueGreenFilePath(projectPath)
+	state := &BlueGreenState{}
+	state.Switch("blue")
+	state.Switch("green")
+	assert.Equal(t, BlueGreenState{Live: "green", Previous: "blue"}, *state)
+	assert.Nil(t, state.Write(statePath))
+
+	deployer := newBlueGreenDeployer(projectPath)
+	assert.Nil(t, deployer.ApplyDeployAs())
+	assert.Equal(t, "blue", deployer.DeployAs)
+	assert.Equal(t, "hello-blue/greeting", deployer.Deployment.Rules["myRule"].Action)
+
+	deployer.recordBlueGreen()
+	state, err = ReadBlueGreenState(statePath)
+	assert.Nil(t, err)
+	assert.Equal(t, BlueGreenState{Live: "blue", Previous: "green"}, *state)
+}
+
+func TestServiceDeployer_applyUnDeployAs(t *testing.T) {
+	projectPath, err := ioutil.TempDir("", "wskdeploy-bluegreen")
+	assert.Nil(t, err)
+	defer os.RemoveAll(projectPath)
+	state := &BlueGreenState{Live: "green", Previous: "blue"}
+	assert.Nil(t, state.Write(filepath.Join(projectPath, BLUE_GREEN_FILE_NAME)))
+	defer func() { utils.Flags.DeployAs = "" }()
+
+	// the live entities cannot be undeployed
+	utils.Flags.DeployAs = "green"
+	err = newBlueGreenDeployer(projectPath).applyUnDeployAs()
+	assert.IsType(t, &wskderrors.CommandError{}, err)
+
+	utils.Flags.DeployAs = "blue"
+	deployer := newBlueGreenDeployer(projectPath)
+	assert.Nil(t, deployer.applyUnDeployAs())
+	assert.Contains(t, deployer.Deployment.Packages, "hello-blue")
+	assert.Contains(t, deployer.Deployment.Triggers, "locationUpdate-blue")
+	assert.Empty(t, deployer.Deployment.Rules)
+	assert.Empty(t, deployer.Deployment.Apis)
+}
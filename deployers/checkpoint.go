@@ -0,0 +1,129 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+)
+
+// name of the file (relative to the project path) which records the progress
+// of a deployment that did not complete
+const CHECKPOINT_FILE_NAME = ".wskdeploy.checkpoint"
+
+// DeploymentCheckpoint records which entities were successfully deployed,
+// keyed by entity type (package, action, trigger, ...), so that a failed
+// deployment can be resumed with "wskdeploy --resume".
+type DeploymentCheckpoint struct {
+	ManifestPath string              `json:"manifest"`
+	Deployed     map[string][]string `json:"deployed"`
+	mt           sync.RWMutex
+}
+
+func NewDeploymentCheckpoint(manifestPath string) *DeploymentCheckpoint {
+	return &DeploymentCheckpoint{
+		ManifestPath: manifestPath,
+		Deployed:     make(map[string][]string),
+	}
+}
+
+func GetCheckpointFilePath(projectPath string) string {
+	return path.Join(projectPath, CHECKPOINT_FILE_NAME)
+}
+
+// Add records the entity of the given type as deployed
+func (checkpoint *DeploymentCheckpoint) Add(entity string, name string) {
+	checkpoint.mt.Lock()
+	defer checkpoint.mt.Unlock()
+	for _, n := range checkpoint.Deployed[entity] {
+		if n == name {
+			return
+		}
+	}
+	checkpoint.Deployed[entity] = append(checkpoint.Deployed[entity], name)
+}
+
+// Entities returns a copy of the entities recorded as deployed, keyed by type
+func (checkpoint *DeploymentCheckpoint) Entities() map[string][]string {
+	checkpoint.mt.RLock()
+	defer checkpoint.mt.RUnlock()
+	entities := make(map[string][]string, len(checkpoint.Deployed))
+	for entity, names := range checkpoint.Deployed {
+		entities[entity] = append([]string{}, names...)
+	}
+	return entities
+}
+
+// Contains returns true if the entity of the given type was recorded as deployed
+func (checkpoint *DeploymentCheckpoint) Contains(entity string, name string) bool {
+	if checkpoint == nil {
+		return false
+	}
+	checkpoint.mt.RLock()
+	defer checkpoint.mt.RUnlock()
+	for _, n := range checkpoint.Deployed[entity] {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (checkpoint *DeploymentCheckpoint) IsEmpty() bool {
+	checkpoint.mt.RLock()
+	defer checkpoint.mt.RUnlock()
+	return len(checkpoint.Deployed) == 0
+}
+
+func (checkpoint *DeploymentCheckpoint) Write(filePath string) error {
+	checkpoint.mt.RLock()
+	content, err := json.MarshalIndent(checkpoint, "", "  ")
+	checkpoint.mt.RUnlock()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filePath, content, 0644)
+}
+
+func ReadCheckpoint(filePath string) (*DeploymentCheckpoint, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	checkpoint := NewDeploymentCheckpoint("")
+	if err := json.Unmarshal(content, checkpoint); err != nil {
+		return nil, wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	if checkpoint.Deployed == nil {
+		checkpoint.Deployed = make(map[string][]string)
+	}
+	return checkpoint, nil
+}
+
+func RemoveCheckpoint(filePath string) error {
+	if utils.FileExists(filePath) {
+		return os.Remove(filePath)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentCheckpoint_WriteRead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wskdeploy")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	checkpoint := NewDeploymentCheckpoint("manifest.yaml")
+	assert.True(t, checkpoint.IsEmpty())
+	checkpoint.Add(parsers.YAML_KEY_PACKAGE, "helloworld")
+	checkpoint.Add(parsers.YAML_KEY_ACTION, "helloworld/hello")
+	checkpoint.Add(parsers.YAML_KEY_ACTION, "helloworld/hello")
+
+	checkpointPath := GetCheckpointFilePath(dir)
+	assert.Nil(t, checkpoint.Write(checkpointPath))
+
+	saved, err := ReadCheckpoint(checkpointPath)
+	assert.Nil(t, err)
+	assert.Equal(t, "manifest.yaml", saved.ManifestPath)
+	assert.True(t, saved.Contains(parsers.YAML_KEY_PACKAGE, "helloworld"))
+	assert.True(t, saved.Contains(parsers.YAML_KEY_ACTION, "helloworld/hello"))
+	assert.Equal(t, 1, len(saved.Deployed[parsers.YAML_KEY_ACTION]))
+	assert.False(t, saved.Contains(parsers.YAML_KEY_TRIGGER, "helloworld/hello"))
+
+	assert.Nil(t, RemoveCheckpoint(checkpointPath))
+	_, err = ReadCheckpoint(checkpointPath)
+	assert.NotNil(t, err)
+}
+
+func TestDeploymentCheckpoint_Nil(t *testing.T) {
+	var checkpoint *DeploymentCheckpoint
+	assert.False(t, checkpoint.Contains(parsers.YAML_KEY_PACKAGE, "helloworld"))
+}
+
+func TestServiceDeployer_isCheckpointed(t *testing.T) {
+	deployer := NewServiceDeployer()
+	assert.False(t, deployer.isCheckpointed(parsers.YAML_KEY_RULE, "rule1"))
+
+	deployer.ResumeCheckpoint = NewDeploymentCheckpoint("manifest.yaml")
+	deployer.ResumeCheckpoint.Add(parsers.YAML_KEY_RULE, "rule1")
+	assert.True(t, deployer.isCheckpointed(parsers.YAML_KEY_RULE, "rule1"))
+	// skipped entities are carried over to the current checkpoint
+	assert.True(t, deployer.Checkpoint.Contains(parsers.YAML_KEY_RULE, "rule1"))
+}
+
+func TestRetry(t *testing.T) {
+	throttled := &whisk.WskError{RootErr: errors.New("Too many requests"), ExitCode: 429 - HTTP_STATUS_CODE_OFFSET}
+	notFound := &whisk.WskError{RootErr: errors.New("Not found"), ExitCode: 404 - HTTP_STATUS_CODE_OFFSET}
+	unavailable := &whisk.WskError{RootErr: errors.New("Service unavailable"), ExitCode: 503 - HTTP_STATUS_CODE_OFFSET}
+
+	assert.True(t, isRetriableError(throttled))
+	assert.True(t, isRetriableError(unavailable))
+	assert.False(t, isRetriableError(notFound))
+	assert.False(t, isRetriableError(errors.New("not a whisk error")))
+
+	// transient errors are retried until the call succeeds
+	calls := 0
+	err := retry(DEFAULT_ATTEMPTS, time.Millisecond, func() error {
+		calls++
+		if calls < 3 {
+			return throttled
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, calls)
+
+	// other errors are returned right away
+	calls = 0
+	err = retry(DEFAULT_ATTEMPTS, time.Millisecond, func() error {
+		calls++
+		return notFound
+	})
+	assert.Equal(t, notFound, err)
+	assert.Equal(t, 1, calls)
+
+	// give up after the given number of attempts
+	calls = 0
+	err = retry(2, time.Millisecond, func() error {
+		calls++
+		return unavailable
+	})
+	assert.Equal(t, unavailable, err)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// cloneDependency fetches a dependency, a variable so that tests do not reach
+// GitHub
+var cloneDependency = func(reader *utils.GitReader) error {
+	return reader.CloneDependency()
+}
+
+// fetchDependencies fetches up to n dependencies at a time while a status line
+// shows the progress. The dependencies fetched from the same archive, i.e. the
+// same repository and version, are fetched one after the other since they are
+// extracted to the same directory. No dependency is started after a failure,
+// the error of the first dependency which failed (by name) is returned once the
+// ones being fetched are done.
+func fetchDependencies(readers []*utils.GitReader, n int) error {
+	if len(readers) == 0 {
+		return nil
+	}
+	if n <= 0 {
+		n = utils.DEFAULT_PARALLEL_FETCHES
+	}
+
+	archives := make(map[string][]*utils.GitReader)
+	keys := make([]string, 0)
+	for _, reader := range readers {
+		key := reader.Url + "@" + reader.Version
+		if _, exists := archives[key]; !exists {
+			keys = append(keys, key)
+		}
+		archives[key] = append(archives[key], reader)
+	}
+	sort.Strings(keys)
+
+	status := newParallelStatus(len(readers))
+	status.messageId = wski18n.ID_MSG_DEPENDENCY_FETCH_STATUS_X_done_X_total_X_running_X
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	var mt sync.Mutex
+	errs := make(map[string]error)
+	failed := func() bool {
+		mt.Lock()
+		defer mt.Unlock()
+		return len(errs) > 0
+	}
+
+	semaphore := make(chan struct{}, n)
+	for _, key := range keys {
+		semaphore <- struct{}{}
+		if failed() {
+			<-semaphore
+			break
+		}
+		wg.Add(1)
+		go func(readers []*utils.GitReader) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			for _, reader := range readers {
+				if failed() {
+					return
+				}
+				status.start(reader.Name)
+				if err := cloneDependency(reader); err != nil {
+					mt.Lock()
+					errs[reader.Name] = wskderrors.NewYAMLFileFormatError(reader.Name, err)
+					mt.Unlock()
+				}
+				status.finish(reader.Name)
+			}
+		}(archives[key])
+	}
+	wg.Wait()
+	wskprint.ClearStatusLine()
+
+	if len(errs) > 0 {
+		names := make([]string, 0, len(errs))
+		for name := range errs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return errs[names[0]]
+	}
+	wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_DEPENDENCY_FETCHED_X_count_X_duration_X,
+		map[string]interface{}{wski18n.KEY_COUNT: len(readers),
+			wski18n.KEY_DURATION: (time.Since(start) / time.Millisecond * time.Millisecond).String()}))
+	return nil
+}
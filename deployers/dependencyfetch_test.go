@@ -0,0 +1,76 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchDependencies(t *testing.T) {
+	saved := cloneDependency
+	defer func() { cloneDependency = saved }()
+
+	var mt sync.Mutex
+	running, maxRunning := 0, 0
+	archives := make(map[string]bool)
+	cloneDependency = func(reader *utils.GitReader) error {
+		mt.Lock()
+		key := reader.Url + "@" + reader.Version
+		assert.False(t, archives[key], "an archive is extracted by one dependency at a time")
+		archives[key] = true
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		mt.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+
+		mt.Lock()
+		archives[key] = false
+		running--
+		mt.Unlock()
+		if reader.Name == "broken" {
+			return errors.New("not found")
+		}
+		return nil
+	}
+
+	readers := []*utils.GitReader{
+		{Name: "a", Url: "https://github.com/apache/a", Version: "master"},
+		{Name: "b", Url: "https://github.com/apache/b", Version: "master"},
+		{Name: "c", Url: "https://github.com/apache/c", Version: "master"},
+		{Name: "c2", Url: "https://github.com/apache/c", Version: "master"},
+		{Name: "d", Url: "https://github.com/apache/d", Version: "1.0"},
+	}
+	assert.Nil(t, fetchDependencies(readers, 2))
+	assert.Equal(t, 2, maxRunning, "at most n dependencies are fetched at a time")
+
+	readers = append(readers, &utils.GitReader{Name: "broken", Url: "https://github.com/apache/e", Version: "master"})
+	err := fetchDependencies(readers, 10)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "broken")
+}
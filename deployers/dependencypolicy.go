@@ -0,0 +1,107 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"sort"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// CheckDependencyPolicy fails if the project of a dependency, fetched from a
+// repository which may not be trusted, deploys more than packages of actions
+// and sequences to the namespace of the project:
+//   (1) its triggers, rules, APIs, plugins and hooks are only deployed with --allow-dep-side-effects
+//   (2) its entities may not be deployed to another namespace
+//   (3) its packages may not replace the packages of the project
+func (deployer *ServiceDeployer) CheckDependencyPolicy(depName string, dependency *ServiceDeployer) error {
+	violations := make([]string, 0)
+	namespaces := map[string]bool{"": true, whisk.DEFAULT_NAMESPACE: true}
+	if deployer.ClientConfig != nil {
+		namespaces[deployer.ClientConfig.Namespace] = true
+	}
+	checkNamespace := func(key string, name string, namespace string) {
+		if !namespaces[namespace] {
+			violations = append(violations, wski18n.T(wski18n.ID_MSG_DEPENDENCY_POLICY_NAMESPACE_X_key_X_name_X_namespace_X,
+				map[string]interface{}{wski18n.KEY_KEY: key, wski18n.KEY_NAME: name, wski18n.KEY_NAMESPACE: namespace}))
+		}
+	}
+	sideEffect := func(key string, name string) {
+		if !deployer.Flags.AllowDepSideEffects {
+			violations = append(violations, wski18n.T(wski18n.ID_MSG_DEPENDENCY_POLICY_SIDE_EFFECT_X_key_X_name_X,
+				map[string]interface{}{wski18n.KEY_KEY: key, wski18n.KEY_NAME: name}))
+		}
+	}
+	// the commands of hooks are run by the shell and their actions invoked
+	hookSideEffects := func(hooks *DeploymentHooks) {
+		if hooks == nil {
+			return
+		}
+		if len(hooks.PreDeploy) > 0 {
+			sideEffect(parsers.YAML_KEY_PRE_DEPLOY, hooks.Entity)
+		}
+		if len(hooks.PostDeploy) > 0 {
+			sideEffect(parsers.YAML_KEY_POST_DEPLOY, hooks.Entity)
+		}
+	}
+
+	for packName, pack := range dependency.Deployment.Packages {
+		if _, exists := deployer.Deployment.Packages[packName]; exists {
+			violations = append(violations, wski18n.T(wski18n.ID_MSG_DEPENDENCY_POLICY_PACKAGE_X_name_X,
+				map[string]interface{}{wski18n.KEY_NAME: packName}))
+		}
+		if pack.Package != nil {
+			checkNamespace(parsers.YAML_KEY_PACKAGE, packName, pack.Package.Namespace)
+		}
+		hookSideEffects(pack.Hooks)
+		for _, hooks := range pack.ActionHooks {
+			hookSideEffects(hooks)
+		}
+		for name, action := range pack.Actions {
+			checkNamespace(parsers.YAML_KEY_ACTION, name, action.Action.Namespace)
+		}
+		for name, sequence := range pack.Sequences {
+			checkNamespace(parsers.YAML_KEY_SEQUENCE, name, sequence.Action.Namespace)
+		}
+	}
+	for name, trigger := range dependency.Deployment.Triggers {
+		sideEffect(parsers.YAML_KEY_TRIGGER, name)
+		checkNamespace(parsers.YAML_KEY_TRIGGER, name, trigger.Namespace)
+	}
+	for name, rule := range dependency.Deployment.Rules {
+		sideEffect(parsers.YAML_KEY_RULE, name)
+		checkNamespace(parsers.YAML_KEY_RULE, name, rule.Namespace)
+	}
+	for name := range dependency.Deployment.Apis {
+		sideEffect(parsers.YAML_KEY_API, name)
+	}
+	// the commands of plugins are run with the auth key of the project
+	for _, extension := range dependency.Deployment.Extensions {
+		sideEffect(extension.Key, extension.entity())
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	sort.Strings(violations)
+	return wskderrors.NewDependencyPolicyError(wski18n.T(wski18n.ID_ERR_DEPENDENCY_POLICY_X_name_X,
+		map[string]interface{}{wski18n.KEY_NAME: depName}), depName, violations)
+}
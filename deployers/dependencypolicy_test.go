@@ -0,0 +1,88 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestDependency(packageName string, namespace string) *ServiceDeployer {
+	dependency := NewServiceDeployer()
+	pack := NewDeploymentPackage()
+	pack.Package = &whisk.Package{Name: packageName, Namespace: namespace}
+	pack.Actions["hello"] = utils.ActionRecord{Action: &whisk.Action{Name: "hello", Namespace: namespace}}
+	dependency.Deployment.Packages[packageName] = pack
+	return dependency
+}
+
+func TestServiceDeployer_CheckDependencyPolicy(t *testing.T) {
+	defer func(allow bool) { utils.Flags.AllowDepSideEffects = allow }(utils.Flags.AllowDepSideEffects)
+	utils.Flags.AllowDepSideEffects = false
+
+	deployer := NewServiceDeployer()
+	deployer.ClientConfig = &whisk.Config{Namespace: "guest"}
+	deployer.Deployment.Packages["myproject"] = NewDeploymentPackage()
+
+	assert.Nil(t, deployer.CheckDependencyPolicy("utils", newTestDependency("utils", "guest")))
+	assert.Nil(t, deployer.CheckDependencyPolicy("utils", newTestDependency("utils", "")))
+
+	dependency := newTestDependency("utils", "guest")
+	dependency.Deployment.Triggers["everyminute"] = &whisk.Trigger{Name: "everyminute"}
+	dependency.Deployment.Rules["hourly"] = &whisk.Rule{Name: "hourly"}
+	dependency.Deployment.Apis["hello"] = &whisk.ApiCreateRequest{}
+	dependency.Deployment.Extensions = append(dependency.Deployment.Extensions,
+		&PluginExtension{Extension: parsers.Extension{Key: "cloudant_databases", Plugin: "cloudant"}})
+	err := deployer.CheckDependencyPolicy("utils", dependency)
+	if assert.IsType(t, &wskderrors.DependencyPolicyError{}, err) {
+		assert.Equal(t, 4, len(err.(*wskderrors.DependencyPolicyError).Violations))
+		assert.Contains(t, err.Error(), "cloudant_databases [project]")
+	}
+
+	// the hooks of its packages and actions
+	hooked := newTestDependency("utils", "guest")
+	hooked.Deployment.Packages["utils"].Hooks = &DeploymentHooks{Entity: "utils",
+		PostDeploy: []parsers.Hook{{Run: "./seed.sh"}}}
+	hooked.Deployment.Packages["utils"].ActionHooks["hello"] = &DeploymentHooks{Entity: "utils/hello",
+		PreDeploy: []parsers.Hook{{Run: "make"}}, PostDeploy: []parsers.Hook{{Action: "/whisk.system/utils/echo"}}}
+	err = deployer.CheckDependencyPolicy("utils", hooked)
+	if assert.IsType(t, &wskderrors.DependencyPolicyError{}, err) {
+		assert.Equal(t, 3, len(err.(*wskderrors.DependencyPolicyError).Violations))
+		assert.Contains(t, err.Error(), "pre_deploy [utils/hello]")
+	}
+
+	utils.Flags.AllowDepSideEffects = true
+	assert.Nil(t, deployer.CheckDependencyPolicy("utils", dependency), "side effects are allowed")
+	assert.Nil(t, deployer.CheckDependencyPolicy("utils", hooked), "hooks are allowed")
+
+	// neither the namespace nor the packages of the project, even with side effects
+	err = deployer.CheckDependencyPolicy("utils", newTestDependency("utils", "tenant1"))
+	if assert.IsType(t, &wskderrors.DependencyPolicyError{}, err) {
+		assert.Equal(t, 2, len(err.(*wskderrors.DependencyPolicyError).Violations), "the package and its action")
+	}
+	err = deployer.CheckDependencyPolicy("utils", newTestDependency("myproject", "guest"))
+	assert.IsType(t, &wskderrors.DependencyPolicyError{}, err)
+	assert.Contains(t, err.Error(), "myproject")
+}
@@ -0,0 +1,234 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskenv"
+)
+
+// Keys of the values which are made available to ${deployed.<path>} references
+const (
+	DEPLOYED_KEY_PACKAGES    = "packages"
+	DEPLOYED_KEY_ACTIONS     = "actions"
+	DEPLOYED_KEY_TRIGGERS    = "triggers"
+	DEPLOYED_KEY_APIS        = "apis"
+	DEPLOYED_KEY_NAME        = "name"
+	DEPLOYED_KEY_NAMESPACE   = "namespace"
+	DEPLOYED_KEY_URL         = "url"
+	DEPLOYED_KEY_ANNOTATIONS = "annotations"
+
+	// actions deployed outside of any package are reachable through the "default" package
+	DEFAULT_WEB_PACKAGE = "default"
+)
+
+var deployedReferenceRegex = regexp.MustCompile(`\$\{(` + regexp.QuoteMeta(wskenv.DEPLOYED_REFERENCE_PREFIX) + `[^}]+)\}`)
+
+// DeployedOutputs holds values which are only known once entities are
+// deployed, e.g. the URL of a web action or annotations generated by the
+// platform. Values are indexed by their reference path, for example
+// "deployed.packages.hello.actions.world.url".
+type DeployedOutputs struct {
+	values map[string]interface{}
+	// keys of each path, names may contain dots
+	keys map[string][]string
+	mt   sync.RWMutex
+}
+
+func NewDeployedOutputs() *DeployedOutputs {
+	return &DeployedOutputs{values: make(map[string]interface{}), keys: make(map[string][]string)}
+}
+
+func (outputs *DeployedOutputs) Set(value interface{}, keys ...string) {
+	outputs.mt.Lock()
+	defer outputs.mt.Unlock()
+	path := deployedPath(keys...)
+	outputs.values[path] = value
+	outputs.keys[path] = keys
+}
+
+func (outputs *DeployedOutputs) Get(path string) (interface{}, bool) {
+	outputs.mt.RLock()
+	defer outputs.mt.RUnlock()
+	value, ok := outputs.values[path]
+	return value, ok
+}
+
+// Tree returns the deployed values nested by their keys, e.g.
+// {"packages": {"hello": {"actions": {"world": {"url": ...}}}}}
+func (outputs *DeployedOutputs) Tree() map[string]interface{} {
+	outputs.mt.RLock()
+	defer outputs.mt.RUnlock()
+	tree := make(map[string]interface{})
+	for path, value := range outputs.values {
+		keys := outputs.keys[path]
+		node := tree
+		for _, key := range keys[:len(keys)-1] {
+			child, ok := node[key].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				node[key] = child
+			}
+			node = child
+		}
+		node[keys[len(keys)-1]] = value
+	}
+	return tree
+}
+
+// WriteFile writes the deployed values as JSON, see Tree(), for the steps
+// which follow the deployment, e.g. the build of a frontend calling web actions
+func (outputs *DeployedOutputs) WriteFile(filePath string) error {
+	content, err := json.MarshalIndent(outputs.Tree(), "", "  ")
+	if err != nil {
+		return wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	if err := ioutil.WriteFile(filePath, append(content, '\n'), 0644); err != nil {
+		return wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	return nil
+}
+
+func deployedPath(keys ...string) string {
+	return wskenv.DEPLOYED_REFERENCE_PREFIX + strings.Join(keys, ".")
+}
+
+func (outputs *DeployedOutputs) AddPackage(namespace string, name string) {
+	outputs.Set("/"+namespace+"/"+name, DEPLOYED_KEY_PACKAGES, name, DEPLOYED_KEY_NAME)
+	outputs.Set(namespace, DEPLOYED_KEY_PACKAGES, name, DEPLOYED_KEY_NAMESPACE)
+}
+
+// AddAction records the outputs of an action, the action name is expected to
+// be in the form "package/action" for actions deployed in a package
+func (outputs *DeployedOutputs) AddAction(host string, namespace string, name string, annotations whisk.KeyValueArr) {
+	packageName, actionName := splitActionName(name)
+	keys := []string{DEPLOYED_KEY_PACKAGES, packageName, DEPLOYED_KEY_ACTIONS, actionName}
+	outputs.Set("/"+namespace+"/"+name, append(keys, DEPLOYED_KEY_NAME)...)
+	outputs.Set(namespace, append(keys, DEPLOYED_KEY_NAMESPACE)...)
+	outputs.Set(utils.WebActionURL(host, namespace, packageName, actionName), append(keys, DEPLOYED_KEY_URL)...)
+	for _, annotation := range annotations {
+		outputs.Set(annotation.Value, append(keys, DEPLOYED_KEY_ANNOTATIONS, annotation.Key)...)
+	}
+}
+
+// splitActionName returns the package and the name of an action in the form
+// "package/action", the package is "default" for actions outside of a package
+func splitActionName(name string) (string, string) {
+	if i := strings.Index(name, "/"); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return DEFAULT_WEB_PACKAGE, name
+}
+
+func (outputs *DeployedOutputs) AddTrigger(namespace string, name string) {
+	outputs.Set("/"+namespace+"/"+name, DEPLOYED_KEY_TRIGGERS, name, DEPLOYED_KEY_NAME)
+	outputs.Set(namespace, DEPLOYED_KEY_TRIGGERS, name, DEPLOYED_KEY_NAMESPACE)
+}
+
+func (outputs *DeployedOutputs) AddApi(name string, url string) {
+	outputs.Set(url, DEPLOYED_KEY_APIS, name, DEPLOYED_KEY_URL)
+}
+
+// HasDeployedReferences returns true if any of the parameters refers to a deployed value
+func HasDeployedReferences(params whisk.KeyValueArr) bool {
+	for _, param := range params {
+		if hasDeployedReference(param.Value) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasDeployedReference(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return deployedReferenceRegex.MatchString(v)
+	case map[string]interface{}:
+		for _, item := range v {
+			if hasDeployedReference(item) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if hasDeployedReference(item) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ResolveParameters returns a copy of the parameters in which every
+// ${deployed.<path>} reference is replaced with the deployed value, along
+// with the list of references which could not be resolved.
+func (outputs *DeployedOutputs) ResolveParameters(params whisk.KeyValueArr) (whisk.KeyValueArr, []string) {
+	resolved := make(whisk.KeyValueArr, 0, len(params))
+	unresolved := make([]string, 0)
+	for _, param := range params {
+		resolved = append(resolved, whisk.KeyValue{
+			Key:   param.Key,
+			Value: outputs.resolve(param.Value, &unresolved),
+		})
+	}
+	return resolved, unresolved
+}
+
+func (outputs *DeployedOutputs) resolve(value interface{}, unresolved *[]string) interface{} {
+	switch v := value.(type) {
+	case string:
+		// a value made of a single reference keeps the type of the deployed value
+		if m := deployedReferenceRegex.FindStringSubmatch(v); m != nil && m[0] == v {
+			if deployed, ok := outputs.Get(m[1]); ok {
+				return deployed
+			}
+			*unresolved = append(*unresolved, m[1])
+			return v
+		}
+		return deployedReferenceRegex.ReplaceAllStringFunc(v, func(reference string) string {
+			path := deployedReferenceRegex.FindStringSubmatch(reference)[1]
+			if deployed, ok := outputs.Get(path); ok {
+				return fmt.Sprint(deployed)
+			}
+			*unresolved = append(*unresolved, path)
+			return reference
+		})
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[key] = outputs.resolve(item, unresolved)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			s = append(s, outputs.resolve(item, unresolved))
+		}
+		return s
+	}
+	return value
+}
@@ -0,0 +1,128 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeployedOutputs_ResolveParameters(t *testing.T) {
+	outputs := NewDeployedOutputs()
+	outputs.AddPackage("guest", "hello")
+	outputs.AddAction("openwhisk.example.com", "guest", "hello/world",
+		whisk.KeyValueArr{{Key: "require-whisk-auth", Value: 1234}})
+	outputs.AddAction("https://openwhisk.example.com/", "guest", "standalone", nil)
+	outputs.AddTrigger("guest", "everyMinute")
+
+	params := whisk.KeyValueArr{
+		{Key: "url", Value: "${deployed.packages.hello.actions.world.url}"},
+		{Key: "token", Value: "${deployed.packages.hello.actions.world.annotations.require-whisk-auth}"},
+		{Key: "message", Value: "call ${deployed.packages.default.actions.standalone.url} from ${deployed.packages.hello.name}"},
+		{Key: "nested", Value: map[string]interface{}{"trigger": "${deployed.triggers.everyMinute.name}"}},
+		{Key: "plain", Value: 42},
+	}
+	assert.True(t, HasDeployedReferences(params))
+
+	resolved, unresolved := outputs.ResolveParameters(params)
+	assert.Equal(t, 0, len(unresolved))
+	assert.Equal(t, "https://openwhisk.example.com/api/v1/web/guest/hello/world", resolved[0].Value)
+	assert.Equal(t, 1234, resolved[1].Value, "A single reference should keep the type of the deployed value.")
+	assert.Equal(t, "call https://openwhisk.example.com/api/v1/web/guest/default/standalone from /guest/hello", resolved[2].Value)
+	assert.Equal(t, map[string]interface{}{"trigger": "/guest/everyMinute"}, resolved[3].Value)
+	assert.Equal(t, 42, resolved[4].Value)
+	assert.False(t, HasDeployedReferences(resolved))
+
+	// the original parameters are left untouched
+	assert.Equal(t, "${deployed.packages.hello.actions.world.url}", params[0].Value)
+}
+
+func TestDeployedOutputs_Unresolved(t *testing.T) {
+	outputs := NewDeployedOutputs()
+	params := whisk.KeyValueArr{
+		{Key: "url", Value: "${deployed.packages.missing.actions.world.url}"},
+		{Key: "env", Value: "${HOME}"},
+	}
+	assert.True(t, HasDeployedReferences(params))
+	assert.False(t, HasDeployedReferences(params[1:]))
+
+	resolved, unresolved := outputs.ResolveParameters(params)
+	assert.Equal(t, []string{"deployed.packages.missing.actions.world.url"}, unresolved)
+	assert.Equal(t, "${deployed.packages.missing.actions.world.url}", resolved[0].Value)
+}
+
+func TestDeployedOutputs_WriteFile(t *testing.T) {
+	outputs := NewDeployedOutputs()
+	outputs.AddAction("openwhisk.example.com", "guest", "hello/world",
+		whisk.KeyValueArr{{Key: "require-whisk-auth", Value: "s3cr3t"}})
+	outputs.AddTrigger("guest", "every.minute")
+	outputs.AddApi("book-club", "https://openwhisk.example.com/api/1234/club")
+
+	dir, err := ioutil.TempDir("", "outputs")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	outputsFile := filepath.Join(dir, "outputs.json")
+	assert.Nil(t, outputs.WriteFile(outputsFile))
+
+	content, err := ioutil.ReadFile(outputsFile)
+	assert.Nil(t, err)
+	var tree map[string]interface{}
+	assert.Nil(t, json.Unmarshal(content, &tree))
+	assert.Equal(t, map[string]interface{}{
+		"packages": map[string]interface{}{
+			"hello": map[string]interface{}{
+				"actions": map[string]interface{}{
+					"world": map[string]interface{}{
+						"name":        "/guest/hello/world",
+						"namespace":   "guest",
+						"url":         "https://openwhisk.example.com/api/v1/web/guest/hello/world",
+						"annotations": map[string]interface{}{"require-whisk-auth": "s3cr3t"},
+					},
+				},
+			},
+		},
+		// names may contain dots
+		"triggers": map[string]interface{}{
+			"every.minute": map[string]interface{}{"name": "/guest/every.minute", "namespace": "guest"},
+		},
+		"apis": map[string]interface{}{
+			"book-club": map[string]interface{}{"url": "https://openwhisk.example.com/api/1234/club"},
+		},
+	}, tree)
+}
+
+func TestServiceDeployer_resolveDeployedReferences(t *testing.T) {
+	deployer := NewServiceDeployer()
+	deployer.DeployedOutputs.AddTrigger("guest", "everyMinute")
+	params := whisk.KeyValueArr{{Key: "trigger", Value: "${deployed.triggers.everyMinute.name}"}}
+	resolved, err := deployer.resolveDeployedReferences("action", "hello/world", params)
+	assert.Nil(t, err)
+	assert.Equal(t, "/guest/everyMinute", resolved[0].Value)
+
+	params = whisk.KeyValueArr{{Key: "trigger", Value: "${deployed.triggers.hourly.name}"}}
+	_, err = deployer.resolveDeployedReferences("action", "hello/world", params)
+	assert.NotNil(t, err)
+}
@@ -18,13 +18,12 @@
 package deployers
 
 import (
-	"errors"
 	"github.com/apache/incubator-openwhisk-client-go/whisk"
 	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
-	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
-	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wskenv"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
 )
 
 type DeploymentReader struct {
@@ -45,6 +44,7 @@ func (reader *DeploymentReader) HandleYaml() error {
 	dep := reader.serviceDeployer
 
 	deploymentParser := parsers.NewYAMLParser()
+	deploymentParser.Flags = dep.Flags
 	deployment, err := deploymentParser.ParseDeployment(dep.DeploymentPath)
 
 	reader.DeploymentDescriptor = deployment
@@ -61,282 +61,250 @@ func (reader *DeploymentReader) BindAssets() error {
 	if err := reader.bindActionInputsAndAnnotations(); err != nil {
 		return err
 	}
+	if err := reader.bindSequenceInputsAndAnnotations(); err != nil {
+		return err
+	}
 	if err := reader.bindTriggerInputsAndAnnotations(); err != nil {
 		return err
 	}
+	if err := reader.bindRules(); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// bindPackageInputsAndAnnotations binds the inputs of the project of the
+// deployment file to every package, then the inputs and annotations of its
+// packages, which take precedence
 func (reader *DeploymentReader) bindPackageInputsAndAnnotations() error {
+	project := reader.DeploymentDescriptor.GetProject()
+	if project.Packages == nil && len(project.Package.Packagename) != 0 {
+		// a single package is specified in deployment YAML file with "package" key
+		parsers.Deprecations.Add(parsers.DeprecatedKey{FilePath: reader.serviceDeployer.DeploymentPath, FileType: parsers.FILE_TYPE_DEPLOYMENT,
+			OldKey: parsers.YAML_KEY_PACKAGE, NewKey: parsers.YAML_KEY_PACKAGES})
+	}
+	packMap := reader.deploymentPackages()
 
-	packMap := make(map[string]parsers.Package)
+	for packName := range packMap {
+		// packages left out with --packages or --exclude-package
+		if reader.serviceDeployer.Flags.IsPackageSelected(packName) && reader.serviceDeployer.Deployment.Packages[packName] == nil {
+			reader.warnEntityNotInManifest(parsers.YAML_KEY_PACKAGE, packName)
+		}
+	}
 
-	if reader.DeploymentDescriptor.GetProject().Packages == nil {
-		// a single package is specified in deployment YAML file with "package" key
-		if len(reader.DeploymentDescriptor.GetProject().Package.Packagename) != 0 {
-			packMap[reader.DeploymentDescriptor.GetProject().Package.Packagename] = reader.DeploymentDescriptor.GetProject().Package
-			// TODO() i18n
-			wskprint.PrintlnOpenWhiskWarning("The package YAML key in deployment file will soon be deprecated. Please use packages instead as described in specifications.")
-		} else {
-			if reader.DeploymentDescriptor.Packages != nil {
-				for packName, depPacks := range reader.DeploymentDescriptor.Packages {
-					depPacks.Packagename = packName
-					packMap[packName] = depPacks
-				}
-			} else {
-				packMap[reader.DeploymentDescriptor.Package.Packagename] = reader.DeploymentDescriptor.Package
+	for packName, serviceDeployPack := range reader.serviceDeployer.Deployment.Packages {
+		// packages left out with --packages or --exclude-package
+		if !reader.serviceDeployer.Flags.IsPackageSelected(packName) {
+			continue
+		}
+		layers := []binding{{key: parsers.YAML_KEY_PROJECT, name: project.Name, inputs: project.Inputs}}
+		if pack, exists := packMap[packName]; exists {
+			// a namespace set in the deployment file overrides the one in the manifest
+			if len(pack.Namespace) > 0 {
+				serviceDeployPack.Package.Namespace = pack.Namespace
 			}
+			layers = append(layers, binding{key: parsers.YAML_KEY_PACKAGE, name: packName, inputs: pack.Inputs,
+				annotations: pack.Annotations, delInputs: pack.DelInputs, delAnnotations: pack.DelAnnotations})
 		}
-	} else {
-		for packName, depPacks := range reader.DeploymentDescriptor.GetProject().Packages {
-			depPacks.Packagename = packName
-			packMap[packName] = depPacks
+		wskPackage := serviceDeployPack.Package
+		if err := reader.mergeBindings(bindable{&wskPackage.Parameters, &wskPackage.Annotations}, layers...); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	for packName, pack := range packMap {
-
+func (reader *DeploymentReader) bindActionInputsAndAnnotations() error {
+	for packName, pack := range reader.deploymentPackages() {
+		// packages left out with --packages or --exclude-package
+		if !reader.serviceDeployer.Flags.IsPackageSelected(packName) {
+			continue
+		}
 		serviceDeployPack := reader.serviceDeployer.Deployment.Packages[packName]
-
 		if serviceDeployPack == nil {
-			// TODO() i18n
-			wskprint.PrintlnOpenWhiskWarning("Package name in deployment file " + packName + " does not match with manifest file.")
-			break
+			continue
 		}
 
-		keyValArr := make(whisk.KeyValueArr, 0)
-
-		if len(pack.Inputs) > 0 {
-			for name, input := range pack.Inputs {
-				var keyVal whisk.KeyValue
-
-				keyVal.Key = name
-
-				keyVal.Value = wskenv.GetEnvVar(input.Value)
-
-				keyValArr = append(keyValArr, keyVal)
+		for actionName, action := range pack.Actions {
+			record, exists := serviceDeployPack.Actions[actionName]
+			if !exists {
+				reader.warnEntityNotInManifest(parsers.YAML_KEY_ACTION, actionName)
+				continue
 			}
-
-			depParams := make(map[string]whisk.KeyValue)
-			for _, kv := range keyValArr {
-				depParams[kv.Key] = kv
+			layer := binding{key: parsers.YAML_KEY_ACTION, name: actionName, inputs: action.Inputs,
+				annotations: action.Annotations, delInputs: action.DelInputs, delAnnotations: action.DelAnnotations}
+			// the annotations left out are removed from the deployed action as well
+			layer.hook = func() error {
+				record.DelAnnotations = append(record.DelAnnotations, layer.delAnnotations...)
+				serviceDeployPack.Actions[actionName] = record
+				return nil
 			}
-
-			for _, keyVal := range serviceDeployPack.Package.Parameters {
-				if _, exists := depParams[keyVal.Key]; !exists {
-					keyValArr = append(keyValArr, keyVal)
-				}
-			}
-
-			serviceDeployPack.Package.Parameters = keyValArr
-		}
-
-		if len(pack.Annotations) > 0 {
-			// iterate over each annotation from deployment file
-			for name, input := range pack.Annotations {
-				// check if annotation key in deployment file exists in manifest file
-				// setting a bool flag to false assuming key does not exist in manifest
-				keyExistsInManifest := false
-				// iterate over each annotation from manifest file
-				for i, a := range serviceDeployPack.Package.Annotations {
-					if name == a.Key {
-						// annotation key is found in manifest
-						keyExistsInManifest = true
-						// overwrite annotation in manifest file with deployment file
-						serviceDeployPack.Package.Annotations[i].Value = input
-						break
-					}
-				}
-				if !keyExistsInManifest {
-					// TODO() i18n, need to use an ID
-					// TODO() fix grammar error; need command before "but"
-					err := errors.New(wski18n.T("Annotation key \"" + name + "\" does not exist in manifest file but specified in deployment file."))
-					return wskderrors.NewYAMLFileFormatError(reader.DeploymentDescriptor.Filepath, err)
-				}
+			wskAction := record.Action
+			if err := reader.mergeBindings(bindable{&wskAction.Parameters, &wskAction.Annotations}, layer); err != nil {
+				return err
 			}
 		}
 	}
 	return nil
 }
 
-func (reader *DeploymentReader) bindActionInputsAndAnnotations() error {
-
-	packMap := make(map[string]parsers.Package)
-
-	if reader.DeploymentDescriptor.GetProject().Packages == nil {
-		// a single package is specified in deployment YAML file with "package" key
-		if len(reader.DeploymentDescriptor.GetProject().Package.Packagename) != 0 {
-			packMap[reader.DeploymentDescriptor.GetProject().Package.Packagename] = reader.DeploymentDescriptor.GetProject().Package
-		} else {
-			if reader.DeploymentDescriptor.Packages != nil {
-				for packName, depPacks := range reader.DeploymentDescriptor.Packages {
-					depPacks.Packagename = packName
-					packMap[packName] = depPacks
-				}
-			} else {
-				packMap[reader.DeploymentDescriptor.Package.Packagename] = reader.DeploymentDescriptor.Package
-			}
+func (reader *DeploymentReader) bindSequenceInputsAndAnnotations() error {
+	for packName, pack := range reader.deploymentPackages() {
+		// packages left out with --packages or --exclude-package
+		if !reader.serviceDeployer.Flags.IsPackageSelected(packName) {
+			continue
 		}
-	} else {
-		for packName, depPacks := range reader.DeploymentDescriptor.GetProject().Packages {
-			depPacks.Packagename = packName
-			packMap[packName] = depPacks
-		}
-	}
-
-	for packName, pack := range packMap {
-
 		serviceDeployPack := reader.serviceDeployer.Deployment.Packages[packName]
-
 		if serviceDeployPack == nil {
-			break
+			continue
 		}
 
-		for actionName, action := range pack.Actions {
-
-			keyValArr := make(whisk.KeyValueArr, 0)
-
-			if len(action.Inputs) > 0 {
-				for name, input := range action.Inputs {
-					var keyVal whisk.KeyValue
-
-					keyVal.Key = name
-
-					keyVal.Value = wskenv.GetEnvVar(input.Value)
-
-					keyValArr = append(keyValArr, keyVal)
-				}
-
-				if wskAction, exists := serviceDeployPack.Actions[actionName]; exists {
-					depParams := make(map[string]whisk.KeyValue)
-					for _, kv := range keyValArr {
-						depParams[kv.Key] = kv
-					}
-
-					for _, keyVal := range wskAction.Action.Parameters {
-						if _, exists := depParams[keyVal.Key]; !exists {
-							keyValArr = append(keyValArr, keyVal)
-						}
-					}
-					wskAction.Action.Parameters = keyValArr
-				}
+		for sequenceName, sequence := range pack.Sequences {
+			record, exists := serviceDeployPack.Sequences[sequenceName]
+			if !exists {
+				return reader.entityNotInManifestError(parsers.YAML_KEY_SEQUENCE, sequenceName)
 			}
-
-			if wskAction, exists := serviceDeployPack.Actions[actionName]; exists {
-				// iterate over each annotation from deployment file
-				for name, input := range action.Annotations {
-					// check if annotation key in deployment file exists in manifest file
-					// setting a bool flag to false assuming key does not exist in manifest
-					keyExistsInManifest := false
-					// iterate over each annotation from manifest file
-					for i, a := range wskAction.Action.Annotations {
-						if name == a.Key {
-							// annotation key is found in manifest
-							keyExistsInManifest = true
-							// overwrite annotation in manifest file with deployment file
-							wskAction.Action.Annotations[i].Value = input
-							break
-						}
-					}
-					if !keyExistsInManifest {
-						// TODO() i18n, need to use an ID
-						err := errors.New(wski18n.T("Annotation key \"" + name + "\" does not exist in manifest file but specified in deployment file."))
-						return wskderrors.NewYAMLFileFormatError(reader.DeploymentDescriptor.Filepath, err)
+			layer := binding{key: parsers.YAML_KEY_SEQUENCE, name: sequenceName, inputs: sequence.Inputs,
+				annotations: sequence.Annotations, delInputs: sequence.DelInputs, delAnnotations: sequence.DelAnnotations}
+			wskAction := record.Action
+			// e.g. a sequence exported to the web in a single environment
+			if len(sequence.Web) > 0 {
+				layer.hook = func() error {
+					annotations, err := parsers.WebSequenceAnnotations(reader.DeploymentDescriptor.Filepath,
+						sequenceName, sequence.Web, wskAction.Annotations)
+					if err == nil {
+						wskAction.Annotations = annotations
 					}
+					return err
 				}
 			}
+			if err := reader.mergeBindings(bindable{&wskAction.Parameters, &wskAction.Annotations}, layer); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
 func (reader *DeploymentReader) bindTriggerInputsAndAnnotations() error {
+	for _, pack := range reader.deploymentPackages() {
+		for triggerName, trigger := range pack.Triggers {
 
-	packMap := make(map[string]parsers.Package)
-
-	if reader.DeploymentDescriptor.GetProject().Packages == nil {
-		if len(reader.DeploymentDescriptor.GetProject().Package.Packagename) != 0 {
-			packMap[reader.DeploymentDescriptor.GetProject().Package.Packagename] = reader.DeploymentDescriptor.GetProject().Package
-		} else {
-			if reader.DeploymentDescriptor.Packages != nil {
-				for packName, depPacks := range reader.DeploymentDescriptor.Packages {
-					depPacks.Packagename = packName
-					packMap[packName] = depPacks
+			if len(trigger.Feed) > 0 {
+				if err := reader.bindTriggerFeed(triggerName, trigger.Feed); err != nil {
+					return err
 				}
-			} else {
-				packMap[reader.DeploymentDescriptor.Package.Packagename] = reader.DeploymentDescriptor.Package
 			}
-		}
-	} else {
-		for packName, depPacks := range reader.DeploymentDescriptor.GetProject().Packages {
-			depPacks.Packagename = packName
-			packMap[packName] = depPacks
+
+			wskTrigger, exists := reader.serviceDeployer.Deployment.Triggers[triggerName]
+			if !exists {
+				reader.warnEntityNotInManifest(parsers.YAML_KEY_TRIGGER, triggerName)
+				continue
+			}
+			layer := binding{key: parsers.YAML_KEY_TRIGGER, name: triggerName, inputs: trigger.Inputs,
+				annotations: trigger.Annotations, delInputs: trigger.DelInputs, delAnnotations: trigger.DelAnnotations}
+			if err := reader.mergeBindings(bindable{&wskTrigger.Parameters, &wskTrigger.Annotations}, layer); err != nil {
+				return err
+			}
 		}
 	}
+	return nil
+}
 
-	for _, pack := range packMap {
-
-		serviceDeployment := reader.serviceDeployer.Deployment
-
-		for triggerName, trigger := range pack.Triggers {
-
-			keyValArr := make(whisk.KeyValueArr, 0)
-
-			if len(trigger.Inputs) > 0 {
-				for name, input := range trigger.Inputs {
-					var keyVal whisk.KeyValue
-
-					keyVal.Key = name
-					keyVal.Value = wskenv.GetEnvVar(input.Value)
-
-					keyValArr = append(keyValArr, keyVal)
-				}
-
-				if wskTrigger, exists := serviceDeployment.Triggers[triggerName]; exists {
-
-					depParams := make(map[string]whisk.KeyValue)
-					for _, kv := range keyValArr {
-						depParams[kv.Key] = kv
-					}
+// bindTriggerFeed replaces the feed of a trigger of the manifest, e.g. to use
+// another instance of the alarms package per environment
+func (reader *DeploymentReader) bindTriggerFeed(triggerName string, feed string) error {
+	wskTrigger, exists := reader.serviceDeployer.Deployment.Triggers[triggerName]
+	if !exists {
+		return reader.entityNotInManifestError(parsers.YAML_KEY_TRIGGER, triggerName)
+	}
+	feed = wskenv.ConvertSingleName(feed)
+	for i, a := range wskTrigger.Annotations {
+		if a.Key == parsers.YAML_KEY_FEED {
+			wskTrigger.Annotations[i].Value = feed
+			return nil
+		}
+	}
+	// a trigger of the manifest without a feed becomes a feed trigger
+	wskTrigger.Annotations = append(whisk.KeyValueArr{{Key: parsers.YAML_KEY_FEED, Value: feed}}, wskTrigger.Annotations...)
+	return nil
+}
 
-					for _, keyVal := range wskTrigger.Parameters {
-						// TODO() i18n
-						wskprint.PrintlnOpenWhiskOutput("Checking key " + keyVal.Key)
-						if _, exists := depParams[keyVal.Key]; !exists {
-							keyValArr = append(keyValArr, keyVal)
-						}
-					}
-					wskTrigger.Parameters = keyValArr
-				}
+// bindRules binds the annotations and the status of the rules given by the
+// deployment file, e.g. to deploy a rule inactive in an environment, the
+// status of the other rules is left as it is
+func (reader *DeploymentReader) bindRules() error {
+	for _, pack := range reader.deploymentPackages() {
+		for ruleName, rule := range pack.Rules {
+			if len(rule.Status) == 0 && len(rule.Annotations) == 0 && len(rule.DelAnnotations) == 0 {
+				continue
 			}
-
-			if wskTrigger, exists := serviceDeployment.Triggers[triggerName]; exists {
-				// iterate over each annotation from deployment file
-				for name, input := range trigger.Annotations {
-					// check if annotation key in deployment file exists in manifest file
-					// setting a bool flag to false assuming key does not exist in manifest
-					keyExistsInManifest := false
-					// iterate over each annotation from manifest file
-					for i, a := range wskTrigger.Annotations {
-						if name == a.Key {
-							// annotation key is found in manifest
-							keyExistsInManifest = true
-							// overwrite annotation in manifest file with deployment file
-							wskTrigger.Annotations[i].Value = input
-							break
-						}
-					}
-					if !keyExistsInManifest {
-						// TODO() i18n, need to use an ID
-						err := errors.New(wski18n.T("Annotation key \"" + name + "\" does not exist in manifest file but specified in deployment file."))
-						return wskderrors.NewYAMLFileFormatError(reader.DeploymentDescriptor.Filepath, err)
-					}
-				}
+			wskRule, exists := reader.serviceDeployer.Deployment.Rules[ruleName]
+			if !exists {
+				return reader.entityNotInManifestError(parsers.YAML_KEY_RULE, ruleName)
+			}
+			layer := binding{key: parsers.YAML_KEY_RULE, name: ruleName,
+				annotations: rule.Annotations, delAnnotations: rule.DelAnnotations}
+			layer.hook = func() error {
+				return reader.bindRuleStatus(wskRule, rule.Status)
+			}
+			if err := reader.mergeBindings(bindable{annotations: &wskRule.Annotations}, layer); err != nil {
+				return err
 			}
-
 		}
+	}
+	return nil
+}
 
+// bindRuleStatus sets the status of a rule given by the deployment file
+func (reader *DeploymentReader) bindRuleStatus(wskRule *whisk.Rule, ruleStatus string) error {
+	if len(ruleStatus) == 0 {
+		return nil
+	}
+	status := wskenv.ConvertSingleName(ruleStatus)
+	if status != parsers.YAML_VALUE_RULE_ACTIVE && status != parsers.YAML_VALUE_RULE_INACTIVE {
+		return wskderrors.NewYAMLFileFormatError(reader.DeploymentDescriptor.Filepath,
+			wski18n.T(wski18n.ID_ERR_RULE_STATUS_INVALID_X_name_X_value_X,
+				map[string]interface{}{wski18n.KEY_NAME: wskRule.Name, wski18n.KEY_VALUE: status}))
 	}
+	wskRule.Status = status
 	return nil
 }
+
+// warnEntityNotInManifest warns of an entity of the deployment file which is
+// not defined in the manifest, it is skipped as the packages are
+func (reader *DeploymentReader) warnEntityNotInManifest(key string, name string) {
+	wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_DEPLOYMENT_ENTITY_NOT_IN_MANIFEST_X_key_X_name_X,
+		map[string]interface{}{wski18n.KEY_KEY: key, wski18n.KEY_NAME: name}))
+}
+
+// entityNotInManifestError reports an entity of the deployment file which is
+// not defined in the manifest, its inputs would not be bound otherwise
+func (reader *DeploymentReader) entityNotInManifestError(key string, name string) error {
+	return wskderrors.NewYAMLFileFormatError(reader.DeploymentDescriptor.Filepath,
+		wski18n.T(wski18n.ID_ERR_DEPLOYMENT_ENTITY_NOT_IN_MANIFEST_X_key_X_name_X,
+			map[string]interface{}{wski18n.KEY_KEY: key, wski18n.KEY_NAME: name}))
+}
+
+// deploymentPackages returns the packages of the deployment file by name
+func (reader *DeploymentReader) deploymentPackages() map[string]parsers.Package {
+	packMap := make(map[string]parsers.Package)
+	project := reader.DeploymentDescriptor.GetProject()
+	if project.Packages != nil {
+		for packName, depPacks := range project.Packages {
+			depPacks.Packagename = packName
+			packMap[packName] = depPacks
+		}
+	} else if len(project.Package.Packagename) != 0 {
+		packMap[project.Package.Packagename] = project.Package
+	} else if reader.DeploymentDescriptor.Packages != nil {
+		for packName, depPacks := range reader.DeploymentDescriptor.Packages {
+			depPacks.Packagename = packName
+			packMap[packName] = depPacks
+		}
+	} else {
+		packMap[reader.DeploymentDescriptor.Package.Packagename] = reader.DeploymentDescriptor.Package
+	}
+	return packMap
+}
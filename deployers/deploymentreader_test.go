@@ -21,6 +21,8 @@ package deployers
 
 import (
 	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"reflect"
@@ -210,3 +212,117 @@ func TestDeploymentReader_BindAssets_ActionAnnotations(t *testing.T) {
 	assert.True(t, eq, "Expected list of annotations does not match with actual list, expected annotations: %v actual annotations: %v", expected_annotations, actual_annotations)
 }
 
+
+func TestDeploymentReader_BindAssets_InputMerge(t *testing.T) {
+	sDeployer := NewServiceDeployer()
+	sDeployer.DeploymentPath = "../tests/dat/deployment_validate_input_merge.yaml"
+	pack := NewDeploymentPackage()
+	pack.Package = &whisk.Package{Name: "hello",
+		Parameters: whisk.KeyValueArr{{Key: "hosts", Value: []interface{}{"a.example.com", "b.example.com"}}}}
+	pack.Actions["world"] = utils.ActionRecord{Action: &whisk.Action{Name: "world",
+		Parameters: whisk.KeyValueArr{
+			{Key: "origins", Value: []interface{}{"https://example.com"}},
+			{Key: "tags", Value: []interface{}{"prod", "eu"}}}}}
+	sDeployer.Deployment.Packages["hello"] = pack
+
+	dReader := NewDeploymentReader(sDeployer)
+	assert.Nil(t, dReader.HandleYaml())
+	assert.Nil(t, dReader.bindPackageInputsAndAnnotations())
+	assert.Nil(t, dReader.bindActionInputsAndAnnotations())
+
+	params := func(kvs whisk.KeyValueArr) map[string]interface{} {
+		values := make(map[string]interface{})
+		for _, kv := range kvs {
+			values[kv.Key] = kv.Value
+		}
+		return values
+	}
+	assert.Equal(t, []interface{}{"a.example.com", "b.example.com", "c.example.com"},
+		params(pack.Package.Parameters)["hosts"], "unique-union")
+	actionParams := params(pack.Actions["world"].Action.Parameters)
+	assert.Equal(t, []interface{}{"https://example.com", "https://dev.example.com"}, actionParams["origins"], "append")
+	assert.Equal(t, []interface{}{"dev"}, actionParams["tags"], "replace")
+}
+
+func TestDeploymentReader_BindAssets_RuleStatusAndFeed(t *testing.T) {
+	sDeployer := NewServiceDeployer()
+	sDeployer.DeploymentPath = "../tests/dat/deployment_validate_rule_status_feed.yaml"
+	sDeployer.Deployment.Packages["alarms"] = &DeploymentPackage{Package: &whisk.Package{Name: "alarms"}}
+	sDeployer.Deployment.Triggers["everyMinute"] = &whisk.Trigger{Name: "everyMinute",
+		Annotations: whisk.KeyValueArr{{Key: "feed", Value: "/whisk.system/alarms/alarm"}}}
+	sDeployer.Deployment.Triggers["everyHour"] = &whisk.Trigger{Name: "everyHour"}
+	sDeployer.Deployment.Rules["minuteRule"] = &whisk.Rule{Name: "minuteRule"}
+	sDeployer.Deployment.Rules["hourRule"] = &whisk.Rule{Name: "hourRule"}
+
+	dReader := NewDeploymentReader(sDeployer)
+	assert.Nil(t, dReader.HandleYaml())
+	assert.Nil(t, dReader.BindAssets())
+
+	assert.Equal(t, "inactive", sDeployer.Deployment.Rules["minuteRule"].Status)
+	assert.Equal(t, "active", sDeployer.Deployment.Rules["hourRule"].Status)
+	assert.Equal(t, whisk.KeyValueArr{{Key: "feed", Value: "/whisk.system/alarms/alarm"}},
+		sDeployer.Deployment.Triggers["everyMinute"].Annotations)
+	assert.Equal(t, "*/5 * * * *", sDeployer.Deployment.Triggers["everyMinute"].Parameters.GetValue("cron"))
+	assert.Equal(t, whisk.KeyValueArr{{Key: "feed", Value: "/staging/alarms/alarm"}},
+		sDeployer.Deployment.Triggers["everyHour"].Annotations, "the trigger becomes a feed trigger")
+
+	// the rules of the deployment file must be defined in the manifest
+	delete(sDeployer.Deployment.Rules, "hourRule")
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, dReader.BindAssets())
+}
+
+func TestDeploymentReader_BindAssets_Bindings(t *testing.T) {
+	sDeployer := NewServiceDeployer()
+	sDeployer.DeploymentPath = "../tests/dat/deployment_validate_bindings.yaml"
+	pack := NewDeploymentPackage()
+	pack.Package = &whisk.Package{Name: "hello"}
+	pack.Actions["world"] = utils.ActionRecord{Action: &whisk.Action{Name: "world",
+		Parameters:  whisk.KeyValueArr{{Key: "debug", Value: true}},
+		Annotations: whisk.KeyValueArr{{Key: "description", Value: "hello"}}}}
+	pack.Sequences["greeting"] = utils.ActionRecord{Action: &whisk.Action{Name: "greeting",
+		Annotations: whisk.KeyValueArr{{Key: "final", Value: true}}}}
+	pack.Sequences["farewell"] = utils.ActionRecord{Action: &whisk.Action{Name: "farewell"}}
+	sDeployer.Deployment.Packages["hello"] = pack
+	sDeployer.Deployment.Rules["greetingRule"] = &whisk.Rule{Name: "greetingRule",
+		Annotations: whisk.KeyValueArr{{Key: "owner", Value: "dev"}}}
+
+	dReader := NewDeploymentReader(sDeployer)
+	assert.Nil(t, dReader.HandleYaml())
+	assert.Nil(t, dReader.BindAssets())
+
+	assert.Equal(t, whisk.KeyValueArr{{Key: "region", Value: "eu"}}, pack.Package.Parameters, "project inputs")
+	world := pack.Actions["world"]
+	assert.Empty(t, world.Action.Parameters)
+	assert.Empty(t, world.Action.Annotations)
+	assert.Equal(t, []string{"description"}, world.DelAnnotations, "removed from the deployed action")
+	greeting := pack.Sequences["greeting"].Action
+	assert.Equal(t, whisk.KeyValueArr{{Key: "name", Value: "Bernie"}}, greeting.Parameters)
+	assert.Equal(t, whisk.KeyValueArr{{Key: "final", Value: false}}, greeting.Annotations)
+	assert.True(t, isWebAction(pack.Sequences["farewell"].Action.Annotations), "web sequence")
+	assert.Equal(t, whisk.KeyValueArr{{Key: "owner", Value: "ops"}}, sDeployer.Deployment.Rules["greetingRule"].Annotations)
+}
+
+// the actions and triggers of the deployment file which are not defined in
+// the manifest are skipped with a warning
+func TestDeploymentReader_BindAssets_NotInManifest(t *testing.T) {
+	sDeployer := NewServiceDeployer()
+	sDeployer.DeploymentPath = "../tests/dat/deployment_validate_bindings.yaml"
+	pack := NewDeploymentPackage()
+	pack.Package = &whisk.Package{Name: "hello"}
+	sDeployer.Deployment.Packages["hello"] = pack
+
+	dReader := NewDeploymentReader(sDeployer)
+	assert.Nil(t, dReader.HandleYaml())
+	assert.Nil(t, dReader.bindActionInputsAndAnnotations(), "action")
+	assert.Equal(t, 0, len(pack.Actions))
+
+	sDeployer = NewServiceDeployer()
+	sDeployer.DeploymentPath = "../tests/dat/deployment_validate_trigger_inputs.yaml"
+	dReader = NewDeploymentReader(sDeployer)
+	assert.Nil(t, dReader.HandleYaml())
+	assert.Nil(t, dReader.bindTriggerInputsAndAnnotations(), "trigger")
+	assert.Equal(t, 0, len(sDeployer.Deployment.Triggers))
+	sDeployer.Deployment.Triggers["everyMinute"] = &whisk.Trigger{Name: "everyMinute"}
+	assert.Nil(t, dReader.bindTriggerInputsAndAnnotations())
+	assert.Equal(t, "*/5 * * * *", sDeployer.Deployment.Triggers["everyMinute"].Parameters.GetValue("cron"))
+}
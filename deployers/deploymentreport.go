@@ -0,0 +1,176 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskenv"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// DeploymentReport is the data a --report-template is rendered with once a
+// project is deployed or undeployed
+type DeploymentReport struct {
+	Event     string // deploy or undeploy
+	Status    string // success or failure
+	Error     string
+	Project   string
+	ApiHost   string
+	Namespace string
+	Timestamp time.Time
+	// entities composed from the manifest and deployment files
+	Plan *DeploymentProject
+	// entities deployed (or undeployed) keyed by type, e.g. "action": ["hello/world"]
+	Entities map[string][]string
+	// entities which failed when the deployment continues on errors
+	Failures []string
+
+	outputs *DeployedOutputs
+}
+
+// NewDeploymentReport creates the report of the (un)deployment of the plan, a
+// failure if err is not nil
+func (deployer *ServiceDeployer) NewDeploymentReport(event string, plan *DeploymentProject, entities map[string][]string, err error) *DeploymentReport {
+	report := &DeploymentReport{
+		Event:     event,
+		Status:    NOTIFICATION_STATUS_SUCCESS,
+		Project:   deployer.ProjectName,
+		Timestamp: time.Now().UTC(),
+		Plan:      plan,
+		Entities:  entities,
+		Failures:  deployer.Failures.List(),
+		outputs:   deployer.DeployedOutputs,
+	}
+	if err != nil {
+		report.Status = NOTIFICATION_STATUS_FAILURE
+		report.Error = err.Error()
+	}
+	if deployer.ClientConfig != nil {
+		report.ApiHost = deployer.ClientConfig.Host
+		report.Namespace = deployer.ClientConfig.Namespace
+	}
+	return report
+}
+
+// Deployed returns a value known once entities are deployed, the path is the
+// one of ${deployed.<path>} references, e.g. "packages.hello.actions.world.url"
+func (report *DeploymentReport) Deployed(path string) interface{} {
+	if report.outputs == nil {
+		return nil
+	}
+	if !strings.HasPrefix(path, wskenv.DEPLOYED_REFERENCE_PREFIX) {
+		path = wskenv.DEPLOYED_REFERENCE_PREFIX + path
+	}
+	value, _ := report.outputs.Get(path)
+	return value
+}
+
+var reportTemplateFuncs = template.FuncMap{
+	"join": strings.Join,
+	"json": func(value interface{}) (string, error) {
+		content, err := json.MarshalIndent(value, "", "  ")
+		return string(content), err
+	},
+}
+
+// Render renders the report with the Go text/template of the file or URL, to
+// the output file or to the standard output if it is empty
+func (report *DeploymentReport) Render(templatePath string, outputPath string) error {
+	content, err := utils.Read(templatePath)
+	if err != nil {
+		return wskderrors.NewFileReadError(templatePath, err.Error())
+	}
+
+	tmpl, err := template.New(path.Base(templatePath)).Funcs(reportTemplateFuncs).Parse(string(content))
+	if err != nil {
+		return reportTemplateError(templatePath, err)
+	}
+
+	rendered := new(bytes.Buffer)
+	if err := tmpl.Execute(rendered, report); err != nil {
+		return reportTemplateError(templatePath, err)
+	}
+
+	var out io.Writer = os.Stdout
+	if len(outputPath) > 0 {
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return wskderrors.NewFileReadError(outputPath, err.Error())
+		}
+		defer file.Close()
+		out = file
+	}
+	// the values of secret inputs are redacted
+	if _, err := io.WriteString(out, wskprint.MaskSecrets(rendered.String())); err != nil {
+		return wskderrors.NewFileReadError(outputPath, err.Error())
+	}
+	return nil
+}
+
+func reportTemplateError(templatePath string, err error) error {
+	return wskderrors.NewCommandError("--report-template",
+		wski18n.T(wski18n.ID_ERR_REPORT_TEMPLATE_X_path_X_err_X,
+			map[string]interface{}{wski18n.KEY_PATH: templatePath, wski18n.KEY_ERR: err.Error()}))
+}
+
+// Entities returns the entities of the plan keyed by type, actions and
+// sequences are named package/action as in the checkpoint of a deployment
+func (plan *DeploymentProject) Entities() map[string][]string {
+	entities := make(map[string][]string)
+	add := func(entity string, name string) {
+		entities[entity] = append(entities[entity], name)
+	}
+	for packName, pack := range plan.Packages {
+		if pack.Package != nil {
+			packName = pack.Package.Name
+			add(parsers.YAML_KEY_PACKAGE, packName)
+		}
+		for _, action := range pack.Actions {
+			add(parsers.YAML_KEY_ACTION, path.Join(packName, action.Action.Name))
+		}
+		for _, sequence := range pack.Sequences {
+			add(parsers.YAML_KEY_SEQUENCE, path.Join(packName, sequence.Action.Name))
+		}
+	}
+	for _, trigger := range plan.Triggers {
+		add(parsers.YAML_KEY_TRIGGER, trigger.Name)
+	}
+	for _, rule := range plan.Rules {
+		add(parsers.YAML_KEY_RULE, rule.Name)
+	}
+	for name := range plan.Apis {
+		add(parsers.YAML_KEY_API, name)
+	}
+	for _, names := range entities {
+		sort.Strings(names)
+	}
+	return entities
+}
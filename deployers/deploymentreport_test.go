@@ -0,0 +1,99 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func newReportTestPlan() *DeploymentProject {
+	plan := NewDeploymentProject()
+	pack := NewDeploymentPackage()
+	pack.Package = &whisk.Package{Name: "hello"}
+	pack.Actions["world"] = utils.ActionRecord{Action: &whisk.Action{Name: "world"}}
+	pack.Actions["bye"] = utils.ActionRecord{Action: &whisk.Action{Name: "bye"}}
+	pack.Sequences["greet"] = utils.ActionRecord{Action: &whisk.Action{Name: "greet"}}
+	plan.Packages["hello"] = pack
+	plan.Triggers["everyhour"] = &whisk.Trigger{Name: "everyhour"}
+	plan.Rules["greet_hourly"] = &whisk.Rule{Name: "greet_hourly"}
+	return plan
+}
+
+func TestDeploymentProject_Entities(t *testing.T) {
+	entities := newReportTestPlan().Entities()
+	assert.Equal(t, []string{"hello"}, entities[parsers.YAML_KEY_PACKAGE])
+	assert.Equal(t, []string{"hello/bye", "hello/world"}, entities[parsers.YAML_KEY_ACTION])
+	assert.Equal(t, []string{"hello/greet"}, entities[parsers.YAML_KEY_SEQUENCE])
+	assert.Equal(t, []string{"everyhour"}, entities[parsers.YAML_KEY_TRIGGER])
+	assert.Equal(t, []string{"greet_hourly"}, entities[parsers.YAML_KEY_RULE])
+}
+
+func TestDeploymentReport_Render(t *testing.T) {
+	dir, err := ioutil.TempDir("", "report")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	deployer := NewServiceDeployer()
+	deployer.ProjectName = "helloworld"
+	deployer.ClientConfig = &whisk.Config{Host: "localhost:3233", Namespace: "guest"}
+	deployer.DeployedOutputs.AddAction("localhost:3233", "guest", "hello/world", nil)
+	deployer.Failures.Add("action", "hello/bye", errors.New("upload failed"))
+	plan := newReportTestPlan()
+	report := deployer.NewDeploymentReport(NOTIFICATION_EVENT_DEPLOY, plan, plan.Entities(), nil)
+
+	templatePath := filepath.Join(dir, "notes.tmpl")
+	outputPath := filepath.Join(dir, "notes.md")
+	assert.Nil(t, ioutil.WriteFile(templatePath, []byte(
+		`# {{.Project}} {{.Event}} {{.Status}} in {{.Namespace}}
+{{range $name, $pack := .Plan.Packages}}{{range $action, $record := $pack.Actions}}- {{$name}}/{{$action}}
+{{end}}{{end}}actions: {{join (index .Entities "action") ", "}}
+url: {{.Deployed "packages.hello.actions.world.url"}}
+failures: {{len .Failures}}
+`), 0644))
+	assert.Nil(t, report.Render(templatePath, outputPath))
+	content, err := ioutil.ReadFile(outputPath)
+	assert.Nil(t, err)
+	assert.Equal(t, `# helloworld deploy success in guest
+- hello/bye
+- hello/world
+actions: hello/bye, hello/world
+url: https://localhost:3233/api/v1/web/guest/hello/world
+failures: 1
+`, string(content))
+
+	assert.Nil(t, ioutil.WriteFile(templatePath, []byte("{{.Project"), 0644))
+	err = report.Render(templatePath, outputPath)
+	_, ok := err.(*wskderrors.CommandError)
+	assert.True(t, ok, "an invalid template is reported")
+
+	err = report.Render(filepath.Join(dir, "missing.tmpl"), outputPath)
+	_, ok = err.(*wskderrors.FileReadError)
+	assert.True(t, ok)
+}
@@ -0,0 +1,78 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// isEmptyPackage reports whether a package of the manifest has none of the
+// entities wskdeploy deploys, its inputs and annotations aside
+func isEmptyPackage(pkg parsers.Package) bool {
+	return len(pkg.Actions) == 0 && len(pkg.Sequences) == 0 && len(pkg.Triggers) == 0 &&
+		len(pkg.Feeds) == 0 && len(pkg.Rules) == 0 && len(pkg.Dependencies) == 0 &&
+		len(pkg.Bindings) == 0 && len(pkg.Apis.Routes) == 0 && len(pkg.Apis.Swagger) == 0
+}
+
+// ValidateNotEmpty refuses a manifest without packages and the packages
+// without entities, which are most often entities indented at the wrong level
+// rather than meant to be empty. With --allow-empty they are only reported.
+// A managed deployment may have no packages, it undeploys the entities of its
+// project, and the packages of a project read with --allow-defaults get the
+// actions of its directory.
+func ValidateNotEmpty(manifest *parsers.YAML, manifestPath string, useDefaults bool, flags *utils.WskdeployFlags) error {
+	packages := manifest.GetPackages()
+	if len(packages) == 0 {
+		if flags.Managed {
+			return nil
+		}
+		args := map[string]interface{}{wski18n.KEY_PATH: manifestPath}
+		if flags.AllowEmpty {
+			wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_MANIFEST_EMPTY_X_path_X, args))
+			return nil
+		}
+		return wskderrors.NewYAMLFileFormatError(manifestPath, wski18n.T(wski18n.ID_ERR_MANIFEST_EMPTY_X_path_X, args))
+	}
+	if useDefaults {
+		return nil
+	}
+
+	empty := make([]string, 0)
+	for name, pkg := range packages {
+		if isEmptyPackage(pkg) {
+			empty = append(empty, name)
+		}
+	}
+	if len(empty) == 0 {
+		return nil
+	}
+	sort.Strings(empty)
+	args := map[string]interface{}{wski18n.KEY_PATH: manifestPath, wski18n.KEY_NAMES: strings.Join(empty, ", ")}
+	if flags.AllowEmpty {
+		wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_PACKAGES_EMPTY_X_path_X_names_X, args))
+		return nil
+	}
+	return wskderrors.NewYAMLFileFormatError(manifestPath, wski18n.T(wski18n.ID_ERR_PACKAGES_EMPTY_X_path_X_names_X, args))
+}
@@ -0,0 +1,51 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateNotEmpty(t *testing.T) {
+	flags := &utils.WskdeployFlags{}
+	manifest := &parsers.YAML{Project: parsers.Project{Name: "hello"}}
+	err := ValidateNotEmpty(manifest, "manifest.yaml", false, flags)
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "--allow-empty"))
+
+	manifest.Project.Packages = map[string]parsers.Package{
+		"hello":   {Actions: map[string]parsers.Action{"hello": {}}},
+		"actions": {},
+	}
+	err = ValidateNotEmpty(manifest, "manifest.yaml", false, flags)
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "[actions]"))
+	// the packages get the actions of the project directory
+	assert.Nil(t, ValidateNotEmpty(manifest, "manifest.yaml", true, flags))
+
+	flags.AllowEmpty = true
+	assert.Nil(t, ValidateNotEmpty(manifest, "manifest.yaml", false, flags))
+	assert.Nil(t, ValidateNotEmpty(&parsers.YAML{}, "manifest.yaml", false, flags))
+}
@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// EncryptInputs encrypts the inputs of the packages, actions, sequences and
+// triggers of the deployment plan listed in their encrypted-inputs annotation,
+// once the values of the deployment file are bound, so that they are stored
+// encrypted on OpenWhisk, see utils.WskdeployFlags.EncryptInput(). The values which are
+// already encrypted are deployed as is.
+func (deployer *ServiceDeployer) EncryptInputs() error {
+	for packageName, pack := range deployer.Deployment.Packages {
+		if err := deployer.encryptParameters(packageName, pack.Package.Parameters, pack.Package.Annotations); err != nil {
+			return err
+		}
+		for _, records := range []map[string]utils.ActionRecord{pack.Actions, pack.Sequences} {
+			for name, record := range records {
+				action := record.Action
+				if err := deployer.encryptParameters(path.Join(packageName, name), action.Parameters, action.Annotations); err != nil {
+					return err
+				}
+			}
+		}
+		for name, binding := range pack.Bindings {
+			if err := deployer.encryptParameters(name, binding.Parameters, binding.Annotations); err != nil {
+				return err
+			}
+		}
+	}
+	for name, trigger := range deployer.Deployment.Triggers {
+		if err := deployer.encryptParameters(name, trigger.Parameters, trigger.Annotations); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encryptParameters replaces the values of the encrypted inputs of an entity
+// with their ciphertext
+func (deployer *ServiceDeployer) encryptParameters(entity string, parameters whisk.KeyValueArr, annotations whisk.KeyValueArr) error {
+	inputs := parsers.GetEncryptedInputs(annotations)
+	if len(inputs) == 0 {
+		return nil
+	}
+	if len(deployer.Flags.EncryptionProvider) == 0 {
+		return wskderrors.NewCommandError("--encryption-provider",
+			wski18n.T(wski18n.ID_ERR_ENCRYPTION_PROVIDER_MISSING_X_entity_X_inputs_X,
+				map[string]interface{}{wski18n.KEY_ENTITY: entity, wski18n.KEY_INPUTS: strings.Join(inputs, ", ")}))
+	}
+	encrypted := make(map[string]bool, len(inputs))
+	for _, input := range inputs {
+		encrypted[input] = true
+	}
+	for i, parameter := range parameters {
+		if !encrypted[parameter.Key] || utils.IsEncryptedValue(parameter.Value) {
+			continue
+		}
+		ciphertext, err := deployer.Flags.EncryptInput(entity, parameter.Key, parameter.Value)
+		if err != nil {
+			return err
+		}
+		// the plaintext is masked like the values of secret inputs
+		if s, ok := parameter.Value.(string); ok {
+			wskprint.AddSecret(s)
+		} else {
+			wskprint.AddSecret(fmt.Sprint(parameter.Value))
+		}
+		parameters[i].Value = ciphertext
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceDeployer_EncryptInputs(t *testing.T) {
+	deployer := NewServiceDeployer()
+	deployer.Deployment.Triggers["pushes"] = &whisk.Trigger{Name: "pushes",
+		Parameters: whisk.KeyValueArr{{Key: "token", Value: "s3cr3t"}, {Key: "repository", Value: "hello"},
+			{Key: "secret", Value: "wskenc:command:c2VjcmV0"}},
+		Annotations: whisk.KeyValueArr{{Key: utils.ENCRYPTED_INPUTS_ANNOT, Value: []interface{}{"secret", "token"}}}}
+
+	// a provider is required
+	err := deployer.EncryptInputs()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "token")
+
+	if _, err := exec.LookPath("rev"); err != nil {
+		t.Skip("rev is not installed")
+	}
+	utils.Flags.EncryptionProvider = "command:rev"
+	defer func() { utils.Flags.EncryptionProvider = "" }()
+	assert.Nil(t, deployer.EncryptInputs())
+	parameters := deployer.Deployment.Triggers["pushes"].Parameters
+	assert.Equal(t, "wskenc:command:\"t3rc3s\"", parameters.GetValue("token"))
+	assert.Equal(t, "hello", parameters.GetValue("repository"))
+	// values already encrypted are deployed as is
+	assert.Equal(t, "wskenc:command:c2VjcmV0", parameters.GetValue("secret"))
+}
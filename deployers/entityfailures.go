@@ -0,0 +1,131 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// EntityFailures collects the entities which failed to deploy when the
+// deployment continues on errors (see --continue-on-error)
+type EntityFailures struct {
+	mt       sync.Mutex
+	failures []string
+}
+
+func NewEntityFailures() *EntityFailures {
+	return &EntityFailures{failures: make([]string, 0)}
+}
+
+// Add records the failure of the entity of the given type
+func (failures *EntityFailures) Add(entity string, name string, err error) {
+	failures.mt.Lock()
+	defer failures.mt.Unlock()
+	failures.failures = append(failures.failures, entity+" ["+name+"]: "+err.Error())
+}
+
+func (failures *EntityFailures) IsEmpty() bool {
+	failures.mt.Lock()
+	defer failures.mt.Unlock()
+	return len(failures.failures) == 0
+}
+
+// List returns the failures, one "entity [name]: error" per entity
+func (failures *EntityFailures) List() []string {
+	failures.mt.Lock()
+	defer failures.mt.Unlock()
+	return append([]string{}, failures.failures...)
+}
+
+// Error returns the error reporting every failure, nil if there is none
+func (failures *EntityFailures) Error() error {
+	failures.mt.Lock()
+	defer failures.mt.Unlock()
+	if len(failures.failures) == 0 {
+		return nil
+	}
+	return wskderrors.NewPartialDeploymentError(
+		wski18n.T(wski18n.ID_ERR_PARTIAL_DEPLOYMENT_X_count_X,
+			map[string]interface{}{wski18n.KEY_COUNT: strconv.Itoa(len(failures.failures))}),
+		append([]string{}, failures.failures...))
+}
+
+// deployEntity deploys an entity within the time allowed by --entity-timeout,
+// if any. With --continue-on-error, an entity which fails is recorded and nil
+// is returned so that the other entities are deployed.
+func (deployer *ServiceDeployer) deployEntity(entity string, name string, deploy func(deployer *ServiceDeployer) error) error {
+	if deployer.Context != nil && deployer.Context.Err() != nil {
+		return deployer.Context.Err()
+	}
+	start := time.Now()
+	err := deployer.withEntityTimeout(deployer.Flags.EntityTimeout, entity, name, deploy)
+	if deployer.Durations != nil {
+		deployer.Durations.Add(entity, name, time.Since(start))
+	}
+	if deployer.Results != nil {
+		deployer.Results.Add(entity, name, time.Since(start), err)
+	}
+	if err == nil || !deployer.Flags.ContinueOnError {
+		return err
+	}
+
+	deployer.Failures.Add(entity, name, err)
+	deployer.Output.PrintlnOpenWhiskError(err.Error())
+	deployer.Output.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_MSG_ENTITY_SKIPPED_X_key_X_name_X,
+		map[string]interface{}{
+			wski18n.KEY_KEY:  entity,
+			wski18n.KEY_NAME: name}))
+	return nil
+}
+
+// withEntityTimeout fails the deployment of the entity if it does not complete
+// in time. The entity is deployed by a worker with its own client, the worker
+// of an entity which timed out is left behind, e.g. waiting for a hanging feed
+// action, without affecting the client of this deployer.
+func (deployer *ServiceDeployer) withEntityTimeout(timeout time.Duration, entity string, name string, deploy func(deployer *ServiceDeployer) error) error {
+	if timeout <= 0 {
+		return deploy(deployer)
+	}
+
+	worker, err := deployer.newWorker()
+	if err != nil {
+		return err
+	}
+	worker.Output = deployer.Output
+
+	done := make(chan error, 1)
+	go func() {
+		done <- deploy(worker)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(timeout):
+		return wskderrors.NewEntityTimeoutError(wski18n.T(wski18n.ID_ERR_ENTITY_TIMEOUT_X_key_X_name_X_timeout_X,
+			map[string]interface{}{
+				wski18n.KEY_KEY:     entity,
+				wski18n.KEY_NAME:    name,
+				wski18n.KEY_TIMEOUT: timeout.String()}), entity, name)
+	}
+}
@@ -0,0 +1,92 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceDeployer_withEntityTimeout(t *testing.T) {
+	deployer := NewServiceDeployer()
+	hang := make(chan struct{})
+	defer close(hang)
+
+	err := deployer.withEntityTimeout(10*time.Millisecond, "trigger feed", "everyhour", func(worker *ServiceDeployer) error {
+		assert.True(t, worker != deployer, "the entity is deployed by a worker")
+		<-hang
+		return nil
+	})
+	assert.NotNil(t, err)
+	timeoutErr, ok := err.(*wskderrors.EntityTimeoutError)
+	assert.True(t, ok)
+	assert.Equal(t, "everyhour", timeoutErr.Name)
+
+	err = deployer.withEntityTimeout(time.Minute, "action", "hello/world", func(worker *ServiceDeployer) error {
+		return errors.New("failed")
+	})
+	assert.Equal(t, "failed", err.Error())
+}
+
+func TestServiceDeployer_deployEntity_ContinueOnError(t *testing.T) {
+	deployer := NewServiceDeployer()
+	failed := func(deployer *ServiceDeployer) error { return errors.New("upload failed") }
+
+	assert.NotNil(t, deployer.deployEntity("action", "hello/world", failed))
+	assert.True(t, deployer.Failures.IsEmpty())
+	assert.Nil(t, deployer.Failures.Error())
+
+	utils.Flags.ContinueOnError = true
+	defer func() { utils.Flags.ContinueOnError = false }()
+	assert.Nil(t, deployer.deployEntity("action", "hello/world", failed))
+	assert.Nil(t, deployer.deployEntity("rule", "hello_rule", func(deployer *ServiceDeployer) error { return nil }))
+
+	err := deployer.Failures.Error()
+	assert.NotNil(t, err)
+	partialErr, ok := err.(*wskderrors.PartialDeploymentError)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"action [hello/world]: upload failed"}, partialErr.Failures)
+}
+
+func TestServiceDeployer_deployEntity_ContextDone(t *testing.T) {
+	deployer := NewServiceDeployer()
+	ctx, cancel := context.WithCancel(context.Background())
+	deployer.Context = ctx
+
+	deployed := 0
+	deploy := func(deployer *ServiceDeployer) error {
+		deployed++
+		return nil
+	}
+	assert.Nil(t, deployer.deployEntity("action", "hello/world", deploy))
+
+	cancel()
+	utils.Flags.ContinueOnError = true
+	defer func() { utils.Flags.ContinueOnError = false }()
+	assert.Equal(t, context.Canceled, deployer.deployEntity("rule", "hello_rule", deploy))
+	assert.Equal(t, 1, deployed)
+	assert.True(t, deployer.Failures.IsEmpty(), "a cancelled deployment is not a partial deployment")
+}
@@ -0,0 +1,118 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// flags entities are overridden with, see ApplyEntityOverrides()
+const (
+	FLAG_PARAM      = "--param"
+	FLAG_ANNOTATION = "--annotation"
+)
+
+// ApplyEntityOverrides sets the parameters of --param and the annotations of
+// --annotation on the entities of the deployment plan once the manifest and
+// deployment files are bound, so that they take precedence over both. A value
+// which replaces a string is kept as given, others are read as YAML, e.g. 512
+// is a number and true a boolean.
+func (deployer *ServiceDeployer) ApplyEntityOverrides() error {
+	for _, param := range deployer.Flags.Params {
+		if err := deployer.applyEntityOverride(FLAG_PARAM, param); err != nil {
+			return err
+		}
+	}
+	for _, annotation := range deployer.Flags.Annotations {
+		if err := deployer.applyEntityOverride(FLAG_ANNOTATION, annotation); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (deployer *ServiceDeployer) applyEntityOverride(flag string, value string) error {
+	override, err := parsers.ParseEntityOverride(flag, value)
+	if err != nil {
+		return err
+	}
+	target, ok := deployer.overrideTarget(override.Entity)
+	if !ok {
+		return wskderrors.NewCommandError(flag, wski18n.T(wski18n.ID_ERR_ENTITY_OVERRIDE_NOT_FOUND_X_flag_X_entity_X_value_X,
+			map[string]interface{}{wski18n.KEY_FLAG: flag, wski18n.KEY_ENTITY: override.Entity, wski18n.KEY_VALUE: value}))
+	}
+	keyValues := target.annotations
+	if flag == FLAG_PARAM {
+		if target.parameters == nil {
+			return wskderrors.NewCommandError(flag, wski18n.T(wski18n.ID_ERR_ENTITY_OVERRIDE_NO_PARAMETERS_X_entity_X_value_X,
+				map[string]interface{}{wski18n.KEY_ENTITY: override.Entity, wski18n.KEY_VALUE: value}))
+		}
+		keyValues = target.parameters
+	}
+	setEntityOverride(keyValues, override)
+	return nil
+}
+
+// overrideTarget returns the parameters and annotations of an entity given as
+// package/action, for actions and sequences, or by name, for packages, then
+// triggers, then rules
+func (deployer *ServiceDeployer) overrideTarget(entity string) (bindable, bool) {
+	if strings.Contains(entity, "/") {
+		parts := strings.SplitN(entity, "/", 2)
+		pack, ok := deployer.Deployment.Packages[parts[0]]
+		if !ok {
+			return bindable{}, false
+		}
+		for _, records := range []map[string]utils.ActionRecord{pack.Actions, pack.Sequences} {
+			if record, ok := records[parts[1]]; ok {
+				return bindable{parameters: &record.Action.Parameters, annotations: &record.Action.Annotations}, true
+			}
+		}
+		return bindable{}, false
+	}
+	if pack, ok := deployer.Deployment.Packages[entity]; ok {
+		return bindable{parameters: &pack.Package.Parameters, annotations: &pack.Package.Annotations}, true
+	}
+	if trigger, ok := deployer.Deployment.Triggers[entity]; ok {
+		return bindable{parameters: &trigger.Parameters, annotations: &trigger.Annotations}, true
+	}
+	if rule, ok := deployer.Deployment.Rules[entity]; ok {
+		return bindable{annotations: &rule.Annotations}, true
+	}
+	return bindable{}, false
+}
+
+// setEntityOverride replaces the value of the key, or adds the key if it is not set
+func setEntityOverride(keyValues *whisk.KeyValueArr, override parsers.EntityOverride) {
+	index := indexOfKey(*keyValues, override.Key)
+	if index < 0 {
+		*keyValues = append(*keyValues, whisk.KeyValue{Key: override.Key, Value: override.Value})
+		return
+	}
+	if _, isString := (*keyValues)[index].Value.(string); isString {
+		(*keyValues)[index].Value = override.Raw
+		return
+	}
+	(*keyValues)[index].Value = override.Value
+}
@@ -0,0 +1,61 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceDeployer_ApplyEntityOverrides(t *testing.T) {
+	deployer := NewServiceDeployer()
+	pack := NewDeploymentPackage()
+	pack.Package = &whisk.Package{Name: "hello", Parameters: whisk.KeyValueArr{{Key: "region", Value: "us"}}}
+	greeting := &whisk.Action{Name: "greeting",
+		Parameters:  whisk.KeyValueArr{{Key: "name", Value: "Amy"}, {Key: "port", Value: 80}},
+		Annotations: whisk.KeyValueArr{{Key: "final", Value: false}}}
+	pack.Actions["greeting"] = utils.ActionRecord{Action: greeting, Packagename: "hello"}
+	deployer.Deployment.Packages["hello"] = pack
+	deployer.Deployment.Triggers["locationUpdate"] = &whisk.Trigger{Name: "locationUpdate"}
+	deployer.Deployment.Rules["myRule"] = &whisk.Rule{Name: "myRule"}
+
+	utils.Flags.Params = []string{"hello/greeting.name=0123", "hello/greeting.port=8080", "hello.region=eu",
+		"locationUpdate.place=Paris"}
+	utils.Flags.Annotations = []string{"hello/greeting.final=true", "myRule.owner=team-a"}
+	defer func() { utils.Flags.Params, utils.Flags.Annotations = nil, nil }()
+	assert.Nil(t, deployer.ApplyEntityOverrides())
+
+	// strings are kept as given, other values are read as YAML
+	assert.Equal(t, "0123", greeting.Parameters.GetValue("name"))
+	assert.Equal(t, 8080, greeting.Parameters.GetValue("port"))
+	assert.Equal(t, true, greeting.Annotations.GetValue("final"))
+	assert.Equal(t, "eu", pack.Package.Parameters.GetValue("region"))
+	assert.Equal(t, "Paris", deployer.Deployment.Triggers["locationUpdate"].Parameters.GetValue("place"))
+	assert.Equal(t, "team-a", deployer.Deployment.Rules["myRule"].Annotations.GetValue("owner"))
+
+	for _, params := range [][]string{{"hello/farewell.name=Bob"}, {"unknown.name=Bob"}, {"myRule.name=Bob"}} {
+		utils.Flags.Params = params
+		assert.IsType(t, &wskderrors.CommandError{}, deployer.ApplyEntityOverrides(), params[0])
+	}
+}
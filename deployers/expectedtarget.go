@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// CheckExpectedTarget returns an error when the API host or the namespace of
+// the credentials does not match the expected-target of the project, so that
+// a project is not deployed to production from a development shell by
+// mistake. With --override-target, a warning is printed instead.
+func (deployer *ServiceDeployer) CheckExpectedTarget() error {
+	target := deployer.ExpectedTarget
+	if target == nil || deployer.ClientConfig == nil {
+		return nil
+	}
+
+	host := targetHost(deployer.ClientConfig.Host)
+	checks := []struct {
+		key      string
+		value    string
+		patterns parsers.TargetPatterns
+	}{
+		{parsers.TARGET_API_HOST, host, target.ApiHost},
+		{parsers.TARGET_NAMESPACE, deployer.ClientConfig.Namespace, target.Namespace},
+	}
+	for _, check := range checks {
+		if len(check.patterns) == 0 || matchesTarget(check.patterns, check.value) ||
+			(check.key == parsers.TARGET_API_HOST && matchesTarget(check.patterns, strings.Split(host, ":")[0])) {
+			continue
+		}
+		if deployer.Flags.OverrideTarget {
+			wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_UNEXPECTED_TARGET_OVERRIDDEN_X_key_X_value_X,
+				map[string]interface{}{wski18n.KEY_KEY: check.key, wski18n.KEY_VALUE: check.value}))
+			continue
+		}
+		return wskderrors.NewWhiskClientInvalidConfigError(wski18n.T(wski18n.ID_ERR_UNEXPECTED_TARGET_X_key_X_value_X_expected_X,
+			map[string]interface{}{wski18n.KEY_KEY: check.key, wski18n.KEY_VALUE: check.value,
+				wski18n.KEY_EXPECTED: strings.Join(check.patterns, ", ")}))
+	}
+	return nil
+}
+
+// targetHost returns the host, and port if any, of an API host which may be
+// given as a URL, e.g. https://openwhisk.example.com:443/api
+func targetHost(apiHost string) string {
+	if strings.Contains(apiHost, "://") {
+		if hostURL, err := url.Parse(apiHost); err == nil {
+			return strings.ToLower(hostURL.Host)
+		}
+	}
+	return strings.ToLower(strings.SplitN(apiHost, "/", 2)[0])
+}
+
+// matchesTarget returns true if the value matches one of the globs, the globs
+// which are not valid match nothing
+func matchesTarget(patterns parsers.TargetPatterns, value string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(interpolateString(pattern))
+		if matched, err := path.Match(strings.ToLower(pattern), strings.ToLower(value)); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestServiceDeployer_CheckExpectedTarget(t *testing.T) {
+	var project parsers.Project
+	assert.Nil(t, yaml.Unmarshal([]byte("expected-target:\n  apihost: \"*.dev.example.com\"\n  namespace: [dev, \"team-*\"]\n"), &project))
+	assert.Equal(t, parsers.TargetPatterns{"*.dev.example.com"}, project.ExpectedTarget.ApiHost)
+	assert.Equal(t, parsers.TargetPatterns{"dev", "team-*"}, project.ExpectedTarget.Namespace)
+
+	deployer := NewServiceDeployer()
+	deployer.ClientConfig = &whisk.Config{Host: "https://openwhisk.dev.example.com:443", Namespace: "team-a"}
+	assert.Nil(t, deployer.CheckExpectedTarget(), "no expected-target")
+
+	deployer.ExpectedTarget = project.ExpectedTarget
+	assert.Nil(t, deployer.CheckExpectedTarget())
+	deployer.ClientConfig.Host = "OpenWhisk.dev.example.com"
+	assert.Nil(t, deployer.CheckExpectedTarget(), "hosts are not case sensitive")
+
+	deployer.ClientConfig.Namespace = "prod"
+	assert.IsType(t, &wskderrors.WhiskClientInvalidConfigError{}, deployer.CheckExpectedTarget())
+	deployer.ClientConfig.Namespace = "dev"
+	deployer.ClientConfig.Host = "openwhisk.example.com"
+	assert.IsType(t, &wskderrors.WhiskClientInvalidConfigError{}, deployer.CheckExpectedTarget())
+
+	utils.Flags.OverrideTarget = true
+	defer func() { utils.Flags.OverrideTarget = false }()
+	assert.Nil(t, deployer.CheckExpectedTarget(), "--override-target")
+}
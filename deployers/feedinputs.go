@@ -0,0 +1,83 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// ValidateFeedInputs checks that the triggers of well-known feeds, see
+// utils.FeedProviders, have the inputs the feed action fails without, either
+// as inputs of the trigger (from the manifest or the deployment file) or
+// bound to the package of the feed by the inputs of its dependency. All the
+// missing inputs are reported at once, along with a hint of the feed.
+func (deployer *ServiceDeployer) ValidateFeedInputs() error {
+	bindings := make(map[string]string)
+	bound := make(map[string]whisk.KeyValueArr)
+	for _, pack := range deployer.Deployment.Packages {
+		for name, dep := range pack.Dependencies {
+			if dep.IsBinding {
+				bindings[name] = dep.Location
+				bound[name] = dep.Parameters
+			}
+		}
+	}
+
+	problems := make([]string, 0)
+	for _, trigger := range deployer.Deployment.Triggers {
+		feed, isFeed := utils.IsFeedAction(trigger)
+		if !isFeed {
+			continue
+		}
+		name, provider := utils.GetFeedProvider(feed, bindings)
+		if provider == nil {
+			continue
+		}
+		binding := strings.Split(feed, "/")[0]
+		for _, input := range provider.Inputs {
+			if hasInput(trigger.Parameters, input) || hasInput(bound[binding], input) {
+				continue
+			}
+			problems = append(problems, wski18n.T(wski18n.ID_ERR_FEED_INPUT_MISSING_X_trigger_X_feed_X_input_X_hint_X,
+				map[string]interface{}{wski18n.KEY_TRIGGER: trigger.Name, wski18n.KEY_FEED: name,
+					wski18n.KEY_INPUT: input, wski18n.KEY_HINT: wski18n.T(provider.Hint)}))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	message := wski18n.T(wski18n.ID_ERR_FEED_INPUTS_INVALID_X_count_X,
+		map[string]interface{}{wski18n.KEY_COUNT: len(problems)})
+	return wskderrors.NewYAMLFileFormatError(deployer.ManifestPath, message+"\n"+strings.Join(problems, "\n"))
+}
+
+// hasInput reports whether the input is set to a value other than an empty
+// string
+func hasInput(inputs whisk.KeyValueArr, key string) bool {
+	value := inputs.GetValue(key)
+	return value != nil && fmt.Sprint(value) != ""
+}
@@ -0,0 +1,65 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newFeedTrigger(name string, feed string, parameters whisk.KeyValueArr) *whisk.Trigger {
+	return &whisk.Trigger{Name: name, Parameters: parameters,
+		Annotations: whisk.KeyValueArr{{Key: "feed", Value: feed}}}
+}
+
+func TestServiceDeployer_ValidateFeedInputs(t *testing.T) {
+	deployer := NewServiceDeployer()
+	pack := NewDeploymentPackage()
+	pack.Package = &whisk.Package{Name: "hello"}
+	pack.Dependencies["mydb"] = utils.NewDependencyRecord("", "hello", "/whisk.system/cloudant", "",
+		whisk.KeyValueArr{{Key: "dbname", Value: "guests"}}, nil, true)
+	pack.Dependencies["myalarms"] = utils.NewDependencyRecord("", "hello", "/whisk.system/alarms", "", nil, nil, true)
+	deployer.Deployment.Packages["hello"] = pack
+
+	deployer.Deployment.Triggers["everyhour"] = newFeedTrigger("everyhour", "/whisk.system/alarms/alarm",
+		whisk.KeyValueArr{{Key: "cron", Value: "0 * * * *"}})
+	// the input is bound to the package of the feed
+	deployer.Deployment.Triggers["changes"] = newFeedTrigger("changes", "mydb/changes", nil)
+	// feeds which are not known are left to the feed action
+	deployer.Deployment.Triggers["custom"] = newFeedTrigger("custom", "/guest/custom/feed", nil)
+	assert.Nil(t, deployer.ValidateFeedInputs())
+
+	deployer.Deployment.Triggers["once"] = newFeedTrigger("once", "myalarms/once",
+		whisk.KeyValueArr{{Key: "date", Value: ""}})
+	deployer.Deployment.Triggers["pushes"] = newFeedTrigger("pushes", "/whisk.system/github/webhook",
+		whisk.KeyValueArr{{Key: "username", Value: "guest"}, {Key: "repository", Value: "hello"},
+			{Key: "accessToken", Value: "token"}})
+	err := deployer.ValidateFeedInputs()
+	assert.NotNil(t, err)
+	// all the missing inputs are reported at once
+	for _, input := range []string{"[once]", "[date]", "[alarms/once]", "[pushes]", "[events]"} {
+		assert.True(t, strings.Contains(err.Error(), input), input)
+	}
+	assert.False(t, strings.Contains(err.Error(), "[changes]"))
+}
@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// annotation of the triggers of feeds which holds the hash of the parameters
+// the feed action was invoked with, see updateFeedAction()
+const ANNOTATION_FEED_HASH = "feed-hash"
+
+// the lifecycle event a feed action is invoked with to change the parameters
+// of a trigger, see https://github.com/apache/incubator-openwhisk/blob/master/docs/feeds.md
+const FEED_LIFECYCLE_EVENT_UPDATE = "UPDATE"
+
+// feedHash hashes the parameters of the trigger along with its feed and the
+// key the feed action is invoked with, so that the feed is updated when the
+// key changes as well
+func (deployer *ServiceDeployer) feedHash(trigger *whisk.Trigger, feedName string) string {
+	parameters := make(map[string]interface{})
+	for _, keyValue := range trigger.Parameters {
+		parameters[keyValue.Key] = keyValue.Value
+	}
+	return historyHash(map[string]interface{}{
+		parsers.YAML_KEY_FEED: deployer.qualifiedFeed(feedName),
+		"parameters":          parameters,
+		FEED_PARAM_AUTH_KEY:   deployer.ClientConfig.AuthToken,
+	})
+}
+
+// qualifiedFeed returns the feed as /namespace/package/action
+func (deployer *ServiceDeployer) qualifiedFeed(feedName string) string {
+	qName, err := utils.ParseQualifiedName(feedName, deployer.ClientConfig.Namespace)
+	if err != nil {
+		return feedName
+	}
+	return "/" + qName.Namespace + "/" + qName.EntityName
+}
+
+// updateFeedAction changes the parameters of a trigger deployed with the same
+// feed by invoking the feed action with the UPDATE lifecycle event, instead of
+// deleting and creating the trigger, so that the feed keeps its state, e.g.
+// the last sequence of a database. The feed action is not invoked when the
+// parameters are unchanged. It returns false when the trigger has to be
+// created again: the feed changed, or the feed action does not support UPDATE.
+func (deployer *ServiceDeployer) updateFeedAction(trigger *whisk.Trigger, deployed *whisk.Trigger, feedName string, params map[string]interface{}) (bool, error) {
+	deployedFeed, isFeed := utils.IsFeedAction(deployed)
+	if !isFeed || deployer.qualifiedFeed(deployedFeed) != deployer.qualifiedFeed(feedName) {
+		return false, nil
+	}
+
+	hash, _ := trigger.Annotations.GetValue(ANNOTATION_FEED_HASH).(string)
+	if deployedHash, _ := deployed.Annotations.GetValue(ANNOTATION_FEED_HASH).(string); deployedHash == hash {
+		whisk.Debug(whisk.DbgInfo, wski18n.T(wski18n.ID_MSG_FEED_UNCHANGED_X_trigger_X,
+			map[string]interface{}{wski18n.KEY_TRIGGER: trigger.Name}))
+	} else {
+		update := make(map[string]interface{})
+		for key, value := range params {
+			update[key] = value
+		}
+		update[FEED_PARAM_LIFECYCLE_EVENT] = FEED_LIFECYCLE_EVENT_UPDATE
+
+		qName, err := utils.ParseQualifiedName(feedName, deployer.ClientConfig.Namespace)
+		if err != nil {
+			return true, err
+		}
+		err = deployer.inNamespace(qName.Namespace, func() error {
+			return deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+				_, _, err := deployer.Client.Actions.Invoke(qName.EntityName, update, true, false)
+				return err
+			})
+		})
+		if err != nil {
+			wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_FEED_UPDATE_FAILED_X_trigger_X_err_X,
+				map[string]interface{}{wski18n.KEY_TRIGGER: trigger.Name, wski18n.KEY_ERR: err.Error()}))
+			return false, nil
+		}
+		wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_FEED_UPDATED_X_trigger_X,
+			map[string]interface{}{wski18n.KEY_TRIGGER: trigger.Name}))
+	}
+
+	// the annotations of the trigger, and the hash of its parameters
+	var response *http.Response
+	err := deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+		var err error
+		_, response, err = deployer.Client.Triggers.Insert(trigger, true)
+		return err
+	})
+	if err != nil {
+		return true, createWhiskClientError(err.(*whisk.WskError), response, parsers.TRIGGER_FEED, true)
+	}
+	return true, nil
+}
@@ -0,0 +1,71 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestFeedDeployer() *ServiceDeployer {
+	deployer := NewServiceDeployer()
+	deployer.ClientConfig = &whisk.Config{Namespace: "guest", AuthToken: "user:pass"}
+	return deployer
+}
+
+func TestServiceDeployer_feedHash(t *testing.T) {
+	deployer := newTestFeedDeployer()
+	trigger := &whisk.Trigger{Name: "everyhour", Parameters: whisk.KeyValueArr{
+		{Key: "cron", Value: "0 * * * *"}, {Key: "trigger_payload", Value: map[string]interface{}{"name": "Amy"}}}}
+	hash := deployer.feedHash(trigger, "/whisk.system/alarms/alarm")
+
+	reordered := &whisk.Trigger{Name: "everyhour", Parameters: whisk.KeyValueArr{
+		{Key: "trigger_payload", Value: map[string]interface{}{"name": "Amy"}}, {Key: "cron", Value: "0 * * * *"}}}
+	assert.Equal(t, hash, deployer.feedHash(reordered, "/whisk.system/alarms/alarm"))
+
+	changed := &whisk.Trigger{Name: "everyhour", Parameters: whisk.KeyValueArr{{Key: "cron", Value: "*/5 * * * *"}}}
+	assert.NotEqual(t, hash, deployer.feedHash(changed, "/whisk.system/alarms/alarm"))
+	assert.NotEqual(t, hash, deployer.feedHash(trigger, "/whisk.system/alarms/once"))
+
+	deployer.ClientConfig.AuthToken = "user:rotated"
+	assert.NotEqual(t, hash, deployer.feedHash(trigger, "/whisk.system/alarms/alarm"), "the feed is updated with the new key")
+}
+
+func TestServiceDeployer_qualifiedFeed(t *testing.T) {
+	deployer := newTestFeedDeployer()
+	assert.Equal(t, "/whisk.system/alarms/alarm", deployer.qualifiedFeed("/whisk.system/alarms/alarm"))
+	assert.Equal(t, "/guest/myalarms/alarm", deployer.qualifiedFeed("myalarms/alarm"))
+	assert.Equal(t, deployer.qualifiedFeed("/guest/myalarms/alarm"), deployer.qualifiedFeed("myalarms/alarm"))
+}
+
+func TestServiceDeployer_updateFeedAction_OtherFeed(t *testing.T) {
+	deployer := newTestFeedDeployer()
+	trigger := &whisk.Trigger{Name: "everyhour", Annotations: whisk.KeyValueArr{{Key: "feed", Value: "/whisk.system/alarms/alarm"}}}
+	deployed := &whisk.Trigger{Name: "everyhour", Annotations: whisk.KeyValueArr{{Key: "feed", Value: "/whisk.system/alarms/once"}}}
+	updated, err := deployer.updateFeedAction(trigger, deployed, "/whisk.system/alarms/alarm", nil)
+	assert.Nil(t, err)
+	assert.False(t, updated, "a trigger of another feed is created again")
+
+	updated, err = deployer.updateFeedAction(trigger, &whisk.Trigger{Name: "everyhour"}, "/whisk.system/alarms/alarm", nil)
+	assert.Nil(t, err)
+	assert.False(t, updated, "a trigger without feed is created again")
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
 	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
 )
 
@@ -46,8 +47,7 @@ func NewFileSystemReader(serviceDeployer *ServiceDeployer) *FileSystemReader {
 
 func (reader *FileSystemReader) ReadProjectDirectory(manifest *parsers.YAML) ([]utils.ActionRecord, error) {
 
-	// TODO() i18n
-	wskprint.PrintlnOpenWhiskOutput("Inspecting project directory for actions....")
+	wskprint.PrintlnOpenWhiskOutput(wski18n.T(wski18n.ID_MSG_INSPECTING_PROJECT_DIRECTORY))
 
 	projectPathCount, err := reader.getFilePathCount(reader.serviceDeployer.ProjectPath)
 	actions := make([]utils.ActionRecord, 0)
@@ -91,8 +91,8 @@ func (reader *FileSystemReader) ReadProjectDirectory(manifest *parsers.YAML) ([]
 					}
 				}
 			} else if strings.HasPrefix(fpath, reader.serviceDeployer.ProjectPath+"/"+FileSystemSourceDirectoryName) {
-				// TODO() i18n
-				wskprint.PrintlnOpenWhiskOutput("Searching directory " + filepath.Base(fpath) + " for action source code.")
+				wskprint.PrintlnOpenWhiskOutput(wski18n.T(wski18n.ID_MSG_SEARCHING_DIRECTORY_X_path_X,
+					map[string]interface{}{wski18n.KEY_PATH: filepath.Base(fpath)}))
 			} else {
 				return filepath.SkipDir
 			}
@@ -144,7 +144,7 @@ func (reader *FileSystemReader) CreateActionFromFile(manipath, filePath string)
 		return name, action, nil
 	}
 	// If the action is not supported, we better to return an error.
-	return "", nil, errors.New("Unsupported action type.")
+	return "", nil, errors.New(wski18n.T(wski18n.ID_ERR_ACTION_TYPE_UNSUPPORTED))
 }
 
 func (reader *FileSystemReader) getFilePathCount(path string) (int, error) {
@@ -174,7 +174,9 @@ func (reader *FileSystemReader) SetFileActions(actions []utils.ActionRecord) err
 				existAction.Filepath = fileAction.Filepath
 			} else {
 				// Action exists, but references two different sources
-				return errors.New("Conflict detected for action named " + existAction.Action.Name + ". Found two locations for source file: " + existAction.Filepath + " and " + fileAction.Filepath)
+				return errors.New(wski18n.T(wski18n.ID_ERR_ACTION_SOURCE_CONFLICT_X_action_X_source_X_path_X,
+					map[string]interface{}{wski18n.KEY_ACTION: existAction.Action.Name,
+						wski18n.KEY_SOURCE: existAction.Filepath, wski18n.KEY_PATH: fileAction.Filepath}))
 			}
 		} else {
 			// not a new action so to actions in package
@@ -0,0 +1,173 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// entityGraph is the graph of the deployment plan: the actions (and sequences)
+// of its packages, whether they are web actions, and its triggers. Rules,
+// sequences and APIs are the edges to them.
+type entityGraph struct {
+	// packages of the manifest, by name and by /namespace/package
+	packages          map[string]bool
+	qualifiedPackages map[string]bool
+	// actions and sequences, by package/action and /namespace/package/action
+	actions          map[string]bool
+	qualifiedActions map[string]bool
+	web              map[string]bool
+	triggers         map[string]bool
+	dependencies     map[string]bool
+}
+
+func (deployer *ServiceDeployer) newEntityGraph() *entityGraph {
+	graph := &entityGraph{
+		packages:          make(map[string]bool),
+		qualifiedPackages: make(map[string]bool),
+		actions:           make(map[string]bool),
+		qualifiedActions:  make(map[string]bool),
+		web:               make(map[string]bool),
+		triggers:          make(map[string]bool),
+		dependencies:      make(map[string]bool),
+	}
+	for packageName, pack := range deployer.Deployment.Packages {
+		namespace := pack.Package.Namespace
+		if len(namespace) == 0 && deployer.ClientConfig != nil {
+			namespace = deployer.ClientConfig.Namespace
+		}
+		graph.packages[packageName] = true
+		graph.qualifiedPackages[path.Join("/"+namespace, packageName)] = true
+		for _, records := range []map[string]utils.ActionRecord{pack.Actions, pack.Sequences} {
+			for name, record := range records {
+				graph.actions[path.Join(packageName, name)] = true
+				graph.qualifiedActions[path.Join("/"+namespace, packageName, name)] = true
+				graph.web[path.Join(packageName, name)] = isWebAction(record.Action.Annotations)
+			}
+		}
+		for name := range pack.Dependencies {
+			graph.dependencies[name] = true
+		}
+	}
+	for name := range deployer.Deployment.Triggers {
+		graph.triggers[name] = true
+	}
+	return graph
+}
+
+// declares reports whether an action given as package/action is declared, an
+// action of a package which is not part of the manifest, e.g. a dependency or
+// a package deployed before, is left to the server
+func (graph *entityGraph) declares(action string) bool {
+	parts := strings.Split(action, "/")
+	if len(parts) != 2 || !graph.packages[parts[0]] || graph.dependencies[parts[0]] {
+		return true
+	}
+	return graph.actions[action]
+}
+
+func isWebAction(annotations whisk.KeyValueArr) bool {
+	return fmt.Sprint(annotations.GetValue(utils.WEB_EXPORT_ANNOT)) == "true"
+}
+
+// ValidateGraph checks the references between the entities of the deployment
+// plan before anything is deployed: the triggers and actions of rules, the
+// actions of sequences, and the actions APIs map to must be web actions.
+// References to other namespaces, dependencies and packages which are not
+// part of the manifest are left to the server. All the broken references are
+// reported at once.
+func (deployer *ServiceDeployer) ValidateGraph(manifest *parsers.YAML) error {
+	graph := deployer.newEntityGraph()
+	problems := make([]string, 0)
+
+	for _, rule := range deployer.Deployment.Rules {
+		trigger := fmt.Sprint(rule.Trigger)
+		if !strings.Contains(trigger, "/") && !graph.triggers[trigger] {
+			problems = append(problems, wski18n.T(wski18n.ID_ERR_GRAPH_RULE_TRIGGER_NOT_FOUND_X_rule_X_trigger_X,
+				map[string]interface{}{wski18n.KEY_RULE: rule.Name, wski18n.KEY_TRIGGER: trigger}))
+		}
+		action := fmt.Sprint(rule.Action)
+		if !strings.HasPrefix(action, "/") && !graph.declares(action) {
+			problems = append(problems, wski18n.T(wski18n.ID_ERR_GRAPH_RULE_ACTION_NOT_FOUND_X_rule_X_action_X,
+				map[string]interface{}{wski18n.KEY_RULE: rule.Name, wski18n.KEY_ACTION: action}))
+		}
+	}
+
+	for _, pack := range deployer.Deployment.Packages {
+		for name, sequence := range pack.Sequences {
+			for _, component := range sequence.Action.Exec.Components {
+				if graph.qualifiedPackages[path.Dir(component)] && !graph.qualifiedActions[component] {
+					problems = append(problems, wski18n.T(wski18n.ID_ERR_GRAPH_SEQUENCE_ACTION_NOT_FOUND_X_sequence_X_action_X,
+						map[string]interface{}{wski18n.KEY_SEQUENCE: name, wski18n.KEY_ACTION: component}))
+				}
+			}
+		}
+	}
+
+	// the actions of APIs declared by routes are only known by name, they are
+	// not deployed yet (see ManifestReader.SetApis()), and the ones of OpenAPI
+	// documents are checked when the manifest is parsed
+	checkApiAction := func(api string, action string) {
+		if graph.actions[action] && !graph.web[action] {
+			problems = append(problems, wski18n.T(wski18n.ID_ERR_GRAPH_API_NOT_WEB_X_api_X_action_X,
+				map[string]interface{}{wski18n.KEY_API: api, wski18n.KEY_ACTION: action}))
+		}
+	}
+	if manifest != nil {
+		for packageName, pkg := range manifest.GetPackages() {
+			for _, api := range pkg.GetApis() {
+				action := strings.Trim(api.Action.Name, "/")
+				if !strings.Contains(action, "/") {
+					action = path.Join(packageName, action)
+				}
+				checkApiAction(api.ApiName, action)
+			}
+		}
+	}
+	for name, api := range deployer.Deployment.Apis {
+		if len(api.ApiDoc.Swagger) == 0 {
+			continue
+		}
+		document, err := parsers.ParseSwaggerDocument([]byte(api.ApiDoc.Swagger), name, "")
+		if err != nil {
+			return err
+		}
+		for _, operation := range document.Operations {
+			if operation.Namespace == parsers.SWAGGER_DEFAULT_NAMESPACE {
+				checkApiAction(name, path.Join(operation.Package, operation.Action))
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	message := wski18n.T(wski18n.ID_ERR_GRAPH_INVALID_X_count_X,
+		map[string]interface{}{wski18n.KEY_COUNT: len(problems)})
+	return wskderrors.NewYAMLFileFormatError(deployer.ManifestPath, message+"\n"+strings.Join(problems, "\n"))
+}
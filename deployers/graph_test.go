@@ -0,0 +1,72 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newGraphDeployer() *ServiceDeployer {
+	deployer := NewServiceDeployer()
+	deployer.ClientConfig = &whisk.Config{Namespace: "guest"}
+
+	pack := NewDeploymentPackage()
+	pack.Package = &whisk.Package{Name: "hello"}
+	webAnnotations := whisk.KeyValueArr{{Key: utils.WEB_EXPORT_ANNOT, Value: true}}
+	pack.Actions["hello"] = utils.ActionRecord{Action: &whisk.Action{Name: "hello", Annotations: webAnnotations}}
+	pack.Actions["bye"] = utils.ActionRecord{Action: &whisk.Action{Name: "bye"}}
+	pack.Sequences["hello_sequence"] = utils.ActionRecord{Action: &whisk.Action{Name: "hello_sequence",
+		Exec: &whisk.Exec{Kind: "sequence", Components: []string{"/guest/hello/hello", "/tenant1/other/bye"}}}}
+	deployer.Deployment.Packages["hello"] = pack
+	deployer.Deployment.Triggers["everyhour"] = &whisk.Trigger{Name: "everyhour"}
+	deployer.Deployment.Rules["hourly"] = &whisk.Rule{Name: "hourly", Trigger: "everyhour", Action: "hello/hello_sequence"}
+	// actions of packages which are not part of the manifest are left to the server
+	deployer.Deployment.Rules["external"] = &whisk.Rule{Name: "external", Trigger: "/whisk.system/alarm", Action: "utils/echo"}
+	return deployer
+}
+
+func TestServiceDeployer_ValidateGraph(t *testing.T) {
+	deployer := newGraphDeployer()
+	manifest := &parsers.YAML{Package: parsers.Package{Packagename: "hello"}}
+	manifest.Package.Apis.Routes = map[string]map[string]map[string]map[string]string{
+		"greetings": {"hello": {"world": {"hello": "get"}}},
+	}
+	assert.Nil(t, deployer.ValidateGraph(manifest))
+
+	deployer.Deployment.Rules["hourly"].Trigger = "everyminute"
+	deployer.Deployment.Rules["daily"] = &whisk.Rule{Name: "daily", Trigger: "everyhour", Action: "hello/missing"}
+	deployer.Deployment.Packages["hello"].Sequences["hello_sequence"].Action.Exec.Components =
+		[]string{"/guest/hello/hello", "/guest/hello/missing"}
+	manifest.Package.Apis.Routes["greetings"]["hello"]["world"]["bye"] = "post"
+
+	err := deployer.ValidateGraph(manifest)
+	assert.NotNil(t, err)
+	// all the broken references are reported at once
+	for _, reference := range []string{"everyminute", "hello/missing", "/guest/hello/missing", "hello/bye"} {
+		assert.True(t, strings.Contains(err.Error(), reference), reference)
+	}
+	assert.False(t, strings.Contains(err.Error(), "utils/echo"))
+}
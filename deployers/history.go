@@ -0,0 +1,338 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// name of the file (relative to the project path) which records the entities
+// of the last deployments of the project, written with --history
+const HISTORY_FILE_NAME = ".wskdeploy.history"
+
+// older deployments are dropped from the history
+const HISTORY_MAX_DEPLOYMENTS = 10
+
+const (
+	HISTORY_CHANGE_ADDED   = "added"
+	HISTORY_CHANGE_CHANGED = "changed"
+	HISTORY_CHANGE_REMOVED = "removed"
+)
+
+// HistoryEntity is the hash of an entity as deployed, and the time of the
+// deployment which last changed it
+type HistoryEntity struct {
+	Hash    string    `json:"hash"`
+	Updated time.Time `json:"updated"`
+}
+
+// HistoryDeployment records the entities of one deployment keyed by entity
+// type (package, action, trigger, ...) and name
+type HistoryDeployment struct {
+	Time      time.Time                           `json:"time"`
+	Manifest  string                              `json:"manifest"`
+	Namespace string                              `json:"namespace"`
+	Entities  map[string]map[string]HistoryEntity `json:"entities"`
+}
+
+type DeploymentHistory struct {
+	Deployments []HistoryDeployment `json:"deployments"`
+}
+
+// HistoryChange is an entity added, changed or removed between two deployments
+type HistoryChange struct {
+	Entity  string
+	Name    string
+	Change  string
+	Updated time.Time
+}
+
+func (change HistoryChange) String() string {
+	sign := map[string]string{HISTORY_CHANGE_ADDED: "+", HISTORY_CHANGE_CHANGED: "~", HISTORY_CHANGE_REMOVED: "-"}[change.Change]
+	return sign + " " + change.Entity + " " + change.Name
+}
+
+func GetHistoryFilePath(projectPath string) string {
+	return path.Join(projectPath, HISTORY_FILE_NAME)
+}
+
+// ReadHistory reads the history of the project, which is empty if the project
+// was never deployed with --history
+func ReadHistory(filePath string) (*DeploymentHistory, error) {
+	history := &DeploymentHistory{Deployments: make([]HistoryDeployment, 0)}
+	if !utils.FileExists(filePath) {
+		return history, nil
+	}
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	if err := json.Unmarshal(content, history); err != nil {
+		return nil, wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	return history, nil
+}
+
+func (history *DeploymentHistory) Write(filePath string) error {
+	content, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filePath, content, 0644)
+}
+
+// Last returns the last deployment recorded, or nil
+func (history *DeploymentHistory) Last() *HistoryDeployment {
+	if len(history.Deployments) == 0 {
+		return nil
+	}
+	return &history.Deployments[len(history.Deployments)-1]
+}
+
+// Add appends a deployment to the history; the entities it did not change
+// keep the time they were last updated
+func (history *DeploymentHistory) Add(deployment HistoryDeployment) {
+	if last := history.Last(); last != nil {
+		for entity, entities := range deployment.Entities {
+			for name, current := range entities {
+				if previous, ok := last.Entities[entity][name]; ok && previous.Hash == current.Hash {
+					entities[name] = previous
+				}
+			}
+		}
+	}
+	history.Deployments = append(history.Deployments, deployment)
+	if len(history.Deployments) > HISTORY_MAX_DEPLOYMENTS {
+		history.Deployments = history.Deployments[len(history.Deployments)-HISTORY_MAX_DEPLOYMENTS:]
+	}
+}
+
+// Changelog returns the changes between the last two deployments, everything
+// is added if the project was deployed only once
+func (history *DeploymentHistory) Changelog() []HistoryChange {
+	count := len(history.Deployments)
+	if count == 0 {
+		return []HistoryChange{}
+	}
+	previous := HistoryDeployment{}
+	if count > 1 {
+		previous = history.Deployments[count-2]
+	}
+	return CompareDeployments(previous, history.Deployments[count-1])
+}
+
+// CompareDeployments returns the entities added, changed and removed between
+// two deployments sorted by entity type and name
+func CompareDeployments(previous HistoryDeployment, current HistoryDeployment) []HistoryChange {
+	changes := make([]HistoryChange, 0)
+	for entity, entities := range current.Entities {
+		for name, e := range entities {
+			if before, ok := previous.Entities[entity][name]; !ok {
+				changes = append(changes, HistoryChange{Entity: entity, Name: name, Change: HISTORY_CHANGE_ADDED, Updated: e.Updated})
+			} else if before.Hash != e.Hash {
+				changes = append(changes, HistoryChange{Entity: entity, Name: name, Change: HISTORY_CHANGE_CHANGED, Updated: e.Updated})
+			}
+		}
+	}
+	for entity, entities := range previous.Entities {
+		for name := range entities {
+			if _, ok := current.Entities[entity][name]; !ok {
+				changes = append(changes, HistoryChange{Entity: entity, Name: name, Change: HISTORY_CHANGE_REMOVED, Updated: current.Time})
+			}
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Entity != changes[j].Entity {
+			return changes[i].Entity < changes[j].Entity
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// NewHistoryDeployment hashes the entities of the deployment plan, leaving out
+// the annotations which change whenever the manifest is parsed
+func (deployer *ServiceDeployer) NewHistoryDeployment(now time.Time) HistoryDeployment {
+	deployment := HistoryDeployment{
+		Time:     now,
+		Manifest: deployer.ManifestPath,
+		Entities: make(map[string]map[string]HistoryEntity),
+	}
+	if deployer.ClientConfig != nil {
+		deployment.Namespace = deployer.ClientConfig.Namespace
+	}
+	add := func(entity string, name string, value interface{}) {
+		if _, ok := deployment.Entities[entity]; !ok {
+			deployment.Entities[entity] = make(map[string]HistoryEntity)
+		}
+		deployment.Entities[entity][name] = HistoryEntity{Hash: historyHash(value), Updated: now}
+	}
+
+	for packName, pack := range deployer.Deployment.Packages {
+		if pack.Package != nil {
+			add(parsers.YAML_KEY_PACKAGE, packName, map[string]interface{}{
+				"binding":     pack.Package.Binding,
+				"parameters":  historyKeyValues(pack.Package.Parameters),
+				"annotations": historyKeyValues(removeKeyValue(pack.Package.Annotations, utils.MANAGED)),
+			})
+		}
+		for depName, dependency := range pack.Dependencies {
+			add(parsers.YAML_KEY_DEPENDENCY, depName, map[string]interface{}{
+				"location":    dependency.Location,
+				"version":     dependency.Version,
+				"parameters":  historyKeyValues(dependency.Parameters),
+				"annotations": historyKeyValues(dependency.Annotations),
+			})
+		}
+		for entity, records := range map[string]map[string]utils.ActionRecord{parsers.YAML_KEY_ACTION: pack.Actions, parsers.YAML_KEY_SEQUENCE: pack.Sequences} {
+			for actionName, record := range records {
+				annotations := removeKeyValue(record.Action.Annotations, utils.MANAGED)
+				if deployer.isWebSecretGenerated(packName, actionName) {
+					annotations = removeKeyValue(annotations, utils.REQUIRE_WHISK_AUTH_ANNOT)
+				}
+				add(entity, strings.Join([]string{packName, actionName}, "/"), map[string]interface{}{
+					"exec":        record.Action.Exec,
+					"limits":      record.Action.Limits,
+					"parameters":  historyKeyValues(record.Action.Parameters),
+					"annotations": historyKeyValues(annotations),
+				})
+			}
+		}
+	}
+	for name, trigger := range deployer.Deployment.Triggers {
+		add(parsers.YAML_KEY_TRIGGER, name, map[string]interface{}{
+			"parameters":  historyKeyValues(trigger.Parameters),
+			"annotations": historyKeyValues(removeKeyValue(trigger.Annotations, utils.MANAGED)),
+		})
+	}
+	for name, rule := range deployer.Deployment.Rules {
+		add(parsers.YAML_KEY_RULE, name, map[string]interface{}{
+			"trigger": entityName(rule.Trigger),
+			"action":  entityName(rule.Action),
+		})
+	}
+	for name, api := range deployer.Deployment.Apis {
+		add(parsers.YAML_KEY_API, name, api)
+	}
+	return deployment
+}
+
+// RetainChanges removes the entities which did not change since the previous
+// deployment from the deployment plan and returns the changes. Packages are
+// kept since the actions are deployed in them, rules are kept with their
+// trigger since a feed trigger is recreated when it changes.
+func (deployer *ServiceDeployer) RetainChanges(previous HistoryDeployment, current HistoryDeployment) []HistoryChange {
+	changes := CompareDeployments(previous, current)
+	changed := make(map[string]map[string]bool)
+	for _, change := range changes {
+		if change.Change == HISTORY_CHANGE_REMOVED {
+			continue
+		}
+		if _, ok := changed[change.Entity]; !ok {
+			changed[change.Entity] = make(map[string]bool)
+		}
+		changed[change.Entity][change.Name] = true
+	}
+
+	for packName, pack := range deployer.Deployment.Packages {
+		for name := range pack.Actions {
+			if !changed[parsers.YAML_KEY_ACTION][strings.Join([]string{packName, name}, "/")] {
+				delete(pack.Actions, name)
+			}
+		}
+		for name := range pack.Sequences {
+			if !changed[parsers.YAML_KEY_SEQUENCE][strings.Join([]string{packName, name}, "/")] {
+				delete(pack.Sequences, name)
+			}
+		}
+		for name := range pack.Dependencies {
+			if !changed[parsers.YAML_KEY_DEPENDENCY][name] {
+				delete(pack.Dependencies, name)
+			}
+		}
+	}
+	for name := range deployer.Deployment.Triggers {
+		if !changed[parsers.YAML_KEY_TRIGGER][name] {
+			delete(deployer.Deployment.Triggers, name)
+		}
+	}
+	for name, rule := range deployer.Deployment.Rules {
+		if !changed[parsers.YAML_KEY_RULE][name] && !changed[parsers.YAML_KEY_TRIGGER][entityName(rule.Trigger)] {
+			delete(deployer.Deployment.Rules, name)
+		}
+	}
+	for name := range deployer.Deployment.Apis {
+		if !changed[parsers.YAML_KEY_API][name] {
+			delete(deployer.Deployment.Apis, name)
+		}
+	}
+	return changes
+}
+
+// recordHistory adds the deployment which just succeeded to the history of
+// the project, a failure to write it does not fail the deployment
+func (deployer *ServiceDeployer) recordHistory() {
+	if !deployer.Flags.History {
+		return
+	}
+	historyPath := GetHistoryFilePath(deployer.ProjectPath)
+	history, err := ReadHistory(historyPath)
+	if err != nil {
+		wskprint.PrintlnOpenWhiskWarning(err.Error())
+		return
+	}
+	history.Add(deployer.NewHistoryDeployment(time.Now().UTC()))
+	if err := history.Write(historyPath); err != nil {
+		wskprint.PrintlnOpenWhiskWarning(err.Error())
+		return
+	}
+	deployer.Output.Debug(whisk.DbgInfo, wski18n.T(wski18n.ID_MSG_HISTORY_RECORDED_X_path_X,
+		map[string]interface{}{wski18n.KEY_PATH: historyPath}))
+}
+
+func historyKeyValues(keyValues whisk.KeyValueArr) map[string]interface{} {
+	values := make(map[string]interface{}, len(keyValues))
+	for _, keyValue := range keyValues {
+		values[keyValue.Key] = jsonValue(keyValue.Value)
+	}
+	return values
+}
+
+// historyHash hashes the JSON of an entity, the keys of maps being sorted
+func historyHash(value interface{}) string {
+	content, err := json.Marshal(jsonValue(value))
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])[:12]
+}
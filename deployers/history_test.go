@@ -0,0 +1,127 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newHistoryDeployer(code string) *ServiceDeployer {
+	deployer := NewServiceDeployer()
+	pack := NewDeploymentPackage()
+	pack.Package = &whisk.Package{Name: "hello"}
+	pack.Actions["world"] = utils.ActionRecord{Action: &whisk.Action{
+		Name:        "world",
+		Exec:        &whisk.Exec{Kind: "nodejs:6", Code: &code},
+		Parameters:  whisk.KeyValueArr{{Key: "name", Value: "Amy"}, {Key: "place", Value: "Paris"}},
+		Annotations: whisk.KeyValueArr{{Key: utils.MANAGED, Value: map[string]interface{}{"projectHash": code}}},
+	}}
+	deployer.Deployment.Packages["hello"] = pack
+	deployer.Deployment.Triggers["everyminute"] = &whisk.Trigger{Name: "everyminute"}
+	return deployer
+}
+
+func TestDeploymentHistory_Changelog(t *testing.T) {
+	first := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(time.Hour)
+
+	history := &DeploymentHistory{}
+	assert.Empty(t, history.Changelog())
+
+	history.Add(newHistoryDeployer("v1").NewHistoryDeployment(first))
+	assert.Equal(t, 3, len(history.Changelog()), "everything is added by the first deployment")
+
+	deployer := newHistoryDeployer("v2")
+	delete(deployer.Deployment.Triggers, "everyminute")
+	deployer.Deployment.Rules["hourly"] = &whisk.Rule{Name: "hourly", Trigger: "everyhour", Action: "hello/world"}
+	history.Add(deployer.NewHistoryDeployment(second))
+
+	changes := history.Changelog()
+	assert.Equal(t, []HistoryChange{
+		{Entity: parsers.YAML_KEY_ACTION, Name: "hello/world", Change: HISTORY_CHANGE_CHANGED, Updated: second},
+		{Entity: parsers.YAML_KEY_RULE, Name: "hourly", Change: HISTORY_CHANGE_ADDED, Updated: second},
+		{Entity: parsers.YAML_KEY_TRIGGER, Name: "everyminute", Change: HISTORY_CHANGE_REMOVED, Updated: second},
+	}, changes)
+	assert.Equal(t, "~ action hello/world", changes[0].String())
+	assert.Equal(t, first, history.Last().Entities[parsers.YAML_KEY_PACKAGE]["hello"].Updated, "the package did not change")
+
+	// neither the order of parameters nor the managed annotation is a change
+	deployer = newHistoryDeployer("v2")
+	action := deployer.Deployment.Packages["hello"].Actions["world"].Action
+	action.Parameters[0], action.Parameters[1] = action.Parameters[1], action.Parameters[0]
+	action.Annotations[0].Value = map[string]interface{}{"projectHash": "v3"}
+	assert.Equal(t, history.Last().Entities[parsers.YAML_KEY_ACTION]["hello/world"].Hash,
+		deployer.NewHistoryDeployment(second).Entities[parsers.YAML_KEY_ACTION]["hello/world"].Hash)
+
+	for i := 0; i < HISTORY_MAX_DEPLOYMENTS; i++ {
+		history.Add(deployer.NewHistoryDeployment(second))
+	}
+	assert.Equal(t, HISTORY_MAX_DEPLOYMENTS, len(history.Deployments))
+}
+
+func TestDeploymentHistory_Write(t *testing.T) {
+	dir, err := ioutil.TempDir("", "history")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	historyPath := GetHistoryFilePath(dir)
+
+	history, err := ReadHistory(historyPath)
+	assert.Nil(t, err)
+	assert.Nil(t, history.Last(), "there is no history yet")
+
+	history.Add(newHistoryDeployer("v1").NewHistoryDeployment(time.Now().UTC()))
+	assert.Nil(t, history.Write(historyPath))
+
+	read, err := ReadHistory(historyPath)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(read.Deployments))
+	assert.Equal(t, history.Last().Entities, read.Last().Entities)
+}
+
+func TestServiceDeployer_RetainChanges(t *testing.T) {
+	previous := newHistoryDeployer("v1")
+	previous.Deployment.Rules["minuteRule"] = &whisk.Rule{Name: "minuteRule", Trigger: "everyminute", Action: "hello/world"}
+	deployed := previous.NewHistoryDeployment(time.Now())
+
+	deployer := newHistoryDeployer("v1")
+	deployer.Deployment.Rules["minuteRule"] = &whisk.Rule{Name: "minuteRule", Trigger: "everyminute", Action: "hello/world"}
+	assert.Empty(t, deployer.RetainChanges(deployed, deployer.NewHistoryDeployment(time.Now())))
+	assert.Empty(t, deployer.Deployment.Packages["hello"].Actions, "the action did not change")
+	assert.Empty(t, deployer.Deployment.Triggers)
+	assert.Empty(t, deployer.Deployment.Rules)
+	assert.NotNil(t, deployer.Deployment.Packages["hello"], "packages are kept")
+
+	deployer = newHistoryDeployer("v2")
+	deployer.Deployment.Triggers["everyminute"].Parameters = whisk.KeyValueArr{{Key: "cron", Value: "* * * * *"}}
+	deployer.Deployment.Rules["minuteRule"] = &whisk.Rule{Name: "minuteRule", Trigger: "everyminute", Action: "hello/world"}
+	changes := deployer.RetainChanges(deployed, deployer.NewHistoryDeployment(time.Now()))
+	assert.Equal(t, 2, len(changes))
+	assert.Equal(t, 1, len(deployer.Deployment.Packages["hello"].Actions))
+	assert.Equal(t, 1, len(deployer.Deployment.Triggers))
+	assert.Equal(t, 1, len(deployer.Deployment.Rules), "the rule of a changed trigger is redeployed")
+}
@@ -0,0 +1,198 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskenv"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// variables set for the commands run by hooks
+const (
+	HOOK_ENV_PROJECT   = "WSKDEPLOY_PROJECT"
+	HOOK_ENV_NAMESPACE = "WSKDEPLOY_NAMESPACE"
+	HOOK_ENV_ENTITY    = "WSKDEPLOY_ENTITY"
+	HOOK_ENV_HOOK      = "WSKDEPLOY_HOOK"
+)
+
+// DeploymentHooks are the pre_deploy and post_deploy hooks of a package or an
+// action, see parsers.Hook
+type DeploymentHooks struct {
+	// the package, or package/action, the hooks are named after
+	Entity     string
+	PreDeploy  []parsers.Hook
+	PostDeploy []parsers.Hook
+}
+
+// NewDeploymentHooks validates the hooks of an entity, nil is returned if it
+// has none
+func NewDeploymentHooks(filePath string, entity string, preDeploy []parsers.Hook, postDeploy []parsers.Hook) (*DeploymentHooks, error) {
+	if len(preDeploy) == 0 && len(postDeploy) == 0 {
+		return nil, nil
+	}
+	if err := parsers.ValidateHooks(filePath, entity, parsers.YAML_KEY_PRE_DEPLOY, preDeploy); err != nil {
+		return nil, err
+	}
+	if err := parsers.ValidateHooks(filePath, entity, parsers.YAML_KEY_POST_DEPLOY, postDeploy); err != nil {
+		return nil, err
+	}
+	return &DeploymentHooks{Entity: entity, PreDeploy: preDeploy, PostDeploy: postDeploy}, nil
+}
+
+// actionHooks returns the hooks of an action of the plan, nil if it has none
+func (deployer *ServiceDeployer) actionHooks(packageName string, actionName string) *DeploymentHooks {
+	pack, ok := deployer.Deployment.Packages[packageName]
+	if !ok {
+		return nil
+	}
+	return pack.ActionHooks[actionName]
+}
+
+// RunPostDeployHooks runs the post_deploy hooks of the packages once their
+// actions and sequences are deployed, in the namespace of each package
+func (deployer *ServiceDeployer) RunPostDeployHooks() error {
+	names := make([]string, 0, len(deployer.Deployment.Packages))
+	for name, pack := range deployer.Deployment.Packages {
+		if pack.Hooks != nil && len(pack.Hooks.PostDeploy) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pack := deployer.Deployment.Packages[name]
+		err := deployer.deployEntity(parsers.YAML_KEY_POST_DEPLOY, name, func(deployer *ServiceDeployer) error {
+			return deployer.inNamespace(pack.Package.Namespace, func() error {
+				return deployer.runHooks(pack.Hooks, parsers.YAML_KEY_POST_DEPLOY)
+			})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runHooks runs the pre_deploy or post_deploy hooks, in order. A hook which
+// fails fails the entity, unless its on_failure is continue.
+func (deployer *ServiceDeployer) runHooks(hooks *DeploymentHooks, key string) error {
+	if hooks == nil {
+		return nil
+	}
+	list := hooks.PreDeploy
+	if key == parsers.YAML_KEY_POST_DEPLOY {
+		list = hooks.PostDeploy
+	}
+	for i, hook := range list {
+		name := parsers.HookName(hooks.Entity, key, i)
+		var output string
+		var err error
+		if len(hook.Run) > 0 {
+			output, err = deployer.runHookCommand(name, hook, hooks.Entity, key)
+		} else {
+			output, err = deployer.invokeHookAction(name, hook)
+		}
+		if err == nil {
+			deployer.Output.Debug(whisk.DbgInfo, output)
+			continue
+		}
+		if hook.OnFailure == parsers.HOOK_ON_FAILURE_CONTINUE {
+			deployer.Output.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_HOOK_FAILED_X_name_X_err_X,
+				map[string]interface{}{wski18n.KEY_NAME: name, wski18n.KEY_ERR: err.Error()}))
+			continue
+		}
+		return wskderrors.NewCommandError(name, strings.TrimSpace(wski18n.T(wski18n.ID_ERR_HOOK_FAILED_X_name_X_err_X_output_X,
+			map[string]interface{}{wski18n.KEY_NAME: name, wski18n.KEY_ERR: err.Error(), wski18n.KEY_OUTPUT: strings.TrimSpace(output)})))
+	}
+	return nil
+}
+
+// runHookCommand runs the command of a hook with the shell, in the directory
+// of the manifest, and returns its output
+func (deployer *ServiceDeployer) runHookCommand(name string, hook parsers.Hook, entity string, key string) (string, error) {
+	deployer.Output.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_HOOK_RUN_X_name_X_command_X,
+		map[string]interface{}{wski18n.KEY_NAME: name, wski18n.KEY_COMMAND: hook.Run}))
+
+	shell := []string{"sh", "-c"}
+	if runtime.GOOS == "windows" {
+		shell = []string{"cmd", "/C"}
+	}
+	var command *exec.Cmd
+	if deployer.Context != nil {
+		command = exec.CommandContext(deployer.Context, shell[0], shell[1], hook.Run)
+	} else {
+		command = exec.Command(shell[0], shell[1], hook.Run)
+	}
+	command.Dir = filepath.Dir(deployer.ManifestPath)
+	namespace := ""
+	if deployer.Client != nil {
+		namespace = deployer.Client.Namespace
+	}
+	command.Env = append(os.Environ(),
+		HOOK_ENV_PROJECT+"="+deployer.ProjectName,
+		HOOK_ENV_NAMESPACE+"="+namespace,
+		HOOK_ENV_ENTITY+"="+entity,
+		HOOK_ENV_HOOK+"="+key)
+	output, err := command.CombinedOutput()
+	return string(output), err
+}
+
+// invokeHookAction invokes the action of a hook and waits for its result, an
+// action which is not fully qualified is one of the namespace of the entity
+func (deployer *ServiceDeployer) invokeHookAction(name string, hook parsers.Hook) (string, error) {
+	action := hook.Action
+	if value, ok := wskenv.GetEnvVar(action).(string); ok {
+		action = value
+	}
+	deployer.Output.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_HOOK_INVOKE_X_name_X_action_X,
+		map[string]interface{}{wski18n.KEY_NAME: name, wski18n.KEY_ACTION: action}))
+
+	params := make(map[string]interface{}, len(hook.Inputs))
+	for key, value := range hook.Inputs {
+		params[key] = wskenv.GetEnvVar(value)
+	}
+	qName, err := utils.ParseQualifiedName(action, "")
+	if err != nil {
+		return "", err
+	}
+	namespace := ""
+	if strings.HasPrefix(action, "/") {
+		namespace = qName.Namespace
+	}
+	var result map[string]interface{}
+	err = deployer.inNamespace(namespace, func() error {
+		result, _, err = deployer.Client.Actions.Invoke(qName.EntityName, params, true, true)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	output, err := json.Marshal(result)
+	return string(output), err
+}
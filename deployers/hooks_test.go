@@ -0,0 +1,86 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDeploymentHooks(t *testing.T) {
+	hooks, err := NewDeploymentHooks("manifest.yaml", "hello", nil, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, hooks)
+
+	hooks, err = NewDeploymentHooks("manifest.yaml", "hello/greeting",
+		[]parsers.Hook{{Run: "./migrate.sh"}}, []parsers.Hook{{Action: "hello/warm", OnFailure: parsers.HOOK_ON_FAILURE_CONTINUE}})
+	assert.Nil(t, err)
+	assert.Equal(t, "hello/greeting", hooks.Entity)
+
+	invalid := [][]parsers.Hook{
+		{{}},
+		{{Run: "./migrate.sh", Action: "hello/migrate"}},
+		{{Run: "./migrate.sh", Inputs: map[string]interface{}{"version": 2}}},
+		{{Run: "./migrate.sh", OnFailure: "ignore"}},
+	}
+	for _, preDeploy := range invalid {
+		_, err := NewDeploymentHooks("manifest.yaml", "hello", preDeploy, nil)
+		if assert.NotNil(t, err) {
+			assert.Contains(t, err.Error(), "hello pre_deploy #1")
+		}
+	}
+}
+
+func TestServiceDeployer_runHooks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wskdeploy-hooks")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	deployer := NewServiceDeployer()
+	deployer.ProjectName = "helloworld"
+	deployer.ManifestPath = filepath.Join(dir, "manifest.yaml")
+	hooks := &DeploymentHooks{
+		Entity:     "hello/greeting",
+		PreDeploy:  []parsers.Hook{{Run: "echo $WSKDEPLOY_PROJECT $WSKDEPLOY_ENTITY $WSKDEPLOY_HOOK > hook.txt"}},
+		PostDeploy: []parsers.Hook{{Run: "echo migrating; exit 3"}},
+	}
+
+	// the command runs in the directory of the manifest
+	assert.Nil(t, deployer.runHooks(hooks, parsers.YAML_KEY_PRE_DEPLOY))
+	content, err := ioutil.ReadFile(filepath.Join(dir, "hook.txt"))
+	assert.Nil(t, err)
+	assert.Equal(t, "helloworld hello/greeting pre_deploy", strings.TrimSpace(string(content)))
+
+	err = deployer.runHooks(hooks, parsers.YAML_KEY_POST_DEPLOY)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "hello/greeting post_deploy #1")
+		assert.Contains(t, err.Error(), "migrating")
+	}
+
+	hooks.PostDeploy[0].OnFailure = parsers.HOOK_ON_FAILURE_CONTINUE
+	assert.Nil(t, deployer.runHooks(hooks, parsers.YAML_KEY_POST_DEPLOY))
+	assert.Nil(t, deployer.runHooks(nil, parsers.YAML_KEY_PRE_DEPLOY))
+}
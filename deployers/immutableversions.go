@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// annotation of the packages deployed with --immutable-versions which holds
+// the hash of the package and of its actions and sequences
+const ANNOTATION_CONTENT_HASH = "content-hash"
+
+// CheckImmutableVersions fails, with --immutable-versions, if a package of the
+// project is already deployed with the same version and another content, so
+// that each change of a shared package requires a new version. The packages
+// are annotated with the hash of their content, the packages deployed before
+// without it are accepted.
+func (deployer *ServiceDeployer) CheckImmutableVersions() error {
+	if !deployer.Flags.ImmutableVersions {
+		return nil
+	}
+
+	hashes := deployer.packageContentHashes()
+	violations := make([]string, 0)
+	for packName, pack := range deployer.Deployment.Packages {
+		packa := pack.Package
+		if packa == nil || (packa.Binding != nil && len(packa.Binding.Name) > 0) {
+			continue
+		}
+		version, _ := packa.Annotations.GetValue(parsers.ANNOTATION_PACKAGE_VERSION).(string)
+
+		var deployed *whisk.Package
+		found, err := deployer.getDeployed(packa.Namespace, func() (*http.Response, error) {
+			var response *http.Response
+			var err error
+			deployed, response, err = deployer.Client.Packages.Get(packa.Name)
+			return response, err
+		})
+		if err != nil {
+			return err
+		}
+		if found && deployed != nil {
+			if violation := immutableVersionViolation(packName, version, hashes[packName], deployed); len(violation) > 0 {
+				violations = append(violations, violation)
+			} else if deployedVersion, _ := deployed.Annotations.GetValue(parsers.ANNOTATION_PACKAGE_VERSION).(string); len(deployedVersion) > 0 && deployedVersion != version {
+				wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_PACKAGE_VERSION_BUMPED_X_name_X_old_X_new_X,
+					map[string]interface{}{wski18n.KEY_NAME: packName, wski18n.KEY_OLD: deployedVersion, wski18n.KEY_NEW: version}))
+			}
+		}
+		packa.Annotations = append(removeKeyValue(packa.Annotations, ANNOTATION_CONTENT_HASH),
+			whisk.KeyValue{Key: ANNOTATION_CONTENT_HASH, Value: hashes[packName]})
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	sort.Strings(violations)
+	return wskderrors.NewImmutableVersionError(wski18n.T(wski18n.ID_ERR_IMMUTABLE_VERSIONS), violations)
+}
+
+// immutableVersionViolation returns why the package may not replace the one
+// deployed, i.e. its version is the same but not the hash of its content, or
+// an empty string
+func immutableVersionViolation(name string, version string, hash string, deployed *whisk.Package) string {
+	deployedVersion, _ := deployed.Annotations.GetValue(parsers.ANNOTATION_PACKAGE_VERSION).(string)
+	deployedHash, _ := deployed.Annotations.GetValue(ANNOTATION_CONTENT_HASH).(string)
+	if deployedVersion != version || len(deployedHash) == 0 || deployedHash == hash {
+		return ""
+	}
+	return wski18n.T(wski18n.ID_MSG_IMMUTABLE_VERSION_CHANGED_X_name_X_version_X,
+		map[string]interface{}{wski18n.KEY_NAME: name, wski18n.KEY_VERSION: version})
+}
+
+// packageContentHashes hashes each package of the plan with its actions and
+// sequences, the same way as the history of the project does
+func (deployer *ServiceDeployer) packageContentHashes() map[string]string {
+	entities := deployer.NewHistoryDeployment(time.Time{}).Entities
+	hashes := make(map[string]string)
+	for packName := range deployer.Deployment.Packages {
+		content := map[string]string{parsers.YAML_KEY_PACKAGE: entities[parsers.YAML_KEY_PACKAGE][packName].Hash}
+		for _, entity := range []string{parsers.YAML_KEY_ACTION, parsers.YAML_KEY_SEQUENCE} {
+			for name, e := range entities[entity] {
+				if strings.HasPrefix(name, packName+"/") {
+					content[entity+" "+name] = e.Hash
+				}
+			}
+		}
+		hashes[packName] = historyHash(content)
+	}
+	return hashes
+}
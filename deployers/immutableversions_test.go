@@ -0,0 +1,53 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceDeployer_packageContentHashes(t *testing.T) {
+	deployer := newHistoryDeployer("v1")
+	deployer.Deployment.Packages["other"] = &DeploymentPackage{Package: &whisk.Package{Name: "other"}}
+	hashes := deployer.packageContentHashes()
+	assert.Equal(t, hashes, deployer.packageContentHashes())
+
+	changed := newHistoryDeployer("v2")
+	changed.Deployment.Packages["other"] = &DeploymentPackage{Package: &whisk.Package{Name: "other"}}
+	changedHashes := changed.packageContentHashes()
+	assert.NotEqual(t, hashes["hello"], changedHashes["hello"], "the code of an action of the package changed")
+	assert.Equal(t, hashes["other"], changedHashes["other"])
+}
+
+func TestImmutableVersionViolation(t *testing.T) {
+	deployed := &whisk.Package{Name: "hello", Annotations: whisk.KeyValueArr{
+		{Key: parsers.ANNOTATION_PACKAGE_VERSION, Value: "1.0.0"},
+		{Key: ANNOTATION_CONTENT_HASH, Value: "3f1a0c2b9d4e"},
+	}}
+	assert.Empty(t, immutableVersionViolation("hello", "1.0.0", "3f1a0c2b9d4e", deployed), "the same content")
+	assert.Empty(t, immutableVersionViolation("hello", "1.1.0", "a7c41d5e0b12", deployed), "a new version")
+	assert.Contains(t, immutableVersionViolation("hello", "1.0.0", "a7c41d5e0b12", deployed), "1.0.0")
+	assert.Empty(t, immutableVersionViolation("hello", "1.0.0", "a7c41d5e0b12", &whisk.Package{Name: "hello"}),
+		"packages deployed without a content hash are accepted")
+}
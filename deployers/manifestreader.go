@@ -18,13 +18,15 @@
 package deployers
 
 import (
-	"errors"
+	"path"
 	"strings"
 
 	"github.com/apache/incubator-openwhisk-client-go/whisk"
 	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
 	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
 )
 
 var clientConfig *whisk.Config
@@ -44,14 +46,62 @@ func NewManifestReader(serviceDeployer *ServiceDeployer) *ManifestReader {
 func (deployer *ManifestReader) ParseManifest() (*parsers.YAML, *parsers.YAMLParser, error) {
 	dep := deployer.serviceDeployer
 	manifestParser := parsers.NewYAMLParser()
+	manifestParser.Flags = dep.Flags
+	if dep.ClientConfig != nil {
+		manifestParser.Namespace = dep.ClientConfig.Namespace
+	}
+	// the overlays and values of --set override the manifest of the project,
+	// not the ones of its dependencies
+	if !dep.IsDependency {
+		manifestParser.Overlays = dep.Flags.Overlays
+		manifestParser.Overrides = dep.Flags.Set
+	}
 	manifest, err := manifestParser.ParseManifest(dep.ManifestPath)
 
 	if err != nil {
 		return manifest, manifestParser, err
 	}
+	// the manifest of the project, if the manifest path is a directory
+	dep.ManifestPath = manifest.Filepath
+
+	if !deployer.IsUndeploy && !dep.IsDependency {
+		if err := ValidateNotEmpty(manifest, dep.ManifestPath, dep.IsDefault, dep.Flags); err != nil {
+			return manifest, manifestParser, err
+		}
+	}
+
+	// packages left out with --packages or --exclude-package, the packages of
+	// dependencies are not selected
+	if dep.Flags.HasPackageSelection() && !dep.IsDependency {
+		if pattern, err := dep.Flags.ValidatePackagePatterns(); err != nil {
+			return manifest, manifestParser, wskderrors.NewCommandError("--packages",
+				wski18n.T(wski18n.ID_ERR_PACKAGE_PATTERN_INVALID_X_value_X_err_X,
+					map[string]interface{}{wski18n.KEY_VALUE: pattern, wski18n.KEY_ERR: err.Error()}))
+		}
+		if deployer.IsUndeploy {
+			retainDependencies(dep, manifest)
+		}
+		if manifest.SelectPackages(dep.Flags.IsPackageSelected) == 0 {
+			wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_NO_PACKAGE_SELECTED_X_path_X,
+				map[string]interface{}{wski18n.KEY_PATH: dep.ManifestPath}))
+		}
+	}
 	return manifest, manifestParser, nil
 }
 
+// retainDependencies records the dependencies of the packages which are left
+// out of the undeployment, they are still used by the project
+func retainDependencies(deployer *ServiceDeployer, manifest *parsers.YAML) {
+	for name, pkg := range manifest.GetPackages() {
+		if deployer.Flags.IsPackageSelected(name) {
+			continue
+		}
+		for depName := range pkg.Dependencies {
+			deployer.RetainedDependencies[depName] = true
+		}
+	}
+}
+
 func (reader *ManifestReader) InitRootPackage(manifestParser *parsers.YAMLParser, manifest *parsers.YAML, ma whisk.KeyValue) error {
 	packages, err := manifestParser.ComposeAllPackages(manifest, reader.serviceDeployer.ManifestPath, ma)
 	if err != nil {
@@ -73,6 +123,11 @@ func (deployer *ManifestReader) HandleYaml(sdeployer *ServiceDeployer, manifestP
 		return wskderrors.NewYAMLFileFormatError(manifestName, err)
 	}
 
+	bindings, err := manifestParser.ComposeBindingsFromAllPackages(manifest, deployer.serviceDeployer.ManifestPath, ma)
+	if err != nil {
+		return wskderrors.NewYAMLFileFormatError(manifestName, err)
+	}
+
 	actions, err := manifestParser.ComposeActionsFromAllPackages(manifest, deployer.serviceDeployer.ManifestPath, ma)
 	if err != nil {
 		return wskderrors.NewYAMLFileFormatError(manifestName, err)
@@ -93,7 +148,7 @@ func (deployer *ManifestReader) HandleYaml(sdeployer *ServiceDeployer, manifestP
 		return wskderrors.NewYAMLFileFormatError(manifestName, err)
 	}
 
-	apis, err := manifestParser.ComposeApiRecordsFromAllPackages(manifest)
+	apis, err := manifestParser.ComposeApiRecordsFromAllPackages(manifest, deployer.serviceDeployer.ManifestPath)
 	if err != nil {
 		return wskderrors.NewYAMLFileFormatError(manifestName, err)
 	}
@@ -103,6 +158,11 @@ func (deployer *ManifestReader) HandleYaml(sdeployer *ServiceDeployer, manifestP
 		return wskderrors.NewYAMLFileFormatError(manifestName, err)
 	}
 
+	err = deployer.SetBindings(bindings)
+	if err != nil {
+		return wskderrors.NewYAMLFileFormatError(manifestName, err)
+	}
+
 	err = deployer.SetActions(actions)
 	if err != nil {
 		return wskderrors.NewYAMLFileFormatError(manifestName, err)
@@ -113,6 +173,11 @@ func (deployer *ManifestReader) HandleYaml(sdeployer *ServiceDeployer, manifestP
 		return wskderrors.NewYAMLFileFormatError(manifestName, err)
 	}
 
+	err = deployer.SetHooks(manifest)
+	if err != nil {
+		return err
+	}
+
 	err = deployer.SetTriggers(triggers)
 	if err != nil {
 		return wskderrors.NewYAMLFileFormatError(manifestName, err)
@@ -131,21 +196,30 @@ func (deployer *ManifestReader) HandleYaml(sdeployer *ServiceDeployer, manifestP
 	return nil
 }
 
+// SetDependencies records the dependencies of the packages, the ones which are
+// not installed yet are fetched concurrently, see --parallel-fetches
 func (reader *ManifestReader) SetDependencies(deps map[string]utils.DependencyRecord) error {
+	fetches := make([]*utils.GitReader, 0)
 	for name, dep := range deps {
 		n := strings.Split(name, ":")
 		depName := n[1]
 		if (depName == "") {
-			return nil
+			return fetchDependencies(fetches, reader.serviceDeployer.Flags.ParallelFetches)
+		}
+		// on undeployment, a dependency which is not cloned anymore is cloned
+		// again so that its entities are known
+		cloned := reader.IsUndeploy && utils.FileExists(dependencyProjectPath(depName, dep))
+		// a dependency already deployed from the same location and version is
+		// neither fetched nor deployed again, see --reuse-dependencies
+		if !reader.IsUndeploy && reader.serviceDeployer.isDependencyDeployed(depName, dep,
+			reader.serviceDeployer.Deployment.Packages[dep.Packagename].Package.Namespace) {
+			reader.serviceDeployer.ReusedDependencies[depName] = true
+			cloned = true
 		}
-		if !dep.IsBinding && !reader.IsUndeploy {
+		if !dep.IsBinding && !cloned {
 			if _, exists := reader.serviceDeployer.DependencyMaster[depName]; !exists {
 				// dependency
-				gitReader := utils.NewGitReader(depName, dep)
-				err := gitReader.CloneDependency()
-				if err != nil {
-					return wskderrors.NewYAMLFileFormatError(depName, err)
-				}
+				fetches = append(fetches, utils.NewGitReader(depName, dep))
 			} else {
 				// TODO: we should do a check to make sure this dependency is compatible with an already installed one.
 				// If not, we should throw dependency mismatch error.
@@ -158,6 +232,56 @@ func (reader *ManifestReader) SetDependencies(deps map[string]utils.DependencyRe
 
 	}
 
+	return fetchDependencies(fetches, reader.serviceDeployer.Flags.ParallelFetches)
+}
+
+// SetBindings records the bindings of the packages to deployed packages
+func (reader *ManifestReader) SetBindings(bindings map[string][]*whisk.BindingPackage) error {
+	dep := reader.serviceDeployer
+
+	dep.mt.Lock()
+	defer dep.mt.Unlock()
+
+	for packageName, packageBindings := range bindings {
+		pkg, exist := dep.Deployment.Packages[packageName]
+		if !exist {
+			continue
+		}
+		for _, binding := range packageBindings {
+			pkg.Bindings[binding.Name] = binding
+		}
+	}
+	return nil
+}
+
+// SetHooks records the pre_deploy and post_deploy hooks of the packages and
+// actions of the manifest
+func (reader *ManifestReader) SetHooks(manifest *parsers.YAML) error {
+	dep := reader.serviceDeployer
+
+	dep.mt.Lock()
+	defer dep.mt.Unlock()
+
+	for packageName, pkg := range manifest.GetPackages() {
+		depPkg, exist := dep.Deployment.Packages[packageName]
+		if !exist {
+			continue
+		}
+		hooks, err := NewDeploymentHooks(manifest.Filepath, packageName, pkg.PreDeploy, pkg.PostDeploy)
+		if err != nil {
+			return err
+		}
+		depPkg.Hooks = hooks
+		for actionName, action := range pkg.Actions {
+			hooks, err := NewDeploymentHooks(manifest.Filepath, path.Join(packageName, actionName), action.PreDeploy, action.PostDeploy)
+			if err != nil {
+				return err
+			}
+			if hooks != nil {
+				depPkg.ActionHooks[actionName] = hooks
+			}
+		}
+	}
 	return nil
 }
 
@@ -182,10 +306,10 @@ func (reader *ManifestReader) SetPackage(packages map[string]*whisk.Package) err
 				dep.Deployment.Packages[pkg.Name].Package = existPkg
 				return nil
 			} else {
-				// TODO(): i18n of error message (or create a new named error)
 				// TODO(): Is there a better way to handle an existing dependency of same name?
-				err := errors.New("Package [" + pkg.Name + "] exists already.")
-				return wskderrors.NewYAMLParserErr(reader.serviceDeployer.ManifestPath, err)
+				return wskderrors.NewYAMLParserErr(reader.serviceDeployer.ManifestPath,
+					wski18n.T(wski18n.ID_ERR_PACKAGE_EXISTS_X_package_X,
+						map[string]interface{}{wski18n.KEY_PACKAGE: pkg.Name}))
 			}
 		}
 		newPack := NewDeploymentPackage()
@@ -235,11 +359,10 @@ func (reader *ManifestReader) SetActions(actions []utils.ActionRecord) error {
 
 			} else {
 				// Action exists, but references two different sources
-				// TODO(): i18n of error message (or create a new named error)
-				err := errors.New("Conflict detected for action named [" +
-					existAction.Action.Name + "].\nFound two locations for source file: [" +
-					existAction.Filepath + "] and [" + manifestAction.Filepath + "]")
-				return wskderrors.NewYAMLParserErr(reader.serviceDeployer.ManifestPath, err)
+				return wskderrors.NewYAMLParserErr(reader.serviceDeployer.ManifestPath,
+					wski18n.T(wski18n.ID_ERR_ACTION_SOURCE_CONFLICT_X_action_X_source_X_path_X,
+						map[string]interface{}{wski18n.KEY_ACTION: existAction.Action.Name,
+							wski18n.KEY_SOURCE: existAction.Filepath, wski18n.KEY_PATH: manifestAction.Filepath}))
 			}
 		} else {
 			// not a new action so update the action in the package
@@ -257,23 +380,23 @@ func (reader *ManifestReader) SetActions(actions []utils.ActionRecord) error {
 // TODO create named errors
 func (reader *ManifestReader) checkAction(action utils.ActionRecord) error {
 	if action.Filepath == "" {
-		// TODO(): i18n of error message (or create a new named error)
-		err := errors.New("Action [" + action.Action.Name + "] has no source code location set.")
-		return wskderrors.NewYAMLParserErr(reader.serviceDeployer.ManifestPath, err)
+		return wskderrors.NewYAMLParserErr(reader.serviceDeployer.ManifestPath,
+			wski18n.T(wski18n.ID_ERR_ACTION_SOURCE_LOCATION_MISSING_X_action_X,
+				map[string]interface{}{wski18n.KEY_ACTION: action.Action.Name}))
 	}
 
 	if action.Action.Exec.Kind == "" {
-		// TODO(): i18n of error message (or create a new named error)
-		err := errors.New("Action [" + action.Action.Name + "] has no kind set.")
-		return wskderrors.NewYAMLParserErr(reader.serviceDeployer.ManifestPath, err)
+		return wskderrors.NewYAMLParserErr(reader.serviceDeployer.ManifestPath,
+			wski18n.T(wski18n.ID_ERR_ACTION_KIND_MISSING_X_action_X,
+				map[string]interface{}{wski18n.KEY_ACTION: action.Action.Name}))
 	}
 
 	if action.Action.Exec.Code != nil {
 		code := *action.Action.Exec.Code
 		if code == "" && action.Action.Exec.Kind != "sequence" {
-			// TODO(): i18n of error message (or create a new named error)
-			err := errors.New("Action [" + action.Action.Name + "] has no source code.")
-			return wskderrors.NewYAMLParserErr(reader.serviceDeployer.ManifestPath, err)
+			return wskderrors.NewYAMLParserErr(reader.serviceDeployer.ManifestPath,
+				wski18n.T(wski18n.ID_ERR_ACTION_SOURCE_CODE_MISSING_X_action_X,
+					map[string]interface{}{wski18n.KEY_ACTION: action.Action.Name}))
 		}
 	}
 
@@ -291,10 +414,9 @@ func (reader *ManifestReader) SetSequences(actions []utils.ActionRecord) error {
 		// If the sequence action exists in actions, return error
 		_, exists := reader.serviceDeployer.Deployment.Packages[seqAction.Packagename].Actions[seqAction.Action.Name]
 		if exists == true {
-			// TODO(): i18n of error message (or create a new named error)
-			err := errors.New("Sequence action's name [" +
-				seqAction.Action.Name + "] is already used by an action.")
-			return wskderrors.NewYAMLParserErr(reader.serviceDeployer.ManifestPath, err)
+			return wskderrors.NewYAMLParserErr(reader.serviceDeployer.ManifestPath,
+				wski18n.T(wski18n.ID_ERR_SEQUENCE_NAME_USED_BY_ACTION_X_sequence_X,
+					map[string]interface{}{wski18n.KEY_SEQUENCE: seqAction.Action.Name}))
 		}
 		existAction, exists := reader.serviceDeployer.Deployment.Packages[seqAction.Packagename].Sequences[seqAction.Action.Name]
 
@@ -373,19 +495,17 @@ func (reader *ManifestReader) SetRules(rules []*whisk.Rule) error {
 
 func (reader *ManifestReader) SetApis(ar []*whisk.ApiCreateRequest) error {
 	dep := reader.serviceDeployer
-	var apis []*whisk.ApiCreateRequest = make([]*whisk.ApiCreateRequest, 0)
 
 	dep.mt.Lock()
 	defer dep.mt.Unlock()
 
-	for _, api := range apis {
-		existApi, exist := dep.Deployment.Apis[api.ApiDoc.ApiName]
-		if exist {
-			existApi.ApiDoc.ApiName = api.ApiDoc.ApiName
-		} else {
-			dep.Deployment.Apis[api.ApiDoc.ApiName] = api
+	for _, api := range ar {
+		// APIs declared by routes are not deployed yet, their actions are only
+		// known by name (see Package.GetApis())
+		if len(api.ApiDoc.Swagger) == 0 {
+			continue
 		}
-
+		dep.Deployment.Apis[api.ApiDoc.ApiName] = api
 	}
 	return nil
 }
@@ -21,6 +21,7 @@ package deployers
 
 import (
 	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"github.com/apache/incubator-openwhisk-client-go/whisk"
@@ -59,3 +60,25 @@ func TestManifestReader_param(t *testing.T) {
 
 	// TODO.
 }
+
+func TestManifestReader_ParseManifest_RetainedDependencies(t *testing.T) {
+	utils.Flags.Packages = []string{"billing"}
+	defer func() { utils.Flags.Packages = nil }()
+
+	deployer := NewServiceDeployer()
+	deployer.ManifestPath = "../tests/dat/manifest_validate_dependencies_undeploy.yaml"
+	reader := NewManifestReader(deployer)
+	reader.IsUndeploy = true
+	manifest, _, err := reader.ParseManifest()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(manifest.GetPackages()))
+	// the dependency of the package left out is still used
+	assert.Equal(t, map[string]bool{"common": true}, deployer.RetainedDependencies)
+
+	deployer = NewServiceDeployer()
+	deployer.ManifestPath = "../tests/dat/manifest_validate_dependencies_undeploy.yaml"
+	deployer.IsDependency = true
+	_, _, err = NewManifestReader(deployer).ParseManifest()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(deployer.RetainedDependencies), "the packages of dependencies are not selected")
+}
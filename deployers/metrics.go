@@ -0,0 +1,271 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// directory (relative to the project path) and name of the file which records
+// the metrics of the last deployments of the project, written with --history
+const (
+	METRICS_DIR_NAME  = ".wskdeploy"
+	METRICS_FILE_NAME = "metrics.json"
+)
+
+// older deployments are dropped from the metrics
+const METRICS_MAX_DEPLOYMENTS = 50
+
+// the slowest entities of a deployment are kept, so that the file stays small
+// for projects with many entities
+const METRICS_MAX_ENTITIES = 10
+
+// EntityMetrics is the time an entity took to deploy
+type EntityMetrics struct {
+	Entity   string `json:"entity"`
+	Name     string `json:"name"`
+	Duration int64  `json:"durationMs"`
+}
+
+// DeploymentMetrics records how long one deployment took, whether it
+// succeeded, the number of entities of each type and its slowest entities
+type DeploymentMetrics struct {
+	Time      time.Time       `json:"time"`
+	Duration  int64           `json:"durationMs"`
+	Succeeded bool            `json:"succeeded"`
+	Entities  map[string]int  `json:"entities"`
+	Slowest   []EntityMetrics `json:"slowest"`
+}
+
+type ProjectMetrics struct {
+	Deployments []DeploymentMetrics `json:"deployments"`
+}
+
+// MetricsSummary gives the trends of the deployments recorded
+type MetricsSummary struct {
+	Deployments int
+	Failures    int
+	Last        time.Duration
+	Average     time.Duration
+	Min         time.Duration
+	Max         time.Duration
+	// entities of the first and last deployments recorded
+	FirstEntities int
+	LastEntities  int
+	// entities by average duration, the slowest first
+	Slowest []EntityMetrics
+}
+
+func GetMetricsFilePath(projectPath string) string {
+	return path.Join(projectPath, METRICS_DIR_NAME, METRICS_FILE_NAME)
+}
+
+// ReadMetrics reads the metrics of the project, which are empty if the
+// project was never deployed with --history
+func ReadMetrics(filePath string) (*ProjectMetrics, error) {
+	metrics := &ProjectMetrics{Deployments: make([]DeploymentMetrics, 0)}
+	if !utils.FileExists(filePath) {
+		return metrics, nil
+	}
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	if err := json.Unmarshal(content, metrics); err != nil {
+		return nil, wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	return metrics, nil
+}
+
+func (metrics *ProjectMetrics) Write(filePath string) error {
+	content, err := json.MarshalIndent(metrics, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(path.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filePath, content, 0644)
+}
+
+// Add appends a deployment to the metrics and drops the oldest ones
+func (metrics *ProjectMetrics) Add(deployment DeploymentMetrics) {
+	metrics.Deployments = append(metrics.Deployments, deployment)
+	if len(metrics.Deployments) > METRICS_MAX_DEPLOYMENTS {
+		metrics.Deployments = metrics.Deployments[len(metrics.Deployments)-METRICS_MAX_DEPLOYMENTS:]
+	}
+}
+
+// Summarize returns the trends of the deployments recorded, nil if there is
+// none. The slowest entities are averaged over the deployments which recorded
+// them, at most count of them are returned.
+func (metrics *ProjectMetrics) Summarize(count int) *MetricsSummary {
+	if len(metrics.Deployments) == 0 {
+		return nil
+	}
+	summary := &MetricsSummary{Deployments: len(metrics.Deployments)}
+	var total time.Duration
+	durations := make(map[EntityMetrics][]int64)
+	for i, deployment := range metrics.Deployments {
+		duration := time.Duration(deployment.Duration) * time.Millisecond
+		total += duration
+		if i == 0 || duration < summary.Min {
+			summary.Min = duration
+		}
+		if duration > summary.Max {
+			summary.Max = duration
+		}
+		if !deployment.Succeeded {
+			summary.Failures++
+		}
+		for _, entity := range deployment.Slowest {
+			key := EntityMetrics{Entity: entity.Entity, Name: entity.Name}
+			durations[key] = append(durations[key], entity.Duration)
+		}
+	}
+	summary.Average = total / time.Duration(len(metrics.Deployments))
+	summary.Last = time.Duration(metrics.Deployments[len(metrics.Deployments)-1].Duration) * time.Millisecond
+	summary.FirstEntities = countEntities(metrics.Deployments[0].Entities)
+	summary.LastEntities = countEntities(metrics.Deployments[len(metrics.Deployments)-1].Entities)
+
+	summary.Slowest = make([]EntityMetrics, 0, len(durations))
+	for key, values := range durations {
+		var sum int64
+		for _, value := range values {
+			sum += value
+		}
+		key.Duration = sum / int64(len(values))
+		summary.Slowest = append(summary.Slowest, key)
+	}
+	sortEntityMetrics(summary.Slowest)
+	if len(summary.Slowest) > count {
+		summary.Slowest = summary.Slowest[:count]
+	}
+	return summary
+}
+
+// FailureRate returns the percentage of the deployments which failed
+func (summary *MetricsSummary) FailureRate() int {
+	return summary.Failures * 100 / summary.Deployments
+}
+
+func countEntities(entities map[string]int) int {
+	count := 0
+	for _, n := range entities {
+		count += n
+	}
+	return count
+}
+
+// the slowest first, then by entity type and name
+func sortEntityMetrics(entities []EntityMetrics) {
+	sort.Slice(entities, func(i, j int) bool {
+		if entities[i].Duration != entities[j].Duration {
+			return entities[i].Duration > entities[j].Duration
+		}
+		if entities[i].Entity != entities[j].Entity {
+			return entities[i].Entity < entities[j].Entity
+		}
+		return entities[i].Name < entities[j].Name
+	})
+}
+
+// EntityDurations collects the time each entity took to deploy, entities may
+// be deployed concurrently (see --parallel)
+type EntityDurations struct {
+	mt        sync.Mutex
+	durations []EntityMetrics
+}
+
+func NewEntityDurations() *EntityDurations {
+	return &EntityDurations{durations: make([]EntityMetrics, 0)}
+}
+
+func (durations *EntityDurations) Add(entity string, name string, duration time.Duration) {
+	durations.mt.Lock()
+	defer durations.mt.Unlock()
+	durations.durations = append(durations.durations,
+		EntityMetrics{Entity: entity, Name: name, Duration: int64(duration / time.Millisecond)})
+}
+
+// Slowest returns at most count entities, the slowest first
+func (durations *EntityDurations) Slowest(count int) []EntityMetrics {
+	durations.mt.Lock()
+	slowest := append([]EntityMetrics{}, durations.durations...)
+	durations.mt.Unlock()
+	sortEntityMetrics(slowest)
+	if len(slowest) > count {
+		slowest = slowest[:count]
+	}
+	return slowest
+}
+
+// NewDeploymentMetrics returns the metrics of the deployment which started at
+// start and ended now, the entities are counted from the deployment plan
+func (deployer *ServiceDeployer) NewDeploymentMetrics(start time.Time, now time.Time, err error) DeploymentMetrics {
+	metrics := DeploymentMetrics{
+		Time:      start.UTC(),
+		Duration:  int64(now.Sub(start) / time.Millisecond),
+		Succeeded: err == nil,
+		Entities:  make(map[string]int),
+		Slowest:   make([]EntityMetrics, 0),
+	}
+	for entity, entities := range deployer.NewHistoryDeployment(time.Time{}).Entities {
+		if len(entities) > 0 {
+			metrics.Entities[entity] = len(entities)
+		}
+	}
+	if deployer.Durations != nil {
+		metrics.Slowest = deployer.Durations.Slowest(METRICS_MAX_ENTITIES)
+	}
+	return metrics
+}
+
+// recordMetrics adds the deployment which started at start to the metrics of
+// the project whether it failed or not, a failure to write them does not fail
+// the deployment
+func (deployer *ServiceDeployer) recordMetrics(start time.Time, err error) {
+	if !deployer.Flags.History {
+		return
+	}
+	metricsPath := GetMetricsFilePath(deployer.ProjectPath)
+	metrics, readErr := ReadMetrics(metricsPath)
+	if readErr != nil {
+		wskprint.PrintlnOpenWhiskWarning(readErr.Error())
+		return
+	}
+	metrics.Add(deployer.NewDeploymentMetrics(start, time.Now(), err))
+	if writeErr := metrics.Write(metricsPath); writeErr != nil {
+		wskprint.PrintlnOpenWhiskWarning(writeErr.Error())
+		return
+	}
+	deployer.Output.Debug(whisk.DbgInfo, wski18n.T(wski18n.ID_MSG_METRICS_RECORDED_X_path_X,
+		map[string]interface{}{wski18n.KEY_PATH: metricsPath}))
+}
@@ -0,0 +1,98 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectMetrics_WriteRead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "metrics")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	metricsPath := GetMetricsFilePath(dir)
+	metrics, err := ReadMetrics(metricsPath)
+	assert.Nil(t, err)
+	assert.Nil(t, metrics.Summarize(5), "no deployment is recorded yet")
+
+	deployer := newHistoryDeployer("v1")
+	start := time.Date(2018, 3, 1, 12, 0, 0, 0, time.UTC)
+	deployer.Durations.Add("action", "hello/world", 1200*time.Millisecond)
+	metrics.Add(deployer.NewDeploymentMetrics(start, start.Add(2*time.Second), nil))
+	assert.Nil(t, metrics.Write(metricsPath), "the directory of the metrics is created")
+
+	read, err := ReadMetrics(metricsPath)
+	assert.Nil(t, err)
+	assert.Equal(t, metrics, read)
+	assert.Equal(t, int64(2000), read.Deployments[0].Duration)
+	assert.Equal(t, 1, read.Deployments[0].Entities["action"])
+	assert.Equal(t, []EntityMetrics{{Entity: "action", Name: "hello/world", Duration: 1200}}, read.Deployments[0].Slowest)
+}
+
+func TestProjectMetrics_Summarize(t *testing.T) {
+	metrics := &ProjectMetrics{}
+	for i := 0; i < METRICS_MAX_DEPLOYMENTS+2; i++ {
+		metrics.Add(DeploymentMetrics{Duration: 1000, Succeeded: true, Entities: map[string]int{"action": 1}})
+	}
+	assert.Equal(t, METRICS_MAX_DEPLOYMENTS, len(metrics.Deployments), "the oldest deployments are dropped")
+
+	metrics = &ProjectMetrics{}
+	metrics.Add(DeploymentMetrics{Duration: 1000, Succeeded: true, Entities: map[string]int{"package": 1, "action": 2},
+		Slowest: []EntityMetrics{{"action", "hello/world", 600}, {"action", "hello/bye", 200}}})
+	metrics.Add(DeploymentMetrics{Duration: 4000, Succeeded: false, Entities: map[string]int{"package": 1, "action": 3},
+		Slowest: []EntityMetrics{{"action", "hello/world", 200}, {"trigger", "everyMinute", 3000}}})
+	metrics.Add(DeploymentMetrics{Duration: 1000, Succeeded: true, Entities: map[string]int{"package": 1, "action": 3}})
+
+	summary := metrics.Summarize(2)
+	assert.Equal(t, 3, summary.Deployments)
+	assert.Equal(t, 1, summary.Failures)
+	assert.Equal(t, 33, summary.FailureRate())
+	assert.Equal(t, time.Second, summary.Last)
+	assert.Equal(t, 2*time.Second, summary.Average)
+	assert.Equal(t, time.Second, summary.Min)
+	assert.Equal(t, 4*time.Second, summary.Max)
+	assert.Equal(t, 3, summary.FirstEntities)
+	assert.Equal(t, 4, summary.LastEntities)
+	assert.Equal(t, []EntityMetrics{{"trigger", "everyMinute", 3000}, {"action", "hello/world", 400}}, summary.Slowest)
+}
+
+func TestEntityDurations_Slowest(t *testing.T) {
+	durations := NewEntityDurations()
+	durations.Add("action", "hello/world", 10*time.Millisecond)
+	durations.Add("rule", "hello_rule", 30*time.Millisecond)
+	durations.Add("action", "hello/bye", 20*time.Millisecond)
+	assert.Equal(t, []EntityMetrics{{"rule", "hello_rule", 30}, {"action", "hello/bye", 20}}, durations.Slowest(2))
+}
+
+func TestServiceDeployer_deployEntity_Durations(t *testing.T) {
+	deployer := NewServiceDeployer()
+	failed := errors.New("failed")
+	assert.Equal(t, failed, deployer.deployEntity("action", "hello/world", func(deployer *ServiceDeployer) error { return failed }))
+	slowest := deployer.Durations.Slowest(METRICS_MAX_ENTITIES)
+	assert.Equal(t, 1, len(slowest), "the entities which fail are timed too")
+	assert.Equal(t, "hello/world", slowest[0].Name)
+}
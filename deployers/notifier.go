@@ -0,0 +1,334 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskenv"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// notification types, events and statuses
+const (
+	NOTIFICATION_TYPE_WEBHOOK = "webhook"
+	NOTIFICATION_TYPE_SLACK   = "slack"
+	NOTIFICATION_TYPE_TEAMS   = "teams"
+
+	NOTIFICATION_EVENT_DEPLOY   = "deploy"
+	NOTIFICATION_EVENT_UNDEPLOY = "undeploy"
+
+	NOTIFICATION_STATUS_SUCCESS = "success"
+	NOTIFICATION_STATUS_FAILURE = "failure"
+)
+
+// Notification is the structured payload posted to generic webhooks, chat
+// notifiers (Slack, Teams) post a message built from it instead
+type Notification struct {
+	Event     string `json:"event"` // e.g. "deploy.success"
+	Project   string `json:"project,omitempty"`
+	Package   string `json:"package,omitempty"`
+	Action    string `json:"action,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Timestamp string `json:"timestamp"`
+}
+
+// NewNotification creates the notification of the outcome of an event, a
+// failure if err is not nil
+func NewNotification(event string, err error) Notification {
+	notification := Notification{
+		Event:     event + "." + NOTIFICATION_STATUS_SUCCESS,
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+	}
+	if err != nil {
+		notification.Event = event + "." + NOTIFICATION_STATUS_FAILURE
+		notification.Error = err.Error()
+	}
+	return notification
+}
+
+// Notifier delivers notifications to a single destination
+type Notifier interface {
+	Notify(notification Notification) error
+}
+
+// NotifierFactory creates the notifier of a "notifications" entry of the manifest
+type NotifierFactory func(config parsers.Notification) Notifier
+
+var notifierFactories = map[string]NotifierFactory{
+	NOTIFICATION_TYPE_WEBHOOK: func(config parsers.Notification) Notifier {
+		return NewWebhookNotifier(config.Url, func(n Notification) interface{} { return n })
+	},
+	NOTIFICATION_TYPE_SLACK: func(config parsers.Notification) Notifier {
+		return NewWebhookNotifier(config.Url, func(n Notification) interface{} {
+			return map[string]interface{}{"text": n.Message()}
+		})
+	},
+	NOTIFICATION_TYPE_TEAMS: func(config parsers.Notification) Notifier {
+		return NewWebhookNotifier(config.Url, func(n Notification) interface{} {
+			return map[string]interface{}{
+				"@type":      "MessageCard",
+				"@context":   "https://schema.org/extensions",
+				"summary":    n.Event,
+				"text":       n.Message(),
+				"themeColor": n.color(),
+			}
+		})
+	},
+}
+var notifierFactoriesMt sync.RWMutex
+
+// RegisterNotifier makes a new type of notification available to manifests,
+// registering an existing type replaces its notifier
+func RegisterNotifier(notificationType string, factory NotifierFactory) {
+	notifierFactoriesMt.Lock()
+	defer notifierFactoriesMt.Unlock()
+	notifierFactories[notificationType] = factory
+}
+
+func getNotifierFactory(notificationType string) (NotifierFactory, bool) {
+	notifierFactoriesMt.RLock()
+	defer notifierFactoriesMt.RUnlock()
+	factory, ok := notifierFactories[notificationType]
+	return factory, ok
+}
+
+// WebhookNotifier posts notifications as JSON to a URL, the format function
+// converts the notification into the payload expected by the destination
+type WebhookNotifier struct {
+	Url    string
+	Format func(notification Notification) interface{}
+	Client *http.Client
+}
+
+func NewWebhookNotifier(url string, format func(notification Notification) interface{}) *WebhookNotifier {
+	return &WebhookNotifier{
+		Url:    url,
+		Format: format,
+		Client: &http.Client{Timeout: time.Second * utils.DEFAULT_HTTP_TIMEOUT},
+	}
+}
+
+func (notifier *WebhookNotifier) Notify(notification Notification) error {
+	payload, err := json.Marshal(notifier.Format(notification))
+	if err != nil {
+		return err
+	}
+	response, err := notifier.Client.Post(notifier.Url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%s", response.Status)
+	}
+	return nil
+}
+
+// Message describes the notification in a sentence, for chat notifiers
+func (notification Notification) Message() string {
+	entity := "project [" + notification.Project + "]"
+	switch {
+	case len(notification.Action) > 0:
+		entity = parsers.YAML_KEY_ACTION + " [" + notification.Action + "]"
+	case len(notification.Package) > 0:
+		entity = parsers.YAML_KEY_PACKAGE + " [" + notification.Package + "]"
+	}
+	message := wski18n.T(wski18n.ID_MSG_NOTIFICATION_X_event_X_entity_X_namespace_X,
+		map[string]interface{}{
+			wski18n.KEY_EVENT:     notification.Event,
+			wski18n.KEY_ENTITY:    entity,
+			wski18n.KEY_NAMESPACE: notification.Namespace})
+	if len(notification.Error) > 0 {
+		message += "\n" + notification.Error
+	}
+	return message
+}
+
+func (notification Notification) color() string {
+	if strings.HasSuffix(notification.Event, NOTIFICATION_STATUS_FAILURE) {
+		return "d00000"
+	}
+	return "2eb886"
+}
+
+// NotificationHook is a notifier along with the events it is interested in
+type NotificationHook struct {
+	Notifier Notifier
+	Events   []string
+	host     string
+}
+
+// Matches returns true if the hook subscribed to the event, e.g. "deploy.failure"
+// matches the "deploy", "failure" and "deploy.failure" filters, an empty filter
+// matches every event
+func (hook *NotificationHook) Matches(event string) bool {
+	if len(hook.Events) == 0 {
+		return true
+	}
+	parts := strings.SplitN(event, ".", 2)
+	for _, filter := range hook.Events {
+		if filter == event || filter == parts[0] || (len(parts) > 1 && filter == parts[1]) {
+			return true
+		}
+	}
+	return false
+}
+
+// DeploymentNotifications holds the notification hooks declared for the
+// project, its packages and their actions
+type DeploymentNotifications struct {
+	Project  []*NotificationHook
+	Packages map[string][]*NotificationHook
+	// indexed by "package/action"
+	Actions map[string][]*NotificationHook
+}
+
+func NewDeploymentNotifications() *DeploymentNotifications {
+	return &DeploymentNotifications{
+		Project:  make([]*NotificationHook, 0),
+		Packages: make(map[string][]*NotificationHook),
+		Actions:  make(map[string][]*NotificationHook),
+	}
+}
+
+// Load reads the "notifications" of the project, packages and actions of the manifest
+func (notifications *DeploymentNotifications) Load(manifest *parsers.YAML) error {
+	hooks, err := newNotificationHooks(manifest.Filepath, manifest.GetProject().Notifications)
+	if err != nil {
+		return err
+	}
+	notifications.Project = append(notifications.Project, hooks...)
+
+	for packageName, pkg := range manifest.GetPackages() {
+		if hooks, err = newNotificationHooks(manifest.Filepath, pkg.Notifications); err != nil {
+			return err
+		}
+		if len(hooks) > 0 {
+			notifications.Packages[packageName] = append(notifications.Packages[packageName], hooks...)
+		}
+		for actionName, action := range pkg.Actions {
+			if hooks, err = newNotificationHooks(manifest.Filepath, action.Notifications); err != nil {
+				return err
+			}
+			if len(hooks) > 0 {
+				key := packageName + "/" + actionName
+				notifications.Actions[key] = append(notifications.Actions[key], hooks...)
+			}
+		}
+	}
+	return nil
+}
+
+func newNotificationHooks(filePath string, configs []parsers.Notification) ([]*NotificationHook, error) {
+	hooks := make([]*NotificationHook, 0, len(configs))
+	for _, config := range configs {
+		if value, ok := wskenv.GetEnvVar(config.Url).(string); ok {
+			config.Url = value
+		}
+		if len(config.Url) == 0 {
+			return nil, wskderrors.NewYAMLFileFormatError(filePath,
+				wski18n.T(wski18n.ID_ERR_MISSING_MANDATORY_KEY_X_key_X,
+					map[string]interface{}{wski18n.KEY_KEY: "notifications.url"}))
+		}
+
+		notificationType := config.Type
+		if len(notificationType) == 0 {
+			notificationType = NOTIFICATION_TYPE_WEBHOOK
+		}
+		factory, ok := getNotifierFactory(notificationType)
+		if !ok {
+			return nil, wskderrors.NewYAMLFileFormatError(filePath,
+				wski18n.T(wski18n.ID_ERR_NOTIFICATION_INVALID_X_key_X_value_X,
+					map[string]interface{}{wski18n.KEY_KEY: "type", wski18n.KEY_VALUE: notificationType}))
+		}
+		for _, event := range config.Events {
+			if !isNotificationEvent(event) {
+				return nil, wskderrors.NewYAMLFileFormatError(filePath,
+					wski18n.T(wski18n.ID_ERR_NOTIFICATION_INVALID_X_key_X_value_X,
+						map[string]interface{}{wski18n.KEY_KEY: "events", wski18n.KEY_VALUE: event}))
+			}
+		}
+
+		host := config.Url
+		if u, err := url.Parse(config.Url); err == nil && len(u.Host) > 0 {
+			host = u.Host
+		}
+		hooks = append(hooks, &NotificationHook{Notifier: factory(config), Events: config.Events, host: host})
+	}
+	return hooks, nil
+}
+
+func isNotificationEvent(event string) bool {
+	for _, e := range []string{NOTIFICATION_EVENT_DEPLOY, NOTIFICATION_EVENT_UNDEPLOY} {
+		for _, s := range []string{NOTIFICATION_STATUS_SUCCESS, NOTIFICATION_STATUS_FAILURE} {
+			if event == e || event == s || event == e+"."+s {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// NotifyProject notifies the hooks of the project and of every package that
+// the deployment (or undeployment) completed or failed
+func (notifications *DeploymentNotifications) NotifyProject(notification Notification) {
+	if notifications == nil {
+		return
+	}
+	notify(notifications.Project, notification)
+	for packageName, hooks := range notifications.Packages {
+		n := notification
+		n.Package = packageName
+		notify(hooks, n)
+	}
+}
+
+// NotifyAction notifies the hooks of an action, the action is expected to be
+// in the form "package/action"
+func (notifications *DeploymentNotifications) NotifyAction(notification Notification) {
+	if notifications == nil {
+		return
+	}
+	notify(notifications.Actions[notification.Package+"/"+notification.Action], notification)
+}
+
+// a notification which cannot be delivered does not fail the deployment
+func notify(hooks []*NotificationHook, notification Notification) {
+	for _, hook := range hooks {
+		if !hook.Matches(notification.Event) {
+			continue
+		}
+		if err := hook.Notifier.Notify(notification); err != nil {
+			wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_NOTIFICATION_FAILED_X_host_X_err_X,
+				map[string]interface{}{wski18n.KEY_HOST: hook.host, wski18n.KEY_ERR: err.Error()}))
+		}
+	}
+}
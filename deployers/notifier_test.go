@@ -0,0 +1,147 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+const TEST_MANIFEST_WITH_NOTIFICATIONS = `
+project:
+  name: helloworld
+  notifications:
+    - url: ${NOTIFY_URL}/project
+      events: [failure]
+  packages:
+    hello:
+      notifications:
+        - url: ${NOTIFY_URL}/slack
+          type: slack
+          events: [deploy]
+      actions:
+        hello:
+          function: hello.js
+          notifications:
+            - url: ${NOTIFY_URL}/action
+`
+
+type testWebhook struct {
+	server   *httptest.Server
+	mt       sync.Mutex
+	payloads map[string][]map[string]interface{}
+}
+
+func newTestWebhook() *testWebhook {
+	webhook := &testWebhook{payloads: make(map[string][]map[string]interface{})}
+	webhook.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := make(map[string]interface{})
+		json.NewDecoder(r.Body).Decode(&payload)
+		webhook.mt.Lock()
+		defer webhook.mt.Unlock()
+		webhook.payloads[r.URL.Path] = append(webhook.payloads[r.URL.Path], payload)
+	}))
+	return webhook
+}
+
+func TestDeploymentNotifications(t *testing.T) {
+	webhook := newTestWebhook()
+	defer webhook.server.Close()
+	os.Setenv("NOTIFY_URL", webhook.server.URL)
+	defer os.Unsetenv("NOTIFY_URL")
+
+	manifest := parsers.YAML{}
+	assert.Nil(t, yaml.Unmarshal([]byte(TEST_MANIFEST_WITH_NOTIFICATIONS), &manifest))
+	notifications := NewDeploymentNotifications()
+	assert.Nil(t, notifications.Load(&manifest))
+	assert.Equal(t, 1, len(notifications.Project))
+	assert.Equal(t, 1, len(notifications.Packages["hello"]))
+	assert.Equal(t, 1, len(notifications.Actions["hello/hello"]))
+
+	action := NewNotification(NOTIFICATION_EVENT_DEPLOY, nil)
+	action.Project, action.Package, action.Action, action.Namespace = "helloworld", "hello", "hello", "guest"
+	notifications.NotifyAction(action)
+
+	project := NewNotification(NOTIFICATION_EVENT_DEPLOY, nil)
+	project.Project = "helloworld"
+	notifications.NotifyProject(project)
+	notifications.NotifyProject(NewNotification(NOTIFICATION_EVENT_UNDEPLOY, errors.New("undeployment failed")))
+
+	// the action hook is notified of every event with the structured payload
+	assert.Equal(t, 1, len(webhook.payloads["/action"]))
+	assert.Equal(t, "deploy.success", webhook.payloads["/action"][0]["event"])
+	assert.Equal(t, "hello", webhook.payloads["/action"][0]["action"])
+	assert.Equal(t, "guest", webhook.payloads["/action"][0]["namespace"])
+
+	// the project hook is only notified of failures
+	assert.Equal(t, 1, len(webhook.payloads["/project"]))
+	assert.Equal(t, "undeploy.failure", webhook.payloads["/project"][0]["event"])
+	assert.Equal(t, "undeployment failed", webhook.payloads["/project"][0]["error"])
+
+	// the package hook is only notified of deployments, with a Slack message
+	assert.Equal(t, 1, len(webhook.payloads["/slack"]))
+	text := webhook.payloads["/slack"][0]["text"].(string)
+	assert.True(t, strings.Contains(text, "deploy.success"), text)
+	assert.True(t, strings.Contains(text, "[hello]"), text)
+}
+
+func TestDeploymentNotifications_Invalid(t *testing.T) {
+	for _, config := range []parsers.Notification{
+		{Url: ""},
+		{Url: "http://localhost", Type: "pager"},
+		{Url: "http://localhost", Events: []string{"deployed"}},
+	} {
+		manifest := parsers.YAML{Project: parsers.Project{Notifications: []parsers.Notification{config}}}
+		assert.NotNil(t, NewDeploymentNotifications().Load(&manifest))
+	}
+}
+
+type testNotifier struct {
+	notifications []Notification
+}
+
+func (notifier *testNotifier) Notify(notification Notification) error {
+	notifier.notifications = append(notifier.notifications, notification)
+	return nil
+}
+
+func TestRegisterNotifier(t *testing.T) {
+	notifier := &testNotifier{}
+	RegisterNotifier("test", func(config parsers.Notification) Notifier { return notifier })
+
+	manifest := parsers.YAML{Project: parsers.Project{
+		Notifications: []parsers.Notification{{Url: "test://", Type: "test", Events: []string{"undeploy.success"}}}}}
+	notifications := NewDeploymentNotifications()
+	assert.Nil(t, notifications.Load(&manifest))
+	notifications.NotifyProject(NewNotification(NOTIFICATION_EVENT_DEPLOY, nil))
+	notifications.NotifyProject(NewNotification(NOTIFICATION_EVENT_UNDEPLOY, nil))
+	assert.Equal(t, 1, len(notifier.notifications))
+	assert.Equal(t, "undeploy.success", notifier.notifications[0].Event)
+}
@@ -0,0 +1,71 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"sort"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+)
+
+// DeployBindings creates the bindings of the packages to deployed packages,
+// in the namespace of the package declaring them. Like the bindings of
+// dependencies, they are shared by the --deploy-as suffixes.
+func (deployer *ServiceDeployer) DeployBindings() error {
+	for _, pack := range deployer.Deployment.Packages {
+		for _, binding := range sortedBindings(pack.Bindings) {
+			binding := binding
+			err := deployer.deployEntity(parsers.PACKAGE_BINDING, binding.Name, func(deployer *ServiceDeployer) error {
+				return deployer.inNamespace(binding.Namespace, func() error {
+					return deployer.createBinding(binding)
+				})
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// UnDeployBindings removes the bindings of the packages, the bound packages
+// are left as they are
+func (deployer *ServiceDeployer) UnDeployBindings(deployment *DeploymentProject) error {
+	for _, pack := range deployment.Packages {
+		for _, binding := range sortedBindings(pack.Bindings) {
+			if err := deployer.deleteBindingInNamespace(binding.Namespace, binding.Name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func sortedBindings(bindings map[string]*whisk.BindingPackage) []*whisk.BindingPackage {
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	sorted := make([]*whisk.BindingPackage, 0, len(names))
+	for _, name := range names {
+		sorted = append(sorted, bindings[name])
+	}
+	return sorted
+}
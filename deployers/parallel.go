@@ -0,0 +1,193 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+type actionTask struct {
+	pkg    *whisk.Package
+	action *whisk.Action
+}
+
+// deployActionsInParallel deploys up to n actions at a time. The messages of
+// each action are buffered and printed once the action is deployed, while a
+// status line shows the progress. No action is started after a failure, the
+// first error is returned once the actions being deployed are done.
+func (deployer *ServiceDeployer) deployActionsInParallel(n int) error {
+	tasks := make([]actionTask, 0)
+	for _, pack := range deployer.Deployment.Packages {
+		for _, action := range pack.Actions {
+			tasks = append(tasks, actionTask{pkg: pack.Package, action: action.Action})
+		}
+	}
+	status := newParallelStatus(len(tasks))
+
+	var wg sync.WaitGroup
+	var mt sync.Mutex
+	var firstErr error
+	failed := func() bool {
+		mt.Lock()
+		defer mt.Unlock()
+		return firstErr != nil
+	}
+
+	semaphore := make(chan struct{}, n)
+	for _, task := range tasks {
+		semaphore <- struct{}{}
+		if failed() {
+			<-semaphore
+			break
+		}
+		wg.Add(1)
+		go func(task actionTask) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			name := task.action.Name
+			status.start(name)
+			worker, err := deployer.newWorker()
+			if err == nil {
+				err = worker.deployAction(parsers.YAML_KEY_ACTION, task.pkg, task.action)
+			}
+			if err != nil {
+				mt.Lock()
+				if firstErr == nil {
+					firstErr = err
+				} else {
+					worker.Output.PrintlnOpenWhiskError(err.Error())
+				}
+				mt.Unlock()
+			}
+			worker.Output.Flush()
+			status.finish(name)
+		}(task)
+	}
+	wg.Wait()
+	wskprint.ClearStatusLine()
+	return firstErr
+}
+
+// newWorker creates a deployer sharing the deployment, flags, checkpoints and
+// outputs of this deployer, with its own client since the namespace of the
+// client is switched while deploying an entity (see inNamespace) and its own
+// output. Every field but the client and the output is copied, see
+// TestServiceDeployer_newWorker.
+func (deployer *ServiceDeployer) newWorker() (*ServiceDeployer, error) {
+	worker := &ServiceDeployer{
+		ProjectName:            deployer.ProjectName,
+		Deployment:             deployer.Deployment,
+		Client:                 deployer.Client,
+		RootPackageName:        deployer.RootPackageName,
+		IsInteractive:          deployer.IsInteractive,
+		IsDefault:              deployer.IsDefault,
+		ManifestPath:           deployer.ManifestPath,
+		ProjectPath:            deployer.ProjectPath,
+		DeploymentPath:         deployer.DeploymentPath,
+		DeployActionInPackage:  deployer.DeployActionInPackage,
+		InteractiveChoice:      deployer.InteractiveChoice,
+		ClientConfig:           deployer.ClientConfig,
+		Flags:                  deployer.Flags,
+		ApigwClient:            deployer.ApigwClient,
+		ApigwAuth:              deployer.ApigwAuth,
+		DependencyMaster:       deployer.DependencyMaster,
+		UndeployedDependencies: deployer.UndeployedDependencies,
+		RetainedDependencies:   deployer.RetainedDependencies,
+		ReusedDependencies:     deployer.ReusedDependencies,
+		IsDependency:           deployer.IsDependency,
+		DeployAs:               deployer.DeployAs,
+		DependencyChain:        deployer.DependencyChain,
+		ManagedAnnotation:      deployer.ManagedAnnotation,
+		Revision:               deployer.Revision,
+		Provenance:             deployer.Provenance,
+		OverwriteAnnotations:   deployer.OverwriteAnnotations,
+		ExpectedTarget:         deployer.ExpectedTarget,
+		Checkpoint:             deployer.Checkpoint,
+		ResumeCheckpoint:       deployer.ResumeCheckpoint,
+		DeployedOutputs:        deployer.DeployedOutputs,
+		Notifications:          deployer.Notifications,
+		Failures:               deployer.Failures,
+		Durations:              deployer.Durations,
+		Results:                deployer.Results,
+		Context:                deployer.Context,
+		Output:                 wskprint.NewBuffer(),
+	}
+	if deployer.ClientConfig != nil {
+		config := *deployer.ClientConfig
+		client, err := CreateNewClient(&config)
+		if err != nil {
+			return worker, err
+		}
+		worker.Client = client
+		worker.ClientConfig = &config
+	}
+	return worker, nil
+}
+
+// parallelStatus keeps the status line up to date with the actions deployed
+// so far and the ones being deployed
+type parallelStatus struct {
+	mt      sync.Mutex
+	total   int
+	done    int
+	running map[string]bool
+	// message of the status line, given the done, total and running tasks
+	messageId string
+}
+
+func newParallelStatus(total int) *parallelStatus {
+	return &parallelStatus{total: total, running: make(map[string]bool),
+		messageId: wski18n.ID_MSG_PARALLEL_DEPLOY_STATUS_X_done_X_total_X_running_X}
+}
+
+func (status *parallelStatus) start(name string) {
+	status.mt.Lock()
+	defer status.mt.Unlock()
+	status.running[name] = true
+	status.update()
+}
+
+func (status *parallelStatus) finish(name string) {
+	status.mt.Lock()
+	defer status.mt.Unlock()
+	delete(status.running, name)
+	status.done++
+	status.update()
+}
+
+func (status *parallelStatus) update() {
+	names := make([]string, 0, len(status.running))
+	for name := range status.running {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	wskprint.SetStatusLine(wski18n.T(status.messageId,
+		map[string]interface{}{
+			wski18n.KEY_DONE:    strconv.Itoa(status.done),
+			wski18n.KEY_TOTAL:   strconv.Itoa(status.total),
+			wski18n.KEY_RUNNING: strings.Join(names, ", ")}))
+}
@@ -0,0 +1,105 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// nonZero returns a value of the type which is not its zero value
+func nonZero(t reflect.Type) reflect.Value {
+	value := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Ptr:
+		value = reflect.New(t.Elem())
+	case reflect.String:
+		value.SetString("x")
+	case reflect.Bool:
+		value.SetBool(true)
+	case reflect.Int, reflect.Int64:
+		value.SetInt(1)
+	case reflect.Slice:
+		value = reflect.MakeSlice(t, 1, 1)
+	case reflect.Map:
+		value = reflect.MakeMap(t)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath == "" {
+				value.Field(i).Set(nonZero(t.Field(i).Type))
+				break
+			}
+		}
+	}
+	return value
+}
+
+func TestServiceDeployer_newWorker(t *testing.T) {
+	deployer := NewServiceDeployer()
+	deployer.ApigwAuth = NewIAMTokenProvider("apikey")
+	deployer.Context = context.Background()
+	value := reflect.ValueOf(deployer).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.PkgPath == "" && field.Type.Kind() != reflect.Interface && field.Name != "ClientConfig" {
+			value.Field(i).Set(nonZero(field.Type))
+		}
+	}
+
+	worker, err := deployer.newWorker()
+	assert.Nil(t, err)
+	workerValue := reflect.ValueOf(worker).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		switch field.Name {
+		case "Client", "ClientConfig", "Output":
+			continue
+		}
+		if field.PkgPath == "" {
+			assert.True(t, reflect.DeepEqual(value.Field(i).Interface(), workerValue.Field(i).Interface()),
+				"the field "+field.Name+" of the deployer is copied to the worker")
+		}
+	}
+	assert.False(t, worker.Output == deployer.Output, "the worker has its own output")
+}
+
+func TestServiceDeployer_newWorker_Retry(t *testing.T) {
+	deployer := NewServiceDeployer()
+	deployer.Flags = &utils.WskdeployFlags{RateLimit: 10}
+	deployer.ClientConfig = &whisk.Config{Host: "openwhisk.example.com", Namespace: "guest", AuthToken: "user:pass"}
+
+	requests := 0
+	err := deployer.withEntityTimeout(time.Minute, "action", "hello/world", func(worker *ServiceDeployer) error {
+		assert.True(t, worker.Flags == deployer.Flags, "the worker deploys with the flags of the deployer")
+		assert.True(t, worker.Client != nil && worker.Client != deployer.Client, "the worker has its own client")
+		return worker.retry(1, 0, func() error {
+			requests++
+			return nil
+		})
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, requests)
+}
@@ -0,0 +1,272 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// commands a plugin is run with: the values of its keys are validated when
+// the deployment plan is built, then deployed with the project, or undeployed
+const (
+	PLUGIN_COMMAND_VALIDATE = "validate"
+	PLUGIN_COMMAND_DEPLOY   = "deploy"
+	PLUGIN_COMMAND_UNDEPLOY = "undeploy"
+	// version of the JSON requests and responses of plugins
+	PLUGIN_PROTOCOL_VERSION = 1
+	// variable the auth key is given to the commands of plugins with, it is
+	// left out of the request which may be logged by the plugin
+	PLUGIN_ENV_AUTH = "WSKDEPLOY_AUTH"
+)
+
+// PluginRequest is what a plugin is asked to do with the value of one of its
+// keys, the commands of plugins read it as JSON on their standard input
+type PluginRequest struct {
+	Version int    `json:"version"`
+	Command string `json:"command"`
+	Key     string `json:"key"`
+	// the package of the key, empty for a key of the project
+	Package   string      `json:"package,omitempty"`
+	Value     interface{} `json:"value"`
+	Project   string      `json:"project,omitempty"`
+	Manifest  string      `json:"manifest"`
+	ApiHost   string      `json:"apihost,omitempty"`
+	Namespace string      `json:"namespace,omitempty"`
+}
+
+// PluginResponse is the answer of a plugin, the commands of plugins write it
+// as JSON on their standard output
+type PluginResponse struct {
+	// the steps taken, or on validate the steps the deployment will take,
+	// e.g. "create the database orders"
+	Steps []string `json:"steps,omitempty"`
+	Error string   `json:"error,omitempty"`
+}
+
+// Plugin handles custom keys of the project or of its packages, e.g.
+// cloudant_databases, which are not part of the schema of the manifest
+type Plugin interface {
+	Keys() []string
+	Handle(request PluginRequest) (PluginResponse, error)
+}
+
+// Plugins are the plugins built into wskdeploy, by name, the ones declared
+// in the manifest take precedence
+var Plugins = make(map[string]Plugin)
+
+// RegisterPlugin adds a plugin built into wskdeploy, its keys are accepted in
+// every manifest
+func RegisterPlugin(name string, plugin Plugin) {
+	Plugins[name] = plugin
+	for _, key := range plugin.Keys() {
+		parsers.PluginKeys[key] = name
+	}
+}
+
+// CommandPlugin is a plugin declared in the manifest, see
+// parsers.PluginDeclaration. Its command is run by the shell, in the directory
+// of the manifest, with the PluginRequest as JSON on its standard input, and
+// writes the PluginResponse as JSON on its standard output. A command which
+// exits with an error, or responds with one, fails the deployment.
+type CommandPlugin struct {
+	Name       string
+	Run        string
+	PluginKeys []string
+	Dir        string
+	// the variables the command is run with in addition to the environment
+	Env     []string
+	Context context.Context
+}
+
+func (plugin *CommandPlugin) Keys() []string {
+	return plugin.PluginKeys
+}
+
+func (plugin *CommandPlugin) Handle(request PluginRequest) (PluginResponse, error) {
+	response := PluginResponse{}
+	input, err := json.Marshal(request)
+	if err != nil {
+		return response, err
+	}
+
+	shell := []string{"sh", "-c"}
+	if runtime.GOOS == "windows" {
+		shell = []string{"cmd", "/C"}
+	}
+	var command *exec.Cmd
+	if plugin.Context != nil {
+		command = exec.CommandContext(plugin.Context, shell[0], shell[1], plugin.Run)
+	} else {
+		command = exec.Command(shell[0], shell[1], plugin.Run)
+	}
+	command.Dir = plugin.Dir
+	command.Env = append(os.Environ(), plugin.Env...)
+	command.Stdin = bytes.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	command.Stdout, command.Stderr = &stdout, &stderr
+	if err := command.Run(); err != nil {
+		if output := strings.TrimSpace(stderr.String() + stdout.String()); len(output) > 0 {
+			return response, errors.New(err.Error() + "\n" + output)
+		}
+		return response, err
+	}
+	// a command with nothing to say succeeded
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return response, nil
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return response, errors.New(err.Error() + "\n" + strings.TrimSpace(stdout.String()))
+	}
+	return response, nil
+}
+
+// PluginExtension is a custom key of the manifest in the deployment plan,
+// with the plugin which handles it and the steps it takes
+type PluginExtension struct {
+	parsers.Extension
+	Handler Plugin
+	Steps   []string
+}
+
+// entity names the package of the key, or the project, in messages
+func (extension *PluginExtension) entity() string {
+	if len(extension.Package) > 0 {
+		return extension.Package
+	}
+	return parsers.YAML_KEY_PROJECT
+}
+
+// LoadPlugins adds the custom keys of the project and of the packages of the
+// plan to it, and validates them if asked to, see ValidatePlugins(). It is
+// called before the packages are renamed with --deploy-as, and the keys of
+// packages left out of the plan are skipped.
+func (deployer *ServiceDeployer) LoadPlugins(manifest *parsers.YAML, validate bool) error {
+	extensions, err := parsers.ManifestExtensions(manifest, deployer.ManifestPath)
+	if err != nil {
+		return err
+	}
+	declared := manifest.GetProject().Plugins
+	for _, extension := range extensions {
+		if _, ok := deployer.Deployment.Packages[extension.Package]; len(extension.Package) > 0 && !ok {
+			continue
+		}
+		handler := Plugins[extension.Plugin]
+		if declaration, ok := declared[extension.Plugin]; ok {
+			handler = deployer.commandPlugin(extension.Plugin, declaration)
+		}
+		deployer.Deployment.Extensions = append(deployer.Deployment.Extensions,
+			&PluginExtension{Extension: extension, Handler: handler})
+	}
+	if validate {
+		return deployer.ValidatePlugins()
+	}
+	return nil
+}
+
+// ValidatePlugins validates each custom key of the plan by its plugin, which
+// returns the steps the deployment takes. The plugins of a dependency are only
+// validated once it passes CheckDependencyPolicy(), since their commands come
+// from its manifest.
+func (deployer *ServiceDeployer) ValidatePlugins() error {
+	for _, extension := range deployer.Deployment.Extensions {
+		response, err := deployer.runPlugin(extension, PLUGIN_COMMAND_VALIDATE)
+		if err != nil {
+			return err
+		}
+		extension.Steps = response.Steps
+	}
+	return nil
+}
+
+func (deployer *ServiceDeployer) commandPlugin(name string, declaration parsers.PluginDeclaration) *CommandPlugin {
+	plugin := &CommandPlugin{
+		Name:       name,
+		Run:        declaration.Run,
+		PluginKeys: declaration.Keys,
+		Dir:        filepath.Dir(deployer.ManifestPath),
+		Env:        []string{HOOK_ENV_PROJECT + "=" + deployer.ProjectName},
+		Context:    deployer.Context,
+	}
+	if deployer.ClientConfig != nil {
+		plugin.Env = append(plugin.Env, PLUGIN_ENV_AUTH+"="+deployer.ClientConfig.AuthToken)
+	}
+	return plugin
+}
+
+// RunPlugins deploys or undeploys the custom keys of the plan, in order on
+// deployment and in reverse order on undeployment
+func (deployer *ServiceDeployer) RunPlugins(command string) error {
+	extensions := deployer.Deployment.Extensions
+	for i := range extensions {
+		extension := extensions[i]
+		if command == PLUGIN_COMMAND_UNDEPLOY {
+			extension = extensions[len(extensions)-1-i]
+		}
+		deployer.Output.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_PLUGIN_RUN_X_name_X_key_X_entity_X_command_X,
+			map[string]interface{}{wski18n.KEY_NAME: extension.Plugin, wski18n.KEY_KEY: extension.Key,
+				wski18n.KEY_ENTITY: extension.entity(), wski18n.KEY_COMMAND: command}))
+		response, err := deployer.runPlugin(extension, command)
+		if err != nil {
+			return err
+		}
+		for _, step := range response.Steps {
+			deployer.Output.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_PLUGIN_STEP_X_name_X_step_X,
+				map[string]interface{}{wski18n.KEY_NAME: extension.Plugin, wski18n.KEY_STEP: step}))
+		}
+	}
+	return nil
+}
+
+// runPlugin runs the command of the plugin of the key, a plugin which fails
+// or responds with an error is a command error
+func (deployer *ServiceDeployer) runPlugin(extension *PluginExtension, command string) (PluginResponse, error) {
+	request := PluginRequest{
+		Version:  PLUGIN_PROTOCOL_VERSION,
+		Command:  command,
+		Key:      extension.Key,
+		Package:  extension.Package,
+		Value:    parsers.ResolveAnnotation(extension.Value),
+		Project:  deployer.ProjectName,
+		Manifest: deployer.ManifestPath,
+	}
+	if deployer.ClientConfig != nil {
+		request.ApiHost, request.Namespace = deployer.ClientConfig.Host, deployer.ClientConfig.Namespace
+	}
+	response, err := extension.Handler.Handle(request)
+	if err == nil && len(response.Error) > 0 {
+		err = errors.New(response.Error)
+	}
+	if err != nil {
+		return response, wskderrors.NewCommandError(extension.Plugin, wski18n.T(wski18n.ID_ERR_PLUGIN_FAILED_X_name_X_key_X_entity_X_err_X,
+			map[string]interface{}{wski18n.KEY_NAME: extension.Plugin, wski18n.KEY_KEY: extension.Key,
+				wski18n.KEY_ENTITY: extension.entity(), wski18n.KEY_ERR: err.Error()}))
+	}
+	return response, nil
+}
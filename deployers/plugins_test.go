@@ -0,0 +1,151 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/stretchr/testify/assert"
+)
+
+type testPlugin struct {
+	requests []PluginRequest
+	err      error
+}
+
+func (plugin *testPlugin) Keys() []string {
+	return []string{"cloudant_databases"}
+}
+
+func (plugin *testPlugin) Handle(request PluginRequest) (PluginResponse, error) {
+	plugin.requests = append(plugin.requests, request)
+	return PluginResponse{Steps: []string{request.Command + " " + request.Package}}, plugin.err
+}
+
+func TestCommandPlugin_Handle(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wskdeploy-plugins")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	plugin := &CommandPlugin{
+		Name: "cloudant",
+		Run:  `cat > request.json; echo '{"steps": ["create the database orders"]}'`,
+		Dir:  dir,
+	}
+	request := PluginRequest{Version: PLUGIN_PROTOCOL_VERSION, Command: PLUGIN_COMMAND_DEPLOY, Key: "cloudant_databases", Package: "billing"}
+	response, err := plugin.Handle(request)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"create the database orders"}, response.Steps)
+	// the request is given on the standard input, in the directory of the manifest
+	content, err := ioutil.ReadFile(filepath.Join(dir, "request.json"))
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), `"command":"deploy"`)
+	assert.Contains(t, string(content), `"package":"billing"`)
+
+	plugin.Run = "true"
+	_, err = plugin.Handle(request)
+	assert.Nil(t, err, "no response")
+
+	plugin.Run = "echo quota exceeded >&2; exit 2"
+	_, err = plugin.Handle(request)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "quota exceeded")
+	}
+
+	plugin.Run = "echo done"
+	_, err = plugin.Handle(request)
+	assert.NotNil(t, err, "not JSON")
+}
+
+func TestServiceDeployer_RunPlugins(t *testing.T) {
+	defer func(plugins map[string]Plugin) { Plugins = plugins }(Plugins)
+	defer delete(parsers.PluginKeys, "cloudant_databases")
+	Plugins = make(map[string]Plugin)
+	plugin := &testPlugin{}
+	RegisterPlugin("cloudant", plugin)
+
+	manifest := &parsers.YAML{Project: parsers.Project{
+		Name:       "shop",
+		Extensions: map[string]interface{}{"cloudant_databases": "audit"},
+		Packages: map[string]parsers.Package{
+			"billing": {Extensions: map[string]interface{}{"cloudant_databases": "orders"}},
+			"reports": {Extensions: map[string]interface{}{"cloudant_databases": "views"}},
+		},
+	}}
+	deployer := NewServiceDeployer()
+	deployer.ProjectName = "shop"
+	deployer.ManifestPath = "manifest.yaml"
+	// the package reports is left out of the plan
+	deployer.Deployment.Packages["billing"] = NewDeploymentPackage()
+
+	assert.Nil(t, deployer.LoadPlugins(manifest, true))
+	if assert.Equal(t, 2, len(deployer.Deployment.Extensions)) {
+		assert.Equal(t, []string{"validate "}, deployer.Deployment.Extensions[0].Steps)
+		assert.Equal(t, []string{"validate billing"}, deployer.Deployment.Extensions[1].Steps)
+	}
+
+	plugin.requests = nil
+	assert.Nil(t, deployer.RunPlugins(PLUGIN_COMMAND_DEPLOY))
+	assert.Nil(t, deployer.RunPlugins(PLUGIN_COMMAND_UNDEPLOY))
+	if assert.Equal(t, 4, len(plugin.requests)) {
+		assert.Equal(t, "audit", plugin.requests[0].Value)
+		assert.Equal(t, "shop", plugin.requests[0].Project)
+		// the keys are undeployed in reverse order
+		assert.Equal(t, "billing", plugin.requests[2].Package)
+		assert.Equal(t, PLUGIN_COMMAND_UNDEPLOY, plugin.requests[2].Command)
+	}
+
+	plugin.err = errors.New("quota exceeded")
+	err := deployer.RunPlugins(PLUGIN_COMMAND_DEPLOY)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "quota exceeded")
+	}
+}
+
+func TestServiceDeployer_ValidatePlugins(t *testing.T) {
+	defer func(plugins map[string]Plugin) { Plugins = plugins }(Plugins)
+	defer delete(parsers.PluginKeys, "cloudant_databases")
+	Plugins = make(map[string]Plugin)
+	plugin := &testPlugin{}
+	RegisterPlugin("cloudant", plugin)
+
+	manifest := &parsers.YAML{Project: parsers.Project{
+		Name:       "utils",
+		Extensions: map[string]interface{}{"cloudant_databases": "audit"},
+	}}
+	// the plugins of a dependency are loaded without being run
+	dependency := NewServiceDeployer()
+	dependency.IsDependency = true
+	dependency.ManifestPath = "manifest.yaml"
+	assert.Nil(t, dependency.LoadPlugins(manifest, false))
+	assert.Equal(t, 1, len(dependency.Deployment.Extensions))
+	assert.Equal(t, 0, len(plugin.requests))
+
+	assert.Nil(t, dependency.ValidatePlugins())
+	if assert.Equal(t, 1, len(plugin.requests)) {
+		assert.Equal(t, PLUGIN_COMMAND_VALIDATE, plugin.requests[0].Command)
+		assert.Equal(t, []string{"validate "}, dependency.Deployment.Extensions[0].Steps)
+	}
+}
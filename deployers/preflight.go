@@ -0,0 +1,150 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// minimum version of the OpenWhisk API the projects are deployed to
+const PREFLIGHT_MIN_API_VERSION = "1.0.0"
+
+// description of the OpenWhisk API, returned by the /api/v1 path of the API host
+type openWhiskApiInfo struct {
+	ApiVersion  string `json:"api_version"`
+	Description string `json:"description"`
+}
+
+// Preflight checks that the API host of the credentials can be reached, over
+// a trusted TLS connection unless the credentials allow insecure connections,
+// and that it serves a version of the OpenWhisk API wskdeploy supports. The
+// project is not deployed otherwise, so that a wrong API host or a missing
+// proxy fails with one error rather than with an error for every entity.
+func (deployer *ServiceDeployer) Preflight() error {
+	config := deployer.ClientConfig
+	if config == nil {
+		return nil
+	}
+	host := config.Host
+	apiURL := utils.ApiHostURL(host, utils.API_BASE_PATH+utils.API_VERSION_PATH)
+
+	request, err := http.NewRequest("GET", apiURL, nil)
+	if err != nil {
+		return preflightUnreachableError(host, nil, err)
+	}
+	request.Header.Set("Accept", "application/json")
+
+	client, err := preflightClient(config)
+	if err != nil {
+		return preflightTLSError(host, err)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		if isTLSError(err) {
+			return preflightTLSError(host, err)
+		}
+		return preflightUnreachableError(host, request, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return wskderrors.NewPreflightError(wski18n.T(wski18n.ID_ERR_PREFLIGHT_STATUS_X_host_X_url_X_status_X,
+			map[string]interface{}{wski18n.KEY_HOST: host, wski18n.KEY_URL: apiURL, wski18n.KEY_STATUS: response.Status}), host)
+	}
+	var info openWhiskApiInfo
+	if err := json.NewDecoder(response.Body).Decode(&info); err != nil || len(info.ApiVersion) == 0 {
+		return wskderrors.NewPreflightError(wski18n.T(wski18n.ID_ERR_PREFLIGHT_NOT_OPENWHISK_X_host_X_url_X,
+			map[string]interface{}{wski18n.KEY_HOST: host, wski18n.KEY_URL: apiURL}), host)
+	}
+	if result, err := utils.CompareVersions(info.ApiVersion, PREFLIGHT_MIN_API_VERSION); err == nil && result < 0 {
+		return wskderrors.NewPreflightError(wski18n.T(wski18n.ID_ERR_PREFLIGHT_API_VERSION_X_host_X_version_X_value_X,
+			map[string]interface{}{wski18n.KEY_HOST: host, wski18n.KEY_VERSION: info.ApiVersion,
+				wski18n.KEY_VALUE: PREFLIGHT_MIN_API_VERSION}), host)
+	}
+
+	whisk.Debug(whisk.DbgInfo, wski18n.T(wski18n.ID_MSG_PREFLIGHT_OK_X_host_X_version_X,
+		map[string]interface{}{wski18n.KEY_HOST: host, wski18n.KEY_VERSION: info.ApiVersion}))
+	return nil
+}
+
+// preflightClient returns an HTTP client with the TLS configuration of the
+// credentials, which goes through the proxy of the environment, if any
+func preflightClient(config *whisk.Config) (*http.Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: config.Insecure}
+	if len(config.Cert) > 0 && len(config.Key) > 0 {
+		certificate, err := tls.LoadX509KeyPair(config.Cert, config.Key)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return &http.Client{
+		Timeout: time.Second * utils.DEFAULT_HTTP_TIMEOUT,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+	}, nil
+}
+
+func isTLSError(err error) bool {
+	if urlError, ok := err.(*url.Error); ok {
+		err = urlError.Err
+	}
+	switch err.(type) {
+	case x509.UnknownAuthorityError, x509.HostnameError, x509.CertificateInvalidError, tls.RecordHeaderError:
+		return true
+	}
+	// the errors of the verification of certificates may be wrapped, and the
+	// HTTP response of a host which does not serve HTTPS is not a TLS record
+	message := err.Error()
+	return strings.Contains(message, "x509: ") || strings.Contains(message, "tls: ") ||
+		strings.Contains(message, "HTTP response to HTTPS client")
+}
+
+func preflightTLSError(host string, err error) error {
+	return wskderrors.NewPreflightError(wski18n.T(wski18n.ID_ERR_PREFLIGHT_TLS_X_host_X_err_X,
+		map[string]interface{}{wski18n.KEY_HOST: host, wski18n.KEY_ERR: err.Error()}), host)
+}
+
+// preflightUnreachableError hints at the proxy the request went through, if
+// any, as a missing or wrong proxy is the usual reason
+func preflightUnreachableError(host string, request *http.Request, err error) error {
+	message := wski18n.T(wski18n.ID_ERR_PREFLIGHT_UNREACHABLE_X_host_X_err_X,
+		map[string]interface{}{wski18n.KEY_HOST: host, wski18n.KEY_ERR: err.Error()})
+	if request != nil {
+		if proxy, _ := http.ProxyFromEnvironment(request); proxy != nil {
+			message += " " + wski18n.T(wski18n.ID_MSG_PREFLIGHT_PROXY_X_proxy_X,
+				map[string]interface{}{wski18n.KEY_PROXY: proxy.Scheme + "://" + proxy.Host})
+		} else {
+			message += " " + wski18n.T(wski18n.ID_MSG_PREFLIGHT_NO_PROXY)
+		}
+	}
+	return wskderrors.NewPreflightError(message, host)
+}
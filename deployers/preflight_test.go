@@ -0,0 +1,87 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceDeployer_Preflight(t *testing.T) {
+	apiVersion := "1.0.0"
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"api_version":"` + apiVersion + `","description":"OpenWhisk"}`))
+		case "/html/api/v1":
+			w.Write([]byte("<html></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	server := httptest.NewTLSServer(handler)
+	defer server.Close()
+
+	deployer := NewServiceDeployer()
+	deployer.ClientConfig = &whisk.Config{Host: server.URL, Insecure: true}
+	assert.Nil(t, deployer.Preflight())
+
+	apiVersion = "0.9"
+	err := deployer.Preflight()
+	if assert.IsType(t, &wskderrors.PreflightError{}, err, "API version") {
+		assert.Contains(t, err.Error(), "[0.9]")
+	}
+	apiVersion = "1.0.0"
+
+	deployer.ClientConfig.Host = server.URL + "/missing"
+	err = deployer.Preflight()
+	if assert.IsType(t, &wskderrors.PreflightError{}, err, "status") {
+		assert.Contains(t, err.Error(), "404")
+	}
+	deployer.ClientConfig.Host = server.URL + "/html"
+	assert.IsType(t, &wskderrors.PreflightError{}, deployer.Preflight(), "not the OpenWhisk API")
+
+	deployer.ClientConfig = &whisk.Config{Host: server.URL, Insecure: false}
+	err = deployer.Preflight()
+	if assert.IsType(t, &wskderrors.PreflightError{}, err, "untrusted certificate") {
+		assert.Contains(t, err.Error(), "TLS")
+	}
+
+	plain := httptest.NewServer(handler)
+	deployer.ClientConfig = &whisk.Config{Host: "https://" + strings.TrimPrefix(plain.URL, "http://"), Insecure: true}
+	err = deployer.Preflight()
+	if assert.IsType(t, &wskderrors.PreflightError{}, err, "HTTPS to an HTTP server") {
+		assert.Contains(t, err.Error(), "TLS")
+	}
+
+	plain.Close()
+	deployer.ClientConfig = &whisk.Config{Host: plain.URL, Insecure: true}
+	err = deployer.Preflight()
+	if assert.IsType(t, &wskderrors.PreflightError{}, err, "unreachable") {
+		assert.Contains(t, err.Error(), "proxy")
+	}
+}
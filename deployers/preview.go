@@ -0,0 +1,292 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+	"gopkg.in/yaml.v2"
+)
+
+// The preview of a deployment plan, i.e. the entities as they would be
+// deployed, once the manifest and deployment files are composed, the
+// variables resolved and the code of the actions read
+type previewProject struct {
+	Project   string                    `yaml:"project,omitempty"`
+	Version   string                    `yaml:"version,omitempty"`
+	Commit    string                    `yaml:"commit,omitempty"`
+	Branch    string                    `yaml:"branch,omitempty"`
+	Namespace string                    `yaml:"namespace"`
+	Packages  map[string]previewPackage `yaml:"packages,omitempty"`
+	Triggers  map[string]previewTrigger `yaml:"triggers,omitempty"`
+	Rules     map[string]previewRule    `yaml:"rules,omitempty"`
+	Apis      map[string]previewApi     `yaml:"apis,omitempty"`
+	Plugins   []previewPlugin           `yaml:"plugins,omitempty"`
+}
+
+type previewPackage struct {
+	Namespace    string                       `yaml:"namespace,omitempty"`
+	Shared       bool                         `yaml:"shared,omitempty"`
+	Parameters   map[string]interface{}       `yaml:"parameters,omitempty"`
+	Annotations  map[string]interface{}       `yaml:"annotations,omitempty"`
+	Dependencies map[string]previewDependency `yaml:"dependencies,omitempty"`
+	Bindings     map[string]previewBinding    `yaml:"bindings,omitempty"`
+	Actions      map[string]previewAction     `yaml:"actions,omitempty"`
+	Sequences    map[string]previewAction     `yaml:"sequences,omitempty"`
+}
+
+type previewDependency struct {
+	Location    string                 `yaml:"location"`
+	Binding     bool                   `yaml:"binding,omitempty"`
+	Parameters  map[string]interface{} `yaml:"parameters,omitempty"`
+	Annotations map[string]interface{} `yaml:"annotations,omitempty"`
+}
+
+// package the binding is bound to, as /namespace/package
+type previewBinding struct {
+	Package     string                 `yaml:"package"`
+	Parameters  map[string]interface{} `yaml:"parameters,omitempty"`
+	Annotations map[string]interface{} `yaml:"annotations,omitempty"`
+}
+
+type previewAction struct {
+	Kind   string `yaml:"kind"`
+	Main   string `yaml:"main,omitempty"`
+	Image  string `yaml:"image,omitempty"`
+	Source string `yaml:"source,omitempty"`
+	Binary bool   `yaml:"binary,omitempty"`
+	// size of the code, base64 encoded for binary code
+	CodeSize    int                    `yaml:"code-size,omitempty"`
+	Components  []string               `yaml:"components,omitempty"`
+	Limits      map[string]int         `yaml:"limits,omitempty"`
+	Parameters  map[string]interface{} `yaml:"parameters,omitempty"`
+	Annotations map[string]interface{} `yaml:"annotations,omitempty"`
+}
+
+type previewTrigger struct {
+	Namespace   string                 `yaml:"namespace,omitempty"`
+	Feed        string                 `yaml:"feed,omitempty"`
+	Parameters  map[string]interface{} `yaml:"parameters,omitempty"`
+	Annotations map[string]interface{} `yaml:"annotations,omitempty"`
+}
+
+type previewRule struct {
+	Trigger string `yaml:"trigger"`
+	Action  string `yaml:"action"`
+}
+
+// custom key of the project or of a package and the steps its plugin takes
+type previewPlugin struct {
+	Plugin  string   `yaml:"plugin"`
+	Key     string   `yaml:"key"`
+	Package string   `yaml:"package,omitempty"`
+	Steps   []string `yaml:"steps,omitempty"`
+}
+
+type previewApi struct {
+	Name     string `yaml:"name,omitempty"`
+	BasePath string `yaml:"basepath,omitempty"`
+	Path     string `yaml:"path,omitempty"`
+	Method   string `yaml:"method,omitempty"`
+	Action   string `yaml:"action,omitempty"`
+	Swagger  bool   `yaml:"swagger,omitempty"`
+}
+
+// Preview writes the entities of the deployment plan as YAML, for review
+// before they are deployed: the parameters and annotations are the final ones
+// and the code of the actions is given by its size. The values of secret
+// inputs are masked. Nothing is sent to the API host.
+func (deployer *ServiceDeployer) Preview(out io.Writer) error {
+	plan := deployer.Deployment
+	preview := previewProject{
+		Project:   deployer.ProjectName,
+		Version:   deployer.Revision.Version,
+		Commit:    deployer.Revision.Commit,
+		Branch:    deployer.Revision.Branch,
+		Namespace: deployer.ClientConfig.Namespace,
+		Packages:  make(map[string]previewPackage),
+		Triggers:  make(map[string]previewTrigger),
+		Rules:     make(map[string]previewRule),
+		Apis:      make(map[string]previewApi),
+	}
+
+	for name, pack := range plan.Packages {
+		pkg := previewPackage{
+			Namespace:    pack.Package.Namespace,
+			Parameters:   previewKeyValues(pack.Package.Parameters),
+			Annotations:  previewKeyValues(pack.Package.Annotations),
+			Dependencies: make(map[string]previewDependency),
+			Bindings:     make(map[string]previewBinding),
+			Actions:      make(map[string]previewAction),
+			Sequences:    make(map[string]previewAction),
+		}
+		if pack.Package.Publish != nil {
+			pkg.Shared = *pack.Package.Publish
+		}
+		for depName, depRecord := range pack.Dependencies {
+			pkg.Dependencies[depName] = previewDependency{
+				Location:    depRecord.Location,
+				Binding:     depRecord.IsBinding,
+				Parameters:  previewKeyValues(depRecord.Parameters),
+				Annotations: previewKeyValues(depRecord.Annotations),
+			}
+		}
+		for bindingName, binding := range pack.Bindings {
+			pkg.Bindings[bindingName] = previewBinding{
+				Package:     "/" + binding.Binding.Namespace + "/" + binding.Binding.Name,
+				Parameters:  previewKeyValues(binding.Parameters),
+				Annotations: previewKeyValues(binding.Annotations),
+			}
+		}
+		for actionName, record := range pack.Actions {
+			pkg.Actions[actionName] = newPreviewAction(record)
+		}
+		for sequenceName, record := range pack.Sequences {
+			pkg.Sequences[sequenceName] = newPreviewAction(record)
+		}
+		preview.Packages[name] = pkg
+	}
+
+	for name, trigger := range plan.Triggers {
+		feed, _ := utils.IsFeedAction(trigger)
+		preview.Triggers[name] = previewTrigger{
+			Namespace:   trigger.Namespace,
+			Feed:        feed,
+			Parameters:  previewKeyValues(trigger.Parameters),
+			Annotations: previewKeyValues(trigger.Annotations),
+		}
+	}
+
+	for name, rule := range plan.Rules {
+		triggerName, _ := rule.Trigger.(string)
+		actionName, _ := rule.Action.(string)
+		preview.Rules[name] = previewRule{
+			Trigger: deployer.getQualifiedName(triggerName, deployer.ClientConfig.Namespace),
+			Action:  deployer.qualifiedRuleAction(actionName),
+		}
+	}
+
+	for name, api := range plan.Apis {
+		if api.ApiDoc == nil {
+			continue
+		}
+		doc := api.ApiDoc
+		previewed := previewApi{Name: doc.ApiName, BasePath: doc.GatewayBasePath, Swagger: len(doc.Swagger) > 0}
+		if !previewed.Swagger {
+			previewed.Path, previewed.Method = doc.GatewayRelPath, doc.GatewayMethod
+			if doc.Action != nil {
+				previewed.Action = doc.Action.Name
+			}
+		}
+		preview.Apis[name] = previewed
+	}
+
+	for _, extension := range plan.Extensions {
+		preview.Plugins = append(preview.Plugins, previewPlugin{
+			Plugin:  extension.Plugin,
+			Key:     extension.Key,
+			Package: extension.Package,
+			Steps:   extension.Steps,
+		})
+	}
+
+	content, err := yaml.Marshal(preview)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(content)
+	return err
+}
+
+func newPreviewAction(record utils.ActionRecord) previewAction {
+	action := record.Action
+	preview := previewAction{
+		Parameters:  previewKeyValues(action.Parameters),
+		Annotations: previewKeyValues(action.Annotations),
+	}
+	if exec := action.Exec; exec != nil {
+		preview.Kind, preview.Main, preview.Image, preview.Components = exec.Kind, exec.Main, exec.Image, exec.Components
+		if exec.Code != nil {
+			preview.CodeSize = len(*exec.Code)
+		}
+		if exec.Binary != nil {
+			preview.Binary = *exec.Binary
+		}
+	}
+	if len(preview.Components) == 0 {
+		preview.Source = record.Filepath
+	}
+	if limits := action.Limits; limits != nil {
+		preview.Limits = make(map[string]int)
+		for key, value := range map[string]*int{"timeout": limits.Timeout, "memorySize": limits.Memory, "logSize": limits.Logsize} {
+			if value != nil {
+				preview.Limits[key] = *value
+			}
+		}
+	}
+	return preview
+}
+
+func previewKeyValues(keyValues whisk.KeyValueArr) map[string]interface{} {
+	if len(keyValues) == 0 {
+		return nil
+	}
+	values := make(map[string]interface{}, len(keyValues))
+	for _, keyValue := range keyValues {
+		values[keyValue.Key] = previewValue(keyValue.Value)
+	}
+	return values
+}
+
+// previewValue returns the value with its secrets masked, the values are
+// masked one by one as the masked YAML document would not be valid
+func previewValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return wskprint.MaskSecrets(v)
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			masked[key] = previewValue(item)
+		}
+		return masked
+	case map[interface{}]interface{}:
+		masked := make(map[interface{}]interface{}, len(v))
+		for key, item := range v {
+			masked[key] = previewValue(item)
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			masked = append(masked, previewValue(item))
+		}
+		return masked
+	default:
+		if text := fmt.Sprintf("%v", v); wskprint.MaskSecrets(text) != text {
+			return wskprint.MaskSecrets(text)
+		}
+		return v
+	}
+}
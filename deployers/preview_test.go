@@ -0,0 +1,83 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestServiceDeployer_Preview(t *testing.T) {
+	deployer := NewServiceDeployer()
+	deployer.ProjectName = "greetings"
+	deployer.RootPackageName = "hello"
+	deployer.ClientConfig = &whisk.Config{Namespace: "guest"}
+
+	code := "UEsDBAo="
+	binary := true
+	timeout := 30000
+	pack := NewDeploymentPackage()
+	pack.Package = &whisk.Package{Name: "hello", Parameters: whisk.KeyValueArr{{Key: "token", Value: "s3cr3t"}}}
+	pack.Actions["world"] = utils.ActionRecord{Filepath: "actions/hello.zip",
+		Action: &whisk.Action{Name: "world", Exec: &whisk.Exec{Kind: "nodejs:6", Code: &code, Binary: &binary},
+			Limits:      &whisk.Limits{Timeout: &timeout},
+			Annotations: whisk.KeyValueArr{{Key: "web-export", Value: true}}}}
+	pack.Sequences["greet"] = utils.ActionRecord{Filepath: "greet", Action: &whisk.Action{Name: "greet",
+		Exec: &whisk.Exec{Kind: "sequence", Components: []string{"/guest/hello/world"}}}}
+	deployer.Deployment.Packages["hello"] = pack
+	deployer.Deployment.Triggers["everyhour"] = &whisk.Trigger{Name: "everyhour",
+		Annotations: whisk.KeyValueArr{{Key: "feed", Value: "/whisk.system/alarms/alarm"}}}
+	deployer.Deployment.Rules["greet_hourly"] = &whisk.Rule{Name: "greet_hourly", Trigger: "everyhour", Action: "greet"}
+
+	wskprint.AddSecret("s3cr3t")
+	defer wskprint.ClearSecrets()
+	out := new(bytes.Buffer)
+	assert.Nil(t, deployer.Preview(out))
+
+	var preview map[string]interface{}
+	assert.Nil(t, yaml.Unmarshal(out.Bytes(), &preview), out.String())
+	assert.Equal(t, "greetings", preview["project"])
+	assert.Equal(t, "guest", preview["namespace"])
+
+	hello := preview["packages"].(map[interface{}]interface{})["hello"].(map[interface{}]interface{})
+	assert.Equal(t, wskprint.STR_SECRET_MASK, hello["parameters"].(map[interface{}]interface{})["token"])
+	world := hello["actions"].(map[interface{}]interface{})["world"].(map[interface{}]interface{})
+	assert.Equal(t, "nodejs:6", world["kind"])
+	assert.Equal(t, "actions/hello.zip", world["source"])
+	assert.Equal(t, len(code), world["code-size"])
+	assert.Equal(t, true, world["binary"])
+	assert.Equal(t, timeout, world["limits"].(map[interface{}]interface{})["timeout"])
+	assert.Equal(t, true, world["annotations"].(map[interface{}]interface{})["web-export"])
+	greet := hello["sequences"].(map[interface{}]interface{})["greet"].(map[interface{}]interface{})
+	assert.Equal(t, []interface{}{"/guest/hello/world"}, greet["components"])
+	assert.Nil(t, greet["source"])
+
+	trigger := preview["triggers"].(map[interface{}]interface{})["everyhour"].(map[interface{}]interface{})
+	assert.Equal(t, "/whisk.system/alarms/alarm", trigger["feed"])
+	rule := preview["rules"].(map[interface{}]interface{})["greet_hourly"].(map[interface{}]interface{})
+	assert.Equal(t, "/guest/everyhour", rule["trigger"])
+	assert.Equal(t, "/guest/hello/greet", rule["action"])
+}
@@ -0,0 +1,168 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"errors"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// name of the file of the user's home directory which profiles are read from
+const PROFILES_FILE_NAME = ".wskprofiles"
+
+// keys of a profile, the same as the keys of .wskprops
+const (
+	PROFILE_KEY_APIHOST            = "APIHOST"
+	PROFILE_KEY_AUTH               = "AUTH"
+	PROFILE_KEY_NAMESPACE          = "NAMESPACE"
+	PROFILE_KEY_APIGW_ACCESS_TOKEN = "APIGW_ACCESS_TOKEN"
+	PROFILE_KEY_APIGW_HOST         = "APIGW_HOST"
+	PROFILE_KEY_IAM_API_KEY        = "IAM_API_KEY"
+	PROFILE_KEY_KEY                = "KEY"
+	PROFILE_KEY_CERT               = "CERT"
+)
+
+// Profile holds the credentials of an OpenWhisk deployment, e.g. "staging" or
+// "production", selected with --profile
+type Profile struct {
+	Name             string
+	APIHost          string
+	AuthKey          string
+	Namespace        string
+	ApigwAccessToken string
+	ApigwHost        string
+	IamApiKey        string
+	Key              string
+	Cert             string
+}
+
+var GetProfilesFilePath = func() string {
+	return path.Join(utils.GetHomeDirectory(), PROFILES_FILE_NAME)
+}
+
+// GetProfile reads the profile of the given name from the profiles file
+var GetProfile = func(name string) (*Profile, error) {
+	filePath := GetProfilesFilePath()
+	profiles, err := ReadProfiles(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if profile, ok := profiles[name]; ok {
+		return profile, nil
+	}
+
+	names := make([]string, 0, len(profiles))
+	for n := range profiles {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return nil, wskderrors.NewWhiskClientInvalidConfigError(wski18n.T(wski18n.ID_ERR_PROFILE_NOT_FOUND_X_name_X_path_X_profiles_X,
+		map[string]interface{}{
+			wski18n.KEY_NAME:     name,
+			wski18n.KEY_PATH:     filePath,
+			wski18n.KEY_PROFILES: strings.Join(names, ", ")}))
+}
+
+// ReadProfiles reads the profiles of a file, see ParseProfiles()
+func ReadProfiles(filePath string) (map[string]*Profile, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	profiles, err := ParseProfiles(content)
+	if err != nil {
+		return nil, wskderrors.NewWhiskClientInvalidConfigError(wski18n.T(wski18n.ID_ERR_PROFILE_INVALID_X_path_X_err_X,
+			map[string]interface{}{
+				wski18n.KEY_PATH: filePath,
+				wski18n.KEY_ERR:  err.Error()}))
+	}
+	return profiles, nil
+}
+
+// ParseProfiles parses profiles, each starts with its name in brackets and
+// holds the keys of .wskprops, e.g.
+//   [staging]
+//   APIHOST=openwhisk.staging.example.com
+//   AUTH=...
+//   NAMESPACE=guest
+// Keys which are not known are ignored, so that a profile may be shared with
+// other tools.
+func ParseProfiles(content []byte) (map[string]*Profile, error) {
+	profiles := make(map[string]*Profile)
+	var profile *Profile
+
+	lines := strings.Split(strings.Replace(string(content), "\r\n", "\n", -1), "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") || len(strings.TrimSpace(line[1:len(line)-1])) == 0 {
+				return nil, errors.New(wski18n.T(wski18n.ID_ERR_PROFILE_NAME_INVALID_X_line_X_value_X,
+					map[string]interface{}{wski18n.KEY_LINE: i + 1, wski18n.KEY_VALUE: line}))
+			}
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if _, ok := profiles[name]; ok {
+				return nil, errors.New(wski18n.T(wski18n.ID_ERR_PROFILE_DUPLICATE_X_line_X_name_X,
+					map[string]interface{}{wski18n.KEY_LINE: i + 1, wski18n.KEY_NAME: name}))
+			}
+			profile = &Profile{Name: name}
+			profiles[name] = profile
+			continue
+		}
+
+		separator := strings.Index(line, "=")
+		if separator < 0 {
+			return nil, errors.New(wski18n.T(wski18n.ID_ERR_LINE_MISSING_SEPARATOR_X_line_X_value_X,
+				map[string]interface{}{wski18n.KEY_LINE: i + 1, wski18n.KEY_VALUE: line}))
+		}
+		if profile == nil {
+			return nil, errors.New(wski18n.T(wski18n.ID_ERR_PROFILE_KEY_WITHOUT_PROFILE_X_line_X_value_X,
+				map[string]interface{}{wski18n.KEY_LINE: i + 1, wski18n.KEY_VALUE: line}))
+		}
+		value := strings.TrimSpace(line[separator+1:])
+		switch strings.ToUpper(strings.TrimSpace(line[:separator])) {
+		case PROFILE_KEY_APIHOST:
+			profile.APIHost = value
+		case PROFILE_KEY_AUTH:
+			profile.AuthKey = value
+		case PROFILE_KEY_NAMESPACE:
+			profile.Namespace = value
+		case PROFILE_KEY_APIGW_ACCESS_TOKEN:
+			profile.ApigwAccessToken = value
+		case PROFILE_KEY_APIGW_HOST:
+			profile.ApigwHost = value
+		case PROFILE_KEY_IAM_API_KEY:
+			profile.IamApiKey = value
+		case PROFILE_KEY_KEY:
+			profile.Key = value
+		case PROFILE_KEY_CERT:
+			profile.Cert = value
+		}
+	}
+	return profiles, nil
+}
@@ -0,0 +1,82 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProfiles(t *testing.T) {
+	profiles, err := ReadProfiles("../tests/dat/wskprofiles")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(profiles))
+	assert.Equal(t, "openwhisk.staging.example.com", profiles["staging"].APIHost)
+	assert.Equal(t, "staging-apigw-token", profiles["staging"].ApigwAccessToken)
+	assert.Equal(t, "https://openwhisk.example.com:8443", profiles["production"].APIHost)
+	assert.Empty(t, profiles["production"].Namespace)
+
+	for _, content := range []string{
+		"APIHOST=openwhisk.example.com",
+		"[staging\nAPIHOST=openwhisk.example.com",
+		"[staging]\nAPIHOST openwhisk.example.com",
+		"[staging]\n[staging]",
+	} {
+		_, err := ParseProfiles([]byte(content))
+		assert.NotNil(t, err, content)
+	}
+}
+
+func TestNewWhiskConfigWithProfile(t *testing.T) {
+	getProfilesFilePath := GetProfilesFilePath
+	GetProfilesFilePath = func() string { return "../tests/dat/wskprofiles" }
+	defer func() {
+		GetProfilesFilePath = getProfilesFilePath
+		utils.Flags.Profile = ""
+		initializeFlags()
+	}()
+
+	// the profile replaces .wskprops
+	utils.Flags.Profile = "staging"
+	config, err := NewWhiskConfig("../tests/dat/wskprops", "", "", false, &utils.Flags)
+	assert.Nil(t, err)
+	assert.Equal(t, "openwhisk.staging.example.com", config.Host)
+	assert.Equal(t, "staging-user:staging-password", config.AuthToken)
+	assert.Equal(t, "staging", config.Namespace)
+	apigwConfig, err := NewApigwConfig(config, "../tests/dat/wskprops", "", "", &utils.Flags)
+	assert.Nil(t, err)
+	assert.Equal(t, "staging-apigw-token", apigwConfig.ApigwAccessToken)
+	assert.Equal(t, config.Host, apigwConfig.Host)
+
+	// the command line takes precedence over the profile
+	utils.Flags.Profile = "production"
+	utils.Flags.Namespace = CLI_NAMESPACE
+	config, err = NewWhiskConfig("../tests/dat/wskprops", "", "", false, &utils.Flags)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://openwhisk.example.com:8443", config.Host)
+	assert.Equal(t, CLI_NAMESPACE, config.Namespace)
+
+	utils.Flags.Profile = "development"
+	_, err = NewWhiskConfig("../tests/dat/wskprops", "", "", false, &utils.Flags)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "production, staging")
+}
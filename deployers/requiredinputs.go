@@ -0,0 +1,110 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskenv"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// ValidateRequiredInputs checks that the inputs the manifest declares with
+// "required: true" have a value once the deployment file and --param are
+// applied, so that actions are not deployed without their configuration. A
+// value which is nil, an empty string, list or map is missing. All the missing
+// inputs are reported at once, along with the environment variables their
+// value refers to and the keys of the deployment file which could set them.
+func (deployer *ServiceDeployer) ValidateRequiredInputs(manifest *parsers.YAML) error {
+	problems := make([]string, 0)
+	check := func(entity string, keyPath string, inputs map[string]parsers.Parameter, parameters whisk.KeyValueArr) {
+		for name, input := range inputs {
+			if !input.Required || !isMissingInput(parameters.GetValue(name)) {
+				continue
+			}
+			problems = append(problems, wski18n.T(wski18n.ID_ERR_REQUIRED_INPUT_MISSING_X_entity_X_input_X_sources_X,
+				map[string]interface{}{wski18n.KEY_ENTITY: entity, wski18n.KEY_INPUT: name,
+					wski18n.KEY_SOURCES: requiredInputSources(entity, keyPath+"."+name, name, input)}))
+		}
+	}
+
+	for packageName, pkg := range manifest.GetPackages() {
+		pack, ok := deployer.Deployment.Packages[packageName]
+		if !ok {
+			continue
+		}
+		packagePath := strings.Join([]string{parsers.YAML_KEY_PROJECT, parsers.YAML_KEY_PACKAGES, packageName}, ".")
+		check(packageName, packagePath+"."+parsers.YAML_KEY_INPUTS, pkg.Inputs, pack.Package.Parameters)
+		for name, action := range pkg.Actions {
+			if record, ok := pack.Actions[name]; ok {
+				check(packageName+"/"+name, strings.Join([]string{packagePath, parsers.YAML_KEY_ACTIONS, name, parsers.YAML_KEY_INPUTS}, "."),
+					action.Inputs, record.Action.Parameters)
+			}
+		}
+		for name, trigger := range pkg.Triggers {
+			if deployed, ok := deployer.Deployment.Triggers[name]; ok {
+				check(name, strings.Join([]string{packagePath, parsers.YAML_KEY_TRIGGERS, name, parsers.YAML_KEY_INPUTS}, "."),
+					trigger.Inputs, deployed.Parameters)
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	message := wski18n.T(wski18n.ID_ERR_REQUIRED_INPUTS_INVALID_X_count_X,
+		map[string]interface{}{wski18n.KEY_COUNT: len(problems)})
+	return wskderrors.NewYAMLFileFormatError(deployer.ManifestPath, message+"\n"+strings.Join(problems, "\n"))
+}
+
+// isMissingInput reports whether the value of a required input is nil or empty
+func isMissingInput(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return v.Len() == 0
+	}
+	return false
+}
+
+// requiredInputSources lists what could set a required input: the environment
+// variables its value or default refers to, its key in the deployment file and
+// --param
+func requiredInputSources(entity string, key string, name string, input parsers.Parameter) string {
+	sources := make([]string, 0)
+	for _, value := range []interface{}{input.Value, input.Default} {
+		for _, env := range wskenv.EnvVarNames(value) {
+			sources = append(sources, wski18n.T(wski18n.ID_MSG_REQUIRED_INPUT_SOURCE_ENV_X_name_X,
+				map[string]interface{}{wski18n.KEY_NAME: env}))
+		}
+	}
+	sources = append(sources, wski18n.T(wski18n.ID_MSG_REQUIRED_INPUT_SOURCE_DEPLOYMENT_X_key_X,
+		map[string]interface{}{wski18n.KEY_KEY: key}))
+	sources = append(sources, wski18n.T(wski18n.ID_MSG_REQUIRED_INPUT_SOURCE_PARAM_X_value_X,
+		map[string]interface{}{wski18n.KEY_VALUE: entity + "." + name}))
+	return strings.Join(sources, ", ")
+}
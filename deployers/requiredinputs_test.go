@@ -0,0 +1,71 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceDeployer_ValidateRequiredInputs(t *testing.T) {
+	os.Unsetenv("REQUIRED_DB_URL")
+	manifest := &parsers.YAML{}
+	manifest.Packages = map[string]parsers.Package{
+		"hello": {
+			Inputs: map[string]parsers.Parameter{"region": {Required: true}},
+			Actions: map[string]parsers.Action{
+				"greeting": {Inputs: map[string]parsers.Parameter{
+					"dburl":    {Value: "$REQUIRED_DB_URL", Required: true},
+					"tags":     {Type: "json", Required: true},
+					"optional": {Type: "string"},
+				}},
+			},
+		},
+	}
+
+	deployer := NewServiceDeployer()
+	pack := NewDeploymentPackage()
+	pack.Package = &whisk.Package{Name: "hello", Parameters: whisk.KeyValueArr{{Key: "region", Value: "eu-de"}}}
+	pack.Actions["greeting"] = utils.ActionRecord{Action: &whisk.Action{Name: "greeting",
+		Parameters: whisk.KeyValueArr{{Key: "dburl", Value: ""}, {Key: "tags", Value: map[string]interface{}{}},
+			{Key: "optional", Value: ""}}}}
+	deployer.Deployment.Packages["hello"] = pack
+
+	err := deployer.ValidateRequiredInputs(manifest)
+	assert.NotNil(t, err)
+	// all the missing inputs are reported at once, with what could set them
+	for _, expected := range []string{"[dburl]", "[tags]", "[hello/greeting]", "[REQUIRED_DB_URL]",
+		"[project.packages.hello.actions.greeting.inputs.dburl]", "--param hello/greeting.tags=<value>"} {
+		assert.True(t, strings.Contains(err.Error(), expected), expected)
+	}
+	assert.False(t, strings.Contains(err.Error(), "[optional]"))
+	assert.False(t, strings.Contains(err.Error(), "[region]"))
+
+	// e.g. set by the deployment file
+	pack.Actions["greeting"].Action.Parameters = whisk.KeyValueArr{{Key: "dburl", Value: "https://db"},
+		{Key: "tags", Value: []interface{}{"a"}}}
+	assert.Nil(t, deployer.ValidateRequiredInputs(manifest))
+}
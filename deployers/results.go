@@ -0,0 +1,138 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+)
+
+// name of the test case of a deployment which failed before or after its
+// entities were deployed, e.g. an invalid manifest
+const RESULTS_DEPLOYMENT_CASE = "deployment"
+
+// EntityResult is the outcome of the deployment of an entity, Err is nil if it
+// was deployed
+type EntityResult struct {
+	Entity   string
+	Name     string
+	Duration time.Duration
+	Err      error
+}
+
+// EntityResults collects the outcome of the deployment of each entity, written
+// as a JUnit report with --results-file. Entities may be deployed concurrently
+// (see --parallel).
+type EntityResults struct {
+	mt      sync.Mutex
+	results []EntityResult
+}
+
+func NewEntityResults() *EntityResults {
+	return &EntityResults{results: make([]EntityResult, 0)}
+}
+
+func (results *EntityResults) Add(entity string, name string, duration time.Duration, err error) {
+	results.mt.Lock()
+	defer results.mt.Unlock()
+	results.results = append(results.results, EntityResult{Entity: entity, Name: name, Duration: duration, Err: err})
+}
+
+// List returns the results in the order the entities were deployed
+func (results *EntityResults) List() []EntityResult {
+	results.mt.Lock()
+	defer results.mt.Unlock()
+	return append([]EntityResult{}, results.results...)
+}
+
+// The JUnit report, the format read by Jenkins and GitLab
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func junitSeconds(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
+
+// JUnit returns the results as a JUnit report of a test suite named after the
+// project, with a test case per entity whose class is the type of the entity.
+// A deployment which failed without an entity failing, e.g. on an invalid
+// manifest, is reported as a failed test case of its own.
+func (results *EntityResults) JUnit(project string, duration time.Duration, deployErr error) ([]byte, error) {
+	suite := junitTestSuite{Name: project, Time: junitSeconds(duration), Cases: make([]junitTestCase, 0)}
+	entityFailed := false
+	for _, result := range results.List() {
+		testCase := junitTestCase{ClassName: project + "." + result.Entity, Name: result.Name, Time: junitSeconds(result.Duration)}
+		if result.Err != nil {
+			testCase.Failure = &junitFailure{Message: result.Err.Error(), Text: result.Err.Error()}
+			suite.Failures++
+			entityFailed = true
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	if deployErr != nil && !entityFailed {
+		suite.Cases = append(suite.Cases, junitTestCase{ClassName: project, Name: RESULTS_DEPLOYMENT_CASE, Time: junitSeconds(0),
+			Failure: &junitFailure{Message: deployErr.Error(), Text: deployErr.Error()}})
+		suite.Failures++
+	}
+	suite.Tests = len(suite.Cases)
+
+	content, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(content, '\n')...), nil
+}
+
+// WriteJUnitFile writes the results as a JUnit report to the file, see JUnit()
+func (results *EntityResults) WriteJUnitFile(path string, project string, duration time.Duration, deployErr error) error {
+	content, err := results.JUnit(project, duration, deployErr)
+	if err != nil {
+		return wskderrors.NewFileReadError(path, err.Error())
+	}
+	if err := ioutil.WriteFile(path, content, 0644); err != nil {
+		return wskderrors.NewFileReadError(path, err.Error())
+	}
+	return nil
+}
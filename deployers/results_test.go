@@ -0,0 +1,76 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntityResults_JUnit(t *testing.T) {
+	results := NewEntityResults()
+	results.Add("action", "hello/world", 1500*time.Millisecond, nil)
+	results.Add("rule", "hello_rule", 20*time.Millisecond, errors.New("trigger [everyhour] not found"))
+
+	content, err := results.JUnit("hello", 2*time.Second, errors.New("partial deployment"))
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(content), xml.Header))
+
+	var report junitTestSuites
+	assert.Nil(t, xml.Unmarshal(content, &report))
+	suite := report.Suites[0]
+	assert.Equal(t, "hello", suite.Name)
+	assert.Equal(t, 2, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	assert.Equal(t, "2.000", suite.Time)
+	assert.Equal(t, "hello.action", suite.Cases[0].ClassName)
+	assert.Equal(t, "1.500", suite.Cases[0].Time)
+	assert.Nil(t, suite.Cases[0].Failure)
+	assert.Equal(t, "trigger [everyhour] not found", suite.Cases[1].Failure.Message)
+}
+
+func TestEntityResults_JUnitDeploymentFailed(t *testing.T) {
+	// the deployment failed before any entity, e.g. on an invalid manifest
+	content, err := NewEntityResults().JUnit("hello", time.Second, errors.New("invalid manifest"))
+	assert.Nil(t, err)
+
+	var report junitTestSuites
+	assert.Nil(t, xml.Unmarshal(content, &report))
+	suite := report.Suites[0]
+	assert.Equal(t, 1, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	assert.Equal(t, RESULTS_DEPLOYMENT_CASE, suite.Cases[0].Name)
+	assert.Equal(t, "invalid manifest", suite.Cases[0].Failure.Message)
+}
+
+func TestDeployEntity_Results(t *testing.T) {
+	deployer := NewServiceDeployer()
+	failed := errors.New("failed")
+	assert.Equal(t, failed, deployer.deployEntity("action", "hello/world", func(deployer *ServiceDeployer) error { return failed }))
+	results := deployer.Results.List()
+	assert.Equal(t, 1, len(results))
+	assert.Equal(t, "hello/world", results[0].Name)
+	assert.Equal(t, failed, results[0].Err)
+}
@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// annotation of the package a dependency is deployed as, i.e. the package
+// named after the dependency, recording the location and version it was
+// deployed from, see --reuse-dependencies
+const DEPENDENCY_REF_ANNOT = "whisk-dependency"
+
+const (
+	DEPENDENCY_REF_LOCATION = "location"
+	DEPENDENCY_REF_VERSION  = "version"
+)
+
+// dependencyRefAnnotation returns the annotation recording the location and
+// version of a dependency
+func dependencyRefAnnotation(depRecord utils.DependencyRecord) whisk.KeyValue {
+	return whisk.KeyValue{Key: DEPENDENCY_REF_ANNOT, Value: map[string]interface{}{
+		DEPENDENCY_REF_LOCATION: depRecord.Location,
+		DEPENDENCY_REF_VERSION:  depRecord.Version,
+	}}
+}
+
+// matchesDependencyRef reports whether the annotations of a deployed package
+// record the location and version of the dependency
+func matchesDependencyRef(annotations whisk.KeyValueArr, depRecord utils.DependencyRecord) bool {
+	ref, ok := annotations.GetValue(DEPENDENCY_REF_ANNOT).(map[string]interface{})
+	return ok && fmt.Sprint(ref[DEPENDENCY_REF_LOCATION]) == depRecord.Location &&
+		fmt.Sprint(ref[DEPENDENCY_REF_VERSION]) == depRecord.Version
+}
+
+// isDependencyDeployed reports whether a GitHub dependency is already deployed
+// in the namespace from the same location and version, with
+// --reuse-dependencies, so that it is neither fetched nor deployed again. A
+// dependency on a branch, e.g. master, is always deployed again since the
+// branch may have moved.
+func (deployer *ServiceDeployer) isDependencyDeployed(depName string, depRecord utils.DependencyRecord, namespace string) bool {
+	if !deployer.Flags.ReuseDependencies || deployer.Client == nil || depRecord.IsBinding ||
+		depRecord.Version == parsers.YAML_VALUE_BRANCH_MASTER {
+		return false
+	}
+	var deployed *whisk.Package
+	found, err := deployer.getDeployed(namespace, func() (*http.Response, error) {
+		var response *http.Response
+		var err error
+		deployed, response, err = deployer.Client.Packages.Get(depName)
+		return response, err
+	})
+	if err != nil || !found || deployed == nil || !matchesDependencyRef(deployed.Annotations, depRecord) {
+		return false
+	}
+	wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_DEPENDENCY_REUSED_X_name_X_location_X_version_X,
+		map[string]interface{}{wski18n.KEY_NAME: depName, wski18n.KEY_LOCATION: depRecord.Location,
+			wski18n.KEY_VERSION: depRecord.Version}))
+	return true
+}
+
+// recordDependencyRef annotates the package a dependency was deployed as with
+// the location and version of the dependency, the package is the root package
+// of the dependency when no binding is created for it
+func (deployer *ServiceDeployer) recordDependencyRef(depName string, depRecord utils.DependencyRecord, namespace string) error {
+	return deployer.inNamespace(namespace, func() error {
+		var deployed *whisk.Package
+		var response *http.Response
+		err := deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+			var err error
+			deployed, response, err = deployer.Client.Packages.Get(depName)
+			return err
+		})
+		if err != nil {
+			return createWhiskClientError(err.(*whisk.WskError), response, parsers.YAML_KEY_PACKAGE, true)
+		}
+		deployed.Annotations = append(removeKeyValue(deployed.Annotations, DEPENDENCY_REF_ANNOT), dependencyRefAnnotation(depRecord))
+		err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+			_, response, err = deployer.Client.Packages.Insert(deployed, true)
+			return err
+		})
+		if err != nil {
+			return createWhiskClientError(err.(*whisk.WskError), response, parsers.YAML_KEY_PACKAGE, true)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,58 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchesDependencyRef(t *testing.T) {
+	depRecord := utils.DependencyRecord{Location: "github.com/apache/openwhisk-test/packages/helloworlds", Version: "1.0.0"}
+	annotations := whisk.KeyValueArr{{Key: "description", Value: "hello"}, dependencyRefAnnotation(depRecord)}
+	assert.True(t, matchesDependencyRef(annotations, depRecord))
+
+	// the annotation of a deployed package is read back from JSON
+	deployed := whisk.KeyValueArr{{Key: DEPENDENCY_REF_ANNOT, Value: map[string]interface{}{
+		DEPENDENCY_REF_LOCATION: depRecord.Location, DEPENDENCY_REF_VERSION: "1.0.0"}}}
+	assert.True(t, matchesDependencyRef(deployed, depRecord))
+
+	other := depRecord
+	other.Version = "1.1.0"
+	assert.False(t, matchesDependencyRef(annotations, other), "another version is deployed again")
+	assert.False(t, matchesDependencyRef(whisk.KeyValueArr{}, depRecord), "a package without the annotation is deployed again")
+}
+
+func TestServiceDeployer_isDependencyDeployed(t *testing.T) {
+	deployer := NewServiceDeployer()
+	deployer.Client = &whisk.Client{Config: &whisk.Config{Namespace: "guest"}}
+	depRecord := utils.DependencyRecord{Location: "github.com/apache/openwhisk-test/packages/helloworlds", Version: "master"}
+
+	assert.False(t, deployer.isDependencyDeployed("helloworlds", depRecord, ""), "without --reuse-dependencies")
+	utils.Flags.ReuseDependencies = true
+	defer func() { utils.Flags.ReuseDependencies = false }()
+	assert.False(t, deployer.isDependencyDeployed("helloworlds", depRecord, ""), "a branch may have moved")
+	depRecord.IsBinding = true
+	depRecord.Version = "1.0.0"
+	assert.False(t, deployer.isDependencyDeployed("helloworlds", depRecord, ""), "bindings are not fetched")
+}
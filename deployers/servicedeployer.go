@@ -19,10 +19,13 @@ package deployers
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"net"
 	"os"
 	"path"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -37,10 +40,22 @@ import (
 )
 
 const (
-	CONFLICT_CODE    = 153
-	CONFLICT_MESSAGE = "Concurrent modification to resource detected"
-	DEFAULT_ATTEMPTS = 3
-	DEFAULT_INTERVAL = 1 * time.Second
+	CONFLICT_CODE        = 153
+	CONFLICT_MESSAGE     = "Concurrent modification to resource detected"
+	DEFAULT_ATTEMPTS     = 5
+	DEFAULT_INTERVAL     = 1 * time.Second
+	DEFAULT_MAX_INTERVAL = 16 * time.Second
+	// the whisk client reports a failed HTTP request with an exit code of (status code - 256)
+	HTTP_STATUS_CODE_OFFSET = 256
+	// parameters the feed action of a trigger is invoked with, see
+	// https://github.com/apache/incubator-openwhisk/blob/master/docs/feeds.md
+	FEED_PARAM_AUTH_KEY         = "authKey"
+	FEED_PARAM_LIFECYCLE_EVENT  = "lifecycleEvent"
+	FEED_PARAM_TRIGGER_NAME     = "triggerName"
+	FEED_LIFECYCLE_EVENT_CREATE = "CREATE"
+	FEED_LIFECYCLE_EVENT_DELETE = "DELETE"
+	// dependencies of dependencies are deployed up to this depth
+	MAX_DEPENDENCY_DEPTH = 5
 )
 
 type DeploymentProject struct {
@@ -48,6 +63,8 @@ type DeploymentProject struct {
 	Triggers map[string]*whisk.Trigger
 	Rules    map[string]*whisk.Rule
 	Apis     map[string]*whisk.ApiCreateRequest
+	// custom keys of the project and its packages, handled by plugins
+	Extensions []*PluginExtension
 }
 
 func NewDeploymentProject() *DeploymentProject {
@@ -62,15 +79,23 @@ func NewDeploymentProject() *DeploymentProject {
 type DeploymentPackage struct {
 	Package      *whisk.Package
 	Dependencies map[string]utils.DependencyRecord
-	Actions      map[string]utils.ActionRecord
-	Sequences    map[string]utils.ActionRecord
+	// bindings of the package to deployed packages, see bindings in manifest
+	Bindings  map[string]*whisk.BindingPackage
+	Actions   map[string]utils.ActionRecord
+	Sequences map[string]utils.ActionRecord
+	// pre_deploy and post_deploy hooks of the package, and of its actions by
+	// name, see DeploymentHooks
+	Hooks       *DeploymentHooks
+	ActionHooks map[string]*DeploymentHooks
 }
 
 func NewDeploymentPackage() *DeploymentPackage {
 	var dep DeploymentPackage
 	dep.Dependencies = make(map[string]utils.DependencyRecord)
+	dep.Bindings = make(map[string]*whisk.BindingPackage)
 	dep.Actions = make(map[string]utils.ActionRecord)
 	dep.Sequences = make(map[string]utils.ActionRecord)
+	dep.ActionHooks = make(map[string]*DeploymentHooks)
 	return &dep
 }
 
@@ -95,8 +120,65 @@ type ServiceDeployer struct {
 	DeployActionInPackage bool
 	InteractiveChoice     bool
 	ClientConfig          *whisk.Config
+	// flags of the deployment, utils.Flags from the command line or those of
+	// the ProjectConfig of a project deployed as a library
+	Flags *utils.WskdeployFlags
+	// client of the API gateway when it is configured separately, see NewApigwConfig()
+	ApigwClient *whisk.Client
+	// provider of the access token of the API gateway when it is not configured,
+	// see NewApigwAuthProvider()
+	ApigwAuth ApigwAuthProvider
 	DependencyMaster      map[string]utils.DependencyRecord
+	// dependencies undeployed so far, shared with the deployers of dependencies
+	// so that a dependency of several packages is undeployed once
+	UndeployedDependencies map[string]bool
+	// dependencies of the packages left out with --packages or --exclude-package,
+	// they are kept on undeployment
+	RetainedDependencies map[string]bool
+	// dependencies already deployed from the same location and version, they
+	// are neither fetched nor deployed, see --reuse-dependencies
+	ReusedDependencies map[string]bool
+	// the project is a dependency of another project, see getDependentDeployer()
+	IsDependency bool
+	// suffix of the packages and triggers deployed side by side with the live
+	// ones, see ApplyDeployAs()
+	DeployAs string
+	// the dependencies from the root project to this one, e.g. [utils, logging]
+	// for a dependency of a dependency
+	DependencyChain []string
 	ManagedAnnotation     whisk.KeyValue
+	// version of the project and revision of its git repository, stored in
+	// the managed annotation
+	Revision utils.ManagedRevision
+	// checksums of the manifest and deployment files and version of wskdeploy,
+	// stored in the managed annotation and compared by VerifyDeployment()
+	Provenance utils.ManagedProvenance
+	// the annotations of deployed actions which are not in the manifest are
+	// removed, see overwrite_annotations
+	OverwriteAnnotations bool
+	// the API hosts and namespaces the project may be deployed to, see
+	// CheckExpectedTarget()
+	ExpectedTarget *parsers.ExpectedTarget
+	// entities deployed so far, saved to disk if the deployment fails
+	Checkpoint *DeploymentCheckpoint
+	// entities deployed by a previous (failed) run, skipped when resuming
+	ResumeCheckpoint *DeploymentCheckpoint
+	// values known once entities are deployed, see DeployOutputBindings()
+	DeployedOutputs *DeployedOutputs
+	// webhooks notified when the project, its packages or actions are (un)deployed
+	Notifications *DeploymentNotifications
+	// messages of an entity deployed concurrently with others, nil if the
+	// messages are printed right away
+	Output *wskprint.Buffer
+	// entities which failed when the deployment continues on errors
+	Failures *EntityFailures
+	// time each entity took to deploy, recorded in the metrics of the project
+	Durations *EntityDurations
+	// outcome of the deployment of each entity, see --results-file
+	Results *EntityResults
+	// the deployment stops before the next entity once the context is done,
+	// nil if the deployment cannot be cancelled
+	Context context.Context
 }
 
 // NewServiceDeployer is a Factory to create a new ServiceDeployer
@@ -106,6 +188,16 @@ func NewServiceDeployer() *ServiceDeployer {
 	dep.IsInteractive = true
 	dep.DeployActionInPackage = true
 	dep.DependencyMaster = make(map[string]utils.DependencyRecord)
+	dep.UndeployedDependencies = make(map[string]bool)
+	dep.RetainedDependencies = make(map[string]bool)
+	dep.ReusedDependencies = make(map[string]bool)
+	dep.Checkpoint = NewDeploymentCheckpoint("")
+	dep.DeployedOutputs = NewDeployedOutputs()
+	dep.Notifications = NewDeploymentNotifications()
+	dep.Failures = NewEntityFailures()
+	dep.Durations = NewEntityDurations()
+	dep.Results = NewEntityResults()
+	dep.Flags = &utils.Flags
 
 	return &dep
 }
@@ -114,6 +206,7 @@ func NewServiceDeployer() *ServiceDeployer {
 // Check if the deployment yaml could be parsed by Manifest Parser.
 func (deployer *ServiceDeployer) Check() {
 	ps := parsers.NewYAMLParser()
+	ps.Flags = deployer.Flags
 	if utils.FileExists(deployer.DeploymentPath) {
 		ps.ParseDeployment(deployer.DeploymentPath)
 	}
@@ -134,11 +227,22 @@ func (deployer *ServiceDeployer) ConstructDeploymentPlan() error {
 
 	deployer.RootPackageName = manifest.Package.Packagename
 	deployer.ProjectName = manifest.GetProject().Name
+	deployer.OverwriteAnnotations = manifest.GetProject().OverwriteAnnotations
+	deployer.ExpectedTarget = manifest.GetProject().ExpectedTarget
+	deployer.Revision.Version = interpolateString(manifest.GetProject().Version)
+	deployer.Revision.Commit, deployer.Revision.Branch = utils.GetGitRevision(path.Dir(manifest.Filepath))
+	if deployer.Provenance, err = utils.NewManagedProvenance(manifest.Filepath, deployer.DeploymentPath); err != nil {
+		return wskderrors.NewFileReadError(manifest.Filepath, err.Error())
+	}
+
+	if err := deployer.Notifications.Load(manifest); err != nil {
+		return err
+	}
 
 	// Generate Managed Annotations if its marked as a Managed Deployment
 	// Managed deployments are the ones when OpenWhisk entities are deployed with command line flag --managed.
 	// Which results in a hidden annotation in every OpenWhisk entity in manifest file.
-	if utils.Flags.Managed {
+	if deployer.Flags.Managed {
 		// OpenWhisk entities are annotated with Project Name and therefore
 		// Project Name in manifest/deployment file is mandatory for managed deployments
 		if deployer.ProjectName == "" {
@@ -149,7 +253,10 @@ func (deployer *ServiceDeployer) ConstructDeploymentPlan() error {
 		}
 		// Every OpenWhisk entity in the manifest file will be annotated with:
 		//managed: '{"__OW__PROJECT__NAME": <name>, "__OW__PROJECT_HASH": <hash>, "__OW__FILE": <path>}'
-		deployer.ManagedAnnotation, err = utils.GenerateManagedAnnotation(deployer.ProjectName, manifest.Filepath)
+		// along with the owner and contact of the project, if any
+		project := manifest.GetProject()
+		deployer.ManagedAnnotation, err = utils.GenerateManagedAnnotation(deployer.ProjectName,
+			interpolateString(project.Owner), interpolateString(project.Contact), deployer.Revision, deployer.Provenance, manifest.Filepath)
 		if err != nil {
 			return wskderrors.NewYAMLFileFormatError(manifest.Filepath, err.Error())
 		}
@@ -179,11 +286,8 @@ func (deployer *ServiceDeployer) ConstructDeploymentPlan() error {
 
 	// (TODO) delete this warning after deprecating application in manifest file
 	if manifest.Application.Name != "" {
-		wskprint.PrintOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_DEPRECATED_KEY_REPLACED_X_oldkey_X_filetype_X_newkey_X,
-			map[string]interface{}{
-				"oldkey": parsers.YAML_KEY_APPLICATION,
-				"newkey": parsers.YAML_KEY_PROJECT,
-				"filetype": "manifest"}))
+		parsers.Deprecations.Add(parsers.DeprecatedKey{FilePath: deployer.ManifestPath, FileType: parsers.FILE_TYPE_MANIFEST,
+			OldKey: parsers.YAML_KEY_APPLICATION, NewKey: parsers.YAML_KEY_PROJECT})
 	}
 
 	// process deployment file
@@ -197,11 +301,8 @@ func (deployer *ServiceDeployer) ConstructDeploymentPlan() error {
 
 		// (TODO) delete this warning after deprecating application in deployment file
 		if deploymentReader.DeploymentDescriptor.Application.Name != "" {
-			wskprint.PrintOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_DEPRECATED_KEY_REPLACED_X_oldkey_X_filetype_X_newkey_X,
-				map[string]interface{}{
-					"oldkey": parsers.YAML_KEY_APPLICATION,
-					"newkey": parsers.YAML_KEY_PROJECT,
-					"filetype": "deployment"}))
+			parsers.Deprecations.Add(parsers.DeprecatedKey{FilePath: deployer.DeploymentPath, FileType: parsers.FILE_TYPE_DEPLOYMENT,
+				OldKey: parsers.YAML_KEY_APPLICATION, NewKey: parsers.YAML_KEY_PROJECT})
 		}
 
 		// compare the name of the project
@@ -223,6 +324,30 @@ func (deployer *ServiceDeployer) ConstructDeploymentPlan() error {
 		}
 	}
 
+	if err := deployer.ApplyEntityOverrides(); err != nil {
+		return err
+	}
+	if err := deployer.ValidateGraph(manifest); err != nil {
+		return err
+	}
+	if err := deployer.ValidateFeedInputs(); err != nil {
+		return err
+	}
+	if err := deployer.ValidateRequiredInputs(manifest); err != nil {
+		return err
+	}
+	// the plugins of a dependency are validated by DeployDependencies() once
+	// it passes the dependency policy
+	if err := deployer.LoadPlugins(manifest, !deployer.IsDependency); err != nil {
+		return err
+	}
+	if err := deployer.EncryptInputs(); err != nil {
+		return err
+	}
+	if err := deployer.ApplyDeployAs(); err != nil {
+		return err
+	}
+
 	return err
 }
 
@@ -237,6 +362,13 @@ func (deployer *ServiceDeployer) ConstructUnDeploymentPlan() (*DeploymentProject
 	}
 
 	deployer.RootPackageName = manifest.Package.Packagename
+	deployer.ProjectName = manifest.GetProject().Name
+	deployer.ExpectedTarget = manifest.GetProject().ExpectedTarget
+
+	if err := deployer.Notifications.Load(manifest); err != nil {
+		return deployer.Deployment, err
+	}
+
 	manifestReader.InitRootPackage(manifestParser, manifest, whisk.KeyValue{})
 
 	// process file system
@@ -267,11 +399,8 @@ func (deployer *ServiceDeployer) ConstructUnDeploymentPlan() (*DeploymentProject
 
 	// (TODO) delete this warning after deprecating application in manifest file
 	if manifest.Application.Name != "" {
-		wskprint.PrintOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_DEPRECATED_KEY_REPLACED_X_oldkey_X_filetype_X_newkey_X,
-			map[string]interface{}{
-				"oldkey": parsers.YAML_KEY_APPLICATION,
-				"newkey": parsers.YAML_KEY_PROJECT,
-				"filetype": "manifest"}))
+		parsers.Deprecations.Add(parsers.DeprecatedKey{FilePath: deployer.ManifestPath, FileType: parsers.FILE_TYPE_MANIFEST,
+			OldKey: parsers.YAML_KEY_APPLICATION, NewKey: parsers.YAML_KEY_PROJECT})
 	}
 
 	// process deployment file
@@ -284,11 +413,8 @@ func (deployer *ServiceDeployer) ConstructUnDeploymentPlan() (*DeploymentProject
 
 		// (TODO) delete this warning after deprecating application in deployment file
 		if deploymentReader.DeploymentDescriptor.Application.Name != "" {
-			wskprint.PrintOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_DEPRECATED_KEY_REPLACED_X_oldkey_X_filetype_X_newkey_X,
-				map[string]interface{}{
-					"oldkey": parsers.YAML_KEY_APPLICATION,
-					"newkey": parsers.YAML_KEY_PROJECT,
-					"filetype": "deployment"}))
+			parsers.Deprecations.Add(parsers.DeprecatedKey{FilePath: deployer.DeploymentPath, FileType: parsers.FILE_TYPE_DEPLOYMENT,
+				OldKey: parsers.YAML_KEY_APPLICATION, NewKey: parsers.YAML_KEY_PROJECT})
 		}
 
 		// compare the name of the application
@@ -311,6 +437,17 @@ func (deployer *ServiceDeployer) ConstructUnDeploymentPlan() (*DeploymentProject
 		}
 	}
 
+	// the plugins of a dependency are only deployed, and so undeployed, with
+	// --allow-dep-side-effects, see CheckDependencyPolicy()
+	if !deployer.IsDependency || deployer.Flags.AllowDepSideEffects {
+		if err := deployer.LoadPlugins(manifest, false); err != nil {
+			return deployer.Deployment, err
+		}
+	}
+	if err := deployer.applyUnDeployAs(); err != nil {
+		return deployer.Deployment, err
+	}
+
 	verifiedPlan := deployer.Deployment
 
 	return verifiedPlan, err
@@ -336,11 +473,20 @@ func (deployer *ServiceDeployer) Deploy() error {
 		// TODO() make possible responses constants (enum?) and create "No" corallary
 		if strings.EqualFold(text, "y") || strings.EqualFold(text, "yes") {
 			deployer.InteractiveChoice = true
-			if err := deployer.deployAssets(); err != nil {
+			start := time.Now()
+			err := deployer.deployAssets()
+			deployer.notifyProject(NOTIFICATION_EVENT_DEPLOY, err)
+			deployer.recordMetrics(start, err)
+			if err != nil {
 				wskprint.PrintOpenWhiskError(wski18n.T(wski18n.ID_MSG_DEPLOYMENT_FAILED))
+				deployer.saveCheckpoint()
 				return err
 			}
 
+			deployer.clearCheckpoint()
+			deployer.recordHistory()
+			deployer.recordBlueGreen()
+			deployer.recordSnapshot()
 			wskprint.PrintOpenWhiskSuccess(wski18n.T(wski18n.ID_MSG_DEPLOYMENT_SUCCEEDED))
 			return nil
 
@@ -355,11 +501,20 @@ func (deployer *ServiceDeployer) Deploy() error {
 	}
 
 	// non-interactive
-	if err := deployer.deployAssets(); err != nil {
+	start := time.Now()
+	err := deployer.deployAssets()
+	deployer.notifyProject(NOTIFICATION_EVENT_DEPLOY, err)
+	deployer.recordMetrics(start, err)
+	if err != nil {
 		wskprint.PrintOpenWhiskError(wski18n.T(wski18n.ID_MSG_DEPLOYMENT_FAILED))
+		deployer.saveCheckpoint()
 		return err
 	}
 
+	deployer.clearCheckpoint()
+	deployer.recordHistory()
+	deployer.recordBlueGreen()
+	deployer.recordSnapshot()
 	wskprint.PrintOpenWhiskSuccess(wski18n.T(wski18n.T(wski18n.ID_MSG_DEPLOYMENT_SUCCEEDED)))
 	return nil
 
@@ -367,6 +522,16 @@ func (deployer *ServiceDeployer) Deploy() error {
 
 func (deployer *ServiceDeployer) deployAssets() error {
 
+	// the custom keys of plugins, e.g. databases, are deployed before the
+	// entities which may use them
+	if err := deployer.RunPlugins(PLUGIN_COMMAND_DEPLOY); err != nil {
+		return err
+	}
+
+	if len(deployer.DeployAs) > 0 {
+		return deployer.deployBlueGreen()
+	}
+
 	if err := deployer.DeployPackages(); err != nil {
 		return err
 	}
@@ -375,6 +540,10 @@ func (deployer *ServiceDeployer) deployAssets() error {
 		return err
 	}
 
+	if err := deployer.DeployBindings(); err != nil {
+		return err
+	}
+
 	if err := deployer.DeployActions(); err != nil {
 		return err
 	}
@@ -383,6 +552,10 @@ func (deployer *ServiceDeployer) deployAssets() error {
 		return err
 	}
 
+	if err := deployer.RunPostDeployHooks(); err != nil {
+		return err
+	}
+
 	if err := deployer.DeployTriggers(); err != nil {
 		return err
 	}
@@ -395,11 +568,21 @@ func (deployer *ServiceDeployer) deployAssets() error {
 		return err
 	}
 
+	if err := deployer.DeployOutputBindings(); err != nil {
+		return err
+	}
+
+	// entities skipped because of --continue-on-error fail the deployment, the
+	// managed entities are not refreshed since the failed ones may be outdated
+	if err := deployer.Failures.Error(); err != nil {
+		return err
+	}
+
 	// During managed deployments, after deploying list of entities in a project
 	// refresh previously deployed project entities, delete the assets which is no longer part of the project
 	// i.e. in a subsequent managed deployment of the same project minus few OpenWhisk entities
 	// from the manifest file must result in undeployment of those deleted entities
-	if utils.Flags.Managed {
+	if deployer.Flags.Managed {
 		if err := deployer.RefreshManagedEntities(deployer.ManagedAnnotation); err != nil {
 			errString := wski18n.T(wski18n.ID_MSG_MANAGED_UNDEPLOYMENT_FAILED)
 			whisk.Debug(whisk.DbgError, errString)
@@ -433,7 +616,9 @@ func (deployer *ServiceDeployer) DeployDependencies() error {
 				bindingPackage.Parameters = depRecord.Parameters
 				bindingPackage.Annotations = depRecord.Annotations
 
-				error := deployer.createBinding(bindingPackage)
+				error := deployer.inNamespace(bindingPackage.Namespace, func() error {
+					return deployer.createBinding(bindingPackage)
+				})
 				if error != nil {
 					return error
 				} else {
@@ -442,6 +627,8 @@ func (deployer *ServiceDeployer) DeployDependencies() error {
 					whisk.Debug(whisk.DbgInfo, output)
 				}
 
+			} else if deployer.ReusedDependencies[depName] {
+				continue
 			} else {
 				depServiceDeployer, err := deployer.getDependentDeployer(depName, depRecord)
 				if err != nil {
@@ -452,6 +639,12 @@ func (deployer *ServiceDeployer) DeployDependencies() error {
 				if err != nil {
 					return err
 				}
+				if err := deployer.CheckDependencyPolicy(depName, depServiceDeployer); err != nil {
+					return err
+				}
+				if err := depServiceDeployer.ValidatePlugins(); err != nil {
+					return err
+				}
 
 				if err := depServiceDeployer.deployAssets(); err != nil {
 					errString := wski18n.T(wski18n.ID_MSG_DEPENDENCY_DEPLOYMENT_FAILURE_X_name_X,
@@ -460,16 +653,16 @@ func (deployer *ServiceDeployer) DeployDependencies() error {
 					return err
 				}
 
-				// if the RootPackageName is different from depName
+				// if the root package is different from depName
 				// create a binding to the origin package
-				if depServiceDeployer.RootPackageName != depName {
+				if rootPackage := depServiceDeployer.dependencyRootPackage(depName); len(rootPackage) > 0 && rootPackage != depName {
 					bindingPackage := new(whisk.BindingPackage)
 					bindingPackage.Namespace = pack.Package.Namespace
 					bindingPackage.Name = depName
 					pub := false
 					bindingPackage.Publish = &pub
 
-					qName, err := utils.ParseQualifiedName(depServiceDeployer.RootPackageName, depServiceDeployer.Deployment.Packages[depServiceDeployer.RootPackageName].Package.Namespace)
+					qName, err := utils.ParseQualifiedName(rootPackage, depServiceDeployer.Deployment.Packages[rootPackage].Package.Namespace)
 					if err != nil {
 						return err
 					}
@@ -477,9 +670,11 @@ func (deployer *ServiceDeployer) DeployDependencies() error {
 					bindingPackage.Binding = whisk.Binding{qName.Namespace, qName.EntityName}
 
 					bindingPackage.Parameters = depRecord.Parameters
-					bindingPackage.Annotations = depRecord.Annotations
+					bindingPackage.Annotations = append(depRecord.Annotations, dependencyRefAnnotation(depRecord))
 
-					err = deployer.createBinding(bindingPackage)
+					err = deployer.inNamespace(bindingPackage.Namespace, func() error {
+						return deployer.createBinding(bindingPackage)
+					})
 					if err != nil {
 						return err
 					} else {
@@ -487,6 +682,10 @@ func (deployer *ServiceDeployer) DeployDependencies() error {
 							map[string]interface{}{"name": depName})
 						whisk.Debug(whisk.DbgInfo, output)
 					}
+				} else if rootPackage == depName && deployer.Flags.ReuseDependencies {
+					if err := deployer.recordDependencyRef(depName, depRecord, pack.Package.Namespace); err != nil {
+						return err
+					}
 				}
 			}
 		}
@@ -499,8 +698,12 @@ func (deployer *ServiceDeployer) DeployDependencies() error {
 func (deployer *ServiceDeployer) RefreshManagedEntities(maValue whisk.KeyValue) error {
 
 	ma := maValue.Value.(map[string]interface{})
-	if err := deployer.RefreshManagedTriggers(ma); err != nil {
-		return err
+	// triggers are not tracked by package, the triggers of the packages left
+	// out with --packages or --exclude-package would be taken as deleted
+	if !deployer.Flags.HasPackageSelection() {
+		if err := deployer.RefreshManagedTriggers(ma); err != nil {
+			return err
+		}
 	}
 
 	if err := deployer.RefreshManagedRules(ma); err != nil {
@@ -548,7 +751,7 @@ func (deployer *ServiceDeployer) RefreshManagedActions(packageName string, ma ma
 				wskprint.PrintOpenWhiskWarning(output)
 
 				var err error
-				err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+				err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
 					_, err := deployer.Client.Actions.Delete(actionName)
 					return err
 				})
@@ -588,8 +791,16 @@ func (deployer *ServiceDeployer) RefreshManagedTriggers(ma map[string]interface{
 						"project": ma[utils.OW_PROJECT_NAME]})
 				wskprint.PrintOpenWhiskWarning(output)
 
+				// the feed provider is told to remove its registration first
+				if feedname, isFeed := utils.IsFeedAction(&trigger); isFeed {
+					if err := deployer.deleteFeedAction(&trigger, feedname); err != nil {
+						return err
+					}
+					continue
+				}
+
 				var err error
-				err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+				err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
 					_, _, err := deployer.Client.Triggers.Delete(trigger.Name)
 					return err
 				})
@@ -623,6 +834,10 @@ func (deployer *ServiceDeployer) RefreshManagedPackages(ma map[string]interface{
 	// hash differs, indicates that the package was part of the current project but
 	// now is deleted from the manifest file and should be undeployed.
 	for _, pkg := range packages {
+		// packages left out with --packages or --exclude-package are kept
+		if !deployer.Flags.IsPackageSelected(pkg.Name) {
+			continue
+		}
 		if a := pkg.Annotations.GetValue(utils.MANAGED); a != nil {
 			// decode the JSON blob and retrieve __OW_PROJECT_NAME and __OW_PROJECT_HASH
 			pa := a.(map[string]interface{})
@@ -641,7 +856,7 @@ func (deployer *ServiceDeployer) RefreshManagedPackages(ma map[string]interface{
 				wskprint.PrintOpenWhiskWarning(output)
 
 				var err error
-				err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+				err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
 					_, err := deployer.Client.Packages.Delete(pkg.Name)
 					return err
 				})
@@ -657,7 +872,16 @@ func (deployer *ServiceDeployer) RefreshManagedPackages(ma map[string]interface{
 
 func (deployer *ServiceDeployer) DeployPackages() error {
 	for _, pack := range deployer.Deployment.Packages {
-		err := deployer.createPackage(pack.Package)
+		packa := pack.Package
+		hooks := pack.Hooks
+		err := deployer.deployEntity(parsers.YAML_KEY_PACKAGE, packa.Name, func(deployer *ServiceDeployer) error {
+			return deployer.inNamespace(packa.Namespace, func() error {
+				if err := deployer.runHooks(hooks, parsers.YAML_KEY_PRE_DEPLOY); err != nil {
+					return err
+				}
+				return deployer.createPackage(packa)
+			})
+		})
 		if err != nil {
 			return err
 		}
@@ -668,9 +892,13 @@ func (deployer *ServiceDeployer) DeployPackages() error {
 // Deploy Sequences into OpenWhisk
 func (deployer *ServiceDeployer) DeploySequences() error {
 
+	if err := deployer.validateSequenceComponents(); err != nil {
+		return err
+	}
+
 	for _, pack := range deployer.Deployment.Packages {
 		for _, action := range pack.Sequences {
-			err := deployer.createAction(pack.Package.Name, action.Action)
+			err := deployer.deployAction(parsers.YAML_KEY_SEQUENCE, pack.Package, action.Action)
 			if err != nil {
 				return err
 			}
@@ -679,12 +907,66 @@ func (deployer *ServiceDeployer) DeploySequences() error {
 	return nil
 }
 
+// validateSequenceComponents checks that every action of a sequence is either
+// an action (or sequence) of the deployment or already exists on the server
+func (deployer *ServiceDeployer) validateSequenceComponents() error {
+	declared := make(map[string]bool)
+	for packageName, pack := range deployer.Deployment.Packages {
+		namespace := pack.Package.Namespace
+		if len(namespace) == 0 && deployer.ClientConfig != nil {
+			namespace = deployer.ClientConfig.Namespace
+		}
+		for name := range pack.Actions {
+			declared[path.Join("/"+namespace, packageName, name)] = true
+		}
+		for name := range pack.Sequences {
+			declared[path.Join("/"+namespace, packageName, name)] = true
+		}
+	}
+
+	for _, pack := range deployer.Deployment.Packages {
+		for name, sequence := range pack.Sequences {
+			for _, component := range sequence.Action.Exec.Components {
+				if declared[component] || deployer.actionExists(component) {
+					declared[component] = true
+					continue
+				}
+				return wskderrors.NewYAMLFileFormatError(deployer.ManifestPath,
+					wski18n.T(wski18n.ID_ERR_SEQUENCE_COMPONENT_NOT_FOUND_X_sequence_X_action_X,
+						map[string]interface{}{wski18n.KEY_SEQUENCE: name, wski18n.KEY_ACTION: component}))
+			}
+		}
+	}
+	return nil
+}
+
+// actionExists looks up a fully qualified action, i.e. /namespace/package/action
+// or /namespace/action, on the server
+func (deployer *ServiceDeployer) actionExists(qualifiedName string) bool {
+	if deployer.Client == nil {
+		return false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(qualifiedName, "/"), "/", 2)
+	if len(parts) != 2 {
+		return false
+	}
+	err := deployer.inNamespace(parts[0], func() error {
+		_, _, err := deployer.Client.Actions.Get(parts[1])
+		return err
+	})
+	return err == nil
+}
+
 // Deploy Actions into OpenWhisk
 func (deployer *ServiceDeployer) DeployActions() error {
 
+	if deployer.Flags.Parallel > 1 {
+		return deployer.deployActionsInParallel(deployer.Flags.Parallel)
+	}
+
 	for _, pack := range deployer.Deployment.Packages {
 		for _, action := range pack.Actions {
-			err := deployer.createAction(pack.Package.Name, action.Action)
+			err := deployer.deployAction(parsers.YAML_KEY_ACTION, pack.Package, action.Action)
 			if err != nil {
 				return err
 			}
@@ -696,19 +978,24 @@ func (deployer *ServiceDeployer) DeployActions() error {
 // Deploy Triggers into OpenWhisk
 func (deployer *ServiceDeployer) DeployTriggers() error {
 	for _, trigger := range deployer.Deployment.Triggers {
-
-		if feedname, isFeed := utils.IsFeedAction(trigger); isFeed {
-			err := deployer.createFeedAction(trigger, feedname)
-			if err != nil {
-				return err
-			}
-		} else {
-			err := deployer.createTrigger(trigger)
-			if err != nil {
-				return err
-			}
+		trigger := trigger
+		err := deployer.deployEntity(parsers.YAML_KEY_TRIGGER, trigger.Name, func(deployer *ServiceDeployer) error {
+			return deployer.inNamespace(trigger.Namespace, func() error {
+				if feedname, isFeed := utils.IsFeedAction(trigger); isFeed {
+					// createFeedAction() is also used to recreate feeds with bound outputs,
+					// so the checkpoint is looked up here
+					if deployer.isCheckpointed(parsers.TRIGGER_FEED, trigger.Name) {
+						deployer.DeployedOutputs.AddTrigger(deployer.ClientConfig.Namespace, trigger.Name)
+						return nil
+					}
+					return deployer.createFeedAction(trigger, feedname)
+				}
+				return deployer.createTrigger(trigger)
+			})
+		})
+		if err != nil {
+			return err
 		}
-
 	}
 	return nil
 
@@ -717,7 +1004,12 @@ func (deployer *ServiceDeployer) DeployTriggers() error {
 // Deploy Rules into OpenWhisk
 func (deployer *ServiceDeployer) DeployRules() error {
 	for _, rule := range deployer.Deployment.Rules {
-		err := deployer.createRule(rule)
+		rule := rule
+		err := deployer.deployEntity(parsers.YAML_KEY_RULE, rule.Name, func(deployer *ServiceDeployer) error {
+			return deployer.inNamespace(rule.Namespace, func() error {
+				return deployer.createRule(rule)
+			})
+		})
 		if err != nil {
 			return err
 		}
@@ -727,8 +1019,20 @@ func (deployer *ServiceDeployer) DeployRules() error {
 
 // Deploy Apis into OpenWhisk
 func (deployer *ServiceDeployer) DeployApis() error {
+	provider, err := deployer.Flags.GetProvider()
+	if err != nil {
+		return err
+	}
+	if provider != nil && !provider.ApiGateway && len(deployer.Deployment.Apis) > 0 {
+		wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_PROVIDER_NO_API_GATEWAY_X_name_X_count_X,
+			map[string]interface{}{wski18n.KEY_NAME: provider.Name, wski18n.KEY_COUNT: len(deployer.Deployment.Apis)}))
+		return nil
+	}
 	for _, api := range deployer.Deployment.Apis {
-		err := deployer.createApi(api)
+		api := api
+		err := deployer.deployEntity(parsers.YAML_KEY_API, api.ApiDoc.ApiName, func(deployer *ServiceDeployer) error {
+			return deployer.createApi(api)
+		})
 		if err != nil {
 			return err
 		}
@@ -738,11 +1042,16 @@ func (deployer *ServiceDeployer) DeployApis() error {
 
 func (deployer *ServiceDeployer) createBinding(packa *whisk.BindingPackage) error {
 
+	if deployer.isCheckpointed(parsers.PACKAGE_BINDING, packa.Name) {
+		deployer.DeployedOutputs.AddPackage(deployer.ClientConfig.Namespace, packa.Name)
+		return nil
+	}
+
 	displayPreprocessingInfo("package binding", packa.Name, true)
 
 	var err error
 	var response *http.Response
-	err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+	err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
 		_, response, err = deployer.Client.Packages.Insert(packa, true)
 		return err
 	})
@@ -751,17 +1060,24 @@ func (deployer *ServiceDeployer) createBinding(packa *whisk.BindingPackage) erro
 		return createWhiskClientError(err.(*whisk.WskError), response, "package binding", true)
 	}
 
+	deployer.Checkpoint.Add(parsers.PACKAGE_BINDING, packa.Name)
+	deployer.DeployedOutputs.AddPackage(deployer.ClientConfig.Namespace, packa.Name)
 	displayPostprocessingInfo(parsers.PACKAGE_BINDING, packa.Name, true)
 	return nil
 }
 
 func (deployer *ServiceDeployer) createPackage(packa *whisk.Package) error {
 
+	if deployer.isCheckpointed(parsers.YAML_KEY_PACKAGE, packa.Name) {
+		deployer.DeployedOutputs.AddPackage(deployer.ClientConfig.Namespace, packa.Name)
+		return nil
+	}
+
 	displayPreprocessingInfo(parsers.YAML_KEY_PACKAGE, packa.Name, true)
 
 	var err error
 	var response *http.Response
-	err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+	err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
 		_, response, err = deployer.Client.Packages.Insert(packa, true)
 		return err
 	})
@@ -769,17 +1085,24 @@ func (deployer *ServiceDeployer) createPackage(packa *whisk.Package) error {
 		return createWhiskClientError(err.(*whisk.WskError), response, parsers.YAML_KEY_PACKAGE, true)
 	}
 
+	deployer.Checkpoint.Add(parsers.YAML_KEY_PACKAGE, packa.Name)
+	deployer.DeployedOutputs.AddPackage(deployer.ClientConfig.Namespace, packa.Name)
 	displayPostprocessingInfo(parsers.YAML_KEY_PACKAGE, packa.Name, true)
 	return nil
 }
 
 func (deployer *ServiceDeployer) createTrigger(trigger *whisk.Trigger) error {
 
+	if deployer.isCheckpointed(parsers.YAML_KEY_TRIGGER, trigger.Name) {
+		deployer.DeployedOutputs.AddTrigger(deployer.ClientConfig.Namespace, trigger.Name)
+		return nil
+	}
+
 	displayPreprocessingInfo(parsers.YAML_KEY_TRIGGER, trigger.Name, true)
 
 	var err error
 	var response *http.Response
-	err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+	err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
 		_, response, err = deployer.Client.Triggers.Insert(trigger, true)
 		return err
 	})
@@ -787,6 +1110,8 @@ func (deployer *ServiceDeployer) createTrigger(trigger *whisk.Trigger) error {
 		return createWhiskClientError(err.(*whisk.WskError), response, parsers.YAML_KEY_TRIGGER, true)
 	}
 
+	deployer.Checkpoint.Add(parsers.YAML_KEY_TRIGGER, trigger.Name)
+	deployer.DeployedOutputs.AddTrigger(deployer.ClientConfig.Namespace, trigger.Name)
 	displayPostprocessingInfo(parsers.YAML_KEY_TRIGGER, trigger.Name, true)
 	return nil
 }
@@ -803,33 +1128,43 @@ func (deployer *ServiceDeployer) createFeedAction(trigger *whisk.Trigger, feedNa
 		params[keyVal.Key] = keyVal.Value
 	}
 
-	// TODO() defone keys and lifecylce operation names as const
-	params["authKey"] = deployer.ClientConfig.AuthToken
-	params["lifecycleEvent"] = "CREATE"
-	params["triggerName"] = "/" + deployer.Client.Namespace + "/" + trigger.Name
+	for key, value := range deployer.feedParameters(trigger, FEED_LIFECYCLE_EVENT_CREATE) {
+		params[key] = value
+	}
 
 	pub := true
 	t := &whisk.Trigger{
-		Name:        trigger.Name,
-		Annotations: trigger.Annotations,
-		Publish:     &pub,
+		Name: trigger.Name,
+		Annotations: append(removeKeyValue(trigger.Annotations, ANNOTATION_FEED_HASH),
+			whisk.KeyValue{Key: ANNOTATION_FEED_HASH, Value: deployer.feedHash(trigger, feedName)}),
+		Publish: &pub,
 	}
 
 	// triggers created using any of the feeds including cloudant, alarm, message hub etc
-	// does not honor UPDATE or overwrite=true with CREATE
+	// does not honor overwrite=true with CREATE
 	// wskdeploy is designed such that, it updates trigger feeds if they exists
 	// or creates new in case they are missing
-	// To address trigger feed UPDATE issue, we are checking here if trigger feed
-	// exists, if so, delete it and recreate it
-	_, r, _ := deployer.Client.Triggers.Get(trigger.Name)
-	if r.StatusCode == 200 {
+	// A trigger deployed with the same feed is updated with the UPDATE lifecycle
+	// event, see updateFeedAction(), otherwise it is deleted and recreated
+	deployed, r, _ := deployer.Client.Triggers.Get(trigger.Name)
+	if r != nil && r.StatusCode == 200 {
+		updated, err := deployer.updateFeedAction(t, deployed, feedName, params)
+		if err != nil {
+			return err
+		}
+		if updated {
+			deployer.Checkpoint.Add(parsers.TRIGGER_FEED, trigger.Name)
+			deployer.DeployedOutputs.AddTrigger(deployer.ClientConfig.Namespace, trigger.Name)
+			displayPostprocessingInfo(parsers.TRIGGER_FEED, trigger.Name, true)
+			return nil
+		}
 		// trigger feed already exists so first lets delete it and then recreate it
 		deployer.deleteFeedAction(trigger, feedName)
 	}
 
 	var err error
 	var response *http.Response
-	err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+	err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
 		_, response, err = deployer.Client.Triggers.Insert(t, true)
 		return err
 	})
@@ -844,7 +1179,7 @@ func (deployer *ServiceDeployer) createFeedAction(trigger *whisk.Trigger, feedNa
 
 		namespace := deployer.Client.Namespace
 		deployer.Client.Namespace = qName.Namespace
-		err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+		err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
 			_, response, err = deployer.Client.Actions.Invoke(qName.EntityName, params, true, false)
 			return err
 		})
@@ -854,7 +1189,7 @@ func (deployer *ServiceDeployer) createFeedAction(trigger *whisk.Trigger, feedNa
 			// Remove the created trigger
 			deployer.Client.Triggers.Delete(trigger.Name)
 
-			retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+			deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
 				_, _, err := deployer.Client.Triggers.Delete(trigger.Name)
 				return err
 			})
@@ -863,11 +1198,17 @@ func (deployer *ServiceDeployer) createFeedAction(trigger *whisk.Trigger, feedNa
 		}
 	}
 
+	deployer.Checkpoint.Add(parsers.TRIGGER_FEED, trigger.Name)
+	deployer.DeployedOutputs.AddTrigger(deployer.ClientConfig.Namespace, trigger.Name)
 	displayPostprocessingInfo(parsers.TRIGGER_FEED, trigger.Name, true)
 	return nil
 }
 
 func (deployer *ServiceDeployer) createRule(rule *whisk.Rule) error {
+	if deployer.isCheckpointed(parsers.YAML_KEY_RULE, rule.Name) {
+		return nil
+	}
+
 	displayPreprocessingInfo(parsers.YAML_KEY_RULE, rule.Name, true)
 
 	// The rule's trigger should include the namespace with pattern /namespace/trigger
@@ -876,17 +1217,11 @@ func (deployer *ServiceDeployer) createRule(rule *whisk.Rule) error {
 	// with pattern /namespace/package/action
 	// TODO(TBD): please refer https://github.com/openwhisk/openwhisk/issues/1577
 
-	// if it contains a slash, then the action is qualified by a package name
-	if strings.Contains(rule.Action.(string), "/") {
-		rule.Action = deployer.getQualifiedName(rule.Action.(string), deployer.ClientConfig.Namespace)
-	} else {
-		// if not, we assume the action is inside the root package
-		rule.Action = deployer.getQualifiedName(strings.Join([]string{deployer.RootPackageName, rule.Action.(string)}, "/"), deployer.ClientConfig.Namespace)
-	}
+	rule.Action = deployer.qualifiedRuleAction(rule.Action.(string))
 
 	var err error
 	var response *http.Response
-	err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+	err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
 		_, response, err = deployer.Client.Rules.Insert(rule, true)
 		return err
 	})
@@ -895,24 +1230,54 @@ func (deployer *ServiceDeployer) createRule(rule *whisk.Rule) error {
 		return createWhiskClientError(err.(*whisk.WskError), response, parsers.YAML_KEY_RULE, true)
 	}
 
+	// rules are created active, the status of an existing rule is kept unless
+	// the deployment file gives it
+	if len(rule.Status) > 0 {
+		err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+			_, response, err = deployer.Client.Rules.SetState(rule.Name, rule.Status)
+			return err
+		})
+		if err != nil {
+			return createWhiskClientError(err.(*whisk.WskError), response, parsers.YAML_KEY_RULE, true)
+		}
+	}
+
+	deployer.Checkpoint.Add(parsers.YAML_KEY_RULE, rule.Name)
 	displayPostprocessingInfo(parsers.YAML_KEY_RULE, rule.Name, true)
 	return nil
 }
 
 // Utility function to call go-whisk framework to make action
 func (deployer *ServiceDeployer) createAction(pkgname string, action *whisk.Action) error {
+	webSecretGenerated := deployer.isWebSecretGenerated(pkgname, action.Name)
+	delAnnotations := deployer.delAnnotations(pkgname, action.Name)
 	// call ActionService through the Client
 	if deployer.DeployActionInPackage {
 		// the action will be created under package with pattern 'packagename/actionname'
 		action.Name = strings.Join([]string{pkgname, action.Name}, "/")
 	}
 
-	displayPreprocessingInfo(parsers.YAML_KEY_ACTION, action.Name, true)
+	if deployer.isCheckpointed(parsers.YAML_KEY_ACTION, action.Name) {
+		deployer.DeployedOutputs.AddAction(deployer.ClientConfig.Host, deployer.ClientConfig.Namespace, action.Name, action.Annotations)
+		return nil
+	}
+
+	deployer.Output.Debug(whisk.DbgInfo, preprocessingInfo(parsers.YAML_KEY_ACTION, action.Name, true))
+
+	if webSecretGenerated {
+		deployer.reuseWebSecret(action)
+	}
+	if len(delAnnotations) > 0 || deployer.OverwriteAnnotations {
+		if err := deployer.removeAnnotations(action, delAnnotations); err != nil {
+			return err
+		}
+	}
 
 	var err error
 	var response *http.Response
-	err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
-		_, response, err = deployer.Client.Actions.Insert(action, true)
+	var deployedAction *whisk.Action
+	err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+		deployedAction, response, err = deployer.Client.Actions.Insert(action, true)
 		return err
 	})
 
@@ -920,21 +1285,168 @@ func (deployer *ServiceDeployer) createAction(pkgname string, action *whisk.Acti
 		return createWhiskClientError(err.(*whisk.WskError), response, parsers.YAML_KEY_ACTION, true)
 	}
 
-	displayPostprocessingInfo(parsers.YAML_KEY_ACTION, action.Name, true)
+	deployer.Checkpoint.Add(parsers.YAML_KEY_ACTION, action.Name)
+	// annotations returned by the platform include the generated ones
+	annotations := action.Annotations
+	if deployedAction != nil {
+		annotations = deployedAction.Annotations
+	}
+	deployer.DeployedOutputs.AddAction(deployer.ClientConfig.Host, deployer.ClientConfig.Namespace, action.Name, annotations)
+	deployer.Output.Debug(whisk.DbgInfo, postprocessingInfo(parsers.YAML_KEY_ACTION, action.Name, true))
+
+	if fmt.Sprint(annotations.GetValue(utils.WEB_EXPORT_ANNOT)) == "true" {
+		packageName, actionName := splitActionName(action.Name)
+		deployer.Output.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_WEB_ACTION_URL_X_name_X_url_X,
+			map[string]interface{}{
+				wski18n.KEY_NAME: action.Name,
+				wski18n.KEY_URL:  utils.WebActionURL(deployer.ClientConfig.Host, deployer.ClientConfig.Namespace, packageName, actionName)}))
+	}
+	if webSecretGenerated {
+		deployer.Output.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_WEB_ACTION_SECRET_X_name_X_secret_X,
+			map[string]interface{}{
+				wski18n.KEY_NAME:   action.Name,
+				wski18n.KEY_SECRET: action.Annotations.GetValue(utils.REQUIRE_WHISK_AUTH_ANNOT)}))
+	}
 	return nil
 }
 
+// the secret of a web action with "web-secure: true" is generated when the
+// manifest is parsed, it is looked up in the deployment by package and action
+func (deployer *ServiceDeployer) isWebSecretGenerated(pkgname string, actionName string) bool {
+	if deployer.Deployment == nil {
+		return false
+	}
+	if pack, ok := deployer.Deployment.Packages[pkgname]; ok {
+		return pack.Actions[actionName].WebSecretGenerated
+	}
+	return false
+}
+
+func (deployer *ServiceDeployer) delAnnotations(pkgname string, actionName string) []string {
+	if deployer.Deployment == nil {
+		return nil
+	}
+	if pack, ok := deployer.Deployment.Packages[pkgname]; ok {
+		return pack.Actions[actionName].DelAnnotations
+	}
+	return nil
+}
+
+// annotations the platform adds to actions, they are never removed
+var platformAnnotations = map[string]bool{"exec": true, "provide-api-key": true}
+
+// removedAnnotations returns the keys of the deployed annotations which the
+// update of an action removes: the ones listed by del_annotations or, with
+// overwrite_annotations, all the ones which are not set anymore
+func removedAnnotations(deployed whisk.KeyValueArr, annotations whisk.KeyValueArr, delAnnotations []string, overwrite bool) []string {
+	listed := make(map[string]bool, len(delAnnotations))
+	for _, key := range delAnnotations {
+		listed[key] = true
+	}
+	removed := make([]string, 0)
+	for _, annotation := range deployed {
+		if annotations.FindKeyValue(annotation.Key) >= 0 || platformAnnotations[annotation.Key] {
+			continue
+		}
+		if listed[annotation.Key] || overwrite {
+			removed = append(removed, annotation.Key)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// removeAnnotations removes the annotations of the deployed action, see
+// removedAnnotations(). The update replaces the annotations of the action,
+// except when it has no annotations left as they are then left out of the
+// request, in which case the action is deleted before it is created again.
+func (deployer *ServiceDeployer) removeAnnotations(action *whisk.Action, delAnnotations []string) error {
+	deployedAction, _, err := deployer.Client.Actions.Get(action.Name)
+	if err != nil || deployedAction == nil {
+		// the action is created
+		return nil
+	}
+	removed := removedAnnotations(deployedAction.Annotations, action.Annotations, delAnnotations, deployer.OverwriteAnnotations)
+	if len(removed) == 0 {
+		return nil
+	}
+	for _, key := range removed {
+		deployer.Output.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_ANNOTATION_REMOVED_X_key_X_action_X,
+			map[string]interface{}{wski18n.KEY_KEY: key, wski18n.KEY_ACTION: action.Name}))
+	}
+	if len(action.Annotations) > 0 {
+		return nil
+	}
+
+	deployer.Output.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_ACTION_RECREATED_X_action_X,
+		map[string]interface{}{wski18n.KEY_ACTION: action.Name}))
+	var response *http.Response
+	err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+		response, err = deployer.Client.Actions.Delete(action.Name)
+		return err
+	})
+	if err != nil {
+		return createWhiskClientError(err.(*whisk.WskError), response, parsers.YAML_KEY_ACTION, true)
+	}
+	return nil
+}
+
+// reuseWebSecret replaces a generated secret with the secret of the deployed
+// action, so that the callers of a web action do not have to be updated each
+// time it is deployed
+func (deployer *ServiceDeployer) reuseWebSecret(action *whisk.Action) {
+	deployedAction, _, err := deployer.Client.Actions.Get(action.Name)
+	if err != nil || deployedAction == nil {
+		return
+	}
+	var secret interface{}
+	switch value := deployedAction.Annotations.GetValue(utils.REQUIRE_WHISK_AUTH_ANNOT).(type) {
+	case float64:
+		if value != float64(int64(value)) {
+			return
+		}
+		secret = int64(value)
+	case string:
+		secret = value
+	default:
+		// not secured, or secured by the OpenWhisk authentication (true)
+		return
+	}
+	for i := range action.Annotations {
+		if action.Annotations[i].Key == utils.REQUIRE_WHISK_AUTH_ANNOT {
+			action.Annotations[i].Value = secret
+		}
+	}
+}
+
 // create api (API Gateway functionality)
 func (deployer *ServiceDeployer) createApi(api *whisk.ApiCreateRequest) error {
 
+	// several APIs can share the same name, qualify it by its path and verb
+	apiKey := api.ApiDoc.ApiName + " " + api.ApiDoc.GatewayMethod + " " + api.ApiDoc.GatewayBasePath + api.ApiDoc.GatewayRelPath
+	if deployer.isCheckpointed(parsers.YAML_KEY_API, apiKey) {
+		return nil
+	}
+
 	displayPreprocessingInfo(parsers.YAML_KEY_API, api.ApiDoc.ApiName, true)
 
-	var err error
+	if len(api.ApiDoc.Swagger) > 0 {
+		if err := deployer.resolveSwaggerBackends(api); err != nil {
+			return err
+		}
+	}
+
+	client, options, err := deployer.apigwClient()
+	if err != nil {
+		return err
+	}
+
 	var response *http.Response
+	var deployedApi *whisk.ApiCreateResponse
 
 	// TODO() Is there an api delete function? could not find it
-	err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
-		_, response, err = deployer.Client.Apis.Insert(api, nil, true)
+	err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+		deployedApi, response, err = client.Apis.Insert(api, options, true)
 		return err
 	})
 
@@ -942,10 +1454,170 @@ func (deployer *ServiceDeployer) createApi(api *whisk.ApiCreateRequest) error {
 		return createWhiskClientError(err.(*whisk.WskError), response, parsers.YAML_KEY_API, true)
 	}
 
+	deployer.Checkpoint.Add(parsers.YAML_KEY_API, apiKey)
+	if deployedApi != nil {
+		deployer.DeployedOutputs.AddApi(api.ApiDoc.ApiName, deployedApi.BaseUrl)
+	}
 	displayPostprocessingInfo(parsers.YAML_KEY_API, api.ApiDoc.ApiName, true)
 	return nil
 }
 
+// resolveSwaggerBackends sets the namespace and the web action URL of the
+// operations of an OpenAPI document which are backed by actions of the project
+func (deployer *ServiceDeployer) resolveSwaggerBackends(api *whisk.ApiCreateRequest) error {
+	document, err := parsers.ParseSwaggerDocument([]byte(api.ApiDoc.Swagger), api.ApiDoc.ApiName, "")
+	if err != nil {
+		return err
+	}
+	namespace := deployer.ClientConfig.Namespace
+	for _, operation := range document.Operations {
+		if operation.Namespace == parsers.SWAGGER_DEFAULT_NAMESPACE {
+			operation.SetBackend(namespace, utils.WebActionURL(deployer.ClientConfig.Host,
+				namespace, operation.Package, operation.Action)+utils.WEB_ACTION_HTTP_EXTENSION)
+		}
+	}
+	if api.ApiDoc.Swagger, err = document.JSON(); err != nil {
+		return err
+	}
+	api.ApiDoc.Namespace = namespace
+	return nil
+}
+
+// apigwClient returns the client which creates APIs, along with the options
+// authenticating the requests to the API gateway if an access token is set or
+// provided, see ApigwAuth
+func (deployer *ServiceDeployer) apigwClient() (*whisk.Client, *whisk.ApiCreateRequestOptions, error) {
+	client := deployer.Client
+	if deployer.ApigwClient != nil {
+		client = deployer.ApigwClient
+	}
+	if client == nil {
+		return client, nil, nil
+	}
+	token := client.ApigwAccessToken
+	if len(token) == 0 && deployer.ApigwAuth != nil {
+		var err error
+		if token, err = deployer.ApigwAuth.AccessToken(); err != nil {
+			return client, nil, err
+		}
+	}
+	if len(token) == 0 {
+		return client, nil, nil
+	}
+	return client, &whisk.ApiCreateRequestOptions{
+		// the API gateway identifies the user by the UUID of the auth key
+		SpaceGuid:   strings.Split(client.AuthToken, ":")[0],
+		AccessToken: token,
+	}, nil
+}
+
+// DeployOutputBindings is the second binding pass: once all entities are
+// created, the ones with inputs referring to deployed values, for example
+// ${deployed.packages.hello.actions.world.url}, are resolved and updated.
+func (deployer *ServiceDeployer) DeployOutputBindings() error {
+	for _, pack := range deployer.Deployment.Packages {
+		if HasDeployedReferences(pack.Package.Parameters) {
+			params, err := deployer.resolveDeployedReferences(parsers.YAML_KEY_PACKAGE, pack.Package.Name, pack.Package.Parameters)
+			if err != nil {
+				return err
+			}
+			pack.Package.Parameters = params
+			if err := deployer.updateEntity(parsers.YAML_KEY_PACKAGE, pack.Package.Name, pack.Package.Namespace, func() (*http.Response, error) {
+				_, response, err := deployer.Client.Packages.Insert(pack.Package, true)
+				return response, err
+			}); err != nil {
+				return err
+			}
+		}
+
+		actions := make([]utils.ActionRecord, 0, len(pack.Actions)+len(pack.Sequences))
+		for _, action := range pack.Actions {
+			actions = append(actions, action)
+		}
+		for _, action := range pack.Sequences {
+			actions = append(actions, action)
+		}
+		for _, action := range actions {
+			if !HasDeployedReferences(action.Action.Parameters) {
+				continue
+			}
+			params, err := deployer.resolveDeployedReferences(parsers.YAML_KEY_ACTION, action.Action.Name, action.Action.Parameters)
+			if err != nil {
+				return err
+			}
+			// the action name was already qualified by its package when it was created
+			wskAction := action.Action
+			wskAction.Parameters = params
+			if err := deployer.updateEntity(parsers.YAML_KEY_ACTION, wskAction.Name, pack.Package.Namespace, func() (*http.Response, error) {
+				_, response, err := deployer.Client.Actions.Insert(wskAction, true)
+				return response, err
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, trigger := range deployer.Deployment.Triggers {
+		if !HasDeployedReferences(trigger.Parameters) {
+			continue
+		}
+		params, err := deployer.resolveDeployedReferences(parsers.YAML_KEY_TRIGGER, trigger.Name, trigger.Parameters)
+		if err != nil {
+			return err
+		}
+		trigger.Parameters = params
+		if feedname, isFeed := utils.IsFeedAction(trigger); isFeed {
+			// feed parameters are only passed to the feed action, update the feed
+			if err := deployer.inNamespace(trigger.Namespace, func() error {
+				return deployer.createFeedAction(trigger, feedname)
+			}); err != nil {
+				return err
+			}
+		} else {
+			wskTrigger := trigger
+			if err := deployer.updateEntity(parsers.YAML_KEY_TRIGGER, wskTrigger.Name, wskTrigger.Namespace, func() (*http.Response, error) {
+				_, response, err := deployer.Client.Triggers.Insert(wskTrigger, true)
+				return response, err
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (deployer *ServiceDeployer) resolveDeployedReferences(entity string, name string, params whisk.KeyValueArr) (whisk.KeyValueArr, error) {
+	resolved, unresolved := deployer.DeployedOutputs.ResolveParameters(params)
+	if len(unresolved) > 0 {
+		errString := wski18n.T(wski18n.ID_ERR_DEPLOYED_REFERENCE_UNRESOLVED_X_reference_X_key_X_name_X,
+			map[string]interface{}{
+				wski18n.KEY_REFERENCE: strings.Join(unresolved, ", "),
+				wski18n.KEY_KEY:       entity,
+				wski18n.KEY_NAME:      name})
+		return nil, wskderrors.NewYAMLFileFormatError(deployer.ManifestPath, errString)
+	}
+	return resolved, nil
+}
+
+func (deployer *ServiceDeployer) updateEntity(entity string, name string, namespace string, update func() (*http.Response, error)) error {
+	displayPreprocessingInfo(entity, name, true)
+
+	var err error
+	var response *http.Response
+	err = deployer.inNamespace(namespace, func() error {
+		return deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+			response, err = update()
+			return err
+		})
+	})
+	if err != nil {
+		return createWhiskClientError(err.(*whisk.WskError), response, entity, true)
+	}
+
+	displayPostprocessingInfo(entity, name, true)
+	return nil
+}
+
 func (deployer *ServiceDeployer) UnDeploy(verifiedPlan *DeploymentProject) error {
 	if deployer.IsInteractive == true {
 		deployer.printDeploymentAssets(verifiedPlan)
@@ -964,7 +1636,9 @@ func (deployer *ServiceDeployer) UnDeploy(verifiedPlan *DeploymentProject) error
 		if strings.EqualFold(text, "y") || strings.EqualFold(text, "yes") {
 			deployer.InteractiveChoice = true
 
-			if err := deployer.unDeployAssets(verifiedPlan); err != nil {
+			err := deployer.unDeployAssets(verifiedPlan)
+			deployer.notifyProject(NOTIFICATION_EVENT_UNDEPLOY, err)
+			if err != nil {
 				wskprint.PrintOpenWhiskError(wski18n.T(wski18n.T(wski18n.ID_MSG_UNDEPLOYMENT_FAILED)))
 				return err
 			}
@@ -980,7 +1654,9 @@ func (deployer *ServiceDeployer) UnDeploy(verifiedPlan *DeploymentProject) error
 	}
 
 	// non-interactive
-	if err := deployer.unDeployAssets(verifiedPlan); err != nil {
+	err := deployer.unDeployAssets(verifiedPlan)
+	deployer.notifyProject(NOTIFICATION_EVENT_UNDEPLOY, err)
+	if err != nil {
 		wskprint.PrintOpenWhiskError(wski18n.T(wski18n.T(wski18n.ID_MSG_UNDEPLOYMENT_FAILED)))
 		return err
 	}
@@ -1011,27 +1687,47 @@ func (deployer *ServiceDeployer) unDeployAssets(verifiedPlan *DeploymentProject)
 		return err
 	}
 
+	// the dependencies and bindings are shared with the entities of the other
+	// suffixes
+	if len(deployer.DeployAs) > 0 {
+		return nil
+	}
+
+	if err := deployer.UnDeployBindings(verifiedPlan); err != nil {
+		return err
+	}
+
 	if err := deployer.UnDeployDependencies(); err != nil {
 		return err
 	}
 
+	if err := deployer.RunPlugins(PLUGIN_COMMAND_UNDEPLOY); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// UnDeployDependencies removes the bindings of the dependencies, and the
+// entities of the dependencies cloned from GitHub. A dependency which is also a
+// dependency of a package left out of the undeployment is kept.
 func (deployer *ServiceDeployer) UnDeployDependencies() error {
 	for _, pack := range deployer.Deployment.Packages {
 		for depName, depRecord := range pack.Dependencies {
+			if deployer.UndeployedDependencies[depName] {
+				continue
+			}
+			if deployer.RetainedDependencies[depName] {
+				wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_DEPENDENCY_KEPT_X_name_X,
+					map[string]interface{}{wski18n.KEY_NAME: depName}))
+				continue
+			}
 			output := wski18n.T(wski18n.ID_MSG_DEPENDENCY_UNDEPLOYING_X_name_X,
 				map[string]interface{}{"name": depName})
 			whisk.Debug(whisk.DbgInfo, output)
 
 			if depRecord.IsBinding {
-				var err error
-				err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
-					_, err := deployer.Client.Packages.Delete(depName)
-					return err
-				})
-				if err != nil {
+				if err := deployer.deleteBindingInNamespace(pack.Package.Namespace, depName); err != nil {
 					return err
 				}
 			} else {
@@ -1047,17 +1743,9 @@ func (deployer *ServiceDeployer) UnDeployDependencies() error {
 				}
 
 				// delete binding pkg if the origin package name is different
-				if depServiceDeployer.RootPackageName != depName {
-					if _, _, ok := deployer.Client.Packages.Get(depName); ok == nil {
-						var err error
-						var response *http.Response
-						err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
-							response, err = deployer.Client.Packages.Delete(depName)
-							return err
-						})
-						if err != nil {
-							return createWhiskClientError(err.(*whisk.WskError), response, parsers.PACKAGE_BINDING, false)
-						}
+				if rootPackage := depServiceDeployer.dependencyRootPackage(depName); len(rootPackage) > 0 && rootPackage != depName {
+					if err := deployer.deleteBindingInNamespace(pack.Package.Namespace, depName); err != nil {
+						return err
 					}
 				}
 
@@ -1068,6 +1756,7 @@ func (deployer *ServiceDeployer) UnDeployDependencies() error {
 					return err
 				}
 			}
+			deployer.UndeployedDependencies[depName] = true
 			output = wski18n.T(wski18n.ID_MSG_DEPENDENCY_UNDEPLOYMENT_SUCCESS_X_name_X,
 				map[string]interface{}{"name": depName})
 			whisk.Debug(whisk.DbgInfo, output)
@@ -1077,9 +1766,37 @@ func (deployer *ServiceDeployer) UnDeployDependencies() error {
 	return nil
 }
 
+// bindings are created in the namespace of the package which depends on them
+func (deployer *ServiceDeployer) deleteBindingInNamespace(namespace string, name string) error {
+	return deployer.inNamespace(namespace, func() error {
+		return deployer.deleteBinding(name)
+	})
+}
+
+func (deployer *ServiceDeployer) deleteBinding(name string) error {
+	displayPreprocessingInfo(parsers.PACKAGE_BINDING, name, false)
+
+	// a binding which is already removed is skipped
+	if _, _, err := deployer.Client.Packages.Get(name); err == nil {
+		var response *http.Response
+		err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+			response, err = deployer.Client.Packages.Delete(name)
+			return err
+		})
+		if err != nil {
+			return createWhiskClientError(err.(*whisk.WskError), response, parsers.PACKAGE_BINDING, false)
+		}
+	}
+	displayPostprocessingInfo(parsers.PACKAGE_BINDING, name, false)
+	return nil
+}
+
 func (deployer *ServiceDeployer) UnDeployPackages(deployment *DeploymentProject) error {
 	for _, pack := range deployment.Packages {
-		err := deployer.deletePackage(pack.Package)
+		packa := pack.Package
+		err := deployer.inNamespace(packa.Namespace, func() error {
+			return deployer.deletePackage(packa)
+		})
 		if err != nil {
 			return err
 		}
@@ -1091,7 +1808,7 @@ func (deployer *ServiceDeployer) UnDeploySequences(deployment *DeploymentProject
 
 	for _, pack := range deployment.Packages {
 		for _, action := range pack.Sequences {
-			err := deployer.deleteAction(pack.Package.Name, action.Action)
+			err := deployer.deleteActionInNamespace(pack.Package, action.Action)
 			if err != nil {
 				return err
 			}
@@ -1105,7 +1822,7 @@ func (deployer *ServiceDeployer) UnDeployActions(deployment *DeploymentProject)
 
 	for _, pack := range deployment.Packages {
 		for _, action := range pack.Actions {
-			err := deployer.deleteAction(pack.Package.Name, action.Action)
+			err := deployer.deleteActionInNamespace(pack.Package, action.Action)
 			if err != nil {
 				return err
 			}
@@ -1118,16 +1835,15 @@ func (deployer *ServiceDeployer) UnDeployActions(deployment *DeploymentProject)
 func (deployer *ServiceDeployer) UnDeployTriggers(deployment *DeploymentProject) error {
 
 	for _, trigger := range deployment.Triggers {
-		if feedname, isFeed := utils.IsFeedAction(trigger); isFeed {
-			err := deployer.deleteFeedAction(trigger, feedname)
-			if err != nil {
-				return err
-			}
-		} else {
-			err := deployer.deleteTrigger(trigger)
-			if err != nil {
-				return err
+		trigger := trigger
+		err := deployer.inNamespace(trigger.Namespace, func() error {
+			if feedname, isFeed := deployer.deployedFeed(trigger); isFeed {
+				return deployer.deleteFeedAction(trigger, feedname)
 			}
+			return deployer.deleteTrigger(trigger)
+		})
+		if err != nil {
+			return err
 		}
 	}
 
@@ -1139,7 +1855,10 @@ func (deployer *ServiceDeployer) UnDeployTriggers(deployment *DeploymentProject)
 func (deployer *ServiceDeployer) UnDeployRules(deployment *DeploymentProject) error {
 
 	for _, rule := range deployment.Rules {
-		err := deployer.deleteRule(rule)
+		rule := rule
+		err := deployer.inNamespace(rule.Namespace, func() error {
+			return deployer.deleteRule(rule)
+		})
 		if err != nil {
 			return err
 		}
@@ -1154,7 +1873,7 @@ func (deployer *ServiceDeployer) deletePackage(packa *whisk.Package) error {
 	if _, _, ok := deployer.Client.Packages.Get(packa.Name); ok == nil {
 		var err error
 		var response *http.Response
-		err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+		err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
 			response, err = deployer.Client.Packages.Delete(packa.Name)
 			return err
 		})
@@ -1173,7 +1892,7 @@ func (deployer *ServiceDeployer) deleteTrigger(trigger *whisk.Trigger) error {
 
 	var err error
 	var response *http.Response
-	err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+	err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
 		_, response, err = deployer.Client.Triggers.Delete(trigger.Name)
 		return err
 	})
@@ -1186,18 +1905,14 @@ func (deployer *ServiceDeployer) deleteTrigger(trigger *whisk.Trigger) error {
 	return nil
 }
 
+// deleteFeedAction invokes the feed action with the DELETE lifecycle event, so
+// that the feed provider (e.g. alarms, cloudant, kafka) removes its registration
+// of the trigger, and then deletes the trigger
 func (deployer *ServiceDeployer) deleteFeedAction(trigger *whisk.Trigger, feedName string) error {
 
-	params := make(whisk.KeyValueArr, 0)
-	// TODO() define keys and operations as const
-	params = append(params, whisk.KeyValue{Key: "authKey", Value: deployer.ClientConfig.AuthToken})
-	params = append(params, whisk.KeyValue{Key: "lifecycleEvent", Value: "DELETE"})
-	params = append(params, whisk.KeyValue{Key: "triggerName", Value: "/" + deployer.Client.Namespace + "/" + trigger.Name})
+	displayPreprocessingInfo(parsers.TRIGGER_FEED, trigger.Name, false)
 
-	parameters := make(map[string]interface{})
-	for _, keyVal := range params {
-		parameters[keyVal.Key] = keyVal.Value
-	}
+	parameters := deployer.feedParameters(trigger, FEED_LIFECYCLE_EVENT_DELETE)
 
 	qName, err := utils.ParseQualifiedName(feedName, deployer.ClientConfig.Namespace)
 	if err != nil {
@@ -1207,7 +1922,7 @@ func (deployer *ServiceDeployer) deleteFeedAction(trigger *whisk.Trigger, feedNa
 	namespace := deployer.Client.Namespace
 	deployer.Client.Namespace = qName.Namespace
 	var response *http.Response
-	err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+	err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
 		_, response, err = deployer.Client.Actions.Invoke(qName.EntityName, parameters, true, false)
 		return err
 	})
@@ -1224,7 +1939,7 @@ func (deployer *ServiceDeployer) deleteFeedAction(trigger *whisk.Trigger, feedNa
 	} else {
 		trigger.Parameters = nil
 		var err error
-		err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+		err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
 			_, response, err = deployer.Client.Triggers.Delete(trigger.Name)
 			return err
 		})
@@ -1234,16 +1949,39 @@ func (deployer *ServiceDeployer) deleteFeedAction(trigger *whisk.Trigger, feedNa
 		}
 	}
 
+	displayPostprocessingInfo(parsers.TRIGGER_FEED, trigger.Name, false)
 	return nil
 }
 
+// feedParameters returns the parameters the feed action is invoked with for
+// the lifecycle event of the trigger
+func (deployer *ServiceDeployer) feedParameters(trigger *whisk.Trigger, lifecycleEvent string) map[string]interface{} {
+	return map[string]interface{}{
+		FEED_PARAM_AUTH_KEY:        deployer.ClientConfig.AuthToken,
+		FEED_PARAM_LIFECYCLE_EVENT: lifecycleEvent,
+		FEED_PARAM_TRIGGER_NAME:    "/" + deployer.Client.Namespace + "/" + trigger.Name,
+	}
+}
+
+// deployedFeed returns the feed of the trigger as deployed, which the feed
+// provider registered the trigger with, or the feed of the manifest if the
+// trigger cannot be retrieved
+func (deployer *ServiceDeployer) deployedFeed(trigger *whisk.Trigger) (string, bool) {
+	if deployed, _, err := deployer.Client.Triggers.Get(trigger.Name); err == nil && deployed != nil {
+		if feedName, isFeed := utils.IsFeedAction(deployed); isFeed {
+			return feedName, true
+		}
+	}
+	return utils.IsFeedAction(trigger)
+}
+
 func (deployer *ServiceDeployer) deleteRule(rule *whisk.Rule) error {
 
 	displayPreprocessingInfo(parsers.YAML_KEY_RULE, rule.Name, false)
 
 	var err error
 	var response *http.Response
-	err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+	err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
 		response, err = deployer.Client.Rules.Delete(rule.Name)
 		return err
 	})
@@ -1268,7 +2006,7 @@ func (deployer *ServiceDeployer) deleteAction(pkgname string, action *whisk.Acti
 	if _, _, ok := deployer.Client.Actions.Get(action.Name); ok == nil {
 		var err error
 		var response *http.Response
-		err = retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+		err = deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
 			response, err = deployer.Client.Actions.Delete(action.Name)
 			return err
 		})
@@ -1282,30 +2020,184 @@ func (deployer *ServiceDeployer) deleteAction(pkgname string, action *whisk.Acti
 	return nil
 }
 
+// retry invokes the callback until it succeeds, returns an error which is not
+// worth retrying or runs out of attempts. The wait between two attempts doubles
+// each time, starting from sleep and capped at DEFAULT_MAX_INTERVAL.
 func retry(attempts int, sleep time.Duration, callback func() error) error {
 	var err error
 	for i := 0; ; i++ {
 		err = callback()
-		if i >= (attempts - 1) {
-			break
-		}
-		if err != nil {
-			wskErr := err.(*whisk.WskError)
-			if wskErr.ExitCode == CONFLICT_CODE && strings.Contains(wskErr.Error(), CONFLICT_MESSAGE) {
-				time.Sleep(sleep)
-				// TODO() i18n
-				whisk.Debug(whisk.DbgError, "Retrying [%s] after error: %s\n", strconv.Itoa(i+1), err)
-			} else {
-				return err
-			}
-		} else {
+		if err == nil || i >= (attempts-1) || !isRetriableError(err) {
 			return err
 		}
+		time.Sleep(sleep)
+		whisk.Debug(whisk.DbgError, wski18n.T(wski18n.ID_MSG_RETRY_X_attempt_X_err_X,
+			map[string]interface{}{wski18n.KEY_ATTEMPT: i + 1, wski18n.KEY_ERR: err.Error()})+"\n")
+		sleep = sleep * 2
+		if sleep > DEFAULT_MAX_INTERVAL {
+			sleep = DEFAULT_MAX_INTERVAL
+		}
+	}
+}
+
+// isRetriableError returns true for errors which are expected to be transient:
+// concurrent modifications, throttling (429), server side failures (5xx) and
+// network timeouts.
+func isRetriableError(err error) bool {
+	wskErr, ok := err.(*whisk.WskError)
+	if !ok {
+		return false
+	}
+
+	if wskErr.ExitCode == CONFLICT_CODE && strings.Contains(wskErr.Error(), CONFLICT_MESSAGE) {
+		return true
+	}
+
+	if netErr, ok := wskErr.RootErr.(net.Error); ok && netErr.Timeout() {
+		return true
+	}
+
+	statusCode := wskErr.ExitCode + HTTP_STATUS_CODE_OFFSET
+	return statusCode == http.StatusTooManyRequests ||
+		(statusCode >= http.StatusInternalServerError && statusCode <= 599)
+}
+
+// LoadCheckpoint reads the checkpoint left behind by a previous deployment of
+// the same manifest which did not complete; entities recorded in it are skipped.
+func (deployer *ServiceDeployer) LoadCheckpoint() error {
+	checkpointPath := GetCheckpointFilePath(deployer.ProjectPath)
+	if !utils.FileExists(checkpointPath) {
+		wskprint.PrintOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_CHECKPOINT_NOT_FOUND_X_path_X,
+			map[string]interface{}{wski18n.KEY_PATH: checkpointPath}))
+		return nil
+	}
+
+	checkpoint, err := ReadCheckpoint(checkpointPath)
+	if err != nil {
+		return err
+	}
+
+	if checkpoint.ManifestPath != deployer.ManifestPath {
+		wskprint.PrintOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_CHECKPOINT_MANIFEST_MISMATCH_X_path_X_mpath_X,
+			map[string]interface{}{
+				wski18n.KEY_PATH:          checkpointPath,
+				wski18n.KEY_MANIFEST_PATH: checkpoint.ManifestPath}))
+		return nil
+	}
+
+	wskprint.PrintOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_CHECKPOINT_RESUMING_X_path_X,
+		map[string]interface{}{wski18n.KEY_PATH: checkpointPath}))
+	deployer.ResumeCheckpoint = checkpoint
+	return nil
+}
+
+func (deployer *ServiceDeployer) isCheckpointed(entity string, name string) bool {
+	if !deployer.ResumeCheckpoint.Contains(entity, name) {
+		return false
+	}
+	// carry the entity over so that the next checkpoint (if any) still includes it
+	deployer.Checkpoint.Add(entity, name)
+	deployer.Output.Debug(whisk.DbgInfo, wski18n.T(wski18n.ID_MSG_CHECKPOINT_SKIPPED_X_key_X_name_X,
+		map[string]interface{}{
+			wski18n.KEY_KEY:  entity,
+			wski18n.KEY_NAME: name}))
+	return true
+}
+
+// saveCheckpoint records the entities deployed so far, so that the deployment
+// can be completed later on using --resume
+func (deployer *ServiceDeployer) saveCheckpoint() {
+	if deployer.Checkpoint.IsEmpty() {
+		return
+	}
+	checkpointPath := GetCheckpointFilePath(deployer.ProjectPath)
+	deployer.Checkpoint.ManifestPath = deployer.ManifestPath
+	if err := deployer.Checkpoint.Write(checkpointPath); err != nil {
+		wskprint.PrintlnOpenWhiskWarning(err.Error())
+		return
+	}
+	wskprint.PrintOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_CHECKPOINT_SAVED_X_path_X,
+		map[string]interface{}{wski18n.KEY_PATH: checkpointPath}))
+}
+
+func (deployer *ServiceDeployer) clearCheckpoint() {
+	if err := RemoveCheckpoint(GetCheckpointFilePath(deployer.ProjectPath)); err != nil {
+		wskprint.PrintlnOpenWhiskWarning(err.Error())
 	}
-	return err
 }
 
 // from whisk go client
+// inNamespace runs the callback with the client targeting the given namespace,
+// an empty namespace keeps the namespace of the client configuration (from the
+// --namespace flag, the deployment or manifest file, or .wskprops)
+func (deployer *ServiceDeployer) inNamespace(namespace string, callback func() error) error {
+	if len(namespace) == 0 || deployer.Client == nil {
+		return callback()
+	}
+	current := deployer.Client.Namespace
+	deployer.Client.Namespace = namespace
+	defer func() { deployer.Client.Namespace = current }()
+	return callback()
+}
+
+// deployAction deploys an action or sequence, see deployEntity()
+func (deployer *ServiceDeployer) deployAction(entity string, pkg *whisk.Package, action *whisk.Action) error {
+	name := strings.Join([]string{pkg.Name, action.Name}, "/")
+	return deployer.deployEntity(entity, name, func(deployer *ServiceDeployer) error {
+		return deployer.createActionInNamespace(pkg, action)
+	})
+}
+
+// actions and sequences are deployed to the namespace of their package, the
+// post_deploy hooks of an action run once it is deployed
+func (deployer *ServiceDeployer) createActionInNamespace(pkg *whisk.Package, action *whisk.Action) error {
+	name := action.Name
+	hooks := deployer.actionHooks(pkg.Name, name)
+	err := deployer.inNamespace(pkg.Namespace, func() error {
+		if err := deployer.runHooks(hooks, parsers.YAML_KEY_PRE_DEPLOY); err != nil {
+			return err
+		}
+		if err := deployer.createAction(pkg.Name, action); err != nil {
+			return err
+		}
+		return deployer.runHooks(hooks, parsers.YAML_KEY_POST_DEPLOY)
+	})
+	deployer.notifyAction(NOTIFICATION_EVENT_DEPLOY, pkg, name, err)
+	return err
+}
+
+func (deployer *ServiceDeployer) deleteActionInNamespace(pkg *whisk.Package, action *whisk.Action) error {
+	name := action.Name
+	err := deployer.inNamespace(pkg.Namespace, func() error {
+		return deployer.deleteAction(pkg.Name, action)
+	})
+	deployer.notifyAction(NOTIFICATION_EVENT_UNDEPLOY, pkg, name, err)
+	return err
+}
+
+// notifyProject posts the outcome of the (un)deployment to the notification
+// hooks of the project and of its packages
+func (deployer *ServiceDeployer) notifyProject(event string, err error) {
+	notification := NewNotification(event, err)
+	notification.Project = deployer.ProjectName
+	if deployer.ClientConfig != nil {
+		notification.Namespace = deployer.ClientConfig.Namespace
+	}
+	deployer.Notifications.NotifyProject(notification)
+}
+
+func (deployer *ServiceDeployer) notifyAction(event string, pkg *whisk.Package, name string, err error) {
+	notification := NewNotification(event, err)
+	notification.Project = deployer.ProjectName
+	notification.Package = pkg.Name
+	notification.Action = name
+	notification.Namespace = pkg.Namespace
+	if len(notification.Namespace) == 0 && deployer.ClientConfig != nil {
+		notification.Namespace = deployer.ClientConfig.Namespace
+	}
+	deployer.Notifications.NotifyAction(notification)
+}
+
 func (deployer *ServiceDeployer) getQualifiedName(name string, namespace string) string {
 	if strings.HasPrefix(name, "/") {
 		return name
@@ -1319,6 +2211,15 @@ func (deployer *ServiceDeployer) getQualifiedName(name string, namespace string)
 	}
 }
 
+func (deployer *ServiceDeployer) qualifiedRuleAction(action string) string {
+	// if it contains a slash, then the action is qualified by a package name
+	if strings.Contains(action, "/") {
+		return deployer.getQualifiedName(action, deployer.ClientConfig.Namespace)
+	}
+	// if not, we assume the action is inside the root package
+	return deployer.getQualifiedName(strings.Join([]string{deployer.RootPackageName, action}, "/"), deployer.ClientConfig.Namespace)
+}
+
 func (deployer *ServiceDeployer) printDeploymentAssets(assets *DeploymentProject) {
 
 	// pretty ASCII OpenWhisk graphic
@@ -1333,9 +2234,9 @@ func (deployer *ServiceDeployer) printDeploymentAssets(assets *DeploymentProject
 		for _, p := range pack.Package.Parameters {
 			jsonValue, err := utils.PrettyJSON(p.Value)
 			if err != nil {
-				fmt.Printf("        - %s : %s\n", p.Key, wskderrors.STR_UNKNOWN_VALUE)
+				wskprint.PrintlnOpenWhiskOutput(fmt.Sprintf("        - %s : %s", p.Key, wskderrors.STR_UNKNOWN_VALUE))
 			} else {
-				fmt.Printf("        - %s : %v\n", p.Key, jsonValue)
+				wskprint.PrintlnOpenWhiskOutput(fmt.Sprintf("        - %s : %v", p.Key, jsonValue))
 			}
 		}
 
@@ -1347,6 +2248,11 @@ func (deployer *ServiceDeployer) printDeploymentAssets(assets *DeploymentProject
 			}
 		}
 
+		for _, binding := range sortedBindings(pack.Bindings) {
+			wskprint.PrintlnOpenWhiskOutput("  * binding: " + binding.Name)
+			wskprint.PrintlnOpenWhiskOutput("    package: /" + binding.Binding.Namespace + "/" + binding.Binding.Name)
+		}
+
 		wskprint.PrintlnOpenWhiskOutput("")
 
 		for _, action := range pack.Actions {
@@ -1357,28 +2263,28 @@ func (deployer *ServiceDeployer) printDeploymentAssets(assets *DeploymentProject
 				if reflect.TypeOf(p.Value).Kind() == reflect.Map {
 					if _, ok := p.Value.(map[interface{}]interface{}); ok {
 						var temp map[string]interface{} = utils.ConvertInterfaceMap(p.Value.(map[interface{}]interface{}))
-						fmt.Printf("        - %s : %v\n", p.Key, temp)
+						wskprint.PrintlnOpenWhiskOutput(fmt.Sprintf("        - %s : %v", p.Key, temp))
 					} else {
 						jsonValue, err := utils.PrettyJSON(p.Value)
 						if err != nil {
-							fmt.Printf("        - %s : %s\n", p.Key, wskderrors.STR_UNKNOWN_VALUE)
+							wskprint.PrintlnOpenWhiskOutput(fmt.Sprintf("        - %s : %s", p.Key, wskderrors.STR_UNKNOWN_VALUE))
 						} else {
-							fmt.Printf("        - %s : %v\n", p.Key, jsonValue)
+							wskprint.PrintlnOpenWhiskOutput(fmt.Sprintf("        - %s : %v", p.Key, jsonValue))
 						}
 					}
 				} else {
 					jsonValue, err := utils.PrettyJSON(p.Value)
 					if err != nil {
-						fmt.Printf("        - %s : %s\n", p.Key, wskderrors.STR_UNKNOWN_VALUE)
+						wskprint.PrintlnOpenWhiskOutput(fmt.Sprintf("        - %s : %s", p.Key, wskderrors.STR_UNKNOWN_VALUE))
 					} else {
-						fmt.Printf("        - %s : %v\n", p.Key, jsonValue)
+						wskprint.PrintlnOpenWhiskOutput(fmt.Sprintf("        - %s : %v", p.Key, jsonValue))
 					}
 				}
 
 			}
 			wskprint.PrintlnOpenWhiskOutput("    annotations: ")
 			for _, p := range action.Action.Annotations {
-				fmt.Printf("        - %s : %v\n", p.Key, p.Value)
+				wskprint.PrintlnOpenWhiskOutput(fmt.Sprintf("        - %s : %v", p.Key, p.Value))
 
 			}
 		}
@@ -1399,9 +2305,9 @@ func (deployer *ServiceDeployer) printDeploymentAssets(assets *DeploymentProject
 		for _, p := range trigger.Parameters {
 			jsonValue, err := utils.PrettyJSON(p.Value)
 			if err != nil {
-				fmt.Printf("        - %s : %s\n", p.Key, wskderrors.STR_UNKNOWN_VALUE)
+				wskprint.PrintlnOpenWhiskOutput(fmt.Sprintf("        - %s : %s", p.Key, wskderrors.STR_UNKNOWN_VALUE))
 			} else {
-				fmt.Printf("        - %s : %v\n", p.Key, jsonValue)
+				wskprint.PrintlnOpenWhiskOutput(fmt.Sprintf("        - %s : %v", p.Key, jsonValue))
 			}
 		}
 
@@ -1422,17 +2328,76 @@ func (deployer *ServiceDeployer) printDeploymentAssets(assets *DeploymentProject
 		wskprint.PrintlnOpenWhiskOutput("    - trigger: " + rule.Trigger.(string) + "\n    - action: " + rule.Action.(string))
 	}
 
+	if len(assets.Extensions) > 0 {
+		wskprint.PrintlnOpenWhiskOutput("\n Plugins")
+		for _, extension := range assets.Extensions {
+			wskprint.PrintlnOpenWhiskOutput("* " + extension.Key + " of " + extension.entity() + ": " + extension.Plugin)
+			for _, step := range extension.Steps {
+				wskprint.PrintlnOpenWhiskOutput("    - " + step)
+			}
+		}
+	}
+
 	wskprint.PrintlnOpenWhiskOutput("")
 
 }
 
-func (deployer *ServiceDeployer) getDependentDeployer(depName string, depRecord utils.DependencyRecord) (*ServiceDeployer, error) {
-	depServiceDeployer := NewServiceDeployer()
+// dependencyProjectPath returns the path a dependency is cloned to from GitHub
+func dependencyProjectPath(depName string, depRecord utils.DependencyRecord) string {
 	projectPath := path.Join(depRecord.ProjectPath, depName+"-"+depRecord.Version)
 	if len(depRecord.SubFolder) > 0 {
 		projectPath = path.Join(projectPath, depRecord.SubFolder)
 	}
+	return projectPath
+}
+
+// dependencyRootPackage returns the package of a dependency its binding
+// refers to: the package of a manifest with a single "package", otherwise the
+// package named as the dependency or the only package of the manifest. It is
+// empty if there are several packages, none named as the dependency.
+func (deployer *ServiceDeployer) dependencyRootPackage(depName string) string {
+	if len(deployer.RootPackageName) > 0 {
+		return deployer.RootPackageName
+	}
+	if _, exists := deployer.Deployment.Packages[depName]; exists {
+		return depName
+	}
+	if len(deployer.Deployment.Packages) == 1 {
+		for name := range deployer.Deployment.Packages {
+			return name
+		}
+	}
+	return ""
+}
+
+func (deployer *ServiceDeployer) getDependentDeployer(depName string, depRecord utils.DependencyRecord) (*ServiceDeployer, error) {
+	chain := append(append([]string{}, deployer.DependencyChain...), depName)
+	for _, name := range deployer.DependencyChain {
+		if name == depName {
+			return nil, wskderrors.NewYAMLFileFormatError(deployer.ManifestPath,
+				wski18n.T(wski18n.ID_ERR_DEPENDENCY_CYCLE_X_name_X_chain_X,
+					map[string]interface{}{wski18n.KEY_NAME: depName, wski18n.KEY_CHAIN: strings.Join(chain, " -> ")}))
+		}
+	}
+	if len(chain) > MAX_DEPENDENCY_DEPTH {
+		return nil, wskderrors.NewYAMLFileFormatError(deployer.ManifestPath,
+			wski18n.T(wski18n.ID_ERR_DEPENDENCY_DEPTH_X_name_X_chain_X_max_X,
+				map[string]interface{}{wski18n.KEY_NAME: depName, wski18n.KEY_CHAIN: strings.Join(chain, " -> "),
+					wski18n.KEY_MAX: MAX_DEPENDENCY_DEPTH}))
+	}
+
+	depServiceDeployer := NewServiceDeployer()
+	projectPath := dependencyProjectPath(depName, depRecord)
 	manifestPath := utils.GetManifestFilePath(projectPath)
+	if len(depRecord.ManifestFile) > 0 {
+		manifestPath = path.Join(projectPath, depRecord.ManifestFile)
+	}
+	if !utils.FileExists(manifestPath) {
+		return nil, wskderrors.NewErrorManifestFileNotFound(projectPath,
+			wski18n.T(wski18n.ID_ERR_DEPENDENCY_MANIFEST_NOT_FOUND_X_name_X_location_X_path_X,
+				map[string]interface{}{wski18n.KEY_NAME: depName, wski18n.KEY_LOCATION: depRecord.Location,
+					wski18n.KEY_PATH: projectPath}))
+	}
 	deploymentPath := utils.GetDeploymentFilePath(projectPath)
 	depServiceDeployer.ProjectPath = projectPath
 	depServiceDeployer.ManifestPath = manifestPath
@@ -1441,16 +2406,31 @@ func (deployer *ServiceDeployer) getDependentDeployer(depName string, depRecord
 
 	depServiceDeployer.Client = deployer.Client
 	depServiceDeployer.ClientConfig = deployer.ClientConfig
+	depServiceDeployer.Flags = deployer.Flags
 
 	depServiceDeployer.DependencyMaster = deployer.DependencyMaster
 
 	// share the master dependency list
 	depServiceDeployer.DependencyMaster = deployer.DependencyMaster
+	depServiceDeployer.UndeployedDependencies = deployer.UndeployedDependencies
+	depServiceDeployer.RetainedDependencies = deployer.RetainedDependencies
+	depServiceDeployer.IsDependency = true
+	depServiceDeployer.DependencyChain = chain
+
+	// dependencies are deployed as part of the same deployment, share its checkpoint
+	depServiceDeployer.Checkpoint = deployer.Checkpoint
+	depServiceDeployer.ResumeCheckpoint = deployer.ResumeCheckpoint
+	depServiceDeployer.DeployedOutputs = deployer.DeployedOutputs
+	depServiceDeployer.Results = deployer.Results
 
 	return depServiceDeployer, nil
 }
 
 func displayPreprocessingInfo(entity string, name string, onDeploy bool){
+	whisk.Debug(whisk.DbgInfo, preprocessingInfo(entity, name, onDeploy))
+}
+
+func preprocessingInfo(entity string, name string, onDeploy bool) string {
 
 	var msgKey string
 	if onDeploy{
@@ -1458,14 +2438,17 @@ func displayPreprocessingInfo(entity string, name string, onDeploy bool){
 	} else {
 		msgKey = wski18n.ID_MSG_ENTITY_UNDEPLOYING_X_key_X_name_X
 	}
-	msg := wski18n.T(msgKey,
+	return wski18n.T(msgKey,
 		map[string]interface{}{
 			"key": entity,
 			"name": name})
-	whisk.Debug(whisk.DbgInfo, msg)
 }
 
 func displayPostprocessingInfo(entity string, name string, onDeploy bool){
+	whisk.Debug(whisk.DbgInfo, postprocessingInfo(entity, name, onDeploy))
+}
+
+func postprocessingInfo(entity string, name string, onDeploy bool) string {
 
 	var msgKey string
 	if onDeploy{
@@ -1473,11 +2456,10 @@ func displayPostprocessingInfo(entity string, name string, onDeploy bool){
 	} else {
 		msgKey = wski18n.ID_MSG_ENTITY_UNDEPLOYED_SUCCESS_X_key_X_name_X
 	}
-	errString := wski18n.T(msgKey,
+	return wski18n.T(msgKey,
 		map[string]interface{}{
 			"key": entity,
 			"name": name})
-	whisk.Debug(whisk.DbgInfo, errString)
 }
 
 func createWhiskClientError(err *whisk.WskError, response *http.Response, entity string, onCreate bool)(*wskderrors.WhiskClientError){
@@ -0,0 +1,208 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceDeployer_inNamespace(t *testing.T) {
+	config := &whisk.Config{Namespace: "guest"}
+	deployer := NewServiceDeployer()
+	deployer.ClientConfig = config
+	deployer.Client = &whisk.Client{Config: config}
+
+	// entities without a namespace use the namespace of the client configuration
+	deployer.inNamespace("", func() error {
+		assert.Equal(t, "guest", deployer.Client.Namespace)
+		return nil
+	})
+
+	deployer.inNamespace("tenant1", func() error {
+		assert.Equal(t, "tenant1", deployer.Client.Namespace)
+		assert.Equal(t, "/tenant1/trigger", deployer.getQualifiedName("trigger", deployer.ClientConfig.Namespace))
+		return nil
+	})
+	assert.Equal(t, "guest", deployer.Client.Namespace)
+
+	// the namespace is restored when the callback fails
+	err := deployer.inNamespace("tenant2", func() error {
+		return errors.New("failed")
+	})
+	assert.NotNil(t, err)
+	assert.Equal(t, "guest", deployer.Client.Namespace)
+}
+
+func TestServiceDeployer_validateSequenceComponents(t *testing.T) {
+	deployer := NewServiceDeployer()
+	deployer.ClientConfig = &whisk.Config{Namespace: "guest"}
+
+	pack := NewDeploymentPackage()
+	pack.Package = &whisk.Package{Name: "hello"}
+	pack.Actions["hello"] = utils.ActionRecord{Action: &whisk.Action{Name: "hello"}}
+	pack.Sequences["hello_sequence"] = utils.ActionRecord{Action: &whisk.Action{Name: "hello_sequence",
+		Exec: &whisk.Exec{Kind: "sequence", Components: []string{"/guest/hello/hello", "/tenant1/other/bye"}}}}
+	other := NewDeploymentPackage()
+	other.Package = &whisk.Package{Name: "other", Namespace: "tenant1"}
+	other.Actions["bye"] = utils.ActionRecord{Action: &whisk.Action{Name: "bye"}}
+	deployer.Deployment.Packages["hello"] = pack
+	deployer.Deployment.Packages["other"] = other
+	assert.Nil(t, deployer.validateSequenceComponents())
+
+	// without a client, actions which are not declared cannot be found
+	pack.Sequences["hello_sequence"].Action.Exec.Components = []string{"/guest/hello/hello", "/guest/other/bye"}
+	assert.NotNil(t, deployer.validateSequenceComponents())
+}
+
+func TestServiceDeployer_ProjectTriggers(t *testing.T) {
+	deployer := NewServiceDeployer()
+	deployer.ClientConfig = &whisk.Config{Namespace: "guest"}
+	deployer.ManifestPath = "../tests/dat/manifest_validate_package_namespace.yaml"
+
+	triggers, err := deployer.ProjectTriggers()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(triggers))
+	assert.Equal(t, "tenant1", triggers["tenant1_trigger"].Namespace)
+	assert.Equal(t, "/tenant2/other_trigger", deployer.getQualifiedName("other_trigger", triggers["other_trigger"].Namespace))
+
+	// only the triggers of the project can be fired
+	_, _, err = deployer.FireTrigger("unknown_trigger", nil)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "default_trigger, other_trigger, tenant1_trigger")
+}
+
+func TestServiceDeployer_feedParameters(t *testing.T) {
+	config := &whisk.Config{Namespace: "guest", AuthToken: "user:pass"}
+	deployer := NewServiceDeployer()
+	deployer.ClientConfig = config
+	deployer.Client = &whisk.Client{Config: config}
+
+	trigger := &whisk.Trigger{Name: "everyhour",
+		Annotations: whisk.KeyValueArr{{Key: "feed", Value: "/whisk.system/alarms/alarm"}}}
+	feedName, isFeed := utils.IsFeedAction(trigger)
+	assert.True(t, isFeed)
+	assert.Equal(t, "/whisk.system/alarms/alarm", feedName)
+
+	// the feed provider is told which trigger to unregister
+	params := deployer.feedParameters(trigger, FEED_LIFECYCLE_EVENT_DELETE)
+	assert.Equal(t, "DELETE", params[FEED_PARAM_LIFECYCLE_EVENT])
+	assert.Equal(t, "user:pass", params[FEED_PARAM_AUTH_KEY])
+	assert.Equal(t, "/guest/everyhour", params[FEED_PARAM_TRIGGER_NAME])
+
+	// an invalid feed annotation is not a feed
+	trigger.Annotations = whisk.KeyValueArr{{Key: "feed", Value: true}}
+	_, isFeed = utils.IsFeedAction(trigger)
+	assert.False(t, isFeed)
+}
+
+func TestServiceDeployer_resolveSwaggerBackends(t *testing.T) {
+	deployer := NewServiceDeployer()
+	deployer.ClientConfig = &whisk.Config{Host: "openwhisk.example.com", Namespace: "guest"}
+
+	swagger := `{"info":{"title":"book-club"},"basePath":"/club","paths":{"/books":{` +
+		`"get":{"x-openwhisk":{"namespace":"_","package":"book-club","action":"getBooks"}},` +
+		`"post":{"x-openwhisk":{"namespace":"other","package":"library","action":"addBook","url":"https://example.com/addBook"}}}}}`
+	api := &whisk.ApiCreateRequest{ApiDoc: &whisk.Api{ApiName: "book-club", Swagger: swagger}}
+	assert.Nil(t, deployer.resolveSwaggerBackends(api))
+	assert.Equal(t, "guest", api.ApiDoc.Namespace)
+	assert.Contains(t, api.ApiDoc.Swagger, `"url":"https://openwhisk.example.com/api/v1/web/guest/book-club/getBooks.http"`)
+	assert.Contains(t, api.ApiDoc.Swagger, `"url":"https://example.com/addBook"`, "actions of other namespaces are left unchanged")
+}
+
+func TestRemovedAnnotations(t *testing.T) {
+	deployed := whisk.KeyValueArr{
+		{Key: "exec", Value: "nodejs:6"},
+		{Key: "owner", Value: "ops"},
+		{Key: "description", Value: "hello"},
+		{Key: "web-export", Value: true},
+	}
+	annotations := whisk.KeyValueArr{{Key: "web-export", Value: true}}
+
+	assert.Equal(t, []string{}, removedAnnotations(deployed, annotations, nil, false))
+	assert.Equal(t, []string{"owner"}, removedAnnotations(deployed, annotations, []string{"owner", "missing"}, false))
+	// the annotations added by the platform are kept
+	assert.Equal(t, []string{"description", "owner"}, removedAnnotations(deployed, annotations, nil, true))
+	assert.Equal(t, []string{}, removedAnnotations(deployed, annotations, []string{"web-export"}, false), "set again")
+}
+
+func TestServiceDeployer_UnDeployDependencies_Skipped(t *testing.T) {
+	deployer := NewServiceDeployer()
+	pack := NewDeploymentPackage()
+	pack.Package = &whisk.Package{Name: "billing"}
+	pack.Dependencies["utils"] = utils.DependencyRecord{Location: "/whisk.system/utils", IsBinding: true}
+	pack.Dependencies["common"] = utils.DependencyRecord{Location: "https://github.com/openwhisk-test/common"}
+	deployer.Deployment.Packages["billing"] = pack
+	deployer.UndeployedDependencies["utils"] = true
+	deployer.RetainedDependencies["common"] = true
+
+	// the deployer has no client, the dependencies are not undeployed
+	assert.Nil(t, deployer.UnDeployDependencies())
+}
+
+func TestServiceDeployer_getDependentDeployer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wskdeploy-dependencies")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	projectPath := filepath.Join(dir, "common-master", "path", "to", "project")
+	assert.Nil(t, os.MkdirAll(projectPath, os.ModePerm))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(projectPath, "common.yaml"), []byte("packages:\n  common:\n"), 0644))
+
+	deployer := NewServiceDeployer()
+	record := utils.NewDependencyRecord(dir, "billing", "https://github.com/openwhisk-test/common/path/to/project/common.yaml", "master", nil, nil, false)
+	dependency, err := deployer.getDependentDeployer("common", record)
+	if assert.Nil(t, err) {
+		assert.Equal(t, filepath.Join(projectPath, "common.yaml"), dependency.ManifestPath)
+		assert.Equal(t, []string{"common"}, dependency.DependencyChain)
+	}
+
+	record = utils.NewDependencyRecord(dir, "billing", "https://github.com/openwhisk-test/common/path/to/project", "master", nil, nil, false)
+	_, err = deployer.getDependentDeployer("common", record)
+	assert.NotNil(t, err, "the project has no manifest.yaml")
+
+	deployer.DependencyChain = []string{"common", "logging"}
+	_, err = deployer.getDependentDeployer("common", record)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "common -> logging -> common")
+	}
+	deployer.DependencyChain = []string{"a", "b", "c", "d", "e"}
+	_, err = deployer.getDependentDeployer("common", record)
+	assert.NotNil(t, err, "deeper than MAX_DEPENDENCY_DEPTH")
+}
+
+func TestServiceDeployer_dependencyRootPackage(t *testing.T) {
+	deployer := NewServiceDeployer()
+	deployer.Deployment.Packages["utils"] = NewDeploymentPackage()
+	assert.Equal(t, "utils", deployer.dependencyRootPackage("common"), "the only package")
+
+	deployer.Deployment.Packages["common"] = NewDeploymentPackage()
+	assert.Equal(t, "common", deployer.dependencyRootPackage("common"))
+	assert.Equal(t, "", deployer.dependencyRootPackage("logging"), "several packages")
+
+	deployer.RootPackageName = "utils"
+	assert.Equal(t, "utils", deployer.dependencyRootPackage("common"))
+}
@@ -0,0 +1,245 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+	"gopkg.in/yaml.v2"
+)
+
+// name of the file (in the METRICS_DIR_NAME directory of the project) which
+// records the entities of the last successful deployment, so that they can be
+// undeployed with "wskdeploy undeploy --snapshot" once the manifest changed
+const SNAPSHOT_FILE_NAME = "last-deployed.yaml"
+
+const SNAPSHOT_VERSION = 1
+
+// DeploymentSnapshot records the entities deployed by their names as sent to
+// OpenWhisk, i.e. once the variables are resolved and --deploy-as applied
+type DeploymentSnapshot struct {
+	Version   int                        `yaml:"snapshot_version"`
+	Time      time.Time                  `yaml:"time"`
+	Project   string                     `yaml:"project,omitempty"`
+	Manifest  string                     `yaml:"manifest"`
+	ApiHost   string                     `yaml:"apihost,omitempty"`
+	Namespace string                     `yaml:"namespace,omitempty"`
+	Packages  map[string]SnapshotPackage `yaml:"packages,omitempty"`
+	Triggers  map[string]SnapshotTrigger `yaml:"triggers,omitempty"`
+	Rules     map[string]SnapshotEntity  `yaml:"rules,omitempty"`
+}
+
+type SnapshotPackage struct {
+	Namespace string   `yaml:"namespace,omitempty"`
+	Actions   []string `yaml:"actions,omitempty"`
+	Sequences []string `yaml:"sequences,omitempty"`
+	// bindings of the package to deployed packages, by name
+	Bindings map[string]SnapshotEntity `yaml:"bindings,omitempty"`
+	// dependencies of the package, by the name they are bound with
+	Dependencies map[string]SnapshotDependency `yaml:"dependencies,omitempty"`
+}
+
+// SnapshotEntity is an entity recorded by the namespace it was deployed to
+type SnapshotEntity struct {
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+type SnapshotTrigger struct {
+	Namespace string `yaml:"namespace,omitempty"`
+	Feed      string `yaml:"feed,omitempty"`
+}
+
+type SnapshotDependency struct {
+	Location string `yaml:"location"`
+	Binding  bool   `yaml:"binding,omitempty"`
+}
+
+func GetSnapshotFilePath(projectPath string) string {
+	return path.Join(projectPath, METRICS_DIR_NAME, SNAPSHOT_FILE_NAME)
+}
+
+// NewDeploymentSnapshot records the entities of the deployment plan
+func (deployer *ServiceDeployer) NewDeploymentSnapshot(now time.Time) *DeploymentSnapshot {
+	snapshot := &DeploymentSnapshot{
+		Version:  SNAPSHOT_VERSION,
+		Time:     now,
+		Project:  deployer.ProjectName,
+		Manifest: deployer.ManifestPath,
+		Packages: make(map[string]SnapshotPackage),
+		Triggers: make(map[string]SnapshotTrigger),
+		Rules:    make(map[string]SnapshotEntity),
+	}
+	if deployer.ClientConfig != nil {
+		snapshot.ApiHost, snapshot.Namespace = deployer.ClientConfig.Host, deployer.ClientConfig.Namespace
+	}
+	names := func(records map[string]utils.ActionRecord) []string {
+		result := make([]string, 0, len(records))
+		for _, record := range records {
+			result = append(result, record.Action.Name)
+		}
+		sort.Strings(result)
+		return result
+	}
+
+	for _, pack := range deployer.Deployment.Packages {
+		snapshotPackage := SnapshotPackage{
+			Namespace:    pack.Package.Namespace,
+			Actions:      names(pack.Actions),
+			Sequences:    names(pack.Sequences),
+			Bindings:     make(map[string]SnapshotEntity),
+			Dependencies: make(map[string]SnapshotDependency),
+		}
+		for _, binding := range pack.Bindings {
+			snapshotPackage.Bindings[binding.Name] = SnapshotEntity{Namespace: binding.Namespace}
+		}
+		for name, dependency := range pack.Dependencies {
+			snapshotPackage.Dependencies[name] = SnapshotDependency{Location: dependency.Location, Binding: dependency.IsBinding}
+		}
+		snapshot.Packages[pack.Package.Name] = snapshotPackage
+	}
+	for _, trigger := range deployer.Deployment.Triggers {
+		feed, _ := utils.IsFeedAction(trigger)
+		snapshot.Triggers[trigger.Name] = SnapshotTrigger{Namespace: trigger.Namespace, Feed: feed}
+	}
+	for _, rule := range deployer.Deployment.Rules {
+		snapshot.Rules[rule.Name] = SnapshotEntity{Namespace: rule.Namespace}
+	}
+	return snapshot
+}
+
+// Write writes the snapshot, the directory of the file is created if needed
+func (snapshot *DeploymentSnapshot) Write(filePath string) error {
+	content, err := yaml.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filePath, content, 0644)
+}
+
+func ReadSnapshot(filePath string) (*DeploymentSnapshot, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	snapshot := &DeploymentSnapshot{}
+	if err := yaml.Unmarshal(content, snapshot); err != nil {
+		return nil, wskderrors.NewYAMLFileFormatError(filePath, err.Error())
+	}
+	if snapshot.Version != SNAPSHOT_VERSION {
+		return nil, wskderrors.NewYAMLFileFormatError(filePath, wski18n.T(wski18n.ID_ERR_SNAPSHOT_VERSION_X_version_X,
+			map[string]interface{}{wski18n.KEY_VERSION: snapshot.Version}))
+	}
+	return snapshot, nil
+}
+
+// UnDeploymentPlan returns the plan which undeploys the entities of the
+// snapshot. The dependencies cloned from GitHub are not recorded by the
+// snapshot, they are returned to be kept, see ServiceDeployer.RetainedDependencies.
+func (snapshot *DeploymentSnapshot) UnDeploymentPlan() (*DeploymentProject, map[string]bool) {
+	plan := NewDeploymentProject()
+	retained := make(map[string]bool)
+	records := func(names []string, packageName string) map[string]utils.ActionRecord {
+		result := make(map[string]utils.ActionRecord, len(names))
+		for _, name := range names {
+			result[name] = utils.ActionRecord{Action: &whisk.Action{Name: name}, Packagename: packageName}
+		}
+		return result
+	}
+
+	for name, snapshotPackage := range snapshot.Packages {
+		pack := NewDeploymentPackage()
+		pack.Package = &whisk.Package{Name: name, Namespace: snapshotPackage.Namespace}
+		pack.Actions = records(snapshotPackage.Actions, name)
+		pack.Sequences = records(snapshotPackage.Sequences, name)
+		for bindingName, binding := range snapshotPackage.Bindings {
+			pack.Bindings[bindingName] = &whisk.BindingPackage{Name: bindingName, Namespace: binding.Namespace}
+		}
+		for depName, dependency := range snapshotPackage.Dependencies {
+			pack.Dependencies[depName] = utils.DependencyRecord{Location: dependency.Location, IsBinding: dependency.Binding}
+			if !dependency.Binding {
+				retained[depName] = true
+			}
+		}
+		plan.Packages[name] = pack
+	}
+	for name, snapshotTrigger := range snapshot.Triggers {
+		trigger := &whisk.Trigger{Name: name, Namespace: snapshotTrigger.Namespace}
+		if len(snapshotTrigger.Feed) > 0 {
+			trigger.Annotations = whisk.KeyValueArr{{Key: "feed", Value: snapshotTrigger.Feed}}
+		}
+		plan.Triggers[name] = trigger
+	}
+	for name, rule := range snapshot.Rules {
+		plan.Rules[name] = &whisk.Rule{Name: name, Namespace: rule.Namespace}
+	}
+	return plan, retained
+}
+
+// ConstructSnapshotUnDeploymentPlan returns the plan which undeploys the
+// entities of the snapshot, the manifest is not read. The snapshot must have
+// been written for the API host and namespace the deployer is configured for.
+func (deployer *ServiceDeployer) ConstructSnapshotUnDeploymentPlan(snapshotPath string) (*DeploymentProject, error) {
+	snapshot, err := ReadSnapshot(snapshotPath)
+	if err != nil {
+		return deployer.Deployment, err
+	}
+	if deployer.ClientConfig != nil {
+		checks := []struct{ key, expected, value string }{
+			{"apihost", snapshot.ApiHost, deployer.ClientConfig.Host},
+			{"namespace", snapshot.Namespace, deployer.ClientConfig.Namespace},
+		}
+		for _, check := range checks {
+			if len(check.expected) > 0 && check.expected != check.value {
+				return deployer.Deployment, wskderrors.NewWhiskClientInvalidConfigError(wski18n.T(wski18n.ID_ERR_SNAPSHOT_TARGET_X_path_X_key_X_value_X_expected_X,
+					map[string]interface{}{wski18n.KEY_PATH: snapshotPath, wski18n.KEY_KEY: check.key,
+						wski18n.KEY_VALUE: check.value, wski18n.KEY_EXPECTED: check.expected}))
+			}
+		}
+	}
+	deployer.ProjectName = snapshot.Project
+	deployer.Deployment, deployer.RetainedDependencies = snapshot.UnDeploymentPlan()
+	return deployer.Deployment, nil
+}
+
+// recordSnapshot writes the snapshot of the deployment, which succeeded, a
+// snapshot which cannot be written is a warning
+func (deployer *ServiceDeployer) recordSnapshot() {
+	if deployer.IsDependency {
+		return
+	}
+	snapshotPath := GetSnapshotFilePath(deployer.ProjectPath)
+	if err := deployer.NewDeploymentSnapshot(time.Now().UTC()).Write(snapshotPath); err != nil {
+		wskprint.PrintlnOpenWhiskWarning(err.Error())
+		return
+	}
+	deployer.Output.Debug(whisk.DbgInfo, wski18n.T(wski18n.ID_MSG_SNAPSHOT_RECORDED_X_path_X,
+		map[string]interface{}{wski18n.KEY_PATH: snapshotPath}))
+}
@@ -0,0 +1,97 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentSnapshot_UnDeploymentPlan(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wskdeploy-snapshot")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	deployer := newHistoryDeployer("v1")
+	deployer.ProjectName = "helloworld"
+	deployer.ProjectPath = dir
+	deployer.ClientConfig = &whisk.Config{Host: "openwhisk.example.com", Namespace: "guest"}
+	pack := deployer.Deployment.Packages["hello"]
+	pack.Sequences["greetings"] = utils.ActionRecord{Action: &whisk.Action{Name: "greetings"}}
+	pack.Bindings["db"] = &whisk.BindingPackage{Name: "db", Namespace: "guest"}
+	pack.Dependencies["utils"] = utils.DependencyRecord{Location: "github.com/apache/incubator-openwhisk-test/packages/utils"}
+	pack.Dependencies["alarms"] = utils.DependencyRecord{Location: "/whisk.system/alarms", IsBinding: true}
+	deployer.Deployment.Triggers["everyminute"].Annotations = whisk.KeyValueArr{{Key: "feed", Value: "/whisk.system/alarms/alarm"}}
+	deployer.Deployment.Rules["minutely"] = &whisk.Rule{Name: "minutely", Trigger: "everyminute", Action: "hello/world"}
+
+	deployer.recordSnapshot()
+	snapshotPath := GetSnapshotFilePath(dir)
+	assert.Equal(t, filepath.Join(dir, ".wskdeploy", "last-deployed.yaml"), snapshotPath)
+
+	undeployer := NewServiceDeployer()
+	undeployer.ClientConfig = deployer.ClientConfig
+	plan, err := undeployer.ConstructSnapshotUnDeploymentPlan(snapshotPath)
+	assert.Nil(t, err)
+	assert.Equal(t, "helloworld", undeployer.ProjectName)
+	assert.Equal(t, map[string][]string{
+		"package":  {"hello"},
+		"action":   {"hello/world"},
+		"sequence": {"hello/greetings"},
+		"trigger":  {"everyminute"},
+		"rule":     {"minutely"},
+	}, plan.Entities())
+	assert.Equal(t, "db", plan.Packages["hello"].Bindings["db"].Name)
+	feed, isFeed := utils.IsFeedAction(plan.Triggers["everyminute"])
+	assert.True(t, isFeed)
+	assert.Equal(t, "/whisk.system/alarms/alarm", feed)
+	// the dependencies cloned from GitHub are kept, their bindings are removed
+	assert.Equal(t, map[string]bool{"utils": true}, undeployer.RetainedDependencies)
+
+	// the snapshot is undeployed from where it was deployed
+	undeployer = NewServiceDeployer()
+	undeployer.ClientConfig = &whisk.Config{Host: "openwhisk.example.com", Namespace: "production"}
+	_, err = undeployer.ConstructSnapshotUnDeploymentPlan(snapshotPath)
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), "production")
+	}
+}
+
+func TestReadSnapshot(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wskdeploy-snapshot")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	snapshotPath := filepath.Join(dir, "last-deployed.yaml")
+
+	_, err = ReadSnapshot(snapshotPath)
+	assert.IsType(t, &wskderrors.FileReadError{}, err)
+
+	snapshot := &DeploymentSnapshot{Version: SNAPSHOT_VERSION + 1, Time: time.Now().UTC()}
+	assert.Nil(t, snapshot.Write(snapshotPath))
+	_, err = ReadSnapshot(snapshotPath)
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err, "unknown version")
+}
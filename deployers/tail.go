@@ -0,0 +1,148 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// time between two polls of the activations with --tail
+const DEFAULT_TAIL_INTERVAL = 2 * time.Second
+
+// number of activations, and of actions of managed projects, read at once
+const TAIL_LIST_LIMIT = 200
+
+// annotation of an activation with the path of its action, e.g.
+// guest/hello/greeting
+const ACTIVATION_PATH_ANNOT = "path"
+
+// TailActivations prints the logs and results of the activations of the
+// actions and sequences of the project, see tailedActions(), as they complete,
+// until ctx is done. Errors of the activations API are printed as warnings and
+// the next poll tries again.
+func (deployer *ServiceDeployer) TailActivations(ctx context.Context, interval time.Duration) error {
+	actions, err := deployer.tailedActions()
+	if err != nil {
+		return err
+	}
+	wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_TAIL_STARTED_X_count_X,
+		map[string]interface{}{wski18n.KEY_COUNT: len(actions)}))
+
+	since := time.Now().UnixNano() / int64(time.Millisecond)
+	printed := make(map[string]bool)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		activations, _, err := deployer.Client.Activations.List(&whisk.ActivationListOptions{
+			Since: since, Docs: true, Limit: TAIL_LIST_LIMIT})
+		if err != nil {
+			wskprint.PrintlnOpenWhiskWarning(err.Error())
+		}
+		// activations are listed from the most recent one
+		sort.Slice(activations, func(i, j int) bool { return activations[i].Start < activations[j].Start })
+		for _, activation := range activations {
+			if printed[activation.ActivationID] || !actions[activationAction(activation)] {
+				continue
+			}
+			printed[activation.ActivationID] = true
+			wskprint.PrintlnOpenWhiskOutput(formatActivation(activation))
+			// activations of the same millisecond are listed again, and skipped
+			if activation.Start > since {
+				since = activation.Start
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// tailedActions returns the actions and sequences of the project as
+// package/action, along with the actions whose managed annotation names the
+// project with --managed, e.g. the ones of packages left out with --packages
+func (deployer *ServiceDeployer) tailedActions() (map[string]bool, error) {
+	actions := make(map[string]bool)
+	for packageName, pack := range deployer.Deployment.Packages {
+		for _, records := range []map[string]utils.ActionRecord{pack.Actions, pack.Sequences} {
+			for name := range records {
+				actions[path.Join(packageName, name)] = true
+			}
+		}
+	}
+	if !deployer.Flags.Managed || deployer.Client == nil {
+		return actions, nil
+	}
+	deployed, _, err := deployer.Client.Actions.List("", &whisk.ActionListOptions{Limit: TAIL_LIST_LIMIT})
+	if err != nil {
+		return nil, err
+	}
+	for _, action := range deployed {
+		managed, ok := action.Annotations.GetValue(utils.MANAGED).(map[string]interface{})
+		if ok && managed[utils.OW_PROJECT_NAME] == deployer.ProjectName {
+			actions[withoutNamespace(path.Join(action.Namespace, action.Name))] = true
+		}
+	}
+	return actions, nil
+}
+
+// activationAction returns the action of an activation as package/action
+func activationAction(activation whisk.Activation) string {
+	if actionPath, ok := activation.Annotations.GetValue(ACTIVATION_PATH_ANNOT).(string); ok {
+		return withoutNamespace(actionPath)
+	}
+	return withoutNamespace(path.Join(activation.Namespace, activation.Name))
+}
+
+// withoutNamespace returns namespace/package/action as package/action
+func withoutNamespace(qualifiedName string) string {
+	parts := strings.SplitN(strings.TrimPrefix(qualifiedName, "/"), "/", 2)
+	return parts[len(parts)-1]
+}
+
+// formatActivation returns a line with the action, ID, status and duration of
+// an activation, followed by its logs and result, indented
+func formatActivation(activation whisk.Activation) string {
+	lines := []string{wski18n.T(wski18n.ID_MSG_TAIL_ACTIVATION_X_name_X_id_X_status_X_duration_X,
+		map[string]interface{}{
+			wski18n.KEY_NAME:     activationAction(activation),
+			wski18n.KEY_ID:       activation.ActivationID,
+			wski18n.KEY_STATUS:   activation.Response.Status,
+			wski18n.KEY_DURATION: activation.Duration})}
+	for _, log := range activation.Logs {
+		lines = append(lines, "    "+log)
+	}
+	if activation.Response.Result != nil {
+		if result, err := json.Marshal(activation.Response.Result); err == nil {
+			lines = append(lines, "    "+string(result))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
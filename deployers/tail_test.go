@@ -0,0 +1,60 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceDeployer_tailedActions(t *testing.T) {
+	deployer := NewServiceDeployer()
+	pack := NewDeploymentPackage()
+	pack.Package = &whisk.Package{Name: "hello"}
+	pack.Actions["greeting"] = utils.ActionRecord{Action: &whisk.Action{Name: "greeting"}, Packagename: "hello"}
+	pack.Sequences["welcome"] = utils.ActionRecord{Action: &whisk.Action{Name: "welcome"}, Packagename: "hello"}
+	deployer.Deployment.Packages["hello"] = pack
+
+	actions, err := deployer.tailedActions()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{"hello/greeting": true, "hello/welcome": true}, actions)
+}
+
+func TestActivationAction(t *testing.T) {
+	activation := whisk.Activation{Namespace: "guest", Name: "greeting",
+		Annotations: whisk.KeyValueArr{{Key: ACTIVATION_PATH_ANNOT, Value: "guest/hello/greeting"}}}
+	assert.Equal(t, "hello/greeting", activationAction(activation))
+	// activations without path are the ones of actions without package
+	assert.Equal(t, "greeting", activationAction(whisk.Activation{Namespace: "guest", Name: "greeting"}))
+}
+
+func TestFormatActivation(t *testing.T) {
+	activation := whisk.Activation{ActivationID: "44794bd6aab74415b4e42a308d880e5b", Duration: 12,
+		Logs:        []string{"2018-03-02T15:11:20.161Z stdout: hello"},
+		Annotations: whisk.KeyValueArr{{Key: ACTIVATION_PATH_ANNOT, Value: "guest/hello/greeting"}}}
+	activation.Response.Status = "success"
+	activation.Response.Result = &whisk.Result{"payload": "Hello, Bernie"}
+	assert.Equal(t, "hello/greeting 44794bd6aab74415b4e42a308d880e5b success 12ms\n"+
+		"    2018-03-02T15:11:20.161Z stdout: hello\n"+
+		`    {"payload":"Hello, Bernie"}`, formatActivation(activation))
+}
@@ -0,0 +1,208 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// Throttle paces the requests sent to the OpenWhisk server for a namespace,
+// which are shared by the parallel workers and the deployers of dependencies,
+// see --rate-limit, --burst and --max-concurrent-requests. The rate is a token
+// bucket. When the server throttles the namespace (429), the requests of the
+// namespace are held for a while, DEFAULT_INTERVAL doubled on each throttled
+// request up to DEFAULT_MAX_INTERVAL, and the requests in flight are halved,
+// growing back by one on each request which succeeds up to a ceiling below the
+// requests in flight when throttled. The ceiling is raised by one once as many
+// requests as it allows succeed in a row, up to --max-concurrent-requests if
+// any, so that a transient 429 does not cap the namespace for good.
+type Throttle struct {
+	mt   sync.Mutex
+	cond *sync.Cond
+	// requests per second, no rate limit if 0, and the requests sent at once
+	// once idle
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	// requests in flight, those allowed, no limit if 0, the most allowed,
+	// which is lowered when the namespace is throttled, the requests which
+	// succeeded in a row at the ceiling, and the limit of the flags
+	inFlight  int
+	allowed   int
+	ceiling   int
+	successes int
+	limit     int
+	// requests are held until then once the namespace is throttled
+	pausedUntil time.Time
+	pause       time.Duration
+}
+
+// NewThrottle returns a throttle of rate requests per second, with a burst of
+// requests once idle, rate rounded up if 0, and at most maxInFlight requests
+// in flight, no limit if 0
+func NewThrottle(rate float64, burst int, maxInFlight int) *Throttle {
+	if burst <= 0 {
+		burst = int(math.Max(1, math.Ceil(rate)))
+	}
+	throttle := &Throttle{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now(),
+		allowed: maxInFlight, ceiling: maxInFlight, limit: maxInFlight}
+	throttle.cond = sync.NewCond(&throttle.mt)
+	return throttle
+}
+
+type throttleConfig struct {
+	rate        float64
+	burst       int
+	maxInFlight int
+}
+
+var throttles = struct {
+	sync.Mutex
+	byNamespace map[string]*Throttle
+	configs     map[string]throttleConfig
+}{byNamespace: make(map[string]*Throttle), configs: make(map[string]throttleConfig)}
+
+// GetThrottle returns the throttle of the namespace of the API host, created
+// from the flags, it is replaced when they change, e.g. from a project to the
+// next one
+func GetThrottle(apiHost string, namespace string, flags *utils.WskdeployFlags) *Throttle {
+	config := throttleConfig{rate: flags.RateLimit, burst: flags.Burst,
+		maxInFlight: flags.MaxConcurrentRequests}
+	key := apiHost + "/" + namespace
+
+	throttles.Lock()
+	defer throttles.Unlock()
+	if throttle, ok := throttles.byNamespace[key]; ok && throttles.configs[key] == config {
+		return throttle
+	}
+	throttle := NewThrottle(config.rate, config.burst, config.maxInFlight)
+	throttles.byNamespace[key] = throttle
+	throttles.configs[key] = config
+	return throttle
+}
+
+// Do sends a request once the throttle allows it, the namespace is throttled
+// if the request fails with 429
+func (throttle *Throttle) Do(request func() error) error {
+	throttle.acquire()
+	err := request()
+	throttle.release(isThrottledError(err))
+	return err
+}
+
+func (throttle *Throttle) acquire() {
+	throttle.mt.Lock()
+	defer throttle.mt.Unlock()
+	for {
+		var wait time.Duration
+		now := time.Now()
+		switch {
+		case now.Before(throttle.pausedUntil):
+			wait = throttle.pausedUntil.Sub(now)
+		case throttle.allowed > 0 && throttle.inFlight >= throttle.allowed:
+			throttle.cond.Wait()
+			continue
+		case throttle.rate > 0:
+			throttle.tokens = math.Min(throttle.burst, throttle.tokens+now.Sub(throttle.last).Seconds()*throttle.rate)
+			throttle.last = now
+			if throttle.tokens < 1 {
+				wait = time.Duration((1 - throttle.tokens) / throttle.rate * float64(time.Second))
+			}
+		}
+		if wait <= 0 {
+			break
+		}
+		throttle.mt.Unlock()
+		time.Sleep(wait)
+		throttle.mt.Lock()
+	}
+	if throttle.rate > 0 {
+		throttle.tokens--
+	}
+	throttle.inFlight++
+}
+
+func (throttle *Throttle) release(throttled bool) {
+	throttle.mt.Lock()
+	defer throttle.mt.Unlock()
+	if throttled {
+		throttle.pause = throttle.pause * 2
+		if throttle.pause < DEFAULT_INTERVAL {
+			throttle.pause = DEFAULT_INTERVAL
+		}
+		if throttle.pause > DEFAULT_MAX_INTERVAL {
+			throttle.pause = DEFAULT_MAX_INTERVAL
+		}
+		throttle.pausedUntil = time.Now().Add(throttle.pause)
+		// the requests in flight, this one included, were too many
+		throttle.ceiling = int(math.Max(1, float64(throttle.inFlight-1)))
+		throttle.allowed = int(math.Max(1, float64(throttle.inFlight/2)))
+		throttle.successes = 0
+		whisk.Debug(whisk.DbgWarn, wski18n.T(wski18n.ID_WARN_THROTTLED_X_duration_X_max_X,
+			map[string]interface{}{wski18n.KEY_DURATION: throttle.pause.String(), wski18n.KEY_MAX: throttle.allowed})+"\n")
+	} else {
+		throttle.pause = 0
+		if throttle.allowed > 0 && throttle.allowed < throttle.ceiling {
+			throttle.allowed++
+		} else if throttle.allowed > 0 && (throttle.limit == 0 || throttle.ceiling < throttle.limit) {
+			// the namespace may take more requests than when it was throttled
+			if throttle.successes++; throttle.successes >= throttle.ceiling {
+				throttle.ceiling++
+				throttle.allowed++
+				throttle.successes = 0
+			}
+		}
+	}
+	throttle.inFlight--
+	throttle.cond.Broadcast()
+}
+
+// isThrottledError reports whether the server refused a request because of
+// the limits of the namespace, i.e. too many requests per minute or in flight
+func isThrottledError(err error) bool {
+	wskErr, ok := err.(*whisk.WskError)
+	return ok && wskErr.ExitCode+HTTP_STATUS_CODE_OFFSET == http.StatusTooManyRequests
+}
+
+// throttle returns the throttle of the namespace of the client of the
+// deployer, nil if it has no client
+func (deployer *ServiceDeployer) throttle() *Throttle {
+	if deployer.Client == nil || deployer.Client.Config == nil {
+		return nil
+	}
+	return GetThrottle(deployer.Client.Host, deployer.Client.Namespace, deployer.Flags)
+}
+
+// retry is retry() with each attempt paced by the throttle of the namespace
+func (deployer *ServiceDeployer) retry(attempts int, sleep time.Duration, callback func() error) error {
+	throttle := deployer.throttle()
+	if throttle == nil {
+		return retry(attempts, sleep, callback)
+	}
+	return retry(attempts, sleep, func() error {
+		return throttle.Do(callback)
+	})
+}
@@ -0,0 +1,146 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottle_Rate(t *testing.T) {
+	throttle := NewThrottle(50, 1, 0)
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		assert.Nil(t, throttle.Do(func() error { return nil }))
+	}
+	// the first request is sent at once, the next ones every 20ms
+	assert.True(t, time.Since(start) >= 90*time.Millisecond, "the requests are paced by the rate")
+}
+
+func TestThrottle_MaxInFlight(t *testing.T) {
+	throttle := NewThrottle(0, 0, 2)
+	var mt sync.Mutex
+	inFlight, most := 0, 0
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			throttle.Do(func() error {
+				mt.Lock()
+				inFlight++
+				if inFlight > most {
+					most = inFlight
+				}
+				mt.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				mt.Lock()
+				inFlight--
+				mt.Unlock()
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 2, most)
+}
+
+func TestThrottle_Throttled(t *testing.T) {
+	throttle := NewThrottle(0, 0, 0)
+	for i := 0; i < 4; i++ {
+		throttle.acquire()
+	}
+	throttle.release(true)
+	assert.Equal(t, 2, throttle.allowed, "the requests in flight are halved")
+	assert.Equal(t, 3, throttle.ceiling)
+	assert.Equal(t, DEFAULT_INTERVAL, throttle.pause)
+	assert.True(t, throttle.pausedUntil.After(time.Now()), "the requests are held")
+
+	throttle.release(true)
+	assert.Equal(t, 2*DEFAULT_INTERVAL, throttle.pause, "the pause doubles")
+	assert.Equal(t, 1, throttle.allowed)
+
+	throttle.release(false)
+	throttle.release(false)
+	assert.Equal(t, time.Duration(0), throttle.pause)
+	assert.Equal(t, 2, throttle.ceiling)
+	assert.Equal(t, 2, throttle.allowed, "the requests in flight grow back up to the ceiling")
+}
+
+func TestThrottle_Recovery(t *testing.T) {
+	throttle := NewThrottle(0, 0, 0)
+	for i := 0; i < 4; i++ {
+		throttle.acquire()
+	}
+	throttle.release(true)
+	throttle.pausedUntil = time.Time{}
+	for i := 0; i < 3; i++ {
+		throttle.release(false)
+	}
+	assert.Equal(t, 3, throttle.allowed)
+	assert.Equal(t, 3, throttle.ceiling)
+	for i := 0; i < 3+4+5; i++ {
+		assert.Nil(t, throttle.Do(func() error { return nil }))
+	}
+	assert.Equal(t, 6, throttle.ceiling, "the ceiling is raised after a run of successes")
+	assert.Equal(t, 6, throttle.allowed)
+
+	// the ceiling does not go above --max-concurrent-requests
+	throttle = NewThrottle(0, 0, 4)
+	for i := 0; i < 4; i++ {
+		throttle.acquire()
+	}
+	throttle.release(true)
+	throttle.pausedUntil = time.Time{}
+	for i := 0; i < 3; i++ {
+		throttle.release(false)
+	}
+	for i := 0; i < 20; i++ {
+		assert.Nil(t, throttle.Do(func() error { return nil }))
+	}
+	assert.Equal(t, 4, throttle.ceiling)
+	assert.Equal(t, 4, throttle.allowed)
+}
+
+func TestIsThrottledError(t *testing.T) {
+	throttled := &whisk.WskError{RootErr: errors.New("Too many requests"), ExitCode: 429 - HTTP_STATUS_CODE_OFFSET}
+	unavailable := &whisk.WskError{RootErr: errors.New("Service unavailable"), ExitCode: 503 - HTTP_STATUS_CODE_OFFSET}
+	assert.True(t, isThrottledError(throttled))
+	assert.False(t, isThrottledError(unavailable))
+	assert.False(t, isThrottledError(nil))
+}
+
+func TestGetThrottle(t *testing.T) {
+	flags := &utils.WskdeployFlags{RateLimit: 10}
+
+	throttle := GetThrottle("openwhisk.example.com", "guest", flags)
+	assert.True(t, throttle == GetThrottle("openwhisk.example.com", "guest", flags), "the throttle is shared by the namespace")
+	assert.False(t, throttle == GetThrottle("openwhisk.example.com", "other", flags))
+	assert.Equal(t, float64(10), throttle.burst, "the burst is the rate by default")
+
+	flags.RateLimit = 20
+	assert.False(t, throttle == GetThrottle("openwhisk.example.com", "guest", flags), "the throttle follows the flags")
+}
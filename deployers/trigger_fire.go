@@ -0,0 +1,91 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// ProjectTriggers returns the triggers declared in the manifest of the project,
+// indexed by their name in the manifest
+func (deployer *ServiceDeployer) ProjectTriggers() (map[string]*whisk.Trigger, error) {
+	manifest, parser, err := NewManifestReader(deployer).ParseManifest()
+	if err != nil {
+		return nil, err
+	}
+	composed, err := parser.ComposeTriggersFromAllPackages(manifest, deployer.ManifestPath, whisk.KeyValue{})
+	if err != nil {
+		return nil, err
+	}
+
+	triggers := make(map[string]*whisk.Trigger)
+	for _, trigger := range composed {
+		triggers[trigger.Name] = trigger
+	}
+	return triggers, nil
+}
+
+// FireTrigger fires a trigger of the project with the payload given, e.g. to
+// exercise its rules once the project is deployed. The trigger is fired in the
+// namespace it is deployed to, its qualified name and the id of the activation
+// are returned.
+func (deployer *ServiceDeployer) FireTrigger(name string, payload map[string]interface{}) (string, string, error) {
+	triggers, err := deployer.ProjectTriggers()
+	if err != nil {
+		return "", "", err
+	}
+	trigger, ok := triggers[name]
+	if !ok {
+		names := make([]string, 0, len(triggers))
+		for n := range triggers {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return "", "", wskderrors.NewYAMLFileFormatError(deployer.ManifestPath,
+			wski18n.T(wski18n.ID_ERR_TRIGGER_NOT_IN_PROJECT_X_name_X_triggers_X,
+				map[string]interface{}{
+					wski18n.KEY_NAME:     name,
+					wski18n.KEY_TRIGGERS: strings.Join(names, ", ")}))
+	}
+
+	qualifiedName := deployer.getQualifiedName(trigger.Name, trigger.Namespace)
+	var fired *whisk.Trigger
+	var response *http.Response
+	err = deployer.inNamespace(trigger.Namespace, func() error {
+		fired, response, err = deployer.Client.Triggers.Fire(trigger.Name, payload)
+		return err
+	})
+	if err != nil {
+		if wskErr, ok := err.(*whisk.WskError); ok {
+			return qualifiedName, "", wskderrors.NewWhiskClientError(wskErr.Error(), wskErr.ExitCode, response)
+		}
+		return qualifiedName, "", err
+	}
+
+	activationId := ""
+	if fired != nil {
+		activationId = fired.ActivationId
+	}
+	return qualifiedName, activationId, nil
+}
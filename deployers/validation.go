@@ -0,0 +1,111 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"path"
+	"sort"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// Validate checks the manifest and the deployment file of the project without
+// OpenWhisk: both files must parse, every entity of the manifest must compose,
+// see parsers.YAMLParser.ValidateManifest(), and every entity the deployment
+// file binds inputs or annotations to must be defined in the manifest. All the
+// errors are returned, none if the project is valid.
+func (deployer *ServiceDeployer) Validate() []error {
+	manifestReader := NewManifestReader(deployer)
+	manifest, manifestParser, err := manifestReader.ParseManifest()
+	if err != nil {
+		return []error{err}
+	}
+	problems := manifestParser.ValidateManifest(manifest, deployer.ManifestPath)
+
+	if !utils.FileExists(deployer.DeploymentPath) {
+		return problems
+	}
+	deploymentReader := NewDeploymentReader(deployer)
+	if err := deploymentReader.HandleYaml(); err != nil {
+		return append(problems, err)
+	}
+	return append(problems, deploymentReader.validateEntities(manifest)...)
+}
+
+// validateEntities returns an error for each package, action, sequence,
+// trigger and rule of the deployment file which the manifest does not define
+func (reader *DeploymentReader) validateEntities(manifest *parsers.YAML) []error {
+	manifestPackages := manifest.GetPackages()
+	triggers := make(map[string]bool)
+	rules := make(map[string]bool)
+	for _, pkg := range manifestPackages {
+		for name := range pkg.Triggers {
+			triggers[name] = true
+		}
+		for name := range pkg.Rules {
+			rules[name] = true
+		}
+	}
+
+	messages := make([]string, 0)
+	notDefined := func(key string, name string) {
+		messages = append(messages, wski18n.T(wski18n.ID_ERR_DEPLOYMENT_ENTITY_NOT_IN_MANIFEST_X_key_X_name_X,
+			map[string]interface{}{wski18n.KEY_KEY: key, wski18n.KEY_NAME: name}))
+	}
+	for packageName, pkg := range reader.deploymentPackages() {
+		// a deployment file without packages
+		if len(packageName) == 0 {
+			continue
+		}
+		manifestPackage, exists := manifestPackages[packageName]
+		if !exists {
+			notDefined(parsers.YAML_KEY_PACKAGE, packageName)
+			continue
+		}
+		for name := range pkg.Actions {
+			if _, exists := manifestPackage.Actions[name]; !exists {
+				notDefined(parsers.YAML_KEY_ACTION, path.Join(packageName, name))
+			}
+		}
+		for name := range pkg.Sequences {
+			if _, exists := manifestPackage.Sequences[name]; !exists {
+				notDefined(parsers.YAML_KEY_SEQUENCE, path.Join(packageName, name))
+			}
+		}
+		for name := range pkg.Triggers {
+			if !triggers[name] {
+				notDefined(parsers.YAML_KEY_TRIGGER, name)
+			}
+		}
+		for name := range pkg.Rules {
+			if !rules[name] {
+				notDefined(parsers.YAML_KEY_RULE, name)
+			}
+		}
+	}
+
+	sort.Strings(messages)
+	problems := make([]error, 0, len(messages))
+	for _, message := range messages {
+		problems = append(problems, wskderrors.NewYAMLFileFormatError(reader.DeploymentDescriptor.Filepath, message))
+	}
+	return problems
+}
@@ -0,0 +1,62 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceDeployer_Validate(t *testing.T) {
+	supported, defaults, extensions := utils.SupportedRunTimes, utils.DefaultRunTimes, utils.FileExtensionRuntimeKindMap
+	deprecated := utils.DeprecatedRunTimes
+	defer func() {
+		utils.SupportedRunTimes, utils.DefaultRunTimes, utils.FileExtensionRuntimeKindMap = supported, defaults, extensions
+		utils.DeprecatedRunTimes = deprecated
+	}()
+	utils.RefreshRuntimes("")
+	deployer := NewServiceDeployer()
+	deployer.ClientConfig = &whisk.Config{Namespace: "guest"}
+	deployer.ManifestPath = "../tests/dat/manifest_validate_project.yaml"
+	deployer.DeploymentPath = "../tests/dat/deployment_validate_project.yaml"
+
+	problems := deployer.Validate()
+	messages := make([]string, 0, len(problems))
+	for _, problem := range problems {
+		messages = append(messages, problem.Error())
+	}
+	report := strings.Join(messages, "\n")
+
+	// all the problems are reported at once
+	assert.Equal(t, 6, len(problems), report)
+	for _, expected := range []string{"missing.js", "cobol:1", "[versioned]",
+		"[helloworld/bye]", "[everyminute]", "[goodbyeworld]"} {
+		assert.Contains(t, report, expected)
+	}
+	assert.NotContains(t, report, "[helloworld/hello]")
+
+	deployer.ManifestPath = "../tests/dat/manifest_hello_nodejs.yaml"
+	deployer.DeploymentPath = ""
+	assert.Equal(t, 0, len(deployer.Validate()))
+}
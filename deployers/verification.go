@@ -0,0 +1,443 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// fields of the entities which are verified
+const (
+	VERIFY_FIELD_CODE        = "code"
+	VERIFY_FIELD_KIND        = "kind"
+	VERIFY_FIELD_MAIN        = "main"
+	VERIFY_FIELD_COMPONENTS  = "components"
+	VERIFY_FIELD_PARAMETER   = "parameter"
+	VERIFY_FIELD_ANNOTATION  = "annotation"
+	VERIFY_FIELD_LIMIT       = "limit"
+	VERIFY_FIELD_WEB         = "web"
+	VERIFY_FIELD_TRIGGER     = "trigger"
+	VERIFY_FIELD_ACTION      = "action"
+	VERIFY_FIELD_STATUS      = "status"
+	VERIFY_FIELD_MANIFEST    = "manifest sha256"
+	VERIFY_FIELD_DEPLOYMENT  = "deployment sha256"
+	VERIFY_VALUE_NOT_PRESENT = "<none>"
+)
+
+// VerifyMismatch is a difference between an entity of the deployment plan and
+// the entity deployed, an entity which is not deployed has no field
+type VerifyMismatch struct {
+	Entity   string
+	Name     string
+	Field    string
+	Expected string
+	Deployed string
+}
+
+func (mismatch VerifyMismatch) String() string {
+	if len(mismatch.Field) == 0 {
+		return wski18n.T(wski18n.ID_MSG_VERIFY_NOT_DEPLOYED_X_key_X_name_X,
+			map[string]interface{}{wski18n.KEY_KEY: mismatch.Entity, wski18n.KEY_NAME: mismatch.Name})
+	}
+	return wski18n.T(wski18n.ID_MSG_VERIFY_MISMATCH_X_key_X_name_X_field_X_expected_X_value_X,
+		map[string]interface{}{wski18n.KEY_KEY: mismatch.Entity, wski18n.KEY_NAME: mismatch.Name,
+			wski18n.KEY_FIELD: mismatch.Field, wski18n.KEY_EXPECTED: mismatch.Expected,
+			wski18n.KEY_VALUE: mismatch.Deployed})
+}
+
+// VerifyDeployment fetches the packages, actions, sequences, triggers and
+// rules of the deployment plan and returns how they differ from the plan: the
+// hash of the code, the kind, main and components of actions, their limits,
+// web flags and parameters, and the annotations of the plan. The annotations
+// added by OpenWhisk, e.g. "exec", are not differences. Nothing is deployed,
+// the plan must be constructed first, see ConstructDeploymentPlan().
+func (deployer *ServiceDeployer) VerifyDeployment() ([]VerifyMismatch, error) {
+	mismatches := make([]VerifyMismatch, 0)
+
+	for _, pack := range deployer.Deployment.Packages {
+		expected := pack.Package
+		var deployed *whisk.Package
+		found, err := deployer.getDeployed(expected.Namespace, func() (*http.Response, error) {
+			var response *http.Response
+			var err error
+			deployed, response, err = deployer.Client.Packages.Get(expected.Name)
+			return response, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !found || deployed == nil {
+			mismatches = append(mismatches, VerifyMismatch{Entity: parsers.YAML_KEY_PACKAGE, Name: expected.Name})
+		} else {
+			mismatches = append(mismatches, verifyKeyValues(parsers.YAML_KEY_PACKAGE, expected.Name, VERIFY_FIELD_PARAMETER, expected.Parameters, deployed.Parameters, true)...)
+			mismatches = append(mismatches, verifyKeyValues(parsers.YAML_KEY_PACKAGE, expected.Name, VERIFY_FIELD_ANNOTATION, expected.Annotations, deployed.Annotations, false)...)
+			mismatches = append(mismatches, deployer.verifyProvenance(expected.Name, deployed.Annotations)...)
+		}
+
+		for entity, records := range map[string]map[string]utils.ActionRecord{parsers.YAML_KEY_ACTION: pack.Actions, parsers.YAML_KEY_SEQUENCE: pack.Sequences} {
+			for actionName, record := range records {
+				name := actionName
+				if deployer.DeployActionInPackage {
+					name = strings.Join([]string{expected.Name, actionName}, "/")
+				}
+				var deployedAction *whisk.Action
+				found, err := deployer.getDeployed(expected.Namespace, func() (*http.Response, error) {
+					var response *http.Response
+					var err error
+					deployedAction, response, err = deployer.Client.Actions.Get(name)
+					return response, err
+				})
+				if err != nil {
+					return nil, err
+				}
+				if !found || deployedAction == nil {
+					mismatches = append(mismatches, VerifyMismatch{Entity: entity, Name: name})
+					continue
+				}
+				action := *record.Action
+				if deployer.isWebSecretGenerated(expected.Name, actionName) {
+					// the secret generated when the manifest is parsed is not the one deployed
+					action.Annotations = removeKeyValue(action.Annotations, utils.REQUIRE_WHISK_AUTH_ANNOT)
+				}
+				mismatches = append(mismatches, verifyAction(entity, name, &action, deployedAction)...)
+			}
+		}
+	}
+
+	for _, trigger := range deployer.Deployment.Triggers {
+		expected := trigger
+		var deployed *whisk.Trigger
+		found, err := deployer.getDeployed(expected.Namespace, func() (*http.Response, error) {
+			var response *http.Response
+			var err error
+			deployed, response, err = deployer.Client.Triggers.Get(expected.Name)
+			return response, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !found || deployed == nil {
+			mismatches = append(mismatches, VerifyMismatch{Entity: parsers.YAML_KEY_TRIGGER, Name: expected.Name})
+			continue
+		}
+		mismatches = append(mismatches, verifyTrigger(expected, deployed)...)
+	}
+
+	for _, rule := range deployer.Deployment.Rules {
+		expected := rule
+		var deployed *whisk.Rule
+		found, err := deployer.getDeployed(expected.Namespace, func() (*http.Response, error) {
+			var response *http.Response
+			var err error
+			deployed, response, err = deployer.Client.Rules.Get(expected.Name)
+			return response, err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !found || deployed == nil {
+			mismatches = append(mismatches, VerifyMismatch{Entity: parsers.YAML_KEY_RULE, Name: expected.Name})
+			continue
+		}
+		mismatches = append(mismatches, verifyRule(expected, deployed)...)
+	}
+
+	sort.SliceStable(mismatches, func(i, j int) bool {
+		if mismatches[i].Entity != mismatches[j].Entity {
+			return mismatches[i].Entity < mismatches[j].Entity
+		}
+		return mismatches[i].Name < mismatches[j].Name
+	})
+	return mismatches, nil
+}
+
+// verifyProvenance prints the files a package deployed with --managed was
+// deployed from, and reports the manifest and deployment files which changed
+// since, see utils.ManagedProvenance
+func (deployer *ServiceDeployer) verifyProvenance(name string, annotations whisk.KeyValueArr) []VerifyMismatch {
+	deployed := utils.GetManagedProvenance(annotations)
+	if len(deployed.ManifestChecksum) == 0 {
+		return nil
+	}
+	wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_VERIFY_PROVENANCE_X_key_X_name_X_provenance_X,
+		map[string]interface{}{wski18n.KEY_KEY: parsers.YAML_KEY_PACKAGE, wski18n.KEY_NAME: name,
+			wski18n.KEY_PROVENANCE: deployed.String()}))
+
+	mismatches := make([]VerifyMismatch, 0)
+	checksums := []struct {
+		field    string
+		expected string
+		deployed string
+	}{
+		{VERIFY_FIELD_MANIFEST, deployer.Provenance.ManifestChecksum, deployed.ManifestChecksum},
+		{VERIFY_FIELD_DEPLOYMENT, deployer.Provenance.DeploymentChecksum, deployed.DeploymentChecksum},
+	}
+	for _, checksum := range checksums {
+		if checksum.expected == checksum.deployed {
+			continue
+		}
+		mismatch := VerifyMismatch{Entity: parsers.YAML_KEY_PACKAGE, Name: name, Field: checksum.field,
+			Expected: checksum.expected, Deployed: checksum.deployed}
+		if len(mismatch.Expected) == 0 {
+			mismatch.Expected = VERIFY_VALUE_NOT_PRESENT
+		}
+		if len(mismatch.Deployed) == 0 {
+			mismatch.Deployed = VERIFY_VALUE_NOT_PRESENT
+		}
+		mismatches = append(mismatches, mismatch)
+	}
+	return mismatches
+}
+
+// getDeployed fetches an entity in its namespace, an entity which is not
+// found is not an error but is not deployed
+func (deployer *ServiceDeployer) getDeployed(namespace string, get func() (*http.Response, error)) (bool, error) {
+	var response *http.Response
+	err := deployer.inNamespace(namespace, func() error {
+		return deployer.retry(DEFAULT_ATTEMPTS, DEFAULT_INTERVAL, func() error {
+			var err error
+			response, err = get()
+			if response != nil && response.StatusCode == http.StatusNotFound {
+				return nil
+			}
+			return err
+		})
+	})
+	if err != nil {
+		if wskErr, ok := err.(*whisk.WskError); ok {
+			return false, wskderrors.NewWhiskClientError(wskErr.Error(), wskErr.ExitCode, response)
+		}
+		return false, err
+	}
+	return response == nil || response.StatusCode != http.StatusNotFound, nil
+}
+
+func verifyAction(entity string, name string, expected *whisk.Action, deployed *whisk.Action) []VerifyMismatch {
+	mismatches := make([]VerifyMismatch, 0)
+	mismatch := func(field string, expectedValue string, deployedValue string) {
+		if expectedValue != deployedValue {
+			mismatches = append(mismatches, VerifyMismatch{Entity: entity, Name: name, Field: field, Expected: expectedValue, Deployed: deployedValue})
+		}
+	}
+
+	if exec := expected.Exec; exec != nil {
+		deployedExec := deployed.Exec
+		if deployedExec == nil {
+			deployedExec = &whisk.Exec{}
+		}
+		if exec.Code != nil {
+			mismatch(VERIFY_FIELD_CODE, codeHash(exec.Code), codeHash(deployedExec.Code))
+		}
+		// the default kind of a runtime is resolved by OpenWhisk
+		if len(exec.Kind) > 0 && !strings.HasSuffix(exec.Kind, ":default") {
+			mismatch(VERIFY_FIELD_KIND, exec.Kind, deployedExec.Kind)
+		}
+		if len(exec.Main) > 0 {
+			mismatch(VERIFY_FIELD_MAIN, exec.Main, deployedExec.Main)
+		}
+		if len(exec.Components) > 0 {
+			mismatch(VERIFY_FIELD_COMPONENTS, strings.Join(exec.Components, ","), strings.Join(deployedExec.Components, ","))
+		}
+	}
+
+	if limits := expected.Limits; limits != nil {
+		deployedLimits := deployed.Limits
+		if deployedLimits == nil {
+			deployedLimits = &whisk.Limits{}
+		}
+		for _, limit := range []struct {
+			key      string
+			expected *int
+			deployed *int
+		}{
+			{"timeout", limits.Timeout, deployedLimits.Timeout},
+			{"memorySize", limits.Memory, deployedLimits.Memory},
+			{"logSize", limits.Logsize, deployedLimits.Logsize},
+		} {
+			if limit.expected != nil {
+				mismatch(VERIFY_FIELD_LIMIT+" "+limit.key, verifyString(*limit.expected), verifyString(limit.deployed))
+			}
+		}
+	}
+
+	mismatch(VERIFY_FIELD_WEB, webFlag(expected.Annotations), webFlag(deployed.Annotations))
+	mismatches = append(mismatches, verifyKeyValues(entity, name, VERIFY_FIELD_PARAMETER, expected.Parameters, deployed.Parameters, true)...)
+	mismatches = append(mismatches, verifyKeyValues(entity, name, VERIFY_FIELD_ANNOTATION, expected.Annotations, deployed.Annotations, false)...)
+	return mismatches
+}
+
+// the parameters of a trigger with a feed are passed to the feed, they are
+// not parameters of the trigger
+func verifyTrigger(expected *whisk.Trigger, deployed *whisk.Trigger) []VerifyMismatch {
+	mismatches := make([]VerifyMismatch, 0)
+	if _, isFeed := utils.IsFeedAction(expected); !isFeed {
+		mismatches = append(mismatches, verifyKeyValues(parsers.YAML_KEY_TRIGGER, expected.Name, VERIFY_FIELD_PARAMETER, expected.Parameters, deployed.Parameters, true)...)
+	}
+	return append(mismatches, verifyKeyValues(parsers.YAML_KEY_TRIGGER, expected.Name, VERIFY_FIELD_ANNOTATION, expected.Annotations, deployed.Annotations, false)...)
+}
+
+// the trigger and action of a rule are compared by name, the deployed rule
+// gives them as {"name": ..., "path": ...}
+func verifyRule(expected *whisk.Rule, deployed *whisk.Rule) []VerifyMismatch {
+	mismatches := make([]VerifyMismatch, 0)
+	for _, field := range []struct {
+		key      string
+		expected interface{}
+		deployed interface{}
+	}{
+		{VERIFY_FIELD_TRIGGER, expected.Trigger, deployed.Trigger},
+		{VERIFY_FIELD_ACTION, expected.Action, deployed.Action},
+	} {
+		expectedName, deployedName := entityName(field.expected), entityName(field.deployed)
+		if expectedName != deployedName {
+			mismatches = append(mismatches, VerifyMismatch{Entity: parsers.YAML_KEY_RULE, Name: expected.Name,
+				Field: field.key, Expected: expectedName, Deployed: deployedName})
+		}
+	}
+	if len(expected.Status) > 0 && expected.Status != deployed.Status {
+		mismatches = append(mismatches, VerifyMismatch{Entity: parsers.YAML_KEY_RULE, Name: expected.Name,
+			Field: VERIFY_FIELD_STATUS, Expected: expected.Status, Deployed: deployed.Status})
+	}
+	return mismatches
+}
+
+// verifyKeyValues compares the values of the keys expected with the ones
+// deployed, the keys deployed which are not expected are differences if all
+// keys must be the same, e.g. for parameters
+func verifyKeyValues(entity string, name string, field string, expected whisk.KeyValueArr, deployed whisk.KeyValueArr, all bool) []VerifyMismatch {
+	mismatches := make([]VerifyMismatch, 0)
+	for _, keyValue := range expected {
+		deployedValue := VERIFY_VALUE_NOT_PRESENT
+		if deployed.FindKeyValue(keyValue.Key) >= 0 {
+			deployedValue = verifyString(deployed.GetValue(keyValue.Key))
+		}
+		if expectedValue := verifyString(keyValue.Value); expectedValue != deployedValue {
+			mismatches = append(mismatches, VerifyMismatch{Entity: entity, Name: name, Field: field + " " + keyValue.Key,
+				Expected: wskprint.MaskSecrets(expectedValue), Deployed: wskprint.MaskSecrets(deployedValue)})
+		}
+	}
+	if all {
+		for _, keyValue := range deployed {
+			if expected.FindKeyValue(keyValue.Key) < 0 {
+				mismatches = append(mismatches, VerifyMismatch{Entity: entity, Name: name, Field: field + " " + keyValue.Key,
+					Expected: VERIFY_VALUE_NOT_PRESENT, Deployed: wskprint.MaskSecrets(verifyString(keyValue.Value))})
+			}
+		}
+	}
+	return mismatches
+}
+
+// verifyString returns the value as JSON, so that the values parsed from YAML
+// compare with the ones decoded from the responses of OpenWhisk, e.g. 1 and
+// 1.0 or maps with keys of any type
+func verifyString(value interface{}) string {
+	if v, ok := value.(*int); ok {
+		if v == nil {
+			return VERIFY_VALUE_NOT_PRESENT
+		}
+		return fmt.Sprint(*v)
+	}
+	content, err := json.Marshal(jsonValue(value))
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(content, &decoded); err != nil {
+		return string(content)
+	}
+	if text, ok := decoded.(string); ok {
+		return text
+	}
+	content, _ = json.Marshal(decoded)
+	return string(content)
+}
+
+// jsonValue returns the value with the keys of its maps as strings, the
+// values of the maps are kept as they are
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = jsonValue(item)
+		}
+		return converted
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[key] = jsonValue(item)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			converted = append(converted, jsonValue(item))
+		}
+		return converted
+	}
+	return value
+}
+
+// codeHash returns a short SHA-256 of the code, the code itself may be large
+// or binary
+func codeHash(code *string) string {
+	if code == nil {
+		return VERIFY_VALUE_NOT_PRESENT
+	}
+	sum := sha256.Sum256([]byte(*code))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+func webFlag(annotations whisk.KeyValueArr) string {
+	return fmt.Sprint(verifyString(annotations.GetValue(utils.WEB_EXPORT_ANNOT)) == "true")
+}
+
+func entityName(entity interface{}) string {
+	switch e := entity.(type) {
+	case string:
+		return e[strings.LastIndex(e, "/")+1:]
+	case map[string]interface{}:
+		return fmt.Sprint(e["name"])
+	case map[interface{}]interface{}:
+		return fmt.Sprint(e["name"])
+	}
+	return fmt.Sprint(entity)
+}
+
+func removeKeyValue(keyValues whisk.KeyValueArr, key string) whisk.KeyValueArr {
+	removed := make(whisk.KeyValueArr, 0, len(keyValues))
+	for _, keyValue := range keyValues {
+		if keyValue.Key != key {
+			removed = append(removed, keyValue)
+		}
+	}
+	return removed
+}
@@ -0,0 +1,112 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestVerifyAction(code string, timeout int) *whisk.Action {
+	return &whisk.Action{
+		Name:   "hello",
+		Exec:   &whisk.Exec{Kind: "nodejs:6", Code: &code},
+		Limits: &whisk.Limits{Timeout: &timeout},
+		Parameters: whisk.KeyValueArr{
+			{Key: "name", Value: "Amy"},
+			{Key: "places", Value: map[interface{}]interface{}{"city": "Paris", "zip": 75001}},
+		},
+		Annotations: whisk.KeyValueArr{{Key: "description", Value: "greets"}},
+	}
+}
+
+func TestVerifyAction(t *testing.T) {
+	expected := newTestVerifyAction("function main() {}", 60000)
+	deployed := newTestVerifyAction("function main() {}", 60000)
+	deployed.Parameters[1].Value = map[string]interface{}{"city": "Paris", "zip": 75001.0}
+	deployed.Annotations = append(deployed.Annotations, whisk.KeyValue{Key: "exec", Value: "nodejs:6"})
+	assert.Empty(t, verifyAction(parsers.YAML_KEY_ACTION, "hello", expected, deployed),
+		"values decoded from JSON and annotations added by OpenWhisk are not differences")
+
+	deployed = newTestVerifyAction("function main() { return {}; }", 300000)
+	deployed.Parameters = append(deployed.Parameters, whisk.KeyValue{Key: "extra", Value: true})
+	deployed.Annotations = whisk.KeyValueArr{{Key: "web-export", Value: true}}
+	fields := make([]string, 0)
+	for _, mismatch := range verifyAction(parsers.YAML_KEY_ACTION, "hello", expected, deployed) {
+		fields = append(fields, mismatch.Field)
+	}
+	assert.Equal(t, []string{VERIFY_FIELD_CODE, "limit timeout", VERIFY_FIELD_WEB, "parameter extra", "annotation description"}, fields)
+}
+
+func TestVerifyRule(t *testing.T) {
+	expected := &whisk.Rule{Name: "hourly", Trigger: "everyhour", Action: "helloworld/hello"}
+	deployed := &whisk.Rule{Name: "hourly",
+		Trigger: map[string]interface{}{"name": "everyhour", "path": "guest"},
+		Action:  map[string]interface{}{"name": "hello", "path": "guest/helloworld"}}
+	assert.Empty(t, verifyRule(expected, deployed))
+
+	deployed.Action = map[string]interface{}{"name": "goodbye", "path": "guest/helloworld"}
+	mismatches := verifyRule(expected, deployed)
+	if assert.Equal(t, 1, len(mismatches)) {
+		assert.Equal(t, VERIFY_FIELD_ACTION, mismatches[0].Field)
+		assert.Contains(t, mismatches[0].String(), "goodbye")
+	}
+}
+
+func TestVerifyTrigger_Feed(t *testing.T) {
+	expected := &whisk.Trigger{Name: "everyhour",
+		Parameters:  whisk.KeyValueArr{{Key: "cron", Value: "0 * * * *"}},
+		Annotations: whisk.KeyValueArr{{Key: "feed", Value: "/whisk.system/alarms/alarm"}}}
+	deployed := &whisk.Trigger{Name: "everyhour",
+		Annotations: whisk.KeyValueArr{{Key: "feed", Value: "/whisk.system/alarms/alarm"}}}
+	assert.Empty(t, verifyTrigger(expected, deployed), "the parameters of a feed are not the ones of the trigger")
+
+	deployed.Annotations = nil
+	mismatches := verifyTrigger(expected, deployed)
+	if assert.Equal(t, 1, len(mismatches)) {
+		assert.Equal(t, VERIFY_VALUE_NOT_PRESENT, mismatches[0].Deployed)
+	}
+}
+
+func TestServiceDeployer_verifyProvenance(t *testing.T) {
+	deployer := NewServiceDeployer()
+	deployer.Provenance = utils.ManagedProvenance{ManifestChecksum: "aaaa", WskdeployVersion: "0.9.8"}
+	managed := func(provenance utils.ManagedProvenance) whisk.KeyValueArr {
+		ma, err := utils.GenerateManagedAnnotation("hello", "", "", utils.ManagedRevision{}, provenance,
+			"../tests/dat/manifest_validate_typed_annotations.yaml")
+		assert.Nil(t, err)
+		return whisk.KeyValueArr{ma}
+	}
+
+	assert.Empty(t, deployer.verifyProvenance("hello", whisk.KeyValueArr{}), "not deployed with --managed")
+	assert.Empty(t, deployer.verifyProvenance("hello", managed(utils.ManagedProvenance{ManifestChecksum: "aaaa",
+		WskdeployVersion: "0.9.7"})), "the version of wskdeploy is not a difference")
+
+	mismatches := deployer.verifyProvenance("hello", managed(utils.ManagedProvenance{ManifestChecksum: "bbbb",
+		DeploymentChecksum: "cccc"}))
+	assert.Equal(t, []VerifyMismatch{
+		{Entity: parsers.YAML_KEY_PACKAGE, Name: "hello", Field: VERIFY_FIELD_MANIFEST, Expected: "aaaa", Deployed: "bbbb"},
+		{Entity: parsers.YAML_KEY_PACKAGE, Name: "hello", Field: VERIFY_FIELD_DEPLOYMENT, Expected: VERIFY_VALUE_NOT_PRESENT, Deployed: "cccc"},
+	}, mismatches)
+}
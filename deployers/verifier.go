@@ -18,8 +18,6 @@
 package deployers
 
 import (
-	"fmt"
-
 	"github.com/apache/incubator-openwhisk-client-go/whisk"
 )
 
@@ -60,8 +58,7 @@ func (vf *Verifier) Filter(deployer *ServiceDeployer, target *DeploymentProject)
 	}
 
 	depApp := NewDeploymentProject()
-	// TODO() i18n
-	fmt.Printf("Target Packages are %#v\n", target.Packages)
+	whisk.Debug(whisk.DbgInfo, "Target Packages are %#v\n", target.Packages)
 	depApp.Packages = target.Packages
 	return depApp, nil
 }
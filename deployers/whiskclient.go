@@ -32,16 +32,28 @@ import (
 	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskenv"
 )
 
 const (
 	COMMANDLINE = "wskdeploy command line"
+	ENVIRONMENT = "environment variable"
 	DEFAULTVALUE = "default value"
 	WSKPROPS = ".wskprops"
 	WHISKPROPERTY = "whisk.properties"
 	INTERINPUT = "interactve input"
 )
 
+// environment variables read after the command line, e.g. to configure each
+// stage of a CI pipeline, they may also be set in the .env file of the project
+const (
+	ENV_WHISK_APIHOST   = "WHISK_APIHOST"
+	ENV_WHISK_AUTH      = "WHISK_AUTH"
+	ENV_WHISK_NAMESPACE = "WHISK_NAMESPACE"
+	ENV_WHISK_KEY       = "WHISK_CLIENT_KEY"
+	ENV_WHISK_CERT      = "WHISK_CLIENT_CERT"
+)
+
 type PropertyValue struct {
 	Value  string
 	Source string
@@ -63,8 +75,8 @@ var GetWskPropFromWhiskProperty = func(pi whisk.Properties) (*whisk.Wskprops, er
 	return whisk.GetWskPropFromWhiskProperty(pi)
 }
 
-var GetCommandLineFlags = func() (string, string, string, string, string) {
-	return utils.Flags.ApiHost, utils.Flags.Auth, utils.Flags.Namespace, utils.Flags.Key, utils.Flags.Cert
+var GetCommandLineFlags = func(flags *utils.WskdeployFlags) (string, string, string, string, string) {
+	return flags.ApiHost, flags.Auth, flags.Namespace, flags.Key, flags.Cert
 }
 
 var CreateNewClient = func(config_input *whisk.Config) (*whisk.Client, error) {
@@ -74,13 +86,72 @@ var CreateNewClient = func(config_input *whisk.Config) (*whisk.Client, error) {
 	return whisk.NewClient(netClient, config_input)
 }
 
-// we are reading openwhisk credentials (apihost, namespace, and auth) in the following precedence order:
+// WhiskConfigValues are the values of the client configuration along with the
+// source each of them was read from, see ResolveWhiskConfig()
+type WhiskConfigValues struct {
+	ApiHost    PropertyValue
+	Credential PropertyValue
+	Namespace  PropertyValue
+	Key        PropertyValue
+	Cert       PropertyValue
+}
+
+// NewWhiskConfig returns the client configuration resolved by
+// ResolveWhiskConfig(), it fails if the API host, auth key or namespace is
+// missing
+func NewWhiskConfig(proppath string, deploymentPath string, manifestPath string, isInteractive bool, flags *utils.WskdeployFlags) (*whisk.Config, error) {
+	values, err := ResolveWhiskConfig(proppath, deploymentPath, manifestPath, isInteractive, flags)
+	if err != nil {
+		return &whisk.Config{}, err
+	}
+	credential, namespace, apiHost := values.Credential, values.Namespace, values.ApiHost
+	key, cert := values.Key, values.Cert
+
+	mode := true
+	if (len(cert.Value) != 0 && len(key.Value) != 0) {
+		mode = false
+	}
+
+	clientConfig = &whisk.Config{
+		AuthToken: credential.Value, //Authtoken
+		Namespace: namespace.Value, //Namespace
+		Host:      apiHost.Value,
+		Version:   "v1",
+		Cert:      cert.Value,
+		Key:       key.Value,
+		Insecure:  mode, // true if you want to ignore certificate signing
+	}
+
+	// validate we have credential, apihost and namespace
+	err = validateClientConfig(credential, apiHost, namespace)
+	if err != nil {
+		return clientConfig, err
+	}
+
+	// the base URL keeps the port and the path prefix of the API host, e.g.
+	// https://cluster:8443/openwhisk/api for OpenWhisk exposed under /openwhisk
+	clientConfig.BaseURL, err = utils.ApiBaseURL(apiHost.Value)
+	if err != nil {
+		errmsg := wski18n.T(wski18n.ID_ERR_INVALID_API_HOST_X_host_X_err_X,
+			map[string]interface{}{wski18n.KEY_HOST: apiHost.Value, wski18n.KEY_ERR: err.Error()})
+		return clientConfig, wskderrors.NewWhiskClientInvalidConfigError(errmsg)
+	}
+
+	return clientConfig, nil
+}
+
+// ResolveWhiskConfig reads the openwhisk credentials (apihost, namespace, and
+// auth) and the client key and cert in the following precedence order:
 // (1) wskdeploy command line `wskdeploy --apihost --namespace --auth`
-// (2) deployment file
-// (3) manifest file
-// (4) .wskprops
-// (5) prompt for values in interactive mode if any of them are missing
-func NewWhiskConfig(proppath string, deploymentPath string, manifestPath string, isInteractive bool) (*whisk.Config, error) {
+// (2) environment variables WHISK_APIHOST, WHISK_AUTH, WHISK_NAMESPACE,
+//     WHISK_CLIENT_KEY and WHISK_CLIENT_CERT
+// (3) deployment file
+// (4) manifest file
+// (5) the provider selected with --provider
+// (6) the profile selected with --profile or, without profile, .wskprops
+// (7) prompt for values in interactive mode if any of them are missing
+// Each value is returned along with its source, see `wskdeploy config`.
+func ResolveWhiskConfig(proppath string, deploymentPath string, manifestPath string, isInteractive bool, flags *utils.WskdeployFlags) (*WhiskConfigValues, error) {
 	// struct to store credential, namespace, and host with their respective source
 	credential := PropertyValue{}
 	namespace := PropertyValue{}
@@ -89,17 +160,25 @@ func NewWhiskConfig(proppath string, deploymentPath string, manifestPath string,
 	cert := PropertyValue{}
 
 	// read credentials from command line
-	apihost, auth, ns, keyfile, certfile := GetCommandLineFlags()
+	apihost, auth, ns, keyfile, certfile := GetCommandLineFlags(flags)
 	credential = GetPropertyValue(credential, auth, COMMANDLINE)
 	namespace = GetPropertyValue(namespace, ns, COMMANDLINE)
 	apiHost = GetPropertyValue(apiHost, apihost, COMMANDLINE)
 	key = GetPropertyValue(key, keyfile, COMMANDLINE)
 	cert = GetPropertyValue(cert, certfile, COMMANDLINE)
 
-	// now, read them from deployment file if not found on command line
+	// then from the environment
+	credential = getEnvPropertyValue(credential, ENV_WHISK_AUTH)
+	namespace = getEnvPropertyValue(namespace, ENV_WHISK_NAMESPACE)
+	apiHost = getEnvPropertyValue(apiHost, ENV_WHISK_APIHOST)
+	key = getEnvPropertyValue(key, ENV_WHISK_KEY)
+	cert = getEnvPropertyValue(cert, ENV_WHISK_CERT)
+
+	// now, read them from deployment file if not found on command line or in the environment
 	if len(credential.Value) == 0 || len(namespace.Value) == 0 || len(apiHost.Value) == 0 {
 		if utils.FileExists(deploymentPath) {
 			mm := parsers.NewYAMLParser()
+			mm.Flags = flags
 			deployment, _ := mm.ParseDeployment(deploymentPath)
 			credential = GetPropertyValue(credential, deployment.GetProject().Credential, path.Base(deploymentPath))
 			namespace = GetPropertyValue(namespace, deployment.GetProject().Namespace, path.Base(deploymentPath))
@@ -111,6 +190,7 @@ func NewWhiskConfig(proppath string, deploymentPath string, manifestPath string,
 	if len(credential.Value) == 0 || len(namespace.Value) == 0 || len(apiHost.Value) == 0 {
 		if utils.FileExists(manifestPath) {
 			mm := parsers.NewYAMLParser()
+			mm.Flags = flags
 			manifest, _ := mm.ParseManifest(manifestPath)
 			if manifest.Package.Packagename != "" {
 				credential = GetPropertyValue(credential, manifest.Package.Credential, path.Base(manifestPath))
@@ -128,43 +208,37 @@ func NewWhiskConfig(proppath string, deploymentPath string, manifestPath string,
 		}
 	}
 
-	// Third, we need to look up the variables in .wskprops file.
-	pi := whisk.PropertiesImp{
-		OsPackage: whisk.OSPackageImp{},
+	// the credentials of the provider selected with --provider, e.g. the
+	// variables or the .wskprops file of its CLI
+	provider, err := flags.GetProvider()
+	if err != nil {
+		return nil, err
 	}
-
-	// The error raised here can be neglected, because we will handle it in the end of this function.
-	wskprops, _ := GetWskPropFromWskprops(pi, proppath)
-	credential = GetPropertyValue(credential, wskprops.AuthKey, WSKPROPS)
-	namespace = GetPropertyValue(namespace, wskprops.Namespace, WSKPROPS)
-	apiHost = GetPropertyValue(apiHost, wskprops.APIHost, WSKPROPS)
-	key = GetPropertyValue(key, wskprops.Key, WSKPROPS)
-	cert = GetPropertyValue(cert, wskprops.Cert, WSKPROPS)
-
-	// TODO() see if we can split the following whisk prop logic into a separate function
-	// now, read credentials from whisk.properties but this is only acceptable within Travis
-	// whisk.properties will soon be deprecated and should not be used for any production deployment
-	whiskproperty, _ := GetWskPropFromWhiskProperty(pi)
-
-	var warnmsg string
-
-	credential = GetPropertyValue(credential, whiskproperty.AuthKey, WHISKPROPERTY)
-	if credential.Source == WHISKPROPERTY {
-		warnmsg = wski18n.T(wski18n.ID_WARN_WHISK_PROPS_DEPRECATED,
-			map[string]interface{}{"key": "authenticaton key"})
-		wskprint.PrintlnOpenWhiskWarning(warnmsg)
+	if provider != nil {
+		readProviderCredentials(provider, &credential, &namespace, &apiHost)
 	}
-	namespace = GetPropertyValue(namespace, whiskproperty.Namespace, WHISKPROPERTY)
-	if namespace.Source == WHISKPROPERTY {
-		warnmsg = wski18n.T(wski18n.ID_WARN_WHISK_PROPS_DEPRECATED,
-			map[string]interface{}{"key": "namespace"})
-		wskprint.PrintlnOpenWhiskWarning(warnmsg)
+
+	// Third, we need to look up the variables in the profile selected with --profile,
+	// which replaces .wskprops and whisk.properties
+	if len(flags.Profile) > 0 {
+		profile, err := GetProfile(flags.Profile)
+		if err != nil {
+			return nil, err
+		}
+		source := wski18n.T(wski18n.ID_MSG_PROFILE_SOURCE_X_name_X,
+			map[string]interface{}{wski18n.KEY_NAME: profile.Name})
+		credential = GetPropertyValue(credential, profile.AuthKey, source)
+		namespace = GetPropertyValue(namespace, profile.Namespace, source)
+		apiHost = GetPropertyValue(apiHost, profile.APIHost, source)
+		key = GetPropertyValue(key, profile.Key, source)
+		cert = GetPropertyValue(cert, profile.Cert, source)
+	} else {
+		readWskprops(proppath, &credential, &namespace, &apiHost, &key, &cert)
 	}
-	apiHost = GetPropertyValue(apiHost, whiskproperty.APIHost, WHISKPROPERTY)
-	if apiHost.Source == WHISKPROPERTY {
-		warnmsg = wski18n.T(wski18n.ID_WARN_WHISK_PROPS_DEPRECATED,
-			map[string]interface{}{"key": "API host"})
-		wskprint.PrintlnOpenWhiskWarning(warnmsg)
+
+	if provider != nil {
+		apiHost = GetPropertyValue(apiHost, provider.ApiHost, wski18n.T(wski18n.ID_MSG_PROVIDER_SOURCE_X_name_X,
+			map[string]interface{}{wski18n.KEY_NAME: provider.Name}))
 	}
 
 	// set namespace to default namespace if not yet found
@@ -208,25 +282,176 @@ func NewWhiskConfig(proppath string, deploymentPath string, manifestPath string,
 		}
 	}
 
-	mode := true
-	if (len(cert.Value) != 0 && len(key.Value) != 0) {
-		mode = false
+	// an auth key of the form keychain:<account> is read from the keychain of
+	// the OS, see utils.KEYCHAIN_PREFIX
+	if account, ok := utils.KeychainAccount(credential.Value); ok {
+		secret, err := utils.ReadKeychain(account)
+		if err != nil {
+			return nil, err
+		}
+		credential.Value = secret
+		credential.Source = wski18n.T(wski18n.ID_MSG_KEYCHAIN_SOURCE_X_source_X_account_X,
+			map[string]interface{}{wski18n.KEY_SOURCE: credential.Source, wski18n.KEY_ACCOUNT: account})
 	}
 
-	clientConfig = &whisk.Config{
-		AuthToken: credential.Value, //Authtoken
-		Namespace: namespace.Value, //Namespace
-		Host:      apiHost.Value,
-		Version:   "v1",
-		Cert:      cert.Value,
-		Key:       key.Value,
-		Insecure:  mode, // true if you want to ignore certificate signing
+	return &WhiskConfigValues{ApiHost: apiHost, Credential: credential, Namespace: namespace, Key: key, Cert: cert}, nil
+}
+
+// getEnvPropertyValue sets the property from the environment variable if it is
+// not set yet
+func getEnvPropertyValue(prop PropertyValue, name string) PropertyValue {
+	return GetPropertyValue(prop, os.Getenv(name), ENVIRONMENT+" "+name)
+}
+
+// NewApigwConfig returns the configuration of the client which creates APIs.
+// The API gateway may be authenticated separately from the OpenWhisk API host,
+// with an access token, and may be reached through another host. Both are read
+// in the following precedence order:
+// (1) wskdeploy command line `wskdeploy --apigw-access-token --apigw-host`
+// (2) deployment file, "apigw_access_token" and "apigw_host" of the project
+// (3) manifest file, "apigw_access_token" and "apigw_host" of the project
+// (4) the profile selected with --profile or, without profile, .wskprops (token only)
+// The configuration of the OpenWhisk API host is returned as is if neither is set.
+func NewApigwConfig(config *whisk.Config, proppath string, deploymentPath string, manifestPath string, flags *utils.WskdeployFlags) (*whisk.Config, error) {
+	token := PropertyValue{}
+	host := PropertyValue{}
+
+	token = GetPropertyValue(token, flags.ApigwAccessToken, COMMANDLINE)
+	host = GetPropertyValue(host, flags.ApigwHost, COMMANDLINE)
+
+	for _, filePath := range []string{deploymentPath, manifestPath} {
+		if (len(token.Value) > 0 && len(host.Value) > 0) || !utils.FileExists(filePath) {
+			continue
+		}
+		var yaml *parsers.YAML
+		parser := parsers.NewYAMLParser()
+		parser.Flags = flags
+		if filePath == deploymentPath {
+			yaml, _ = parser.ParseDeployment(filePath)
+		} else {
+			yaml, _ = parser.ParseManifest(filePath)
+		}
+		if yaml == nil {
+			continue
+		}
+		project := yaml.GetProject()
+		token = GetPropertyValue(token, interpolateString(project.ApigwAccessToken), path.Base(filePath))
+		host = GetPropertyValue(host, interpolateString(project.ApigwHost), path.Base(filePath))
 	}
 
-	// validate we have credential, apihost and namespace
-	err := validateClientConfig(credential, apiHost, namespace)
+	if len(flags.Profile) > 0 {
+		profile, err := GetProfile(flags.Profile)
+		if err != nil {
+			return config, err
+		}
+		source := wski18n.T(wski18n.ID_MSG_PROFILE_SOURCE_X_name_X,
+			map[string]interface{}{wski18n.KEY_NAME: profile.Name})
+		token = GetPropertyValue(token, profile.ApigwAccessToken, source)
+		host = GetPropertyValue(host, profile.ApigwHost, source)
+	} else {
+		pi := whisk.PropertiesImp{
+			OsPackage: whisk.OSPackageImp{},
+		}
+		if wskprops, err := GetWskPropFromWskprops(pi, proppath); err == nil {
+			token = GetPropertyValue(token, wskprops.AuthAPIGWKey, WSKPROPS)
+		}
+	}
+
+	if len(token.Value) == 0 && len(host.Value) == 0 {
+		return config, nil
+	}
+
+	apigwConfig := *config
+	apigwConfig.ApigwAccessToken = token.Value
+	if len(host.Value) > 0 {
+		baseURL, err := utils.ApiBaseURL(host.Value)
+		if err != nil {
+			errmsg := wski18n.T(wski18n.ID_ERR_INVALID_API_HOST_X_host_X_err_X,
+				map[string]interface{}{wski18n.KEY_HOST: host.Value, wski18n.KEY_ERR: err.Error()})
+			return config, wskderrors.NewWhiskClientInvalidConfigError(errmsg)
+		}
+		apigwConfig.Host = host.Value
+		apigwConfig.BaseURL = baseURL
+		wskprint.PrintOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_CONFIG_INFO_APIGW_HOST_X_host_X_source_X,
+			map[string]interface{}{wski18n.KEY_HOST: host.Value, wski18n.KEY_SOURCE: host.Source}))
+	}
+	if len(token.Value) > 0 {
+		wskprint.PrintOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_CONFIG_INFO_APIGW_ACCESS_TOKEN_X_source_X,
+			map[string]interface{}{wski18n.KEY_SOURCE: token.Source}))
+	}
+	return &apigwConfig, nil
+}
+
+func interpolateString(value string) string {
+	if s, ok := wskenv.GetEnvVar(value).(string); ok {
+		return s
+	}
+	return value
+}
 
-	return clientConfig, err
+// readProviderCredentials looks up the values which are not set yet in the
+// variables of the provider, and then in its .wskprops file
+func readProviderCredentials(provider *utils.Provider, credential *PropertyValue, namespace *PropertyValue, apiHost *PropertyValue) {
+	source := wski18n.T(wski18n.ID_MSG_PROVIDER_SOURCE_X_name_X,
+		map[string]interface{}{wski18n.KEY_NAME: provider.Name})
+	if len(provider.AuthEnv) > 0 {
+		*credential = GetPropertyValue(*credential, os.Getenv(provider.AuthEnv), source)
+	}
+	if len(provider.NamespaceEnv) > 0 {
+		*namespace = GetPropertyValue(*namespace, os.Getenv(provider.NamespaceEnv), source)
+	}
+	if wskpropsPath := provider.WskpropsPath(); len(wskpropsPath) > 0 && utils.FileExists(wskpropsPath) {
+		pi := whisk.PropertiesImp{
+			OsPackage: whisk.OSPackageImp{},
+		}
+		if wskprops, err := GetWskPropFromWskprops(pi, wskpropsPath); err == nil {
+			*credential = GetPropertyValue(*credential, wskprops.AuthKey, source)
+			*namespace = GetPropertyValue(*namespace, wskprops.Namespace, source)
+			*apiHost = GetPropertyValue(*apiHost, wskprops.APIHost, source)
+		}
+	}
+}
+
+// readWskprops looks up the values which are not set yet in .wskprops, and then
+// in whisk.properties
+func readWskprops(proppath string, credential *PropertyValue, namespace *PropertyValue, apiHost *PropertyValue, key *PropertyValue, cert *PropertyValue) {
+	pi := whisk.PropertiesImp{
+		OsPackage: whisk.OSPackageImp{},
+	}
+
+	// The error raised here can be neglected, because NewWhiskConfig() validates the values in the end.
+	wskprops, _ := GetWskPropFromWskprops(pi, proppath)
+	*credential = GetPropertyValue(*credential, wskprops.AuthKey, WSKPROPS)
+	*namespace = GetPropertyValue(*namespace, wskprops.Namespace, WSKPROPS)
+	*apiHost = GetPropertyValue(*apiHost, wskprops.APIHost, WSKPROPS)
+	*key = GetPropertyValue(*key, wskprops.Key, WSKPROPS)
+	*cert = GetPropertyValue(*cert, wskprops.Cert, WSKPROPS)
+
+	// TODO() see if we can split the following whisk prop logic into a separate function
+	// now, read credentials from whisk.properties but this is only acceptable within Travis
+	// whisk.properties will soon be deprecated and should not be used for any production deployment
+	whiskproperty, _ := GetWskPropFromWhiskProperty(pi)
+
+	var warnmsg string
+
+	*credential = GetPropertyValue(*credential, whiskproperty.AuthKey, WHISKPROPERTY)
+	if credential.Source == WHISKPROPERTY {
+		warnmsg = wski18n.T(wski18n.ID_WARN_WHISK_PROPS_DEPRECATED,
+			map[string]interface{}{"key": "authenticaton key"})
+		wskprint.PrintlnOpenWhiskWarning(warnmsg)
+	}
+	*namespace = GetPropertyValue(*namespace, whiskproperty.Namespace, WHISKPROPERTY)
+	if namespace.Source == WHISKPROPERTY {
+		warnmsg = wski18n.T(wski18n.ID_WARN_WHISK_PROPS_DEPRECATED,
+			map[string]interface{}{"key": "namespace"})
+		wskprint.PrintlnOpenWhiskWarning(warnmsg)
+	}
+	*apiHost = GetPropertyValue(*apiHost, whiskproperty.APIHost, WHISKPROPERTY)
+	if apiHost.Source == WHISKPROPERTY {
+		warnmsg = wski18n.T(wski18n.ID_WARN_WHISK_PROPS_DEPRECATED,
+			map[string]interface{}{"key": "API host"})
+		wskprint.PrintlnOpenWhiskWarning(warnmsg)
+	}
 }
 
 func validateClientConfig(credential PropertyValue, apiHost PropertyValue, namespace PropertyValue) (error) {
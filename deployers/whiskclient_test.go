@@ -20,6 +20,7 @@
 package deployers
 
 import (
+	"os"
 	"testing"
 	"github.com/stretchr/testify/assert"
 	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
@@ -59,7 +60,7 @@ func TestNewWhiskConfig(t *testing.T) {
 	propPath := ""
 	manifestPath := ""
 	deploymentPath := ""
-	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false)
+	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false, &utils.Flags)
 	if err == nil {
 		pi := whisk.PropertiesImp {
 			OsPackage: whisk.OSPackageImp{},
@@ -83,7 +84,7 @@ func TestNewWhiskConfigCommandLine(t *testing.T) {
 	utils.Flags.Auth = CLI_AUTH
 	utils.Flags.Namespace = CLI_NAMESPACE
 
-	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false)
+	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false, &utils.Flags)
 	assert.Nil(t, err, "Failed to read credentials from wskdeploy command line")
     assert.Equal(t, CLI_HOST, config.Host, "Failed to get host name from wskdeploy command line")
     assert.Equal(t, CLI_AUTH, config.AuthToken, "Failed to get auth token from wskdeploy command line")
@@ -92,7 +93,7 @@ func TestNewWhiskConfigCommandLine(t *testing.T) {
 
     utils.Flags.Key = WSKPROPS_KEY
     utils.Flags.Cert = WSKPROPS_CERT
-    config, err = NewWhiskConfig(propPath, deploymentPath, manifestPath, false)
+    config, err = NewWhiskConfig(propPath, deploymentPath, manifestPath, false, &utils.Flags)
     assert.Nil(t, err, "Failed to read credentials from wskdeploy command line")
     assert.Equal(t, CLI_HOST, config.Host, "Failed to get host name from wskdeploy command line")
     assert.Equal(t, CLI_AUTH, config.AuthToken, "Failed to get auth token from wskdeploy command line")
@@ -108,7 +109,7 @@ func TestNewWhiskConfigDeploymentFile(t *testing.T) {
 	propPath := ""
 	manifestPath := ""
 	deploymentPath := "../tests/dat/deployment_validate_credentials.yaml"
-	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false)
+	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false, &utils.Flags)
 	assert.Nil(t, err, "Failed to read credentials from deployment file")
 	assert.Equal(t, DEPLOYMENT_HOST, config.Host, "Failed to get host name from deployment file")
 	assert.Equal(t, DEPLOYMENT_AUTH, config.AuthToken, "Failed to get auth token from deployment file")
@@ -120,7 +121,7 @@ func TestNewWhiskConfigManifestFile(t *testing.T) {
 	propPath := ""
 	manifestPath := "../tests/dat/manifest_validate_credentials.yaml"
 	deploymentPath := ""
-	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false)
+	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false, &utils.Flags)
 	assert.Nil(t, err, "Failed to read credentials from manifest file")
 	assert.Equal(t, MANIFEST_HOST, config.Host, "Failed to get host name from manifest file")
 	assert.Equal(t, MANIFEST_AUTH, config.AuthToken, "Failed to get auth token from manifest file")
@@ -133,7 +134,7 @@ func TestNewWhiskConfigWithWskProps(t *testing.T) {
 	propPath := "../tests/dat/wskprops"
 	manifestPath := ""
 	deploymentPath := ""
-	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false)
+	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false, &utils.Flags)
 	assert.Nil(t, err, "Failed to read credentials from wskprops")
 	assert.Equal(t, WSKPROPS_HOST, config.Host, "Failed to get host name from wskprops")
 	assert.Equal(t, WSKPROPS_AUTH, config.AuthToken, "Failed to get auth token from wskprops")
@@ -143,7 +144,7 @@ func TestNewWhiskConfigWithWskProps(t *testing.T) {
     assert.False(t, config.Insecure, "Config should set insecure to false")
 
     propPath = "../tests/dat/wskpropsnokeycert"
-    config, err = NewWhiskConfig(propPath, deploymentPath, manifestPath, false)
+    config, err = NewWhiskConfig(propPath, deploymentPath, manifestPath, false, &utils.Flags)
     assert.Nil(t, err, "Failed to read credentials from wskprops")
     assert.Equal(t, WSKPROPS_HOST, config.Host, "Failed to get host name from wskprops")
     assert.Equal(t, WSKPROPS_AUTH, config.AuthToken, "Failed to get auth token from wskprops")
@@ -158,7 +159,7 @@ func TestNewWhiskConfigWithWskProps(t *testing.T) {
 	propPath := ""
 	manifestPath := ""
 	deploymentPath := ""
-	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, true)
+	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, true, &utils.Flags)
 	assert.Nil(t, err, "Failed to read credentials in interactive mode")
 }*/
 
@@ -171,7 +172,7 @@ func TestNewWhiskConfigWithCLIDeploymentAndManifestFile(t *testing.T) {
 	utils.Flags.Auth = CLI_AUTH
 	utils.Flags.Namespace = CLI_NAMESPACE
 
-	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false)
+	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false, &utils.Flags)
 	assert.Nil(t, err, "Failed to read credentials from CLI or deployment or manifest file")
 	assert.Equal(t, config.Host, CLI_HOST, "Failed to get host name from wskdeploy CLI")
 	assert.Equal(t, config.AuthToken, CLI_AUTH, "Failed to get auth token from wskdeploy CLI")
@@ -191,7 +192,7 @@ func TestNewWhiskConfigWithCLIAndDeployment(t *testing.T) {
 	utils.Flags.Auth = CLI_AUTH
 	utils.Flags.Namespace = CLI_NAMESPACE
 
-	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false)
+	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false, &utils.Flags)
 	assert.Nil(t, err, "Failed to read credentials from wskdeploy CLI")
 	assert.Equal(t, config.Host, CLI_HOST, "Failed to get host name from wskdeploy CLI")
 	assert.Equal(t, config.AuthToken, CLI_AUTH, "Failed to get auth token from wskdeploy CLI")
@@ -210,7 +211,7 @@ func TestNewWhiskConfigWithCLIAndManifest(t *testing.T) {
 	utils.Flags.Auth = CLI_AUTH
 	utils.Flags.Namespace = CLI_NAMESPACE
 
-	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false)
+	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false, &utils.Flags)
 	assert.Nil(t, err, "Failed to read credentials from manifest file")
 	assert.Equal(t, config.Host, CLI_HOST, "Failed to get host name from wskdeploy CLI")
 	assert.Equal(t, config.AuthToken, CLI_AUTH, "Failed to get auth token from wskdeploy CLI")
@@ -228,7 +229,7 @@ func TestNewWhiskConfigWithCLIAndWskProps(t *testing.T) {
 	utils.Flags.ApiHost = CLI_HOST
 	utils.Flags.Auth = CLI_AUTH
 	utils.Flags.Namespace = CLI_NAMESPACE
-	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false)
+	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false, &utils.Flags)
 	assert.Nil(t, err, "Failed to read credentials from wskdeploy command line")
 	assert.Equal(t, config.Host, CLI_HOST, "Failed to get host name from wskdeploy command line")
 	assert.Equal(t, config.AuthToken, CLI_AUTH, "Failed to get auth token from wskdeploy command line")
@@ -242,10 +243,89 @@ func TestNewWhiskConfigWithDeploymentAndManifestFile(t *testing.T) {
 	propPath := ""
 	manifestPath := "../tests/dat/manifest_validate_credentials.yaml"
 	deploymentPath := "../tests/dat/deployment_validate_credentials.yaml"
-	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false)
+	config, err := NewWhiskConfig(propPath, deploymentPath, manifestPath, false, &utils.Flags)
 	assert.Nil(t, err, "Failed to read credentials from manifest or deployment file")
 	assert.Equal(t, config.Host, DEPLOYMENT_HOST, "Failed to get host name from deployment file")
 	assert.Equal(t, config.AuthToken, DEPLOYMENT_AUTH, "Failed to get auth token from deployment file")
 	assert.Equal(t, config.Namespace, DEPLOYMENT_NAMESPACE, "Failed to get namespace from deployment file")
     assert.True(t, config.Insecure, "Config should set insecure to true")
 }
+
+func TestNewApigwConfig(t *testing.T) {
+	config := &whisk.Config{Host: CLI_HOST, AuthToken: "uuid:key", Namespace: CLI_NAMESPACE}
+
+	// the configuration of the API host is used if the API gateway is not configured
+	apigwConfig, err := NewApigwConfig(config, "", "", "", &utils.Flags)
+	assert.Nil(t, err)
+	assert.True(t, config == apigwConfig)
+
+	os.Setenv("APIGW_ACCESS_TOKEN", "deployment-token")
+	defer os.Unsetenv("APIGW_ACCESS_TOKEN")
+	apigwConfig, err = NewApigwConfig(config, "", "../tests/dat/deployment_validate_apigw.yaml", "", &utils.Flags)
+	assert.Nil(t, err)
+	assert.Equal(t, "deployment-token", apigwConfig.ApigwAccessToken)
+	assert.Equal(t, "https://gateway.example.com:9443/openwhisk", apigwConfig.Host)
+	assert.Equal(t, "https://gateway.example.com:9443/openwhisk/api", apigwConfig.BaseURL.String())
+	assert.Equal(t, CLI_NAMESPACE, apigwConfig.Namespace)
+	assert.Equal(t, CLI_HOST, config.Host, "the configuration of the API host is unchanged")
+
+	// the command line takes precedence over the deployment file
+	utils.Flags.ApigwAccessToken = "cli-token"
+	defer func() { utils.Flags.ApigwAccessToken = "" }()
+	apigwConfig, err = NewApigwConfig(config, "", "../tests/dat/deployment_validate_apigw.yaml", "", &utils.Flags)
+	assert.Nil(t, err)
+	assert.Equal(t, "cli-token", apigwConfig.ApigwAccessToken)
+
+	deployer := NewServiceDeployer()
+	deployer.Client = &whisk.Client{Config: config}
+	deployer.ApigwClient = &whisk.Client{Config: apigwConfig}
+	client, options, err := deployer.apigwClient()
+	assert.Nil(t, err)
+	assert.True(t, client == deployer.ApigwClient)
+	assert.Equal(t, "uuid", options.SpaceGuid)
+	assert.Equal(t, "cli-token", options.AccessToken)
+}
+
+func TestNewWhiskConfigWithProvider(t *testing.T) {
+	initializeFlags()
+	utils.Flags.Provider = utils.PROVIDER_ADOBE_IO_RUNTIME
+	defer func() { utils.Flags.Provider = "" }()
+	os.Setenv("AIO_RUNTIME_AUTH", "aio-uuid:aio-key")
+	os.Setenv("AIO_RUNTIME_NAMESPACE", "aio-namespace")
+	defer os.Unsetenv("AIO_RUNTIME_AUTH")
+	defer os.Unsetenv("AIO_RUNTIME_NAMESPACE")
+
+	config, err := NewWhiskConfig("", "", "", false, &utils.Flags)
+	assert.Nil(t, err)
+	assert.Equal(t, "aio-uuid:aio-key", config.AuthToken, "the credentials are read from the variables of the provider")
+	assert.Equal(t, "aio-namespace", config.Namespace)
+	assert.Equal(t, "https://adobeioruntime.net", config.Host, "the API host of the provider is the default")
+
+	utils.Flags.ApiHost = CLI_HOST
+	defer initializeFlags()
+	config, err = NewWhiskConfig("", "", "", false, &utils.Flags)
+	assert.Nil(t, err)
+	assert.Equal(t, CLI_HOST, config.Host, "the command line takes precedence over the provider")
+
+	utils.Flags.Provider = "unknown"
+	_, err = NewWhiskConfig("", "", "", false, &utils.Flags)
+	assert.NotNil(t, err)
+}
+
+func TestResolveWhiskConfigWithEnvironment(t *testing.T) {
+	initializeFlags()
+	os.Setenv(ENV_WHISK_AUTH, "env-uuid:env-key")
+	os.Setenv(ENV_WHISK_NAMESPACE, "env-namespace")
+	defer os.Unsetenv(ENV_WHISK_AUTH)
+	defer os.Unsetenv(ENV_WHISK_NAMESPACE)
+
+	deploymentPath := "../tests/dat/deployment_validate_credentials.yaml"
+	utils.Flags.Namespace = CLI_NAMESPACE
+	defer initializeFlags()
+	values, err := ResolveWhiskConfig("", deploymentPath, "", false, &utils.Flags)
+	assert.Nil(t, err)
+	assert.Equal(t, PropertyValue{CLI_NAMESPACE, COMMANDLINE}, values.Namespace, "the command line takes precedence over the environment")
+	assert.Equal(t, PropertyValue{"env-uuid:env-key", ENVIRONMENT + " " + ENV_WHISK_AUTH}, values.Credential,
+		"the environment takes precedence over the deployment file")
+	assert.Equal(t, PropertyValue{DEPLOYMENT_HOST, "deployment_validate_credentials.yaml"}, values.ApiHost)
+}
@@ -0,0 +1,421 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+)
+
+// formats the deployment plan is exported to
+const (
+	EXPORT_FORMAT_WSK_SCRIPT = "wsk-script"
+	// serverless.yml of the Serverless Framework, see parsers.ManifestToServerless()
+	EXPORT_FORMAT_SERVERLESS = "serverless"
+)
+
+// directory of the files the script writes, e.g. the zip files of actions
+const WSK_SCRIPT_WORKDIR = "$WORKDIR"
+
+// ExportWskScript writes the deployment plan as a shell script of wsk CLI
+// commands which deploys the same entities, in the order wskdeploy deploys
+// them. The paths of the code of actions are relative to the project path,
+// the script changes to, code which is not in a file is written by the script.
+func (deployer *ServiceDeployer) ExportWskScript(out io.Writer) error {
+	script := &wskScript{manifestDir: filepath.Dir(deployer.ManifestPath)}
+	script.projectPath, _ = filepath.Abs(deployer.ProjectPath)
+	plan := deployer.Deployment
+
+	script.line("#!/bin/sh")
+	script.comment("wsk CLI commands which deploy the project %s", deployer.ProjectName)
+	script.comment("exported by wskdeploy from %s", deployer.ManifestPath)
+	script.line("set -e")
+	script.line("cd " + shellQuote(script.projectPath))
+	script.line("WORKDIR=$(mktemp -d)")
+	script.line(`trap 'rm -rf "$WORKDIR"' EXIT`)
+
+	packages := make([]string, 0, len(plan.Packages))
+	for name := range plan.Packages {
+		packages = append(packages, name)
+	}
+	sort.Strings(packages)
+
+	for _, name := range packages {
+		pack := plan.Packages[name].Package
+		args := []string{"package", "update", script.qualified(pack.Name, pack.Namespace)}
+		args = append(args, keyValueArgs("--param", pack.Parameters)...)
+		args = append(args, keyValueArgs("--annotation", pack.Annotations)...)
+		if pack.Publish != nil {
+			args = append(args, "--shared", yesNo(*pack.Publish))
+		}
+		script.section(parsers.YAML_KEY_PACKAGE, pack.Name)
+		script.wsk(args...)
+	}
+
+	for _, name := range packages {
+		pack := plan.Packages[name]
+		for _, depName := range sortedDependencies(pack.Dependencies) {
+			depRecord := pack.Dependencies[depName]
+			script.section(parsers.YAML_KEY_DEPENDENCY, depName)
+			if !depRecord.IsBinding {
+				script.comment("deploy the project %s with wskdeploy", depRecord.Location)
+				continue
+			}
+			location := depRecord.Location
+			if !strings.HasPrefix(location, "/") {
+				location = "/" + location
+			}
+			args := []string{"package", "bind", shellQuote(location), script.qualified(depName, pack.Package.Namespace)}
+			args = append(args, keyValueArgs("--param", depRecord.Parameters)...)
+			args = append(args, keyValueArgs("--annotation", depRecord.Annotations)...)
+			script.wsk(args...)
+		}
+	}
+
+	for _, name := range packages {
+		pack := plan.Packages[name]
+		for _, binding := range sortedBindings(pack.Bindings) {
+			script.section(parsers.YAML_KEY_BINDING, binding.Name)
+			location := "/" + binding.Binding.Namespace + "/" + binding.Binding.Name
+			args := []string{"package", "bind", shellQuote(location), script.qualified(binding.Name, binding.Namespace)}
+			args = append(args, keyValueArgs("--param", binding.Parameters)...)
+			args = append(args, keyValueArgs("--annotation", binding.Annotations)...)
+			script.wsk(args...)
+		}
+	}
+
+	for _, name := range packages {
+		pack := plan.Packages[name]
+		for _, record := range sortedActions(pack.Actions) {
+			script.section(parsers.YAML_KEY_ACTION, pack.Package.Name+"/"+record.Action.Name)
+			script.action(pack.Package, record)
+		}
+	}
+
+	for _, name := range packages {
+		pack := plan.Packages[name]
+		for _, record := range sortedActions(pack.Sequences) {
+			action := record.Action
+			args := []string{"action", "update", script.qualified(pack.Package.Name+"/"+action.Name, pack.Package.Namespace),
+				"--sequence", shellQuote(strings.Join(action.Exec.Components, ","))}
+			args = append(args, keyValueArgs("--param", action.Parameters)...)
+			args = append(args, keyValueArgs("--annotation", action.Annotations)...)
+			script.section(parsers.YAML_KEY_SEQUENCE, pack.Package.Name+"/"+action.Name)
+			script.wsk(args...)
+		}
+	}
+
+	for _, name := range sortedKeys(plan.Triggers) {
+		trigger := plan.Triggers[name]
+		triggerName := script.qualified(trigger.Name, trigger.Namespace)
+		script.section(parsers.YAML_KEY_TRIGGER, trigger.Name)
+		if feedName, isFeed := utils.IsFeedAction(trigger); isFeed {
+			// feeds are only given their parameters when the trigger is created
+			annotations := make(whisk.KeyValueArr, 0, len(trigger.Annotations))
+			for _, annotation := range trigger.Annotations {
+				if annotation.Key != parsers.YAML_KEY_FEED {
+					annotations = append(annotations, annotation)
+				}
+			}
+			script.line("wsk trigger delete " + triggerName + " >/dev/null 2>&1 || true")
+			args := []string{"trigger", "create", triggerName, "--feed", shellQuote(feedName)}
+			args = append(args, keyValueArgs("--param", trigger.Parameters)...)
+			args = append(args, keyValueArgs("--annotation", annotations)...)
+			script.wsk(args...)
+			continue
+		}
+		args := []string{"trigger", "update", triggerName}
+		args = append(args, keyValueArgs("--param", trigger.Parameters)...)
+		args = append(args, keyValueArgs("--annotation", trigger.Annotations)...)
+		script.wsk(args...)
+	}
+
+	for _, name := range sortedKeys(plan.Rules) {
+		rule := plan.Rules[name]
+		triggerName, _ := rule.Trigger.(string)
+		actionName, _ := rule.Action.(string)
+		script.section(parsers.YAML_KEY_RULE, rule.Name)
+		script.wsk("rule", "update", script.qualified(rule.Name, rule.Namespace),
+			shellQuote(deployer.getQualifiedName(triggerName, deployer.ClientConfig.Namespace)),
+			shellQuote(deployer.qualifiedRuleAction(actionName)))
+	}
+
+	for _, name := range sortedKeys(plan.Apis) {
+		api := plan.Apis[name]
+		script.section(parsers.YAML_KEY_API, name)
+		if len(api.ApiDoc.Swagger) > 0 {
+			if err := deployer.resolveSwaggerBackends(api); err != nil {
+				return err
+			}
+			file := script.file(name+".json", api.ApiDoc.Swagger)
+			script.wsk("api", "create", "--config-file", file)
+			continue
+		}
+		args := []string{"api", "create", shellQuote(api.ApiDoc.GatewayBasePath), shellQuote(api.ApiDoc.GatewayRelPath),
+			shellQuote(api.ApiDoc.GatewayMethod), shellQuote(api.ApiDoc.Action.Name)}
+		if len(api.ApiDoc.ApiName) > 0 {
+			args = append(args, "--apiname", shellQuote(api.ApiDoc.ApiName))
+		}
+		script.wsk(append(args, "--response-type", "http")...)
+	}
+
+	_, err := io.WriteString(out, script.String())
+	return err
+}
+
+type wskScript struct {
+	bytes.Buffer
+	projectPath string
+	manifestDir string
+	files       int
+}
+
+func (script *wskScript) line(line string) {
+	script.WriteString(line + "\n")
+}
+
+func (script *wskScript) comment(format string, args ...interface{}) {
+	script.line("# " + fmt.Sprintf(format, args...))
+}
+
+func (script *wskScript) section(entity string, name string) {
+	script.line("")
+	script.comment("%s %s", entity, name)
+}
+
+func (script *wskScript) wsk(args ...string) {
+	script.line("wsk " + strings.Join(args, " "))
+}
+
+// qualified returns the name of an entity, qualified with its namespace if it
+// is deployed to another namespace than the one of the wsk CLI
+func (script *wskScript) qualified(name string, namespace string) string {
+	if len(namespace) > 0 && !strings.HasPrefix(name, "/") {
+		name = "/" + strings.TrimPrefix(namespace, "/") + "/" + name
+	}
+	return shellQuote(name)
+}
+
+// file writes the content to a file of the work directory and returns its path
+func (script *wskScript) file(name string, content string) string {
+	path := script.workFile(name)
+	delimiter := "WSKDEPLOY_EOF"
+	for strings.Contains(content, delimiter) {
+		delimiter += "_"
+	}
+	script.line("cat > " + path + " <<'" + delimiter + "'")
+	script.line(strings.TrimSuffix(content, "\n"))
+	script.line(delimiter)
+	return path
+}
+
+func (script *wskScript) workFile(name string) string {
+	script.files++
+	name = unsafeFileNameChars.ReplaceAllString(name, "_")
+	return `"` + WSK_SCRIPT_WORKDIR + "/" + strconv.Itoa(script.files) + "_" + name + `"`
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+func (script *wskScript) action(pack *whisk.Package, record utils.ActionRecord) {
+	action := record.Action
+	args := []string{"action", "update", script.qualified(pack.Name+"/"+action.Name, pack.Namespace)}
+	if code := script.actionCode(record); len(code) > 0 {
+		args = append(args, code)
+	}
+	if exec := action.Exec; exec != nil {
+		if len(exec.Image) > 0 {
+			args = append(args, "--docker", shellQuote(exec.Image))
+		} else if len(exec.Kind) > 0 {
+			args = append(args, "--kind", shellQuote(exec.Kind))
+		}
+		if len(exec.Main) > 0 {
+			args = append(args, "--main", shellQuote(exec.Main))
+		}
+	}
+	if limits := action.Limits; limits != nil {
+		if limits.Timeout != nil {
+			args = append(args, "--timeout", strconv.Itoa(*limits.Timeout))
+		}
+		if limits.Memory != nil {
+			args = append(args, "--memory", strconv.Itoa(*limits.Memory))
+		}
+		if limits.Logsize != nil {
+			args = append(args, "--logsize", strconv.Itoa(*limits.Logsize))
+		}
+	}
+	args = append(args, keyValueArgs("--param", action.Parameters)...)
+	args = append(args, keyValueArgs("--annotation", action.Annotations)...)
+	script.wsk(args...)
+}
+
+// actionCode returns the path of the code of an action, directories are zipped
+// by the script, code which is not in a file is written by the script
+func (script *wskScript) actionCode(record utils.ActionRecord) string {
+	exec := record.Action.Exec
+	if sourcePath, isDir := script.sourcePath(record.Filepath); len(sourcePath) > 0 {
+		if !isDir {
+			return shellQuote(sourcePath)
+		}
+		zipFile := script.workFile(record.Action.Name + "." + utils.ZIP_FILE_EXTENSION)
+		script.line("(cd " + shellQuote(sourcePath) + " && zip -qr " + zipFile + " .)")
+		return zipFile
+	}
+	if exec == nil || exec.Code == nil {
+		return ""
+	}
+	if exec.Binary == nil || !*exec.Binary {
+		return script.file(record.Action.Name+codeFileExtension(exec.Kind), *exec.Code)
+	}
+
+	ext := utils.ZIP_FILE_EXTENSION
+	if strings.HasPrefix(exec.Kind, "java") {
+		ext = utils.JAR_FILE_EXTENSION
+	}
+	zipFile := script.workFile(record.Action.Name + "." + ext)
+	script.line("base64 --decode > " + zipFile + " <<'WSKDEPLOY_EOF'")
+	script.line(*exec.Code)
+	script.line("WSKDEPLOY_EOF")
+	return zipFile
+}
+
+// codeFileExtension returns the extension of the source files of a kind, e.g.
+// ".js" for nodejs:6, empty if it is unknown
+func codeFileExtension(kind string) string {
+	runtime := strings.SplitN(kind, ":", 2)[0]
+	extensions := make([]string, 0)
+	for ext, extRuntime := range utils.FileExtensionRuntimeKindMap {
+		if extRuntime == runtime && ext != utils.ZIP_FILE_EXTENSION && ext != utils.JAR_FILE_EXTENSION {
+			extensions = append(extensions, ext)
+		}
+	}
+	if len(extensions) == 0 {
+		return ""
+	}
+	sort.Strings(extensions)
+	return "." + extensions[0]
+}
+
+// sourcePath returns the path of the source file or directory of an action,
+// relative to the project path if it is in the project, empty if there is none
+func (script *wskScript) sourcePath(sourcePath string) (string, bool) {
+	if len(sourcePath) == 0 || strings.HasPrefix(sourcePath, "http") {
+		return "", false
+	}
+	// the directories of actions are relative to the manifest
+	if !utils.FileExists(sourcePath) && !filepath.IsAbs(sourcePath) {
+		sourcePath = filepath.Join(script.manifestDir, sourcePath)
+	}
+	if !utils.FileExists(sourcePath) {
+		return "", false
+	}
+	isDir := utils.IsDirectory(sourcePath)
+	sourcePath, _ = filepath.Abs(sourcePath)
+	if relative, err := filepath.Rel(script.projectPath, sourcePath); err == nil && !strings.HasPrefix(relative, "..") {
+		return relative, isDir
+	}
+	return sourcePath, isDir
+}
+
+// keyValueArgs returns the parameters or annotations as wsk CLI flags, values
+// are given as JSON unless they are strings which are not valid JSON
+func keyValueArgs(flag string, keyValues whisk.KeyValueArr) []string {
+	args := make([]string, 0, 3*len(keyValues))
+	for _, keyValue := range keyValues {
+		var value string
+		if s, ok := keyValue.Value.(string); ok && !json.Valid([]byte(s)) {
+			value = s
+		} else {
+			content, _ := json.Marshal(keyValue.Value)
+			value = string(content)
+		}
+		args = append(args, flag, shellQuote(keyValue.Key), shellQuote(value))
+	}
+	return args
+}
+
+var shellSafeRegex = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// shellQuote quotes a word of a shell command
+func shellQuote(word string) string {
+	if shellSafeRegex.MatchString(word) {
+		return word
+	}
+	return "'" + strings.Replace(word, "'", `'"'"'`, -1) + "'"
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+func sortedActions(records map[string]utils.ActionRecord) []utils.ActionRecord {
+	names := make([]string, 0, len(records))
+	for name := range records {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	sorted := make([]utils.ActionRecord, 0, len(names))
+	for _, name := range names {
+		sorted = append(sorted, records[name])
+	}
+	return sorted
+}
+
+func sortedDependencies(dependencies map[string]utils.DependencyRecord) []string {
+	names := make([]string, 0, len(dependencies))
+	for name := range dependencies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedKeys returns the keys of a map of triggers, rules or APIs
+func sortedKeys(entities interface{}) []string {
+	names := make([]string, 0)
+	switch m := entities.(type) {
+	case map[string]*whisk.Trigger:
+		for name := range m {
+			names = append(names, name)
+		}
+	case map[string]*whisk.Rule:
+		for name := range m {
+			names = append(names, name)
+		}
+	case map[string]*whisk.ApiCreateRequest:
+		for name := range m {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,122 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package deployers
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, "hello/world", shellQuote("hello/world"))
+	assert.Equal(t, "'hello world'", shellQuote("hello world"))
+	assert.Equal(t, `'it'"'"'s'`, shellQuote("it's"))
+	assert.Equal(t, "''", shellQuote(""))
+}
+
+func TestKeyValueArgs(t *testing.T) {
+	args := keyValueArgs("--param", whisk.KeyValueArr{
+		{Key: "name", Value: "Amy"},
+		{Key: "count", Value: 2},
+		{Key: "flag", Value: "true"},
+		{Key: "place", Value: map[string]interface{}{"city": "Paris"}},
+	})
+	assert.Equal(t, []string{
+		"--param", "name", "Amy",
+		"--param", "count", "2",
+		// strings which are valid JSON stay strings
+		"--param", "flag", `'"true"'`,
+		"--param", "place", `'{"city":"Paris"}'`,
+	}, args)
+}
+
+func TestServiceDeployer_ExportWskScript(t *testing.T) {
+	dir, err := ioutil.TempDir("", "export")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, os.MkdirAll(filepath.Join(dir, "actions", "bye"), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "actions", "hello.js"), []byte("function main() {}"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "actions", "bye", "index.js"), []byte("function main() {}"), 0644))
+
+	deployer := NewServiceDeployer()
+	deployer.ProjectPath = dir
+	deployer.ManifestPath = filepath.Join(dir, "manifest.yaml")
+	deployer.ProjectName = "greetings"
+	deployer.RootPackageName = "hello"
+	deployer.ClientConfig = &whisk.Config{Namespace: "guest", Host: "openwhisk.example.com"}
+
+	code := "function main() { return {} }"
+	timeout := 30000
+	pack := NewDeploymentPackage()
+	pack.Package = &whisk.Package{Name: "hello", Parameters: whisk.KeyValueArr{{Key: "greeting", Value: "Hello"}}}
+	pack.Dependencies["utils"] = utils.DependencyRecord{Location: "/whisk.system/utils", IsBinding: true}
+	pack.Actions["world"] = utils.ActionRecord{Filepath: filepath.Join(dir, "actions", "hello.js"),
+		Action: &whisk.Action{Name: "world", Exec: &whisk.Exec{Kind: "nodejs:6", Code: &code},
+			Limits: &whisk.Limits{Timeout: &timeout},
+			Annotations: whisk.KeyValueArr{{Key: "web-export", Value: true}}}}
+	// directories are relative to the manifest
+	pack.Actions["bye"] = utils.ActionRecord{Filepath: "actions/bye",
+		Action: &whisk.Action{Name: "bye", Exec: &whisk.Exec{Kind: "nodejs:6"}}}
+	pack.Actions["inline"] = utils.ActionRecord{
+		Action: &whisk.Action{Name: "inline", Exec: &whisk.Exec{Kind: "nodejs:6", Code: &code}}}
+	pack.Sequences["greet"] = utils.ActionRecord{Action: &whisk.Action{Name: "greet",
+		Exec: &whisk.Exec{Kind: "sequence", Components: []string{"/guest/hello/world", "/guest/hello/bye"}}}}
+	deployer.Deployment.Packages["hello"] = pack
+	deployer.Deployment.Triggers["everyhour"] = &whisk.Trigger{Name: "everyhour",
+		Annotations: whisk.KeyValueArr{{Key: "feed", Value: "/whisk.system/alarms/alarm"}},
+		Parameters:  whisk.KeyValueArr{{Key: "cron", Value: "0 * * * *"}}}
+	deployer.Deployment.Triggers["manual"] = &whisk.Trigger{Name: "manual", Namespace: "tenant1"}
+	deployer.Deployment.Rules["greet_hourly"] = &whisk.Rule{Name: "greet_hourly", Trigger: "everyhour", Action: "greet"}
+
+	out := new(bytes.Buffer)
+	assert.Nil(t, deployer.ExportWskScript(out))
+	script := out.String()
+
+	assert.True(t, strings.HasPrefix(script, "#!/bin/sh\n"))
+	expected := []string{
+		"wsk package update hello --param greeting Hello",
+		"wsk package bind /whisk.system/utils utils",
+		`(cd actions/bye && zip -qr "$WORKDIR/1_bye.zip" .)`,
+		`wsk action update hello/bye "$WORKDIR/1_bye.zip" --kind nodejs:6`,
+		`wsk action update hello/inline "$WORKDIR/2_inline" --kind nodejs:6`,
+		"wsk action update hello/world actions/hello.js --kind nodejs:6 --timeout 30000 --annotation web-export true",
+		"wsk action update hello/greet --sequence /guest/hello/world,/guest/hello/bye",
+		"wsk trigger delete everyhour >/dev/null 2>&1 || true",
+		"wsk trigger create everyhour --feed /whisk.system/alarms/alarm --param cron '0 * * * *'",
+		"wsk trigger update /tenant1/manual",
+		"wsk rule update greet_hourly /guest/everyhour /guest/hello/greet",
+	}
+	position := 0
+	for _, line := range expected {
+		index := strings.Index(script[position:], line+"\n")
+		if assert.True(t, index >= 0, "missing or out of order: "+line+"\n"+script) {
+			position += index + len(line)
+		}
+	}
+	assert.Contains(t, script, "cat > \"$WORKDIR/2_inline\" <<'WSKDEPLOY_EOF'\n"+code+"\nWSKDEPLOY_EOF\n")
+}
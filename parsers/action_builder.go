@@ -0,0 +1,568 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// ActionBuildStep is one step of building the whisk action of an action of
+// the manifest, see ActionBuilder
+type ActionBuildStep func(builder *ActionBuilder) error
+
+// ActionBuildHooks are run by every ActionBuilder after its own steps, in
+// order, e.g. to set the code of actions built by other tools. A hook may
+// change the whisk action or fail the build.
+var ActionBuildHooks []ActionBuildStep
+
+// ActionBuilder builds the whisk action of an action of the manifest: its
+// code, runtime, parameters, annotations, web export and limits, one step
+// at a time. Each step is a method which may be run on its own.
+type ActionBuilder struct {
+	FilePath    string // the manifest the action is read from
+	PackageName string
+	Name        string
+	Action      Action
+	Managed     whisk.KeyValue // the managed annotation, added with --managed
+	Flags       *utils.WskdeployFlags
+
+	WskAction *whisk.Action
+	// extension of the file of the action without the ".", empty for
+	// directories and inline code
+	Ext                string
+	Web                string
+	WebSecretGenerated bool
+	// the temporary directory the code of a function given by URL is
+	// downloaded to, and the path of its file, see ResolveCode()
+	downloadDir string
+	codePath    string
+	// annotations of the manifest, the ones a web action is exported with
+	annotations whisk.KeyValueArr
+}
+
+func NewActionBuilder(filePath string, packageName string, name string, action Action, ma whisk.KeyValue, flags *utils.WskdeployFlags) *ActionBuilder {
+	// set the name of the action (which is the key)
+	action.Name = name
+	wskaction := new(whisk.Action)
+	wskaction.Exec = new(whisk.Exec)
+	return &ActionBuilder{FilePath: filePath, PackageName: packageName, Name: name, Action: action,
+		Managed: ma, Flags: flags, WskAction: wskaction}
+}
+
+// Steps returns the steps of the builder followed by ActionBuildHooks
+func (builder *ActionBuilder) Steps() []ActionBuildStep {
+	steps := []ActionBuildStep{
+		(*ActionBuilder).ResolveRuntimeKind,
+		(*ActionBuilder).ResolveCode,
+		(*ActionBuilder).ResolveRuntime,
+		(*ActionBuilder).ResolveMain,
+		(*ActionBuilder).CheckJavaMain,
+		(*ActionBuilder).ResolveParameters,
+		(*ActionBuilder).ResolveAnnotations,
+		(*ActionBuilder).ResolveWeb,
+		(*ActionBuilder).ResolveLimits,
+		(*ActionBuilder).ResolveProvider,
+		(*ActionBuilder).CheckDelAnnotations,
+	}
+	return append(steps, ActionBuildHooks...)
+}
+
+// Build runs the steps of the builder and returns the record of the action
+func (builder *ActionBuilder) Build() (utils.ActionRecord, error) {
+	defer builder.RemoveDownload()
+	for _, step := range builder.Steps() {
+		if err := step(builder); err != nil {
+			return utils.ActionRecord{}, err
+		}
+	}
+	builder.WskAction.Name = builder.Name
+	pub := false
+	builder.WskAction.Publish = &pub
+
+	return utils.ActionRecord{Action: builder.WskAction, Packagename: builder.PackageName,
+		Filepath: builder.Action.Function, WebSecretGenerated: builder.WebSecretGenerated,
+		DelAnnotations: builder.Action.DelAnnotations}, nil
+}
+
+// RemoveDownload removes the code of the action downloaded from the URL of its
+// function, if any
+func (builder *ActionBuilder) RemoveDownload() {
+	if len(builder.downloadDir) > 0 {
+		os.RemoveAll(builder.downloadDir)
+		builder.downloadDir = ""
+	}
+}
+
+func (builder *ActionBuilder) manifestFileName() string {
+	splitFilePath := strings.Split(builder.FilePath, string(os.PathSeparator))
+	return splitFilePath[len(splitFilePath)-1]
+}
+
+func (builder *ActionBuilder) invalidRuntimeError(errMessage string, runtime string) error {
+	return wskderrors.NewInvalidRuntimeError(errMessage, builder.manifestFileName(), builder.Name, runtime,
+		utils.ListOfSupportedRuntimes(utils.SupportedRunTimes))
+}
+
+// ResolveCode reads the code of the action from its function, a file or a
+// directory which is zipped, relative to the manifest, or the http(s) URL of a
+// file, e.g. an artifact built by CI, which is downloaded first and checked
+// against the sha256 checksum of the action, if any. Code which needs to be
+// built, see utils.CodeBuilders, is built first.
+func (builder *ActionBuilder) ResolveCode() error {
+	action := &builder.Action
+
+	//set action.Function to action.Location
+	//because Location is deprecated in Action entity
+	if action.Location != "" {
+		Deprecations.Add(DeprecatedKey{FilePath: builder.FilePath, FileType: FILE_TYPE_MANIFEST,
+			EntityType: YAML_KEY_ACTION, EntityName: builder.Name, OldKey: YAML_KEY_LOCATION, NewKey: YAML_KEY_FUNCTION})
+	}
+	if action.Function == "" && action.Location != "" {
+		action.Function = action.Location
+	}
+	if action.Function == "" {
+		return nil
+	}
+	if len(action.Build) > 0 {
+		if err := utils.RunBuildCommand(path.Join(builder.PackageName, builder.Name), action.Build, filepath.Dir(builder.FilePath)); err != nil {
+			return err
+		}
+	}
+
+	filePath := strings.TrimRight(builder.FilePath, builder.manifestFileName()) + action.Function
+	remote := utils.IsRemoteActionCode(action.Function)
+	if remote {
+		dir, downloaded, err := utils.DownloadActionCode(path.Join(builder.PackageName, builder.Name), action.Function, action.Sha256)
+		if err != nil {
+			return err
+		}
+		builder.RemoveDownload()
+		builder.downloadDir, filePath = dir, downloaded
+	}
+	builder.codePath = filePath
+	// code which needs to be built, e.g. TypeScript compiled to JavaScript or
+	// the dependencies of a Python action, is built before it is deployed
+	if codeBuilder := utils.FindCodeBuilder(filePath, action.Runtime); codeBuilder != nil {
+		return builder.resolveBuiltCode(codeBuilder, filePath)
+	}
+	if utils.IsDirectory(filePath) {
+		// TODO() define ext as const
+		zipName := filePath + ".zip"
+		err := utils.NewZipWritter(filePath, zipName).Zip()
+		if err != nil {
+			return err
+		}
+		// the code of the zip file is read by GetExec, it is removed once read
+		defer os.Remove(zipName)
+		if err := builder.Flags.ScanActionArtifact(path.Join(builder.PackageName, builder.Name), zipName); err != nil {
+			return err
+		}
+		// TODO(): support docker and main entry as did by go cli?
+		builder.WskAction.Exec, err = utils.GetExec(zipName, action.Runtime, false, "", builder.Flags.MaxActionCodeSize())
+		return err
+	}
+
+	ext := path.Ext(filePath)
+	// drop the "." from file extension
+	if len(ext) > 0 && ext[0] == '.' {
+		ext = ext[1:]
+	}
+	builder.Ext = ext
+
+	// determine default runtime for the given file extension
+	kind := utils.DefaultRunTimes[utils.FileExtensionRuntimeKindMap[ext]]
+
+	// produce an error when a runtime could not be derived from the action file extension
+	// and its not explicitly specified in the manifest YAML file
+	// and action source is not a zip file
+	if len(kind) == 0 && len(action.Runtime) == 0 && ext != utils.ZIP_FILE_EXTENSION {
+		return builder.invalidRuntimeError(wski18n.T(wski18n.ID_ERR_RUNTIME_NOT_DISCOVERED_X_action_X,
+			map[string]interface{}{wski18n.KEY_ACTION: builder.Name}), wski18n.T(wski18n.ID_MSG_RUNTIME_NOT_SPECIFIED))
+	}
+
+	builder.WskAction.Exec.Kind = kind
+
+	// the URL of a function is kept, it names the code of the action
+	if !remote {
+		action.Function = filePath
+	}
+	binary := ext == utils.ZIP_FILE_EXTENSION || ext == utils.JAR_FILE_EXTENSION
+	code, err := utils.ReadActionCode(filePath, binary, builder.Flags.MaxActionCodeSize())
+	if err != nil {
+		return err
+	}
+	if err := builder.Flags.ScanActionArtifact(path.Join(builder.PackageName, builder.Name), filePath); err != nil {
+		return err
+	}
+	if ext == utils.ZIP_FILE_EXTENSION && len(action.Runtime) == 0 {
+		return builder.invalidRuntimeError(wski18n.T(wski18n.ID_ERR_RUNTIME_MISSING_ZIP_X_action_X,
+			map[string]interface{}{wski18n.KEY_ACTION: builder.Name}), wski18n.T(wski18n.ID_MSG_RUNTIME_NOT_SPECIFIED))
+	}
+	builder.WskAction.Exec.Code = &code
+	return nil
+}
+
+// resolveBuiltCode builds the code of the action with the builder and reads the
+// code built, an action without runtime gets the default kind of the runtime
+// of the builder
+func (builder *ActionBuilder) resolveBuiltCode(codeBuilder utils.CodeBuilder, filePath string) error {
+	action := &builder.Action
+	kind := action.Runtime
+	if len(kind) == 0 {
+		kind = utils.DefaultRunTimes[codeBuilder.Language()]
+	}
+	if len(kind) == 0 {
+		return builder.invalidRuntimeError(wski18n.T(wski18n.ID_ERR_RUNTIME_NOT_DISCOVERED_X_action_X,
+			map[string]interface{}{wski18n.KEY_ACTION: builder.Name}), wski18n.T(wski18n.ID_MSG_RUNTIME_NOT_SPECIFIED))
+	}
+
+	name := path.Join(builder.PackageName, builder.Name)
+	built, buildDir, err := utils.BuildCode(codeBuilder, name, filePath, action.Main, builder.Flags.BuildImage)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(buildDir)
+	if err := builder.Flags.ScanActionArtifact(name, built); err != nil {
+		return err
+	}
+	builder.Ext = strings.TrimPrefix(filepath.Ext(built), ".")
+	builder.WskAction.Exec, err = utils.GetExec(built, kind, false, "", builder.Flags.MaxActionCodeSize())
+	return err
+}
+
+// CheckCode checks, without building or reading it, that the code of the
+// action exists and that its runtime is supported, see ResolveCode() and
+// ResolveRuntime(). The code of an action with a build command is not checked,
+// it may not be built yet.
+func (builder *ActionBuilder) CheckCode() error {
+	action := builder.Action
+	function := action.Function
+	if function == "" {
+		function = action.Location
+	}
+	if function == "" || len(action.Build) > 0 || strings.HasPrefix(function, "http") {
+		return nil
+	}
+
+	filePath := filepath.Join(filepath.Dir(builder.FilePath), function)
+	if !utils.FileExists(filePath) {
+		return wskderrors.NewFileReadError(filePath, wski18n.T(wski18n.ID_ERR_ACTION_FUNCTION_NOT_FOUND_X_action_X_path_X,
+			map[string]interface{}{wski18n.KEY_ACTION: path.Join(builder.PackageName, builder.Name), wski18n.KEY_PATH: function}))
+	}
+
+	if len(action.Runtime) > 0 {
+		if !utils.CheckExistRuntime(action.Runtime, utils.SupportedRunTimes) {
+			return builder.invalidRuntimeError(wski18n.T(wski18n.ID_MSG_RUNTIME_UNSUPPORTED_X_runtime_X_action_X,
+				map[string]interface{}{"runtime": action.Runtime, "action": builder.Name}), action.Runtime)
+		}
+		return nil
+	}
+	if codeBuilder := utils.FindCodeBuilder(filePath, ""); codeBuilder != nil {
+		if len(utils.DefaultRunTimes[codeBuilder.Language()]) == 0 {
+			return builder.invalidRuntimeError(wski18n.T(wski18n.ID_ERR_RUNTIME_NOT_DISCOVERED_X_action_X,
+				map[string]interface{}{wski18n.KEY_ACTION: builder.Name}), wski18n.T(wski18n.ID_MSG_RUNTIME_NOT_SPECIFIED))
+		}
+		return nil
+	}
+	if utils.IsDirectory(filePath) {
+		return nil
+	}
+	ext := strings.TrimPrefix(path.Ext(filePath), ".")
+	if ext == utils.ZIP_FILE_EXTENSION {
+		return builder.invalidRuntimeError(wski18n.T(wski18n.ID_ERR_RUNTIME_MISSING_ZIP_X_action_X,
+			map[string]interface{}{wski18n.KEY_ACTION: builder.Name}), wski18n.T(wski18n.ID_MSG_RUNTIME_NOT_SPECIFIED))
+	}
+	if len(utils.DefaultRunTimes[utils.FileExtensionRuntimeKindMap[ext]]) == 0 {
+		return builder.invalidRuntimeError(wski18n.T(wski18n.ID_ERR_RUNTIME_NOT_DISCOVERED_X_action_X,
+			map[string]interface{}{wski18n.KEY_ACTION: builder.Name}), wski18n.T(wski18n.ID_MSG_RUNTIME_NOT_SPECIFIED))
+	}
+	return nil
+}
+
+// ResolveRuntimeKind resolves the name of a runtime, e.g. "nodejs", and its
+// runtime_version to the newest kind supported by the OpenWhisk server, see
+// utils.ResolveRuntimeKind(). Deprecated kinds are kept with a warning.
+func (builder *ActionBuilder) ResolveRuntimeKind() error {
+	action := &builder.Action
+	if len(action.Runtime) == 0 {
+		if len(action.RuntimeVersion) > 0 {
+			return wskderrors.NewYAMLFileFormatError(builder.FilePath,
+				wski18n.T(wski18n.ID_ERR_RUNTIME_VERSION_WITHOUT_RUNTIME_X_action_X,
+					map[string]interface{}{wski18n.KEY_ACTION: builder.Name}))
+		}
+		return nil
+	}
+	if len(action.RuntimeVersion) > 0 && strings.Contains(action.Runtime, ":") {
+		return wskderrors.NewYAMLFileFormatError(builder.FilePath,
+			wski18n.T(wski18n.ID_ERR_RUNTIME_VERSION_WITH_KIND_X_runtime_X_action_X,
+				map[string]interface{}{wski18n.KEY_RUNTIME: action.Runtime, wski18n.KEY_ACTION: builder.Name}))
+	}
+
+	kind, deprecated := utils.ResolveRuntimeKind(action.Runtime, action.RuntimeVersion)
+	if len(kind) == 0 {
+		errMessage := wski18n.T(wski18n.ID_ERR_RUNTIME_VERSION_NOT_FOUND_X_runtime_X_version_X_action_X,
+			map[string]interface{}{wski18n.KEY_RUNTIME: action.Runtime, wski18n.KEY_VERSION: action.RuntimeVersion,
+				wski18n.KEY_ACTION: builder.Name})
+		return builder.invalidRuntimeError(errMessage, action.Runtime+":"+action.RuntimeVersion)
+	}
+	if deprecated {
+		wskprint.PrintOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_RUNTIME_DEPRECATED_X_runtime_X_action_X,
+			map[string]interface{}{wski18n.KEY_RUNTIME: kind, wski18n.KEY_ACTION: builder.Name}))
+	}
+	if kind != action.Runtime {
+		whisk.Debug(whisk.DbgInfo, wski18n.T(wski18n.ID_MSG_RUNTIME_RESOLVED_X_runtime_X_kind_X_action_X,
+			map[string]interface{}{wski18n.KEY_RUNTIME: action.Runtime, wski18n.KEY_KIND: kind,
+				wski18n.KEY_ACTION: builder.Name}))
+		action.Runtime = kind
+	}
+	return nil
+}
+
+/*
+ *  ResolveRuntime performs few checks if action runtime is specified in manifest YAML file
+ *  (1) Check if specified runtime is one of the supported runtimes by OpenWhisk server
+ *  (2) Check if specified runtime is consistent with action source file extensions
+ *  Set the action runtime to match with the source file extension, if wskdeploy is not invoked in strict mode
+ */
+func (builder *ActionBuilder) ResolveRuntime() error {
+	action := builder.Action
+	wskaction := builder.WskAction
+	if action.Runtime == "" {
+		return nil
+	}
+
+	if !utils.CheckExistRuntime(action.Runtime, utils.SupportedRunTimes) {
+		errStr := wski18n.T(wski18n.ID_MSG_RUNTIME_UNSUPPORTED_X_runtime_X_action_X,
+			map[string]interface{}{"runtime": action.Runtime, "action": action.Name})
+		whisk.Debug(whisk.DbgWarn, errStr)
+		if builder.Ext == utils.ZIP_FILE_EXTENSION {
+			// for zip action, error out if specified runtime is not supported by OpenWhisk server
+			return builder.invalidRuntimeError(wski18n.T(wski18n.ID_ERR_RUNTIME_UNSUPPORTED_ZIP_X_runtime_X_action_X,
+				map[string]interface{}{wski18n.KEY_RUNTIME: action.Runtime, wski18n.KEY_ACTION: action.Name}), action.Runtime)
+		}
+		errStr = wski18n.T(wski18n.ID_MSG_RUNTIME_CHANGED_X_runtime_X_action_X,
+			map[string]interface{}{"runtime": wskaction.Exec.Kind, "action": action.Name})
+		whisk.Debug(whisk.DbgWarn, errStr)
+		return nil
+	}
+
+	// for zip actions, rely on the runtimes from the manifest file as it can not be derived from the action source file extension
+	// pick runtime from manifest file if its supported by OpenWhisk server
+	if builder.Ext == utils.ZIP_FILE_EXTENSION || utils.CheckRuntimeConsistencyWithFileExtension(builder.Ext, action.Runtime) {
+		wskaction.Exec.Kind = action.Runtime
+		return nil
+	}
+
+	errStr := wski18n.T(wski18n.ID_MSG_RUNTIME_MISMATCH_X_runtime_X_ext_X_action_X,
+		map[string]interface{}{"runtime": action.Runtime, "ext": builder.Ext, "action": action.Name})
+	wskprint.PrintOpenWhiskWarning(errStr)
+
+	// even if runtime is not consistent with file extension, deploy action with specified runtime in strict mode
+	if builder.Flags.Strict {
+		wskaction.Exec.Kind = action.Runtime
+	} else {
+		errStr := wski18n.T(wski18n.ID_MSG_RUNTIME_CHANGED_X_runtime_X_action_X,
+			map[string]interface{}{"runtime": wskaction.Exec.Kind, "action": action.Name})
+		wskprint.PrintOpenWhiskWarning(errStr)
+	}
+	return nil
+}
+
+// ResolveMain sets the name of the action entry point, if any
+func (builder *ActionBuilder) ResolveMain() error {
+	if builder.Action.Main != "" {
+		builder.WskAction.Exec.Main = builder.Action.Main
+	}
+	return nil
+}
+
+// CheckJavaMain checks that the main class of a jar action is in the jar, the
+// Main-Class of the manifest of the jar is the main class when main is not
+// given
+func (builder *ActionBuilder) CheckJavaMain() error {
+	jarPath := builder.Action.Function
+	if len(builder.codePath) > 0 {
+		jarPath = builder.codePath
+	}
+	if builder.Ext != utils.JAR_FILE_EXTENSION || utils.IsRemoteActionCode(jarPath) {
+		return nil
+	}
+	classes, mainClass, err := utils.ReadJarClasses(jarPath)
+	if err != nil {
+		return err
+	}
+	main := builder.WskAction.Exec.Main
+	if len(main) == 0 {
+		if len(mainClass) == 0 {
+			return wskderrors.NewYAMLFileFormatError(builder.FilePath,
+				wski18n.T(wski18n.ID_ERR_JAVA_MAIN_MISSING_X_action_X_path_X,
+					map[string]interface{}{wski18n.KEY_ACTION: builder.Name, wski18n.KEY_PATH: builder.Action.Function}))
+		}
+		main = mainClass
+		builder.WskAction.Exec.Main = main
+	}
+	if !classes[utils.JavaMainClass(main)] {
+		return wskderrors.NewYAMLFileFormatError(builder.FilePath,
+			wski18n.T(wski18n.ID_ERR_JAVA_MAIN_NOT_FOUND_X_action_X_main_X_path_X,
+				map[string]interface{}{wski18n.KEY_ACTION: builder.Name, wski18n.KEY_MAIN: utils.JavaMainClass(main),
+					wski18n.KEY_PATH: builder.Action.Function}))
+	}
+	return nil
+}
+
+// ResolveProvider adapts the action to the provider selected with
+// --provider: the kind of its runtime, if the provider does not serve it, and
+// the keys of its annotations
+func (builder *ActionBuilder) ResolveProvider() error {
+	provider, err := builder.Flags.GetProvider()
+	if err != nil || provider == nil {
+		return err
+	}
+	if kind := builder.WskAction.Exec.Kind; len(kind) > 0 && provider.RuntimeKind(kind) != kind {
+		builder.WskAction.Exec.Kind = provider.RuntimeKind(kind)
+		wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_MSG_PROVIDER_RUNTIME_X_action_X_kind_X_runtime_X_name_X,
+			map[string]interface{}{wski18n.KEY_ACTION: builder.Name, wski18n.KEY_KIND: kind,
+				wski18n.KEY_RUNTIME: builder.WskAction.Exec.Kind, wski18n.KEY_NAME: provider.Name}))
+	}
+	builder.WskAction.Annotations = provider.RenameAnnotations(builder.WskAction.Annotations)
+	return nil
+}
+
+// ResolveParameters resolves the inputs of the action, inputs without a
+// value are left out. Outputs are resolved for their errors only.
+func (builder *ActionBuilder) ResolveParameters() error {
+	parameters, err := builder.resolveInputs(builder.Action.Inputs)
+	if err != nil {
+		return err
+	}
+	// if we have successfully parser valid key/value parameters
+	if len(parameters) > 0 {
+		builder.WskAction.Parameters = parameters
+	}
+
+	// TODO{} add outputs as annotations (work to discuss officially supporting for compositions)
+	_, err = builder.resolveInputs(builder.Action.Outputs)
+	return err
+}
+
+func (builder *ActionBuilder) resolveInputs(inputs map[string]Parameter) (whisk.KeyValueArr, error) {
+	keyValArr := make(whisk.KeyValueArr, 0)
+	for name, param := range inputs {
+		var keyVal whisk.KeyValue
+		var err error
+		keyVal.Key = name
+		keyVal.Value, err = ResolveParameter(name, &param, builder.FilePath, builder.Flags.SecretsFromEnv)
+		// short circuit on error
+		if err != nil {
+			return nil, err
+		}
+		if keyVal.Value != nil {
+			keyValArr = append(keyValArr, keyVal)
+		}
+	}
+	return keyValArr, nil
+}
+
+// ResolveAnnotations adds the annotations of the action, and the managed
+// annotation if its marked as managed deployment
+func (builder *ActionBuilder) ResolveAnnotations() error {
+	builder.annotations = make(whisk.KeyValueArr, 0)
+	for name, value := range builder.Action.Annotations {
+		var keyVal whisk.KeyValue
+		keyVal.Key = name
+		keyVal.Value = ResolveAnnotation(value)
+		builder.annotations = append(builder.annotations, keyVal)
+	}
+	builder.annotations = AddEncryptedInputs(builder.annotations, builder.Action.Inputs)
+	if len(builder.annotations) > 0 {
+		builder.WskAction.Annotations = append(builder.WskAction.Annotations, builder.annotations...)
+	}
+	if builder.Flags.Managed {
+		builder.WskAction.Annotations = append(builder.WskAction.Annotations, builder.Managed)
+	}
+	return nil
+}
+
+// ResolveWeb adds the web export annotations of the action, and the
+// require-whisk-auth annotation of web-secure
+func (builder *ActionBuilder) ResolveWeb() error {
+	action := builder.Action
+	wskaction := builder.WskAction
+	var err error
+
+	builder.Web = action.Web
+	if action.Webexport != "" {
+		Deprecations.Add(DeprecatedKey{FilePath: builder.FilePath, FileType: FILE_TYPE_MANIFEST,
+			EntityType: YAML_KEY_ACTION, EntityName: builder.Name, OldKey: YAML_KEY_WEB_EXPORT, NewKey: YAML_KEY_WEB})
+		if builder.Web == "" {
+			builder.Web = action.Webexport
+		}
+	}
+	// TODO() add boolean value const
+	if builder.Web == "true" {
+		wskaction.Annotations, err = utils.WebAction("yes", builder.annotations, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if action.WebSecure == nil {
+		return nil
+	}
+	if builder.Web != "true" {
+		return wskderrors.NewYAMLFileFormatError(builder.FilePath,
+			wski18n.T(wski18n.ID_ERR_WEB_SECURE_REQUIRES_WEB_X_action_X,
+				map[string]interface{}{wski18n.KEY_ACTION: builder.Name}))
+	}
+	wskaction.Annotations, _, builder.WebSecretGenerated, err = utils.WebSecure(action.WebSecure, wskaction.Annotations)
+	if err != nil {
+		return wskderrors.NewYAMLFileFormatError(builder.FilePath,
+			wski18n.T(wski18n.ID_ERR_WEB_SECURE_INVALID_X_action_X_value_X,
+				map[string]interface{}{wski18n.KEY_ACTION: builder.Name, wski18n.KEY_VALUE: action.WebSecure}))
+	}
+	return nil
+}
+
+// ResolveLimits sets the valid limits of the action, see ResolveLimits()
+func (builder *ActionBuilder) ResolveLimits() error {
+	if limits := ResolveLimits(builder.Action.Limits); limits != nil {
+		builder.WskAction.Limits = limits
+	}
+	return nil
+}
+
+// CheckDelAnnotations checks an annotation is either set or removed
+func (builder *ActionBuilder) CheckDelAnnotations() error {
+	for _, name := range builder.Action.DelAnnotations {
+		if builder.WskAction.Annotations.FindKeyValue(name) >= 0 {
+			return wskderrors.NewYAMLFileFormatError(builder.FilePath,
+				wski18n.T(wski18n.ID_ERR_ANNOTATION_SET_AND_DELETED_X_key_X_action_X,
+					map[string]interface{}{wski18n.KEY_KEY: name, wski18n.KEY_ACTION: builder.Name}))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+// the manifest itself is not read, the function of actions is relative to it
+const TEST_BUILDER_MANIFEST = "../tests/dat/manifest_action_builder.yaml"
+
+func newTestActionBuilder(action Action) *ActionBuilder {
+	return NewActionBuilder(TEST_BUILDER_MANIFEST, "helloworld", "hello", action, whisk.KeyValue{}, &utils.Flags)
+}
+
+func TestActionBuilder_ResolveCode(t *testing.T) {
+	builder := newTestActionBuilder(Action{Function: "actions/hello.js"})
+	assert.Nil(t, builder.ResolveCode())
+	assert.Equal(t, "js", builder.Ext)
+	assert.Equal(t, "nodejs:6", builder.WskAction.Exec.Kind)
+	assert.NotNil(t, builder.WskAction.Exec.Code)
+	assert.Equal(t, "../tests/dat/actions/hello.js", builder.Action.Function)
+
+	builder = newTestActionBuilder(Action{Function: "actions/hello.unknown"})
+	assert.IsType(t, &wskderrors.InvalidRuntimeError{}, builder.ResolveCode(), "no runtime for the extension")
+
+	builder = newTestActionBuilder(Action{})
+	assert.Nil(t, builder.ResolveCode())
+	assert.Nil(t, builder.WskAction.Exec.Code, "actions without a function have no code")
+}
+
+func TestActionBuilder_ResolveRuntime(t *testing.T) {
+	builder := newTestActionBuilder(Action{Function: "actions/hello.js", Runtime: "nodejs:8"})
+	assert.Nil(t, builder.ResolveCode())
+	assert.Nil(t, builder.ResolveRuntime())
+	assert.Equal(t, "nodejs:8", builder.WskAction.Exec.Kind)
+
+	builder = newTestActionBuilder(Action{Function: "actions/hello.js", Runtime: "python"})
+	assert.Nil(t, builder.ResolveCode())
+	assert.Nil(t, builder.ResolveRuntime())
+	assert.Equal(t, "nodejs:6", builder.WskAction.Exec.Kind, "the runtime of the extension is kept")
+
+	builder = newTestActionBuilder(Action{Runtime: "unknown:1"})
+	builder.Ext = utils.ZIP_FILE_EXTENSION
+	assert.IsType(t, &wskderrors.InvalidRuntimeError{}, builder.ResolveRuntime())
+}
+
+func TestActionBuilder_ResolveRuntimeKind(t *testing.T) {
+	builder := newTestActionBuilder(Action{Function: "actions/hello.js", Runtime: "nodejs", RuntimeVersion: "8"})
+	assert.Nil(t, builder.ResolveRuntimeKind())
+	assert.Equal(t, "nodejs:8", builder.Action.Runtime)
+
+	builder = newTestActionBuilder(Action{Runtime: "nodejs"})
+	assert.Nil(t, builder.ResolveRuntimeKind())
+	assert.Equal(t, "nodejs:8", builder.Action.Runtime, "the newest kind of the runtime")
+
+	builder = newTestActionBuilder(Action{Runtime: "nodejs", RuntimeVersion: "99"})
+	assert.IsType(t, &wskderrors.InvalidRuntimeError{}, builder.ResolveRuntimeKind())
+
+	builder = newTestActionBuilder(Action{Runtime: "nodejs:6", RuntimeVersion: "8"})
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, builder.ResolveRuntimeKind())
+
+	builder = newTestActionBuilder(Action{RuntimeVersion: "8"})
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, builder.ResolveRuntimeKind())
+}
+
+func TestActionBuilder_ResolveParameters(t *testing.T) {
+	builder := newTestActionBuilder(Action{Inputs: map[string]Parameter{
+		"name":  {Type: "string", Value: "Amy"},
+		"count": {Type: "integer", Value: 2},
+	}})
+	assert.Nil(t, builder.ResolveParameters())
+	assert.Equal(t, "Amy", builder.WskAction.Parameters.GetValue("name"))
+	assert.Equal(t, 2, builder.WskAction.Parameters.GetValue("count"))
+
+	builder = newTestActionBuilder(Action{})
+	assert.Nil(t, builder.ResolveParameters())
+	assert.Nil(t, builder.WskAction.Parameters, "actions without inputs have no parameters")
+}
+
+func TestActionBuilder_ResolveAnnotationsAndWeb(t *testing.T) {
+	builder := newTestActionBuilder(Action{Web: "true", WebSecure: true,
+		Annotations: map[string]interface{}{"owner": "amy"}})
+	assert.Nil(t, builder.ResolveAnnotations())
+	assert.Nil(t, builder.ResolveWeb())
+	assert.Equal(t, "amy", builder.WskAction.Annotations.GetValue("owner"))
+	assert.Equal(t, true, builder.WskAction.Annotations.GetValue("web-export"))
+	assert.NotNil(t, builder.WskAction.Annotations.GetValue("require-whisk-auth"))
+	assert.True(t, builder.WebSecretGenerated)
+
+	builder = newTestActionBuilder(Action{WebSecure: true})
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, builder.ResolveWeb(), "web-secure requires web")
+}
+
+func TestActionBuilder_ResolveLimits(t *testing.T) {
+	timeout, memory := 60000, 1
+	builder := newTestActionBuilder(Action{Limits: &Limits{Timeout: &timeout, Memory: &memory}})
+	assert.Nil(t, builder.ResolveLimits())
+	assert.Equal(t, timeout, *builder.WskAction.Limits.Timeout)
+	assert.Nil(t, builder.WskAction.Limits.Memory, "invalid limits are ignored")
+}
+
+func TestActionBuilder_CheckDelAnnotations(t *testing.T) {
+	builder := newTestActionBuilder(Action{DelAnnotations: []string{"owner"}})
+	builder.WskAction.Annotations = whisk.KeyValueArr{{Key: "owner", Value: "amy"}}
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, builder.CheckDelAnnotations())
+}
+
+func TestActionBuilder_Build(t *testing.T) {
+	defer func(hooks []ActionBuildStep) { ActionBuildHooks = hooks }(ActionBuildHooks)
+	ActionBuildHooks = append(ActionBuildHooks, func(builder *ActionBuilder) error {
+		builder.WskAction.Exec.Main = "hook"
+		return nil
+	})
+
+	record, err := newTestActionBuilder(Action{Function: "actions/hello.js", Main: "hello"}).Build()
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", record.Action.Name)
+	assert.Equal(t, "helloworld", record.Packagename)
+	assert.Equal(t, "hook", record.Action.Exec.Main, "hooks run after the steps")
+	assert.False(t, *record.Action.Publish)
+
+	ActionBuildHooks = append(ActionBuildHooks, func(builder *ActionBuilder) error {
+		return errors.New("failed")
+	})
+	_, err = newTestActionBuilder(Action{Function: "actions/hello.js"}).Build()
+	assert.NotNil(t, err)
+}
+
+func TestActionBuilder_ResolveCode_Build(t *testing.T) {
+	builder := newTestActionBuilder(Action{Function: "actions/hello.js", Build: "true"})
+	assert.Nil(t, builder.ResolveCode())
+	assert.NotNil(t, builder.WskAction.Exec.Code)
+
+	builder = newTestActionBuilder(Action{Function: "actions/hello.js", Build: "false"})
+	assert.IsType(t, &wskderrors.CommandError{}, builder.ResolveCode(), "the code is not read when the build fails")
+	assert.Nil(t, builder.WskAction.Exec.Code)
+}
+
+func TestActionBuilder_ResolveCode_URL(t *testing.T) {
+	code := "function main() { return {}; }"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(code))
+	}))
+	defer server.Close()
+
+	function := server.URL + "/builds/42/hello.js"
+	builder := newTestActionBuilder(Action{Function: function})
+	assert.Nil(t, builder.ResolveCode())
+	assert.Equal(t, code, *builder.WskAction.Exec.Code)
+	assert.Equal(t, "js", builder.Ext)
+	assert.Equal(t, function, builder.Action.Function, "the URL names the code of the action")
+	builder.RemoveDownload()
+	assert.False(t, utils.FileExists(builder.codePath), "the code is removed once the action is built")
+
+	builder = newTestActionBuilder(Action{Function: function, Sha256: "0123"})
+	assert.IsType(t, &wskderrors.FileReadError{}, builder.ResolveCode())
+	assert.Nil(t, builder.WskAction.Exec.Code)
+}
+
+func TestActionBuilder_CheckJavaMain(t *testing.T) {
+	jar := "../tests/dat/actions/hello.jar"
+	for _, main := range []string{"Hello", "Hello#main"} {
+		builder := newTestActionBuilder(Action{Function: jar})
+		builder.Ext = utils.JAR_FILE_EXTENSION
+		builder.WskAction.Exec.Main = main
+		assert.Nil(t, builder.CheckJavaMain(), main)
+	}
+
+	builder := newTestActionBuilder(Action{Function: jar})
+	builder.Ext = utils.JAR_FILE_EXTENSION
+	builder.WskAction.Exec.Main = "com.example.Hello"
+	err := builder.CheckJavaMain()
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err)
+	assert.Contains(t, err.Error(), "com.example.Hello")
+
+	builder = newTestActionBuilder(Action{Function: jar})
+	builder.Ext = utils.JAR_FILE_EXTENSION
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, builder.CheckJavaMain(), "the jar has no Main-Class")
+
+	builder = newTestActionBuilder(Action{Function: "actions/hello.js"})
+	builder.Ext = "js"
+	assert.Nil(t, builder.CheckJavaMain(), "not a jar action")
+}
+
+func TestActionBuilder_ResolveProvider(t *testing.T) {
+	defer func(provider string) { utils.Flags.Provider = provider }(utils.Flags.Provider)
+
+	utils.Flags.Provider = ""
+	builder := newTestActionBuilder(Action{Function: "actions/hello.js"})
+	assert.Nil(t, builder.ResolveCode())
+	assert.Nil(t, builder.ResolveProvider())
+	assert.Equal(t, "nodejs:6", builder.WskAction.Exec.Kind, "no provider")
+
+	utils.Flags.Provider = utils.PROVIDER_NIMBELLA
+	assert.Nil(t, builder.ResolveProvider())
+	assert.Equal(t, "nodejs:10", builder.WskAction.Exec.Kind, "the provider does not serve nodejs:6")
+}
@@ -18,13 +18,11 @@
 package parsers
 
 import (
-	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
-	"gopkg.in/yaml.v2"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
 )
 
 func (dm *YAMLParser) unmarshalDeployment(input []byte, deploy *YAML) error {
-	err := yaml.UnmarshalStrict(input, deploy)
+	err := unmarshalYAML(input, deploy)
 	if err != nil {
 		return err
 	}
@@ -33,11 +31,28 @@ func (dm *YAMLParser) unmarshalDeployment(input []byte, deploy *YAML) error {
 
 func (dm *YAMLParser) ParseDeployment(deploymentPath string) (*YAML, error) {
 	dplyyaml := YAML{}
-	content, err := new(utils.ContentReader).LocalReader.ReadLocal(deploymentPath)
+	// the deployment file may define several projects, see --project-name
+	index, err := ReadProjectIndex(deploymentPath)
+	if err != nil {
+		return &dplyyaml, err
+	}
+	document, err := SelectProject(index, dm.Flags.ProjectName, deploymentPath, false)
+	if err != nil {
+		return &dplyyaml, err
+	}
+	deploymentPath = document.Filepath
 
-   	 if err != nil {
-        	return &dplyyaml, wskderrors.NewFileReadError(deploymentPath, err.Error())
-    	}
+	content, err := ResolveIncludes(document.Content, deploymentPath)
+	if err != nil {
+		return &dplyyaml, err
+	}
+	if err = CheckYAMLLimits(content, deploymentPath); err != nil {
+		return &dplyyaml, err
+	}
+
+	if err = CheckVersionRequirement(content, deploymentPath); err != nil {
+		return &dplyyaml, err
+	}
 
 	err = dm.unmarshalDeployment(content, &dplyyaml)
 
@@ -47,6 +62,9 @@ func (dm *YAMLParser) ParseDeployment(deploymentPath string) (*YAML, error) {
 
 	dplyyaml.Filepath = deploymentPath
     	dplyyamlEnvVar := ReadEnvVariable(&dplyyaml)
+	if err = CheckNoExtensions(dplyyamlEnvVar, deploymentPath); err != nil {
+		return dplyyamlEnvVar, err
+	}
 	return dplyyamlEnvVar, nil
 }
 
@@ -0,0 +1,123 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// file types reported with deprecated keys
+const (
+	FILE_TYPE_MANIFEST   = "manifest"
+	FILE_TYPE_DEPLOYMENT = "deployment"
+)
+
+// deprecated YAML keys and the keys replacing them
+const (
+	YAML_KEY_LOCATION   = "location" // replaced by "function"
+	YAML_KEY_FUNCTION   = "function"
+	YAML_KEY_SOURCE     = "source"     // replaced by "feed"
+	YAML_KEY_WEB_EXPORT = "web-export" // replaced by "web"
+	YAML_KEY_WEB        = "web"
+)
+
+// DeprecatedKey records the use of a deprecated key in a manifest or deployment
+// file, the entity is empty for keys at the top level of the file
+type DeprecatedKey struct {
+	FilePath   string
+	FileType   string
+	EntityType string
+	EntityName string
+	OldKey     string
+	NewKey     string
+}
+
+// DeprecationReport collects the deprecated keys found while reading the
+// project files so that they can be reported, along with their replacements,
+// once instead of with a warning each time they are read.
+type DeprecationReport struct {
+	keys []DeprecatedKey
+	mt   sync.Mutex
+}
+
+// Deprecations collects the deprecated keys found by wskdeploy
+var Deprecations = NewDeprecationReport()
+
+func NewDeprecationReport() *DeprecationReport {
+	return &DeprecationReport{keys: make([]DeprecatedKey, 0)}
+}
+
+func (report *DeprecationReport) Add(key DeprecatedKey) {
+	report.mt.Lock()
+	defer report.mt.Unlock()
+	for _, k := range report.keys {
+		if k == key {
+			return
+		}
+	}
+	report.keys = append(report.keys, key)
+}
+
+func (report *DeprecationReport) Keys() []DeprecatedKey {
+	report.mt.Lock()
+	defer report.mt.Unlock()
+	return append([]DeprecatedKey{}, report.keys...)
+}
+
+func (report *DeprecationReport) IsEmpty() bool {
+	return len(report.Keys()) == 0
+}
+
+func (report *DeprecationReport) Reset() {
+	report.mt.Lock()
+	defer report.mt.Unlock()
+	report.keys = make([]DeprecatedKey, 0)
+}
+
+// Print displays every deprecated key found along with the key to use instead
+func (report *DeprecationReport) Print() {
+	keys := report.Keys()
+	if len(keys) == 0 {
+		return
+	}
+
+	wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_DEPRECATION_REPORT_X_count_X,
+		map[string]interface{}{wski18n.KEY_COUNT: len(keys)}))
+	for _, key := range keys {
+		wskprint.PrintlnOpenWhiskOutput(key.String())
+	}
+}
+
+func (key DeprecatedKey) String() string {
+	location := filepath.Base(key.FilePath)
+	if len(key.FilePath) == 0 {
+		location = key.FileType
+	}
+	if len(key.EntityType) > 0 {
+		location += ": " + key.EntityType + " [" + key.EntityName + "]"
+	}
+	return wski18n.T(wski18n.ID_MSG_DEPRECATED_KEY_X_location_X_oldkey_X_newkey_X,
+		map[string]interface{}{
+			wski18n.KEY_LOCATION: location,
+			wski18n.KEY_OLD:      key.OldKey,
+			wski18n.KEY_NEW:      key.NewKey})
+}
@@ -0,0 +1,67 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecationReport(t *testing.T) {
+	Deprecations.Reset()
+	defer Deprecations.Reset()
+
+	manifestFile := "../tests/dat/manifest_deprecated_keys.yaml"
+	p := NewYAMLParser()
+	m, err := p.ParseManifest(manifestFile)
+	assert.Nil(t, err)
+
+	_, err = p.ComposeAllPackages(m, manifestFile, whisk.KeyValue{})
+	assert.Nil(t, err)
+	actions, err := p.ComposeActions(manifestFile, m.Package.Actions, m.Package.Packagename, whisk.KeyValue{})
+	assert.Nil(t, err)
+	_, err = p.ComposeTriggers(manifestFile, m.Package, whisk.KeyValue{})
+	assert.Nil(t, err)
+	// parsing the same file again does not report the keys twice
+	_, err = p.ComposeAllPackages(m, manifestFile, whisk.KeyValue{})
+	assert.Nil(t, err)
+
+	// the deprecated "web-export" key is still honored
+	assert.Equal(t, 1, len(actions))
+	assert.Equal(t, true, actions[0].Action.Annotations.GetValue("web-export"))
+
+	expected := []DeprecatedKey{
+		{FilePath: manifestFile, FileType: FILE_TYPE_MANIFEST, OldKey: YAML_KEY_PACKAGE, NewKey: YAML_KEY_PACKAGES},
+		{FilePath: manifestFile, FileType: FILE_TYPE_MANIFEST, EntityType: YAML_KEY_ACTION, EntityName: "hello",
+			OldKey: YAML_KEY_LOCATION, NewKey: YAML_KEY_FUNCTION},
+		{FilePath: manifestFile, FileType: FILE_TYPE_MANIFEST, EntityType: YAML_KEY_ACTION, EntityName: "hello",
+			OldKey: YAML_KEY_WEB_EXPORT, NewKey: YAML_KEY_WEB},
+		{FilePath: manifestFile, FileType: FILE_TYPE_MANIFEST, EntityType: YAML_KEY_TRIGGER, EntityName: "everyMinute",
+			OldKey: YAML_KEY_SOURCE, NewKey: YAML_KEY_FEED},
+	}
+	assert.Equal(t, expected, Deprecations.Keys())
+
+	assert.Equal(t, "    manifest_deprecated_keys.yaml: action [hello]: replace [location] with [function]",
+		expected[1].String())
+	assert.Equal(t, "    manifest_deprecated_keys.yaml: replace [package] with [packages]",
+		expected[0].String())
+}
@@ -0,0 +1,121 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"bytes"
+	"strconv"
+	"sync"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+var (
+	BOM_UTF8    = []byte{0xEF, 0xBB, 0xBF}
+	BOM_UTF16LE = []byte{0xFF, 0xFE}
+	BOM_UTF16BE = []byte{0xFE, 0xFF}
+)
+
+// files which were already warned about, since manifest and deployment files
+// are parsed several times during a deployment
+var normalizedFiles = make(map[string]bool)
+var normalizedFilesMt sync.Mutex
+
+// NormalizeContent converts the content of a manifest or deployment file, as
+// written by some Windows editors, into the UTF-8 and LF line endings expected
+// by the YAML parser: the byte order mark is removed, UTF-16 is converted to
+// UTF-8 and CRLF line endings are converted to LF, with a warning. Content
+// which is not valid UTF-8 is an error.
+func NormalizeContent(content []byte, filePath string) ([]byte, error) {
+	warnings := make([]string, 0)
+
+	switch {
+	case bytes.HasPrefix(content, BOM_UTF8):
+		content = content[len(BOM_UTF8):]
+		warnings = append(warnings, bomWarning(filePath, "UTF-8"))
+	case bytes.HasPrefix(content, BOM_UTF16LE):
+		content = decodeUTF16(content[len(BOM_UTF16LE):], false)
+		warnings = append(warnings, bomWarning(filePath, "UTF-16LE"))
+	case bytes.HasPrefix(content, BOM_UTF16BE):
+		content = decodeUTF16(content[len(BOM_UTF16BE):], true)
+		warnings = append(warnings, bomWarning(filePath, "UTF-16BE"))
+	}
+
+	if !utf8.Valid(content) {
+		line := 1
+		for i := 0; i < len(content); {
+			r, size := utf8.DecodeRune(content[i:])
+			if r == utf8.RuneError && size <= 1 {
+				break
+			}
+			if r == '\n' {
+				line++
+			}
+			i += size
+		}
+		return content, wskderrors.NewYAMLFileFormatError(filePath,
+			wski18n.T(wski18n.ID_ERR_FILE_ENCODING_INVALID_X_path_X_line_X,
+				map[string]interface{}{
+					wski18n.KEY_PATH: filePath,
+					wski18n.KEY_LINE: strconv.Itoa(line)}))
+	}
+
+	if bytes.Contains(content, []byte("\r")) {
+		content = bytes.Replace(content, []byte("\r\n"), []byte("\n"), -1)
+		// line endings of classic Mac OS
+		content = bytes.Replace(content, []byte("\r"), []byte("\n"), -1)
+		warnings = append(warnings, wski18n.T(wski18n.ID_WARN_FILE_CRLF_X_path_X,
+			map[string]interface{}{wski18n.KEY_PATH: filePath}))
+	}
+
+	if len(warnings) > 0 {
+		normalizedFilesMt.Lock()
+		warned := normalizedFiles[filePath]
+		normalizedFiles[filePath] = true
+		normalizedFilesMt.Unlock()
+		if !warned {
+			for _, warning := range warnings {
+				wskprint.PrintlnOpenWhiskWarning(warning)
+			}
+		}
+	}
+	return content, nil
+}
+
+func bomWarning(filePath string, encoding string) string {
+	return wski18n.T(wski18n.ID_WARN_FILE_BOM_X_path_X_encoding_X,
+		map[string]interface{}{
+			wski18n.KEY_PATH:     filePath,
+			wski18n.KEY_ENCODING: encoding})
+}
+
+func decodeUTF16(content []byte, bigEndian bool) []byte {
+	units := make([]uint16, 0, len(content)/2)
+	for i := 0; i+1 < len(content); i += 2 {
+		if bigEndian {
+			units = append(units, uint16(content[i])<<8|uint16(content[i+1]))
+		} else {
+			units = append(units, uint16(content[i+1])<<8|uint16(content[i]))
+		}
+	}
+	return []byte(string(utf16.Decode(units)))
+}
@@ -0,0 +1,70 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_MANIFEST_CRLF = "packages:\r\n  helloworld:\r\n    actions:\r\n      hello:\r\n        function: actions/hello.js\r\n        runtime: nodejs:6\r\n"
+
+func TestNormalizeContent(t *testing.T) {
+	expected := "packages:\n  helloworld:\n    actions:\n      hello:\n        function: actions/hello.js\n        runtime: nodejs:6\n"
+
+	content, err := NormalizeContent(append(BOM_UTF8, []byte(TEST_MANIFEST_CRLF)...), "manifest.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, string(content))
+
+	// UTF-16 as saved by Notepad
+	utf16 := append([]byte{}, BOM_UTF16LE...)
+	for _, r := range "packages:\r\n  café: {}\r\n" {
+		utf16 = append(utf16, byte(r), byte(r>>8))
+	}
+	content, err = NormalizeContent(utf16, "manifest.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, "packages:\n  café: {}\n", string(content))
+
+	// content which is already normalized is unchanged
+	content, err = NormalizeContent([]byte(expected), "manifest.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, expected, string(content))
+
+	// e.g. a file saved as Latin-1
+	_, err = NormalizeContent([]byte("packages:\n  caf\xe9: {}\n"), "manifest.yaml")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "[2]")
+}
+
+func TestParseManifest_BOMAndCRLF(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wskdeploy")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	manifestPath := filepath.Join(dir, "manifest.yaml")
+	assert.Nil(t, ioutil.WriteFile(manifestPath, append(BOM_UTF8, []byte(TEST_MANIFEST_CRLF)...), 0644))
+
+	manifest, err := NewYAMLParser().ParseManifest(manifestPath)
+	assert.Nil(t, err)
+	assert.Equal(t, "actions/hello.js", manifest.Packages["helloworld"].Actions["hello"].Function)
+}
@@ -0,0 +1,79 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+)
+
+// AddEncryptedInputs adds the names of the inputs declared "encrypted: true"
+// to the encrypted-inputs annotation of an entity, the inputs are encrypted
+// once the deployment plan is composed, see utils.EncryptInput()
+func AddEncryptedInputs(annotations whisk.KeyValueArr, inputs map[string]Parameter) whisk.KeyValueArr {
+	names := make(map[string]bool)
+	for name, input := range inputs {
+		if input.Encrypted {
+			names[name] = true
+		}
+	}
+	if len(names) == 0 {
+		return annotations
+	}
+	index := -1
+	for i, annotation := range annotations {
+		if annotation.Key == utils.ENCRYPTED_INPUTS_ANNOT {
+			index = i
+			for _, name := range GetEncryptedInputs(annotations) {
+				names[name] = true
+			}
+		}
+	}
+
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+	value := make([]interface{}, 0, len(sorted))
+	for _, name := range sorted {
+		value = append(value, name)
+	}
+	if index < 0 {
+		return append(annotations, whisk.KeyValue{Key: utils.ENCRYPTED_INPUTS_ANNOT, Value: value})
+	}
+	annotations[index].Value = value
+	return annotations
+}
+
+// GetEncryptedInputs returns the names of the encrypted inputs of an entity
+func GetEncryptedInputs(annotations whisk.KeyValueArr) []string {
+	names := make([]string, 0)
+	switch value := annotations.GetValue(utils.ENCRYPTED_INPUTS_ANNOT).(type) {
+	case []interface{}:
+		for _, name := range value {
+			names = append(names, fmt.Sprint(name))
+		}
+	case []string:
+		names = append(names, value...)
+	}
+	return names
+}
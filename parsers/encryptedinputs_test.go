@@ -0,0 +1,47 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddEncryptedInputs(t *testing.T) {
+	inputs := map[string]Parameter{
+		"token":    {Type: "string", Encrypted: true},
+		"password": {Type: "string", Encrypted: true},
+		"username": {Type: "string"},
+	}
+	annotations := AddEncryptedInputs(whisk.KeyValueArr{{Key: "final", Value: true}}, inputs)
+	assert.Equal(t, []string{"password", "token"}, GetEncryptedInputs(annotations))
+
+	// the inputs of the deployment file are added to the ones of the manifest
+	annotations = AddEncryptedInputs(annotations, map[string]Parameter{"apikey": {Encrypted: true}})
+	assert.Equal(t, 2, len(annotations))
+	assert.Equal(t, []string{"apikey", "password", "token"}, GetEncryptedInputs(annotations))
+
+	annotations = AddEncryptedInputs(nil, map[string]Parameter{"username": {}})
+	assert.Nil(t, annotations)
+	assert.Empty(t, GetEncryptedInputs(whisk.KeyValueArr{{Key: utils.WEB_EXPORT_ANNOT, Value: true}}))
+}
@@ -0,0 +1,65 @@
+// +build gofuzz
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+// Fuzz is the entry point of go-fuzz (github.com/dvyukov/go-fuzz) for the
+// manifest and deployment files, see "make fuzz". The files are decoded and
+// their inputs and annotations resolved the way a deployment does, without
+// reading the files of actions. Any panic is a crash.
+func Fuzz(data []byte) int {
+	const filePath = "fuzz.yaml"
+	if err := CheckYAMLLimits(data, filePath); err != nil {
+		return 0
+	}
+	var manifest YAML
+	if err := NewYAMLParser().Unmarshal(data, &manifest); err != nil {
+		return 0
+	}
+
+	packages := manifest.Packages
+	if len(packages) == 0 {
+		packages = manifest.GetProject().Packages
+	}
+	for _, pkg := range packages {
+		resolveFuzzInputs(pkg.Inputs, filePath)
+		resolveFuzzAnnotations(pkg.Annotations)
+		for _, action := range pkg.Actions {
+			resolveFuzzInputs(action.Inputs, filePath)
+			resolveFuzzAnnotations(action.Annotations)
+		}
+		for _, trigger := range pkg.Triggers {
+			resolveFuzzInputs(trigger.Inputs, filePath)
+			resolveFuzzAnnotations(trigger.Annotations)
+		}
+	}
+	return 1
+}
+
+func resolveFuzzInputs(inputs map[string]Parameter, filePath string) {
+	for name, param := range inputs {
+		ResolveParameter(name, &param, filePath, false)
+	}
+}
+
+func resolveFuzzAnnotations(annotations map[string]interface{}) {
+	for _, value := range annotations {
+		ResolveAnnotation(value)
+	}
+}
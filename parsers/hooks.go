@@ -0,0 +1,63 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// what happens when a hook fails: the package or action fails, or a warning
+// is printed and the deployment goes on
+const (
+	HOOK_ON_FAILURE_FAIL     = "fail"
+	HOOK_ON_FAILURE_CONTINUE = "continue"
+)
+
+// ValidateHooks checks that every hook of an entity either runs a command or
+// invokes an action, and that its on_failure is known. The hooks are named
+// after the entity in the errors, e.g. hello/greeting pre_deploy #1.
+func ValidateHooks(filePath string, entity string, key string, hooks []Hook) error {
+	for i, hook := range hooks {
+		name := HookName(entity, key, i)
+		if (len(strings.TrimSpace(hook.Run)) == 0) == (len(strings.TrimSpace(hook.Action)) == 0) {
+			return wskderrors.NewYAMLFileFormatError(filePath, wski18n.T(wski18n.ID_ERR_HOOK_INVALID_X_name_X,
+				map[string]interface{}{wski18n.KEY_NAME: name}))
+		}
+		if len(hook.Run) > 0 && len(hook.Inputs) > 0 {
+			return wskderrors.NewYAMLFileFormatError(filePath, wski18n.T(wski18n.ID_ERR_HOOK_INPUTS_WITH_RUN_X_name_X,
+				map[string]interface{}{wski18n.KEY_NAME: name}))
+		}
+		switch hook.OnFailure {
+		case "", HOOK_ON_FAILURE_FAIL, HOOK_ON_FAILURE_CONTINUE:
+		default:
+			return wskderrors.NewYAMLFileFormatError(filePath, wski18n.T(wski18n.ID_ERR_HOOK_ON_FAILURE_INVALID_X_name_X_value_X,
+				map[string]interface{}{wski18n.KEY_NAME: name, wski18n.KEY_VALUE: hook.OnFailure}))
+		}
+	}
+	return nil
+}
+
+// HookName names the hook at the index of the pre_deploy or post_deploy
+// hooks of the entity in messages
+func HookName(entity string, key string, index int) string {
+	return entity + " " + key + " #" + strconv.Itoa(index+1)
+}
@@ -0,0 +1,148 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+const YAML_TAG_INCLUDE = "!include"
+
+// matches "key: !include file.yaml", "- !include file.yaml" and "- key: !include file.yaml"
+var includeRegex = regexp.MustCompile(`^(\s*)(-\s+|-\s+[^#\s][^#]*?:\s+|[^#\s-][^#]*?:\s+)` + YAML_TAG_INCLUDE + `\s+(\S+)\s*$`)
+
+// matches a key or a sequence entry whose value is a block scalar, e.g.
+// "code: |", "- >-" or "- key: |2", whose lines are content, not YAML
+var includeBlockRegex = regexp.MustCompile(`^(\s*)(-\s+)?([^#\s-][^#]*?:\s+)?[|>][-+0-9]*\s*(#.*)?$`)
+
+// ResolveIncludes inlines the YAML fragments referenced by "!include <file>"
+// into the given content. Relative paths are resolved against the directory of
+// the including file and included files may include other files themselves.
+func ResolveIncludes(content []byte, filePath string) ([]byte, error) {
+	return resolveIncludes(content, filePath, []string{filePath})
+}
+
+func resolveIncludes(content []byte, filePath string, chain []string) ([]byte, error) {
+	if !strings.Contains(string(content), YAML_TAG_INCLUDE) {
+		return content, nil
+	}
+
+	lines := strings.Split(string(content), "\n")
+	result := make([]string, 0, len(lines))
+
+	// column the lines of a block scalar are indented past, -1 outside of one
+	blockColumn := -1
+	for _, line := range lines {
+		trimmed := strings.TrimRight(line, "\r")
+		if blockColumn >= 0 {
+			if strings.TrimSpace(trimmed) == "" || indentation(trimmed) > blockColumn {
+				result = append(result, line)
+				continue
+			}
+			blockColumn = -1
+		}
+		if b := includeBlockRegex.FindStringSubmatch(trimmed); b != nil && (len(b[2]) > 0 || len(b[3]) > 0) {
+			// the content of the block is indented past its key, or past
+			// the entry if it has no key
+			blockColumn = len(b[1])
+			if len(b[3]) > 0 {
+				blockColumn += len(b[2])
+			}
+			result = append(result, line)
+			continue
+		}
+
+		m := includeRegex.FindStringSubmatch(trimmed)
+		if m == nil {
+			result = append(result, line)
+			continue
+		}
+
+		leading, prefix, includePath := m[1], m[2], resolveIncludePath(filePath, m[3])
+
+		for _, p := range chain {
+			if p == includePath {
+				errString := wski18n.T(wski18n.ID_ERR_RECURSIVE_INCLUDE_X_path_X_chain_X,
+					map[string]interface{}{
+						wski18n.KEY_PATH:  includePath,
+						wski18n.KEY_CHAIN: strings.Join(append(chain, includePath), " -> ")})
+				return nil, wskderrors.NewYAMLFileFormatError(filePath, errString)
+			}
+		}
+
+		fragment, err := utils.Read(includePath)
+		if err != nil {
+			return nil, wskderrors.NewFileReadError(includePath, err.Error())
+		}
+		fragment, err = NormalizeContent(fragment, includePath)
+		if err != nil {
+			return nil, err
+		}
+
+		fragment, err = resolveIncludes(fragment, includePath, append(chain, includePath))
+		if err != nil {
+			return nil, err
+		}
+
+		// the fragment is indented two spaces past the key (or the sequence
+		// entry) it is included under
+		keyOffset := len(leading)
+		if strings.HasPrefix(prefix, "-") {
+			if rest := strings.TrimLeft(prefix[1:], " \t"); rest != "" {
+				keyOffset += len(prefix) - len(rest)
+			}
+		}
+		indent := strings.Repeat(" ", keyOffset+2)
+
+		result = append(result, leading+strings.TrimRight(prefix, " \t"))
+		for _, fragmentLine := range strings.Split(string(fragment), "\n") {
+			fragmentLine = strings.TrimRight(fragmentLine, "\r")
+			switch {
+			case fragmentLine == "---":
+				// document start marker of the fragment
+			case strings.TrimSpace(fragmentLine) == "":
+				result = append(result, "")
+			default:
+				result = append(result, indent+fragmentLine)
+			}
+		}
+	}
+
+	return []byte(strings.Join(result, "\n")), nil
+}
+
+// indentation returns the number of spaces a line starts with
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func resolveIncludePath(filePath string, includePath string) string {
+	if path.IsAbs(includePath) || strings.HasPrefix(includePath, "http") {
+		return includePath
+	}
+	if strings.HasPrefix(filePath, "http") {
+		return filePath[:strings.LastIndex(filePath, "/")+1] + includePath
+	}
+	return path.Join(path.Dir(filePath), includePath)
+}
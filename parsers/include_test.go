@@ -0,0 +1,95 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var manifest_validate_include = "../tests/dat/manifest_validate_include.yaml"
+var manifest_invalid_recursive_include = "../tests/dat/manifest_invalid_recursive_include.yaml"
+
+func TestParseManifest_Include(t *testing.T) {
+	manifest, err := NewYAMLParser().ParseManifest(manifest_validate_include)
+	assert.Nil(t, err, "Failed to parse manifest with includes.")
+
+	pkg, ok := manifest.Packages["helloworld"]
+	assert.True(t, ok, "Included package is missing.")
+	assert.Equal(t, "1.0", pkg.Version, "Included package version mismatched.")
+
+	action := pkg.Actions["hello"]
+	assert.Equal(t, "actions/hello.js", action.Function, "Included action function mismatched.")
+	assert.Equal(t, 2, len(action.Inputs), "Number of included inputs mismatched.")
+	assert.Equal(t, "string", action.Inputs["name"].Type, "Included input type mismatched.")
+	assert.Equal(t, "location of a person", action.Inputs["place"].Description, "Included input description mismatched.")
+}
+
+func TestParseManifest_RecursiveInclude(t *testing.T) {
+	_, err := NewYAMLParser().ParseManifest(manifest_invalid_recursive_include)
+	assert.NotNil(t, err, "Expected an error for a recursive include.")
+	_, ok := err.(*wskderrors.YAMLFileFormatError)
+	assert.True(t, ok, "Expected a YAMLFileFormatError for a recursive include.")
+}
+
+func TestResolveIncludes_SequenceEntry(t *testing.T) {
+	content := []byte("list:\n  - !include include/inputs.yaml\n  - key: !include include/inputs.yaml\n")
+	resolved, err := ResolveIncludes(content, manifest_validate_include)
+	assert.Nil(t, err)
+	expected := "list:\n  -\n    name:\n        type: string\n        description: name of a person\n" +
+		"    place:\n        type: string\n        description: location of a person\n\n" +
+		"  - key:\n      name:\n          type: string\n          description: name of a person\n" +
+		"      place:\n          type: string\n          description: location of a person\n\n"
+	assert.Equal(t, expected, string(resolved))
+}
+
+// the lines of block scalars are content, e.g. the inline code of an action
+func TestResolveIncludes_BlockScalar(t *testing.T) {
+	content := "actions:\n" +
+		"  hello:\n" +
+		"    code: |\n" +
+		"      inputs: !include include/inputs.yaml\n" +
+		"\n" +
+		"        - !include include/inputs.yaml\n" +
+		"    inputs: !include include/inputs.yaml\n" +
+		"  list:\n" +
+		"    - description: >-\n" +
+		"        key: !include include/inputs.yaml\n" +
+		"    - |\n" +
+		"      key: !include include/inputs.yaml\n"
+	resolved, err := ResolveIncludes([]byte(content), manifest_validate_include)
+	assert.Nil(t, err)
+	expected := "actions:\n" +
+		"  hello:\n" +
+		"    code: |\n" +
+		"      inputs: !include include/inputs.yaml\n" +
+		"\n" +
+		"        - !include include/inputs.yaml\n" +
+		"    inputs:\n" +
+		"      name:\n          type: string\n          description: name of a person\n" +
+		"      place:\n          type: string\n          description: location of a person\n\n" +
+		"  list:\n" +
+		"    - description: >-\n" +
+		"        key: !include include/inputs.yaml\n" +
+		"    - |\n" +
+		"      key: !include include/inputs.yaml\n"
+	assert.Equal(t, expected, string(resolved))
+}
@@ -0,0 +1,96 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// InheritLimits returns the limits of an action, each limit is the first one
+// set in the given limits, e.g. the ones of the action, its package and the
+// project. It returns nil if none is set.
+func InheritLimits(limits ...*Limits) *Limits {
+	inherited := Limits{}
+	first := func(limit func(*Limits) *int) *int {
+		for _, l := range limits {
+			if l != nil && limit(l) != nil {
+				return limit(l)
+			}
+		}
+		return nil
+	}
+	inherited.Timeout = first(func(l *Limits) *int { return l.Timeout })
+	inherited.Memory = first(func(l *Limits) *int { return l.Memory })
+	inherited.Logsize = first(func(l *Limits) *int { return l.Logsize })
+	inherited.ConcurrentActivations = first(func(l *Limits) *int { return l.ConcurrentActivations })
+	inherited.UserInvocationRate = first(func(l *Limits) *int { return l.UserInvocationRate })
+	inherited.CodeSize = first(func(l *Limits) *int { return l.CodeSize })
+	inherited.ParameterSize = first(func(l *Limits) *int { return l.ParameterSize })
+	if inherited == (Limits{}) {
+		return nil
+	}
+	return &inherited
+}
+
+// ResolveLimits returns the valid limits, invalid and unsupported limits are
+// ignored with a warning. It returns nil if there is no valid limit.
+func ResolveLimits(limits *Limits) *whisk.Limits {
+	if limits == nil {
+		return nil
+	}
+	wsklimits := new(whisk.Limits)
+
+	// TODO() use LIMITS_SUPPORTED in yamlparser to enumerata through instead of hardcoding
+	// perhaps change into a tuple
+	if utils.LimitsTimeoutValidation(limits.Timeout) {
+		wsklimits.Timeout = limits.Timeout
+	} else {
+		warnLimitIgnored(LIMIT_VALUE_TIMEOUT)
+	}
+	if utils.LimitsMemoryValidation(limits.Memory) {
+		wsklimits.Memory = limits.Memory
+	} else {
+		warnLimitIgnored(LIMIT_VALUE_MEMORY_SIZE)
+	}
+	if utils.LimitsLogsizeValidation(limits.Logsize) {
+		wsklimits.Logsize = limits.Logsize
+	} else {
+		warnLimitIgnored(LIMIT_VALUE_LOG_SIZE)
+	}
+
+	// TODO() use LIMITS_UNSUPPORTED in yamlparser to enumerata through instead of hardcoding
+	// emit warning errors if these limits are not nil
+	utils.NotSupportLimits(limits.ConcurrentActivations, LIMIT_VALUE_CONCURRENT_ACTIVATIONS)
+	utils.NotSupportLimits(limits.UserInvocationRate, LIMIT_VALUE_USER_INVOCATION_RATE)
+	utils.NotSupportLimits(limits.CodeSize, LIMIT_VALUE_CODE_SIZE)
+	utils.NotSupportLimits(limits.ParameterSize, LIMIT_VALUE_PARAMETER_SIZE)
+
+	if wsklimits.Timeout == nil && wsklimits.Memory == nil && wsklimits.Logsize == nil {
+		return nil
+	}
+	return wsklimits
+}
+
+func warnLimitIgnored(limit string) {
+	warningString := wski18n.T(wski18n.ID_MSG_ACTION_LIMIT_IGNORED_X_limit_X,
+		map[string]interface{}{wski18n.KEY_LIMIT: limit})
+	wskprint.PrintOpenWhiskWarning(warningString)
+}
@@ -0,0 +1,53 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInheritLimits_Precedence(t *testing.T) {
+	timeout, memory, packageMemory, logsize := 1000, 256, 512, 5
+	action := &Limits{Timeout: &timeout}
+	pkg := &Limits{Memory: &packageMemory}
+	project := &Limits{Memory: &memory, Logsize: &logsize}
+
+	limits := InheritLimits(action, pkg, project)
+	assert.Equal(t, timeout, *limits.Timeout)
+	assert.Equal(t, packageMemory, *limits.Memory, "the limits of the package take precedence over the project")
+	assert.Equal(t, logsize, *limits.Logsize)
+	assert.Nil(t, limits.CodeSize)
+
+	assert.Nil(t, InheritLimits(nil, &Limits{}, nil), "no limit is set")
+}
+
+func TestResolveLimits(t *testing.T) {
+	assert.Nil(t, ResolveLimits(nil))
+
+	timeout, memory := 60000, 1
+	limits := ResolveLimits(&Limits{Timeout: &timeout, Memory: &memory})
+	assert.Equal(t, timeout, *limits.Timeout)
+	assert.Nil(t, limits.Memory, "invalid limits are ignored")
+
+	codeSize := 10
+	assert.Nil(t, ResolveLimits(&Limits{Memory: &memory, CodeSize: &codeSize}), "no valid limit")
+}
@@ -0,0 +1,256 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"gopkg.in/yaml.v2"
+)
+
+// number of spaces used to indent a new manifest file
+const DEFAULT_MANIFEST_INDENT = 4
+
+// matches "key:" (optionally followed by a comment) and captures the key
+var manifestKeyRegex = regexp.MustCompile(`^(\s*)([^\s#:-][^:#]*?):\s*(#.*)?$`)
+
+// ManifestEditor adds entities to a manifest file by inserting them into the
+// text of the file, rather than serializing the whole manifest again, so that
+// comments and the order of existing entries are preserved.
+type ManifestEditor struct {
+	Filename string
+	lines    []string
+	indent   int
+}
+
+func NewManifestEditor(filename string) (*ManifestEditor, error) {
+	editor := &ManifestEditor{Filename: filename, indent: DEFAULT_MANIFEST_INDENT}
+	if utils.FileExists(filename) {
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, wskderrors.NewFileReadError(filename, err.Error())
+		}
+		if err := yaml.Unmarshal(content, &YAML{}); err != nil {
+			return nil, wskderrors.NewYAMLParserErr(filename, err.Error())
+		}
+		text := strings.TrimRight(strings.Replace(string(content), "\r\n", "\n", -1), "\n")
+		if len(text) > 0 {
+			editor.lines = strings.Split(text, "\n")
+		}
+		editor.indent = detectIndent(editor.lines)
+	}
+	return editor, nil
+}
+
+// PackageNames returns the names of the packages declared in the manifest
+func (editor *ManifestEditor) PackageNames() []string {
+	names := make([]string, 0)
+	start, end, found := editor.findBlock(0, len(editor.lines), -1, YAML_KEY_PACKAGES)
+	if !found {
+		return names
+	}
+	indent := editor.childIndent(start, end)
+	for i := start + 1; i < end; i++ {
+		if key, ok := editor.keyAt(i, indent); ok {
+			names = append(names, key)
+		}
+	}
+	return names
+}
+
+// Add inserts the entity under the given section ("actions", "triggers" or
+// "rules") of the package, creating the package and the section if needed.
+func (editor *ManifestEditor) Add(packageName string, section string, name string, entity map[string]interface{}) error {
+	if editor.contains(packageName, section, name) {
+		return wskderrors.NewYAMLFileFormatError(editor.Filename,
+			wski18n.T(wski18n.ID_ERR_ENTITY_EXISTS_X_key_X_name_X,
+				map[string]interface{}{
+					wski18n.KEY_KEY:  strings.TrimSuffix(section, "s"),
+					wski18n.KEY_NAME: name}))
+	}
+
+	entityLines, err := editor.marshal(map[string]interface{}{name: entity})
+	if err != nil {
+		return wskderrors.NewYAMLFileFormatError(editor.Filename, err.Error())
+	}
+
+	// walk down "packages" -> package -> section, creating the missing levels
+	path := []string{YAML_KEY_PACKAGES, packageName, section}
+	start, end, indent := -1, len(editor.lines), -1
+	for i, key := range path {
+		keyStart, keyEnd, found := editor.findBlock(start+1, end, indent, key)
+		if !found {
+			childIndent := editor.childIndent(start, end)
+			if start < 0 {
+				childIndent = 0
+			}
+			insert := make([]string, 0)
+			for j, missing := range path[i:] {
+				insert = append(insert, strings.Repeat(" ", childIndent+j*editor.indent)+missing+":")
+			}
+			editor.insert(editor.lastContentLine(start, end)+1,
+				append(insert, editor.indentLines(entityLines, childIndent+len(path[i:])*editor.indent)...))
+			return nil
+		}
+		start, end = keyStart, keyEnd
+		indent = editor.lineIndent(start)
+	}
+
+	editor.insert(editor.lastContentLine(start, end)+1,
+		editor.indentLines(entityLines, editor.childIndent(start, end)))
+	return nil
+}
+
+// Write saves the manifest and verifies it can still be parsed
+func (editor *ManifestEditor) Write() error {
+	content := []byte(strings.Join(editor.lines, "\n") + "\n")
+	if err := yaml.Unmarshal(content, &YAML{}); err != nil {
+		return wskderrors.NewYAMLFileFormatError(editor.Filename, err.Error())
+	}
+	if err := ioutil.WriteFile(editor.Filename, content, 0644); err != nil {
+		return wskderrors.NewFileReadError(editor.Filename, err.Error())
+	}
+	return nil
+}
+
+func (editor *ManifestEditor) contains(packageName string, section string, name string) bool {
+	manifest := YAML{}
+	if err := yaml.Unmarshal([]byte(strings.Join(editor.lines, "\n")), &manifest); err != nil {
+		return false
+	}
+	pkg := manifest.Packages[packageName]
+	exists := false
+	switch section {
+	case YAML_KEY_ACTIONS:
+		_, exists = pkg.Actions[name]
+	case YAML_KEY_TRIGGERS:
+		_, exists = pkg.Triggers[name]
+	case YAML_KEY_RULES:
+		_, exists = pkg.Rules[name]
+	}
+	return exists
+}
+
+func (editor *ManifestEditor) marshal(entity map[string]interface{}) ([]string, error) {
+	content, err := yaml.Marshal(entity)
+	if err != nil {
+		return nil, err
+	}
+	return strings.Split(strings.TrimRight(string(content), "\n"), "\n"), nil
+}
+
+// indentLines re-indents lines marshalled by yaml.v2 (two spaces per level)
+// to the indentation used in the manifest, starting at the given column
+func (editor *ManifestEditor) indentLines(lines []string, column int) []string {
+	indented := make([]string, 0, len(lines))
+	for _, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		level := (len(line) - len(trimmed)) / 2
+		indented = append(indented, strings.Repeat(" ", column+level*editor.indent)+trimmed)
+	}
+	return indented
+}
+
+func (editor *ManifestEditor) insert(index int, lines []string) {
+	result := make([]string, 0, len(editor.lines)+len(lines))
+	result = append(result, editor.lines[:index]...)
+	result = append(result, lines...)
+	editor.lines = append(result, editor.lines[index:]...)
+}
+
+// findBlock returns the line of "key:" indented deeper than parentIndent
+// within [start, end), and the end of the block of lines which belongs to it
+func (editor *ManifestEditor) findBlock(start int, end int, parentIndent int, key string) (int, int, bool) {
+	indent := editor.childIndent(start-1, end)
+	if parentIndent < 0 {
+		indent = 0
+	}
+	for i := start; i < end; i++ {
+		if k, ok := editor.keyAt(i, indent); ok && k == key {
+			blockEnd := i + 1
+			for ; blockEnd < end; blockEnd++ {
+				if editor.isContent(blockEnd) && editor.lineIndent(blockEnd) <= indent {
+					break
+				}
+			}
+			return i, blockEnd, true
+		}
+	}
+	return -1, -1, false
+}
+
+// childIndent returns the indentation of the first entry of the block starting
+// at line start, or the default indentation if the block is empty
+func (editor *ManifestEditor) childIndent(start int, end int) int {
+	parentIndent := 0
+	if start >= 0 {
+		parentIndent = editor.lineIndent(start)
+	}
+	for i := start + 1; i < end; i++ {
+		if editor.isContent(i) {
+			if indent := editor.lineIndent(i); indent > parentIndent || start < 0 {
+				return indent
+			}
+			break
+		}
+	}
+	return parentIndent + editor.indent
+}
+
+// lastContentLine returns the last line of the block which is not blank or a
+// comment, so that comments introducing the next entry stay with it
+func (editor *ManifestEditor) lastContentLine(start int, end int) int {
+	for i := end - 1; i > start; i-- {
+		if editor.isContent(i) {
+			return i
+		}
+	}
+	return start
+}
+
+func (editor *ManifestEditor) keyAt(i int, indent int) (string, bool) {
+	m := manifestKeyRegex.FindStringSubmatch(editor.lines[i])
+	if m == nil || len(m[1]) != indent {
+		return "", false
+	}
+	return strings.Trim(strings.TrimSpace(m[2]), `"'`), true
+}
+
+func (editor *ManifestEditor) isContent(i int) bool {
+	trimmed := strings.TrimSpace(editor.lines[i])
+	return trimmed != "" && !strings.HasPrefix(trimmed, "#") && trimmed != "---"
+}
+
+func (editor *ManifestEditor) lineIndent(i int) int {
+	return len(editor.lines[i]) - len(strings.TrimLeft(editor.lines[i], " "))
+}
+
+func detectIndent(lines []string) int {
+	for _, line := range lines {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") && len(trimmed) < len(line) {
+			return len(line) - len(trimmed)
+		}
+	}
+	return DEFAULT_MANIFEST_INDENT
+}
@@ -0,0 +1,125 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_MANIFEST_WITH_COMMENTS = `# hello world project
+packages:
+  # the main package
+  helloworld:
+    version: 1.0
+    actions:
+      hello:
+        function: src/hello.js # greets the caller
+    # triggers are added below
+
+  other:
+    version: 1.0
+`
+
+func writeTestManifest(t *testing.T, content string) (string, func()) {
+	dir, err := ioutil.TempDir("", "wskdeploy")
+	assert.Nil(t, err)
+	manifestPath := filepath.Join(dir, "manifest.yaml")
+	if len(content) > 0 {
+		assert.Nil(t, ioutil.WriteFile(manifestPath, []byte(content), 0644))
+	}
+	return manifestPath, func() { os.RemoveAll(dir) }
+}
+
+func TestManifestEditor_Add(t *testing.T) {
+	manifestPath, cleanup := writeTestManifest(t, TEST_MANIFEST_WITH_COMMENTS)
+	defer cleanup()
+
+	editor, err := NewManifestEditor(manifestPath)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"helloworld", "other"}, editor.PackageNames())
+
+	assert.Nil(t, editor.Add("helloworld", YAML_KEY_ACTIONS, "goodbye",
+		map[string]interface{}{"function": "src/goodbye.js", "runtime": "nodejs:6", "web-export": true}))
+	assert.Nil(t, editor.Add("helloworld", YAML_KEY_TRIGGERS, "everyMinute",
+		map[string]interface{}{"feed": "/whisk.system/alarms/alarm"}))
+	assert.Nil(t, editor.Add("other", YAML_KEY_RULES, "greetEveryMinute",
+		map[string]interface{}{"trigger": "everyMinute", "action": "helloworld/hello"}))
+	assert.Nil(t, editor.Add("newpackage", YAML_KEY_ACTIONS, "hello",
+		map[string]interface{}{"function": "src/hello.js"}))
+	assert.Nil(t, editor.Write())
+
+	content, err := ioutil.ReadFile(manifestPath)
+	assert.Nil(t, err)
+	for _, comment := range []string{"# hello world project", "# the main package", "# greets the caller", "# triggers are added below"} {
+		assert.True(t, strings.Contains(string(content), comment), "comment was not preserved: "+comment)
+	}
+	// new actions are added after the existing ones, with the indentation of the file
+	assert.True(t, strings.Contains(string(content), `
+      hello:
+        function: src/hello.js # greets the caller
+      goodbye:
+        function: src/goodbye.js
+`))
+
+	manifest, err := NewYAMLParser().ParseManifest(manifestPath)
+	assert.Nil(t, err)
+	pkg := manifest.Packages["helloworld"]
+	assert.Equal(t, "src/hello.js", pkg.Actions["hello"].Function)
+	assert.Equal(t, "src/goodbye.js", pkg.Actions["goodbye"].Function)
+	assert.Equal(t, "nodejs:6", pkg.Actions["goodbye"].Runtime)
+	assert.Equal(t, "true", pkg.Actions["goodbye"].Webexport)
+	assert.Equal(t, "/whisk.system/alarms/alarm", pkg.Triggers["everyMinute"].Feed)
+	assert.Equal(t, "everyMinute", manifest.Packages["other"].Rules["greetEveryMinute"].Trigger)
+	assert.Equal(t, "src/hello.js", manifest.Packages["newpackage"].Actions["hello"].Function)
+}
+
+func TestManifestEditor_AddExisting(t *testing.T) {
+	manifestPath, cleanup := writeTestManifest(t, TEST_MANIFEST_WITH_COMMENTS)
+	defer cleanup()
+
+	editor, err := NewManifestEditor(manifestPath)
+	assert.Nil(t, err)
+	err = editor.Add("helloworld", YAML_KEY_ACTIONS, "hello", map[string]interface{}{"function": "src/other.js"})
+	assert.NotNil(t, err)
+	// an action of the same name in another package is fine
+	assert.Nil(t, editor.Add("other", YAML_KEY_ACTIONS, "hello", map[string]interface{}{"function": "src/other.js"}))
+}
+
+func TestManifestEditor_NewManifest(t *testing.T) {
+	manifestPath, cleanup := writeTestManifest(t, "")
+	defer cleanup()
+
+	editor, err := NewManifestEditor(manifestPath)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(editor.PackageNames()))
+	assert.Nil(t, editor.Add("helloworld", YAML_KEY_ACTIONS, "hello", map[string]interface{}{"function": "src/hello.js"}))
+	assert.Nil(t, editor.Add("helloworld", YAML_KEY_ACTIONS, "goodbye", map[string]interface{}{"function": "src/goodbye.js"}))
+	assert.Nil(t, editor.Write())
+
+	manifest, err := NewYAMLParser().ParseManifest(manifestPath)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(manifest.Packages["helloworld"].Actions))
+}
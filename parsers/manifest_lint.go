@@ -0,0 +1,354 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"gopkg.in/yaml.v2"
+)
+
+// severities of the rules of the linter, the findings of "off" rules are not
+// reported
+const (
+	LINT_SEVERITY_ERROR   = "error"
+	LINT_SEVERITY_WARNING = "warning"
+	LINT_SEVERITY_INFO    = "info"
+	LINT_SEVERITY_OFF     = "off"
+)
+
+const (
+	LINT_RULE_DUPLICATE_KEY       = "duplicate-key"
+	LINT_RULE_UNUSED_INPUT        = "unused-input"
+	LINT_RULE_UNREFERENCED_ACTION = "unreferenced-action"
+	LINT_RULE_LONG_TIMEOUT        = "long-timeout"
+	LINT_RULE_MISSING_DESCRIPTION = "missing-description"
+)
+
+// timeout of actions (in ms) beyond which long-timeout reports them, the
+// default timeout of OpenWhisk
+const LINT_LONG_TIMEOUT = 60000
+
+// annotation which describes a package or an action
+const LINT_DESCRIPTION_ANNOTATION = "description"
+
+// LintFinding is a finding of a rule of the linter
+type LintFinding struct {
+	Rule     string
+	Severity string
+	Message  string
+}
+
+func (finding LintFinding) String() string {
+	return fmt.Sprintf("[%s] %s", finding.Rule, finding.Message)
+}
+
+// LintRule is a rule of the linter, its check returns the messages of its
+// findings in a manifest
+type LintRule struct {
+	Name     string
+	Severity string // default severity
+	Check    func(manifest *lintManifest) []string
+}
+
+// LintRules are the rules of the linter, in the order they are reported
+var LintRules = []LintRule{
+	{LINT_RULE_DUPLICATE_KEY, LINT_SEVERITY_ERROR, lintDuplicateKeys},
+	{LINT_RULE_UNUSED_INPUT, LINT_SEVERITY_WARNING, lintUnusedInputs},
+	{LINT_RULE_LONG_TIMEOUT, LINT_SEVERITY_WARNING, lintLongTimeouts},
+	{LINT_RULE_UNREFERENCED_ACTION, LINT_SEVERITY_INFO, lintUnreferencedActions},
+	{LINT_RULE_MISSING_DESCRIPTION, LINT_SEVERITY_INFO, lintMissingDescriptions},
+}
+
+// Linter checks a manifest against LintRules, the severity of each rule may
+// be changed, e.g. to fail on unused inputs
+type Linter struct {
+	Severities map[string]string
+}
+
+func NewLinter() *Linter {
+	return &Linter{Severities: make(map[string]string)}
+}
+
+// SetSeverity sets the severity of a rule, given as "<rule>=<severity>",
+// e.g. "unused-input=error"
+func (linter *Linter) SetSeverity(ruleSeverity string) error {
+	parts := strings.SplitN(ruleSeverity, "=", 2)
+	rule := strings.TrimSpace(parts[0])
+	names := make([]string, 0, len(LintRules))
+	known := false
+	for _, lintRule := range LintRules {
+		names = append(names, lintRule.Name)
+		known = known || lintRule.Name == rule
+	}
+	if !known {
+		return wskderrors.NewCommandError("lint", wski18n.T(wski18n.ID_ERR_LINT_RULE_UNKNOWN_X_rule_X_rules_X,
+			map[string]interface{}{wski18n.KEY_RULE: rule, wski18n.KEY_RULES: strings.Join(names, ", ")}))
+	}
+
+	severities := []string{LINT_SEVERITY_ERROR, LINT_SEVERITY_WARNING, LINT_SEVERITY_INFO, LINT_SEVERITY_OFF}
+	severity := ""
+	if len(parts) == 2 {
+		severity = strings.ToLower(strings.TrimSpace(parts[1]))
+	}
+	for _, valid := range severities {
+		if severity == valid {
+			linter.Severities[rule] = severity
+			return nil
+		}
+	}
+	return wskderrors.NewCommandError("lint", wski18n.T(wski18n.ID_ERR_LINT_SEVERITY_INVALID_X_rule_X_value_X_severities_X,
+		map[string]interface{}{wski18n.KEY_RULE: rule, wski18n.KEY_VALUE: severity,
+			wski18n.KEY_SEVERITIES: strings.Join(severities, ", ")}))
+}
+
+// Lint returns the findings of the rules in the manifest, the manifest is
+// read leniently so that keys which are declared twice are reported rather
+// than failing the parser
+func (linter *Linter) Lint(manifestPath string) ([]LintFinding, error) {
+	content, err := utils.Read(manifestPath)
+	if err != nil {
+		return nil, wskderrors.NewFileReadError(manifestPath, err.Error())
+	}
+	if content, err = NormalizeContent(content, manifestPath); err != nil {
+		return nil, err
+	}
+	if content, err = ResolveIncludes(content, manifestPath); err != nil {
+		return nil, err
+	}
+	if err = CheckYAMLLimits(content, manifestPath); err != nil {
+		return nil, err
+	}
+
+	manifest := &lintManifest{filePath: manifestPath, packages: make(map[string]Package)}
+	if err := yaml.Unmarshal(content, &manifest.tree); err != nil {
+		return nil, wskderrors.NewYAMLParserErr(manifestPath, err)
+	}
+	var parsed YAML
+	if err := yaml.Unmarshal(content, &parsed); err != nil {
+		return nil, wskderrors.NewYAMLParserErr(manifestPath, err)
+	}
+	if len(parsed.Package.Packagename) > 0 {
+		manifest.packages[parsed.Package.Packagename] = parsed.Package
+	}
+	for name, pkg := range parsed.Packages {
+		manifest.packages[name] = pkg
+	}
+	for name, pkg := range parsed.GetProject().Packages {
+		manifest.packages[name] = pkg
+	}
+
+	findings := make([]LintFinding, 0)
+	for _, rule := range LintRules {
+		severity := rule.Severity
+		if configured, ok := linter.Severities[rule.Name]; ok {
+			severity = configured
+		}
+		if severity == LINT_SEVERITY_OFF {
+			continue
+		}
+		for _, message := range rule.Check(manifest) {
+			findings = append(findings, LintFinding{Rule: rule.Name, Severity: severity, Message: message})
+		}
+	}
+	return findings, nil
+}
+
+// lintManifest is the manifest the rules check, both as a tree which keeps
+// the keys declared twice and as packages
+type lintManifest struct {
+	filePath string
+	tree     yaml.MapSlice
+	packages map[string]Package
+}
+
+// the names of the packages, sorted so that findings are reported in order
+func (manifest *lintManifest) packageNames() []string {
+	names := make([]string, 0, len(manifest.packages))
+	for name := range manifest.packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedActionNames(actions map[string]Action) []string {
+	names := make([]string, 0, len(actions))
+	for name := range actions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func lintDuplicateKeys(manifest *lintManifest) []string {
+	messages := make([]string, 0)
+	var walk func(value interface{}, keyPath string)
+	walk = func(value interface{}, keyPath string) {
+		switch node := value.(type) {
+		case yaml.MapSlice:
+			seen := make(map[string]bool)
+			for _, item := range node {
+				key := fmt.Sprintf("%v", item.Key)
+				if seen[key] {
+					messages = append(messages, wski18n.T(wski18n.ID_MSG_LINT_DUPLICATE_KEY_X_key_X_path_X,
+						map[string]interface{}{wski18n.KEY_KEY: key, wski18n.KEY_PATH: strings.TrimPrefix(keyPath, ".")}))
+				}
+				seen[key] = true
+				walk(item.Value, keyPath+"."+key)
+			}
+		case []interface{}:
+			for index, item := range node {
+				walk(item, fmt.Sprintf("%s[%d]", keyPath, index))
+			}
+		}
+	}
+	walk(manifest.tree, "")
+	return messages
+}
+
+// the inputs of an action are expected to appear in its code, e.g.
+// "params.name", the code of zip files and directories is not checked
+func lintUnusedInputs(manifest *lintManifest) []string {
+	messages := make([]string, 0)
+	for _, packageName := range manifest.packageNames() {
+		actions := manifest.packages[packageName].Actions
+		for _, actionName := range sortedActionNames(actions) {
+			action := actions[actionName]
+			function := action.Function
+			if len(function) == 0 {
+				function = action.Location
+			}
+			ext := strings.TrimPrefix(path.Ext(function), ".")
+			if len(function) == 0 || len(action.Inputs) == 0 || ext == utils.ZIP_FILE_EXTENSION || ext == utils.JAR_FILE_EXTENSION {
+				continue
+			}
+			functionPath := filepath.Join(filepath.Dir(manifest.filePath), function)
+			if utils.IsDirectory(functionPath) {
+				continue
+			}
+			code, err := ioutil.ReadFile(functionPath)
+			if err != nil {
+				continue
+			}
+			inputs := make([]string, 0, len(action.Inputs))
+			for name := range action.Inputs {
+				inputs = append(inputs, name)
+			}
+			sort.Strings(inputs)
+			for _, name := range inputs {
+				if !strings.Contains(string(code), name) {
+					messages = append(messages, wski18n.T(wski18n.ID_MSG_LINT_UNUSED_INPUT_X_key_X_action_X_path_X,
+						map[string]interface{}{wski18n.KEY_KEY: name, wski18n.KEY_ACTION: packageName + "/" + actionName,
+							wski18n.KEY_PATH: function}))
+				}
+			}
+		}
+	}
+	return messages
+}
+
+func lintLongTimeouts(manifest *lintManifest) []string {
+	messages := make([]string, 0)
+	for _, packageName := range manifest.packageNames() {
+		actions := manifest.packages[packageName].Actions
+		for _, actionName := range sortedActionNames(actions) {
+			limits := actions[actionName].Limits
+			if limits != nil && limits.Timeout != nil && *limits.Timeout > LINT_LONG_TIMEOUT {
+				messages = append(messages, wski18n.T(wski18n.ID_MSG_LINT_LONG_TIMEOUT_X_action_X_value_X_max_X,
+					map[string]interface{}{wski18n.KEY_ACTION: packageName + "/" + actionName,
+						wski18n.KEY_VALUE: *limits.Timeout, wski18n.KEY_MAX: LINT_LONG_TIMEOUT}))
+			}
+		}
+	}
+	return messages
+}
+
+// an action is used if it is a web action, or the action of a rule, an
+// action of a sequence or the action of an API of any package
+func lintUnreferencedActions(manifest *lintManifest) []string {
+	referenced := make(map[string]bool)
+	reference := func(packageName string, name string) {
+		parts := strings.Split(strings.Trim(name, "/"), "/")
+		if len(parts) == 1 {
+			referenced[packageName+"/"+parts[0]] = true
+		} else {
+			referenced[strings.Join(parts[len(parts)-2:], "/")] = true
+		}
+	}
+	for packageName, pkg := range manifest.packages {
+		for _, rule := range pkg.Rules {
+			reference(packageName, rule.Action)
+		}
+		for _, sequence := range pkg.Sequences {
+			for _, name := range sequence.Actions {
+				reference(packageName, name)
+			}
+		}
+		for _, basePaths := range pkg.Apis.Routes {
+			for _, paths := range basePaths {
+				for _, actions := range paths {
+					for name := range actions {
+						reference(packageName, name)
+					}
+				}
+			}
+		}
+	}
+
+	messages := make([]string, 0)
+	for _, packageName := range manifest.packageNames() {
+		actions := manifest.packages[packageName].Actions
+		for _, actionName := range sortedActionNames(actions) {
+			action := actions[actionName]
+			web := strings.ToLower(action.Web + action.Webexport)
+			if referenced[packageName+"/"+actionName] || web == "true" || web == "yes" || web == "raw" {
+				continue
+			}
+			messages = append(messages, wski18n.T(wski18n.ID_MSG_LINT_UNREFERENCED_ACTION_X_action_X,
+				map[string]interface{}{wski18n.KEY_ACTION: packageName + "/" + actionName}))
+		}
+	}
+	return messages
+}
+
+func lintMissingDescriptions(manifest *lintManifest) []string {
+	messages := make([]string, 0)
+	missing := func(key string, name string, annotations map[string]interface{}) {
+		if _, ok := annotations[LINT_DESCRIPTION_ANNOTATION]; !ok {
+			messages = append(messages, wski18n.T(wski18n.ID_MSG_LINT_MISSING_DESCRIPTION_X_key_X_name_X,
+				map[string]interface{}{wski18n.KEY_KEY: key, wski18n.KEY_NAME: name}))
+		}
+	}
+	for _, packageName := range manifest.packageNames() {
+		pkg := manifest.packages[packageName]
+		missing(YAML_KEY_PACKAGE, packageName, pkg.Annotations)
+		for _, actionName := range sortedActionNames(pkg.Actions) {
+			missing(YAML_KEY_ACTION, packageName+"/"+actionName, pkg.Actions[actionName].Annotations)
+		}
+	}
+	return messages
+}
@@ -0,0 +1,85 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_LINT_MANIFEST = "../tests/dat/manifest_lint.yaml"
+
+func lintFindings(findings []LintFinding, rule string) []LintFinding {
+	ruleFindings := make([]LintFinding, 0)
+	for _, finding := range findings {
+		if finding.Rule == rule {
+			ruleFindings = append(ruleFindings, finding)
+		}
+	}
+	return ruleFindings
+}
+
+func TestLinter_Lint(t *testing.T) {
+	findings, err := NewLinter().Lint(TEST_LINT_MANIFEST)
+	assert.Nil(t, err)
+
+	duplicates := lintFindings(findings, LINT_RULE_DUPLICATE_KEY)
+	if assert.Equal(t, 1, len(duplicates)) {
+		assert.Equal(t, LINT_SEVERITY_ERROR, duplicates[0].Severity)
+		assert.Contains(t, duplicates[0].Message, "packages.helloworld.actions.greeting.inputs")
+	}
+
+	unused := lintFindings(findings, LINT_RULE_UNUSED_INPUT)
+	if assert.Equal(t, 1, len(unused)) {
+		assert.Contains(t, unused[0].Message, "[unused]")
+	}
+
+	timeouts := lintFindings(findings, LINT_RULE_LONG_TIMEOUT)
+	if assert.Equal(t, 1, len(timeouts)) {
+		assert.Contains(t, timeouts[0].Message, "helloworld/hello")
+	}
+
+	unreferenced := lintFindings(findings, LINT_RULE_UNREFERENCED_ACTION)
+	if assert.Equal(t, 1, len(unreferenced), "hello is the action of a rule and greeting a web action") {
+		assert.Contains(t, unreferenced[0].Message, "helloworld/unreferenced")
+	}
+
+	descriptions := lintFindings(findings, LINT_RULE_MISSING_DESCRIPTION)
+	if assert.Equal(t, 1, len(descriptions)) {
+		assert.Contains(t, descriptions[0].Message, "helloworld/greeting")
+	}
+}
+
+func TestLinter_SetSeverity(t *testing.T) {
+	linter := NewLinter()
+	assert.Nil(t, linter.SetSeverity("unused-input=error"))
+	assert.Nil(t, linter.SetSeverity("missing-description = OFF"))
+	assert.NotNil(t, linter.SetSeverity("unknown-rule=error"))
+	assert.NotNil(t, linter.SetSeverity("long-timeout=fatal"))
+	assert.NotNil(t, linter.SetSeverity("long-timeout"))
+
+	findings, err := linter.Lint(TEST_LINT_MANIFEST)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(lintFindings(findings, LINT_RULE_MISSING_DESCRIPTION)))
+	for _, finding := range lintFindings(findings, LINT_RULE_UNUSED_INPUT) {
+		assert.Equal(t, LINT_SEVERITY_ERROR, finding.Severity)
+	}
+}
@@ -0,0 +1,240 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"gopkg.in/yaml.v2"
+)
+
+// name of a singular package, replaced by its key under "packages"
+const YAML_KEY_NAME = "name"
+
+// matches "key:" or "key: value" and captures the indentation, the key and the rest of the line
+var migrateKeyRegex = regexp.MustCompile(`^(\s*)([^\s#:\-][^:#]*?)\s*:(\s.*)?$`)
+
+// matches the value of a key introducing a block scalar, e.g. "|" or ">-"
+var blockScalarRegex = regexp.MustCompile(`^\s*[|>][-+0-9]*\s*(#.*)?$`)
+
+// deprecated keys of entities and the keys replacing them, indexed by the
+// section ("actions" or "triggers") the entities are declared in
+var entityKeyMigrations = map[string]map[string]string{
+	YAML_KEY_ACTIONS: {
+		YAML_KEY_LOCATION:   YAML_KEY_FUNCTION,
+		YAML_KEY_WEB_EXPORT: YAML_KEY_WEB,
+	},
+	YAML_KEY_TRIGGERS: {
+		YAML_KEY_SOURCE: YAML_KEY_FEED,
+	},
+}
+
+type migrateLine struct {
+	text   string
+	key    string
+	value  string
+	indent int
+	parent int
+	path   []string
+}
+
+// MigrateYAML rewrites the deprecated keys of a manifest or deployment file
+// to the keys of the latest schema:
+//
+//   application: -> project:
+//   package: (with name:) -> packages: <name>:
+//   location: -> function: (actions)
+//   web-export: -> web: (actions)
+//   source: -> feed: (triggers)
+//
+// The file is rewritten line by line so that comments, the order of the
+// entries and the indentation are preserved. A deprecated key is dropped when
+// its replacement is declared as well, since the replacement takes precedence.
+// The migrated content is returned along with the keys which were replaced.
+func MigrateYAML(content []byte, filePath string, fileType string) ([]byte, []DeprecatedKey, error) {
+	if err := yaml.Unmarshal(content, &YAML{}); err != nil {
+		return nil, nil, wskderrors.NewYAMLParserErr(filePath, err.Error())
+	}
+
+	text := strings.Replace(string(content), "\r\n", "\n", -1)
+	trailingNewline := strings.HasSuffix(text, "\n")
+	lines := parseMigrateLines(strings.Split(strings.TrimSuffix(text, "\n"), "\n"))
+
+	// keys declared by each mapping, to detect replacements which already exist
+	siblings := make(map[int]map[string]bool)
+	for _, line := range lines {
+		if len(line.key) > 0 {
+			if siblings[line.parent] == nil {
+				siblings[line.parent] = make(map[string]bool)
+			}
+			siblings[line.parent][line.key] = true
+		}
+	}
+
+	migrated := make([]DeprecatedKey, 0)
+	deprecated := func(entityType string, entityName string, oldKey string, newKey string) {
+		migrated = append(migrated, DeprecatedKey{FilePath: filePath, FileType: fileType,
+			EntityType: entityType, EntityName: entityName, OldKey: oldKey, NewKey: newKey})
+	}
+
+	result := make([]string, 0, len(lines))
+	// lines of a singular "package" block are shifted by one level of indentation
+	shift, shiftEnd, skip := 0, -1, -1
+	for i, line := range lines {
+		if i >= shiftEnd {
+			shift = 0
+		}
+		if i == skip {
+			continue
+		}
+		out := line.text
+		if len(strings.TrimSpace(out)) > 0 {
+			out = strings.Repeat(" ", shift) + out
+		}
+
+		depth := len(line.path)
+		switch {
+		case len(line.key) == 0:
+		case depth == 0 && line.key == YAML_KEY_APPLICATION:
+			out = renameKey(out, YAML_KEY_APPLICATION, YAML_KEY_PROJECT)
+			deprecated("", "", YAML_KEY_APPLICATION, YAML_KEY_PROJECT)
+
+		case line.key == YAML_KEY_PACKAGE && (depth == 0 ||
+			(depth == 1 && (line.path[0] == YAML_KEY_PROJECT || line.path[0] == YAML_KEY_APPLICATION))):
+			end := blockEnd(lines, i)
+			name, nameLine := packageName(lines, i, end)
+			if len(name) == 0 || siblings[line.parent][YAML_KEY_PACKAGES] {
+				break
+			}
+			childIndent := lines[nameLine].indent
+			result = append(result, strings.Repeat(" ", shift)+renameKey(line.text, YAML_KEY_PACKAGE, YAML_KEY_PACKAGES),
+				strings.Repeat(" ", shift+childIndent)+quoteKey(name)+":")
+			shift, shiftEnd, skip = shift+childIndent-line.indent, end, nameLine
+			deprecated("", "", YAML_KEY_PACKAGE, YAML_KEY_PACKAGES)
+			continue
+
+		case depth >= 2 && entityKeyMigrations[line.path[depth-2]] != nil:
+			section := line.path[depth-2]
+			newKey, ok := entityKeyMigrations[section][line.key]
+			if !ok {
+				break
+			}
+			deprecated(strings.TrimSuffix(section, "s"), line.path[depth-1], line.key, newKey)
+			if siblings[line.parent][newKey] {
+				continue
+			}
+			out = renameKey(out, line.key, newKey)
+		}
+		result = append(result, out)
+	}
+
+	output := strings.Join(result, "\n")
+	if trailingNewline {
+		output += "\n"
+	}
+	if err := yaml.Unmarshal([]byte(output), &YAML{}); err != nil {
+		return nil, nil, wskderrors.NewYAMLFileFormatError(filePath, err.Error())
+	}
+	return []byte(output), migrated, nil
+}
+
+// parseMigrateLines identifies the keys of the file along with the path of
+// the mappings they are declared in, the content of block scalars is ignored
+func parseMigrateLines(text []string) []migrateLine {
+	lines := make([]migrateLine, 0, len(text))
+	stack := make([]int, 0)
+	scalarIndent := -1
+	for _, t := range text {
+		line := migrateLine{text: t, parent: -1, indent: len(t) - len(strings.TrimLeft(t, " "))}
+		trimmed := strings.TrimSpace(t)
+		if len(trimmed) == 0 || strings.HasPrefix(trimmed, "#") || (scalarIndent >= 0 && line.indent > scalarIndent) {
+			lines = append(lines, line)
+			continue
+		}
+		scalarIndent = -1
+
+		for len(stack) > 0 && lines[stack[len(stack)-1]].indent >= line.indent {
+			stack = stack[:len(stack)-1]
+		}
+		if m := migrateKeyRegex.FindStringSubmatch(t); m != nil {
+			line.key = strings.Trim(m[2], `"'`)
+			line.value = strings.TrimSpace(m[3])
+			for _, parent := range stack {
+				line.path = append(line.path, lines[parent].key)
+			}
+			if len(stack) > 0 {
+				line.parent = stack[len(stack)-1]
+			}
+			if blockScalarRegex.MatchString(line.value) {
+				scalarIndent = line.indent
+			}
+			stack = append(stack, len(lines))
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// blockEnd returns the index of the first line after the block of the key at
+// line start, trailing comments and blank lines belong to the next entry
+func blockEnd(lines []migrateLine, start int) int {
+	end := start + 1
+	for i := start + 1; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i].text)
+		if len(trimmed) == 0 || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if lines[i].indent <= lines[start].indent {
+			break
+		}
+		end = i + 1
+	}
+	return end
+}
+
+// packageName returns the value of the "name" key of a singular package block
+func packageName(lines []migrateLine, start int, end int) (string, int) {
+	for i := start + 1; i < end; i++ {
+		if lines[i].parent == start && lines[i].key == YAML_KEY_NAME {
+			var name string
+			if err := yaml.Unmarshal([]byte(lines[i].value), &name); err != nil {
+				return "", -1
+			}
+			return name, i
+		}
+	}
+	return "", -1
+}
+
+func renameKey(line string, oldKey string, newKey string) string {
+	m := migrateKeyRegex.FindStringSubmatch(line)
+	if m == nil || strings.Trim(m[2], `"'`) != oldKey {
+		return line
+	}
+	return m[1] + newKey + ":" + m[3]
+}
+
+func quoteKey(key string) string {
+	if migrateKeyRegex.MatchString(key+":") && !strings.ContainsAny(key, `"'`) {
+		return key
+	}
+	return fmt.Sprintf("%q", key)
+}
@@ -0,0 +1,90 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestMigrateYAML(t *testing.T) {
+	manifestFile := "../tests/dat/manifest_migrate_legacy.yaml"
+	content, err := ioutil.ReadFile(manifestFile)
+	assert.Nil(t, err)
+
+	migrated, keys, err := MigrateYAML(content, manifestFile, FILE_TYPE_MANIFEST)
+	assert.Nil(t, err)
+
+	// comments and block scalars are preserved, the package block is indented one level
+	assert.True(t, strings.HasPrefix(string(migrated), "# legacy hello world manifest\npackages:\n  helloworld:\n    version: 1.0\n"))
+	for _, expected := range []string{
+		"      # greets the caller\n      hello:\n        function: src/hello.js # deprecated\n",
+		"        web: true\n",
+		"        function: src/goodbye.js\n        code: |\n          location: not a key\n",
+		"        feed: /whisk.system/alarms/alarm\n",
+	} {
+		assert.True(t, strings.Contains(string(migrated), expected), "missing from migrated manifest: "+expected)
+	}
+	assert.False(t, strings.Contains(string(migrated), "src/old.js"))
+
+	manifest := YAML{}
+	assert.Nil(t, yaml.Unmarshal(migrated, &manifest))
+	assert.Equal(t, "", manifest.Package.Packagename)
+	pkg := manifest.Packages["helloworld"]
+	assert.Equal(t, "1.0", pkg.Version)
+	assert.Equal(t, "src/hello.js", pkg.Actions["hello"].Function)
+	assert.Equal(t, "", pkg.Actions["hello"].Location)
+	assert.Equal(t, "true", pkg.Actions["hello"].Web)
+	assert.Equal(t, "src/goodbye.js", pkg.Actions["goodbye"].Function)
+	assert.Equal(t, "/whisk.system/alarms/alarm", pkg.Triggers["everyMinute"].Feed)
+	assert.Equal(t, "everyMinute", pkg.Rules["greetEveryMinute"].Trigger)
+
+	expectedKeys := []DeprecatedKey{
+		{OldKey: YAML_KEY_PACKAGE, NewKey: YAML_KEY_PACKAGES},
+		{EntityType: YAML_KEY_ACTION, EntityName: "hello", OldKey: YAML_KEY_LOCATION, NewKey: YAML_KEY_FUNCTION},
+		{EntityType: YAML_KEY_ACTION, EntityName: "hello", OldKey: YAML_KEY_WEB_EXPORT, NewKey: YAML_KEY_WEB},
+		{EntityType: YAML_KEY_ACTION, EntityName: "goodbye", OldKey: YAML_KEY_LOCATION, NewKey: YAML_KEY_FUNCTION},
+		{EntityType: YAML_KEY_TRIGGER, EntityName: "everyMinute", OldKey: YAML_KEY_SOURCE, NewKey: YAML_KEY_FEED},
+	}
+	assert.Equal(t, len(expectedKeys), len(keys))
+	for i, key := range keys {
+		expectedKeys[i].FilePath = manifestFile
+		expectedKeys[i].FileType = FILE_TYPE_MANIFEST
+		assert.Equal(t, expectedKeys[i], key)
+	}
+
+	// migrating a migrated manifest does not change it
+	again, keys, err := MigrateYAML(migrated, manifestFile, FILE_TYPE_MANIFEST)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(keys))
+	assert.Equal(t, string(migrated), string(again))
+}
+
+func TestMigrateYAML_Project(t *testing.T) {
+	deployment := "application:\n  name: hello\n  package:\n    name: \"hello world\"\n    inputs:\n      name: Amy\n"
+	migrated, keys, err := MigrateYAML([]byte(deployment), "deployment.yaml", FILE_TYPE_DEPLOYMENT)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(keys))
+	assert.Equal(t, "project:\n  name: hello\n  packages:\n    hello world:\n      inputs:\n        name: Amy\n", string(migrated))
+}
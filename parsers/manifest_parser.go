@@ -18,13 +18,10 @@
 package parsers
 
 import (
-	"errors"
 	"io/ioutil"
 	"os"
 	"path"
 	"strings"
-	"encoding/base64"
-	"fmt"
 	"gopkg.in/yaml.v2"
 
 	"github.com/apache/incubator-openwhisk-client-go/whisk"
@@ -67,7 +64,7 @@ func Write(manifest *YAML, filename string) error {
 }
 
 func (dm *YAMLParser) Unmarshal(input []byte, manifest *YAML) error {
-	err := yaml.UnmarshalStrict(input, manifest)
+	err := unmarshalYAML(input, manifest)
 	if err != nil {
 		return err
 	}
@@ -77,8 +74,6 @@ func (dm *YAMLParser) Unmarshal(input []byte, manifest *YAML) error {
 func (dm *YAMLParser) marshal(manifest *YAML) (output []byte, err error) {
 	data, err := yaml.Marshal(manifest)
 	if err != nil {
-		// TODO() i18n
-		fmt.Printf("err happened during marshal :%v", err)
 		return nil, err
 	}
 	return data, nil
@@ -88,9 +83,41 @@ func (dm *YAMLParser) ParseManifest(manifestPath string) (*YAML, error) {
 	mm := NewYAMLParser()
 	maniyaml := YAML{}
 
-	content, err := utils.Read(manifestPath)
+	// the manifest may define several projects, see --project-name
+	index, err := ReadProjectIndex(manifestPath)
 	if err != nil {
-		return &maniyaml, wskderrors.NewFileReadError(manifestPath, err.Error())
+		return &maniyaml, err
+	}
+	document, err := SelectProject(index, dm.Flags.ProjectName, manifestPath, true)
+	if err != nil {
+		return &maniyaml, err
+	}
+	manifestPath = document.Filepath
+
+	content, err := ResolveIncludes(document.Content, manifestPath)
+	if err != nil {
+		return &maniyaml, err
+	}
+	content, err = ApplyManifestOverlays(content, dm.Overlays, manifestPath)
+	if err != nil {
+		return &maniyaml, err
+	}
+	if err = CheckYAMLLimits(content, manifestPath); err != nil {
+		return &maniyaml, err
+	}
+
+	if err = CheckVersionRequirement(content, manifestPath); err != nil {
+		return &maniyaml, err
+	}
+
+	content, err = ResolveEntityReferences(content, manifestPath, dm.Namespace)
+	if err != nil {
+		return &maniyaml, err
+	}
+
+	content, err = ApplyManifestOverrides(content, dm.Overrides, manifestPath)
+	if err != nil {
+		return &maniyaml, err
 	}
 
 	err = mm.Unmarshal(content, &maniyaml)
@@ -100,6 +127,11 @@ func (dm *YAMLParser) ParseManifest(manifestPath string) (*YAML, error) {
 	maniyaml.Filepath = manifestPath
 	manifest := ReadEnvVariable(&maniyaml)
 
+	// the keys which are not part of the schema are handled by plugins
+	if _, err = ManifestExtensions(manifest, manifestPath); err != nil {
+		return manifest, err
+	}
+
 	return manifest, nil
 }
 
@@ -152,6 +184,12 @@ func (dm *YAMLParser) ComposeDependencies(pkg Package, projectPath string, fileP
 			isBinding = true
 		} else if utils.LocationIsGithub(location) {
 
+			if !utils.LocationIsGithubRepo(location) {
+				return nil, wskderrors.NewYAMLFileFormatError(filePath,
+					wski18n.T(wski18n.ID_ERR_DEPENDENCY_LOCATION_INVALID_X_name_X_location_X,
+						map[string]interface{}{wski18n.KEY_NAME: key, wski18n.KEY_LOCATION: location}))
+			}
+
 			// TODO() define const for the protocol prefix, etc.
 			if !strings.HasPrefix(location, "https://") && !strings.HasPrefix(location, "http://") {
 				location = "https://" + dependency.Location
@@ -159,8 +197,9 @@ func (dm *YAMLParser) ComposeDependencies(pkg Package, projectPath string, fileP
 
 			isBinding = false
 		} else {
-			// TODO() i18n
-			return nil, errors.New("Dependency type is unknown.  wskdeploy only supports /whisk.system bindings or github.com packages.")
+			return nil, wskderrors.NewYAMLFileFormatError(filePath,
+				wski18n.T(wski18n.ID_ERR_DEPENDENCY_TYPE_UNKNOWN_X_name_X_location_X,
+					map[string]interface{}{wski18n.KEY_NAME: key, wski18n.KEY_LOCATION: location}))
 		}
 
 		keyValArrParams := make(whisk.KeyValueArr, 0)
@@ -168,7 +207,7 @@ func (dm *YAMLParser) ComposeDependencies(pkg Package, projectPath string, fileP
 			var keyVal whisk.KeyValue
 			keyVal.Key = name
 
-			keyVal.Value, errorParser = ResolveParameter(name, &param, filePath)
+			keyVal.Value, errorParser = ResolveParameter(name, &param, filePath, dm.Flags.SecretsFromEnv)
 
 			if errorParser != nil {
 				return nil, errorParser
@@ -183,7 +222,7 @@ func (dm *YAMLParser) ComposeDependencies(pkg Package, projectPath string, fileP
 		for name, value := range dependency.Annotations {
 			var keyVal whisk.KeyValue
 			keyVal.Key = name
-			keyVal.Value = wskenv.GetEnvVar(value)
+			keyVal.Value = ResolveAnnotation(value)
 
 			keyValArrAnot = append(keyValArrAnot, keyVal)
 		}
@@ -199,11 +238,20 @@ func (dm *YAMLParser) ComposeDependencies(pkg Package, projectPath string, fileP
 func (dm *YAMLParser) ComposeAllPackages(manifest *YAML, filePath string, ma whisk.KeyValue) (map[string]*whisk.Package, error) {
 	packages := map[string]*whisk.Package{}
 	manifestPackages := make(map[string]Package)
+	project := manifest.GetProject()
+	if _, err := inheritsActionInputs(project, filePath); err != nil {
+		return nil, err
+	}
 
 	if manifest.Package.Packagename != "" {
-		// TODO() i18n
-		fmt.Println("WARNING: using package inside of manifest file will soon be deprecated, please use packages instead.")
-		s, err := dm.ComposePackage(manifest.Package, manifest.Package.Packagename, filePath, ma)
+		Deprecations.Add(DeprecatedKey{FilePath: filePath, FileType: FILE_TYPE_MANIFEST,
+			OldKey: YAML_KEY_PACKAGE, NewKey: YAML_KEY_PACKAGES})
+		pkg, err := inheritProject(project, manifest.Package, manifest.Package.Packagename, filePath)
+		if err != nil {
+			return nil, err
+		}
+		pkg.Inputs = inheritInputs(project.Inputs, pkg.Inputs)
+		s, err := dm.ComposePackage(pkg, manifest.Package.Packagename, filePath, ma)
 		if err == nil {
 			packages[manifest.Package.Packagename] = s
 		} else {
@@ -213,11 +261,16 @@ func (dm *YAMLParser) ComposeAllPackages(manifest *YAML, filePath string, ma whi
 		if len(manifest.Packages) != 0 {
 			manifestPackages = manifest.Packages
 		} else {
-			manifestPackages = manifest.GetProject().Packages
+			manifestPackages = project.Packages
 		}
 	}
 
 	for n, p := range manifestPackages {
+		p, err := inheritProject(project, p, n, filePath)
+		if err != nil {
+			return nil, err
+		}
+		p.Inputs = inheritInputs(project.Inputs, p.Inputs)
 		s, err := dm.ComposePackage(p, n, filePath, ma)
 
 		if err == nil {
@@ -230,10 +283,139 @@ func (dm *YAMLParser) ComposeAllPackages(manifest *YAML, filePath string, ma whi
 	return packages, nil
 }
 
+// inheritInputs merges the inputs of the project into the inputs of a package
+// or an action, an input of the package or action overrides the input of the
+// project with the same name
+func inheritInputs(projectInputs map[string]Parameter, inputs map[string]Parameter) map[string]Parameter {
+	if len(projectInputs) == 0 {
+		return inputs
+	}
+	merged := make(map[string]Parameter, len(projectInputs)+len(inputs))
+	for name, param := range projectInputs {
+		merged[name] = param
+	}
+	for name, param := range inputs {
+		merged[name] = param
+	}
+	return merged
+}
+
+// inheritAnnotations merges the annotations of the project into the annotations
+// of a package, action, sequence or trigger, an annotation of the entity
+// overrides the annotation of the project with the same name. With
+// "inherit-annotations: false" the entity inherits no annotation, with a list of
+// names it inherits the annotations listed only.
+func inheritAnnotations(projectAnnotations map[string]interface{}, inherit interface{}, annotations map[string]interface{}, filePath string, entityName string) (map[string]interface{}, error) {
+	// names of the annotations inherited, all of them if nil
+	var names map[string]bool
+	switch value := inherit.(type) {
+	case nil:
+	case bool:
+		if !value {
+			names = make(map[string]bool)
+		}
+	case []interface{}:
+		names = make(map[string]bool, len(value))
+		for _, name := range value {
+			str, ok := name.(string)
+			if !ok {
+				return nil, inheritAnnotationsError(filePath, entityName, inherit)
+			}
+			names[str] = true
+		}
+	default:
+		return nil, inheritAnnotationsError(filePath, entityName, inherit)
+	}
+
+	if len(projectAnnotations) == 0 {
+		return annotations, nil
+	}
+	merged := make(map[string]interface{}, len(projectAnnotations)+len(annotations))
+	for name, value := range projectAnnotations {
+		if names == nil || names[name] {
+			merged[name] = value
+		}
+	}
+	for name, value := range annotations {
+		merged[name] = value
+	}
+	return merged, nil
+}
+
+func inheritAnnotationsError(filePath string, entityName string, inherit interface{}) error {
+	return wskderrors.NewYAMLFileFormatError(filePath,
+		wski18n.T(wski18n.ID_ERR_INHERIT_ANNOTATIONS_INVALID_X_name_X_value_X,
+			map[string]interface{}{wski18n.KEY_NAME: entityName, wski18n.KEY_VALUE: inherit}))
+}
+
+// inheritProject returns a copy of the package whose annotations, and
+// the ones of its actions, sequences and triggers, inherit the annotations of the
+// project, see inheritAnnotations(). The "inherit-annotations" of the package
+// applies to its entities which do not set their own. The limits of its actions
+// inherit the ones of the package and project, see InheritLimits().
+func inheritProject(project Project, pkg Package, packageName string, filePath string) (Package, error) {
+	var err error
+	if pkg.Annotations, err = inheritAnnotations(project.Annotations, pkg.InheritAnnotations, pkg.Annotations, filePath, packageName); err != nil {
+		return pkg, err
+	}
+	inherit := func(entityInherit interface{}) interface{} {
+		if entityInherit == nil {
+			return pkg.InheritAnnotations
+		}
+		return entityInherit
+	}
+
+	actions := make(map[string]Action, len(pkg.Actions))
+	for name, action := range pkg.Actions {
+		if action.Annotations, err = inheritAnnotations(project.Annotations, inherit(action.InheritAnnotations), action.Annotations, filePath, name); err != nil {
+			return pkg, err
+		}
+		action.Limits = InheritLimits(action.Limits, pkg.Limits, project.Limits)
+		actions[name] = action
+	}
+	pkg.Actions = actions
+
+	sequences := make(map[string]Sequence, len(pkg.Sequences))
+	for name, sequence := range pkg.Sequences {
+		if sequence.Annotations, err = inheritAnnotations(project.Annotations, inherit(sequence.InheritAnnotations), sequence.Annotations, filePath, name); err != nil {
+			return pkg, err
+		}
+		sequences[name] = sequence
+	}
+	pkg.Sequences = sequences
+
+	triggers := make(map[string]Trigger, len(pkg.Triggers))
+	for name, trigger := range pkg.Triggers {
+		if trigger.Annotations, err = inheritAnnotations(project.Annotations, inherit(trigger.InheritAnnotations), trigger.Annotations, filePath, name); err != nil {
+			return pkg, err
+		}
+		triggers[name] = trigger
+	}
+	pkg.Triggers = triggers
+	return pkg, nil
+}
+
+// inheritsActionInputs returns true if the inputs of the project are set on
+// actions as well as on packages
+func inheritsActionInputs(project Project, filePath string) (bool, error) {
+	switch project.InputsScope {
+	case "", YAML_VALUE_INPUTS_SCOPE_PACKAGES:
+		return false, nil
+	case YAML_VALUE_INPUTS_SCOPE_ALL:
+		return true, nil
+	}
+	return false, wskderrors.NewYAMLFileFormatError(filePath,
+		wski18n.T(wski18n.ID_ERR_INPUTS_SCOPE_INVALID_X_value_X,
+			map[string]interface{}{wski18n.KEY_VALUE: project.InputsScope}))
+}
+
 func (dm *YAMLParser) ComposePackage(pkg Package, packageName string, filePath string, ma whisk.KeyValue) (*whisk.Package, error) {
 	var errorParser error
 	pag := &whisk.Package{}
 	pag.Name = packageName
+	if err := dm.checkNamingConvention(YAML_KEY_PACKAGE, packageName); err != nil {
+		return nil, err
+	}
 	//The namespace for this package is absent, so we use default guest here.
 	pag.Namespace = pkg.Namespace
 	pub := false
@@ -278,8 +460,16 @@ func (dm *YAMLParser) ComposePackage(pkg Package, packageName string, filePath s
 		wskprint.PrintOpenWhiskWarning(warningString)
 
 		pkg.License = DEFAULT_PACKAGE_LICENSE
-	} else {
-		utils.CheckLicense(pkg.License)
+	} else if err := dm.Flags.ValidateLicense(pkg.License); err != nil {
+		// an allow-list which cannot be read always fails, invalid and
+		// disallowed licenses only fail in strict mode
+		if _, ok := err.(*wskderrors.FileReadError); ok {
+			return nil, err
+		}
+		if dm.Flags.Strict {
+			return nil, wskderrors.NewYAMLFileFormatError(filePath, err.Error())
+		}
+		wskprint.PrintlnOpenWhiskWarning(err.Error())
 	}
 
 	//set parameters
@@ -288,7 +478,7 @@ func (dm *YAMLParser) ComposePackage(pkg Package, packageName string, filePath s
 		var keyVal whisk.KeyValue
 		keyVal.Key = name
 
-		keyVal.Value, errorParser = ResolveParameter(name, &param, filePath)
+		keyVal.Value, errorParser = ResolveParameter(name, &param, filePath, dm.Flags.SecretsFromEnv)
 
 		if errorParser != nil {
 			return nil, errorParser
@@ -308,15 +498,21 @@ func (dm *YAMLParser) ComposePackage(pkg Package, packageName string, filePath s
 	for name, value := range pkg.Annotations {
 		var keyVal whisk.KeyValue
 		keyVal.Key = name
-		keyVal.Value = wskenv.GetEnvVar(value)
+		keyVal.Value = ResolveAnnotation(value)
 		listOfAnnotations = append(listOfAnnotations, keyVal)
 	}
 	if len(listOfAnnotations) > 0 {
 		pag.Annotations = append(pag.Annotations, listOfAnnotations...)
 	}
+	pag.Annotations = AddEncryptedInputs(pag.Annotations, pkg.Inputs)
+
+	// the version of an immutable package is checked against the deployed one
+	if dm.Flags.ImmutableVersions {
+		pag.Annotations = append(pag.Annotations, whisk.KeyValue{Key: ANNOTATION_PACKAGE_VERSION, Value: pkg.Version})
+	}
 
 	// add Managed Annotations if this is Managed Deployment
-	if utils.Flags.Managed {
+	if dm.Flags.Managed {
 		pag.Annotations = append(pag.Annotations, ma)
 	}
 
@@ -329,7 +525,18 @@ func (dm *YAMLParser) ComposeSequencesFromAllPackages(namespace string, mani *YA
 	manifestPackages := make(map[string]Package)
 
 	if mani.Package.Packagename != "" {
-		return dm.ComposeSequences(namespace, mani.Package.Sequences, mani.Package.Packagename, ma)
+		pkg, err := inheritProject(mani.GetProject(), mani.Package, mani.Package.Packagename, mani.Filepath)
+		if err != nil {
+			return nil, err
+		}
+		s, err := dm.composeSequences(mani.Filepath, getPackageNamespace(pkg, namespace), pkg.Sequences, pkg.Packagename, nil, ma)
+		if err != nil {
+			return nil, err
+		}
+		if err := CheckSequenceCycles(mani.Filepath, s); err != nil {
+			return nil, err
+		}
+		return s, nil
 	} else {
 		if len(mani.Packages) != 0 {
 			manifestPackages = mani.Packages
@@ -338,33 +545,70 @@ func (dm *YAMLParser) ComposeSequencesFromAllPackages(namespace string, mani *YA
 		}
 	}
 
+	// sequences may refer to the actions of the other packages, which are
+	// deployed to the namespace of their package
+	packageNamespaces := make(map[string]string)
+	for n, p := range manifestPackages {
+		packageNamespaces[n] = getPackageNamespace(p, namespace)
+	}
+
 	for n, p := range manifestPackages {
-		s, err := dm.ComposeSequences(namespace, p.Sequences, n, ma)
+		p, err := inheritProject(mani.GetProject(), p, n, mani.Filepath)
+		if err != nil {
+			return nil, err
+		}
+		s, err := dm.composeSequences(mani.Filepath, getPackageNamespace(p, namespace), p.Sequences, n, packageNamespaces, ma)
 		if err == nil {
 			s1 = append(s1, s...)
 		} else {
 			return nil, err
 		}
 	}
+	if err := CheckSequenceCycles(mani.Filepath, s1); err != nil {
+		return nil, err
+	}
 	return s1, nil
 }
 
+// getPackageNamespace returns the namespace declared by the package, if any,
+// or the given default namespace
+func getPackageNamespace(pkg Package, namespace string) string {
+	if len(pkg.Namespace) > 0 {
+		return pkg.Namespace
+	}
+	return namespace
+}
+
+func setActionsNamespace(records []utils.ActionRecord, namespace string) {
+	for _, record := range records {
+		record.Action.Namespace = namespace
+	}
+}
+
 func (dm *YAMLParser) ComposeSequences(namespace string, sequences map[string]Sequence, packageName string, ma whisk.KeyValue) ([]utils.ActionRecord, error) {
+	return dm.composeSequences("", namespace, sequences, packageName, nil, ma)
+}
+
+func (dm *YAMLParser) composeSequences(filePath string, namespace string, sequences map[string]Sequence, packageName string, packageNamespaces map[string]string, ma whisk.KeyValue) ([]utils.ActionRecord, error) {
 	var s1 []utils.ActionRecord = make([]utils.ActionRecord, 0)
 
 	for key, sequence := range sequences {
+		if err := dm.checkNamingConvention(YAML_KEY_SEQUENCE, key); err != nil {
+			return nil, err
+		}
 		wskaction := new(whisk.Action)
 		wskaction.Exec = new(whisk.Exec)
 		wskaction.Exec.Kind = YAML_KEY_SEQUENCE
-		actionList := strings.Split(sequence.Actions, ",")
 
 		var components []string
-		for _, a := range actionList {
-			act := strings.TrimSpace(a)
-			if !strings.ContainsRune(act, '/') && !strings.HasPrefix(act, packageName +"/") {
-				act = path.Join(packageName, act)
+		for _, a := range sequence.Actions {
+			component, ok := resolveSequenceComponent(strings.TrimSpace(a), namespace, packageName, packageNamespaces)
+			if !ok {
+				return nil, wskderrors.NewYAMLFileFormatError(filePath,
+					wski18n.T(wski18n.ID_ERR_SEQUENCE_COMPONENT_INVALID_X_sequence_X_action_X,
+						map[string]interface{}{wski18n.KEY_SEQUENCE: key, wski18n.KEY_ACTION: a}))
 			}
-			components = append(components, path.Join("/"+namespace, act))
+			components = append(components, component)
 		}
 
 		wskaction.Exec.Components = components
@@ -377,7 +621,7 @@ func (dm *YAMLParser) ComposeSequences(namespace string, sequences map[string]Se
 		for name, value := range sequence.Annotations {
 			var keyVal whisk.KeyValue
 			keyVal.Key = name
-			keyVal.Value = wskenv.GetEnvVar(value)
+			keyVal.Value = ResolveAnnotation(value)
 
 			keyValArr = append(keyValArr, keyVal)
 		}
@@ -386,8 +630,16 @@ func (dm *YAMLParser) ComposeSequences(namespace string, sequences map[string]Se
 			wskaction.Annotations = keyValArr
 		}
 
+		if len(sequence.Web) > 0 {
+			annotations, err := WebSequenceAnnotations(filePath, key, sequence.Web, wskaction.Annotations)
+			if err != nil {
+				return nil, err
+			}
+			wskaction.Annotations = annotations
+		}
+
 		// appending managed annotations if its a managed deployment
-		if utils.Flags.Managed {
+		if dm.Flags.Managed {
 			wskaction.Annotations = append(wskaction.Annotations, ma)
 		}
 
@@ -397,22 +649,91 @@ func (dm *YAMLParser) ComposeSequences(namespace string, sequences map[string]Se
 	return s1, nil
 }
 
+// WebSequenceAnnotations returns the annotations of a sequence with the web
+// export annotations of web, i.e. true, false or raw
+func WebSequenceAnnotations(filePath string, sequenceName string, web string, annotations whisk.KeyValueArr) (whisk.KeyValueArr, error) {
+	annotations, err := utils.WebAction(web, annotations, false)
+	if err != nil {
+		return nil, wskderrors.NewYAMLFileFormatError(filePath,
+			wski18n.T(wski18n.ID_ERR_SEQUENCE_WEB_INVALID_X_sequence_X_value_X,
+				map[string]interface{}{wski18n.KEY_SEQUENCE: sequenceName, wski18n.KEY_VALUE: web}))
+	}
+	return annotations, nil
+}
+
+// resolveSequenceComponent returns the fully qualified name of an action of a
+// sequence, which is either:
+//   "action", an action of the package of the sequence
+//   "package/action", an action of another package of the manifest (deployed to
+//     the namespace of that package) or of a package of the same namespace
+//   "/namespace/package/action" or "/namespace/action", a fully qualified action
+func resolveSequenceComponent(component string, namespace string, packageName string, packageNamespaces map[string]string) (string, bool) {
+	qualified := strings.HasPrefix(component, "/")
+	parts := strings.Split(strings.TrimPrefix(component, "/"), "/")
+	for _, part := range parts {
+		if len(part) == 0 {
+			return "", false
+		}
+	}
+
+	switch {
+	case qualified && (len(parts) == 2 || len(parts) == 3):
+		return component, true
+	case !qualified && len(parts) == 1:
+		return path.Join("/"+namespace, packageName, component), true
+	case !qualified && len(parts) == 2:
+		if ns, ok := packageNamespaces[parts[0]]; ok {
+			namespace = ns
+		}
+		return path.Join("/"+namespace, component), true
+	}
+	return "", false
+}
+
 func (dm *YAMLParser) ComposeActionsFromAllPackages(manifest *YAML, filePath string, ma whisk.KeyValue) ([]utils.ActionRecord, error) {
 	var s1 []utils.ActionRecord = make([]utils.ActionRecord, 0)
 	manifestPackages := make(map[string]Package)
 
+	project := manifest.GetProject()
+	inherits, err := inheritsActionInputs(project, filePath)
+	if err != nil {
+		return nil, err
+	}
+	actionsWithInputs := func(actions map[string]Action) map[string]Action {
+		if !inherits {
+			return actions
+		}
+		result := make(map[string]Action, len(actions))
+		for name, action := range actions {
+			action.Inputs = inheritInputs(project.Inputs, action.Inputs)
+			result[name] = action
+		}
+		return result
+	}
+
 	if manifest.Package.Packagename != "" {
-		return dm.ComposeActions(filePath, manifest.Package.Actions, manifest.Package.Packagename, ma)
+		pkg, err := inheritProject(project, manifest.Package, manifest.Package.Packagename, filePath)
+		if err != nil {
+			return nil, err
+		}
+		s1, err := dm.ComposeActions(filePath, actionsWithInputs(pkg.Actions), manifest.Package.Packagename, ma)
+		setActionsNamespace(s1, manifest.Package.Namespace)
+		return s1, err
 	} else {
 		if len(manifest.Packages) != 0 {
 			manifestPackages = manifest.Packages
 		} else {
-			manifestPackages = manifest.GetProject().Packages
+			manifestPackages = project.Packages
 		}
 	}
 	for n, p := range manifestPackages {
-		a, err := dm.ComposeActions(filePath, p.Actions, n, ma)
+		p, err := inheritProject(project, p, n, filePath)
+		if err != nil {
+			return nil, err
+		}
+		a, err := dm.ComposeActions(filePath, actionsWithInputs(p.Actions), n, ma)
 		if err == nil {
+			setActionsNamespace(a, p.Namespace)
 			s1 = append(s1, a...)
 		} else {
 			return nil, err
@@ -422,274 +743,20 @@ func (dm *YAMLParser) ComposeActionsFromAllPackages(manifest *YAML, filePath str
 }
 
 func (dm *YAMLParser) ComposeActions(filePath string, actions map[string]Action, packageName string, ma whisk.KeyValue) ([]utils.ActionRecord, error) {
-
-	// TODO() i18n
-	const RUNTIME_ERR_MESSAGE = "Please specify any of the supported runtime for zip actions in manifest YAML."
-	var errorParser error
-	var ext string
 	var s1 []utils.ActionRecord = make([]utils.ActionRecord, 0)
 
 	for key, action := range actions {
-		splitFilePath := strings.Split(filePath, string(os.PathSeparator))
-		// set the name of the action (which is the key)
-		action.Name = key
-
-		// Create action data object with CLI
-		wskaction := new(whisk.Action)
-		wskaction.Exec = new(whisk.Exec)
-
-		/*
-   		 *  Action.Function
-   		 */
-		//set action.Function to action.Location
-		//because Location is deprecated in Action entity
-		if action.Function == "" && action.Location != "" {
-			action.Function = action.Location
-		}
-
-		//bind action, and exposed URL
-		if action.Function != "" {
-
-			filePath := strings.TrimRight(filePath, splitFilePath[len(splitFilePath)-1]) + action.Function
-
-			if utils.IsDirectory(filePath) {
-				// TODO() define ext as const
-				zipName := filePath + ".zip"
-				err := utils.NewZipWritter(filePath, zipName).Zip()
-				if err != nil {
-					return nil, err
-				}
-				// TODO() do not use defer in a loop, resource leaks possible
-				defer os.Remove(zipName)
-				// TODO(): support docker and main entry as did by go cli?
-				wskaction.Exec, err = utils.GetExec(zipName, action.Runtime, false, "")
-				if err != nil {
-					return nil, err
-				}
-			} else {
-				ext = path.Ext(filePath)
-				// drop the "." from file extension
-				if len(ext) > 0 && ext[0] == '.' {
-					ext = ext[1:]
-				}
-
-				// determine default runtime for the given file extension
-				var kind string
-				r := utils.FileExtensionRuntimeKindMap[ext]
-				kind = utils.DefaultRunTimes[r]
-
-				// produce an error when a runtime could not be derived from the action file extension
-				// and its not explicitly specified in the manifest YAML file
-				// and action source is not a zip file
-				if len(kind) == 0 && len(action.Runtime) == 0 && ext != utils.ZIP_FILE_EXTENSION {
-					// TODO() i18n
-					errMessage := "ERROR: Failed to discover runtime from the action source files. " + RUNTIME_ERR_MESSAGE
-					return nil, wskderrors.NewInvalidRuntimeError(errMessage, splitFilePath[len(splitFilePath)-1], action.Name, "Not Specified in Manifest YAML", utils.ListOfSupportedRuntimes(utils.SupportedRunTimes))
-				}
-
-				wskaction.Exec.Kind = kind
-
-				action.Function = filePath
-				dat, err := utils.Read(filePath)
-				if err != nil {
-					return s1, err
-				}
-				code := string(dat)
-				if ext == utils.ZIP_FILE_EXTENSION || ext == utils.JAR_FILE_EXTENSION {
-					code = base64.StdEncoding.EncodeToString([]byte(dat))
-				}
-				if ext == utils.ZIP_FILE_EXTENSION && len(action.Runtime) == 0 {
-					// TODO() i18n
-					errMessage := "ERROR: Runtime is missing for zip action. " + RUNTIME_ERR_MESSAGE
-					return nil, wskderrors.NewInvalidRuntimeError(errMessage, splitFilePath[len(splitFilePath)-1], action.Name, "Not Specified in Manifest YAML", utils.ListOfSupportedRuntimes(utils.SupportedRunTimes))
-				}
-				wskaction.Exec.Code = &code
-			}
-
-		}
-
-		/*
- 		 *  Action.Runtime
-		 *  Perform few checks if action runtime is specified in manifest YAML file
-		 *  (1) Check if specified runtime is one of the supported runtimes by OpenWhisk server
-		 *  (2) Check if specified runtime is consistent with action source file extensions
-		 *  Set the action runtime to match with the source file extension, if wskdeploy is not invoked in strict mode
- 		 */
-		if action.Runtime != "" {
-			if utils.CheckExistRuntime(action.Runtime, utils.SupportedRunTimes) {
-				// for zip actions, rely on the runtimes from the manifest file as it can not be derived from the action source file extension
-				// pick runtime from manifest file if its supported by OpenWhisk server
-				if ext == utils.ZIP_FILE_EXTENSION {
-					wskaction.Exec.Kind = action.Runtime
-				} else {
-					if utils.CheckRuntimeConsistencyWithFileExtension(ext, action.Runtime) {
-						wskaction.Exec.Kind = action.Runtime
-					} else {
-						errStr := wski18n.T(wski18n.ID_MSG_RUNTIME_MISMATCH_X_runtime_X_ext_X_action_X,
-							map[string]interface{}{"runtime": action.Runtime, "ext": ext, "action": action.Name})
-						wskprint.PrintOpenWhiskWarning(errStr)
-
-						// even if runtime is not consistent with file extension, deploy action with specified runtime in strict mode
-						if utils.Flags.Strict {
-							wskaction.Exec.Kind = action.Runtime
-						} else {
-							errStr := wski18n.T(wski18n.ID_MSG_RUNTIME_CHANGED_X_runtime_X_action_X,
-								map[string]interface{}{"runtime": wskaction.Exec.Kind, "action": action.Name})
-							wskprint.PrintOpenWhiskWarning(errStr)
-						}
-					}
-				}
-			} else {
-				errStr := wski18n.T(wski18n.ID_MSG_RUNTIME_UNSUPPORTED_X_runtime_X_action_X,
-					map[string]interface{}{"runtime": action.Runtime, "action": action.Name})
-				whisk.Debug(whisk.DbgWarn, errStr)
-				if ext == utils.ZIP_FILE_EXTENSION {
-					// TODO() i18n
-					// for zip action, error out if specified runtime is not supported by OpenWhisk server
-					errMessage := "ERROR: Given runtime for a zip action is not supported by OpenWhisk server. " + RUNTIME_ERR_MESSAGE
-					return nil, wskderrors.NewInvalidRuntimeError(errMessage, splitFilePath[len(splitFilePath)-1], action.Name, action.Runtime, utils.ListOfSupportedRuntimes(utils.SupportedRunTimes))
-				} else {
-					errStr = wski18n.T(wski18n.ID_MSG_RUNTIME_CHANGED_X_runtime_X_action_X,
-						map[string]interface{}{"runtime": wskaction.Exec.Kind, "action": action.Name})
-					whisk.Debug(whisk.DbgWarn, errStr)
-				}
-
-			}
-		}
-
-		// we can specify the name of the action entry point using main
-		if action.Main != "" {
-			wskaction.Exec.Main = action.Main
-		}
-
-		/*
-		 *  Action.Inputs
-		 */
-		keyValArr := make(whisk.KeyValueArr, 0)
-		for name, param := range action.Inputs {
-			var keyVal whisk.KeyValue
-			keyVal.Key = name
-			keyVal.Value, errorParser = ResolveParameter(name, &param, filePath)
-
-			if errorParser != nil {
-				return nil, errorParser
-			}
-
-			if keyVal.Value != nil {
-				keyValArr = append(keyValArr, keyVal)
-			}
-		}
-
-		// if we have successfully parser valid key/value parameters
-		if len(keyValArr) > 0 {
-			wskaction.Parameters = keyValArr
-		}
-
-		/*
- 		 *  Action.Outputs
-		 */
-		keyValArr = make(whisk.KeyValueArr, 0)
-		for name, param := range action.Outputs {
-			var keyVal whisk.KeyValue
-			keyVal.Key = name
-			keyVal.Value, errorParser = ResolveParameter(name, &param, filePath)
-
-			// short circuit on error
-			if errorParser != nil {
-				return nil, errorParser
-			}
-
-			if keyVal.Value != nil {
-				keyValArr = append(keyValArr, keyVal)
-			}
-		}
-
-		// TODO{} add outputs as annotations (work to discuss officially supporting for compositions)
-		if len(keyValArr) > 0 {
-			// TODO() ?
-			//wskaction.Annotations  // TBD
-		}
-
-		/*
- 		 *  Action.Annotations
- 		 */
-		listOfAnnotations := make(whisk.KeyValueArr, 0)
-		for name, value := range action.Annotations {
-			var keyVal whisk.KeyValue
-			keyVal.Key = name
-			keyVal.Value = wskenv.GetEnvVar(value)
-			listOfAnnotations = append(listOfAnnotations, keyVal)
-		}
-		if len(listOfAnnotations) > 0 {
-			wskaction.Annotations = append(wskaction.Annotations, listOfAnnotations...)
-		}
-		// add managed annotations if its marked as managed deployment
-		if utils.Flags.Managed {
-			wskaction.Annotations = append(wskaction.Annotations, ma)
-		}
-
-		/*
-  		 *  Web Export
-  		 */
-		// TODO() add boolean value const
-		if action.Webexport == "true" {
-			wskaction.Annotations, errorParser = utils.WebAction("yes", listOfAnnotations, false)
-			if errorParser != nil {
-				return s1, errorParser
-			}
+		if err := dm.checkNamingConvention(YAML_KEY_ACTION, key); err != nil {
+			return nil, err
 		}
-
-		/*
- 		 *  Action.Limits
- 		 */
-		if action.Limits!=nil {
-			wsklimits :=  new(whisk.Limits)
-
-			// TODO() use LIMITS_SUPPORTED in yamlparser to enumerata through instead of hardcoding
-			// perhaps change into a tuple
-			if utils.LimitsTimeoutValidation(action.Limits.Timeout) {
-				wsklimits.Timeout = action.Limits.Timeout
-			} else {
-				warningString := wski18n.T(wski18n.ID_MSG_ACTION_LIMIT_IGNORED_X_limit_X,
-					map[string]interface{}{wski18n.KEY_LIMIT: LIMIT_VALUE_TIMEOUT})
-				wskprint.PrintOpenWhiskWarning(warningString)
-			}
-			if utils.LimitsMemoryValidation(action.Limits.Memory) {
-				wsklimits.Memory = action.Limits.Memory
-			} else {
-				warningString := wski18n.T(wski18n.ID_MSG_ACTION_LIMIT_IGNORED_X_limit_X,
-					map[string]interface{}{wski18n.KEY_LIMIT: LIMIT_VALUE_MEMORY_SIZE})
-				wskprint.PrintOpenWhiskWarning(warningString)
-			}
-			if utils.LimitsLogsizeValidation(action.Limits.Logsize) {
-				wsklimits.Logsize = action.Limits.Logsize
-			} else {
-				warningString := wski18n.T(wski18n.ID_MSG_ACTION_LIMIT_IGNORED_X_limit_X,
-					map[string]interface{}{wski18n.KEY_LIMIT: LIMIT_VALUE_LOG_SIZE})
-				wskprint.PrintOpenWhiskWarning(warningString)
-			}
-			if wsklimits.Timeout!=nil || wsklimits.Memory!=nil || wsklimits.Logsize!=nil {
-				wskaction.Limits = wsklimits
-			}
-
-			// TODO() use LIMITS_UNSUPPORTED in yamlparser to enumerata through instead of hardcoding
-			// emit warning errors if these limits are not nil
-			utils.NotSupportLimits(action.Limits.ConcurrentActivations, LIMIT_VALUE_CONCURRENT_ACTIVATIONS)
-			utils.NotSupportLimits(action.Limits.UserInvocationRate, LIMIT_VALUE_USER_INVOCATION_RATE)
-			utils.NotSupportLimits(action.Limits.CodeSize, LIMIT_VALUE_CODE_SIZE)
-			utils.NotSupportLimits(action.Limits.ParameterSize, LIMIT_VALUE_PARAMETER_SIZE)
+		record, err := NewActionBuilder(filePath, packageName, key, action, ma, dm.Flags).Build()
+		if err != nil {
+			return nil, err
 		}
-
-		wskaction.Name = key
-		pub := false
-		wskaction.Publish = &pub
-
-		record := utils.ActionRecord{Action: wskaction, Packagename: packageName, Filepath: action.Function}
 		s1 = append(s1, record)
 	}
 
 	return s1, nil
-
 }
 
 func (dm *YAMLParser) ComposeTriggersFromAllPackages(manifest *YAML, filePath string, ma whisk.KeyValue) ([]*whisk.Trigger, error) {
@@ -697,7 +764,15 @@ func (dm *YAMLParser) ComposeTriggersFromAllPackages(manifest *YAML, filePath st
 	manifestPackages := make(map[string]Package)
 
 	if manifest.Package.Packagename != "" {
-		return dm.ComposeTriggers(filePath, manifest.Package, ma)
+		pkg, err := inheritProject(manifest.GetProject(), manifest.Package, manifest.Package.Packagename, filePath)
+		if err != nil {
+			return nil, err
+		}
+		t, err := dm.ComposeTriggers(filePath, pkg, ma)
+		if err != nil {
+			return nil, err
+		}
+		return t, resolveLocalFeeds(manifest, filePath, t)
 	} else {
 		if len(manifest.Packages) != 0 {
 			manifestPackages = manifest.Packages
@@ -705,7 +780,11 @@ func (dm *YAMLParser) ComposeTriggersFromAllPackages(manifest *YAML, filePath st
 			manifestPackages = manifest.GetProject().Packages
 		}
 	}
-	for _, p := range manifestPackages {
+	for n, p := range manifestPackages {
+		p, err := inheritProject(manifest.GetProject(), p, n, filePath)
+		if err != nil {
+			return nil, err
+		}
 		t, err := dm.ComposeTriggers(filePath, p, ma)
 		if err == nil {
 			triggers = append(triggers, t...)
@@ -713,7 +792,59 @@ func (dm *YAMLParser) ComposeTriggersFromAllPackages(manifest *YAML, filePath st
 			return nil, err
 		}
 	}
-	return triggers, nil
+	return triggers, resolveLocalFeeds(manifest, filePath, triggers)
+}
+
+// resolveLocalFeeds checks the feeds of triggers which are actions of the
+// manifest, e.g. "feed: mypackage/myFeedAction", they must be annotated as
+// feeds. Actions are deployed before triggers, so that the feed action exists
+// by the time the trigger is created. The feed of a package deployed to its own
+// namespace is qualified with the namespace.
+func resolveLocalFeeds(manifest *YAML, filePath string, triggers []*whisk.Trigger) error {
+	packages := manifest.GetPackages()
+	for _, trigger := range triggers {
+		feedName, isFeed := utils.IsFeedAction(trigger)
+		if !isFeed || strings.HasPrefix(feedName, "/") {
+			continue
+		}
+		names := strings.Split(feedName, "/")
+		if len(names) != 2 {
+			continue
+		}
+		pkg, ok := packages[names[0]]
+		if !ok {
+			continue
+		}
+		action, ok := pkg.Actions[names[1]]
+		if !ok {
+			continue
+		}
+
+		if !isFeedAnnotation(action.Annotations[YAML_KEY_FEED]) {
+			return wskderrors.NewYAMLFileFormatError(filePath,
+				wski18n.T(wski18n.ID_ERR_FEED_ACTION_NOT_ANNOTATED_X_trigger_X_action_X_key_X,
+					map[string]interface{}{wski18n.KEY_TRIGGER: trigger.Name, wski18n.KEY_ACTION: feedName,
+						wski18n.KEY_KEY: YAML_KEY_FEED}))
+		}
+		if len(pkg.Namespace) > 0 {
+			for i, annotation := range trigger.Annotations {
+				if annotation.Key == YAML_KEY_FEED {
+					trigger.Annotations[i].Value = "/" + pkg.Namespace + "/" + feedName
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func isFeedAnnotation(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	}
+	return false
 }
 
 func (dm *YAMLParser) ComposeTriggers(filePath string, pkg Package, ma whisk.KeyValue) ([]*whisk.Trigger, error) {
@@ -723,20 +854,21 @@ func (dm *YAMLParser) ComposeTriggers(filePath string, pkg Package, ma whisk.Key
 	for _, trigger := range pkg.GetTriggerList() {
 		wsktrigger := new(whisk.Trigger)
 		wsktrigger.Name = wskenv.ConvertSingleName(trigger.Name)
+		if err := dm.checkNamingConvention(YAML_KEY_TRIGGER, wsktrigger.Name); err != nil {
+			return nil, err
+		}
+		// triggers are deployed to the namespace of their package unless they declare their own
 		wsktrigger.Namespace = trigger.Namespace
+		if len(wsktrigger.Namespace) == 0 {
+			wsktrigger.Namespace = pkg.Namespace
+		}
 		pub := false
 		wsktrigger.Publish = &pub
 
-		// print warning information when .Source key's value is not empty
+		// record the deprecated .Source key when its value is not empty
 		if trigger.Source != "" {
-			// TODO() i18n use const for keys and values on string
-			warningString := wski18n.T(
-				wski18n.ID_WARN_DEPRECATED_KEY_REPLACED_X_oldkey_X_filetype_X_newkey_X,
-				map[string]interface{}{
-					wski18n.KEY_OLD: "source",
-					wski18n.KEY_NEW: YAML_KEY_FEED,
-					wski18n.KEY_FILE_TYPE: "manifest"})
-			wskprint.PrintOpenWhiskWarning(warningString)
+			Deprecations.Add(DeprecatedKey{FilePath: filePath, FileType: FILE_TYPE_MANIFEST,
+				EntityType: YAML_KEY_TRIGGER, EntityName: trigger.Name, OldKey: YAML_KEY_SOURCE, NewKey: YAML_KEY_FEED})
 		}
 		if trigger.Feed == "" {
 			trigger.Feed = trigger.Source
@@ -759,7 +891,7 @@ func (dm *YAMLParser) ComposeTriggers(filePath string, pkg Package, ma whisk.Key
 			var keyVal whisk.KeyValue
 			keyVal.Key = name
 
-			keyVal.Value, errorParser = ResolveParameter(name, &param, filePath)
+			keyVal.Value, errorParser = ResolveParameter(name, &param, filePath, dm.Flags.SecretsFromEnv)
 
 			if errorParser != nil {
 				return nil, errorParser
@@ -778,15 +910,16 @@ func (dm *YAMLParser) ComposeTriggers(filePath string, pkg Package, ma whisk.Key
 		for name, value := range trigger.Annotations {
 			var keyVal whisk.KeyValue
 			keyVal.Key = name
-			keyVal.Value = wskenv.GetEnvVar(value)
+			keyVal.Value = ResolveAnnotation(value)
 			listOfAnnotations = append(listOfAnnotations, keyVal)
 		}
 		if len(listOfAnnotations) > 0 {
 			wsktrigger.Annotations = append(wsktrigger.Annotations, listOfAnnotations...)
 		}
+		wsktrigger.Annotations = AddEncryptedInputs(wsktrigger.Annotations, trigger.Inputs)
 
 		// add managed annotations if its a managed deployment
-		if utils.Flags.Managed {
+		if dm.Flags.Managed {
 			wsktrigger.Annotations = append(wsktrigger.Annotations, ma)
 		}
 
@@ -826,22 +959,26 @@ func (dm *YAMLParser) ComposeRules(pkg Package, packageName string) ([]*whisk.Ru
 
 	for _, rule := range pkg.GetRuleList() {
 		wskrule := rule.ComposeWskRule()
+		if err := dm.checkNamingConvention(YAML_KEY_RULE, wskrule.Name); err != nil {
+			return nil, err
+		}
 		act := strings.TrimSpace(wskrule.Action.(string))
 		if !strings.ContainsRune(act, '/') && !strings.HasPrefix(act, packageName+"/") {
 			act = path.Join(packageName, act)
 		}
 		wskrule.Action = act
+		wskrule.Namespace = pkg.Namespace
 		r1 = append(r1, wskrule)
 	}
 	return r1, nil
 }
 
-func (dm *YAMLParser) ComposeApiRecordsFromAllPackages(manifest *YAML) ([]*whisk.ApiCreateRequest, error) {
+func (dm *YAMLParser) ComposeApiRecordsFromAllPackages(manifest *YAML, filePath string) ([]*whisk.ApiCreateRequest, error) {
 	var requests []*whisk.ApiCreateRequest = make([]*whisk.ApiCreateRequest, 0)
 	manifestPackages := make(map[string]Package)
 
 	if manifest.Package.Packagename != "" {
-		return dm.ComposeApiRecords(manifest.Package)
+		return dm.ComposeApiRecords(filePath, manifest.Package.Packagename, manifest.Package, manifest)
 	} else {
 		if len(manifest.Packages) != 0 {
 			manifestPackages = manifest.Packages
@@ -850,8 +987,8 @@ func (dm *YAMLParser) ComposeApiRecordsFromAllPackages(manifest *YAML) ([]*whisk
 		}
 	}
 
-	for _, p := range manifestPackages {
-		r, err := dm.ComposeApiRecords(p)
+	for n, p := range manifestPackages {
+		r, err := dm.ComposeApiRecords(filePath, n, p, manifest)
 		if err == nil {
 			requests = append(requests, r...)
 		} else {
@@ -861,8 +998,17 @@ func (dm *YAMLParser) ComposeApiRecordsFromAllPackages(manifest *YAML) ([]*whisk
 	return requests, nil
 }
 
-func (dm *YAMLParser) ComposeApiRecords(pkg Package) ([]*whisk.ApiCreateRequest, error) {
+func (dm *YAMLParser) ComposeApiRecords(filePath string, packageName string, pkg Package, manifest *YAML) ([]*whisk.ApiCreateRequest, error) {
 	var acq []*whisk.ApiCreateRequest = make([]*whisk.ApiCreateRequest, 0)
+
+	if len(pkg.Apis.Swagger) > 0 {
+		acr, err := dm.ComposeSwaggerApi(filePath, packageName, pkg, manifest)
+		if err != nil {
+			return nil, err
+		}
+		return append(acq, acr), nil
+	}
+
 	apis := pkg.GetApis()
 
 	for _, api := range apis {
@@ -872,3 +1018,21 @@ func (dm *YAMLParser) ComposeApiRecords(pkg Package) ([]*whisk.ApiCreateRequest,
 	}
 	return acq, nil
 }
+
+// checkNamingConvention fails when the name of the entity does not follow the
+// naming conventions given by --naming-conventions, or only warns if their
+// severity is "warning"
+func (dm *YAMLParser) checkNamingConvention(entity string, name string) error {
+	conventions, err := dm.Flags.GetNamingConventions()
+	if err != nil || conventions == nil {
+		return err
+	}
+	if err := conventions.Validate(entity, name); err != nil {
+		if conventions.Severity == utils.NAMING_SEVERITY_WARNING {
+			wskprint.PrintlnOpenWhiskWarning(err.(*wskderrors.NamingConventionError).GetMessage())
+			return nil
+		}
+		return err
+	}
+	return nil
+}
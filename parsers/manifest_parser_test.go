@@ -21,6 +21,7 @@ package parsers
 
 import (
     "github.com/stretchr/testify/assert"
+    "encoding/json"
     "io/ioutil"
     "os"
     "testing"
@@ -32,6 +33,7 @@ import (
     "github.com/apache/incubator-openwhisk-client-go/whisk"
     "github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
     "github.com/apache/incubator-openwhisk-wskdeploy/utils"
+    "github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
 )
 
 const (
@@ -994,37 +996,35 @@ func TestResolveParameterForMultiLineParams(t *testing.T) {
 
     // type string - value only param
     param1 := Parameter{Value: v, multiline: true}
-    r1, _ := ResolveParameter(paramName, &param1, "")
+    r1, _ := ResolveParameter(paramName, &param1, "", false)
     assert.Equal(t, v, r1, fmt.Sprintf(TEST_MSG_ACTION_PARAMETER_VALUE_MISMATCH,paramName))
     assert.IsType(t, v, r1, fmt.Sprintf(TEST_MSG_ACTION_PARAMETER_TYPE_MISMATCH,paramName))
 
     // type string - type and value only param
     param2 := Parameter{Type: y, Value: v, multiline: true}
-    r2, _ := ResolveParameter(paramName, &param2, "")
+    r2, _ := ResolveParameter(paramName, &param2, "", false)
     assert.Equal(t, v, r2, fmt.Sprintf(TEST_MSG_ACTION_PARAMETER_VALUE_MISMATCH,paramName))
     assert.IsType(t, v, r2, fmt.Sprintf(TEST_MSG_ACTION_PARAMETER_TYPE_MISMATCH,paramName))
 
     // type string - type, no value, but default value param
     param3 := Parameter{Type: y, Default: d, multiline: true}
-    r3, _ := ResolveParameter(paramName, &param3, "")
+    r3, _ := ResolveParameter(paramName, &param3, "", false)
     assert.Equal(t, d, r3, fmt.Sprintf(TEST_MSG_ACTION_PARAMETER_VALUE_MISMATCH,paramName))
     assert.IsType(t, d, r3, fmt.Sprintf(TEST_MSG_ACTION_PARAMETER_TYPE_MISMATCH,paramName))
 
     // type string - type and value only param
     // type is "string" and value is of type "int"
-    // ResolveParameter matches specified type with the type of the specified value
-    // it fails if both types don't match
-    // ResolveParameter determines type from the specified value
-    // in this case, ResolveParameter returns value of type int
+    // ResolveParameter converts the value to the declared type
+    // in this case, ResolveParameter returns value of type string
     v1 := 11
     param4 := Parameter{Type: y, Value: v1, multiline: true}
-    r4, _ := ResolveParameter(paramName, &param4, "")
-    assert.Equal(t, v1, r4, fmt.Sprintf(TEST_MSG_ACTION_PARAMETER_VALUE_MISMATCH,paramName))
-    assert.IsType(t, v1, r4, fmt.Sprintf(TEST_MSG_ACTION_PARAMETER_TYPE_MISMATCH,paramName))
+    r4, _ := ResolveParameter(paramName, &param4, "", false)
+    assert.Equal(t, "11", r4, fmt.Sprintf(TEST_MSG_ACTION_PARAMETER_VALUE_MISMATCH,paramName))
+    assert.IsType(t, v, r4, fmt.Sprintf(TEST_MSG_ACTION_PARAMETER_TYPE_MISMATCH,paramName))
 
     // type invalid - type only param
     param5 := Parameter{Type: "invalid", multiline: true}
-    _, err := ResolveParameter(paramName, &param5, "")
+    _, err := ResolveParameter(paramName, &param5, "", false)
     assert.NotNil(t, err, "Expected error saying Invalid type for parameter")
     switch errorType := err.(type) {
     default:
@@ -1036,9 +1036,126 @@ func TestResolveParameterForMultiLineParams(t *testing.T) {
     // type none - param without type, without value, and without default value
     param6 := Parameter{multiline: true}
     paramName = "none"
-    r6, _ := ResolveParameter(paramName, &param6, "")
+    r6, _ := ResolveParameter(paramName, &param6, "", false)
     assert.Empty(t, r6, fmt.Sprintf(TEST_MSG_ACTION_PARAMETER_VALUE_MISMATCH,paramName))
 
+    // type integer - required param without value is not defaulted to 0
+    param7 := Parameter{Type: INTEGER, Required: true, multiline: true}
+    paramName = "required"
+    r7, _ := ResolveParameter(paramName, &param7, "", false)
+    assert.Nil(t, r7, fmt.Sprintf(TEST_MSG_ACTION_PARAMETER_VALUE_MISMATCH,paramName))
+
+}
+
+// Test 16a: validate ResolveParameter() converts values to the declared parameter type
+func TestResolveParameterTypeCoercion(t *testing.T) {
+    paramName := "name"
+
+    os.Setenv("WSKDEPLOY_TEST_PORT", "8080")
+    os.Setenv("WSKDEPLOY_TEST_DEBUG", "true")
+    defer os.Unsetenv("WSKDEPLOY_TEST_PORT")
+    defer os.Unsetenv("WSKDEPLOY_TEST_DEBUG")
+
+    values := []struct {
+        paramType string
+        value     interface{}
+        expected  interface{}
+    }{
+        {STRING, 3.14, "3.14"},
+        {STRING, true, "true"},
+        {INTEGER, "42", 42},
+        {INTEGER, 42.0, 42},
+        {INTEGER, "$WSKDEPLOY_TEST_PORT", 8080},
+        {FLOAT, 1, 1.0},
+        {FLOAT, "2.5", 2.5},
+        {BOOLEAN, "false", false},
+        {BOOLEAN, "${WSKDEPLOY_TEST_DEBUG}", true},
+        {ARRAY, "[1, \"two\"]", []interface{}{1.0, "two"}},
+        {ARRAY, []interface{}{map[interface{}]interface{}{"key": "value"}},
+            []interface{}{map[string]interface{}{"key": "value"}}},
+        {JSON, "{\"key\": 1}", map[string]interface{}{"key": 1.0}},
+    }
+    for _, v := range values {
+        param := Parameter{Type: v.paramType, Value: v.value, multiline: true}
+        r, err := ResolveParameter(paramName, &param, "", false)
+        assert.Nil(t, err, fmt.Sprintf("%s %v", v.paramType, v.value))
+        assert.Equal(t, v.expected, r, fmt.Sprintf(TEST_MSG_ACTION_PARAMETER_VALUE_MISMATCH, paramName))
+    }
+
+    // values which can not be converted are reported instead of deployed with the wrong type
+    invalid := []struct {
+        paramType string
+        value     interface{}
+    }{
+        {INTEGER, "eighty"},
+        {INTEGER, 80.8},
+        {FLOAT, "pi"},
+        {BOOLEAN, "maybe"},
+        {BOOLEAN, 1},
+        {ARRAY, "not an array"},
+        {JSON, 7},
+    }
+    for _, v := range invalid {
+        param := Parameter{Type: v.paramType, Value: v.value, multiline: true}
+        _, err := ResolveParameter(paramName, &param, "", false)
+        assert.NotNil(t, err, fmt.Sprintf("%s %v", v.paramType, v.value))
+        assert.IsType(t, &wskderrors.ParameterTypeMismatchError{}, err)
+    }
+
+    // an unset Env. variable results in the default value of the declared type
+    param := Parameter{Type: INTEGER, Value: "$WSKDEPLOY_TEST_UNSET", multiline: true}
+    r, err := ResolveParameter(paramName, &param, "", false)
+    assert.Nil(t, err)
+    assert.Equal(t, 0, r)
+}
+
+func TestResolveParameterSecret(t *testing.T) {
+    os.Setenv("WSKDEPLOY_TEST_TOKEN", "s3cr3t")
+    defer os.Unsetenv("WSKDEPLOY_TEST_TOKEN")
+    defer wskprint.ClearSecrets()
+
+    param := Parameter{Type: STRING, Value: "$WSKDEPLOY_TEST_TOKEN", Secret: true, multiline: true}
+    r, err := ResolveParameter("token", &param, "", false)
+    assert.Nil(t, err)
+    assert.Equal(t, "s3cr3t", r, "the value itself is not masked")
+    assert.Equal(t, "token: "+wskprint.STR_SECRET_MASK, wskprint.MaskSecrets("token: s3cr3t"))
+
+    param = Parameter{Type: INTEGER, Value: 1234, Secret: true, multiline: true}
+    _, err = ResolveParameter("pin", &param, "", false)
+    assert.Nil(t, err)
+    assert.Equal(t, wskprint.STR_SECRET_MASK, wskprint.MaskSecrets("1234"))
+
+    // with --secrets-from-env, secrets may not be written in the file
+    param = Parameter{Type: STRING, Value: "hunter2", Secret: true, multiline: true}
+    _, err = ResolveParameter("password", &param, "manifest.yaml", true)
+    assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err)
+    param = Parameter{Type: STRING, Value: "$WSKDEPLOY_TEST_TOKEN", Secret: true, multiline: true}
+    _, err = ResolveParameter("token", &param, "manifest.yaml", true)
+    assert.Nil(t, err)
+}
+
+// Test 16b: validate typed parameters shared through YAML anchors
+func TestParseManifestForTypedParams(t *testing.T) {
+    manifestFile := "../tests/dat/manifest_validate_typed_params.yaml"
+    m, err := NewYAMLParser().ParseManifest(manifestFile)
+    assert.Nil(t, err, fmt.Sprintf(TEST_ERROR_MANIFEST_PARSE_FAILURE, manifestFile))
+
+    p := NewYAMLParser()
+    actions, err := p.ComposeActionsFromAllPackages(m, manifestFile, whisk.KeyValue{})
+    assert.Nil(t, err, fmt.Sprintf(TEST_ERROR_MANIFEST_PARSE_FAILURE, manifestFile))
+
+    expected := map[string]map[string]interface{}{
+        "typed_string": {"port": "8080", "retries": "3"},
+        "typed_integer": {"port": 8080, "retries": 3},
+    }
+    for _, action := range actions {
+        params := make(map[string]interface{})
+        for _, kv := range action.Action.Parameters {
+            params[kv.Key] = kv.Value
+        }
+        assert.Equal(t, expected[action.Action.Name], params, fmt.Sprintf(TEST_MSG_ACTION_PARAMETER_VALUE_MISMATCH, action.Action.Name))
+    }
+    assert.Equal(t, 2, len(actions))
 }
 
 // Test 17: validate JSON parameters
@@ -1228,6 +1345,36 @@ func TestComposeTriggers(t *testing.T) {
     }
 }
 
+func TestComposeTriggers_NamingConventions(t *testing.T) {
+    manifestFile := "../tests/dat/manifest_data_compose_triggers.yaml"
+    p := NewYAMLParser()
+    m, err := p.ParseManifest(manifestFile)
+    assert.Nil(t, err)
+
+    defer func() { utils.Flags.NamingConventions = "" }()
+    utils.Flags.NamingConventions = "../tests/dat/naming_conventions.yaml"
+    _, err = p.ComposeTriggersFromAllPackages(m, manifestFile, whisk.KeyValue{})
+    assert.Nil(t, err, "trigger1 and trigger2 follow the naming conventions")
+
+    data := "severity: warning\ntrigger: ^[a-z]+-trigger$\n"
+    tmpfile, err := _createTmpfile(data, "manifest_parser_test_naming_")
+    assert.Nil(t, err)
+    defer os.Remove(tmpfile.Name())
+    utils.Flags.NamingConventions = tmpfile.Name()
+    tmpfile.Close()
+    triggers, err := p.ComposeTriggersFromAllPackages(m, manifestFile, whisk.KeyValue{})
+    assert.Nil(t, err, "names which do not follow warning conventions are only reported")
+    assert.Equal(t, 2, len(triggers))
+
+    tmpfile, err = _createTmpfile("trigger: ^[a-z]+-trigger$\n", "manifest_parser_test_naming_")
+    assert.Nil(t, err)
+    defer os.Remove(tmpfile.Name())
+    tmpfile.Close()
+    utils.Flags.NamingConventions = tmpfile.Name()
+    _, err = p.ComposeTriggersFromAllPackages(m, manifestFile, whisk.KeyValue{})
+    assert.IsType(t, &wskderrors.NamingConventionError{}, err)
+}
+
 func TestComposeRules(t *testing.T) {
     data := `package:
   name: helloworld
@@ -1295,7 +1442,7 @@ func TestComposeApiRecords(t *testing.T) {
     // read and parse manifest.yaml file
     p := NewYAMLParser()
     m, _ := p.ParseManifest(tmpfile.Name())
-    apiList, err := p.ComposeApiRecordsFromAllPackages(m)
+    apiList, err := p.ComposeApiRecordsFromAllPackages(m, tmpfile.Name())
     if err != nil {
         assert.Fail(t, "Failed to compose api records")
     }
@@ -1748,3 +1895,385 @@ func TestComposeActionForAnnotations(t *testing.T) {
     eq = reflect.DeepEqual(actual_annotations, expected_annotations)
     assert.True(t, eq, "Expected list of annotations does not match with actual list, expected annotations: %v actual annotations: %v", expected_annotations, actual_annotations)
 }
+
+func TestComposeEntitiesWithPackageNamespace(t *testing.T) {
+    manifestFile := "../tests/dat/manifest_validate_package_namespace.yaml"
+    p := NewYAMLParser()
+    m, err := p.ParseManifest(manifestFile)
+    assert.Nil(t, err, fmt.Sprintf(TEST_ERROR_MANIFEST_PARSE_FAILURE, manifestFile))
+
+    packages, err := p.ComposeAllPackages(m, manifestFile, whisk.KeyValue{})
+    assert.Nil(t, err)
+    assert.Equal(t, "tenant1", packages["tenant1_package"].Namespace)
+    assert.Equal(t, "", packages["default_package"].Namespace)
+
+    actions, err := p.ComposeActionsFromAllPackages(m, manifestFile, whisk.KeyValue{})
+    assert.Nil(t, err)
+    assert.Equal(t, 2, len(actions))
+    for _, action := range actions {
+        if action.Packagename == "tenant1_package" {
+            assert.Equal(t, "tenant1", action.Action.Namespace)
+        } else {
+            assert.Equal(t, "", action.Action.Namespace)
+        }
+    }
+
+    sequences, err := p.ComposeSequencesFromAllPackages("guest", m, whisk.KeyValue{})
+    assert.Nil(t, err)
+    assert.Equal(t, 2, len(sequences))
+    for _, sequence := range sequences {
+        if sequence.Packagename == "tenant1_package" {
+            assert.Equal(t, "tenant1", sequence.Action.Namespace)
+            assert.Equal(t, []string{"/tenant1/tenant1_package/hello"}, sequence.Action.Exec.Components)
+        } else {
+            assert.Equal(t, "guest", sequence.Action.Namespace)
+            assert.Equal(t, []string{"/guest/default_package/hello"}, sequence.Action.Exec.Components)
+        }
+    }
+
+    triggers, err := p.ComposeTriggersFromAllPackages(m, manifestFile, whisk.KeyValue{})
+    assert.Nil(t, err)
+    namespaces := make(map[string]string)
+    for _, trigger := range triggers {
+        namespaces[trigger.Name] = trigger.Namespace
+    }
+    assert.Equal(t, map[string]string{"tenant1_trigger": "tenant1", "default_trigger": "", "other_trigger": "tenant2"}, namespaces)
+
+    rules, err := p.ComposeRulesFromAllPackages(m)
+    assert.Nil(t, err)
+    assert.Equal(t, 1, len(rules))
+    assert.Equal(t, "tenant1", rules[0].Namespace)
+}
+
+func TestComposeSequences_ListAndQualifiedActions(t *testing.T) {
+	manifestFile := "../tests/dat/manifest_data_compose_sequences_list.yaml"
+	p := NewYAMLParser()
+	m, err := p.ParseManifest(manifestFile)
+	assert.Nil(t, err, fmt.Sprintf(TEST_ERROR_MANIFEST_PARSE_FAILURE, manifestFile))
+	assert.Equal(t, SequenceActions{"hello", "other/bye", "/whisk.system/utils/echo"}, m.Packages["hello"].Sequences["hello_list"].Actions)
+
+	sequences, err := p.ComposeSequencesFromAllPackages("guest", m, whisk.KeyValue{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(sequences))
+	for _, sequence := range sequences {
+		switch sequence.Action.Name {
+		case "hello_list":
+			assert.Equal(t, []string{"/guest/hello/hello", "/tenant1/other/bye", "/whisk.system/utils/echo"}, sequence.Action.Exec.Components)
+		case "hello_inline":
+			assert.Equal(t, []string{"/guest/hello/hello", "/tenant1/other/bye"}, sequence.Action.Exec.Components)
+		default:
+			assert.Fail(t, "Unexpected sequence "+sequence.Action.Name)
+		}
+	}
+
+	for _, actions := range []SequenceActions{{"hello", ""}, {"a/b/c"}, {"/guest"}, {"/guest/a/b/c"}, {"//a"}} {
+		sequences := map[string]Sequence{"invalid": {Actions: actions}}
+		_, err := p.ComposeSequences("guest", sequences, "hello", whisk.KeyValue{})
+		assert.NotNil(t, err, actions.String())
+	}
+}
+
+func TestComposeSequences_Web(t *testing.T) {
+	p := NewYAMLParser()
+	sequences := map[string]Sequence{"hello": {Actions: SequenceActions{"hello"}, Web: "raw"}}
+	records, err := p.ComposeSequences("guest", sequences, "hello", whisk.KeyValue{})
+	assert.Nil(t, err)
+	annotations := records[0].Action.Annotations
+	assert.Equal(t, true, annotations.GetValue(utils.WEB_EXPORT_ANNOT))
+	assert.Equal(t, true, annotations.GetValue(utils.RAW_HTTP_ANNOT))
+
+	sequences = map[string]Sequence{"hello": {Actions: SequenceActions{"hello"}, Web: "sometimes"}}
+	_, err = p.ComposeSequences("guest", sequences, "hello", whisk.KeyValue{})
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err)
+}
+
+func TestParseManifest_VersionRequirement(t *testing.T) {
+	// the version is checked before the keys unknown to this release are reported
+	manifestFile := "../tests/dat/manifest_validate_wskdeploy_version.yaml"
+	_, err := NewYAMLParser().ParseManifest(manifestFile)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "spec version [>=1000.0]")
+
+	manifest := []byte("wskdeploy_version: \">=0.9\"\npackages:\n  helloworld:\n")
+	assert.Nil(t, CheckVersionRequirement(manifest, manifestFile))
+	m := YAML{}
+	assert.Nil(t, NewYAMLParser().Unmarshal(manifest, &m))
+	assert.Equal(t, ">=0.9", m.WskdeployVersion.Wskdeploy)
+
+	assert.NotNil(t, CheckVersionRequirement([]byte("wskdeploy_version: \"<0.1\"\n"), manifestFile))
+	assert.NotNil(t, CheckVersionRequirement([]byte("wskdeploy_version: \"latest\"\n"), manifestFile))
+}
+
+func TestComposeActionsWithTypedAnnotations(t *testing.T) {
+    manifestFile := "../tests/dat/manifest_validate_typed_annotations.yaml"
+    os.Setenv("OWNER", "jdoe")
+    defer os.Unsetenv("OWNER")
+    p := NewYAMLParser()
+    m, err := p.ParseManifest(manifestFile)
+    assert.Nil(t, err, fmt.Sprintf(TEST_ERROR_MANIFEST_PARSE_FAILURE, manifestFile))
+
+    actions, err := p.ComposeActionsFromAllPackages(m, manifestFile, whisk.KeyValue{})
+    assert.Nil(t, err)
+    assert.Equal(t, 1, len(actions))
+    annotations := actions[0].Action.Annotations
+    assert.Equal(t, 1234, annotations.GetValue("require-whisk-auth"))
+    assert.Equal(t, true, annotations.GetValue("final"))
+    assert.Equal(t, 0.5, annotations.GetValue("sampling"))
+    assert.Equal(t, "jdoe", annotations.GetValue("owner"))
+    assert.Equal(t, []interface{}{"greeting", "jdoe"}, annotations.GetValue("tags"))
+    assert.Equal(t, map[string]interface{}{
+        "retries": 3,
+        "notify": map[string]interface{}{"email": "jdoe@example.com"},
+    }, annotations.GetValue("limits"))
+
+    // structured annotations can be sent to OpenWhisk as JSON
+    _, err = json.Marshal(annotations)
+    assert.Nil(t, err)
+
+    packages, err := p.ComposeAllPackages(m, manifestFile, whisk.KeyValue{})
+    assert.Nil(t, err)
+    assert.Equal(t, 2, packages["helloworld"].Annotations.GetValue("version"))
+
+    triggers, err := p.ComposeTriggersFromAllPackages(m, manifestFile, whisk.KeyValue{})
+    assert.Nil(t, err)
+    assert.Equal(t, false, triggers[0].Annotations.GetValue("enabled"))
+}
+
+func TestComposeActionsWithWebSecure(t *testing.T) {
+    manifestFile := "../tests/dat/manifest_validate_web_secure.yaml"
+    p := NewYAMLParser()
+    m, err := p.ParseManifest(manifestFile)
+    assert.Nil(t, err, fmt.Sprintf(TEST_ERROR_MANIFEST_PARSE_FAILURE, manifestFile))
+
+    actions, err := p.ComposeActionsFromAllPackages(m, manifestFile, whisk.KeyValue{})
+    assert.Nil(t, err)
+    assert.Equal(t, 4, len(actions))
+    for _, action := range actions {
+        secret := action.Action.Annotations.GetValue(utils.REQUIRE_WHISK_AUTH_ANNOT)
+        assert.Equal(t, action.Action.Name == "generated", action.WebSecretGenerated, action.Action.Name)
+        assert.Equal(t, true, action.Action.Annotations.GetValue(utils.WEB_EXPORT_ANNOT), action.Action.Name)
+        switch action.Action.Name {
+        case "generated":
+            assert.IsType(t, int64(0), secret)
+        case "number":
+            assert.Equal(t, 1234, secret)
+        case "string":
+            assert.Equal(t, "my-secret", secret)
+        case "public":
+            assert.Nil(t, secret)
+        }
+    }
+
+    // web-secure is only valid for web actions
+    manifestFile = "../tests/dat/manifest_invalid_web_secure.yaml"
+    m, err = p.ParseManifest(manifestFile)
+    assert.Nil(t, err, fmt.Sprintf(TEST_ERROR_MANIFEST_PARSE_FAILURE, manifestFile))
+    _, err = p.ComposeActionsFromAllPackages(m, manifestFile, whisk.KeyValue{})
+    assert.NotNil(t, err)
+}
+
+func TestComposePackagesAndActionsWithProjectInputs(t *testing.T) {
+    manifestFile := "../tests/dat/manifest_validate_project_inputs.yaml"
+    p := NewYAMLParser()
+    m, err := p.ParseManifest(manifestFile)
+    assert.Nil(t, err, fmt.Sprintf(TEST_ERROR_MANIFEST_PARSE_FAILURE, manifestFile))
+
+    packages, err := p.ComposeAllPackages(m, manifestFile, whisk.KeyValue{})
+    assert.Nil(t, err)
+    assert.Equal(t, "https://api.example.com", packages["hello"].Parameters.GetValue("apiHost"))
+    assert.Equal(t, "debug", packages["hello"].Parameters.GetValue("logLevel"))
+    assert.Equal(t, "https://api.example.com", packages["bye"].Parameters.GetValue("apiHost"))
+    assert.Equal(t, "info", packages["bye"].Parameters.GetValue("logLevel"))
+
+    actions, err := p.ComposeActionsFromAllPackages(m, manifestFile, whisk.KeyValue{})
+    assert.Nil(t, err)
+    assert.Equal(t, 2, len(actions))
+    for _, action := range actions {
+        assert.Equal(t, "info", action.Action.Parameters.GetValue("logLevel"))
+        if action.Action.Name == "bye" {
+            assert.Equal(t, "https://bye.example.com", action.Action.Parameters.GetValue("apiHost"))
+        } else {
+            assert.Equal(t, "https://api.example.com", action.Action.Parameters.GetValue("apiHost"))
+        }
+    }
+
+    // the inputs of the project are only set on packages by default
+    m.Project.InputsScope = YAML_VALUE_INPUTS_SCOPE_PACKAGES
+    actions, err = p.ComposeActionsFromAllPackages(m, manifestFile, whisk.KeyValue{})
+    assert.Nil(t, err)
+    for _, action := range actions {
+        assert.Nil(t, action.Action.Parameters.GetValue("logLevel"))
+    }
+
+    m.Project.InputsScope = "everything"
+    _, err = p.ComposeAllPackages(m, manifestFile, whisk.KeyValue{})
+    assert.NotNil(t, err)
+}
+
+func TestComposeTriggers_LocalFeed(t *testing.T) {
+	manifestFile := "../tests/dat/manifest_validate_local_feed.yaml"
+	p := NewYAMLParser()
+	m, err := p.ParseManifest(manifestFile)
+	assert.Nil(t, err, fmt.Sprintf(TEST_ERROR_MANIFEST_PARSE_FAILURE, manifestFile))
+
+	triggers, err := p.ComposeTriggersFromAllPackages(m, manifestFile, whisk.KeyValue{})
+	assert.Nil(t, err)
+	feeds := make(map[string]string)
+	for _, trigger := range triggers {
+		feeds[trigger.Name], _ = utils.IsFeedAction(trigger)
+	}
+	// the feed of the manifest is qualified with the namespace of its package
+	assert.Equal(t, map[string]string{"onChange": "/tenant1/feeds/changes", "alarm": "/whisk.system/alarms/alarm"}, feeds)
+
+	manifestFile = "../tests/dat/manifest_validate_local_feed_not_annotated.yaml"
+	m, err = p.ParseManifest(manifestFile)
+	assert.Nil(t, err, fmt.Sprintf(TEST_ERROR_MANIFEST_PARSE_FAILURE, manifestFile))
+	_, err = p.ComposeTriggersFromAllPackages(m, manifestFile, whisk.KeyValue{})
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err, "the feed action is not annotated as a feed")
+}
+
+func TestComposeActions_DelAnnotations(t *testing.T) {
+	data := `project:
+  name: hello
+  overwrite_annotations: true
+  packages:
+    helloworld:
+      actions:
+        hello:
+          function: ../tests/dat/actions/hello.js
+          annotations:
+            owner: ops
+          del_annotations:
+            - description`
+	p, m, tmpfile := testUnmarshalTemporaryFile([]byte(data), "manifest_parser_validate_del_annotations_")
+	assert.True(t, m.GetProject().OverwriteAnnotations)
+	actions, err := p.ComposeActionsFromAllPackages(m, tmpfile, whisk.KeyValue{})
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(actions)) {
+		assert.Equal(t, []string{"description"}, actions[0].DelAnnotations)
+	}
+
+	data = `packages:
+  helloworld:
+    actions:
+      hello:
+        function: ../tests/dat/actions/hello.js
+        annotations:
+          owner: ops
+        del_annotations:
+          - owner`
+	p, m, tmpfile = testUnmarshalTemporaryFile([]byte(data), "manifest_parser_validate_del_annotations_")
+	_, err = p.ComposeActionsFromAllPackages(m, tmpfile, whisk.KeyValue{})
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err, "an annotation is either set or removed")
+}
+
+func TestInheritAnnotations(t *testing.T) {
+	manifestFile := "../tests/dat/manifest_validate_inherit_annotations.yaml"
+	p := NewYAMLParser()
+	m, err := p.ParseManifest(manifestFile)
+	assert.Nil(t, err, fmt.Sprintf(TEST_ERROR_MANIFEST_PARSE_FAILURE, manifestFile))
+
+	annotations := func(kv whisk.KeyValueArr) map[string]interface{} {
+		result := make(map[string]interface{})
+		for _, annotation := range kv {
+			result[annotation.Key] = annotation.Value
+		}
+		return result
+	}
+
+	packages, err := p.ComposeAllPackages(m, manifestFile, whisk.KeyValue{})
+	assert.Nil(t, err)
+	// the annotations of an entity take precedence over the ones of the project
+	assert.Equal(t, map[string]interface{}{"team": "payments", "tier": "silver"}, annotations(packages["billing"].Annotations))
+	assert.Equal(t, map[string]interface{}{}, annotations(packages["internal"].Annotations))
+
+	actions, err := p.ComposeActionsFromAllPackages(m, manifestFile, whisk.KeyValue{})
+	assert.Nil(t, err)
+	expected := map[string]map[string]interface{}{
+		// package annotations are not inherited by its actions
+		"charge": {"team": "checkout", "tier": "gold"},
+		"refund": {"team": "payments"},
+		// the inherit-annotations of the package applies to actions which do not set their own
+		"cleanup": {},
+		"audit":   {"team": "payments", "tier": "gold"},
+	}
+	for _, action := range actions {
+		assert.Equal(t, expected[action.Action.Name], annotations(action.Action.Annotations), action.Action.Name)
+	}
+
+	sequences, err := p.ComposeSequencesFromAllPackages("", m, whisk.KeyValue{})
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(sequences)) {
+		assert.Equal(t, map[string]interface{}{}, annotations(sequences[0].Action.Annotations))
+	}
+
+	triggers, err := p.ComposeTriggersFromAllPackages(m, manifestFile, whisk.KeyValue{})
+	assert.Nil(t, err)
+	if assert.Equal(t, 1, len(triggers)) {
+		assert.Equal(t, "gold", annotations(triggers[0].Annotations)["tier"])
+	}
+
+	_, err = inheritAnnotations(map[string]interface{}{"team": "payments"}, "maybe", nil, manifestFile, "charge")
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err)
+}
+
+func TestInheritLimits(t *testing.T) {
+	manifestFile := "../tests/dat/manifest_validate_inherit_limits.yaml"
+	p := NewYAMLParser()
+	m, err := p.ParseManifest(manifestFile)
+	assert.Nil(t, err, fmt.Sprintf(TEST_ERROR_MANIFEST_PARSE_FAILURE, manifestFile))
+
+	actions, err := p.ComposeActionsFromAllPackages(m, manifestFile, whisk.KeyValue{})
+	assert.Nil(t, err)
+	// timeout, memory and log size of each action
+	expected := map[string][]interface{}{
+		"charge":  {60000, 512, nil},
+		"refund":  {1000, 512, nil},
+		"cleanup": {60000, 256, 5},
+	}
+	value := func(limit *int) interface{} {
+		if limit == nil {
+			return nil
+		}
+		return *limit
+	}
+	for _, action := range actions {
+		limits := action.Action.Limits
+		if assert.NotNil(t, limits, action.Action.Name) {
+			assert.Equal(t, expected[action.Action.Name],
+				[]interface{}{value(limits.Timeout), value(limits.Memory), value(limits.Logsize)}, action.Action.Name)
+		}
+	}
+}
+
+func TestMergeInputValue(t *testing.T) {
+	_, err := MergeInputValue("deployment.yaml", "hosts", "prepend", nil, []interface{}{"a"})
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err, "unknown merge")
+	_, err = MergeInputValue("deployment.yaml", "hosts", INPUT_MERGE_APPEND, "a", []interface{}{"b"})
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err, "not a list in the manifest")
+	merged, err := MergeInputValue("deployment.yaml", "hosts", INPUT_MERGE_APPEND, nil, []interface{}{"b"})
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"b"}, merged, "no value in the manifest")
+}
+
+func TestCoerceInputValue(t *testing.T) {
+	value, err := CoerceInputValue("deployment.yaml", "port", "", 80, "8080")
+	assert.Nil(t, err)
+	assert.Equal(t, 8080, value, "type of the manifest")
+	value, err = CoerceInputValue("deployment.yaml", "port", STRING, 80, 8080)
+	assert.Nil(t, err)
+	assert.Equal(t, "8080", value, "declared type")
+	value, err = CoerceInputValue("deployment.yaml", "port", "", 80, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "", value, "empty strings are kept")
+	value, err = CoerceInputValue("deployment.yaml", "hosts", "", []interface{}{"a"}, "b")
+	assert.Nil(t, err)
+	assert.Equal(t, "b", value, "not a scalar")
+	_, err = CoerceInputValue("deployment.yaml", "debug", "", true, "maybe")
+	assert.IsType(t, &wskderrors.ParameterTypeMismatchError{}, err)
+	value, err = CoerceInputValue("deployment.yaml", "port", INTEGER, 80, uint64(8080))
+	assert.Nil(t, err)
+	assert.Equal(t, 8080, value)
+	_, err = CoerceInputValue("deployment.yaml", "port", INTEGER, 80, uint64(18446744073709551615))
+	assert.IsType(t, &wskderrors.ParameterTypeMismatchError{}, err, "above the largest integer")
+}
@@ -0,0 +1,115 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+)
+
+// ValidateManifest composes every entity of the manifest on its own, without
+// OpenWhisk, and returns the errors of all of them rather than the first one.
+// The code of actions is checked but neither built nor read, see
+// ActionBuilder.CheckCode(), and dependencies are not fetched.
+func (dm *YAMLParser) ValidateManifest(manifest *YAML, filePath string) []error {
+	problems := make([]error, 0)
+	check := func(err error) {
+		if err != nil {
+			problems = append(problems, err)
+		}
+	}
+
+	project := manifest.GetProject()
+	inherits, err := inheritsActionInputs(project, filePath)
+	check(err)
+
+	packages := manifest.GetPackages()
+	packageNamespaces := make(map[string]string)
+	names := make([]string, 0, len(packages))
+	for name, pkg := range packages {
+		packageNamespaces[name] = getPackageNamespace(pkg, dm.Namespace)
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	ma := whisk.KeyValue{}
+	// the sequences of all the packages, which may include each other
+	sequences := make([]utils.ActionRecord, 0)
+	for _, packageName := range names {
+		pkg, err := inheritProject(project, packages[packageName], packageName, filePath)
+		if err != nil {
+			check(err)
+			continue
+		}
+		_, err = dm.ComposePackage(pkg, packageName, filePath, ma)
+		check(err)
+		_, err = dm.ComposeDependencies(pkg, filepath.Dir(filePath), filePath, packageName)
+		check(err)
+		check(ValidateHooks(filePath, packageName, YAML_KEY_PRE_DEPLOY, pkg.PreDeploy))
+		check(ValidateHooks(filePath, packageName, YAML_KEY_POST_DEPLOY, pkg.PostDeploy))
+
+		for _, name := range sortedActionNames(pkg.Actions) {
+			action := pkg.Actions[name]
+			if inherits {
+				action.Inputs = inheritInputs(project.Inputs, action.Inputs)
+			}
+			if err := dm.checkNamingConvention(YAML_KEY_ACTION, name); err != nil {
+				check(err)
+				continue
+			}
+			check(ValidateHooks(filePath, packageName+"/"+name, YAML_KEY_PRE_DEPLOY, action.PreDeploy))
+			check(ValidateHooks(filePath, packageName+"/"+name, YAML_KEY_POST_DEPLOY, action.PostDeploy))
+			builder := NewActionBuilder(filePath, packageName, name, action, ma, dm.Flags)
+			steps := []ActionBuildStep{
+				(*ActionBuilder).ResolveRuntimeKind,
+				(*ActionBuilder).CheckCode,
+				(*ActionBuilder).ResolveParameters,
+				(*ActionBuilder).ResolveAnnotations,
+				(*ActionBuilder).ResolveWeb,
+				(*ActionBuilder).ResolveLimits,
+				(*ActionBuilder).CheckDelAnnotations,
+			}
+			// the steps of an action depend on the ones before, e.g. the code
+			// on the runtime, only its first error is reported
+			for _, step := range steps {
+				if err := step(builder); err != nil {
+					check(err)
+					break
+				}
+			}
+		}
+
+		packageSequences, err := dm.composeSequences(filePath, packageNamespaces[packageName], pkg.Sequences, packageName, packageNamespaces, ma)
+		check(err)
+		sequences = append(sequences, packageSequences...)
+		_, err = dm.ComposeTriggers(filePath, pkg, ma)
+		check(err)
+		_, err = dm.ComposeRules(pkg, packageName)
+		check(err)
+		_, err = dm.ComposeApiRecords(filePath, packageName, pkg, manifest)
+		check(err)
+	}
+
+	check(CheckSequenceCycles(filePath, sequences))
+	_, err = dm.ComposeBindingsFromAllPackages(manifest, filePath, ma)
+	check(err)
+	return problems
+}
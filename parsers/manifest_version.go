@@ -0,0 +1,103 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"gopkg.in/yaml.v2"
+)
+
+const YAML_KEY_WSKDEPLOY_VERSION = "wskdeploy_version"
+
+// VersionRequirement constrains the versions of wskdeploy (and of the
+// specification) a manifest or deployment file may be used with. It is either
+// a constraint on the version of wskdeploy or a map of both constraints:
+//
+//	wskdeploy_version: ">=0.9.8, <2.0"
+//	wskdeploy_version:
+//	  wskdeploy: ">=0.9.8, <2.0"
+//	  spec: ">=0.9.1"
+type VersionRequirement struct {
+	Wskdeploy string `yaml:"wskdeploy,omitempty"`
+	Spec      string `yaml:"spec,omitempty"`
+}
+
+type ParsedVersionRequirement VersionRequirement
+
+func (requirement *VersionRequirement) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var inline string
+	if err := unmarshal(&inline); err == nil {
+		requirement.Wskdeploy = inline
+		return nil
+	}
+
+	var aux ParsedVersionRequirement
+	if err := unmarshal(&aux); err != nil {
+		return err
+	}
+	*requirement = VersionRequirement(aux)
+	return nil
+}
+
+// CheckVersionRequirement fails if the running wskdeploy does not satisfy the
+// "wskdeploy_version" of a manifest or deployment file. The requirement is read
+// before the file is parsed, so that an older wskdeploy reports the version
+// required rather than the keys of the schema it does not know yet.
+func CheckVersionRequirement(content []byte, filePath string) error {
+	var file struct {
+		WskdeployVersion VersionRequirement `yaml:"wskdeploy_version"`
+	}
+	// a file which is not valid YAML is reported by the parser
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil
+	}
+
+	requirements := []struct {
+		key        string
+		constraint string
+		version    string
+	}{
+		{"wskdeploy", file.WskdeployVersion.Wskdeploy, utils.GetWskdeployVersion()},
+		{"spec", file.WskdeployVersion.Spec, utils.SPEC_VERSION},
+	}
+	for _, requirement := range requirements {
+		if len(requirement.constraint) == 0 {
+			continue
+		}
+		ok, err := utils.SatisfiesVersion(requirement.version, requirement.constraint)
+		if err != nil {
+			return wskderrors.NewYAMLFileFormatError(filePath,
+				wski18n.T(wski18n.ID_ERR_VERSION_REQUIREMENT_INVALID_X_key_X_value_X_err_X,
+					map[string]interface{}{
+						wski18n.KEY_KEY:   YAML_KEY_WSKDEPLOY_VERSION + "." + requirement.key,
+						wski18n.KEY_VALUE: requirement.constraint,
+						wski18n.KEY_ERR:   err.Error()}))
+		}
+		if !ok {
+			return wskderrors.NewYAMLFileFormatError(filePath,
+				wski18n.T(wski18n.ID_ERR_VERSION_REQUIREMENT_X_key_X_value_X_version_X,
+					map[string]interface{}{
+						wski18n.KEY_KEY:     requirement.key,
+						wski18n.KEY_VALUE:   requirement.constraint,
+						wski18n.KEY_VERSION: requirement.version}))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"gopkg.in/yaml.v2"
+)
+
+// ApplyManifestOverlays deep-merges the overlays given with --overlay over the
+// content of the manifest before it is parsed, in order, e.g. to specialize
+// a project for an environment. The maps of an overlay are merged with the
+// ones of the manifest: keys which are not in the manifest are added, e.g. an
+// action, keys with a null value (~) are removed, and any other value, lists
+// included, replaces the one of the manifest. Overlays may !include files
+// relative to them.
+func ApplyManifestOverlays(content []byte, overlays []string, manifestPath string) ([]byte, error) {
+	if len(overlays) == 0 {
+		return content, nil
+	}
+	base := yaml.MapSlice{}
+	if err := yaml.Unmarshal(content, &base); err != nil {
+		return nil, wskderrors.NewYAMLParserErr(manifestPath, err)
+	}
+
+	for _, overlayPath := range overlays {
+		overlayContent, err := utils.Read(overlayPath)
+		if err != nil {
+			return nil, wskderrors.NewFileReadError(overlayPath, err.Error())
+		}
+		if overlayContent, err = ResolveIncludes(overlayContent, overlayPath); err != nil {
+			return nil, err
+		}
+		var overlay interface{}
+		if err := yaml.Unmarshal(overlayContent, &overlay); err != nil {
+			return nil, wskderrors.NewYAMLParserErr(overlayPath, err)
+		}
+		if overlay == nil {
+			continue
+		}
+		if _, isMap := overlay.(map[interface{}]interface{}); !isMap {
+			return nil, wskderrors.NewYAMLFileFormatError(overlayPath,
+				wski18n.T(wski18n.ID_ERR_OVERLAY_NOT_MAP_X_path_X,
+					map[string]interface{}{wski18n.KEY_PATH: overlayPath}))
+		}
+		// read again to keep the order of the keys
+		overlayMap := yaml.MapSlice{}
+		if err := yaml.Unmarshal(overlayContent, &overlayMap); err != nil {
+			return nil, wskderrors.NewYAMLParserErr(overlayPath, err)
+		}
+		base = mergeOverlay(base, overlayMap)
+	}
+	return yaml.Marshal(base)
+}
+
+// mergeOverlay merges the keys of the overlay into the base map, see
+// ApplyManifestOverlays(), the order of the keys of the base is kept
+func mergeOverlay(base yaml.MapSlice, overlay yaml.MapSlice) yaml.MapSlice {
+	for _, item := range overlay {
+		key := fmt.Sprint(item.Key)
+		index := -1
+		for i, baseItem := range base {
+			if fmt.Sprint(baseItem.Key) == key {
+				index = i
+				break
+			}
+		}
+		switch {
+		case item.Value == nil:
+			if index >= 0 {
+				base = append(base[:index], base[index+1:]...)
+			}
+		case index < 0:
+			// the null values of a map which is added are left out as well
+			if overlayMap, isMap := item.Value.(yaml.MapSlice); isMap {
+				item.Value = mergeOverlay(yaml.MapSlice{}, overlayMap)
+			}
+			base = append(base, item)
+		default:
+			baseMap, baseIsMap := base[index].Value.(yaml.MapSlice)
+			overlayMap, overlayIsMap := item.Value.(yaml.MapSlice)
+			if baseIsMap && overlayIsMap {
+				base[index].Value = mergeOverlay(baseMap, overlayMap)
+			} else {
+				base[index].Value = item.Value
+			}
+		}
+	}
+	return base
+}
@@ -0,0 +1,92 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_OVERLAY_MANIFEST = `packages:
+  api:
+    actions:
+      greeting:
+        function: src/greeting.js
+        limits:
+          memorySize: 256
+        inputs:
+          name: Amy
+          debug: true
+      debug:
+        function: src/debug.js
+`
+
+const TEST_OVERLAY_PROD = `packages:
+  api:
+    actions:
+      greeting:
+        limits:
+          memorySize: 512
+        inputs:
+          debug: ~
+      debug: ~
+      health:
+        function: src/health.js
+`
+
+func TestApplyManifestOverlays(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wskdeploy-overlays")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	prod := filepath.Join(dir, "prod.yaml")
+	assert.Nil(t, ioutil.WriteFile(prod, []byte(TEST_OVERLAY_PROD), 0644))
+	region := filepath.Join(dir, "region.yaml")
+	assert.Nil(t, ioutil.WriteFile(region, []byte("packages:\n  api:\n    actions:\n      greeting:\n        inputs:\n          region: eu-de\n"), 0644))
+
+	content, err := ApplyManifestOverlays([]byte(TEST_OVERLAY_MANIFEST), []string{prod, region}, "manifest.yaml")
+	assert.Nil(t, err)
+	manifest := YAML{}
+	assert.Nil(t, NewYAMLParser().Unmarshal(content, &manifest))
+	actions := manifest.Packages["api"].Actions
+	assert.Equal(t, 512, *actions["greeting"].Limits.Memory)
+	assert.Equal(t, "src/greeting.js", actions["greeting"].Function, "the other keys are kept")
+	assert.Equal(t, "Amy", actions["greeting"].Inputs["name"].Value)
+	assert.Equal(t, "eu-de", actions["greeting"].Inputs["region"].Value, "overlays are applied in order")
+	assert.NotContains(t, actions["greeting"].Inputs, "debug", "null values remove keys")
+	assert.NotContains(t, actions, "debug")
+	assert.Equal(t, "src/health.js", actions["health"].Function, "entities are added")
+
+	content, err = ApplyManifestOverlays([]byte(TEST_OVERLAY_MANIFEST), nil, "manifest.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, TEST_OVERLAY_MANIFEST, string(content), "the manifest is left as it is without overlays")
+
+	list := filepath.Join(dir, "list.yaml")
+	assert.Nil(t, ioutil.WriteFile(list, []byte("- packages\n"), 0644))
+	_, err = ApplyManifestOverlays([]byte(TEST_OVERLAY_MANIFEST), []string{list}, "manifest.yaml")
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err)
+
+	_, err = ApplyManifestOverlays([]byte(TEST_OVERLAY_MANIFEST), []string{filepath.Join(dir, "missing.yaml")}, "manifest.yaml")
+	assert.IsType(t, &wskderrors.FileReadError{}, err)
+}
@@ -0,0 +1,188 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"gopkg.in/yaml.v2"
+)
+
+// ManifestOverride is a value set at a path of the manifest with --set, e.g.
+// packages.api.actions.greeting.limits.memorySize=512
+type ManifestOverride struct {
+	Path  []string
+	Value interface{}
+}
+
+// ParseManifestOverride parses "<path>=<value>", the keys of the path are
+// separated by dots, a dot within a key is escaped as "\.", and the value is
+// read as YAML so that numbers and booleans keep their type
+func ParseManifestOverride(override string) (ManifestOverride, error) {
+	invalid := wskderrors.NewCommandError("--set", wski18n.T(wski18n.ID_ERR_SET_INVALID_X_value_X,
+		map[string]interface{}{wski18n.KEY_VALUE: override}))
+	parts := strings.SplitN(override, "=", 2)
+	if len(parts) != 2 {
+		return ManifestOverride{}, invalid
+	}
+
+	keys := make([]string, 0)
+	key := ""
+	escaped := false
+	for _, c := range parts[0] {
+		switch {
+		case escaped:
+			key += string(c)
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '.':
+			keys = append(keys, key)
+			key = ""
+		default:
+			key += string(c)
+		}
+	}
+	keys = append(keys, key)
+	for _, key := range keys {
+		if len(strings.TrimSpace(key)) == 0 {
+			return ManifestOverride{}, invalid
+		}
+	}
+
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(parts[1]), &value); err != nil {
+		// the value is a string if it is not valid YAML, e.g. "a: b: c"
+		value = parts[1]
+	}
+	return ManifestOverride{Path: keys, Value: value}, nil
+}
+
+// ApplyManifestOverrides sets the values of --set in the content of the
+// manifest before it is parsed, the maps missing on the path of a value are
+// added. An index of a path, e.g. del_annotations.0, selects an item of a list.
+func ApplyManifestOverrides(content []byte, overrides []string, manifestPath string) ([]byte, error) {
+	if len(overrides) == 0 {
+		return content, nil
+	}
+	tree := yaml.MapSlice{}
+	if err := yaml.Unmarshal(content, &tree); err != nil {
+		return nil, wskderrors.NewYAMLParserErr(manifestPath, err)
+	}
+
+	var root interface{} = tree
+	for _, override := range overrides {
+		parsed, err := ParseManifestOverride(override)
+		if err != nil {
+			return nil, err
+		}
+		root, err = setOverride(root, parsed.Path, parsed.Value)
+		if err != nil {
+			return nil, wskderrors.NewYAMLFileFormatError(manifestPath,
+				wski18n.T(wski18n.ID_ERR_SET_PATH_X_value_X_key_X,
+					map[string]interface{}{wski18n.KEY_VALUE: override, wski18n.KEY_KEY: err.Error()}))
+		}
+	}
+	return yaml.Marshal(root)
+}
+
+// setOverride sets the value at the path of the node and returns the node, or
+// the key of the path which is neither a map nor a list as the error
+func setOverride(node interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	key, rest := path[0], path[1:]
+	switch n := node.(type) {
+	case nil:
+		child, err := setOverride(nil, rest, value)
+		if err != nil {
+			return nil, err
+		}
+		return yaml.MapSlice{{Key: key, Value: child}}, nil
+	case yaml.MapSlice:
+		for i, item := range n {
+			if fmt.Sprint(item.Key) == key {
+				child, err := setOverride(item.Value, rest, value)
+				if err != nil {
+					return nil, err
+				}
+				n[i].Value = child
+				return n, nil
+			}
+		}
+		child, err := setOverride(nil, rest, value)
+		if err != nil {
+			return nil, err
+		}
+		return append(n, yaml.MapItem{Key: key, Value: child}), nil
+	case []interface{}:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= len(n) {
+			return nil, fmt.Errorf("%s", key)
+		}
+		child, err := setOverride(n[index], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		n[index] = child
+		return n, nil
+	}
+	return nil, fmt.Errorf("%s", key)
+}
+
+// EntityOverride is a parameter or annotation set on an entity with --param or
+// --annotation, e.g. hello/greeting.name=Bernie, the entity is a package, an
+// action or sequence of a package, i.e. package/action, a trigger or a rule
+type EntityOverride struct {
+	Entity string
+	Key    string
+	// the value as given, and read as YAML, see ParseManifestOverride()
+	Raw   string
+	Value interface{}
+}
+
+// ParseEntityOverride parses "<entity>.<key>=<value>" given to the flag, the
+// key starts at the first dot so that it may contain dots and slashes, e.g.
+// hello/greeting.example.com/owner=team-a
+func ParseEntityOverride(flag string, override string) (EntityOverride, error) {
+	invalid := wskderrors.NewCommandError(flag, wski18n.T(wski18n.ID_ERR_ENTITY_OVERRIDE_INVALID_X_flag_X_value_X,
+		map[string]interface{}{wski18n.KEY_FLAG: flag, wski18n.KEY_VALUE: override}))
+	parts := strings.SplitN(override, "=", 2)
+	if len(parts) != 2 {
+		return EntityOverride{}, invalid
+	}
+	dot := strings.Index(parts[0], ".")
+	if dot < 0 {
+		return EntityOverride{}, invalid
+	}
+	entity, key := parts[0][:dot], parts[0][dot+1:]
+	if len(strings.TrimSpace(key)) == 0 || len(strings.Trim(entity, "/ ")) == 0 || strings.Count(entity, "/") > 1 {
+		return EntityOverride{}, invalid
+	}
+
+	var value interface{}
+	if err := yaml.Unmarshal([]byte(parts[1]), &value); err != nil || value == nil {
+		value = parts[1]
+	}
+	return EntityOverride{Entity: strings.Trim(entity, "/"), Key: key, Raw: parts[1], Value: value}, nil
+}
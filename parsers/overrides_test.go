@@ -0,0 +1,116 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_OVERRIDES_MANIFEST = `packages:
+  api:
+    actions:
+      greeting:
+        function: src/greeting.js
+        limits:
+          memorySize: 256
+        del_annotations:
+          - exec
+          - owner
+`
+
+func TestParseManifestOverride(t *testing.T) {
+	override, err := ParseManifestOverride("packages.api.actions.greeting.limits.memorySize=512")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"packages", "api", "actions", "greeting", "limits", "memorySize"}, override.Path)
+	assert.Equal(t, 512, override.Value)
+
+	override, err = ParseManifestOverride(`packages.api.annotations.example\.com/owner=team=a`)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"packages", "api", "annotations", "example.com/owner"}, override.Path)
+	assert.Equal(t, "team=a", override.Value, "the value follows the first =")
+
+	override, err = ParseManifestOverride("packages.api.actions.greeting.web=true")
+	assert.Nil(t, err)
+	assert.Equal(t, true, override.Value)
+
+	for _, invalid := range []string{"packages.api", "packages..api=1", "=1"} {
+		_, err = ParseManifestOverride(invalid)
+		assert.IsType(t, &wskderrors.CommandError{}, err, invalid)
+	}
+}
+
+func TestApplyManifestOverrides(t *testing.T) {
+	content, err := ApplyManifestOverrides([]byte(TEST_OVERRIDES_MANIFEST), []string{
+		"packages.api.actions.greeting.limits.memorySize=512",
+		"packages.api.actions.greeting.inputs.name=Amy",
+		"packages.api.actions.greeting.del_annotations.1=team",
+	}, "manifest.yaml")
+	assert.Nil(t, err)
+
+	manifest := YAML{}
+	assert.Nil(t, NewYAMLParser().Unmarshal(content, &manifest))
+	action := manifest.Packages["api"].Actions["greeting"]
+	assert.Equal(t, 512, *action.Limits.Memory)
+	assert.Equal(t, "src/greeting.js", action.Function, "the other keys are kept")
+	assert.Equal(t, "Amy", action.Inputs["name"].Value, "missing maps are added")
+	assert.Equal(t, []string{"exec", "team"}, action.DelAnnotations)
+
+	content, err = ApplyManifestOverrides([]byte(TEST_OVERRIDES_MANIFEST), nil, "manifest.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, TEST_OVERRIDES_MANIFEST, string(content), "the manifest is left as it is without overrides")
+
+	for _, invalid := range []string{
+		"packages.api.actions.greeting.function.name=hello",
+		"packages.api.actions.greeting.del_annotations.2=team",
+		"packages.api.actions.greeting.del_annotations.first=team",
+	} {
+		_, err = ApplyManifestOverrides([]byte(TEST_OVERRIDES_MANIFEST), []string{invalid}, "manifest.yaml")
+		assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err, invalid)
+	}
+}
+
+func TestParseEntityOverride(t *testing.T) {
+	override, err := ParseEntityOverride("--param", "hello/greeting.name=Bernie")
+	assert.Nil(t, err)
+	assert.Equal(t, EntityOverride{Entity: "hello/greeting", Key: "name", Raw: "Bernie", Value: "Bernie"}, override)
+
+	override, err = ParseEntityOverride("--annotation", "hello.example.com/owner=team=a")
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", override.Entity)
+	assert.Equal(t, "example.com/owner", override.Key)
+	assert.Equal(t, "team=a", override.Value, "the value follows the first =")
+
+	override, err = ParseEntityOverride("--param", "hello/greeting.port=0123")
+	assert.Nil(t, err)
+	assert.Equal(t, "0123", override.Raw)
+	assert.Equal(t, 83, override.Value, "the value is read as YAML")
+
+	override, err = ParseEntityOverride("--param", "myTrigger.empty=")
+	assert.Nil(t, err)
+	assert.Equal(t, "", override.Value)
+
+	for _, invalid := range []string{"hello/greeting=1", "hello/greeting.=1", ".name=1", "a/b/c.name=1", "hello.name"} {
+		_, err = ParseEntityOverride("--param", invalid)
+		assert.IsType(t, &wskderrors.CommandError{}, err, invalid)
+	}
+}
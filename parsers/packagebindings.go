@@ -0,0 +1,118 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskenv"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// ComposeBindingsFromAllPackages composes the package bindings declared by the
+// packages of the manifest, keyed by the name of the package declaring them.
+// A binding may not have the name of a package or dependency of the manifest.
+func (dm *YAMLParser) ComposeBindingsFromAllPackages(manifest *YAML, filePath string, ma whisk.KeyValue) (map[string][]*whisk.BindingPackage, error) {
+	packages := manifest.GetPackages()
+	names := make(map[string]bool)
+	for packageName, pkg := range packages {
+		names[packageName] = true
+		for depName := range pkg.Dependencies {
+			names[depName] = true
+		}
+	}
+
+	bindings := make(map[string][]*whisk.BindingPackage)
+	for packageName, pkg := range packages {
+		composed, err := dm.ComposeBindings(filePath, packageName, pkg, ma)
+		if err != nil {
+			return nil, err
+		}
+		for _, binding := range composed {
+			if names[binding.Name] {
+				return nil, wskderrors.NewYAMLFileFormatError(filePath,
+					wski18n.T(wski18n.ID_ERR_BINDING_NAME_CONFLICT_X_binding_X_package_X,
+						map[string]interface{}{wski18n.KEY_BINDING: binding.Name, wski18n.KEY_PACKAGE: packageName}))
+			}
+			names[binding.Name] = true
+		}
+		if len(composed) > 0 {
+			bindings[packageName] = composed
+		}
+	}
+	return bindings, nil
+}
+
+// ComposeBindings composes the bindings of a package, they are created in the
+// namespace of the package, and so is a bound package which is not qualified
+func (dm *YAMLParser) ComposeBindings(filePath string, packageName string, pkg Package, ma whisk.KeyValue) ([]*whisk.BindingPackage, error) {
+	names := make([]string, 0, len(pkg.Bindings))
+	for name := range pkg.Bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	bindings := make([]*whisk.BindingPackage, 0, len(names))
+	for _, name := range names {
+		binding := pkg.Bindings[name]
+		bindingName := wskenv.ConvertSingleName(name)
+		if err := dm.checkNamingConvention(YAML_KEY_PACKAGE, bindingName); err != nil {
+			return nil, err
+		}
+		bound := strings.TrimSpace(wskenv.ConvertSingleName(binding.Package))
+		if len(bound) == 0 {
+			return nil, wskderrors.NewYAMLFileFormatError(filePath,
+				wski18n.T(wski18n.ID_ERR_BINDING_PACKAGE_MISSING_X_binding_X_package_X,
+					map[string]interface{}{wski18n.KEY_BINDING: bindingName, wski18n.KEY_PACKAGE: packageName}))
+		}
+		qName, err := utils.ParseQualifiedName(bound, pkg.Namespace)
+		if err != nil {
+			return nil, wskderrors.NewYAMLFileFormatError(filePath, err.Error())
+		}
+
+		pub := false
+		wskBinding := &whisk.BindingPackage{
+			Name:      bindingName,
+			Namespace: pkg.Namespace,
+			Publish:   &pub,
+			Binding:   whisk.Binding{Namespace: qName.Namespace, Name: qName.EntityName},
+		}
+		for inputName, param := range binding.Inputs {
+			value, err := ResolveParameter(inputName, &param, filePath, dm.Flags.SecretsFromEnv)
+			if err != nil {
+				return nil, err
+			}
+			if value != nil {
+				wskBinding.Parameters = append(wskBinding.Parameters, whisk.KeyValue{Key: inputName, Value: value})
+			}
+		}
+		for key, value := range binding.Annotations {
+			wskBinding.Annotations = append(wskBinding.Annotations, whisk.KeyValue{Key: key, Value: ResolveAnnotation(value)})
+		}
+		wskBinding.Annotations = AddEncryptedInputs(wskBinding.Annotations, binding.Inputs)
+		if dm.Flags.Managed {
+			wskBinding.Annotations = append(wskBinding.Annotations, ma)
+		}
+		bindings = append(bindings, wskBinding)
+	}
+	return bindings, nil
+}
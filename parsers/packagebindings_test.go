@@ -0,0 +1,72 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_BINDINGS_MANIFEST = "../tests/dat/manifest_bindings.yaml"
+
+func TestComposeBindingsFromAllPackages(t *testing.T) {
+	p := NewYAMLParser()
+	m, err := p.ParseManifest(TEST_BINDINGS_MANIFEST)
+	assert.Nil(t, err)
+
+	bindings, err := p.ComposeBindingsFromAllPackages(m, TEST_BINDINGS_MANIFEST, whisk.KeyValue{})
+	assert.Nil(t, err)
+	if !assert.Equal(t, 2, len(bindings["helloworld"])) {
+		return
+	}
+
+	cloudant := bindings["helloworld"][0]
+	assert.Equal(t, "mycloudant", cloudant.Name)
+	assert.Equal(t, whisk.Binding{Namespace: "whisk.system", Name: "cloudant"}, cloudant.Binding)
+	assert.False(t, *cloudant.Publish)
+	assert.Equal(t, "mydb", cloudant.Parameters.GetValue("dbname"))
+	assert.Equal(t, "admin", cloudant.Parameters.GetValue("username"))
+	assert.Equal(t, "team-a", cloudant.Annotations.GetValue("owner"))
+	assert.NotNil(t, cloudant.Annotations.GetValue(utils.ENCRYPTED_INPUTS_ANNOT))
+
+	// a package which is not qualified is a package of the namespace
+	greetings := bindings["helloworld"][1]
+	assert.Equal(t, "mygreetings", greetings.Name)
+	assert.Equal(t, "greetings", greetings.Binding.Name)
+	assert.Equal(t, 0, len(greetings.Parameters))
+}
+
+func TestComposeBindingsFromAllPackages_Invalid(t *testing.T) {
+	p := NewYAMLParser()
+	manifests := map[string]string{
+		"missing package": "packages:\n  helloworld:\n    bindings:\n      mycloudant:\n        inputs:\n          dbname: mydb\n",
+		"package name":    "packages:\n  helloworld:\n    bindings:\n      helloworld:\n        package: /whisk.system/cloudant\n",
+		"dependency name": "packages:\n  helloworld:\n    dependencies:\n      mycloudant:\n        location: /whisk.system/cloudant\n    bindings:\n      mycloudant:\n        package: /whisk.system/cloudant\n",
+	}
+	for name, manifest := range manifests {
+		var m YAML
+		assert.Nil(t, p.Unmarshal([]byte(manifest), &m), name)
+		_, err := p.ComposeBindingsFromAllPackages(&m, TEST_BINDINGS_MANIFEST, whisk.KeyValue{})
+		assert.NotNil(t, err, name)
+	}
+}
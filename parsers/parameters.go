@@ -19,10 +19,14 @@ package parsers
 import (
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 	"encoding/json"
 	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wskenv"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
 )
 
 // TODO(): Support other valid Package Manifest types
@@ -35,6 +39,7 @@ const (
 	FLOAT	string = "float"
 	BOOLEAN	string = "boolean"
 	JSON	string = "json"
+	ARRAY	string = "array"
 )
 
 var validParameterNameMap = map[string]string{
@@ -52,6 +57,8 @@ var validParameterNameMap = map[string]string{
 	"float64": FLOAT,
 	JSON:	   JSON,
 	"map":     JSON,
+	ARRAY:     ARRAY,
+	"slice":   ARRAY,
 }
 
 var typeDefaultValueMap = map[string]interface{}{
@@ -60,6 +67,7 @@ var typeDefaultValueMap = map[string]interface{}{
 	FLOAT:   0.0,
 	BOOLEAN: false,
 	JSON:    make(map[string]interface{}),
+	ARRAY:   make([]interface{}, 0),
 	// TODO() Support these types + their validation
 	// timestamp
 	// null
@@ -150,19 +158,20 @@ func resolveSingleLineParameter(filePath string, paramName string, param *Parame
 		// if this is the case, we must detect it and set the value to the default for that type name.
 		if param.Value != nil && param.Type == "string" {
 			// The value is a <string>; now we must test if is the name of a known Type
-			if isValidParameterType(param.Value.(string)) {
+			// values of an unknown type, e.g. uint64, are an error of ResolveParamTypeFromValue
+			if typeName, ok := param.Value.(string); ok && isValidParameterType(typeName) {
 				// If the value is indeed the name of a Type, we must change BOTH its
 				// Type to be that type and its value to that Type's default value
-				param.Type = param.Value.(string)
+				param.Type = typeName
 				param.Value = getTypeDefaultValue(param.Type)
 				//fmt.Printf("EXIT: Parameter [%s] type=[%v] value=[%v]\n", paramName, param.Type, param.Value)
 			}
 		}
 
 	} else {
-		// TODO() - move string to i18n
 		return param.Value, wskderrors.NewYAMLParserErr(filePath,
-			"Parameter [" + paramName + "] is not single-line format.")
+			wski18n.T(wski18n.ID_ERR_PARAMETER_NOT_SINGLE_LINE_X_key_X,
+				map[string]interface{}{wski18n.KEY_KEY: paramName}))
 	}
 
 	return param.Value, errorParser
@@ -201,9 +210,9 @@ func resolveMultiLineParameter(filePath string, paramName string, param *Paramet
 		// if we have a declared parameter Type, assure that it is a known value
 		if param.Type != "" {
 			if !isValidParameterType(param.Type) {
-				// TODO() - move string to i18n
 				return param.Value, wskderrors.NewYAMLParserErr(filePath,
-					"Parameter [" + paramName + "] has an invalid Type. [" + param.Type + "]")
+					wski18n.T(wski18n.ID_ERR_PARAMETER_TYPE_INVALID_X_key_X_value_X,
+						map[string]interface{}{wski18n.KEY_KEY: paramName, wski18n.KEY_VALUE: param.Type}))
 			}
 		} else {
 			// if we do not have a value for the Parameter Type, use the Parameter Value's Type
@@ -215,9 +224,9 @@ func resolveMultiLineParameter(filePath string, paramName string, param *Paramet
 		//	errorParser = utils.NewParameterTypeMismatchError("", param.Type, valueType )
 		//}
 	} else {
-		// TODO() - move string to i18n
 		return param.Value, wskderrors.NewYAMLParserErr(filePath,
-			"Parameter [" + paramName + "] is not multiline format.")
+			wski18n.T(wski18n.ID_ERR_PARAMETER_NOT_MULTILINE_X_key_X,
+				map[string]interface{}{wski18n.KEY_KEY: paramName}))
 	}
 
 
@@ -267,12 +276,14 @@ func resolveJSONParameter(filePath string, paramName string, param *Parameter, v
 				return temp, errorParser
 			}
 		} else{
-			errorParser = wskderrors.NewParameterTypeMismatchError(filePath, paramName, JSON, param.Type)
+			errorParser = wskderrors.NewParameterValueConversionError(filePath, paramName, JSON,
+				actualParameterType(param.Value), param.Value)
 		}
 
 	} else {
-		// TODO() - move string to i18n
-		errorParser = wskderrors.NewYAMLParserErr(filePath, "Parameter [" + paramName + "] is not JSON format.")
+		errorParser = wskderrors.NewYAMLParserErr(filePath,
+			wski18n.T(wski18n.ID_ERR_PARAMETER_NOT_JSON_X_key_X,
+				map[string]interface{}{wski18n.KEY_KEY: paramName}))
 	}
 
 	return param.Value, errorParser
@@ -295,11 +306,12 @@ func resolveJSONParameter(filePath string, paramName string, param *Parameter, v
     - paramName: name of the parameter for error reporting
     - filepath: the path, including name, of the YAML file which contained the parameter for error reporting
     - param: pointer to Parameter structure being resolved
+    - secretsFromEnv: whether the value of a secret parameter must come from a variable, see --secrets-from-env
 
     Returns:
     - (interface{}) the parameter's resolved value
  */
-func ResolveParameter(paramName string, param *Parameter, filePath string) (interface{}, error) {
+func ResolveParameter(paramName string, param *Parameter, filePath string, secretsFromEnv bool) (interface{}, error) {
 
 	var errorParser error
 	// default resolved parameter value to empty string
@@ -321,11 +333,17 @@ func ResolveParameter(paramName string, param *Parameter, filePath string) (inte
 	// See if we have any Environment Variable replacement within the parameter's value
 
 	// Make sure the parameter's value is a valid, non-empty string
-	if ( param.Value != nil && param.Type == "string") {
+	// Note: values declared as other scalar types may also come from an Env. variable, e.g. "$PORT"
+	if _, isString := param.Value.(string); isString && isScalarParameterType(param.Type) {
 		// perform $ notation replacement on string if any exist
 		value = wskenv.GetEnvVar(param.Value)
 	}
 
+	// Honor the declared Type of multi-line parameters by converting the value to it
+	if param.multiline && errorParser == nil && value != nil {
+		value, errorParser = coerceParameterValue(filePath, paramName, param.Type, value)
+	}
+
 	// JSON - Handle both cases, where value 1) is a string containing JSON, 2) is a map of JSON
 	if param.Type == "json" {
 		value, errorParser = resolveJSONParameter(filePath, paramName, param, value)
@@ -333,19 +351,341 @@ func ResolveParameter(paramName string, param *Parameter, filePath string) (inte
 
 	// Default value to zero value for the Type
 	// Do NOT error/terminate as Value may be provided later by a Deployment file.
-	if value == nil {
+	// A required parameter is left without value, see ServiceDeployer.ValidateRequiredInputs()
+	if value == nil && !param.Required {
 		value = getTypeDefaultValue(param.Type)
 		// @TODO(): Need warning message here to warn of default usage, support for warnings (non-fatal)
 		//msgs := []string{"Parameter [" + paramName + "] is not multiline format."}
 		//return param.Value, utils.NewParserErr(filePath, nil, msgs)
 	}
 
+	if errorParser == nil {
+		errorParser = RegisterSecretParameter(filePath, paramName, param, value, secretsFromEnv)
+	}
+
 	// Trace Parameter struct after resolution
 	//dumpParameter(paramName, param, "AFTER")
 	//fmt.Printf("EXIT: Parameter [%s] type=[%v] value=[%v]\n", paramName, param.Type, value)
 	return value, errorParser
 }
 
+/*
+    RegisterSecretParameter masks the resolved value of a parameter marked as secret in
+    everything printed from now on, see wskprint.MaskSecrets(). With --secrets-from-env,
+    the value of a secret parameter may not be written in the file, it must come from an
+    environment variable (or the --secrets-file).
+
+    Inputs:
+    - filePath: the path, including name, of the YAML file which contained the parameter for error reporting
+    - paramName: name of the parameter for error reporting
+    - param: pointer to the Parameter structure
+    - value: the resolved value of the parameter
+    - secretsFromEnv: whether the value must come from a variable, see --secrets-from-env
+ */
+func RegisterSecretParameter(filePath string, paramName string, param *Parameter, value interface{}, secretsFromEnv bool) error {
+	if !param.Secret {
+		return nil
+	}
+	if secretsFromEnv && param.Value != nil && !wskenv.IsEnvVarReference(param.Value) {
+		return wskderrors.NewYAMLFileFormatError(filePath,
+			wski18n.T(wski18n.ID_ERR_SECRET_PARAMETER_NOT_FROM_ENV_X_key_X,
+				map[string]interface{}{wski18n.KEY_KEY: paramName}))
+	}
+
+	switch v := value.(type) {
+	case nil:
+	case string:
+		wskprint.AddSecret(v)
+	default:
+		// values are printed either way
+		wskprint.AddSecret(fmt.Sprintf("%v", v))
+		if bytes, err := json.Marshal(v); err == nil {
+			wskprint.AddSecret(string(bytes))
+		}
+	}
+	return nil
+}
+
+// how the list value of an input of the deployment file is merged with the one
+// of the manifest, see MergeInputValue()
+const (
+	INPUT_MERGE_REPLACE      = "replace"
+	INPUT_MERGE_APPEND       = "append"
+	INPUT_MERGE_UNIQUE_UNION = "unique-union"
+)
+
+/*
+    MergeInputValue returns the value of an input of the deployment file merged with the value of
+    the same input in the manifest, according to the merge of the input:
+    - "replace" (default): the value of the deployment file replaces the one of the manifest
+    - "append": the items of the list of the deployment file follow the ones of the manifest
+    - "unique-union": likewise, leaving out the items which are in the list of the manifest already
+    so that a deployment file binds the items which differ from one environment to another rather
+    than repeating the whole list.
+
+    Inputs:
+    - filePath: the path, including name, of the YAML file which contained the parameter for error reporting
+    - paramName: name of the parameter for error reporting
+    - merge: the merge of the input
+    - base: the value of the input in the manifest, nil if the manifest has none
+    - value: the resolved value of the input in the deployment file
+ */
+func MergeInputValue(filePath string, paramName string, merge string, base interface{}, value interface{}) (interface{}, error) {
+	switch merge {
+	case "", INPUT_MERGE_REPLACE:
+		return value, nil
+	case INPUT_MERGE_APPEND, INPUT_MERGE_UNIQUE_UNION:
+	default:
+		return nil, wskderrors.NewYAMLFileFormatError(filePath,
+			wski18n.T(wski18n.ID_ERR_INPUT_MERGE_INVALID_X_key_X_value_X_merges_X,
+				map[string]interface{}{wski18n.KEY_KEY: paramName, wski18n.KEY_VALUE: merge,
+					wski18n.KEY_MERGES: strings.Join([]string{INPUT_MERGE_REPLACE, INPUT_MERGE_APPEND, INPUT_MERGE_UNIQUE_UNION}, ", ")}))
+	}
+
+	items, ok := listItems(value)
+	baseItems, baseOk := listItems(base)
+	if !ok || (base != nil && !baseOk) {
+		return nil, wskderrors.NewYAMLFileFormatError(filePath,
+			wski18n.T(wski18n.ID_ERR_INPUT_MERGE_NOT_LIST_X_key_X_value_X,
+				map[string]interface{}{wski18n.KEY_KEY: paramName, wski18n.KEY_VALUE: merge}))
+	}
+
+	merged := append(make([]interface{}, 0, len(baseItems)+len(items)), baseItems...)
+	for _, item := range items {
+		if merge == INPUT_MERGE_UNIQUE_UNION && containsItem(merged, item) {
+			continue
+		}
+		merged = append(merged, item)
+	}
+	return merged, nil
+}
+
+/*
+    CoerceInputValue converts the value of an input of the deployment file to the type it declares
+    or, when it declares none, to the type of the scalar value of the manifest it overrides, e.g. the
+    string "8080" of an environment variable overriding the integer 80. Values which cannot be
+    converted losslessly are an error.
+
+    Inputs:
+    - filePath: the path, including name, of the YAML file which contained the parameter for error reporting
+    - paramName: name of the parameter for error reporting
+    - paramType: the type declared by the input in the deployment file, if any
+    - base: the value of the input in the manifest, nil if the manifest has none
+    - value: the resolved value of the input in the deployment file
+ */
+func CoerceInputValue(filePath string, paramName string, paramType string, base interface{}, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if len(paramType) == 0 {
+		// a string which is empty, e.g. from an unset Env. variable, is kept as is
+		s, isString := value.(string)
+		if !isString || base == nil || strings.TrimSpace(s) == "" {
+			return value, nil
+		}
+		paramType = actualParameterType(base)
+	}
+	if !isScalarParameterType(paramType) {
+		return value, nil
+	}
+	coerced, err := coerceParameterValue(filePath, paramName, paramType, value)
+	if err != nil {
+		return nil, err
+	}
+	if coerced == nil {
+		return getTypeDefaultValue(paramType), nil
+	}
+	return coerced, nil
+}
+
+// listItems returns the items of a list value
+func listItems(value interface{}) ([]interface{}, bool) {
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) {
+		return nil, false
+	}
+	items := make([]interface{}, 0, v.Len())
+	for i := 0; i < v.Len(); i++ {
+		items = append(items, v.Index(i).Interface())
+	}
+	return items, true
+}
+
+func containsItem(items []interface{}, item interface{}) bool {
+	for _, i := range items {
+		if reflect.DeepEqual(i, item) {
+			return true
+		}
+	}
+	return false
+}
+
+func isScalarParameterType(typeName string) bool {
+	return typeName == STRING || typeName == INTEGER || typeName == FLOAT || typeName == BOOLEAN
+}
+
+/*
+    coerceParameterValue converts a parameter value to the parameter's declared Type.
+
+    Conversions are explicit and only performed when they are lossless, e.g. the string "8080"
+    or the float 8080.0 can become the integer 8080, but 80.8 or "eighty" cannot. A new value
+    is always returned, the original value (which may be shared through a YAML anchor) is never
+    modified.
+
+    Inputs:
+    - filePath: the path, including name, of the YAML file which contained the parameter for error reporting
+    - paramName: name of the parameter for error reporting
+    - paramType: the declared parameter type
+    - value: the value to convert
+
+    Returns:
+    - (interface{}) the converted value
+ */
+func coerceParameterValue(filePath string, paramName string, paramType string, value interface{}) (interface{}, error) {
+	// an empty string (e.g. from an unset Env. variable) leaves the value to be set to the type's default value
+	if v, isString := value.(string); isString && paramType != STRING && strings.TrimSpace(v) == "" {
+		return nil, nil
+	}
+
+	switch paramType {
+	case STRING:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case int, int64, uint64, float64, bool:
+			return fmt.Sprint(v), nil
+		}
+
+	case INTEGER:
+		switch v := value.(type) {
+		case int:
+			return v, nil
+		case int64:
+			if int64(int(v)) == v {
+				return int(v), nil
+			}
+		case uint64:
+			// values above the largest int would wrap to negative ones
+			if i := int(v); i >= 0 && uint64(i) == v {
+				return i, nil
+			}
+		case float64:
+			if v == float64(int(v)) {
+				return int(v), nil
+			}
+		case string:
+			if i, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+				return i, nil
+			}
+		}
+
+	case FLOAT:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int:
+			return float64(v), nil
+		case int64:
+			return float64(v), nil
+		case uint64:
+			return float64(v), nil
+		case string:
+			if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+				return f, nil
+			}
+		}
+
+	case BOOLEAN:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return b, nil
+			}
+		}
+
+	case ARRAY:
+		switch v := value.(type) {
+		case []interface{}:
+			return convertParameterArray(v), nil
+		case string:
+			var parsed []interface{}
+			if err := json.Unmarshal([]byte(v), &parsed); err == nil {
+				return parsed, nil
+			}
+		}
+
+	default:
+		// JSON values are resolved by resolveJSONParameter()
+		return value, nil
+	}
+
+	return value, wskderrors.NewParameterValueConversionError(filePath, paramName, paramType,
+		actualParameterType(value), value)
+}
+
+// actualParameterType returns the parameter type name of a value for error reporting
+func actualParameterType(value interface{}) string {
+	if value == nil {
+		return "nil"
+	}
+	kind := reflect.TypeOf(value).Kind().String()
+	if typeName, found := validParameterNameMap[kind]; found {
+		return typeName
+	}
+	return kind
+}
+
+// convertParameterArray copies an array value, converting the YAML maps it contains so that
+// they can be serialized as JSON
+func convertParameterArray(items []interface{}) []interface{} {
+	converted := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		switch v := item.(type) {
+		case map[interface{}]interface{}:
+			converted = append(converted, utils.ConvertInterfaceMap(v))
+		case []interface{}:
+			converted = append(converted, convertParameterArray(v))
+		default:
+			converted = append(converted, v)
+		}
+	}
+	return converted
+}
+
+// ResolveAnnotation returns the value of an annotation as it is sent to OpenWhisk.
+// Strings are interpolated (see wskenv.GetEnvVar), booleans and numbers are kept
+// as is, YAML maps are converted to JSON objects and the values of maps and
+// lists are resolved as well, e.g. "require-whisk-auth: 1234" or structured
+// annotations keep their types.
+func ResolveAnnotation(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return wskenv.GetEnvVar(v)
+	case map[interface{}]interface{}:
+		resolved := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			resolved[fmt.Sprintf("%v", key)] = ResolveAnnotation(item)
+		}
+		return resolved
+	case map[string]interface{}:
+		resolved := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			resolved[key] = ResolveAnnotation(item)
+		}
+		return resolved
+	case []interface{}:
+		resolved := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			resolved = append(resolved, ResolveAnnotation(item))
+		}
+		return resolved
+	default:
+		return v
+	}
+}
+
 // Provide custom Parameter marshalling and unmarshalling
 type ParsedParameter Parameter
 
@@ -362,6 +702,9 @@ func (n *Parameter) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		n.Default = aux.Default
 		n.Status = aux.Status
 		n.Schema = aux.Schema
+		n.Secret = aux.Secret
+		n.Encrypted = aux.Encrypted
+		n.Merge = aux.Merge
 		return nil
 	}
 
@@ -378,7 +721,7 @@ func (n *Parameter) UnmarshalYAML(unmarshal func(interface{}) error) error {
 
 func (n *Parameter) MarshalYAML() (interface{}, error) {
 	if _, ok := n.Value.(string); len(n.Type) == 0 && len(n.Description) == 0 && ok {
-		if !n.Required && len(n.Status) == 0 && n.Schema == nil {
+		if !n.Required && len(n.Status) == 0 && n.Schema == nil && !n.Secret && !n.Encrypted && len(n.Merge) == 0 {
 			return n.Value.(string), nil
 		}
 	}
@@ -392,10 +735,12 @@ func dumpParameter(paramName string, param *Parameter, separator string) {
 	fmt.Printf("%s:\n", separator)
 	fmt.Printf("\t%s: (%T)\n", paramName, param)
 	if param != nil {
-		fmt.Printf("\t\tParameter.Description: [%s]\n", param.Description)
-		fmt.Printf("\t\tParameter.Type: [%s]\n", param.Type)
-		fmt.Printf("\t\t--> Actual Type: [%T]\n", param.Value)
-		fmt.Printf("\t\tParameter.Value: [%v]\n", param.Value)
-		fmt.Printf("\t\tParameter.Default: [%v]\n", param.Default)
+		fmt.Println(wski18n.T(wski18n.ID_MSG_PARAMETER_DUMP_X_description_X_type_X_actual_X_value_X_default_X,
+			map[string]interface{}{
+				wski18n.KEY_DESCRIPTION: param.Description,
+				wski18n.KEY_TYPE:        param.Type,
+				wski18n.KEY_ACTUAL:      fmt.Sprintf("%T", param.Value),
+				wski18n.KEY_VALUE:       param.Value,
+				wski18n.KEY_DEFAULT:     param.Default}))
 	}
 }
@@ -0,0 +1,134 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// PluginDeclaration declares a plugin in the manifest, the command run for the
+// custom keys it handles, e.g.
+//   plugins:
+//     cloudant:
+//       run: ./plugins/cloudant
+//       keys: [cloudant_databases]
+type PluginDeclaration struct {
+	Run  string   `yaml:"run"`  //used in manifest.yaml, command run by the shell, see deployers.CommandPlugin
+	Keys []string `yaml:"keys"` //used in manifest.yaml, custom keys of the project and its packages
+}
+
+// PluginKeys are the custom keys handled by the plugins built into wskdeploy,
+// by key, see deployers.RegisterPlugin()
+var PluginKeys = make(map[string]string)
+
+// Extension is the value of a custom key of the project or of a package, and
+// the name of the plugin which handles it
+type Extension struct {
+	Plugin string
+	Key    string
+	// the package of the key, empty for a key of the project
+	Package string
+	Value   interface{}
+}
+
+// ManifestExtensions returns the custom keys of the project and of the
+// packages of the manifest, the ones of the project first, then the ones of
+// each package by name. A key which no plugin declared in the manifest or
+// built into wskdeploy handles is an error, as any unknown key is.
+func ManifestExtensions(manifest *YAML, filePath string) ([]Extension, error) {
+	project := manifest.GetProject()
+	keys := make(map[string]string, len(PluginKeys))
+	for key, plugin := range PluginKeys {
+		keys[key] = plugin
+	}
+	declared := make(map[string]string)
+	for _, name := range sortedPluginNames(project.Plugins) {
+		plugin := project.Plugins[name]
+		if len(strings.TrimSpace(plugin.Run)) == 0 || len(plugin.Keys) == 0 {
+			return nil, wskderrors.NewYAMLFileFormatError(filePath, wski18n.T(wski18n.ID_ERR_PLUGIN_INVALID_X_name_X,
+				map[string]interface{}{wski18n.KEY_NAME: name}))
+		}
+		for _, key := range plugin.Keys {
+			if other, ok := declared[key]; ok {
+				return nil, wskderrors.NewYAMLFileFormatError(filePath, wski18n.T(wski18n.ID_ERR_PLUGIN_KEY_CONFLICT_X_key_X_name_X_value_X,
+					map[string]interface{}{wski18n.KEY_KEY: key, wski18n.KEY_NAME: other, wski18n.KEY_VALUE: name}))
+			}
+			declared[key] = name
+			keys[key] = name
+		}
+	}
+	return extensions(manifest, filePath, keys)
+}
+
+// CheckNoExtensions returns an error if the project or a package of a
+// deployment file has a key which is not part of its schema, custom keys are
+// only handled in the manifest
+func CheckNoExtensions(deployment *YAML, filePath string) error {
+	_, err := extensions(deployment, filePath, map[string]string{})
+	return err
+}
+
+func extensions(manifest *YAML, filePath string, keys map[string]string) ([]Extension, error) {
+	result := make([]Extension, 0)
+	add := func(entity string, packageName string, values map[string]interface{}) error {
+		names := make([]string, 0, len(values))
+		for key := range values {
+			names = append(names, key)
+		}
+		sort.Strings(names)
+		for _, key := range names {
+			plugin, ok := keys[key]
+			if !ok {
+				return wskderrors.NewYAMLFileFormatError(filePath, wski18n.T(wski18n.ID_ERR_KEY_UNKNOWN_X_key_X_entity_X,
+					map[string]interface{}{wski18n.KEY_KEY: key, wski18n.KEY_ENTITY: entity}))
+			}
+			result = append(result, Extension{Plugin: plugin, Key: key, Package: packageName, Value: values[key]})
+		}
+		return nil
+	}
+
+	project := manifest.GetProject()
+	if err := add(YAML_KEY_PROJECT, "", project.Extensions); err != nil {
+		return nil, err
+	}
+	packages := manifest.GetPackages()
+	names := make([]string, 0, len(packages))
+	for name := range packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := add(name, name, packages[name].Extensions); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func sortedPluginNames(plugins map[string]PluginDeclaration) []string {
+	names := make([]string, 0, len(plugins))
+	for name := range plugins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,116 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifestExtensions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wskdeploy-plugins")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	manifestPath := filepath.Join(dir, "manifest.yaml")
+	write := func(content string) {
+		assert.Nil(t, ioutil.WriteFile(manifestPath, []byte(content), 0644))
+	}
+
+	write(`project:
+  name: shop
+  plugins:
+    cloudant:
+      run: ./plugins/cloudant
+      keys: [cloudant_databases]
+  cloudant_databases:
+    audit: {}
+  packages:
+    billing:
+      cloudant_databases:
+        orders:
+          partitioned: true
+      actions:
+        charge:
+          function: actions/hello.js
+`)
+	p := NewYAMLParser()
+	m, err := p.ParseManifest(manifestPath)
+	assert.Nil(t, err)
+	extensions, err := ManifestExtensions(m, manifestPath)
+	assert.Nil(t, err)
+	if assert.Equal(t, 2, len(extensions)) {
+		assert.Equal(t, Extension{Plugin: "cloudant", Key: "cloudant_databases",
+			Value: map[interface{}]interface{}{"audit": map[interface{}]interface{}{}}}, extensions[0], "keys of the project first")
+		assert.Equal(t, "billing", extensions[1].Package)
+	}
+
+	// keys of the plugins built into wskdeploy are accepted as well
+	defer func() { delete(PluginKeys, "redis_caches") }()
+	PluginKeys["redis_caches"] = "redis"
+	write(`packages:
+  billing:
+    redis_caches:
+      sessions: {}
+`)
+	m, err = p.ParseManifest(manifestPath)
+	assert.Nil(t, err)
+	extensions, _ = ManifestExtensions(m, manifestPath)
+	assert.Equal(t, []Extension{{Plugin: "redis", Key: "redis_caches", Package: "billing",
+		Value: map[interface{}]interface{}{"sessions": map[interface{}]interface{}{}}}}, extensions)
+
+	invalid := map[string]string{
+		"unknown key": `packages:
+  billing:
+    cloudant_databases: {}
+`,
+		"plugin without command": `project:
+  plugins:
+    cloudant:
+      keys: [cloudant_databases]
+`,
+		"key of two plugins": `project:
+  plugins:
+    cloudant:
+      run: ./cloudant
+      keys: [databases]
+    couchdb:
+      run: ./couchdb
+      keys: [databases]
+`,
+	}
+	for name, content := range invalid {
+		write(content)
+		_, err := p.ParseManifest(manifestPath)
+		assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err, name)
+	}
+}
+
+func TestCheckNoExtensions(t *testing.T) {
+	deployment := &YAML{Project: Project{Packages: map[string]Package{
+		"billing": {Extensions: map[string]interface{}{"cloudant_databases": nil}},
+	}}}
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, CheckNoExtensions(deployment, "deployment.yaml"))
+	assert.Nil(t, CheckNoExtensions(&YAML{}, "deployment.yaml"))
+}
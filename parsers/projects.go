@@ -0,0 +1,178 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskenv"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"gopkg.in/yaml.v2"
+)
+
+// a line "---" starts a new YAML document
+var documentSeparatorRegex = regexp.MustCompile(`(?m)^---[ \t]*(#.*)?$`)
+
+// ProjectDocument is a YAML document of a manifest or deployment file, a file
+// (or a directory of files) may define several projects, one per document
+type ProjectDocument struct {
+	Name     string // name of the project, may be empty
+	Filepath string // file the document was read from
+	Content  []byte
+}
+
+// SplitDocuments splits the content of a YAML file into its documents, empty
+// documents (e.g. the comments before the first separator) are left out
+func SplitDocuments(content []byte) [][]byte {
+	documents := make([][]byte, 0)
+	for _, document := range documentSeparatorRegex.Split(string(content), -1) {
+		if !isEmptyDocument(document) {
+			documents = append(documents, []byte(document))
+		}
+	}
+	return documents
+}
+
+func isEmptyDocument(document string) bool {
+	for _, line := range strings.Split(document, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) > 0 && !strings.HasPrefix(line, "#") {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadProjectIndex reads the projects defined in a manifest or deployment file,
+// or in the .yaml and .yml files of a directory
+func ReadProjectIndex(filePath string) ([]ProjectDocument, error) {
+	files := []string{filePath}
+	if info, err := os.Stat(filePath); err == nil && info.IsDir() {
+		files = make([]string, 0)
+		entries, err := ioutil.ReadDir(filePath)
+		if err != nil {
+			return nil, wskderrors.NewFileReadError(filePath, err.Error())
+		}
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+				files = append(files, filepath.Join(filePath, entry.Name()))
+			}
+		}
+		if len(files) == 0 {
+			return nil, wskderrors.NewErrorManifestFileNotFound(filePath,
+				wski18n.T(wski18n.ID_MSG_MANIFEST_FILE_NOT_FOUND_X_path_X,
+					map[string]interface{}{wski18n.KEY_PATH: filePath}))
+		}
+		sort.Strings(files)
+	}
+
+	index := make([]ProjectDocument, 0)
+	for _, file := range files {
+		content, err := utils.Read(file)
+		if err != nil {
+			return nil, wskderrors.NewFileReadError(file, err.Error())
+		}
+		content, err = NormalizeContent(content, file)
+		if err != nil {
+			return nil, err
+		}
+		if err = CheckYAMLLimits(content, file); err != nil {
+			return nil, err
+		}
+
+		documents := SplitDocuments(content)
+		if len(documents) == 0 {
+			// an empty file is still a (empty) document
+			documents = append(documents, content)
+		}
+		for _, document := range documents {
+			name, err := projectName(document)
+			if err != nil {
+				return nil, wskderrors.NewYAMLParserErr(file, err)
+			}
+			index = append(index, ProjectDocument{Name: name, Filepath: file, Content: document})
+		}
+	}
+	return index, nil
+}
+
+func projectName(document []byte) (string, error) {
+	var names struct {
+		Project     struct{ Name string } `yaml:"project"`
+		Application struct{ Name string } `yaml:"application"`
+	}
+	if err := yaml.Unmarshal(document, &names); err != nil {
+		return "", err
+	}
+	name := names.Project.Name
+	if len(name) == 0 {
+		name = names.Application.Name
+	}
+	if value, ok := wskenv.GetEnvVar(name).(string); ok {
+		name = value
+	}
+	return name, nil
+}
+
+// SelectProject returns the document of the project selected with
+// --project-name. A file which defines several projects is ambiguous unless a
+// project is selected, a file which defines a single project is returned as
+// is unless strict is true and another project is selected.
+func SelectProject(index []ProjectDocument, projectName string, filePath string, strict bool) (ProjectDocument, error) {
+	names := make([]string, 0, len(index))
+	seen := make(map[string]bool)
+	for _, document := range index {
+		if seen[document.Name] {
+			return ProjectDocument{}, wskderrors.NewYAMLFileFormatError(filePath,
+				wski18n.T(wski18n.ID_ERR_PROJECT_DUPLICATE_X_project_X_path_X,
+					map[string]interface{}{wski18n.KEY_PROJECT: document.Name, wski18n.KEY_PATH: filePath}))
+		}
+		seen[document.Name] = true
+		names = append(names, document.Name)
+	}
+
+	if len(projectName) == 0 {
+		if len(index) > 1 {
+			return ProjectDocument{}, wskderrors.NewYAMLFileFormatError(filePath,
+				wski18n.T(wski18n.ID_ERR_PROJECT_AMBIGUOUS_X_path_X_projects_X,
+					map[string]interface{}{wski18n.KEY_PATH: filePath, wski18n.KEY_PROJECTS: strings.Join(names, ", ")}))
+		}
+		return index[0], nil
+	}
+
+	for _, document := range index {
+		if document.Name == projectName {
+			return document, nil
+		}
+	}
+	if len(index) == 1 && !strict {
+		return index[0], nil
+	}
+	return ProjectDocument{}, wskderrors.NewYAMLFileFormatError(filePath,
+		wski18n.T(wski18n.ID_ERR_PROJECT_NOT_FOUND_X_project_X_path_X_projects_X,
+			map[string]interface{}{wski18n.KEY_PROJECT: projectName, wski18n.KEY_PATH: filePath,
+				wski18n.KEY_PROJECTS: strings.Join(names, ", ")}))
+}
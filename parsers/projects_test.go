@@ -0,0 +1,85 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_MANIFEST_MULTIPLE_PROJECTS = "../tests/dat/manifest_multiple_projects.yaml"
+
+func TestSplitDocuments(t *testing.T) {
+	documents := SplitDocuments([]byte("---\na: 1\n--- # second\nb: 2\n---\n\n"))
+	assert.Equal(t, 2, len(documents))
+	assert.Equal(t, "\na: 1\n", string(documents[0]))
+	assert.Equal(t, "\nb: 2\n", string(documents[1]))
+
+	// a separator is only recognized at the start of a line
+	assert.Equal(t, 1, len(SplitDocuments([]byte("a: |\n  ---\n  text\n"))))
+}
+
+func TestReadProjectIndex(t *testing.T) {
+	index, err := ReadProjectIndex(TEST_MANIFEST_MULTIPLE_PROJECTS)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(index))
+	assert.Equal(t, "catalog", index[0].Name)
+	assert.Equal(t, "orders", index[1].Name)
+
+	document, err := SelectProject(index, "orders", TEST_MANIFEST_MULTIPLE_PROJECTS, true)
+	assert.Nil(t, err)
+	assert.Equal(t, "orders", document.Name)
+
+	// a project must be selected when several are defined
+	_, err = SelectProject(index, "", TEST_MANIFEST_MULTIPLE_PROJECTS, true)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "catalog, orders")
+	_, err = SelectProject(index, "payments", TEST_MANIFEST_MULTIPLE_PROJECTS, false)
+	assert.NotNil(t, err)
+
+	_, err = SelectProject(append(index, index[0]), "catalog", TEST_MANIFEST_MULTIPLE_PROJECTS, true)
+	assert.NotNil(t, err)
+
+	// a single project is used as is unless strict
+	document, err = SelectProject(index[:1], "payments", TEST_MANIFEST_MULTIPLE_PROJECTS, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "catalog", document.Name)
+	_, err = SelectProject(index[:1], "payments", TEST_MANIFEST_MULTIPLE_PROJECTS, true)
+	assert.NotNil(t, err)
+}
+
+func TestParseManifest_MultipleProjects(t *testing.T) {
+	defer func() { utils.Flags.ProjectName = "" }()
+	p := NewYAMLParser()
+
+	_, err := p.ParseManifest(TEST_MANIFEST_MULTIPLE_PROJECTS)
+	assert.NotNil(t, err)
+
+	utils.Flags.ProjectName = "orders"
+	manifest, err := p.ParseManifest(TEST_MANIFEST_MULTIPLE_PROJECTS)
+	assert.Nil(t, err)
+	assert.Equal(t, "orders", manifest.GetProject().Name)
+	assert.Equal(t, TEST_MANIFEST_MULTIPLE_PROJECTS, manifest.Filepath)
+	_, ok := manifest.GetPackages()["orders"]
+	assert.True(t, ok)
+	assert.Equal(t, 1, len(manifest.GetPackages()))
+}
@@ -0,0 +1,187 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskenv"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"gopkg.in/yaml.v2"
+)
+
+// References of the form ${packages.<path>} refer to the entities of the
+// manifest itself, e.g. ${packages.hello.actions.world.name}
+const ENTITY_REFERENCE_PREFIX = YAML_KEY_PACKAGES + "."
+
+// the key of a reference which is replaced with the fully qualified name of
+// the entity rather than with a value of the manifest
+const ENTITY_REFERENCE_NAME = "name"
+
+var entityReferenceRegex = regexp.MustCompile(`\$\{` + regexp.QuoteMeta(ENTITY_REFERENCE_PREFIX) + `([^}]+)\}`)
+
+// ResolveEntityReferences replaces the ${packages.<path>} references of the
+// content of a manifest, so that e.g. a rule or an API does not hardcode the
+// package of the action it refers to:
+//   ${packages.<package>.name}                  "/namespace/package"
+//   ${packages.<package>.actions.<action>.name}  "/namespace/package/action",
+//     likewise for sequences
+//   ${packages.<package>.triggers.<trigger>.name} "/namespace/trigger",
+//     likewise for rules
+//   ${packages.<package>.<any other path>}      the value at that path, e.g.
+//     ${packages.hello.actions.world.inputs.name}
+// Values may refer to other entities themselves, references which refer back
+// to themselves are an error. The namespace is the one of the package, if it
+// declares one, or the given namespace.
+func ResolveEntityReferences(content []byte, filePath string, namespace string) ([]byte, error) {
+	if !entityReferenceRegex.Match(content) {
+		return content, nil
+	}
+
+	var tree map[interface{}]interface{}
+	if err := yaml.Unmarshal(content, &tree); err != nil {
+		return nil, wskderrors.NewYAMLParserErr(filePath, err)
+	}
+	if len(namespace) == 0 {
+		namespace = whisk.DEFAULT_NAMESPACE
+	}
+	resolver := entityReferenceResolver{tree: tree, filePath: filePath, namespace: namespace}
+
+	resolved, err := resolver.resolve(string(content), nil)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(resolved), nil
+}
+
+type entityReferenceResolver struct {
+	tree      map[interface{}]interface{}
+	filePath  string
+	namespace string
+}
+
+// resolve replaces the references of a string, chain is the references being
+// resolved so far
+func (resolver entityReferenceResolver) resolve(value string, chain []string) (string, error) {
+	var err error
+	resolved := entityReferenceRegex.ReplaceAllStringFunc(value, func(match string) string {
+		if err != nil {
+			return match
+		}
+		reference := entityReferenceRegex.FindStringSubmatch(match)[1]
+		for _, r := range chain {
+			if r == reference {
+				err = wskderrors.NewYAMLFileFormatError(resolver.filePath,
+					wski18n.T(wski18n.ID_ERR_ENTITY_REFERENCE_CYCLE_X_reference_X_chain_X,
+						map[string]interface{}{
+							wski18n.KEY_REFERENCE: ENTITY_REFERENCE_PREFIX + reference,
+							wski18n.KEY_CHAIN:     referenceChain(append(chain, reference))}))
+				return match
+			}
+		}
+		var referenced string
+		if referenced, err = resolver.lookup(reference); err == nil {
+			referenced, err = resolver.resolve(referenced, append(chain, reference))
+		}
+		return referenced
+	})
+	return resolved, err
+}
+
+// lookup returns the fully qualified name or the value a reference refers to
+func (resolver entityReferenceResolver) lookup(reference string) (string, error) {
+	keys := strings.Split(reference, ".")
+	pkg, ok := resolver.findPackage(keys[0])
+	if ok && len(keys) == 2 && keys[1] == ENTITY_REFERENCE_NAME {
+		return fmt.Sprintf("/%s/%s", resolver.packageNamespace(pkg), keys[0]), nil
+	}
+	if ok && len(keys) == 4 && keys[3] == ENTITY_REFERENCE_NAME {
+		if _, exists := lookupKey(pkg, keys[1], keys[2]); exists {
+			switch keys[1] {
+			case YAML_KEY_ACTIONS, YAML_KEY_SEQUENCES:
+				return fmt.Sprintf("/%s/%s/%s", resolver.packageNamespace(pkg), keys[0], keys[2]), nil
+			case YAML_KEY_TRIGGERS, YAML_KEY_RULES:
+				return fmt.Sprintf("/%s/%s", resolver.packageNamespace(pkg), keys[2]), nil
+			}
+		}
+	}
+	if ok {
+		if value, exists := lookupKey(pkg, keys[1:]...); exists {
+			switch value.(type) {
+			case map[interface{}]interface{}, []interface{}, nil:
+			default:
+				return fmt.Sprint(value), nil
+			}
+		}
+	}
+	return "", wskderrors.NewYAMLFileFormatError(resolver.filePath,
+		wski18n.T(wski18n.ID_ERR_ENTITY_REFERENCE_UNRESOLVED_X_reference_X,
+			map[string]interface{}{wski18n.KEY_REFERENCE: ENTITY_REFERENCE_PREFIX + reference}))
+}
+
+// findPackage returns the package of the manifest with the given name, from
+// the packages of the project or the ones of the manifest
+func (resolver entityReferenceResolver) findPackage(name string) (interface{}, bool) {
+	for _, section := range []string{YAML_KEY_PROJECT, YAML_KEY_APPLICATION} {
+		if pkg, ok := lookupKey(resolver.tree, section, YAML_KEY_PACKAGES, name); ok {
+			return pkg, true
+		}
+	}
+	if pkg, ok := lookupKey(resolver.tree, YAML_KEY_PACKAGES, name); ok {
+		return pkg, true
+	}
+	if packageName, ok := lookupKey(resolver.tree, YAML_KEY_PACKAGE, "name"); ok && packageName == name {
+		return resolver.tree[YAML_KEY_PACKAGE], true
+	}
+	return nil, false
+}
+
+func (resolver entityReferenceResolver) packageNamespace(pkg interface{}) string {
+	if namespace, ok := lookupKey(pkg, "namespace"); ok {
+		if s, ok := namespace.(string); ok && len(s) > 0 {
+			return wskenv.ConvertSingleName(s)
+		}
+	}
+	return resolver.namespace
+}
+
+// lookupKey returns the value of nested keys of a YAML mapping
+func lookupKey(node interface{}, keys ...string) (interface{}, bool) {
+	for _, key := range keys {
+		mapping, ok := node.(map[interface{}]interface{})
+		if !ok {
+			return nil, false
+		}
+		if node, ok = mapping[key]; !ok {
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+func referenceChain(chain []string) string {
+	references := make([]string, 0, len(chain))
+	for _, reference := range chain {
+		references = append(references, ENTITY_REFERENCE_PREFIX+reference)
+	}
+	return strings.Join(references, " -> ")
+}
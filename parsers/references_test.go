@@ -0,0 +1,63 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+var manifest_validate_entity_references = "../tests/dat/manifest_validate_entity_references.yaml"
+
+func TestParseManifest_EntityReferences(t *testing.T) {
+	parser := NewYAMLParser()
+	parser.Namespace = "guest"
+	manifest, err := parser.ParseManifest(manifest_validate_entity_references)
+	assert.Nil(t, err)
+
+	packages := manifest.GetProject().Packages
+	assert.Equal(t, "Hello, World", packages["backend"].Actions["greet"].Inputs["greeting"].Value,
+		"references to values are resolved")
+	assert.Equal(t, SequenceActions{"/guest/backend/world", " /guest/backend/greet"},
+		packages["frontend"].Sequences["greeting"].Actions)
+	rule := packages["frontend"].Rules["greet_hourly"]
+	assert.Equal(t, "/guest/everyhour", rule.Trigger)
+	assert.Equal(t, "/tenant1/frontend/greeting", rule.Action, "the namespace of the package")
+}
+
+func TestResolveEntityReferences(t *testing.T) {
+	content := []byte("packages:\n  hello:\n    actions:\n      world:\n        inputs:\n          name: ${packages.hello.name}\n")
+	resolved, err := ResolveEntityReferences(content, "manifest.yaml", "")
+	assert.Nil(t, err)
+	assert.Equal(t, "packages:\n  hello:\n    actions:\n      world:\n        inputs:\n          name: /_/hello\n", string(resolved))
+
+	_, err = ResolveEntityReferences([]byte("packages:\n  hello:\n    inputs:\n      name: ${packages.bye.name}\n"), "manifest.yaml", "guest")
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err, "unknown package")
+	_, err = ResolveEntityReferences([]byte("packages:\n  hello:\n    inputs:\n      name: ${packages.hello.inputs}\n"), "manifest.yaml", "guest")
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err, "references to mappings are not resolved")
+
+	cycle := []byte("packages:\n  hello:\n    inputs:\n      a: ${packages.hello.inputs.b}\n      b: x${packages.hello.inputs.a}\n")
+	_, err = ResolveEntityReferences(cycle, "manifest.yaml", "guest")
+	if assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err) {
+		assert.Contains(t, err.Error(), "packages.hello.inputs.b -> packages.hello.inputs.a -> packages.hello.inputs.b")
+	}
+}
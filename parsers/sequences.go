@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// CheckSequenceCycles returns an error if a sequence of the manifest includes
+// itself, directly or through the other sequences of the manifest. OpenWhisk
+// accepts such a sequence but fails when it is invoked. The error prints the
+// cycle, e.g. /guest/hello/s1 -> /guest/hello/s2 -> /guest/hello/s1.
+func CheckSequenceCycles(filePath string, sequences []utils.ActionRecord) error {
+	// the components of the sequences, by /namespace/package/sequence
+	components := make(map[string][]string, len(sequences))
+	for _, record := range sequences {
+		name := path.Join("/"+record.Action.Namespace, record.Packagename, record.Action.Name)
+		components[name] = record.Action.Exec.Components
+	}
+	names := make([]string, 0, len(components))
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// the sequences whose components are checked, and the ones being checked
+	checked := make(map[string]bool)
+	var visit func(name string, chain []string) []string
+	visit = func(name string, chain []string) []string {
+		for i, n := range chain {
+			if n == name {
+				return append(chain[i:], name)
+			}
+		}
+		if checked[name] {
+			return nil
+		}
+		chain = append(chain, name)
+		for _, component := range components[name] {
+			if _, ok := components[component]; !ok {
+				continue
+			}
+			if cycle := visit(component, chain); cycle != nil {
+				return cycle
+			}
+		}
+		checked[name] = true
+		return nil
+	}
+
+	for _, name := range names {
+		if cycle := visit(name, nil); cycle != nil {
+			return wskderrors.NewYAMLFileFormatError(filePath,
+				wski18n.T(wski18n.ID_ERR_SEQUENCE_CYCLE_X_sequence_X_chain_X,
+					map[string]interface{}{wski18n.KEY_SEQUENCE: cycle[0], wski18n.KEY_CHAIN: strings.Join(cycle, " -> ")}))
+		}
+	}
+	return nil
+}
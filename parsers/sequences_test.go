@@ -0,0 +1,74 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func sequenceRecord(packageName string, name string, components ...string) utils.ActionRecord {
+	action := &whisk.Action{Name: name, Namespace: "guest", Exec: &whisk.Exec{Kind: YAML_KEY_SEQUENCE, Components: components}}
+	return utils.ActionRecord{Action: action, Packagename: packageName}
+}
+
+func TestCheckSequenceCycles(t *testing.T) {
+	sequences := []utils.ActionRecord{
+		sequenceRecord("hello", "s1", "/guest/hello/a", "/guest/hello/s2"),
+		sequenceRecord("hello", "s2", "/guest/hello/s1"),
+	}
+	err := CheckSequenceCycles("manifest.yaml", sequences)
+	assert.NotNil(t, err)
+	assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err)
+	assert.Contains(t, err.Error(), "/guest/hello/s1 -> /guest/hello/s2 -> /guest/hello/s1")
+
+	sequences = []utils.ActionRecord{sequenceRecord("hello", "s1", "/guest/hello/s1")}
+	err = CheckSequenceCycles("manifest.yaml", sequences)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "/guest/hello/s1 -> /guest/hello/s1")
+}
+
+func TestCheckSequenceCycles_NoCycle(t *testing.T) {
+	sequences := []utils.ActionRecord{
+		sequenceRecord("hello", "s1", "/guest/hello/s2", "/guest/hello/s2"),
+		sequenceRecord("hello", "s2", "/guest/hello/a", "/whisk.system/utils/echo"),
+		// the same name in another namespace is another sequence
+		sequenceRecord("hello", "s3", "/other/hello/s3"),
+	}
+	assert.Nil(t, CheckSequenceCycles("manifest.yaml", sequences))
+}
+
+func TestComposeSequences_Cycle(t *testing.T) {
+	manifestFile := "../tests/dat/manifest_validate_sequence_cycle.yaml"
+	p := NewYAMLParser()
+	m, err := p.ParseManifest(manifestFile)
+	assert.Nil(t, err)
+
+	_, err = p.ComposeSequencesFromAllPackages("guest", m, whisk.KeyValue{})
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "/guest/billing/checkout -> /guest/notify/send -> /guest/billing/retry -> /guest/billing/checkout")
+
+	problems := p.ValidateManifest(m, manifestFile)
+	assert.Equal(t, 1, len(problems))
+}
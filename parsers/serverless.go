@@ -0,0 +1,674 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"gopkg.in/yaml.v2"
+)
+
+// serverless.yml files of the serverless-openwhisk plugin of the Serverless
+// Framework, see https://github.com/serverless/serverless-openwhisk
+const (
+	SERVERLESS_FILE_NAME          = "serverless.yml"
+	SERVERLESS_PROVIDER_OPENWHISK = "openwhisk"
+	SERVERLESS_PLUGIN_OPENWHISK   = "serverless-openwhisk"
+	SERVERLESS_EVENT_HTTP         = "http"
+	SERVERLESS_EVENT_TRIGGER      = "trigger"
+	SERVERLESS_EVENT_SCHEDULE     = "schedule"
+	// the feed schedule events are created with
+	SERVERLESS_ALARM_FEED = "/whisk.system/alarms/alarm"
+	// the main function of actions which do not name one
+	DEFAULT_MAIN_FUNCTION = "main"
+)
+
+// ServerlessYAML is the part of a serverless.yml which has an equivalent in
+// the manifest
+type ServerlessYAML struct {
+	Service   string                        `yaml:"service"`
+	Provider  ServerlessProvider            `yaml:"provider"`
+	Plugins   []string                      `yaml:"plugins,omitempty"`
+	Functions map[string]ServerlessFunction `yaml:"functions,omitempty"`
+	Resources ServerlessResources           `yaml:"resources,omitempty"`
+}
+
+type ServerlessProvider struct {
+	Name      string `yaml:"name"`
+	Runtime   string `yaml:"runtime,omitempty"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// ServerlessFunction is an action, named package/action, or a sequence
+type ServerlessFunction struct {
+	Handler     string                   `yaml:"handler,omitempty"` // file without extension and main function, e.g. src/hello.main
+	Name        string                   `yaml:"name,omitempty"`
+	Runtime     string                   `yaml:"runtime,omitempty"`
+	Memory      int                      `yaml:"memory,omitempty"`  // in MB
+	Timeout     int                      `yaml:"timeout,omitempty"` // in seconds
+	Parameters  map[string]interface{}   `yaml:"parameters,omitempty"`
+	Annotations map[string]interface{}   `yaml:"annotations,omitempty"`
+	Sequence    []string                 `yaml:"sequence,omitempty"`
+	Events      []map[string]interface{} `yaml:"events,omitempty"`
+}
+
+type ServerlessResources struct {
+	Packages map[string]ServerlessPackage `yaml:"packages,omitempty"`
+	Triggers map[string]ServerlessTrigger `yaml:"triggers,omitempty"`
+	Apigw    *ServerlessApigw             `yaml:"apigw,omitempty"`
+}
+
+type ServerlessPackage struct {
+	Parameters  map[string]interface{} `yaml:"parameters,omitempty"`
+	Annotations map[string]interface{} `yaml:"annotations,omitempty"`
+	Binding     string                 `yaml:"binding,omitempty"` // /namespace/package
+}
+
+type ServerlessTrigger struct {
+	Parameters     map[string]interface{} `yaml:"parameters,omitempty"`
+	Annotations    map[string]interface{} `yaml:"annotations,omitempty"`
+	Feed           string                 `yaml:"feed,omitempty"`
+	FeedParameters map[string]interface{} `yaml:"feed_parameters,omitempty"`
+}
+
+// ServerlessApigw is the base path of the http events, the name of the
+// service by default
+type ServerlessApigw struct {
+	Basepath string `yaml:"basepath,omitempty"`
+}
+
+// ReadServerless reads a serverless.yml of the openwhisk provider
+func ReadServerless(filePath string) (*ServerlessYAML, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	serverless := &ServerlessYAML{}
+	if err := yaml.Unmarshal(content, serverless); err != nil {
+		return nil, wskderrors.NewYAMLParserErr(filePath, err)
+	}
+	if serverless.Provider.Name != SERVERLESS_PROVIDER_OPENWHISK {
+		return nil, wskderrors.NewYAMLFileFormatError(filePath, wski18n.T(wski18n.ID_ERR_SERVERLESS_PROVIDER_X_provider_X,
+			map[string]interface{}{wski18n.KEY_PROVIDER: serverless.Provider.Name}))
+	}
+	return serverless, nil
+}
+
+// serverlessWarning is the message about a key of an entity which is left out
+// since it has no equivalent in the other format
+func serverlessWarning(key string, name string) string {
+	return wski18n.T(wski18n.ID_WARN_SERVERLESS_LEFT_OUT_X_key_X_name_X,
+		map[string]interface{}{wski18n.KEY_KEY: key, wski18n.KEY_NAME: name})
+}
+
+// ManifestToServerless converts the manifest to a serverless.yml of the
+// serverless-openwhisk plugin, written next to the manifest, along with the
+// warnings about what has no equivalent in serverless.yml and is left out
+func ManifestToServerless(manifest *YAML) (*ServerlessYAML, []string) {
+	warnings := make([]string, 0)
+	project := manifest.GetProject()
+	packages := manifest.GetPackages()
+	packageNames := make([]string, 0, len(packages))
+	for name := range packages {
+		packageNames = append(packageNames, name)
+	}
+	sort.Strings(packageNames)
+
+	serverless := &ServerlessYAML{
+		Service:   project.Name,
+		Provider:  ServerlessProvider{Name: SERVERLESS_PROVIDER_OPENWHISK, Namespace: project.Namespace},
+		Plugins:   []string{SERVERLESS_PLUGIN_OPENWHISK},
+		Functions: make(map[string]ServerlessFunction),
+		Resources: ServerlessResources{
+			Packages: make(map[string]ServerlessPackage),
+			Triggers: make(map[string]ServerlessTrigger),
+		},
+	}
+	if len(serverless.Service) == 0 && len(packageNames) > 0 {
+		serverless.Service = packageNames[0]
+	}
+	if len(project.Inputs) > 0 {
+		warnings = append(warnings, serverlessWarning("inputs", serverless.Service))
+	}
+
+	// functions are named after their action, and after their package as
+	// well if several packages have an action of the same name
+	count := make(map[string]int)
+	for _, pkg := range packages {
+		for name := range pkg.Actions {
+			count[name]++
+		}
+		for name := range pkg.Sequences {
+			count[name]++
+		}
+	}
+	functionKeys := make(map[string]string)
+	functionKey := func(packageName string, name string) string {
+		if count[name] > 1 {
+			return packageName + "-" + name
+		}
+		return name
+	}
+	for _, packageName := range packageNames {
+		for name := range packages[packageName].Actions {
+			functionKeys[path.Join(packageName, name)] = functionKey(packageName, name)
+		}
+		for name := range packages[packageName].Sequences {
+			functionKeys[path.Join(packageName, name)] = functionKey(packageName, name)
+		}
+	}
+	// the function of an action of a package, e.g. greeting or hello/greeting
+	functionOf := func(packageName string, action string) (string, bool) {
+		action = strings.TrimSpace(action)
+		if !strings.Contains(action, "/") {
+			action = path.Join(packageName, action)
+		}
+		key, ok := functionKeys[action]
+		return key, ok
+	}
+	addEvent := func(key string, event map[string]interface{}) {
+		function := serverless.Functions[key]
+		function.Events = append(function.Events, event)
+		serverless.Functions[key] = function
+	}
+
+	for _, packageName := range packageNames {
+		pkg := packages[packageName]
+		if len(pkg.Inputs) > 0 || len(pkg.Annotations) > 0 {
+			serverless.Resources.Packages[packageName] = ServerlessPackage{
+				Parameters:  serverlessParameters(pkg.Inputs),
+				Annotations: pkg.Annotations,
+			}
+		}
+		for name, binding := range pkg.Bindings {
+			location := binding.Package
+			if !strings.HasPrefix(location, "/") {
+				location = "/_/" + location
+			}
+			serverless.Resources.Packages[name] = ServerlessPackage{
+				Parameters:  serverlessParameters(binding.Inputs),
+				Annotations: binding.Annotations,
+				Binding:     location,
+			}
+		}
+		for name, dependency := range pkg.Dependencies {
+			if !strings.HasPrefix(dependency.Location, "/") {
+				warnings = append(warnings, serverlessWarning(YAML_KEY_DEPENDENCY, name))
+				continue
+			}
+			serverless.Resources.Packages[name] = ServerlessPackage{
+				Parameters:  serverlessParameters(dependency.Inputs),
+				Annotations: dependency.Annotations,
+				Binding:     dependency.Location,
+			}
+		}
+		if len(pkg.PreDeploy) > 0 || len(pkg.PostDeploy) > 0 || len(pkg.Notifications) > 0 {
+			warnings = append(warnings, serverlessWarning("hooks", packageName))
+		}
+
+		for _, name := range sortedActionNames(pkg.Actions) {
+			action := pkg.Actions[name]
+			qualified := path.Join(packageName, name)
+			function := ServerlessFunction{
+				Name:        qualified,
+				Runtime:     action.Runtime,
+				Parameters:  serverlessParameters(action.Inputs),
+				Annotations: serverlessAnnotations(action.Annotations, action.Web, action.Webexport),
+			}
+			if len(action.RuntimeVersion) > 0 && !strings.Contains(action.Runtime, ":") {
+				function.Runtime = action.Runtime + ":" + action.RuntimeVersion
+			}
+			code := action.Function
+			if len(code) == 0 {
+				code = action.Location
+			}
+			ext := filepath.Ext(code)
+			main := action.Main
+			if len(main) == 0 {
+				main = DEFAULT_MAIN_FUNCTION
+			}
+			function.Handler = strings.TrimSuffix(code, ext) + "." + main
+			if len(ext) == 0 || ext == "."+utils.ZIP_FILE_EXTENSION || len(action.Build) > 0 {
+				warnings = append(warnings, wski18n.T(wski18n.ID_WARN_SERVERLESS_HANDLER_X_name_X_function_X,
+					map[string]interface{}{wski18n.KEY_NAME: qualified, wski18n.KEY_FUNCTION: code}))
+			}
+			if action.Limits != nil {
+				if action.Limits.Memory != nil {
+					function.Memory = *action.Limits.Memory
+				}
+				if action.Limits.Timeout != nil {
+					// serverless.yml sets timeouts in seconds
+					function.Timeout = (*action.Limits.Timeout + 999) / 1000
+				}
+			}
+			if len(action.PreDeploy) > 0 || len(action.PostDeploy) > 0 || len(action.Notifications) > 0 {
+				warnings = append(warnings, serverlessWarning("hooks", qualified))
+			}
+			serverless.Functions[functionKeys[qualified]] = function
+		}
+
+		names := make([]string, 0, len(pkg.Sequences))
+		for name := range pkg.Sequences {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			sequence := pkg.Sequences[name]
+			function := ServerlessFunction{
+				Name:        path.Join(packageName, name),
+				Parameters:  serverlessParameters(sequence.Inputs),
+				Annotations: serverlessAnnotations(sequence.Annotations, sequence.Web, ""),
+				Sequence:    make([]string, 0, len(sequence.Actions)),
+			}
+			for _, component := range sequence.Actions {
+				component = strings.TrimSpace(component)
+				if key, ok := functionOf(packageName, component); ok && !strings.HasPrefix(component, "/") {
+					component = key
+				}
+				function.Sequence = append(function.Sequence, component)
+			}
+			serverless.Functions[functionKeys[path.Join(packageName, name)]] = function
+		}
+
+		for name, trigger := range pkg.Triggers {
+			serverlessTrigger := ServerlessTrigger{Annotations: trigger.Annotations, Feed: trigger.Feed}
+			if len(serverlessTrigger.Feed) == 0 {
+				serverlessTrigger.Feed = trigger.Source
+			}
+			if len(serverlessTrigger.Feed) > 0 {
+				serverlessTrigger.FeedParameters = serverlessParameters(trigger.Inputs)
+			} else {
+				serverlessTrigger.Parameters = serverlessParameters(trigger.Inputs)
+			}
+			serverless.Resources.Triggers[name] = serverlessTrigger
+		}
+
+		for name, rule := range pkg.Rules {
+			key, ok := functionOf(packageName, rule.Action)
+			if !ok {
+				warnings = append(warnings, serverlessWarning(YAML_KEY_RULE, name))
+				continue
+			}
+			addEvent(key, map[string]interface{}{
+				SERVERLESS_EVENT_TRIGGER: map[string]interface{}{"name": rule.Trigger, "rule": name},
+			})
+		}
+
+		if len(pkg.Apis.Swagger) > 0 {
+			warnings = append(warnings, serverlessWarning(YAML_KEY_SWAGGER, packageName))
+		}
+		for _, api := range sortedApis(pkg.GetApis()) {
+			key, ok := functionOf(packageName, api.Action.Name)
+			if !ok {
+				warnings = append(warnings, serverlessWarning(YAML_KEY_API, api.ApiName))
+				continue
+			}
+			// the http events of serverless.yml share the base path
+			basepath := "/" + strings.Trim(api.GatewayBasePath, "/")
+			relPath := "/" + strings.Trim(api.GatewayRelPath, "/")
+			if serverless.Resources.Apigw == nil {
+				serverless.Resources.Apigw = &ServerlessApigw{Basepath: basepath}
+			} else if serverless.Resources.Apigw.Basepath != basepath {
+				warnings = append(warnings, serverlessWarning(YAML_KEY_API, basepath+relPath))
+				continue
+			}
+			addEvent(key, map[string]interface{}{
+				SERVERLESS_EVENT_HTTP: strings.ToUpper(api.Action.BackendMethod) + " " + relPath,
+			})
+		}
+	}
+	return serverless, warnings
+}
+
+// sortedApis sorts the APIs of a package by base path, path and verb
+func sortedApis(apis []*whisk.Api) []*whisk.Api {
+	sort.Slice(apis, func(i, j int) bool {
+		a := apis[i].GatewayBasePath + " " + apis[i].GatewayRelPath + " " + apis[i].Action.BackendMethod
+		b := apis[j].GatewayBasePath + " " + apis[j].GatewayRelPath + " " + apis[j].Action.BackendMethod
+		return a < b
+	})
+	return apis
+}
+
+// serverlessParameters are the values of inputs, or their default values
+func serverlessParameters(inputs map[string]Parameter) map[string]interface{} {
+	if len(inputs) == 0 {
+		return nil
+	}
+	parameters := make(map[string]interface{}, len(inputs))
+	for name, input := range inputs {
+		parameters[name] = input.Value
+		if input.Value == nil {
+			parameters[name] = input.Default
+		}
+	}
+	return parameters
+}
+
+// serverlessAnnotations are the annotations of an action, along with the web
+// annotations of its web key
+func serverlessAnnotations(annotations map[string]interface{}, web string, webexport string) map[string]interface{} {
+	if len(web) == 0 {
+		web = webexport
+	}
+	result := make(map[string]interface{}, len(annotations)+2)
+	for key, value := range annotations {
+		result[key] = value
+	}
+	switch strings.ToLower(web) {
+	case "true", "yes":
+		result[utils.WEB_EXPORT_ANNOT] = true
+	case "raw":
+		result[utils.WEB_EXPORT_ANNOT] = true
+		result[utils.RAW_HTTP_ANNOT] = true
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// ServerlessToManifest converts a serverless.yml of the serverless-openwhisk
+// plugin to the content of a manifest, along with the warnings about what has
+// no equivalent in the manifest and is left out. The handlers of functions
+// are resolved to the files of dir, the directory of the serverless.yml.
+func ServerlessToManifest(serverless *ServerlessYAML, dir string) (yaml.MapSlice, []string) {
+	warnings := make([]string, 0)
+	service := serverless.Service
+	packages := make(map[string]map[string]interface{})
+	getPackage := func(name string) map[string]interface{} {
+		if _, ok := packages[name]; !ok {
+			packages[name] = make(map[string]interface{})
+		}
+		return packages[name]
+	}
+	getSection := func(packageName string, section string) map[string]interface{} {
+		pkg := getPackage(packageName)
+		if _, ok := pkg[section]; !ok {
+			pkg[section] = make(map[string]interface{})
+		}
+		return pkg[section].(map[string]interface{})
+	}
+
+	// the package and action of every function, functions without package
+	// belong to the package named after the service
+	actions := make(map[string][2]string)
+	keys := make([]string, 0, len(serverless.Functions))
+	for key, function := range serverless.Functions {
+		name := function.Name
+		if len(name) == 0 {
+			name = key
+		}
+		parts := strings.SplitN(strings.Trim(name, "/"), "/", 2)
+		if len(parts) == 1 {
+			parts = []string{service, parts[0]}
+		}
+		actions[key] = [2]string{parts[0], parts[1]}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	// a component of a sequence is either a function or a qualified action
+	component := func(packageName string, name string) string {
+		if action, ok := actions[name]; ok {
+			if action[0] == packageName {
+				return action[1]
+			}
+			return action[0] + "/" + action[1]
+		}
+		return name
+	}
+	basepath := service
+	if serverless.Resources.Apigw != nil && len(serverless.Resources.Apigw.Basepath) > 0 {
+		basepath = strings.Trim(serverless.Resources.Apigw.Basepath, "/")
+	}
+
+	for _, key := range keys {
+		function := serverless.Functions[key]
+		packageName, actionName := actions[key][0], actions[key][1]
+		entity := make(map[string]interface{})
+		if len(function.Parameters) > 0 {
+			entity["inputs"] = function.Parameters
+		}
+		annotations := make(map[string]interface{})
+		for name, value := range function.Annotations {
+			annotations[name] = value
+		}
+		web := ""
+		if fmt.Sprint(annotations[utils.WEB_EXPORT_ANNOT]) == "true" {
+			web = "true"
+			if fmt.Sprint(annotations[utils.RAW_HTTP_ANNOT]) == "true" {
+				web = "raw"
+			}
+			delete(annotations, utils.WEB_EXPORT_ANNOT)
+			delete(annotations, utils.RAW_HTTP_ANNOT)
+		}
+
+		for i, event := range function.Events {
+			for eventType, value := range event {
+				switch eventType {
+				case SERVERLESS_EVENT_HTTP:
+					method, relPath, ok := serverlessHttpEvent(value)
+					if !ok {
+						warnings = append(warnings, serverlessWarning(eventType, key))
+						continue
+					}
+					apis := getSection(packageName, "apis")
+					if _, ok := apis[service]; !ok {
+						apis[service] = make(map[string]interface{})
+					}
+					basepaths := apis[service].(map[string]interface{})
+					if _, ok := basepaths[basepath]; !ok {
+						basepaths[basepath] = make(map[string]interface{})
+					}
+					paths := basepaths[basepath].(map[string]interface{})
+					if _, ok := paths[relPath]; !ok {
+						paths[relPath] = make(map[string]interface{})
+					}
+					paths[relPath].(map[string]interface{})[actionName] = method
+					// actions of APIs are web actions
+					if len(web) == 0 {
+						web = "true"
+					}
+				case SERVERLESS_EVENT_TRIGGER, SERVERLESS_EVENT_SCHEDULE:
+					trigger, rule := "", ""
+					if eventType == SERVERLESS_EVENT_SCHEDULE {
+						trigger = fmt.Sprintf("%s-schedule-%d", key, i+1)
+						getSection(packageName, YAML_KEY_TRIGGERS)[trigger] = map[string]interface{}{
+							"feed":   SERVERLESS_ALARM_FEED,
+							"inputs": map[string]interface{}{"cron": serverlessCron(fmt.Sprint(value))},
+						}
+					} else if config, ok := value.(map[interface{}]interface{}); ok {
+						trigger, rule = fmt.Sprint(config["name"]), fmt.Sprint(config["rule"])
+						if config["rule"] == nil {
+							rule = ""
+						}
+					} else {
+						trigger = fmt.Sprint(value)
+					}
+					if len(rule) == 0 {
+						rule = key + "-" + trigger + "-rule"
+					}
+					getSection(packageName, YAML_KEY_RULES)[rule] = map[string]interface{}{
+						"trigger": trigger,
+						"action":  actionName,
+					}
+				default:
+					warnings = append(warnings, serverlessWarning(eventType, key))
+				}
+			}
+		}
+
+		if web == "true" {
+			entity["web"] = true
+		} else if len(web) > 0 {
+			entity["web"] = web
+		}
+		if len(annotations) > 0 {
+			entity["annotations"] = annotations
+		}
+		if len(function.Sequence) > 0 {
+			components := make([]string, 0, len(function.Sequence))
+			for _, name := range function.Sequence {
+				components = append(components, component(packageName, name))
+			}
+			entity[YAML_KEY_ACTIONS] = components
+			getSection(packageName, YAML_KEY_SEQUENCES)[actionName] = entity
+			continue
+		}
+
+		runtime := function.Runtime
+		if len(runtime) == 0 {
+			runtime = serverless.Provider.Runtime
+		}
+		handler := function.Handler
+		main := DEFAULT_MAIN_FUNCTION
+		if dot := strings.LastIndex(handler, "."); dot > strings.LastIndex(handler, "/") {
+			handler, main = handler[:dot], handler[dot+1:]
+		}
+		entity["function"] = serverlessHandlerFile(dir, handler, runtime)
+		if main != DEFAULT_MAIN_FUNCTION {
+			entity["main"] = main
+		}
+		if len(function.Runtime) > 0 {
+			entity["runtime"] = function.Runtime
+		} else if len(serverless.Provider.Runtime) > 0 {
+			entity["runtime"] = serverless.Provider.Runtime
+		}
+		limits := make(map[string]interface{})
+		if function.Memory > 0 {
+			limits[LIMIT_VALUE_MEMORY_SIZE] = function.Memory
+		}
+		if function.Timeout > 0 {
+			limits[LIMIT_VALUE_TIMEOUT] = function.Timeout * 1000
+		}
+		if len(limits) > 0 {
+			entity["limits"] = limits
+		}
+		getSection(packageName, YAML_KEY_ACTIONS)[actionName] = entity
+	}
+
+	// triggers and bindings belong to the package of the service
+	for name, trigger := range serverless.Resources.Triggers {
+		entity := make(map[string]interface{})
+		inputs := make(map[string]interface{})
+		for key, value := range trigger.Parameters {
+			inputs[key] = value
+		}
+		for key, value := range trigger.FeedParameters {
+			inputs[key] = value
+		}
+		if len(trigger.Feed) > 0 {
+			entity["feed"] = trigger.Feed
+		}
+		if len(inputs) > 0 {
+			entity["inputs"] = inputs
+		}
+		if len(trigger.Annotations) > 0 {
+			entity["annotations"] = trigger.Annotations
+		}
+		getSection(service, YAML_KEY_TRIGGERS)[name] = entity
+	}
+	for name, pkg := range serverless.Resources.Packages {
+		entity := getPackage(name)
+		if len(pkg.Binding) > 0 {
+			delete(packages, name)
+			entity = map[string]interface{}{YAML_KEY_PACKAGE: pkg.Binding}
+			getSection(service, "bindings")[name] = entity
+		}
+		if len(pkg.Parameters) > 0 {
+			entity["inputs"] = pkg.Parameters
+		}
+		if len(pkg.Annotations) > 0 {
+			entity["annotations"] = pkg.Annotations
+		}
+	}
+
+	packageMap := make(map[string]interface{}, len(packages))
+	for name, pkg := range packages {
+		packageMap[name] = pkg
+	}
+	project := yaml.MapSlice{{Key: "name", Value: service}}
+	if len(serverless.Provider.Namespace) > 0 {
+		project = append(project, yaml.MapItem{Key: "namespace", Value: serverless.Provider.Namespace})
+	}
+	project = append(project, yaml.MapItem{Key: YAML_KEY_PACKAGES, Value: packageMap})
+	return yaml.MapSlice{{Key: YAML_KEY_PROJECT, Value: project}}, warnings
+}
+
+// serverlessHttpEvent returns the method and path of an http event, given as
+// "GET /path" or as its method and path
+func serverlessHttpEvent(value interface{}) (string, string, bool) {
+	if config, ok := value.(map[interface{}]interface{}); ok {
+		method, relPath := fmt.Sprint(config["method"]), fmt.Sprint(config["path"])
+		return strings.ToLower(method), strings.Trim(relPath, "/"), config["method"] != nil && config["path"] != nil
+	}
+	fields := strings.Fields(fmt.Sprint(value))
+	if len(fields) != 2 {
+		return "", "", false
+	}
+	return strings.ToLower(fields[0]), strings.Trim(fields[1], "/"), true
+}
+
+// serverlessCron returns the cron expression of a schedule event, given as
+// "cron(* * * * *)" or as the expression
+func serverlessCron(schedule string) string {
+	schedule = strings.TrimSpace(schedule)
+	if strings.HasPrefix(schedule, "cron(") && strings.HasSuffix(schedule, ")") {
+		return strings.TrimSuffix(strings.TrimPrefix(schedule, "cron("), ")")
+	}
+	return schedule
+}
+
+// the extensions of the source files of handlers, by the language of runtimes
+var serverlessHandlerExtensions = []struct {
+	language  string
+	extension string
+}{
+	{"nodejs", utils.NODEJS_FILE_EXTENSION},
+	{"python", utils.PYTHON_FILE_EXTENSION},
+	{"php", utils.PHP_FILE_EXTENSION},
+	{"swift", utils.SWIFT_FILE_EXTENSION},
+	{"java", utils.JAR_FILE_EXTENSION},
+}
+
+// serverlessHandlerFile returns the file of a handler, i.e. the file without
+// extension of the handler, with the extension of the file found in dir, or
+// the extension of the runtime
+func serverlessHandlerFile(dir string, handler string, runtime string) string {
+	for _, candidate := range serverlessHandlerExtensions {
+		if (len(runtime) == 0 || strings.HasPrefix(runtime, candidate.language)) &&
+			utils.FileExists(filepath.Join(dir, handler+"."+candidate.extension)) {
+			return handler + "." + candidate.extension
+		}
+	}
+	for _, candidate := range serverlessHandlerExtensions {
+		if strings.HasPrefix(runtime, candidate.language) {
+			return handler + "." + candidate.extension
+		}
+	}
+	return handler + "." + utils.NODEJS_FILE_EXTENSION
+}
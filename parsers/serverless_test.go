@@ -0,0 +1,109 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	TEST_SERVERLESS_MANIFEST = "../tests/dat/manifest_serverless.yaml"
+	TEST_SERVERLESS_FILE     = "../tests/dat/serverless.yml"
+)
+
+func TestManifestToServerless(t *testing.T) {
+	manifest, err := NewYAMLParser().ParseManifest(TEST_SERVERLESS_MANIFEST)
+	assert.Nil(t, err)
+
+	serverless, warnings := ManifestToServerless(manifest)
+	assert.Equal(t, "helloworld", serverless.Service)
+	assert.Equal(t, SERVERLESS_PROVIDER_OPENWHISK, serverless.Provider.Name)
+
+	greeting := serverless.Functions["greeting"]
+	assert.Equal(t, "hello/greeting", greeting.Name)
+	assert.Equal(t, "actions/hello.main", greeting.Handler)
+	assert.Equal(t, "nodejs:10", greeting.Runtime)
+	assert.Equal(t, 256, greeting.Memory)
+	assert.Equal(t, 2, greeting.Timeout, "timeouts are rounded up to seconds")
+	assert.Equal(t, "Bernie", greeting.Parameters["name"])
+	assert.Equal(t, true, greeting.Annotations["web-export"])
+	assert.Equal(t, []map[string]interface{}{
+		{SERVERLESS_EVENT_TRIGGER: map[string]interface{}{"name": "everyMinute", "rule": "greetEveryMinute"}},
+		{SERVERLESS_EVENT_HTTP: "GET /greeting"},
+	}, greeting.Events)
+
+	assert.Equal(t, "actions/dump_params.goodbye", serverless.Functions["goodbye"].Handler)
+	assert.Equal(t, []string{"greeting", "goodbye"}, serverless.Functions["welcome"].Sequence)
+	assert.Equal(t, "/hello", serverless.Resources.Apigw.Basepath)
+	assert.Equal(t, "/whisk.system/alarms/alarm", serverless.Resources.Triggers["everyMinute"].Feed)
+	assert.Equal(t, "* * * * *", serverless.Resources.Triggers["everyMinute"].FeedParameters["cron"])
+	assert.Equal(t, "eu-de", serverless.Resources.Packages["hello"].Parameters["region"])
+
+	// the post_deploy hook of goodbye has no equivalent
+	assert.Equal(t, []string{serverlessWarning("hooks", "hello/goodbye")}, warnings)
+}
+
+func TestServerlessToManifest(t *testing.T) {
+	serverless, err := ReadServerless(TEST_SERVERLESS_FILE)
+	assert.Nil(t, err)
+
+	content, warnings := ServerlessToManifest(serverless, "../tests/dat")
+	assert.Empty(t, warnings)
+	text, err := yaml.Marshal(content)
+	assert.Nil(t, err)
+	manifest := YAML{}
+	assert.Nil(t, yaml.Unmarshal(text, &manifest))
+
+	assert.Equal(t, "helloworld", manifest.Project.Name)
+	hello := manifest.Project.Packages["hello"]
+	greeting := hello.Actions["greeting"]
+	assert.Equal(t, "actions/hello.js", greeting.Function)
+	assert.Equal(t, "nodejs:10", greeting.Runtime)
+	assert.Equal(t, "true", greeting.Web, "actions of http events are web actions")
+	assert.Equal(t, 256, *greeting.Limits.Memory)
+	assert.Equal(t, 2000, *greeting.Limits.Timeout)
+	assert.Equal(t, "Bernie", greeting.Inputs["name"].Value)
+	assert.Equal(t, SequenceActions{"greeting", "helloworld/goodbye"}, hello.Sequences["welcome"].Actions)
+	assert.Equal(t, map[string]map[string]map[string]string{"hello": {"greeting": {"greeting": "get"}}},
+		hello.Apis.Routes["helloworld"])
+	assert.Equal(t, "everyMinute", hello.Rules["greetEveryMinute"].Trigger)
+
+	// functions without package belong to the package of the service
+	service := manifest.Project.Packages["helloworld"]
+	goodbye := service.Actions["goodbye"]
+	assert.Equal(t, "actions/dump_params.js", goodbye.Function)
+	assert.Equal(t, "goodbye", goodbye.Main)
+	assert.Equal(t, "/whisk.system/alarms/alarm", service.Triggers["goodbye-schedule-1"].Feed)
+	assert.Equal(t, "0 * * * *", service.Triggers["goodbye-schedule-1"].Inputs["cron"].Value)
+	assert.Equal(t, "goodbye", service.Rules["goodbye-goodbye-schedule-1-rule"].Action)
+	assert.Equal(t, "/whisk.system/alarms/alarm", service.Triggers["everyMinute"].Feed)
+	assert.Equal(t, "/whisk.system/cloudant", service.Bindings["cloudant"].Package)
+}
+
+func TestReadServerless_Provider(t *testing.T) {
+	serverless := &ServerlessYAML{}
+	assert.Nil(t, yaml.Unmarshal([]byte("service: hello\nprovider:\n  name: aws\n"), serverless))
+	assert.Equal(t, "aws", serverless.Provider.Name)
+	_, err := ReadServerless("../tests/dat/manifest_serverless.yaml")
+	assert.NotNil(t, err, "a manifest is not a serverless.yml")
+}
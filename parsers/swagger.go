@@ -0,0 +1,261 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"gopkg.in/yaml.v2"
+)
+
+// keys of OpenAPI (Swagger) documents
+const (
+	SWAGGER_KEY_INFO         = "info"
+	SWAGGER_KEY_TITLE        = "title"
+	SWAGGER_KEY_BASE_PATH    = "basePath"
+	SWAGGER_KEY_PATHS        = "paths"
+	SWAGGER_KEY_OPERATION_ID = "operationId"
+	// extension of an operation which names the action it is backed by
+	SWAGGER_KEY_OPENWHISK = "x-openwhisk"
+	SWAGGER_KEY_NAMESPACE = "namespace"
+	SWAGGER_KEY_PACKAGE   = "package"
+	SWAGGER_KEY_ACTION    = "action"
+	SWAGGER_KEY_URL       = "url"
+
+	// namespace of the actions of the deployment
+	SWAGGER_DEFAULT_NAMESPACE = "_"
+)
+
+// HTTP methods of the operations of a path
+var swaggerMethods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+// SwaggerOperation is an operation of an OpenAPI document and the action it
+// is backed by, the document is updated when its x-openwhisk extension changes
+type SwaggerOperation struct {
+	Method    string
+	Path      string
+	Namespace string
+	Package   string
+	Action    string
+	URL       string
+	extension map[string]interface{}
+}
+
+// SetBackend sets the namespace and URL of the action the operation is backed by
+func (operation *SwaggerOperation) SetBackend(namespace string, url string) {
+	operation.Namespace, operation.URL = namespace, url
+	operation.extension[SWAGGER_KEY_NAMESPACE] = namespace
+	operation.extension[SWAGGER_KEY_URL] = url
+}
+
+// SetPackage sets the package of the action the operation is backed by
+func (operation *SwaggerOperation) SetPackage(packageName string) {
+	operation.Package = packageName
+	operation.extension[SWAGGER_KEY_PACKAGE] = packageName
+}
+
+// SwaggerDocument is an OpenAPI (Swagger) document, JSON or YAML, whose
+// operations are backed by actions
+type SwaggerDocument struct {
+	Title      string
+	BasePath   string
+	Operations []*SwaggerOperation
+	content    map[string]interface{}
+}
+
+// JSON returns the document, including the changes to its operations
+func (document *SwaggerDocument) JSON() (string, error) {
+	content, err := json.Marshal(document.content)
+	return string(content), err
+}
+
+// ReadSwaggerDocument reads an OpenAPI document. The action of an operation
+// is the one of its x-openwhisk extension, or the action named after its
+// operationId in the given package.
+func ReadSwaggerDocument(swaggerPath string, packageName string) (*SwaggerDocument, error) {
+	content, err := utils.Read(swaggerPath)
+	if err != nil {
+		return nil, wskderrors.NewFileReadError(swaggerPath, err.Error())
+	}
+	return ParseSwaggerDocument(content, swaggerPath, packageName)
+}
+
+// ParseSwaggerDocument parses an OpenAPI document, see ReadSwaggerDocument()
+func ParseSwaggerDocument(content []byte, swaggerPath string, packageName string) (*SwaggerDocument, error) {
+	content, err := NormalizeContent(content, swaggerPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is YAML, so that both are read the same way
+	var raw interface{}
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, wskderrors.NewYAMLParserErr(swaggerPath, err)
+	}
+	root, ok := jsonValue(raw).(map[string]interface{})
+	if !ok {
+		return nil, swaggerError(swaggerPath, wski18n.T(wski18n.ID_ERR_SWAGGER_NOT_OBJECT))
+	}
+
+	document := &SwaggerDocument{content: root}
+	if info, ok := root[SWAGGER_KEY_INFO].(map[string]interface{}); ok {
+		document.Title, _ = info[SWAGGER_KEY_TITLE].(string)
+	}
+	document.BasePath, _ = root[SWAGGER_KEY_BASE_PATH].(string)
+
+	paths, _ := root[SWAGGER_KEY_PATHS].(map[string]interface{})
+	pathNames := make([]string, 0, len(paths))
+	for pathName := range paths {
+		pathNames = append(pathNames, pathName)
+	}
+	sort.Strings(pathNames)
+
+	for _, pathName := range pathNames {
+		methods, _ := paths[pathName].(map[string]interface{})
+		for _, method := range swaggerMethods {
+			operation, ok := methods[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			swaggerOperation, err := newSwaggerOperation(method, pathName, operation, packageName)
+			if err != nil {
+				return nil, swaggerError(swaggerPath, err.Error())
+			}
+			document.Operations = append(document.Operations, swaggerOperation)
+		}
+	}
+	if len(document.Operations) == 0 {
+		return nil, swaggerError(swaggerPath, wski18n.T(wski18n.ID_ERR_SWAGGER_NO_OPERATION))
+	}
+	return document, nil
+}
+
+func newSwaggerOperation(method string, pathName string, operation map[string]interface{}, packageName string) (*SwaggerOperation, error) {
+	extension, ok := operation[SWAGGER_KEY_OPENWHISK].(map[string]interface{})
+	if !ok {
+		operationId, _ := operation[SWAGGER_KEY_OPERATION_ID].(string)
+		if len(operationId) == 0 {
+			return nil, errors.New(wski18n.T(wski18n.ID_ERR_SWAGGER_OPERATION_NOT_BOUND_X_method_X_path_X,
+				map[string]interface{}{wski18n.KEY_METHOD: strings.ToUpper(method), wski18n.KEY_PATH: pathName}))
+		}
+		extension = map[string]interface{}{
+			SWAGGER_KEY_NAMESPACE: SWAGGER_DEFAULT_NAMESPACE,
+			SWAGGER_KEY_PACKAGE:   packageName,
+			SWAGGER_KEY_ACTION:    operationId,
+		}
+		operation[SWAGGER_KEY_OPENWHISK] = extension
+	}
+
+	swaggerOperation := &SwaggerOperation{Method: method, Path: pathName, extension: extension}
+	swaggerOperation.Namespace, _ = extension[SWAGGER_KEY_NAMESPACE].(string)
+	swaggerOperation.Package, _ = extension[SWAGGER_KEY_PACKAGE].(string)
+	swaggerOperation.Action, _ = extension[SWAGGER_KEY_ACTION].(string)
+	swaggerOperation.URL, _ = extension[SWAGGER_KEY_URL].(string)
+	if len(swaggerOperation.Action) == 0 {
+		return nil, errors.New(wski18n.T(wski18n.ID_ERR_SWAGGER_OPERATION_NO_ACTION_X_method_X_path_X,
+			map[string]interface{}{wski18n.KEY_METHOD: strings.ToUpper(method), wski18n.KEY_PATH: pathName}))
+	}
+	if len(swaggerOperation.Namespace) == 0 {
+		swaggerOperation.Namespace = SWAGGER_DEFAULT_NAMESPACE
+		extension[SWAGGER_KEY_NAMESPACE] = SWAGGER_DEFAULT_NAMESPACE
+	}
+	return swaggerOperation, nil
+}
+
+func swaggerError(swaggerPath string, message string) error {
+	return wskderrors.NewYAMLFileFormatError(swaggerPath,
+		wski18n.T(wski18n.ID_ERR_SWAGGER_INVALID_X_path_X_err_X,
+			map[string]interface{}{wski18n.KEY_PATH: swaggerPath, wski18n.KEY_ERR: message}))
+}
+
+// jsonValue converts the maps of a YAML value to maps with string keys, so
+// that it can be marshalled to JSON, scalars are left unchanged
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			m[fmt.Sprintf("%v", key)] = jsonValue(item)
+		}
+		return m
+	case []interface{}:
+		s := make([]interface{}, 0, len(v))
+		for _, item := range v {
+			s = append(s, jsonValue(item))
+		}
+		return s
+	}
+	return value
+}
+
+// ComposeSwaggerApi composes the API of the OpenAPI document of a package.
+// The actions of its operations must be defined in the manifest, unless they
+// are in another namespace.
+func (dm *YAMLParser) ComposeSwaggerApi(filePath string, packageName string, pkg Package, manifest *YAML) (*whisk.ApiCreateRequest, error) {
+	swaggerPath := pkg.Apis.Swagger
+	if !strings.HasPrefix(swaggerPath, "http") && !filepath.IsAbs(swaggerPath) {
+		swaggerPath = filepath.Join(filepath.Dir(filePath), swaggerPath)
+	}
+	document, err := ReadSwaggerDocument(swaggerPath, packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := manifest.GetPackages()
+	for _, operation := range document.Operations {
+		if operation.Namespace != SWAGGER_DEFAULT_NAMESPACE {
+			continue
+		}
+		if len(operation.Package) == 0 {
+			operation.Package = packageName
+			operation.extension[SWAGGER_KEY_PACKAGE] = packageName
+		}
+		p, ok := packages[operation.Package]
+		_, isAction := p.Actions[operation.Action]
+		_, isSequence := p.Sequences[operation.Action]
+		if !ok || (!isAction && !isSequence) {
+			return nil, wskderrors.NewYAMLFileFormatError(swaggerPath,
+				wski18n.T(wski18n.ID_ERR_SWAGGER_ACTION_NOT_FOUND_X_action_X_method_X_path_X,
+					map[string]interface{}{
+						wski18n.KEY_ACTION: operation.Package + "/" + operation.Action,
+						wski18n.KEY_METHOD: strings.ToUpper(operation.Method),
+						wski18n.KEY_PATH:   operation.Path}))
+		}
+	}
+
+	api := &whisk.Api{
+		ApiName:         document.Title,
+		GatewayBasePath: document.BasePath,
+	}
+	if len(api.ApiName) == 0 {
+		api.ApiName = packageName
+	}
+	if api.Swagger, err = document.JSON(); err != nil {
+		return nil, swaggerError(swaggerPath, err.Error())
+	}
+	return &whisk.ApiCreateRequest{ApiDoc: api}, nil
+}
@@ -0,0 +1,107 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+const manifest_validate_swagger = "../tests/dat/manifest_validate_swagger.yaml"
+
+func TestComposeApiRecords_Swagger(t *testing.T) {
+	p := NewYAMLParser()
+	m, err := p.ParseManifest(manifest_validate_swagger)
+	assert.Nil(t, err)
+	assert.Equal(t, "swagger_book_club.json", m.Packages["book-club"].Apis.Swagger)
+
+	apis, err := p.ComposeApiRecordsFromAllPackages(m, manifest_validate_swagger)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(apis))
+	api := apis[0].ApiDoc
+	assert.Equal(t, "book-club", api.ApiName)
+	assert.Equal(t, "/club", api.GatewayBasePath)
+
+	var swagger map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(api.Swagger), &swagger))
+	books := swagger[SWAGGER_KEY_PATHS].(map[string]interface{})["/books"].(map[string]interface{})
+	get := books["get"].(map[string]interface{})[SWAGGER_KEY_OPENWHISK]
+	assert.Equal(t, map[string]interface{}{"namespace": "_", "package": "book-club", "action": "getBooks"}, get,
+		"the action of an operation without x-openwhisk is named after its operationId")
+	post := books["post"].(map[string]interface{})[SWAGGER_KEY_OPENWHISK]
+	assert.Equal(t, "postBooks", post.(map[string]interface{})[SWAGGER_KEY_ACTION])
+
+	// the actions must be defined in the manifest
+	delete(m.Packages["book-club"].Actions, "postBooks")
+	_, err = p.ComposeApiRecordsFromAllPackages(m, manifest_validate_swagger)
+	assert.NotNil(t, err)
+	_, ok := err.(*wskderrors.YAMLFileFormatError)
+	assert.True(t, ok)
+}
+
+func TestParseSwaggerDocument(t *testing.T) {
+	document, err := ParseSwaggerDocument([]byte(`swagger: "2.0"
+info:
+  title: hello
+basePath: /hello
+paths:
+  /world:
+    get:
+      x-openwhisk:
+        namespace: guest
+        package: hello
+        action: world
+        url: https://openwhisk.example.com/api/v1/web/guest/hello/world.http
+      responses:
+        200:
+          description: hello world
+`), "hello.yaml", "hello")
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", document.Title)
+	assert.Equal(t, 1, len(document.Operations))
+	operation := document.Operations[0]
+	assert.Equal(t, "guest", operation.Namespace)
+	assert.Equal(t, "world", operation.Action)
+
+	operation.SetBackend("other", "https://other.example.com")
+	content, err := document.JSON()
+	assert.Nil(t, err)
+	assert.Contains(t, content, `"namespace":"other"`)
+	assert.Contains(t, content, `"200":{"description":"hello world"}`, "YAML documents are converted to JSON")
+
+	for _, invalid := range []string{"[]", "paths: {}", "paths:\n  /world:\n    get:\n      responses: {}\n"} {
+		_, err := ParseSwaggerDocument([]byte(invalid), "invalid.yaml", "hello")
+		assert.NotNil(t, err, invalid)
+	}
+}
+
+func TestPackageApis_UnmarshalYAML(t *testing.T) {
+	var pkg Package
+	assert.Nil(t, yaml.Unmarshal([]byte("apis:\n  book-club:\n    club:\n      books:\n        getBooks: get\n"), &pkg))
+	assert.Equal(t, "get", pkg.Apis.Routes["book-club"]["club"]["books"]["getBooks"])
+	assert.Empty(t, pkg.Apis.Swagger)
+
+	assert.NotNil(t, yaml.Unmarshal([]byte("apis:\n  swagger: api.json\n  book-club:\n    club: {}\n"), &pkg),
+		"routes and a swagger document cannot be combined")
+}
@@ -0,0 +1,166 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"gopkg.in/yaml.v2"
+)
+
+// Limits of the manifest and deployment files. The files of dependencies are
+// fetched from repositories which are not trusted, and the YAML decoder
+// neither bounds the expansion of aliases nor the nesting of nodes.
+const (
+	YAML_MAX_SIZE            = 10 * 1024 * 1024 // bytes
+	YAML_MAX_DEPTH           = 100              // nested maps, lists and indented lines
+	YAML_MAX_ALIAS_EXPANSION = 100000           // nodes copied by aliases, e.g. "*defaults"
+)
+
+const (
+	YAML_LIMIT_SIZE            = "size"
+	YAML_LIMIT_DEPTH           = "depth"
+	YAML_LIMIT_ALIAS_EXPANSION = "alias expansion"
+)
+
+// anchors "&name" and aliases "*name" at the start of a node
+var yamlAnchorRegex = regexp.MustCompile(`(?:^|[\s\[\{,])([&*])([^\s,\[\]\{\}]+)`)
+
+// CheckYAMLLimits fails if a YAML file is larger, deeper or expands more
+// aliases than the limits above, before it is decoded. Files which are not
+// valid YAML are left to the parser.
+func CheckYAMLLimits(content []byte, filePath string) error {
+	checks := []struct {
+		limit string
+		value int
+		max   int
+	}{
+		{YAML_LIMIT_SIZE, len(content), YAML_MAX_SIZE},
+		{YAML_LIMIT_DEPTH, yamlDepth(content), YAML_MAX_DEPTH},
+		{YAML_LIMIT_ALIAS_EXPANSION, yamlAliasExpansion(content), YAML_MAX_ALIAS_EXPANSION},
+	}
+	for _, check := range checks {
+		if check.value > check.max {
+			return wskderrors.NewYAMLParserErr(filePath,
+				wski18n.T(wski18n.ID_ERR_YAML_LIMIT_EXCEEDED_X_limit_X_value_X_max_X,
+					map[string]interface{}{wski18n.KEY_LIMIT: check.limit, wski18n.KEY_VALUE: check.value,
+						wski18n.KEY_MAX: check.max}))
+		}
+	}
+	return nil
+}
+
+// yamlDepth returns the deepest nesting of the file, counting the indented
+// lines, the items of compact lists ("- - item") and the flow collections
+// ("[{...}]") outside of quoted strings
+func yamlDepth(content []byte) int {
+	maxDepth := 0
+	indents := make([]int, 0)
+	flow := 0
+	for _, line := range strings.Split(string(content), "\n") {
+		trimmed := strings.TrimLeft(line, " \t")
+		if len(trimmed) == 0 || trimmed[0] == '#' {
+			continue
+		}
+		indent := len(line) - len(trimmed)
+		for len(indents) > 0 && indents[len(indents)-1] >= indent {
+			indents = indents[:len(indents)-1]
+		}
+		indents = append(indents, indent)
+
+		depth := len(indents)
+		for strings.HasPrefix(trimmed, "- ") {
+			depth++
+			trimmed = strings.TrimLeft(trimmed[2:], " ")
+		}
+		if depth+flow > maxDepth {
+			maxDepth = depth + flow
+		}
+
+		var quote rune
+		for _, c := range trimmed {
+			switch {
+			case quote != 0:
+				if c == quote {
+					quote = 0
+				}
+			case c == '"' || c == '\'':
+				quote = c
+			case c == '[' || c == '{':
+				flow++
+				if depth+flow > maxDepth {
+					maxDepth = depth + flow
+				}
+			case c == ']' || c == '}':
+				if flow > 0 {
+					flow--
+				}
+			}
+		}
+	}
+	return maxDepth
+}
+
+// yamlAliasExpansion estimates the nodes the aliases of the file expand to.
+// An alias is as large as the node of its anchor, plus the aliases in that
+// node, which are the ones up to the next anchor, e.g. the aliases of
+// "b: &b [*a, *a]" double the size of "a".
+func yamlAliasExpansion(content []byte) int {
+	weights := make(map[string]int)
+	current := ""
+	total := 0
+	for _, match := range yamlAnchorRegex.FindAllSubmatch(content, -1) {
+		name := string(match[2])
+		if match[1][0] == '&' {
+			weights[name] = 1
+			current = name
+			continue
+		}
+		// not an alias of the file, e.g. "*" in the code of an action
+		weight, ok := weights[name]
+		if !ok {
+			continue
+		}
+		total += weight
+		if len(current) > 0 && current != name {
+			weights[current] += weight
+		}
+		if total > YAML_MAX_ALIAS_EXPANSION {
+			break
+		}
+	}
+	return total
+}
+
+// unmarshalYAML decodes a manifest or deployment file, as yaml.UnmarshalStrict
+// does, and returns the panics of the decoder or of the UnmarshalYAML
+// methods of the schema as errors
+func unmarshalYAML(content []byte, out interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New(wski18n.T(wski18n.ID_ERR_YAML_INVALID_X_err_X,
+				map[string]interface{}{wski18n.KEY_ERR: fmt.Sprintf("%v", r)}))
+		}
+	}()
+	return yaml.UnmarshalStrict(content, out)
+}
@@ -0,0 +1,167 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package parsers
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_BILLION_LAUGHS = `packages:
+  a: &a ["lol","lol","lol","lol","lol","lol","lol","lol","lol"]
+  b: &b [*a,*a,*a,*a,*a,*a,*a,*a,*a]
+  c: &c [*b,*b,*b,*b,*b,*b,*b,*b,*b]
+  d: &d [*c,*c,*c,*c,*c,*c,*c,*c,*c]
+  e: &e [*d,*d,*d,*d,*d,*d,*d,*d,*d]
+  f: &f [*e,*e,*e,*e,*e,*e,*e,*e,*e]
+  g: &g [*f,*f,*f,*f,*f,*f,*f,*f,*f]
+  h: &h [*g,*g,*g,*g,*g,*g,*g,*g,*g]
+  i: &i [*h,*h,*h,*h,*h,*h,*h,*h,*h]
+`
+
+func TestCheckYAMLLimits(t *testing.T) {
+	files, err := filepath.Glob("../tests/dat/*.yaml")
+	assert.Nil(t, err)
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		assert.Nil(t, err)
+		assert.Nil(t, CheckYAMLLimits(content, file), file)
+	}
+
+	err = CheckYAMLLimits([]byte(TEST_BILLION_LAUGHS), "manifest.yaml")
+	assert.IsType(t, &wskderrors.YAMLParserError{}, err)
+	assert.Contains(t, err.Error(), YAML_LIMIT_ALIAS_EXPANSION)
+
+	flow := "packages: " + strings.Repeat("[", YAML_MAX_DEPTH) + strings.Repeat("]", YAML_MAX_DEPTH)
+	err = CheckYAMLLimits([]byte(flow), "manifest.yaml")
+	assert.IsType(t, &wskderrors.YAMLParserError{}, err)
+	assert.Contains(t, err.Error(), YAML_LIMIT_DEPTH)
+
+	block := ""
+	for i := 0; i <= YAML_MAX_DEPTH; i++ {
+		block += strings.Repeat(" ", i) + "key:\n"
+	}
+	assert.IsType(t, &wskderrors.YAMLParserError{}, CheckYAMLLimits([]byte(block), "manifest.yaml"))
+
+	quoted := "packages: \"" + strings.Repeat("[", YAML_MAX_DEPTH) + "\""
+	assert.Nil(t, CheckYAMLLimits([]byte(quoted), "manifest.yaml"), "brackets of strings are not nested")
+
+	large := make([]byte, YAML_MAX_SIZE+1)
+	assert.IsType(t, &wskderrors.YAMLParserError{}, CheckYAMLLimits(large, "manifest.yaml"))
+}
+
+func TestYamlAliasExpansion(t *testing.T) {
+	assert.Equal(t, 0, yamlAliasExpansion([]byte("code: |\n  return a *b\n")), "not an alias of the file")
+	assert.Equal(t, 2, yamlAliasExpansion([]byte("a: &a {x: 1}\nb: *a\nc: *a\n")))
+	assert.Equal(t, 3, yamlAliasExpansion([]byte("a: &a 1\nb: &b [*a]\nc: *b\n")))
+}
+
+type panickingSchema struct{}
+
+func (schema *panickingSchema) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	panic("invalid node")
+}
+
+func TestUnmarshalYAML_Panic(t *testing.T) {
+	var out struct {
+		Packages panickingSchema `yaml:"packages"`
+	}
+	err := unmarshalYAML([]byte("packages: hello\n"), &out)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "invalid node")
+}
+
+// inputs of the wrong type, e.g. a string instead of a map, are errors
+func TestUnmarshal_TypeConfusion(t *testing.T) {
+	manifests := []string{
+		"packages:\n  hello:\n    inputs: name\n",
+		"packages:\n  hello:\n    inputs: [name, place]\n",
+		"packages:\n  hello:\n    actions: hello.js\n",
+		"packages:\n  hello:\n    actions:\n      hello:\n        limits: big\n",
+		"packages: [hello]\n",
+	}
+	for _, manifest := range manifests {
+		var m YAML
+		assert.NotNil(t, NewYAMLParser().Unmarshal([]byte(manifest), &m), manifest)
+	}
+}
+
+func TestResolveParameter_UnknownType(t *testing.T) {
+	param := Parameter{Value: uint64(18446744073709551615)}
+	_, err := ResolveParameter("big", &param, "manifest.yaml", false)
+	assert.NotNil(t, err)
+}
+
+// any input, decoded or not, is either parsed or an error, never a panic
+func TestUnmarshal_Property(t *testing.T) {
+	unmarshal := func(content []byte) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = errors.New("panic")
+			}
+		}()
+		if CheckYAMLLimits(content, "manifest.yaml") != nil {
+			return nil
+		}
+		var manifest YAML
+		if NewYAMLParser().Unmarshal(content, &manifest) != nil {
+			return nil
+		}
+		for _, pkg := range manifest.Packages {
+			for name, param := range pkg.Inputs {
+				ResolveParameter(name, &param, "manifest.yaml", false)
+			}
+		}
+		return nil
+	}
+	property := func(prefix string, content []byte) bool {
+		return unmarshal(append([]byte("packages:\n  hello:\n    inputs:\n      "+prefix), content...)) == nil
+	}
+	assert.Nil(t, quick.Check(property, nil))
+}
+
+// any value of an input resolves to a value or an error, never a panic
+func TestResolveParameter_Property(t *testing.T) {
+	types := []string{"", STRING, INTEGER, FLOAT, BOOLEAN, JSON, ARRAY}
+	property := func(value string, number int64, big uint64, ratio float64, flag bool, multiline bool) bool {
+		paramType := types[int(big%uint64(len(types)))]
+		for _, input := range []interface{}{value, number, big, ratio, flag, nil,
+			[]interface{}{value, number}, map[interface{}]interface{}{number: value, ratio: flag}} {
+			param := Parameter{Value: input, Type: paramType, Default: input, multiline: multiline}
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						t.Errorf("panic for %#v: %v", param, r)
+					}
+				}()
+				ResolveParameter("name", &param, "manifest.yaml", false)
+			}()
+		}
+		return true
+	}
+	assert.Nil(t, quick.Check(property, nil))
+}
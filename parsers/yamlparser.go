@@ -18,8 +18,13 @@
 package parsers
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wskenv"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
 )
 
 // YAML schema key names
@@ -33,6 +38,24 @@ const(
 	YAML_KEY_FEED 		= "feed"
 	YAML_KEY_API 		= "api"
 	YAML_KEY_SEQUENCE 	= "sequence"
+	YAML_KEY_SWAGGER 	= "swagger"
+	YAML_KEY_DEPENDENCY 	= "dependency"
+	YAML_KEY_BINDING 	= "binding"
+	YAML_KEY_INHERIT_ANNOTATIONS	= "inherit-annotations"
+	YAML_KEY_EXPECTED_TARGET	= "expected-target"
+	YAML_KEY_PRE_DEPLOY	= "pre_deploy"
+	YAML_KEY_POST_DEPLOY	= "post_deploy"
+)
+
+// YAML schema section names
+const(
+	YAML_KEY_PACKAGES	= "packages"
+	YAML_KEY_ACTIONS	= "actions"
+	YAML_KEY_TRIGGERS	= "triggers"
+	YAML_KEY_RULES		= "rules"
+	YAML_KEY_SEQUENCES	= "sequences"
+	YAML_KEY_INPUTS		= "inputs"
+	YAML_KEY_PLUGINS	= "plugins"
 )
 
 // descriptive key names
@@ -42,11 +65,25 @@ const (
 	PACKAGE_VERSION = "package version"
 	PACKAGE_LICENSE = "package license"
 	TRIGGER_FEED	= "trigger feed"
+	TARGET_API_HOST	= "API host"
+	TARGET_NAMESPACE = "namespace"
+)
+
+// annotations written by wskdeploy
+const(
+	// version of the package in the manifest, with --immutable-versions
+	ANNOTATION_PACKAGE_VERSION	= "package-version"
 )
 
 // YAML schema key values
 const(
 	YAML_VALUE_BRANCH_MASTER	= "master"
+	// scopes of the inputs of the project, see Project.InputsScope
+	YAML_VALUE_INPUTS_SCOPE_PACKAGES	= "packages"
+	YAML_VALUE_INPUTS_SCOPE_ALL		= "all"
+	// states of a rule, see Rule.Status
+	YAML_VALUE_RULE_ACTIVE			= "active"
+	YAML_VALUE_RULE_INACTIVE		= "inactive"
 )
 
 // default values
@@ -83,20 +120,33 @@ var LIMITS_UNSUPPORTED = [](string){
 
 // structs that denote the sample manifest.yaml, wrapped yaml.v2
 func NewYAMLParser() *YAMLParser {
-	return &YAMLParser{}
+	return &YAMLParser{Flags: &utils.Flags}
 }
 
 type YAMLParser struct {
 	manifests []*YAML
 	lastID    uint32
+	// namespace the ${packages.<path>} references of the manifest are
+	// qualified with, see ResolveEntityReferences()
+	Namespace string
+	// YAML files merged over the manifest with --overlay, see ApplyManifestOverlays()
+	Overlays []string
+	// values set at paths of the manifest with --set, see ApplyManifestOverrides()
+	Overrides []string
+	// flags of the deployment, utils.Flags unless the parser is the one of
+	// a deployer, see deployers.ServiceDeployer
+	Flags *utils.WskdeployFlags
 }
 
 type Action struct {
 	//mapping to wsk.Action.Version
 	Version  string `yaml:"version"`           //used in manifest.yaml
 	Location string `yaml:"location"`          //deprecated, used in manifest.yaml
-	Function string `yaml:"function"`          //used in manifest.yaml
+	Function string `yaml:"function"`          //used in manifest.yaml, a path relative to the manifest or an http(s) URL
+	Sha256   string `yaml:"sha256,omitempty"`  //used in manifest.yaml, checksum of the code downloaded from the URL of the function
+	Build    string `yaml:"build,omitempty"`   //used in manifest.yaml, command run in the directory of the manifest before the function is read
 	Runtime  string `yaml:"runtime,omitempty"` //used in manifest.yaml
+	RuntimeVersion string `yaml:"runtime_version,omitempty"` //used in manifest.yaml, see utils.ResolveRuntimeKind()
 	//mapping to wsk.Action.Namespace
 	Namespace  string               `yaml:"namespace"`  //used in deployment.yaml
 	Credential string               `yaml:"credential"` //used in deployment.yaml
@@ -107,9 +157,17 @@ type Action struct {
 	Annotations map[string]interface{} `yaml:"annotations,omitempty"`
 	//Parameters  map[string]interface{} `yaml:parameters` // used in manifest.yaml
 	ExposedUrl string  `yaml:"exposedUrl"` // used in manifest.yaml
-	Webexport  string  `yaml:"web-export"` // used in manifest.yaml
+	Webexport  string  `yaml:"web-export"` // deprecated, used in manifest.yaml
+	Web        string  `yaml:"web"`        // used in manifest.yaml
+	WebSecure  interface{} `yaml:"web-secure,omitempty"` // used in manifest.yaml
 	Main       string  `yaml:"main"`       // used in manifest.yaml
 	Limits     *Limits `yaml:"limits"`     // used in manifest.yaml
+	Notifications []Notification `yaml:"notifications,omitempty"` // used in manifest.yaml
+	DelAnnotations []string      `yaml:"del_annotations,omitempty"` // used in both manifest.yaml and deployment.yaml, annotations removed from the deployed action
+	DelInputs      []string      `yaml:"del_inputs,omitempty"`      // used in deployment.yaml, inputs of the manifest left out
+	InheritAnnotations interface{} `yaml:"inherit-annotations,omitempty"` // used in manifest.yaml, see inheritAnnotations()
+	PreDeploy  []Hook `yaml:"pre_deploy,omitempty"`  // used in manifest.yaml, see Hook
+	PostDeploy []Hook `yaml:"post_deploy,omitempty"` // used in manifest.yaml, see Hook
 }
 
 type Limits struct {
@@ -123,8 +181,67 @@ type Limits struct {
 }
 
 type Sequence struct {
-	Actions     string                 `yaml:"actions"` //used in manifest.yaml
+	Actions     SequenceActions        `yaml:"actions"` //used in manifest.yaml
+	Inputs      map[string]Parameter   `yaml:"inputs,omitempty"` //used in deployment.yaml
 	Annotations map[string]interface{} `yaml:"annotations,omitempty"`
+	Web         string                 `yaml:"web,omitempty"` //used in both manifest.yaml and deployment.yaml, see utils.WebAction()
+	DelInputs      []string            `yaml:"del_inputs,omitempty"`      //used in deployment.yaml, inputs of the manifest left out
+	DelAnnotations []string            `yaml:"del_annotations,omitempty"` //used in deployment.yaml, annotations of the manifest left out
+	InheritAnnotations interface{}     `yaml:"inherit-annotations,omitempty"` //used in manifest.yaml, see inheritAnnotations()
+}
+
+// SequenceActions are the actions of a sequence, in order. They are declared
+// either as a comma separated string or as a YAML list.
+type SequenceActions []string
+
+func (actions *SequenceActions) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		*actions = list
+		return nil
+	}
+
+	var inline string
+	if err := unmarshal(&inline); err != nil {
+		return err
+	}
+	*actions = strings.Split(inline, ",")
+	return nil
+}
+
+// String returns the actions in the comma separated form
+func (actions SequenceActions) String() string {
+	return strings.Join(actions, ",")
+}
+
+// PackageApis are the APIs of a package, declared either as routes, i.e. API
+// name, base path, path and action with its verb, or as an OpenAPI (Swagger)
+// document, e.g. "swagger: api.json"
+type PackageApis struct {
+	Routes  map[string]map[string]map[string]map[string]string
+	Swagger string
+}
+
+func (apis *PackageApis) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var raw map[string]interface{}
+	if err := unmarshal(&raw); err != nil {
+		return err
+	}
+	if swagger, ok := raw[YAML_KEY_SWAGGER].(string); ok {
+		if len(raw) > 1 {
+			return errors.New(wski18n.T(wski18n.ID_ERR_SWAGGER_WITH_ROUTES))
+		}
+		apis.Swagger = swagger
+		return nil
+	}
+	return unmarshal(&apis.Routes)
+}
+
+func (apis PackageApis) MarshalYAML() (interface{}, error) {
+	if len(apis.Swagger) > 0 {
+		return map[string]string{YAML_KEY_SWAGGER: apis.Swagger}, nil
+	}
+	return apis.Routes, nil
 }
 
 type Dependency struct {
@@ -142,6 +259,9 @@ type Parameter struct {
 	Default     interface{} `yaml:"default,omitempty"`
 	Status      string      `yaml:"status,omitempty"`
 	Schema      interface{} `yaml:"schema,omitempty"`
+	Secret      bool        `yaml:"secret,omitempty"` // the value is masked in the output and reports
+	Encrypted   bool        `yaml:"encrypted,omitempty"` // the value is deployed encrypted, see AddEncryptedInputs()
+	Merge       string      `yaml:"merge,omitempty"`  // used in deployment.yaml, how a list is merged with the one of the manifest, see MergeInputValue()
 	multiline   bool
 }
 
@@ -155,6 +275,9 @@ type Trigger struct {
 	//mapping to wsk.Trigger.Name
 	Name        string
 	Annotations map[string]interface{} `yaml:"annotations,omitempty"`
+	InheritAnnotations interface{}     `yaml:"inherit-annotations,omitempty"` //used in manifest.yaml, see inheritAnnotations()
+	DelInputs      []string            `yaml:"del_inputs,omitempty"`      //used in deployment.yaml, inputs of the manifest left out
+	DelAnnotations []string            `yaml:"del_annotations,omitempty"` //used in deployment.yaml, annotations of the manifest left out
 	Source      string                 `yaml:source` // deprecated, used in manifest.yaml
 	//Parameters  map[string]interface{} `yaml:parameters` // used in manifest.yaml
 }
@@ -178,6 +301,10 @@ type Rule struct {
 	Rule   string `yaml:"rule"`   //used in manifest.yaml
 	//mapping to wsk.Rule.Name
 	Name string
+	//mapping to wsk.Rule.Status, active or inactive
+	Status string `yaml:"status,omitempty"` //used in deployment.yaml
+	Annotations    map[string]interface{} `yaml:"annotations,omitempty"`     //used in both manifest.yaml and deployment.yaml
+	DelAnnotations []string               `yaml:"del_annotations,omitempty"` //used in deployment.yaml, annotations of the manifest left out
 }
 
 type Repository struct {
@@ -194,6 +321,7 @@ type Package struct {
 	License      string                `yaml:"license"` //used in manifest.yaml, mandatory
 	Repositories []Repository          `yaml:"repositories,omitempty"`
 	Dependencies map[string]Dependency `yaml: dependencies` //used in manifest.yaml
+	Bindings     map[string]Binding    `yaml:"bindings,omitempty"` //used in manifest.yaml, see ComposeBindings()
 	//mapping to wsk.SentPackageNoPublish.Namespace
 	Namespace   string                 `yaml:"namespace"`  //used in both manifest.yaml and deployment.yaml
 	Credential  string                 `yaml:"credential"` //used in both manifest.yaml and deployment.yaml
@@ -205,8 +333,37 @@ type Package struct {
 	Inputs      map[string]Parameter   `yaml:"inputs"`     //deprecated, used in deployment.yaml
 	Sequences   map[string]Sequence    `yaml:"sequences"`
 	Annotations map[string]interface{} `yaml:"annotations,omitempty"`
+	DelInputs      []string `yaml:"del_inputs,omitempty"`      //used in deployment.yaml, inputs of the manifest left out
+	DelAnnotations []string `yaml:"del_annotations,omitempty"` //used in deployment.yaml, annotations of the manifest left out
+	// inherited by the actions, sequences and triggers of the package which do not set their own
+	InheritAnnotations interface{} `yaml:"inherit-annotations,omitempty"` //used in manifest.yaml, see inheritAnnotations()
+	Limits *Limits `yaml:"limits,omitempty"` //used in manifest.yaml, inherited by the actions of the package, see InheritLimits()
 	//Parameters  map[string]interface{} `yaml: parameters` // used in manifest.yaml
-	Apis map[string]map[string]map[string]map[string]string `yaml:"apis"` //used in manifest.yaml
+	Apis PackageApis `yaml:"apis"` //used in manifest.yaml
+	Notifications []Notification `yaml:"notifications,omitempty"` //used in manifest.yaml
+	PreDeploy     []Hook         `yaml:"pre_deploy,omitempty"`    //used in manifest.yaml, see Hook
+	PostDeploy    []Hook         `yaml:"post_deploy,omitempty"`   //used in manifest.yaml, see Hook
+	// the custom keys of the package, handled by plugins, see ManifestExtensions()
+	Extensions map[string]interface{} `yaml:",inline"`
+}
+
+// Binding is a package binding declared by a package of the manifest, to a
+// package deployed before, e.g. /whisk.system/cloudant, with the parameters
+// and annotations it is bound with
+type Binding struct {
+	Package     string                 `yaml:"package"` //used in manifest.yaml, /namespace/package, or a package of the namespace
+	Inputs      map[string]Parameter   `yaml:"inputs,omitempty"`
+	Annotations map[string]interface{} `yaml:"annotations,omitempty"`
+}
+
+// Hook is run before (pre_deploy) or after (post_deploy) a package or an
+// action is deployed: either a command run in the directory of the manifest,
+// or an action invoked with its inputs, whose activation is waited for
+type Hook struct {
+	Run       string                 `yaml:"run,omitempty"`        //used in manifest.yaml, command run by the shell
+	Action    string                 `yaml:"action,omitempty"`     //used in manifest.yaml, package/action of the namespace or /namespace/package/action
+	Inputs    map[string]interface{} `yaml:"inputs,omitempty"`     //used in manifest.yaml, parameters of the action
+	OnFailure string                 `yaml:"on_failure,omitempty"` //used in manifest.yaml, see HOOK_ON_FAILURE_FAIL
 }
 
 type Project struct {
@@ -214,12 +371,61 @@ type Project struct {
 	Namespace  string             `yaml:"namespace"` //used in deployment.yaml
 	Credential string             `yaml:"credential"`
 	ApiHost    string             `yaml:"apiHost"`
+	ApigwAccessToken string       `yaml:"apigw_access_token,omitempty"` //used in both manifest.yaml and deployment.yaml
+	ApigwHost  string             `yaml:"apigw_host,omitempty"`         //used in both manifest.yaml and deployment.yaml, API gateway host if not the API host
 	Version    string             `yaml:"version"`
+	Owner      string             `yaml:"owner,omitempty"`   //used in manifest.yaml, stored in the managed annotation
+	Contact    string             `yaml:"contact,omitempty"` //used in manifest.yaml, stored in the managed annotation
+	Inputs     map[string]Parameter `yaml:"inputs,omitempty"` //used in both manifest.yaml and deployment.yaml, inherited by every package
+	InputsScope string            `yaml:"inputs_scope,omitempty"` //used in manifest.yaml, "packages" (default) or "all" to set the inputs on actions as well
+	Annotations map[string]interface{} `yaml:"annotations,omitempty"` //used in manifest.yaml, inherited by every package, action, sequence and trigger
+	Limits     *Limits            `yaml:"limits,omitempty"` //used in manifest.yaml, inherited by every action, see InheritLimits()
 	Packages   map[string]Package `yaml:"packages"` //used in deployment.yaml
 	Package    Package            `yaml:"package"`  // being deprecated, used in deployment.yaml
+	Notifications []Notification  `yaml:"notifications,omitempty"` //used in manifest.yaml
+	OverwriteAnnotations bool     `yaml:"overwrite_annotations,omitempty"` //used in manifest.yaml, the annotations of deployed actions which are not in the manifest are removed
+	ExpectedTarget *ExpectedTarget `yaml:"expected-target,omitempty"` //used in manifest.yaml, the project is not deployed elsewhere without --override-target
+	Plugins    map[string]PluginDeclaration `yaml:"plugins,omitempty"` //used in manifest.yaml, see PluginDeclaration
+	// the custom keys of the project, handled by plugins, see ManifestExtensions()
+	Extensions map[string]interface{} `yaml:",inline"`
+}
+
+// ExpectedTarget is the API hosts and namespaces a project may be deployed
+// to, as globs, e.g. "*.dev.example.com", any of them if empty
+type ExpectedTarget struct {
+	ApiHost   TargetPatterns `yaml:"apihost,omitempty"`
+	Namespace TargetPatterns `yaml:"namespace,omitempty"`
+}
+
+// TargetPatterns are the globs of an expected target, declared either as a
+// single string or as a YAML list
+type TargetPatterns []string
+
+func (patterns *TargetPatterns) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var list []string
+	if err := unmarshal(&list); err == nil {
+		*patterns = list
+		return nil
+	}
+
+	var single string
+	if err := unmarshal(&single); err != nil {
+		return err
+	}
+	*patterns = []string{single}
+	return nil
+}
+
+// Notification is a webhook which is posted to when a deployment or an
+// undeployment completes or fails
+type Notification struct {
+	Url    string   `yaml:"url"`              //used in manifest.yaml, mandatory
+	Type   string   `yaml:"type,omitempty"`   //used in manifest.yaml, "webhook" (default), "slack" or "teams"
+	Events []string `yaml:"events,omitempty"` //used in manifest.yaml, all events if empty
 }
 
 type YAML struct {
+	WskdeployVersion VersionRequirement `yaml:"wskdeploy_version,omitempty"` //used in both manifest.yaml and deployment.yaml
 	Application Project            `yaml:"application"` //used in deployment.yaml (being deprecated)
 	Project     Project            `yaml:"project"`     //used in deployment.yaml
 	Packages    map[string]Package `yaml:"packages"`    //used in deployment.yaml
@@ -236,6 +442,42 @@ func (yaml *YAML) GetProject() Project {
 	return yaml.Application
 }
 
+// SelectPackages removes the packages which are not selected from the
+// manifest, and returns the number of packages left
+func (yaml *YAML) SelectPackages(selected func(name string) bool) int {
+	if yaml.Package.Packagename != "" {
+		if !selected(yaml.Package.Packagename) {
+			yaml.Package = Package{}
+			return 0
+		}
+		return 1
+	}
+	// maps are shared with the project returned by GetProject()
+	count := 0
+	for _, packages := range []map[string]Package{yaml.Packages, yaml.GetProject().Packages} {
+		for name := range packages {
+			if selected(name) {
+				count++
+			} else {
+				delete(packages, name)
+			}
+		}
+	}
+	return count
+}
+
+// function to return the packages declared in manifest and deployment files,
+// either with "package", "packages" or under the project
+func (yaml *YAML) GetPackages() map[string]Package {
+	if yaml.Package.Packagename != "" {
+		return map[string]Package{yaml.Package.Packagename: yaml.Package}
+	}
+	if len(yaml.Packages) != 0 {
+		return yaml.Packages
+	}
+	return yaml.GetProject().Packages
+}
+
 func convertPackageName(packageMap map[string]Package) map[string]Package {
 	packages := make(map[string]Package)
 	for packName, depPacks := range packageMap {
@@ -285,6 +527,9 @@ func (rule *Rule) ComposeWskRule() *whisk.Rule {
 	wskrule.Publish = &pub
 	wskrule.Trigger = wskenv.ConvertSingleName(rule.Trigger)
 	wskrule.Action = wskenv.ConvertSingleName(rule.Action)
+	for name, value := range rule.Annotations {
+		wskrule.Annotations = append(wskrule.Annotations, whisk.KeyValue{Key: name, Value: ResolveAnnotation(value)})
+	}
 	return wskrule
 }
 
@@ -339,7 +584,7 @@ func (pkg *Package) GetFeedList() []Feed {
 // This is for parse the manifest yaml file.
 func (pkg *Package) GetApis() []*whisk.Api {
 	var apis = make([]*whisk.Api, 0)
-	for k, v := range pkg.Apis {
+	for k, v := range pkg.Apis.Routes {
 		var apiName string = k
 		for k, v := range v {
 			var gatewayBasePath string = k
@@ -135,3 +135,18 @@ func TestGetApisList(t *testing.T) {
 	apis := pkg.GetApis()
 	assert.Equal(t, 5, len(apis), "Get api list failed.")
 }
+
+func TestSelectPackages(t *testing.T) {
+	mm := NewYAMLParser()
+	manifest, err := mm.ParseManifest("../tests/dat/manifest_validate_project_inputs.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(manifest.GetPackages()))
+
+	assert.Equal(t, 1, manifest.SelectPackages(func(name string) bool { return name == "hello" }))
+	assert.Equal(t, 1, len(manifest.GetPackages()))
+	_, ok := manifest.GetPackages()["hello"]
+	assert.True(t, ok)
+
+	assert.Equal(t, 0, manifest.SelectPackages(func(name string) bool { return false }))
+	assert.Equal(t, 0, len(manifest.GetPackages()))
+}
@@ -0,0 +1,93 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"encoding/base64"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// the default limit of OpenWhisk on the size of the code of an action
+// (whisk.action.code-size.max), the code of zip and jar files is base64 encoded
+const DEFAULT_MAX_ACTION_CODE_SIZE = 48 * 1024 * 1024
+
+// MaxActionCodeSize returns the largest code of an action, --max-code-size or
+// the default limit of OpenWhisk
+func (flags *WskdeployFlags) MaxActionCodeSize() int64 {
+	if flags.MaxCodeSize > 0 {
+		return flags.MaxCodeSize
+	}
+	return DEFAULT_MAX_ACTION_CODE_SIZE
+}
+
+// ReadActionCode returns the code of an action from its file, base64 encoded
+// if it is binary, e.g. a zip or jar file. The size of the code is checked
+// against maxSize, see MaxActionCodeSize(), before the file is read. The
+// OpenWhisk client sends the code as a string, so the code is held in memory
+// once: the file is encoded as it is read into a strings.Builder of the size
+// of the code, whose string is returned without a copy.
+func ReadActionCode(filePath string, binary bool, maxSize int64) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	size := info.Size()
+	if binary {
+		size = int64(base64.StdEncoding.EncodedLen(int(size)))
+	}
+	if size > maxSize {
+		return "", NewActionCodeSizeError(filePath, size, maxSize)
+	}
+
+	var code strings.Builder
+	code.Grow(int(size))
+	if !binary {
+		if _, err := io.Copy(&code, file); err != nil {
+			return "", wskderrors.NewFileReadError(filePath, err.Error())
+		}
+		return code.String(), nil
+	}
+
+	encoder := base64.NewEncoder(base64.StdEncoding, &code)
+	if _, err := io.Copy(encoder, file); err != nil {
+		return "", wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	if err := encoder.Close(); err != nil {
+		return "", wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	return code.String(), nil
+}
+
+// NewActionCodeSizeError returns the error of the file of an action whose code
+// is larger than OpenWhisk accepts
+func NewActionCodeSizeError(filePath string, size int64, max int64) error {
+	return wskderrors.NewActionCodeSizeError(wski18n.T(wski18n.ID_ERR_ACTION_CODE_SIZE_X_path_X_value_X_max_X,
+		map[string]interface{}{wski18n.KEY_PATH: filePath, wski18n.KEY_VALUE: size, wski18n.KEY_MAX: max}),
+		filePath, size, max)
+}
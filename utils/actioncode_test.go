@@ -0,0 +1,65 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadActionCode(t *testing.T) {
+	flags := &WskdeployFlags{}
+
+	file, err := ioutil.TempFile("", "action.zip.")
+	assert.Nil(t, err)
+	defer os.Remove(file.Name())
+	content := make([]byte, 100000)
+	for i := range content {
+		content[i] = byte(i % 251)
+	}
+	_, err = file.Write(content)
+	assert.Nil(t, err)
+	file.Close()
+
+	code, err := ReadActionCode(file.Name(), true, flags.MaxActionCodeSize())
+	assert.Nil(t, err)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(content), code)
+
+	code, err = ReadActionCode(file.Name(), false, flags.MaxActionCodeSize())
+	assert.Nil(t, err)
+	assert.Equal(t, string(content), code)
+
+	// the encoded code is a third larger than the file
+	flags.MaxCodeSize = int64(len(content))
+	_, err = ReadActionCode(file.Name(), false, flags.MaxActionCodeSize())
+	assert.Nil(t, err)
+	_, err = ReadActionCode(file.Name(), true, flags.MaxActionCodeSize())
+	if assert.IsType(t, &wskderrors.ActionCodeSizeError{}, err) {
+		assert.Equal(t, int64(base64.StdEncoding.EncodedLen(len(content))), err.(*wskderrors.ActionCodeSizeError).Size)
+	}
+
+	_, err = ReadActionCode(file.Name()+".missing", true, flags.MaxActionCodeSize())
+	assert.IsType(t, &wskderrors.FileReadError{}, err)
+}
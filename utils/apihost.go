@@ -0,0 +1,98 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+const (
+	DEFAULT_API_HOST_SCHEME = "https"
+	// path of the OpenWhisk REST API, relative to the API host
+	API_BASE_PATH    = "/api"
+	API_VERSION_PATH = "/v1"
+	WEB_ACTIONS_PATH = "/web"
+	// extension of a web action which handles the whole HTTP request
+	WEB_ACTION_HTTP_EXTENSION = ".http"
+)
+
+// ParseApiHost parses an API host given as "host", "host:port" or a URL, which
+// may include a path prefix, e.g. "https://cluster:8443/openwhisk" for an
+// OpenWhisk deployment exposed by an ingress under /openwhisk. A trailing
+// "/api" or "/api/v1" is dropped, so that paths of the REST API can be added.
+func ParseApiHost(apiHost string) (*url.URL, error) {
+	host := strings.TrimSpace(apiHost)
+	if len(host) == 0 {
+		return nil, errors.New(wski18n.T(wski18n.ID_ERR_API_HOST_EMPTY))
+	}
+	if !strings.Contains(host, "://") {
+		host = DEFAULT_API_HOST_SCHEME + "://" + host
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, err
+	}
+	if len(u.Hostname()) == 0 {
+		return nil, errors.New(wski18n.T(wski18n.ID_ERR_API_HOST_NO_HOSTNAME_X_host_X,
+			map[string]interface{}{wski18n.KEY_HOST: apiHost}))
+	}
+
+	prefix := strings.TrimRight(u.Path, "/")
+	prefix = strings.TrimSuffix(prefix, API_BASE_PATH+API_VERSION_PATH)
+	prefix = strings.TrimSuffix(prefix, API_BASE_PATH)
+	u.Path = strings.TrimRight(prefix, "/")
+	u.RawPath = ""
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u, nil
+}
+
+// ApiBaseURL returns the URL of the REST API of the API host, including its
+// port and path prefix, e.g. https://cluster:8443/openwhisk/api
+func ApiBaseURL(apiHost string) (*url.URL, error) {
+	u, err := ParseApiHost(apiHost)
+	if err != nil {
+		return nil, err
+	}
+	u.Path += API_BASE_PATH
+	return u, nil
+}
+
+// ApiHostURL returns the URL of the path relative to the API host, e.g.
+// ApiHostURL("cluster:8443/openwhisk", "/api/v1") returns
+// https://cluster:8443/openwhisk/api/v1, the API host is used unchanged if it
+// cannot be parsed
+func ApiHostURL(apiHost string, path string) string {
+	u, err := ParseApiHost(apiHost)
+	if err != nil {
+		return strings.TrimRight(apiHost, "/") + path
+	}
+	return u.String() + path
+}
+
+// WebActionURL returns the URL of a web action, actions which do not belong to
+// a package are in the "default" package
+func WebActionURL(apiHost string, namespace string, packageName string, actionName string) string {
+	return ApiHostURL(apiHost, API_BASE_PATH+API_VERSION_PATH+WEB_ACTIONS_PATH+
+		"/"+strings.Join([]string{namespace, packageName, actionName}, "/"))
+}
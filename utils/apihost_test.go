@@ -0,0 +1,57 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApiBaseURL(t *testing.T) {
+	expected := map[string]string{
+		"openwhisk.ng.bluemix.net":                   "https://openwhisk.ng.bluemix.net/api",
+		"172.17.0.1:8443":                            "https://172.17.0.1:8443/api",
+		"http://localhost:3233/":                     "http://localhost:3233/api",
+		"https://cluster.local:31001/openwhisk":      "https://cluster.local:31001/openwhisk/api",
+		"https://cluster.local:31001/openwhisk/api/": "https://cluster.local:31001/openwhisk/api",
+		"cluster.local/ingress/openwhisk/api/v1":     "https://cluster.local/ingress/openwhisk/api",
+		"[::1]:8443/openwhisk":                       "https://[::1]:8443/openwhisk/api",
+	}
+	for apiHost, baseURL := range expected {
+		u, err := ApiBaseURL(apiHost)
+		assert.Nil(t, err, apiHost)
+		assert.Equal(t, baseURL, u.String(), apiHost)
+	}
+
+	for _, apiHost := range []string{"", "https://", "http://:8080/openwhisk"} {
+		_, err := ApiBaseURL(apiHost)
+		assert.NotNil(t, err, apiHost)
+	}
+}
+
+func TestWebActionURL(t *testing.T) {
+	assert.Equal(t, "https://cluster.local:31001/openwhisk/api/v1/web/guest/hello/world",
+		WebActionURL("cluster.local:31001/openwhisk/", "guest", "hello", "world"))
+	assert.Equal(t, "http://localhost:3233/api/v1/web/guest/default/world",
+		WebActionURL("http://localhost:3233/api", "guest", "default", "world"))
+	assert.Equal(t, "https://cluster.local:31001/openwhisk/api/v1",
+		ApiHostURL("cluster.local:31001/openwhisk", RUNTIMES_API_PATH))
+}
@@ -0,0 +1,292 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// docker images which compile Go and Rust actions, they are the images of the
+// runtimes and accept the sources of an action on -compile
+const (
+	GO_COMPILE_IMAGE   = "openwhisk/action-golang-v1.15"
+	RUST_COMPILE_IMAGE = "openwhisk/action-rust-v1.34"
+)
+
+// CodeBuild is the code of an action to build, the builder writes its output
+// to Dir, a temporary directory removed once the code is deployed
+type CodeBuild struct {
+	Action string
+	Source string
+	Main   string
+	Dir    string
+	// image the code is built in rather than locally, see --build-image
+	Image string
+}
+
+// CodeBuilder builds the code of actions of a kind, e.g. compiles the
+// TypeScript of a Node.js action, before it is deployed
+type CodeBuilder interface {
+	// Language is the runtime the code is built for, e.g. "nodejs", the
+	// default kind of the runtime is used when the action has none
+	Language() string
+	// Detect reports whether the file or directory of an action needs the
+	// builder
+	Detect(source string) bool
+	// Build returns the file or the directory of the code deployed, a
+	// directory is zipped
+	Build(build CodeBuild) (string, error)
+}
+
+// CodeBuilders are the builders of action code by name
+var CodeBuilders = map[string]CodeBuilder{
+	"typescript": TypeScriptBuilder{},
+	"go":         DockerCompileBuilder{Runtime: "go", Image: GO_COMPILE_IMAGE, Extension: ".go", ProjectFile: "go.mod"},
+	"rust":       DockerCompileBuilder{Runtime: "rust", Image: RUST_COMPILE_IMAGE, Extension: ".rs", ProjectFile: "Cargo.toml"},
+	"dotnet":     DotnetBuilder{},
+	"composer":   ComposerBuilder{},
+	"virtualenv": VirtualenvBuilder{},
+}
+
+// RegisterCodeBuilder adds a builder of action code, registering an existing
+// name replaces its builder
+func RegisterCodeBuilder(name string, builder CodeBuilder) {
+	CodeBuilders[name] = builder
+}
+
+// FindCodeBuilder returns the builder which detects the code of an action, if
+// any, the builders of another runtime than the one of the action are skipped.
+// Builders are consulted by name so that the result does not depend on the
+// order of the map.
+func FindCodeBuilder(source string, runtime string) CodeBuilder {
+	names := make([]string, 0, len(CodeBuilders))
+	for name := range CodeBuilders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		builder := CodeBuilders[name]
+		language := builder.Language()
+		if len(runtime) > 0 && runtime != language && !strings.HasPrefix(runtime, language+":") {
+			continue
+		}
+		if builder.Detect(source) {
+			return builder
+		}
+	}
+	return nil
+}
+
+// BuildCode builds the code of the action in a temporary directory, the
+// directory built is zipped. It returns the file to deploy and the temporary
+// directory, which the caller removes once the file is read.
+func BuildCode(builder CodeBuilder, action string, source string, main string, image string) (string, string, error) {
+	tempDir, err := ioutil.TempDir("", "wskdeploy-build")
+	if err != nil {
+		return "", "", err
+	}
+	buildDir := filepath.Join(tempDir, "build")
+	if err := os.Mkdir(buildDir, 0755); err != nil {
+		os.RemoveAll(tempDir)
+		return "", "", err
+	}
+	built, err := builder.Build(CodeBuild{Action: action, Source: source, Main: main, Dir: buildDir, Image: image})
+	if err != nil {
+		os.RemoveAll(tempDir)
+		return "", "", err
+	}
+	if IsDirectory(built) {
+		zipName := filepath.Join(tempDir, filepath.Base(source)+"."+ZIP_FILE_EXTENSION)
+		if err := NewZipWritter(built, zipName).Zip(); err != nil {
+			os.RemoveAll(tempDir)
+			return "", "", err
+		}
+		built = zipName
+	}
+	return built, tempDir, nil
+}
+
+// runBuildCommand runs a command of a builder in dir, the output of the command
+// is shown if it fails. The standard input and output of the command are the
+// ones given, if any.
+func runBuildCommand(action string, dir string, stdin io.Reader, stdout io.Writer, args ...string) error {
+	commandLine := strings.Join(args, " ")
+	wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_ACTION_BUILD_X_action_X_command_X,
+		map[string]interface{}{wski18n.KEY_ACTION: action, wski18n.KEY_COMMAND: commandLine}))
+
+	var output bytes.Buffer
+	command := exec.Command(args[0], args[1:]...)
+	command.Dir = dir
+	command.Stdin = stdin
+	command.Stdout = &output
+	if stdout != nil {
+		command.Stdout = stdout
+	}
+	command.Stderr = &output
+	if err := command.Run(); err != nil {
+		return wskderrors.NewCommandError(commandLine, wski18n.T(wski18n.ID_ERR_ACTION_BUILD_X_action_X_command_X_err_X_output_X,
+			map[string]interface{}{wski18n.KEY_ACTION: action, wski18n.KEY_COMMAND: commandLine,
+				wski18n.KEY_ERR: err.Error(), wski18n.KEY_OUTPUT: strings.TrimSpace(output.String())}))
+	}
+	whisk.Debug(whisk.DbgInfo, output.String())
+	return nil
+}
+
+// hasFiles reports whether the directory holds a file matching the pattern
+func hasFiles(dir string, pattern string) bool {
+	matches, err := filepath.Glob(filepath.Join(dir, pattern))
+	return err == nil && len(matches) > 0
+}
+
+// TypeScriptBuilder compiles a .ts file, or a directory with a tsconfig.json,
+// to JavaScript with the TypeScript compiler of the project, if any
+type TypeScriptBuilder struct{}
+
+func (TypeScriptBuilder) Language() string {
+	return "nodejs"
+}
+
+func (TypeScriptBuilder) Detect(source string) bool {
+	if IsDirectory(source) {
+		return FileExists(filepath.Join(source, "tsconfig.json"))
+	}
+	return strings.HasSuffix(source, ".ts") && !strings.HasSuffix(source, ".d.ts")
+}
+
+func (TypeScriptBuilder) Build(build CodeBuild) (string, error) {
+	if IsDirectory(build.Source) {
+		// the output of tsconfig.json is relative to the project, the
+		// project is compiled in place of a copy
+		if err := copyDirectory(build.Source, build.Dir); err != nil {
+			return "", err
+		}
+		return build.Dir, runBuildCommand(build.Action, build.Dir, nil, nil, "npx", "tsc", "-p", ".")
+	}
+	err := runBuildCommand(build.Action, filepath.Dir(build.Source), nil, nil,
+		"npx", "tsc", "--outDir", build.Dir, "--module", "commonjs", "--target", "es2017", build.Source)
+	return filepath.Join(build.Dir, strings.TrimSuffix(filepath.Base(build.Source), ".ts")+".js"), err
+}
+
+// DockerCompileBuilder compiles a source file, or a directory with a project
+// file or source files, in the docker image of the runtime, which reads the
+// sources on its standard input and writes the zip file of the executable on
+// its standard output
+type DockerCompileBuilder struct {
+	Runtime     string
+	Image       string
+	Extension   string
+	ProjectFile string
+}
+
+func (builder DockerCompileBuilder) Language() string {
+	return builder.Runtime
+}
+
+func (builder DockerCompileBuilder) Detect(source string) bool {
+	if IsDirectory(source) {
+		return FileExists(filepath.Join(source, builder.ProjectFile)) || hasFiles(source, "*"+builder.Extension)
+	}
+	return filepath.Ext(source) == builder.Extension
+}
+
+func (builder DockerCompileBuilder) Build(build CodeBuild) (string, error) {
+	source := build.Source
+	if IsDirectory(source) {
+		source = filepath.Join(build.Dir, "src."+ZIP_FILE_EXTENSION)
+		if err := NewZipWritter(build.Source, source).Zip(); err != nil {
+			return "", err
+		}
+	}
+	in, err := os.Open(source)
+	if err != nil {
+		return "", wskderrors.NewFileReadError(source, err.Error())
+	}
+	defer in.Close()
+
+	executable := filepath.Join(build.Dir, "exec."+ZIP_FILE_EXTENSION)
+	out, err := os.Create(executable)
+	if err != nil {
+		return "", err
+	}
+	defer out.Close()
+	return executable, runBuildCommand(build.Action, build.Dir, in, out, builder.compileCommand(build.Main)...)
+}
+
+// compileCommand returns the command compiling the sources of an action, main
+// is the function the action starts with
+func (builder DockerCompileBuilder) compileCommand(main string) []string {
+	if len(main) == 0 {
+		main = "main"
+	}
+	return []string{"docker", "run", "-i", "--rm", builder.Image, "-compile", main}
+}
+
+// DotnetBuilder publishes the .NET project of a directory with a .csproj file,
+// the published assemblies are deployed
+type DotnetBuilder struct{}
+
+func (DotnetBuilder) Language() string {
+	return "dotnet"
+}
+
+func (DotnetBuilder) Detect(source string) bool {
+	return IsDirectory(source) && hasFiles(source, "*.csproj")
+}
+
+func (DotnetBuilder) Build(build CodeBuild) (string, error) {
+	// the bin and obj directories of the build are left out of the project
+	src := filepath.Join(build.Dir, "src")
+	if err := copyDirectory(build.Source, src); err != nil {
+		return "", err
+	}
+	out := filepath.Join(build.Dir, "out")
+	return out, runBuildCommand(build.Action, src, nil, nil, "dotnet", "publish", "-c", "Release", "-o", out)
+}
+
+// ComposerBuilder installs the dependencies of the composer.json of a PHP
+// action, unless they are installed in its vendor directory already
+type ComposerBuilder struct{}
+
+func (ComposerBuilder) Language() string {
+	return "php"
+}
+
+func (ComposerBuilder) Detect(source string) bool {
+	return IsDirectory(source) && FileExists(filepath.Join(source, "composer.json")) &&
+		!IsDirectory(filepath.Join(source, "vendor"))
+}
+
+func (ComposerBuilder) Build(build CodeBuild) (string, error) {
+	if err := copyDirectory(build.Source, build.Dir); err != nil {
+		return "", err
+	}
+	return build.Dir, runBuildCommand(build.Action, build.Dir, nil, nil,
+		"composer", "install", "--no-dev", "--no-interaction", "--optimize-autoloader")
+}
@@ -0,0 +1,103 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeCodeFiles(t *testing.T, dir string, names ...string) {
+	for _, name := range names {
+		assert.Nil(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, name), []byte("code\n"), 0644))
+	}
+}
+
+func TestFindCodeBuilder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "codebuilders")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	writeCodeFiles(t, dir, "hello.ts", "hello.d.ts", "hello.js", "hello.go", "hello.rs",
+		"ts/tsconfig.json", "gomod/go.mod", "gosrc/main.go", "cargo/Cargo.toml", "cs/Hello.csproj",
+		"php/composer.json", "vendored/composer.json", "vendored/vendor/autoload.php", "py/requirements.txt")
+
+	tests := map[string]CodeBuilder{
+		"hello.ts":   CodeBuilders["typescript"],
+		"hello.d.ts": nil,
+		"hello.js":   nil,
+		"hello.go":   CodeBuilders["go"],
+		"hello.rs":   CodeBuilders["rust"],
+		"ts":         CodeBuilders["typescript"],
+		"gomod":      CodeBuilders["go"],
+		"gosrc":      CodeBuilders["go"],
+		"cargo":      CodeBuilders["rust"],
+		"cs":         CodeBuilders["dotnet"],
+		"php":        CodeBuilders["composer"],
+		"vendored":   nil,
+		"py":         CodeBuilders["virtualenv"],
+	}
+	for source, expected := range tests {
+		assert.Equal(t, expected, FindCodeBuilder(filepath.Join(dir, source), ""), source)
+	}
+
+	assert.Equal(t, CodeBuilders["typescript"], FindCodeBuilder(filepath.Join(dir, "hello.ts"), "nodejs:8"))
+	assert.Equal(t, CodeBuilders["go"], FindCodeBuilder(filepath.Join(dir, "hello.go"), "go"))
+	assert.Nil(t, FindCodeBuilder(filepath.Join(dir, "hello.go"), "nodejs:8"), "the builder of another runtime")
+	assert.Nil(t, FindCodeBuilder(filepath.Join(dir, "py"), "nodejs:8"), "the builder of another runtime")
+}
+
+type testCodeBuilder struct{}
+
+func (testCodeBuilder) Language() string {
+	return "test"
+}
+
+func (testCodeBuilder) Detect(source string) bool {
+	return filepath.Ext(source) == ".test"
+}
+
+func (testCodeBuilder) Build(build CodeBuild) (string, error) {
+	return build.Dir, ioutil.WriteFile(filepath.Join(build.Dir, "index.js"), []byte(build.Main), 0644)
+}
+
+func TestRegisterCodeBuilder(t *testing.T) {
+	RegisterCodeBuilder("test", testCodeBuilder{})
+	defer delete(CodeBuilders, "test")
+
+	assert.Equal(t, testCodeBuilder{}, FindCodeBuilder("hello.test", ""))
+	assert.Nil(t, FindCodeBuilder("hello.test", "nodejs:8"))
+
+	built, buildDir, err := BuildCode(testCodeBuilder{}, "hello", "hello.test", "main", "")
+	assert.Nil(t, err)
+	defer os.RemoveAll(buildDir)
+	assert.Equal(t, filepath.Join(buildDir, "hello.test.zip"), built, "the directory built is zipped")
+	assert.True(t, FileExists(built))
+}
+
+func TestDockerCompileBuilderCommand(t *testing.T) {
+	builder := CodeBuilders["go"].(DockerCompileBuilder)
+	assert.Equal(t, []string{"docker", "run", "-i", "--rm", GO_COMPILE_IMAGE, "-compile", "main"}, builder.compileCommand(""))
+	assert.Equal(t, []string{"docker", "run", "-i", "--rm", GO_COMPILE_IMAGE, "-compile", "Hello"}, builder.compileCommand("Hello"))
+}
@@ -18,6 +18,7 @@
 package utils
 
 import (
+	"path"
 	"strings"
 
 	"github.com/apache/incubator-openwhisk-client-go/whisk"
@@ -33,6 +34,9 @@ type DependencyRecord struct {
 	IsBinding   bool
 	BaseRepo    string
 	SubFolder   string
+	// manifest of the dependency when its location is the path of a manifest
+	// file rather than of a project, e.g. github.com/org/repo/path/manifest.yaml
+	ManifestFile string
 }
 
 func NewDependencyRecord(projectPath string,
@@ -52,12 +56,19 @@ func NewDependencyRecord(projectPath string,
 	record.IsBinding = isBinding
 	//split url to BaseUrl and SubFolder
 	if !record.IsBinding {
-		paths := strings.Split(location, "/")
-		record.BaseRepo = strings.Join([]string{paths[0],paths[1],paths[2],paths[3],paths[4]},"/")
-		if len(paths)>5 {
-			record.SubFolder = strings.TrimPrefix(record.Location,record.BaseRepo)
-		} else {
-			record.SubFolder = ""
+		paths := strings.Split(strings.TrimSuffix(location, "/"), "/")
+		if len(paths) < 5 {
+			// not a repository, e.g. https://github.com/org, it fails to be cloned
+			record.BaseRepo = location
+			return record
+		}
+		record.BaseRepo = strings.TrimSuffix(strings.Join(paths[:5], "/"), ".git")
+		if len(paths) > 5 {
+			record.SubFolder = "/" + strings.Join(paths[5:], "/")
+		}
+		if ext := path.Ext(record.SubFolder); ext == ".yaml" || ext == ".yml" {
+			record.ManifestFile = path.Base(record.SubFolder)
+			record.SubFolder = strings.TrimSuffix(path.Dir(record.SubFolder), "/")
 		}
 	}
 
@@ -72,6 +83,14 @@ func LocationIsBinding(location string) bool {
 	return false
 }
 
+// LocationIsGithubRepo returns whether the location is the one of a GitHub
+// repository, i.e. github.com/<org>/<repo> with an optional path
+func LocationIsGithubRepo(location string) bool {
+	location = strings.TrimPrefix(strings.TrimPrefix(location, "https://"), "http://")
+	paths := strings.Split(strings.TrimSuffix(location, "/"), "/")
+	return len(paths) >= 3 && paths[0] == "github.com" && len(paths[1]) > 0 && len(paths[2]) > 0
+}
+
 func LocationIsGithub(location string) bool {
 	if strings.HasPrefix(location, "github.com") || strings.HasPrefix(location, "https://github.com") || strings.HasPrefix(location, "http://github.com") {
 		return true
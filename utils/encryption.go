@@ -0,0 +1,167 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// Inputs declared "encrypted: true" are deployed encrypted by the provider
+// selected with --encryption-provider, their values are stored as
+// wskenc:<provider>:<ciphertext> and decrypted by the action. The plaintext is
+// the JSON of the value, e.g. "\"s3cr3t\"" for a string. The names of the
+// encrypted inputs of an entity are listed in its encrypted-inputs annotation.
+const (
+	ENCRYPTED_INPUTS_ANNOT = "encrypted-inputs"
+	ENCRYPTED_VALUE_PREFIX = "wskenc:"
+)
+
+// the encryption providers: aes-gcm encrypts with the AES key of the
+// WSKDEPLOY_ENCRYPTION_KEY variable, base64 encoded, and command:<command>
+// runs the command, e.g. a KMS client, with the plaintext on its standard
+// input and prints the ciphertext
+const (
+	ENCRYPTION_PROVIDER_AES_GCM = "aes-gcm"
+	ENCRYPTION_PROVIDER_COMMAND = "command"
+	ENCRYPTION_KEY_ENV          = "WSKDEPLOY_ENCRYPTION_KEY"
+)
+
+// environment variables the command:<command> provider is run with
+const (
+	ENCRYPTION_ENV_ENTITY = "WSKDEPLOY_ENTITY"
+	ENCRYPTION_ENV_INPUT  = "WSKDEPLOY_INPUT"
+)
+
+// IsEncryptedValue reports whether the value is already encrypted, e.g. set
+// encrypted in the deployment file, it is deployed as is
+func IsEncryptedValue(value interface{}) bool {
+	s, ok := value.(string)
+	return ok && strings.HasPrefix(s, ENCRYPTED_VALUE_PREFIX)
+}
+
+// EncryptInput returns the value of the input of an entity encrypted by the
+// provider selected with --encryption-provider, as
+// wskenc:<provider>:<ciphertext>
+func (flags *WskdeployFlags) EncryptInput(entity string, input string, value interface{}) (string, error) {
+	provider := flags.EncryptionProvider
+	fail := func(err error) (string, error) {
+		return "", wskderrors.NewCommandError("--encryption-provider",
+			wski18n.T(wski18n.ID_ERR_ENCRYPTION_X_entity_X_input_X_provider_X_err_X,
+				map[string]interface{}{wski18n.KEY_ENTITY: entity, wski18n.KEY_INPUT: input,
+					wski18n.KEY_PROVIDER: provider, wski18n.KEY_ERR: err.Error()}))
+	}
+
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return fail(err)
+	}
+	var name, ciphertext string
+	switch {
+	case provider == ENCRYPTION_PROVIDER_AES_GCM:
+		name = ENCRYPTION_PROVIDER_AES_GCM
+		ciphertext, err = encryptAesGcm(os.Getenv(ENCRYPTION_KEY_ENV), plaintext)
+	case strings.HasPrefix(provider, ENCRYPTION_PROVIDER_COMMAND+":"):
+		name = ENCRYPTION_PROVIDER_COMMAND
+		ciphertext, err = encryptWithCommand(strings.TrimPrefix(provider, ENCRYPTION_PROVIDER_COMMAND+":"), entity, input, plaintext)
+	default:
+		err = errors.New(wski18n.T(wski18n.ID_ERR_ENCRYPTION_PROVIDER_UNKNOWN))
+	}
+	if err != nil {
+		return fail(err)
+	}
+	return ENCRYPTED_VALUE_PREFIX + name + ":" + ciphertext, nil
+}
+
+// encryptAesGcm returns base64(nonce + ciphertext + tag), the nonce is 12
+// bytes and the tag 16 bytes
+func encryptAesGcm(encodedKey string, plaintext []byte) (string, error) {
+	gcm, err := newAesGcm(encodedKey)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, plaintext, nil)), nil
+}
+
+func decryptAesGcm(encodedKey string, ciphertext string) ([]byte, error) {
+	gcm, err := newAesGcm(encodedKey)
+	if err != nil {
+		return nil, err
+	}
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, errors.New(wski18n.T(wski18n.ID_ERR_ENCRYPTION_CIPHERTEXT_INVALID))
+	}
+	return gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+}
+
+func newAesGcm(encodedKey string) (cipher.AEAD, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedKey))
+	if err != nil || len(key) == 0 {
+		return nil, errors.New(wski18n.T(wski18n.ID_ERR_ENCRYPTION_KEY_INVALID_X_name_X,
+			map[string]interface{}{wski18n.KEY_NAME: ENCRYPTION_KEY_ENV}))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func encryptWithCommand(commandLine string, entity string, input string, plaintext []byte) (string, error) {
+	args := strings.Fields(commandLine)
+	if len(args) == 0 {
+		return "", errors.New(wski18n.T(wski18n.ID_ERR_ENCRYPTION_PROVIDER_UNKNOWN))
+	}
+	command := exec.Command(args[0], args[1:]...)
+	command.Env = append(os.Environ(), ENCRYPTION_ENV_ENTITY+"="+entity, ENCRYPTION_ENV_INPUT+"="+input)
+	command.Stdin = bytes.NewReader(plaintext)
+	var stderr bytes.Buffer
+	command.Stderr = &stderr
+	output, err := command.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); len(message) > 0 {
+			return "", errors.New(message)
+		}
+		return "", err
+	}
+	ciphertext := strings.TrimSpace(string(output))
+	if len(ciphertext) == 0 {
+		return "", errors.New(wski18n.T(wski18n.ID_ERR_ENCRYPTION_CIPHERTEXT_INVALID))
+	}
+	return ciphertext, nil
+}
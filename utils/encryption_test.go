@@ -0,0 +1,77 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptInput_AesGcm(t *testing.T) {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	assert.Nil(t, err)
+	os.Setenv(ENCRYPTION_KEY_ENV, base64.StdEncoding.EncodeToString(key))
+	defer os.Unsetenv(ENCRYPTION_KEY_ENV)
+	flags := &WskdeployFlags{EncryptionProvider: ENCRYPTION_PROVIDER_AES_GCM}
+
+	value, err := flags.EncryptInput("hello/world", "password", "s3cr3t")
+	assert.Nil(t, err)
+	assert.True(t, IsEncryptedValue(value))
+	assert.True(t, strings.HasPrefix(value, "wskenc:aes-gcm:"))
+	assert.NotContains(t, value, "s3cr3t")
+
+	plaintext, err := decryptAesGcm(os.Getenv(ENCRYPTION_KEY_ENV), strings.TrimPrefix(value, "wskenc:aes-gcm:"))
+	assert.Nil(t, err)
+	assert.Equal(t, `"s3cr3t"`, string(plaintext))
+
+	// the key is required
+	os.Setenv(ENCRYPTION_KEY_ENV, "not a key")
+	_, err = flags.EncryptInput("hello/world", "password", "s3cr3t")
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), ENCRYPTION_KEY_ENV)
+}
+
+func TestEncryptInput_Command(t *testing.T) {
+	if _, err := exec.LookPath("base64"); err != nil {
+		t.Skip("base64 is not installed")
+	}
+	flags := &WskdeployFlags{EncryptionProvider: "command:base64"}
+
+	value, err := flags.EncryptInput("hello/world", "settings", map[string]interface{}{"port": 8080})
+	assert.Nil(t, err)
+	assert.Equal(t, "wskenc:command:"+base64.StdEncoding.EncodeToString([]byte(`{"port":8080}`)), value)
+
+	flags.EncryptionProvider = "vault"
+	_, err = flags.EncryptInput("hello/world", "settings", "value")
+	assert.NotNil(t, err)
+}
+
+func TestIsEncryptedValue(t *testing.T) {
+	assert.True(t, IsEncryptedValue("wskenc:aes-gcm:AAAA"))
+	assert.False(t, IsEncryptedValue("s3cr3t"))
+	assert.False(t, IsEncryptedValue(42))
+}
@@ -0,0 +1,80 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// namespace of the packages of the feeds provided by OpenWhisk
+const WHISK_SYSTEM_NAMESPACE = "whisk.system"
+
+// FeedProvider is a feed of OpenWhisk whose inputs are known, so that the
+// triggers missing one of them fail before anything is deployed rather than
+// when the feed action is invoked
+type FeedProvider struct {
+	// inputs the feed action fails without
+	Inputs []string
+	// message id of the hint given along with a missing input
+	Hint string
+}
+
+// FeedProviders are the feeds of the packages of /whisk.system, by
+// package/feed
+var FeedProviders = map[string]FeedProvider{
+	"alarms/alarm":             {Inputs: []string{"cron"}, Hint: wski18n.ID_MSG_FEED_HINT_ALARM_CRON},
+	"alarms/once":              {Inputs: []string{"date"}, Hint: wski18n.ID_MSG_FEED_HINT_ALARM_ONCE},
+	"alarms/interval":          {Inputs: []string{"minutes"}, Hint: wski18n.ID_MSG_FEED_HINT_ALARM_INTERVAL},
+	"cloudant/changes":         {Inputs: []string{"dbname"}, Hint: wski18n.ID_MSG_FEED_HINT_CLOUDANT_DBNAME},
+	"messaging/messageHubFeed": {Inputs: []string{"topic"}, Hint: wski18n.ID_MSG_FEED_HINT_MESSAGING_TOPIC},
+	"github/webhook": {Inputs: []string{"username", "repository", "accessToken", "events"},
+		Hint: wski18n.ID_MSG_FEED_HINT_GITHUB_WEBHOOK},
+}
+
+// GetFeedProvider returns the feed provider of a feed, either a feed of
+// /whisk.system, e.g. /whisk.system/alarms/alarm, or a feed of a binding of one
+// of its packages, e.g. myalarms/alarm, given the locations of the bindings by
+// name. The name of the feed is returned along with it, nil if the feed is
+// not known.
+func GetFeedProvider(feed string, bindings map[string]string) (string, *FeedProvider) {
+	parts := strings.Split(strings.TrimPrefix(feed, "/"), "/")
+	qualified := strings.HasPrefix(feed, "/")
+	switch {
+	case qualified && len(parts) == 3 && parts[0] == WHISK_SYSTEM_NAMESPACE:
+		parts = parts[1:]
+	case !qualified && len(parts) == 2:
+		location, ok := bindings[parts[0]]
+		if !ok {
+			return "", nil
+		}
+		bound := strings.Split(strings.TrimPrefix(location, "/"), "/")
+		if len(bound) != 2 || bound[0] != WHISK_SYSTEM_NAMESPACE {
+			return "", nil
+		}
+		parts[0] = bound[1]
+	default:
+		return "", nil
+	}
+	name := parts[0] + "/" + parts[1]
+	if provider, ok := FeedProviders[name]; ok {
+		return name, &provider
+	}
+	return "", nil
+}
@@ -0,0 +1,43 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFeedProvider(t *testing.T) {
+	bindings := map[string]string{"myalarms": "/whisk.system/alarms", "mine": "/guest/alarms"}
+
+	name, provider := GetFeedProvider("/whisk.system/alarms/alarm", bindings)
+	assert.Equal(t, "alarms/alarm", name)
+	assert.Equal(t, []string{"cron"}, provider.Inputs)
+	name, provider = GetFeedProvider("myalarms/interval", bindings)
+	assert.Equal(t, "alarms/interval", name)
+	assert.Equal(t, []string{"minutes"}, provider.Inputs)
+
+	// packages of other namespaces are not known
+	for _, feed := range []string{"mine/alarm", "/guest/alarms/alarm", "other/alarm", "/whisk.system/alarms/unknown"} {
+		_, provider = GetFeedProvider(feed, bindings)
+		assert.Nil(t, provider, feed)
+	}
+}
@@ -22,6 +22,8 @@ import (
 	"fmt"
 	"os"
 	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
 )
 
 func MayExists(file string) bool {
@@ -71,7 +73,8 @@ func ReadProps(path string) (map[string]string, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		// If file does not exist, just return props
-		fmt.Printf("Warning: Unable to read whisk properties file '%s' (file open error: %s)\n", path, err)
+		fmt.Println(wski18n.T(wski18n.ID_WARN_WHISK_PROPS_READ_X_path_X_err_X,
+			map[string]interface{}{wski18n.KEY_PATH: path, wski18n.KEY_ERR: err.Error()}))
 		return props, err
 	}
 	defer file.Close()
@@ -99,7 +102,8 @@ func ReadProps(path string) (map[string]string, error) {
 func WriteProps(path string, props map[string]string) error {
 	file, err := os.Create(path)
 	if err != nil {
-		fmt.Printf("Warning: Unable to create whisk properties file '%s' (file create error: %s)\n", path, err)
+		fmt.Println(wski18n.T(wski18n.ID_WARN_WHISK_PROPS_CREATE_X_path_X_err_X,
+			map[string]interface{}{wski18n.KEY_PATH: path, wski18n.KEY_ERR: err.Error()}))
 		return err
 	}
 	defer file.Close()
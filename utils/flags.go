@@ -17,25 +17,79 @@
 
 package utils
 
-var Flags struct {
-	WithinOpenWhisk bool   // is this running within an OpenWhisk action?
-	ApiHost         string // OpenWhisk API host
-	Auth            string // OpenWhisk API key
-	Namespace       string
-	ApiVersion      string // OpenWhisk version
-	CfgFile         string
-	CliVersion      string
-	CliBuild        string
-	Verbose         bool
-	ProjectPath     string
-	DeploymentPath  string
-	ManifestPath    string
-	UseDefaults     bool
-	UseInteractive  bool
-	Strict          bool   // strict flag to support user defined runtime version.
-	Key		string
-	Cert		string
-	Managed 	bool   // OpenWhisk Managed Deployments
+import "time"
+
+// value of --snapshot given without a file, the snapshot of the project path
+const SNAPSHOT_DEFAULT = "last-deployed"
+
+// WskdeployFlags are the flags of a deployment, set from the command line
+// in Flags or from the ProjectConfig of a project deployed as a library
+type WskdeployFlags struct {
+	WithinOpenWhisk       bool   // is this running within an OpenWhisk action?
+	ApiHost               string // OpenWhisk API host
+	Auth                  string // OpenWhisk API key
+	Namespace             string
+	ApiVersion            string // OpenWhisk version
+	CfgFile               string
+	CliVersion            string
+	CliBuild              string
+	Verbose               bool
+	ProjectPath           string
+	DeploymentPath        string
+	ManifestPath          string
+	UseDefaults           bool
+	UseInteractive        bool
+	Strict                bool // strict flag to support user defined runtime version.
+	Key                   string
+	Cert                  string
+	Managed               bool          // OpenWhisk Managed Deployments
+	Resume                bool          // resume a failed deployment from its checkpoint
+	EnvFile               string        // .env file of variables used for interpolation
+	Env                   string        // environment whose .env.<env> file is loaded after the .env file
+	Parallel              int           // number of actions deployed concurrently
+	ParallelFetches       int           // number of dependencies fetched concurrently
+	RateLimit             float64       // requests per second sent to the OpenWhisk server, no limit if 0, see deployers.Throttle
+	Burst                 int           // requests sent at once within the rate limit, the rate rounded up if 0
+	MaxConcurrentRequests int           // requests in flight per namespace, no limit if 0
+	ReuseDependencies     bool          // dependencies already deployed from the same location and version are not deployed again
+	EntityTimeout         time.Duration // time allowed to deploy an entity, no limit if 0
+	ContinueOnError       bool          // deploy the other entities when an entity fails
+	Profile               string        // profile of the credentials, replaces .wskprops
+	ApigwAccessToken      string        // API gateway access token
+	ApigwHost             string        // API gateway host, if not the OpenWhisk API host
+	IamApiKey             string        // IBM Cloud IAM API key exchanged for the API gateway access token
+	LicenseAllowList      string        // file or URL of the licenses allowed for packages
+	Packages              []string      // names or globs of the packages deployed, all packages if empty
+	ExcludePackages       []string      // names or globs of the packages left out
+	ProjectName           string        // project deployed when the manifest defines several projects
+	ReportTemplate        string        // Go text/template the deployment is reported with
+	ReportOutput          string        // file of the report, the standard output if empty
+	Scanner               string        // command run against the code of each action before it is deployed
+	SecretsFile           string        // .env file of variables whose values are masked in the output
+	SecretsFromEnv        bool          // secret inputs may only be set from variables
+	OutputsFile           string        // JSON file the values known once the project is deployed are written to
+	ResultsFile           string        // JUnit report of the entities deployed, see deployers.EntityResults
+	OverrideTarget        bool          // the project is deployed even if the credentials do not match its expected-target
+	SkipPreflight         bool          // the API host is not checked before the project is deployed
+	Preview               bool          // the entities are written as YAML rather than deployed
+	AllowDepSideEffects   bool          // dependencies may deploy triggers, rules, APIs, plugins and hooks
+	MaxCodeSize           int64         // size of the code of an action, base64 encoded if binary, in bytes, see ReadActionCode()
+	History               bool          // the entities deployed are recorded in the history of the project, see deployers.HISTORY_FILE_NAME
+	NamingConventions     string        // file or URL of the patterns the names of entities must match, see ReadNamingConventions()
+	ImmutableVersions     bool          // a version of a package may not be deployed again with another content
+	BuildImage            string        // docker image the dependencies of actions are built in, the local tools are used if empty
+	ErrorFormat           string        // format errors are printed in, text or json, see wskderrors.ErrorReport
+	Overlays              []string      // YAML files merged over the manifest, in order, see parsers.ApplyManifestOverlays()
+	Set                   []string      // values set at paths of the manifest, e.g. packages.hello.actions.world.limits.memorySize=512
+	Params                []string      // parameters set on entities once the project is bound, e.g. hello/greeting.name=Bernie
+	Annotations           []string      // annotations set on entities once the project is bound, e.g. hello/greeting.final=true
+	DeployAs              string        // suffix of the packages and triggers deployed side by side with the live ones, see ApplyDeployAs()
+	Rollback              bool          // the rules and APIs are switched back to the previous --deploy-as
+	Tail                  bool          // the activations of the actions are printed once the project is deployed, see TailActivations()
+	Provider              string        // name or file of the distribution of OpenWhisk deployed to, see ReadProvider()
+	EncryptionProvider    string        // provider the inputs declared encrypted are encrypted by, see EncryptInput()
+	AllowEmpty            bool          // manifests without packages and packages without entities are deployed rather than refused
+	Snapshot              string        // snapshot of the entities of the last deployment undeployed instead of the manifest, see deployers.DeploymentSnapshot
 
 	//action flag definition
 	//from go cli
@@ -53,3 +107,5 @@ var Flags struct {
 		main     string
 	}
 }
+
+var Flags WskdeployFlags
@@ -30,6 +30,9 @@ import (
 	"io/ioutil"
 )
 
+// number of dependencies fetched concurrently, see --parallel-fetches
+const DEFAULT_PARALLEL_FETCHES = 4
+
 type GitReader struct {
 	Name string // the name of the dependency
 	Url  string // pkg repo location, e.g. github.com/user/repo
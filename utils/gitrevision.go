@@ -0,0 +1,52 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// length of the abbreviated commits, as printed by git
+const GIT_SHORT_COMMIT_LENGTH = 7
+
+// GetGitRevision returns the commit and branch checked out in the git
+// repository of the directory, empty if git is not installed or the directory
+// is not part of a repository. The branch is empty when no branch is checked
+// out, e.g. a tag in a CI pipeline.
+func GetGitRevision(dir string) (string, string) {
+	commit := runGit(dir, "rev-parse", "HEAD")
+	if len(commit) == 0 {
+		return "", ""
+	}
+	branch := runGit(dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if branch == "HEAD" {
+		branch = ""
+	}
+	return commit, branch
+}
+
+func runGit(dir string, args ...string) string {
+	command := exec.Command("git", args...)
+	command.Dir = dir
+	output, err := command.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
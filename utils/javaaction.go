@@ -0,0 +1,111 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"archive/zip"
+	"bufio"
+	"os/exec"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// manifest of a jar file and its attribute naming the class run by "java -jar"
+const (
+	JAR_MANIFEST_FILE       = "META-INF/MANIFEST.MF"
+	JAR_MANIFEST_MAIN_CLASS = "Main-Class"
+)
+
+// the main entry of a Java action may name the method of its class, e.g.
+// com.example.Hello#run
+const JAVA_MAIN_METHOD_SEPARATOR = "#"
+
+// RunBuildCommand runs the build command of an action, e.g. "./gradlew jar",
+// in the directory of the manifest, so that the code of the action is built
+// before it is read. The output of the command is printed with --verbose.
+func RunBuildCommand(action string, command string, dir string) error {
+	args := strings.Fields(command)
+	if len(args) == 0 {
+		return nil
+	}
+	wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_ACTION_BUILD_X_action_X_command_X,
+		map[string]interface{}{wski18n.KEY_ACTION: action, wski18n.KEY_COMMAND: command}))
+
+	build := exec.Command(args[0], args[1:]...)
+	build.Dir = dir
+	output, err := build.CombinedOutput()
+	if err != nil {
+		return wskderrors.NewCommandError(command, wski18n.T(wski18n.ID_ERR_ACTION_BUILD_X_action_X_command_X_err_X_output_X,
+			map[string]interface{}{wski18n.KEY_ACTION: action, wski18n.KEY_COMMAND: command,
+				wski18n.KEY_ERR: err.Error(), wski18n.KEY_OUTPUT: strings.TrimSpace(string(output))}))
+	}
+	whisk.Debug(whisk.DbgInfo, string(output))
+	return nil
+}
+
+// ReadJarClasses returns the classes of the jar file by their fully qualified
+// name, e.g. com.example.Hello, and the Main-Class of its manifest, if any
+func ReadJarClasses(jarPath string) (map[string]bool, string, error) {
+	reader, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return nil, "", wskderrors.NewFileReadError(jarPath, err.Error())
+	}
+	defer reader.Close()
+
+	classes := make(map[string]bool)
+	mainClass := ""
+	for _, file := range reader.File {
+		if strings.HasSuffix(file.Name, ".class") {
+			classes[strings.Replace(strings.TrimSuffix(file.Name, ".class"), "/", ".", -1)] = true
+			continue
+		}
+		if file.Name != JAR_MANIFEST_FILE {
+			continue
+		}
+		if mainClass, err = readJarMainClass(file); err != nil {
+			return nil, "", wskderrors.NewFileReadError(jarPath, err.Error())
+		}
+	}
+	return classes, mainClass, nil
+}
+
+func readJarMainClass(file *zip.File) (string, error) {
+	manifest, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer manifest.Close()
+
+	scanner := bufio.NewScanner(manifest)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, JAR_MANIFEST_MAIN_CLASS+":") {
+			return strings.TrimSpace(strings.TrimPrefix(line, JAR_MANIFEST_MAIN_CLASS+":")), nil
+		}
+	}
+	return "", scanner.Err()
+}
+
+// JavaMainClass returns the class of the main entry of a Java action
+func JavaMainClass(main string) string {
+	return strings.SplitN(main, JAVA_MAIN_METHOD_SEPARATOR, 2)[0]
+}
@@ -0,0 +1,83 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunBuildCommand(t *testing.T) {
+	dir, err := ioutil.TempDir("", "build")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	script := filepath.Join(dir, "build.sh")
+	assert.Nil(t, ioutil.WriteFile(script, []byte("#!/bin/sh\necho built > \"$1\"\n"), 0755))
+	assert.Nil(t, RunBuildCommand("hello/world", script+" hello.jar", dir))
+	assert.True(t, FileExists(filepath.Join(dir, "hello.jar")), "the command is run in the directory")
+
+	assert.Nil(t, ioutil.WriteFile(script, []byte("#!/bin/sh\necho 'compilation failed'\nexit 1\n"), 0755))
+	err = RunBuildCommand("hello/world", script, dir)
+	assert.IsType(t, &wskderrors.CommandError{}, err)
+	assert.Contains(t, err.Error(), "compilation failed")
+}
+
+func TestReadJarClasses(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jar")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	jarPath := filepath.Join(dir, "hello.jar")
+	file, err := os.Create(jarPath)
+	assert.Nil(t, err)
+	jar := zip.NewWriter(file)
+	for name, content := range map[string]string{
+		JAR_MANIFEST_FILE:              "Manifest-Version: 1.0\r\nMain-Class: com.example.Hello\r\n",
+		"com/example/Hello.class":      "",
+		"com/example/util/Greet.class": "",
+		"messages.properties":          "greeting=Hello",
+	} {
+		w, err := jar.Create(name)
+		assert.Nil(t, err)
+		w.Write([]byte(content))
+	}
+	assert.Nil(t, jar.Close())
+	assert.Nil(t, file.Close())
+
+	classes, mainClass, err := ReadJarClasses(jarPath)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]bool{"com.example.Hello": true, "com.example.util.Greet": true}, classes)
+	assert.Equal(t, "com.example.Hello", mainClass)
+
+	_, _, err = ReadJarClasses(filepath.Join(dir, "missing.jar"))
+	assert.IsType(t, &wskderrors.FileReadError{}, err)
+}
+
+func TestJavaMainClass(t *testing.T) {
+	assert.Equal(t, "com.example.Hello", JavaMainClass("com.example.Hello"))
+	assert.Equal(t, "com.example.Hello", JavaMainClass("com.example.Hello#run"))
+}
@@ -0,0 +1,88 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// An auth key of the form keychain:<account>, e.g. AUTH=keychain:default in
+// .wskprops, is read from the keychain of the OS: the macOS Keychain, the
+// Windows Credential Manager or the secret service of Linux (secret-tool), as
+// the secret of the service wskdeploy and the given account
+const (
+	KEYCHAIN_PREFIX          = "keychain:"
+	KEYCHAIN_SERVICE         = "wskdeploy"
+	KEYCHAIN_DEFAULT_ACCOUNT = "default"
+)
+
+// KeychainAccount returns the account of an auth key read from the keychain,
+// "default" if it is not given, and false if the auth key is not of the form
+// keychain:<account>
+func KeychainAccount(auth string) (string, bool) {
+	if !strings.HasPrefix(auth, KEYCHAIN_PREFIX) {
+		return "", false
+	}
+	account := strings.TrimSpace(strings.TrimPrefix(auth, KEYCHAIN_PREFIX))
+	if len(account) == 0 {
+		account = KEYCHAIN_DEFAULT_ACCOUNT
+	}
+	return account, true
+}
+
+// ReadKeychain returns the auth key stored in the keychain for the account
+func ReadKeychain(account string) (string, error) {
+	secret, err := readKeychain(account)
+	if err == nil && len(secret) == 0 {
+		err = errors.New(wski18n.T(wski18n.ID_ERR_KEYCHAIN_EMPTY))
+	}
+	if err != nil {
+		return "", keychainError(wski18n.ID_ERR_KEYCHAIN_READ_X_account_X_err_X, account, err)
+	}
+	return secret, nil
+}
+
+// WriteKeychain stores the auth key in the keychain for the account, replacing
+// the one stored before, if any
+func WriteKeychain(account string, secret string) error {
+	err := errors.New(wski18n.T(wski18n.ID_ERR_KEYCHAIN_EMPTY))
+	if len(secret) > 0 {
+		err = writeKeychain(account, secret)
+	}
+	if err != nil {
+		return keychainError(wski18n.ID_ERR_KEYCHAIN_WRITE_X_account_X_err_X, account, err)
+	}
+	return nil
+}
+
+// DeleteKeychain removes the auth key stored in the keychain for the account
+func DeleteKeychain(account string) error {
+	if err := deleteKeychain(account); err != nil {
+		return keychainError(wski18n.ID_ERR_KEYCHAIN_DELETE_X_account_X_err_X, account, err)
+	}
+	return nil
+}
+
+func keychainError(id string, account string, err error) error {
+	return wskderrors.NewCommandError(KEYCHAIN_PREFIX+account, wski18n.T(id,
+		map[string]interface{}{wski18n.KEY_ACCOUNT: account, wski18n.KEY_ERR: err.Error()}))
+}
@@ -0,0 +1,69 @@
+// +build unit,!windows
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeychainAccount(t *testing.T) {
+	account, ok := KeychainAccount("keychain:staging")
+	assert.True(t, ok)
+	assert.Equal(t, "staging", account)
+	account, ok = KeychainAccount("keychain:")
+	assert.True(t, ok)
+	assert.Equal(t, KEYCHAIN_DEFAULT_ACCOUNT, account)
+	_, ok = KeychainAccount("23bc46b1-71f6-4ed5-8c54-816aa4f8c502:123zO3xZCLrMN6v2BKK1dXYFpXlPkccOFqm12CdAsMgRU4VrNZ9lyGVCGuMDGIwP")
+	assert.False(t, ok)
+}
+
+func TestKeychain(t *testing.T) {
+	defer func(run func(string, string, ...string) (string, error)) { runKeychainCommand = run }(runKeychainCommand)
+	var inputs, commands []string
+	runKeychainCommand = func(input string, name string, args ...string) (string, error) {
+		inputs = append(inputs, input)
+		commands = append(commands, name+" "+strings.Join(args, " "))
+		return "uuid:key", nil
+	}
+
+	secret, err := ReadKeychain("staging")
+	assert.Nil(t, err)
+	assert.Equal(t, "uuid:key", secret)
+	assert.True(t, strings.Contains(commands[0], KEYCHAIN_SERVICE))
+	assert.True(t, strings.Contains(commands[0], "staging"))
+
+	// the secret is not part of the command line
+	assert.Nil(t, WriteKeychain("staging", "uuid:secret"))
+	assert.True(t, strings.Contains(inputs[1], "uuid:secret"))
+	assert.False(t, strings.Contains(commands[1], "uuid:secret"))
+	assert.NotNil(t, WriteKeychain("staging", ""))
+	assert.Equal(t, 2, len(commands))
+
+	runKeychainCommand = func(input string, name string, args ...string) (string, error) {
+		return "", errors.New("The specified item could not be found in the keychain.")
+	}
+	_, err = ReadKeychain("staging")
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "wskdeploy keychain set staging"))
+}
@@ -0,0 +1,86 @@
+// +build !windows
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// the commands of the keychains, security on macOS and secret-tool of
+// libsecret on Linux
+const (
+	KEYCHAIN_COMMAND_MACOS = "security"
+	KEYCHAIN_COMMAND_LINUX = "secret-tool"
+)
+
+// runKeychainCommand runs the command with the input on its standard input
+// and returns its output, or its error output as the error
+var runKeychainCommand = func(input string, name string, args ...string) (string, error) {
+	command := exec.Command(name, args...)
+	command.Stdin = strings.NewReader(input)
+	var stdout, stderr bytes.Buffer
+	command.Stdout, command.Stderr = &stdout, &stderr
+	if err := command.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); len(message) > 0 {
+			return "", errors.New(message)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+func readKeychain(account string) (string, error) {
+	if runtime.GOOS == "darwin" {
+		return runKeychainCommand("", KEYCHAIN_COMMAND_MACOS, "find-generic-password",
+			"-s", KEYCHAIN_SERVICE, "-a", account, "-w")
+	}
+	return runKeychainCommand("", KEYCHAIN_COMMAND_LINUX, "lookup", "service", KEYCHAIN_SERVICE, "account", account)
+}
+
+// writeKeychain passes the secret on the standard input of the command, so
+// that it is not part of the command line other processes may read
+func writeKeychain(account string, secret string) error {
+	var err error
+	if runtime.GOOS == "darwin" {
+		// security -i reads the commands from its standard input
+		_, err = runKeychainCommand("add-generic-password -U -s "+KEYCHAIN_SERVICE+" -a "+strconv.Quote(account)+
+			" -w "+strconv.Quote(secret)+"\n", KEYCHAIN_COMMAND_MACOS, "-i")
+	} else {
+		_, err = runKeychainCommand(secret, KEYCHAIN_COMMAND_LINUX, "store", "--label", KEYCHAIN_SERVICE+" "+account,
+			"service", KEYCHAIN_SERVICE, "account", account)
+	}
+	return err
+}
+
+func deleteKeychain(account string) error {
+	var err error
+	if runtime.GOOS == "darwin" {
+		_, err = runKeychainCommand("", KEYCHAIN_COMMAND_MACOS, "delete-generic-password",
+			"-s", KEYCHAIN_SERVICE, "-a", account)
+	} else {
+		_, err = runKeychainCommand("", KEYCHAIN_COMMAND_LINUX, "clear", "service", KEYCHAIN_SERVICE, "account", account)
+	}
+	return err
+}
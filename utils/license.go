@@ -0,0 +1,256 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// operators of SPDX license expressions
+const (
+	LICENSE_OPERATOR_AND  = "AND"
+	LICENSE_OPERATOR_OR   = "OR"
+	LICENSE_OPERATOR_WITH = "WITH"
+	// prefixes of license identifiers which are not in the SPDX list
+	LICENSE_REF_PREFIX     = "LicenseRef-"
+	LICENSE_DOC_REF_PREFIX = "DocumentRef-"
+)
+
+// LicenseExpression is a parsed SPDX license expression, e.g.
+// "MIT OR (Apache-2.0 AND BSD-3-Clause)" or "GPL-2.0+ WITH Classpath-exception-2.0".
+// A leaf of the expression is a license, with its exception if any.
+type LicenseExpression struct {
+	License   string
+	Exception string
+	Operator  string // AND or OR, empty for a license
+	Left      *LicenseExpression
+	Right     *LicenseExpression
+}
+
+// ParseLicenseExpression parses an SPDX license expression, AND takes
+// precedence over OR and operators are case insensitive
+func ParseLicenseExpression(expression string) (*LicenseExpression, error) {
+	parser := &licenseParser{tokens: tokenizeLicenseExpression(expression)}
+	if len(parser.tokens) == 0 {
+		return nil, errors.New(wski18n.T(wski18n.ID_ERR_LICENSE_EXPRESSION_EMPTY))
+	}
+	result, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if parser.pos < len(parser.tokens) {
+		return nil, errors.New(wski18n.T(wski18n.ID_ERR_LICENSE_EXPRESSION_UNEXPECTED_X_value_X,
+			map[string]interface{}{wski18n.KEY_VALUE: parser.tokens[parser.pos]}))
+	}
+	return result, nil
+}
+
+// Licenses returns the licenses of the expression, without their exceptions
+func (expression *LicenseExpression) Licenses() []string {
+	if len(expression.Operator) == 0 {
+		return []string{expression.License}
+	}
+	return append(expression.Left.Licenses(), expression.Right.Licenses()...)
+}
+
+// Satisfies returns true if the licenses accepted satisfy the expression, i.e.
+// both sides of an AND and either side of an OR are accepted
+func (expression *LicenseExpression) Satisfies(accept func(license string) bool) bool {
+	switch expression.Operator {
+	case LICENSE_OPERATOR_AND:
+		return expression.Left.Satisfies(accept) && expression.Right.Satisfies(accept)
+	case LICENSE_OPERATOR_OR:
+		return expression.Left.Satisfies(accept) || expression.Right.Satisfies(accept)
+	}
+	return accept(expression.License)
+}
+
+func tokenizeLicenseExpression(expression string) []string {
+	expression = strings.Replace(expression, "(", " ( ", -1)
+	expression = strings.Replace(expression, ")", " ) ", -1)
+	return strings.Fields(expression)
+}
+
+type licenseParser struct {
+	tokens []string
+	pos    int
+}
+
+func (parser *licenseParser) next() string {
+	if parser.pos < len(parser.tokens) {
+		return parser.tokens[parser.pos]
+	}
+	return ""
+}
+
+func (parser *licenseParser) isOperator(operator string) bool {
+	return strings.ToUpper(parser.next()) == operator
+}
+
+func (parser *licenseParser) parseOr() (*LicenseExpression, error) {
+	return parser.parseBinary(LICENSE_OPERATOR_OR, parser.parseAnd)
+}
+
+func (parser *licenseParser) parseAnd() (*LicenseExpression, error) {
+	return parser.parseBinary(LICENSE_OPERATOR_AND, parser.parseTerm)
+}
+
+func (parser *licenseParser) parseBinary(operator string, operand func() (*LicenseExpression, error)) (*LicenseExpression, error) {
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for parser.isOperator(operator) {
+		parser.pos++
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = &LicenseExpression{Operator: operator, Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (parser *licenseParser) parseTerm() (*LicenseExpression, error) {
+	token := parser.next()
+	switch {
+	case len(token) == 0:
+		return nil, errors.New(wski18n.T(wski18n.ID_ERR_LICENSE_EXPRESSION_MISSING_LICENSE))
+	case token == "(":
+		parser.pos++
+		expression, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if parser.next() != ")" {
+			return nil, errors.New(wski18n.T(wski18n.ID_ERR_LICENSE_EXPRESSION_MISSING_PARENTHESIS))
+		}
+		parser.pos++
+		return expression, nil
+	case token == ")" || parser.isOperator(LICENSE_OPERATOR_AND) ||
+		parser.isOperator(LICENSE_OPERATOR_OR) || parser.isOperator(LICENSE_OPERATOR_WITH):
+		return nil, errors.New(wski18n.T(wski18n.ID_ERR_LICENSE_EXPRESSION_UNEXPECTED_X_value_X,
+			map[string]interface{}{wski18n.KEY_VALUE: token}))
+	}
+
+	parser.pos++
+	expression := &LicenseExpression{License: token}
+	if parser.isOperator(LICENSE_OPERATOR_WITH) {
+		parser.pos++
+		exception := parser.next()
+		if len(exception) == 0 || exception == "(" || exception == ")" {
+			return nil, errors.New(wski18n.T(wski18n.ID_ERR_LICENSE_EXPRESSION_MISSING_EXCEPTION_X_value_X,
+				map[string]interface{}{wski18n.KEY_VALUE: token + " " + LICENSE_OPERATOR_WITH}))
+		}
+		parser.pos++
+		expression.Exception = exception
+	}
+	return expression, nil
+}
+
+// IsLicenseIdentifier returns true if the license is in the SPDX license list,
+// or is a custom license (LicenseRef-), "or later" versions are accepted as
+// well, e.g. GPL-2.0+
+func IsLicenseIdentifier(license string) bool {
+	if strings.HasPrefix(license, LICENSE_REF_PREFIX) || strings.HasPrefix(license, LICENSE_DOC_REF_PREFIX) {
+		return true
+	}
+	for _, id := range []string{license, strings.TrimSuffix(license, "+")} {
+		if len(id) > 0 && (LicenseLocalValidation(id) || LicenseRemoteValidation(id)) {
+			return true
+		}
+	}
+	return false
+}
+
+var licenseAllowList struct {
+	sync.Mutex
+	path     string
+	licenses map[string]bool
+}
+
+// ReadLicenseAllowList reads the licenses allowed by an organization, one SPDX
+// identifier per line, lines starting with # are comments. The allow-list is
+// read from a file or a URL.
+func ReadLicenseAllowList(path string) (map[string]bool, error) {
+	content, err := Read(path)
+	if err != nil {
+		return nil, wskderrors.NewFileReadError(path, err.Error())
+	}
+	licenses := make(map[string]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		licenses[strings.ToUpper(line)] = true
+	}
+	return licenses, nil
+}
+
+// getLicenseAllowList returns the allow-list given by --license-allowlist, it
+// is read once, nil if there is no allow-list
+func (flags *WskdeployFlags) getLicenseAllowList() (map[string]bool, error) {
+	licenseAllowList.Lock()
+	defer licenseAllowList.Unlock()
+	if len(flags.LicenseAllowList) == 0 {
+		return nil, nil
+	}
+	if licenseAllowList.path != flags.LicenseAllowList {
+		licenses, err := ReadLicenseAllowList(flags.LicenseAllowList)
+		if err != nil {
+			return nil, err
+		}
+		licenseAllowList.path, licenseAllowList.licenses = flags.LicenseAllowList, licenses
+	}
+	return licenseAllowList.licenses, nil
+}
+
+// ValidateLicense checks that the license is a valid SPDX license identifier
+// or expression, and that it is allowed by the allow-list of the organization
+// if there is one
+func (flags *WskdeployFlags) ValidateLicense(license string) error {
+	expression, err := ParseLicenseExpression(license)
+	if err != nil {
+		return errors.New(wski18n.T(wski18n.ID_ERR_LICENSE_INVALID_X_license_X_err_X,
+			map[string]interface{}{wski18n.KEY_LICENSE: license, wski18n.KEY_ERR: err.Error()}))
+	}
+	for _, id := range expression.Licenses() {
+		if !IsLicenseIdentifier(id) {
+			return errors.New(wski18n.T(wski18n.ID_ERR_LICENSE_INVALID_X_license_X_err_X,
+				map[string]interface{}{wski18n.KEY_LICENSE: license,
+					wski18n.KEY_ERR: wski18n.T(wski18n.ID_ERR_LICENSE_UNKNOWN_X_license_X,
+						map[string]interface{}{wski18n.KEY_LICENSE: id})}))
+		}
+	}
+
+	allowed, err := flags.getLicenseAllowList()
+	if err != nil {
+		return err
+	}
+	if allowed != nil && !expression.Satisfies(func(id string) bool { return allowed[strings.ToUpper(id)] }) {
+		return errors.New(wski18n.T(wski18n.ID_ERR_LICENSE_NOT_ALLOWED_X_license_X_path_X,
+			map[string]interface{}{wski18n.KEY_LICENSE: license, wski18n.KEY_PATH: flags.LicenseAllowList}))
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLicenseExpression(t *testing.T) {
+	expression, err := ParseLicenseExpression("MIT or (Apache-2.0 AND BSD-3-Clause)")
+	assert.Nil(t, err)
+	assert.Equal(t, LICENSE_OPERATOR_OR, expression.Operator)
+	assert.Equal(t, []string{"MIT", "Apache-2.0", "BSD-3-Clause"}, expression.Licenses())
+
+	// AND takes precedence over OR
+	expression, err = ParseLicenseExpression("MIT AND Apache-2.0 OR GPL-2.0+ WITH Classpath-exception-2.0")
+	assert.Nil(t, err)
+	assert.Equal(t, LICENSE_OPERATOR_OR, expression.Operator)
+	assert.Equal(t, LICENSE_OPERATOR_AND, expression.Left.Operator)
+	assert.Equal(t, "GPL-2.0+", expression.Right.License)
+	assert.Equal(t, "Classpath-exception-2.0", expression.Right.Exception)
+
+	for _, invalid := range []string{"", "MIT AND", "(MIT", "MIT)", "OR MIT", "MIT Apache-2.0", "GPL-2.0 WITH"} {
+		_, err = ParseLicenseExpression(invalid)
+		assert.NotNil(t, err, invalid)
+	}
+}
+
+func TestValidateLicense(t *testing.T) {
+	// the remote license list is not fetched
+	defer func(licenses LicenseJSON) { license_json = licenses }(license_json)
+	license_json = LicenseJSON{Licenses: []LicenseItem{{LicenseID: "Zlib"}}}
+	flags := &WskdeployFlags{}
+
+	for _, valid := range []string{"Apache-2.0", "zlib", "MIT OR Apache-2.0", "GPL-2.0+", "LicenseRef-Proprietary"} {
+		assert.Nil(t, flags.ValidateLicense(valid), valid)
+	}
+	for _, invalid := range []string{"---", "MIT OR Unknown-1.0", "MIT AND"} {
+		assert.NotNil(t, flags.ValidateLicense(invalid), invalid)
+	}
+	assert.True(t, CheckLicense("MIT"))
+	assert.False(t, CheckLicense("Unknown-1.0"))
+
+	allowList, err := ioutil.TempFile("", "licenses")
+	assert.Nil(t, err)
+	defer os.Remove(allowList.Name())
+	allowList.WriteString("# licenses approved by legal\nApache-2.0\nmit\n\n")
+	allowList.Close()
+
+	flags.LicenseAllowList = allowList.Name()
+	assert.Nil(t, flags.ValidateLicense("MIT"))
+	assert.Nil(t, flags.ValidateLicense("Zlib OR Apache-2.0"))
+	assert.NotNil(t, flags.ValidateLicense("Zlib"))
+	assert.NotNil(t, flags.ValidateLicense("MIT AND Zlib"))
+
+	flags.LicenseAllowList = allowList.Name() + ".missing"
+	assert.NotNil(t, flags.ValidateLicense("MIT"))
+}
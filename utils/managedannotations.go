@@ -19,9 +19,12 @@ package utils
 
 import (
 	"crypto/sha1"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
+
 	"github.com/apache/incubator-openwhisk-client-go/whisk"
 )
 
@@ -32,6 +35,11 @@ import (
  * 	__OW__PROJECT__NAME: MyProject
  *	__OW__PROJECT_HASH: SHA1("OpenWhisk " + <size_of_manifest_file> + "\0" + <contents_of_manifest_file>)
  *	__OW__FILE: Absolute path of manifest file on file system
+ *	__OW_OWNER, __OW_CONTACT: owner and contact of the project, if set in the manifest
+ *	__OW_PROJECT_VERSION: version of the project, if set in the manifest
+ *	__OW_GIT_COMMIT, __OW_GIT_BRANCH: revision of the git repository of the manifest, if any
+ *	__OW_MANIFEST_SHA256, __OW_DEPLOYMENT_SHA256: SHA-256 of the manifest and deployment files, if any
+ *	__OW_WSKDEPLOY_VERSION: version of wskdeploy which deployed the project
 */
 
 const (
@@ -40,6 +48,14 @@ const (
 	NULL      = "golang\000"
 	OW_PROJECT_NAME = "__OW_PROJECT_NAME"
 	OW_PROJECT_HASH = "__OW_PROJECT_HASH"
+	OW_OWNER        = "__OW_OWNER"
+	OW_CONTACT      = "__OW_CONTACT"
+	OW_PROJECT_VERSION = "__OW_PROJECT_VERSION"
+	OW_GIT_COMMIT      = "__OW_GIT_COMMIT"
+	OW_GIT_BRANCH      = "__OW_GIT_BRANCH"
+	OW_MANIFEST_SHA256   = "__OW_MANIFEST_SHA256"
+	OW_DEPLOYMENT_SHA256 = "__OW_DEPLOYMENT_SHA256"
+	OW_WSKDEPLOY_VERSION = "__OW_WSKDEPLOY_VERSION"
 
 )
 
@@ -47,6 +63,54 @@ type ManagedAnnotation struct {
 	ProjectName string `json:"__OW_PROJECT_NAME"`
 	ProjectHash string `json:"__OW_PROJECT_HASH"`
 	File        string `json:"__OW_FILE"`
+	Owner       string `json:"__OW_OWNER,omitempty"`
+	Contact     string `json:"__OW_CONTACT,omitempty"`
+	ManagedRevision
+	ManagedProvenance
+}
+
+// ManagedRevision is the revision of a project stored in the managed
+// annotation, so that the revision live in a namespace is known
+type ManagedRevision struct {
+	Version string `json:"__OW_PROJECT_VERSION,omitempty"`
+	Commit  string `json:"__OW_GIT_COMMIT,omitempty"`
+	Branch  string `json:"__OW_GIT_BRANCH,omitempty"`
+}
+
+// ManagedProvenance is the checksums of the manifest and deployment files a
+// project was deployed from and the version of wskdeploy which deployed it,
+// stored in the managed annotation so that the files which produced what is
+// running are known
+type ManagedProvenance struct {
+	ManifestChecksum   string `json:"__OW_MANIFEST_SHA256,omitempty"`
+	DeploymentChecksum string `json:"__OW_DEPLOYMENT_SHA256,omitempty"`
+	WskdeployVersion   string `json:"__OW_WSKDEPLOY_VERSION,omitempty"`
+}
+
+// length of the checksums displayed
+const SHORT_CHECKSUM_LENGTH = 12
+
+// NewManagedProvenance returns the checksums of the manifest and deployment
+// files, the deployment file is optional
+func NewManagedProvenance(manifestPath string, deploymentPath string) (ManagedProvenance, error) {
+	provenance := ManagedProvenance{WskdeployVersion: GetWskdeployVersion()}
+	var err error
+	if strings.HasPrefix(manifestPath, "http") {
+		// the manifest of a URL is not saved
+		content, err := Read(manifestPath)
+		if err != nil {
+			return provenance, err
+		}
+		provenance.ManifestChecksum = fmt.Sprintf("%x", sha256.Sum256(content))
+	} else if provenance.ManifestChecksum, err = FileChecksum(manifestPath); err != nil {
+		return provenance, err
+	}
+	if len(deploymentPath) > 0 && FileExists(deploymentPath) {
+		if provenance.DeploymentChecksum, err = FileChecksum(deploymentPath); err != nil {
+			return provenance, err
+		}
+	}
+	return provenance, nil
 }
 
 // Project Hash is generated based on the following formula:
@@ -91,7 +155,10 @@ func generateProjectHash(filePath string) (string, error) {
 	return projectHash, nil
 }
 
-func GenerateManagedAnnotation(projectName string, filePath string) (whisk.KeyValue, error) {
+// GenerateManagedAnnotation creates the managed annotation of the entities of
+// a project, owner, contact and the fields of the revision and provenance are
+// left out if empty
+func GenerateManagedAnnotation(projectName string, owner string, contact string, revision ManagedRevision, provenance ManagedProvenance, filePath string) (whisk.KeyValue, error) {
 	projectHash, err := generateProjectHash(filePath)
 	managedAnnotation := whisk.KeyValue{}
 	if err != nil {
@@ -101,6 +168,10 @@ func GenerateManagedAnnotation(projectName string, filePath string) (whisk.KeyVa
 		ProjectName: projectName,
 		ProjectHash: projectHash,
 		File:        filePath,
+		Owner:       owner,
+		Contact:     contact,
+		ManagedRevision: revision,
+		ManagedProvenance: provenance,
 	}
 	ma, err := json.Marshal(m)
 	if err != nil {
@@ -111,3 +182,81 @@ func GenerateManagedAnnotation(projectName string, filePath string) (whisk.KeyVa
 	managedAnnotation = whisk.KeyValue{Key:MANAGED, Value:a.(map[string]interface{})}
 	return managedAnnotation, nil
 }
+
+// GetManagedOwner returns the owner and contact stored in the managed
+// annotation of an entity, if any
+func GetManagedOwner(annotations whisk.KeyValueArr) (string, string) {
+	managed, ok := annotations.GetValue(MANAGED).(map[string]interface{})
+	if !ok {
+		return "", ""
+	}
+	owner, _ := managed[OW_OWNER].(string)
+	contact, _ := managed[OW_CONTACT].(string)
+	return owner, contact
+}
+
+// GetManagedRevision returns the revision stored in the managed annotation of
+// an entity, its fields are empty if not stored
+func GetManagedRevision(annotations whisk.KeyValueArr) ManagedRevision {
+	managed, ok := annotations.GetValue(MANAGED).(map[string]interface{})
+	if !ok {
+		return ManagedRevision{}
+	}
+	var revision ManagedRevision
+	revision.Version, _ = managed[OW_PROJECT_VERSION].(string)
+	revision.Commit, _ = managed[OW_GIT_COMMIT].(string)
+	revision.Branch, _ = managed[OW_GIT_BRANCH].(string)
+	return revision
+}
+
+// String returns the revision as "<version> <commit> (<branch>)", with the
+// commit abbreviated and the fields which are empty left out
+func (revision ManagedRevision) String() string {
+	commit := revision.Commit
+	if len(commit) > GIT_SHORT_COMMIT_LENGTH {
+		commit = commit[:GIT_SHORT_COMMIT_LENGTH]
+	}
+	branch := revision.Branch
+	if len(branch) > 0 {
+		branch = "(" + branch + ")"
+	}
+	return strings.Join(strings.Fields(revision.Version+" "+commit+" "+branch), " ")
+}
+
+// GetManagedProvenance returns the provenance stored in the managed annotation
+// of an entity, its fields are empty if not stored
+func GetManagedProvenance(annotations whisk.KeyValueArr) ManagedProvenance {
+	managed, ok := annotations.GetValue(MANAGED).(map[string]interface{})
+	if !ok {
+		return ManagedProvenance{}
+	}
+	var provenance ManagedProvenance
+	provenance.ManifestChecksum, _ = managed[OW_MANIFEST_SHA256].(string)
+	provenance.DeploymentChecksum, _ = managed[OW_DEPLOYMENT_SHA256].(string)
+	provenance.WskdeployVersion, _ = managed[OW_WSKDEPLOY_VERSION].(string)
+	return provenance
+}
+
+// String returns the provenance as "manifest sha256:<checksum> deployment
+// sha256:<checksum> wskdeploy <version>", with the checksums abbreviated and
+// the fields which are empty left out
+func (provenance ManagedProvenance) String() string {
+	fields := make([]string, 0, 3)
+	if checksum := shortChecksum(provenance.ManifestChecksum); len(checksum) > 0 {
+		fields = append(fields, "manifest sha256:"+checksum)
+	}
+	if checksum := shortChecksum(provenance.DeploymentChecksum); len(checksum) > 0 {
+		fields = append(fields, "deployment sha256:"+checksum)
+	}
+	if len(provenance.WskdeployVersion) > 0 {
+		fields = append(fields, "wskdeploy "+provenance.WskdeployVersion)
+	}
+	return strings.Join(fields, " ")
+}
+
+func shortChecksum(checksum string) string {
+	if len(checksum) > SHORT_CHECKSUM_LENGTH {
+		return checksum[:SHORT_CHECKSUM_LENGTH]
+	}
+	return checksum
+}
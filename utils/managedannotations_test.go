@@ -0,0 +1,88 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateManagedAnnotation_Owner(t *testing.T) {
+	manifestFile := "../tests/dat/manifest_validate_typed_annotations.yaml"
+	ma, err := GenerateManagedAnnotation("helloworld", "jdoe", "jdoe@example.com", ManagedRevision{}, ManagedProvenance{}, manifestFile)
+	assert.Nil(t, err)
+	managed := ma.Value.(map[string]interface{})
+	assert.Equal(t, "helloworld", managed[OW_PROJECT_NAME])
+	assert.Equal(t, "jdoe", managed[OW_OWNER])
+	assert.Equal(t, "jdoe@example.com", managed[OW_CONTACT])
+
+	owner, contact := GetManagedOwner(whisk.KeyValueArr{ma})
+	assert.Equal(t, "jdoe", owner)
+	assert.Equal(t, "jdoe@example.com", contact)
+
+	// owner and contact are not stored if not set
+	ma, err = GenerateManagedAnnotation("helloworld", "", "", ManagedRevision{}, ManagedProvenance{}, manifestFile)
+	assert.Nil(t, err)
+	_, ok := ma.Value.(map[string]interface{})[OW_OWNER]
+	assert.False(t, ok)
+
+	owner, contact = GetManagedOwner(whisk.KeyValueArr{})
+	assert.Equal(t, "", owner)
+	assert.Equal(t, "", contact)
+}
+
+func TestGenerateManagedAnnotation_Revision(t *testing.T) {
+	manifestFile := "../tests/dat/manifest_validate_typed_annotations.yaml"
+	revision := ManagedRevision{Version: "1.2.0", Commit: "3fabe37c0d8e5f4a1b2c3d4e5f60718293a4b5c6", Branch: "main"}
+	ma, err := GenerateManagedAnnotation("helloworld", "", "", revision, ManagedProvenance{}, manifestFile)
+	assert.Nil(t, err)
+	managed := ma.Value.(map[string]interface{})
+	assert.Equal(t, "1.2.0", managed[OW_PROJECT_VERSION])
+	assert.Equal(t, "main", managed[OW_GIT_BRANCH])
+
+	assert.Equal(t, revision, GetManagedRevision(whisk.KeyValueArr{ma}))
+	assert.Equal(t, "1.2.0 3fabe37 (main)", revision.String())
+	assert.Equal(t, "3fabe37", ManagedRevision{Commit: revision.Commit}.String())
+	assert.Equal(t, "", GetManagedRevision(whisk.KeyValueArr{}).String())
+}
+
+func TestGenerateManagedAnnotation_Provenance(t *testing.T) {
+	manifestFile := "../tests/dat/manifest_validate_typed_annotations.yaml"
+	provenance, err := NewManagedProvenance(manifestFile, "")
+	assert.Nil(t, err)
+	assert.Equal(t, 64, len(provenance.ManifestChecksum))
+	assert.Empty(t, provenance.DeploymentChecksum, "no deployment file")
+	assert.Equal(t, GetWskdeployVersion(), provenance.WskdeployVersion)
+
+	ma, err := GenerateManagedAnnotation("helloworld", "", "", ManagedRevision{}, provenance, manifestFile)
+	assert.Nil(t, err)
+	assert.Equal(t, provenance.ManifestChecksum, ma.Value.(map[string]interface{})[OW_MANIFEST_SHA256])
+	assert.Equal(t, provenance, GetManagedProvenance(whisk.KeyValueArr{ma}))
+
+	provenance = ManagedProvenance{ManifestChecksum: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		WskdeployVersion: "0.9.8"}
+	assert.Equal(t, "manifest sha256:e3b0c44298fc wskdeploy 0.9.8", provenance.String())
+	assert.Equal(t, "", GetManagedProvenance(whisk.KeyValueArr{}).String())
+
+	_, err = NewManagedProvenance("manifest_not_found.yaml", "")
+	assert.NotNil(t, err)
+}
@@ -20,7 +20,8 @@ package utils
 import (
 	"archive/zip"
 	"bufio"
-	"encoding/base64"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -28,7 +29,9 @@ import (
 	"os/user"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/apache/incubator-openwhisk-client-go/whisk"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
@@ -55,6 +58,10 @@ type ActionRecord struct {
 	Action      *whisk.Action
 	Packagename string
 	Filepath    string
+	// the secret of the "require-whisk-auth" annotation was generated
+	WebSecretGenerated bool
+	// annotations removed from the deployed action, see del_annotations
+	DelAnnotations []string
 }
 
 type TriggerRecord struct {
@@ -87,7 +94,8 @@ type Comparable interface {
 func IsFeedAction(trigger *whisk.Trigger) (string, bool) {
 	for _, annotation := range trigger.Annotations {
 		if annotation.Key == "feed" {
-			return annotation.Value.(string), true
+			feedName, ok := annotation.Value.(string)
+			return feedName, ok
 		}
 	}
 
@@ -130,27 +138,36 @@ func NewZipWritter(src, des string) *ZipWritter {
 	return zw
 }
 
+// ZipWritter archives a directory. Archives are reproducible: entries are
+// sorted by name and carry neither the timestamps nor the owner of the files,
+// so that the archive (and its checksum) only changes with the files.
 type ZipWritter struct {
 	src        string
 	des        string
 	zipWritter *zip.Writer
 }
 
-func (zw *ZipWritter) zipFile(path string, f os.FileInfo, err error) error {
-	if err != nil {
-		return err
-	}
-	if !f.Mode().IsRegular() || f.Size() == 0 {
-		return nil
-	}
+// modification time of every entry, the earliest one of the zip format
+var zipModifiedTime = time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// zipFile adds the file to the archive, only the executable bit of its
+// permissions is kept
+func (zw *ZipWritter) zipFile(path string, name string, f os.FileInfo) error {
 	file, err := os.Open(path)
 	if err != nil {
 		return err
 	}
 	defer file.Close()
 
-	fileName := strings.TrimPrefix(path, zw.src+"/")
-	wr, err := zw.zipWritter.Create(fileName)
+	header := &zip.FileHeader{Name: name, Method: zip.Deflate}
+	header.SetModTime(zipModifiedTime)
+	mode := os.FileMode(0644)
+	if f.Mode()&0111 != 0 {
+		mode = 0755
+	}
+	header.SetMode(mode)
+
+	wr, err := zw.zipWritter.CreateHeader(header)
 	if err != nil {
 		return err
 	}
@@ -163,6 +180,32 @@ func (zw *ZipWritter) zipFile(path string, f os.FileInfo, err error) error {
 }
 
 func (zw *ZipWritter) Zip() error {
+	// collect the files first, so that they are added in the same order on
+	// every platform and the archive is not added to itself
+	paths := make(map[string]string)
+	infos := make(map[string]os.FileInfo)
+	names := make([]string, 0)
+	err := filepath.Walk(zw.src, func(path string, f os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !f.Mode().IsRegular() || f.Size() == 0 || path == zw.des {
+			return nil
+		}
+		rel, err := filepath.Rel(zw.src, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		paths[name], infos[name] = path, f
+		names = append(names, name)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
 	// create zip file
 	zipFile, err := os.Create(zw.des)
 	if err != nil {
@@ -170,9 +213,10 @@ func (zw *ZipWritter) Zip() error {
 	}
 	defer zipFile.Close()
 	zw.zipWritter = zip.NewWriter(zipFile)
-	err = filepath.Walk(zw.src, zw.zipFile)
-	if err != nil {
-		return nil
+	for _, name := range names {
+		if err := zw.zipFile(paths[name], name, infos[name]); err != nil {
+			return err
+		}
 	}
 	err = zw.zipWritter.Close()
 	if err != nil {
@@ -181,11 +225,25 @@ func (zw *ZipWritter) Zip() error {
 	return nil
 }
 
+// FileChecksum returns the SHA-256 checksum of the file, in hexadecimal
+func FileChecksum(filePath string) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 // below codes is from wsk cli with tiny adjusts.
-func GetExec(artifact string, kind string, isDocker bool, mainEntry string) (*whisk.Exec, error) {
+func GetExec(artifact string, kind string, isDocker bool, mainEntry string, maxCodeSize int64) (*whisk.Exec, error) {
 	var err error
 	var code string
-	var content []byte
 	var exec *whisk.Exec
 
 	ext := filepath.Ext(artifact)
@@ -197,11 +255,11 @@ func GetExec(artifact string, kind string, isDocker bool, mainEntry string) (*wh
 	exec = new(whisk.Exec)
 
 	if !isDocker || ext == ZIP_FILE_EXTENSION {
-		content, err = new(ContentReader).ReadLocal(artifact)
+		// the zip file is base64 encoded as it is read
+		code, err = ReadActionCode(artifact, ext == ZIP_FILE_EXTENSION, maxCodeSize)
 		if err != nil {
 			return nil, err
 		}
-		code = string(content)
 		exec.Code = &code
 	}
 
@@ -240,12 +298,6 @@ func GetExec(artifact string, kind string, isDocker bool, mainEntry string) (*wh
 		}
 	}
 
-	// Base64 encode the zip file content
-	if ext == ZIP_FILE_EXTENSION {
-		code = base64.StdEncoding.EncodeToString([]byte(code))
-		exec.Code = &code
-	}
-
 	return exec, nil
 }
 
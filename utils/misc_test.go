@@ -20,9 +20,15 @@
 package utils
 
 import (
+	"archive/zip"
+	"path/filepath"
 	"testing"
 	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"time"
 )
 
 var contentReader = new(ContentReader)
@@ -55,6 +61,21 @@ func TestDependencies(t *testing.T) {
 	assert.Equal(t, "http://github.com/user/repo/subfolder1/subfolder2", record.Location, "URL is wrong")
 	assert.Equal(t, "http://github.com/user/repo", record.BaseRepo, "BaseRepo is wrong")
 	assert.Equal(t, "/subfolder1/subfolder2", record.SubFolder, "SubFolder is wrong")
+
+	record = NewDependencyRecord("projectPath", "packageName", "https://github.com/user/repo.git/path/to/project/", "master", nil, nil, false)
+	assert.Equal(t, "https://github.com/user/repo", record.BaseRepo, "BaseRepo is wrong")
+	assert.Equal(t, "/path/to/project", record.SubFolder, "SubFolder is wrong")
+	assert.Equal(t, "", record.ManifestFile, "ManifestFile is wrong")
+
+	record = NewDependencyRecord("projectPath", "packageName", "https://github.com/user/repo/path/manifest.yml", "master", nil, nil, false)
+	assert.Equal(t, "/path", record.SubFolder, "SubFolder is wrong")
+	assert.Equal(t, "manifest.yml", record.ManifestFile, "ManifestFile is wrong")
+
+	record = NewDependencyRecord("projectPath", "packageName", "https://github.com/user", "master", nil, nil, false)
+	assert.Equal(t, "https://github.com/user", record.BaseRepo, "BaseRepo is wrong")
+
+	assert.True(t, LocationIsGithubRepo("github.com/user/repo/path"))
+	assert.False(t, LocationIsGithubRepo("https://github.com/user"))
 }
 
 func TestParseOpenWhisk(t *testing.T) {
@@ -69,6 +90,47 @@ func TestParseOpenWhisk(t *testing.T) {
 	assert.Equal(t, 1, len(converted["swift"]), "not expected length")
 }
 
+func TestParseOpenWhisk_RuntimesFromHost(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "runtimes")
+	assert.Nil(t, err)
+	defer os.RemoveAll(cacheDir)
+	defer func(dir string) { RuntimesCacheDir = dir }(RuntimesCacheDir)
+	RuntimesCacheDir = cacheDir
+
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, RUNTIMES_API_PATH, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		w.Write([]byte(`{"runtimes":{"nodejs":[{"kind":"nodejs:8","default":true},{"kind":"nodejs:10"}]}}`))
+	}))
+
+	openwhisk, err := ParseOpenWhisk(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"nodejs:8", "nodejs:10"}, ConvertToMap(openwhisk)["nodejs"])
+	assert.Equal(t, "nodejs:8", DefaultRuntimes(openwhisk)["nodejs"])
+
+	// runtimes are read from the cache until it expires
+	openwhisk, err = ParseOpenWhisk(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, requests)
+
+	// an expired cache is used when the host cannot be reached
+	server.Close()
+	cachePath := runtimesCachePath(server.URL)
+	expired := time.Now().Add(-2 * RUNTIMES_CACHE_TTL)
+	assert.Nil(t, os.Chtimes(cachePath, expired, expired))
+	openwhisk, err = ParseOpenWhisk(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(ConvertToMap(openwhisk)["nodejs"]))
+
+	// without a cache the runtimes compiled into wskdeploy are used
+	assert.Nil(t, os.Remove(cachePath))
+	openwhisk, err = ParseOpenWhisk(server.URL)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(ConvertToMap(openwhisk)["php"]))
+}
+
 func TestNewZipWritter(t *testing.T) {
 	filePath := "../tests/src/integration/zipaction/actions/cat"
 	zipName := filePath + ".zip"
@@ -76,3 +138,80 @@ func TestNewZipWritter(t *testing.T) {
 	defer os.Remove(zipName)
 	assert.Equal(t, nil, err, "zip folder error happened.")
 }
+
+func TestZipWritter_Reproducible(t *testing.T) {
+	dir, err := ioutil.TempDir("", "zip")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	src := filepath.Join(dir, "action")
+	assert.Nil(t, os.MkdirAll(filepath.Join(src, "lib"), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(src, "main.js"), []byte("require('./lib/hello')"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(src, "lib", "hello.js"), []byte("exports.main = () => ({})"), 0600))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(src, "run.sh"), []byte("#!/bin/sh"), 0700))
+
+	first := filepath.Join(dir, "first.zip")
+	assert.Nil(t, NewZipWritter(src, first).Zip())
+
+	// the same files, modified at another time with other permissions
+	later := time.Now().Add(time.Hour)
+	for _, name := range []string{"main.js", "lib/hello.js", "run.sh"} {
+		assert.Nil(t, os.Chtimes(filepath.Join(src, name), later, later))
+	}
+	assert.Nil(t, os.Chmod(filepath.Join(src, "lib", "hello.js"), 0640))
+	second := filepath.Join(dir, "second.zip")
+	assert.Nil(t, NewZipWritter(src, second).Zip())
+
+	firstChecksum, err := FileChecksum(first)
+	assert.Nil(t, err)
+	secondChecksum, err := FileChecksum(second)
+	assert.Nil(t, err)
+	assert.Equal(t, firstChecksum, secondChecksum)
+	assert.Equal(t, 64, len(firstChecksum))
+
+	reader, err := zip.OpenReader(second)
+	assert.Nil(t, err)
+	defer reader.Close()
+	names := make([]string, 0)
+	for _, file := range reader.File {
+		names = append(names, file.Name)
+		if file.Name == "run.sh" {
+			assert.Equal(t, os.FileMode(0755), file.Mode())
+		}
+	}
+	assert.Equal(t, []string{"lib/hello.js", "main.js", "run.sh"}, names)
+}
+
+func TestResolveRuntimeKind(t *testing.T) {
+	defer func(supported, deprecated map[string][]string) {
+		SupportedRunTimes, DeprecatedRunTimes = supported, deprecated
+	}(SupportedRunTimes, DeprecatedRunTimes)
+	SupportedRunTimes = map[string][]string{
+		"nodejs": {"nodejs:8", "nodejs:10", "nodejs:6"},
+		"python": {"python", "python:2", "python:3"},
+		"swift":  {"swift:3.1.1", "swift:4.1"},
+	}
+	DeprecatedRunTimes = map[string][]string{"nodejs": {"nodejs", "nodejs:4"}, "swift": {"swift:3"}}
+
+	tests := []struct {
+		runtime, version, kind string
+		deprecated             bool
+	}{
+		{"nodejs", "", "nodejs:10", false},
+		{"nodejs", "8", "nodejs:8", false},
+		{"nodejs:6", "", "nodejs:6", false},
+		{"nodejs", "4", "nodejs:4", true},
+		{"nodejs:4", "", "nodejs:4", true},
+		{"nodejs", "12", "", false},
+		{"python", "", "python", false},
+		{"python", "3", "python:3", false},
+		{"swift", "3", "swift:3.1.1", false},
+		{"swift", "", "swift:4.1", false},
+		{"go:1.11", "", "go:1.11", false},
+		{"go", "1.11", "", false},
+	}
+	for _, test := range tests {
+		kind, deprecated := ResolveRuntimeKind(test.runtime, test.version)
+		assert.Equal(t, test.kind, kind, test.runtime+" "+test.version)
+		assert.Equal(t, test.deprecated, deprecated, test.runtime+" "+test.version)
+	}
+}
@@ -0,0 +1,132 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"gopkg.in/yaml.v2"
+)
+
+// severities of the names which do not follow the naming conventions
+const (
+	NAMING_SEVERITY_ERROR   = "error"
+	NAMING_SEVERITY_WARNING = "warning"
+	NAMING_KEY_SEVERITY     = "severity"
+)
+
+// entity types a naming convention may be defined for, as keyed in manifests
+var NamingEntities = []string{"package", "action", "sequence", "trigger", "rule"}
+
+// NamingConventions are the patterns the names of entities must match, keyed
+// by entity type, e.g.
+//   severity: warning
+//   trigger: ^[a-z][a-z0-9-]*-trigger$
+//   rule: ^[a-z][a-z0-9-]*-rule$
+type NamingConventions struct {
+	Severity string
+	Patterns map[string]*regexp.Regexp
+}
+
+var namingConventions struct {
+	sync.Mutex
+	path        string
+	conventions *NamingConventions
+}
+
+// ReadNamingConventions reads the naming conventions of an organization from
+// a YAML file or URL; an entity type without a pattern may have any name.
+func ReadNamingConventions(path string) (*NamingConventions, error) {
+	content, err := Read(path)
+	if err != nil {
+		return nil, wskderrors.NewFileReadError(path, err.Error())
+	}
+	values := make(map[string]string)
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, wskderrors.NewFileReadError(path, err.Error())
+	}
+
+	conventions := &NamingConventions{Severity: NAMING_SEVERITY_ERROR, Patterns: make(map[string]*regexp.Regexp)}
+	invalid := func(message string) error {
+		return wskderrors.NewYAMLFileFormatError(path, wski18n.T(wski18n.ID_ERR_NAMING_CONVENTIONS_INVALID_X_path_X_err_X,
+			map[string]interface{}{wski18n.KEY_PATH: path, wski18n.KEY_ERR: message}))
+	}
+	for key, value := range values {
+		if key == NAMING_KEY_SEVERITY {
+			if value != NAMING_SEVERITY_ERROR && value != NAMING_SEVERITY_WARNING {
+				return nil, invalid(wski18n.T(wski18n.ID_ERR_NAMING_SEVERITY_INVALID_X_value_X,
+					map[string]interface{}{wski18n.KEY_VALUE: value}))
+			}
+			conventions.Severity = value
+			continue
+		}
+		if !isNamingEntity(key) {
+			return nil, invalid(wski18n.T(wski18n.ID_ERR_NAMING_ENTITY_UNKNOWN_X_key_X_entities_X,
+				map[string]interface{}{wski18n.KEY_KEY: key, wski18n.KEY_ENTITIES: strings.Join(NamingEntities, ", ")}))
+		}
+		pattern, err := regexp.Compile(value)
+		if err != nil {
+			return nil, invalid(err.Error())
+		}
+		conventions.Patterns[key] = pattern
+	}
+	return conventions, nil
+}
+
+func isNamingEntity(key string) bool {
+	for _, entity := range NamingEntities {
+		if entity == key {
+			return true
+		}
+	}
+	return false
+}
+
+// GetNamingConventions returns the conventions given by --naming-conventions,
+// they are read once, nil if there are none
+func (flags *WskdeployFlags) GetNamingConventions() (*NamingConventions, error) {
+	namingConventions.Lock()
+	defer namingConventions.Unlock()
+	if len(flags.NamingConventions) == 0 {
+		return nil, nil
+	}
+	if namingConventions.path != flags.NamingConventions {
+		conventions, err := ReadNamingConventions(flags.NamingConventions)
+		if err != nil {
+			return nil, err
+		}
+		namingConventions.path, namingConventions.conventions = flags.NamingConventions, conventions
+	}
+	return namingConventions.conventions, nil
+}
+
+// Validate returns a NamingConventionError if the name of the entity does not
+// match the pattern of its type
+func (conventions *NamingConventions) Validate(entity string, name string) error {
+	pattern, ok := conventions.Patterns[entity]
+	if !ok || pattern.MatchString(name) {
+		return nil
+	}
+	return wskderrors.NewNamingConventionError(wski18n.T(wski18n.ID_ERR_NAMING_CONVENTION_X_key_X_name_X_pattern_X,
+		map[string]interface{}{wski18n.KEY_KEY: entity, wski18n.KEY_NAME: name, wski18n.KEY_PATTERN: pattern.String()}),
+		entity, name, pattern.String())
+}
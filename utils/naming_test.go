@@ -0,0 +1,51 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadNamingConventions(t *testing.T) {
+	conventions, err := ReadNamingConventions("../tests/dat/naming_conventions.yaml")
+	assert.Nil(t, err)
+	assert.Equal(t, NAMING_SEVERITY_ERROR, conventions.Severity)
+	assert.Nil(t, conventions.Validate("trigger", "locationupdate"))
+	assert.Nil(t, conventions.Validate("action", "Any_Name"), "actions have no naming convention")
+	err = conventions.Validate("rule", "myrule")
+	if assert.IsType(t, &wskderrors.NamingConventionError{}, err) {
+		assert.Equal(t, "^rule[0-9]+$", err.(*wskderrors.NamingConventionError).Pattern)
+	}
+
+	for _, invalid := range []string{"severity: fatal\n", "function: ^[a-z]+$\n", "trigger: \"[a-z\"\n"} {
+		file, err := ioutil.TempFile("", "naming")
+		assert.Nil(t, err)
+		defer os.Remove(file.Name())
+		file.WriteString(invalid)
+		file.Close()
+		_, err = ReadNamingConventions(file.Name())
+		assert.IsType(t, &wskderrors.YAMLFileFormatError{}, err, invalid)
+	}
+}
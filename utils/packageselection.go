@@ -0,0 +1,58 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"path"
+	"strings"
+)
+
+// HasPackageSelection returns true if packages are selected with --packages
+// or --exclude-package, i.e. some packages of the manifest may be left out
+func (flags *WskdeployFlags) HasPackageSelection() bool {
+	return len(flags.Packages) > 0 || len(flags.ExcludePackages) > 0
+}
+
+// IsPackageSelected returns true if the package matches a name or glob of
+// --packages, if any, and does not match any of --exclude-package
+func (flags *WskdeployFlags) IsPackageSelected(name string) bool {
+	if len(flags.Packages) > 0 && !matchesPackage(flags.Packages, name) {
+		return false
+	}
+	return !matchesPackage(flags.ExcludePackages, name)
+}
+
+// ValidatePackagePatterns returns the first pattern of --packages or
+// --exclude-package which is not a valid glob
+func (flags *WskdeployFlags) ValidatePackagePatterns() (string, error) {
+	for _, pattern := range append(append([]string{}, flags.Packages...), flags.ExcludePackages...) {
+		if _, err := path.Match(strings.TrimSpace(pattern), ""); err != nil {
+			return pattern, err
+		}
+	}
+	return "", nil
+}
+
+func matchesPackage(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, err := path.Match(strings.TrimSpace(pattern), name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPackageSelected(t *testing.T) {
+	flags := &WskdeployFlags{}
+
+	assert.False(t, flags.HasPackageSelection())
+	assert.True(t, flags.IsPackageSelected("api-users"))
+
+	flags.Packages = []string{"api-*", "billing"}
+	flags.ExcludePackages = []string{"api-internal"}
+	assert.True(t, flags.HasPackageSelection())
+	assert.True(t, flags.IsPackageSelected("api-users"))
+	assert.True(t, flags.IsPackageSelected("billing"))
+	assert.False(t, flags.IsPackageSelected("api-internal"))
+	assert.False(t, flags.IsPackageSelected("billing-reports"))
+
+	// packages may be excluded without selecting any
+	flags.Packages = nil
+	flags.ExcludePackages = []string{"*-test"}
+	assert.True(t, flags.IsPackageSelected("billing"))
+	assert.False(t, flags.IsPackageSelected("billing-test"))
+
+	_, err := flags.ValidatePackagePatterns()
+	assert.Nil(t, err)
+	flags.Packages = []string{"api-[users"}
+	pattern, err := flags.ValidatePackagePatterns()
+	assert.NotNil(t, err)
+	assert.Equal(t, "api-[users", pattern)
+}
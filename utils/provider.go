@@ -0,0 +1,175 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"gopkg.in/yaml.v2"
+)
+
+// providers known to wskdeploy, see --provider
+const (
+	PROVIDER_OPENWHISK        = "openwhisk"
+	PROVIDER_ADOBE_IO_RUNTIME = "adobe-io-runtime"
+	PROVIDER_NIMBELLA         = "nimbella"
+)
+
+// Provider adapts the deployment to a distribution of OpenWhisk: the runtime
+// kinds it serves, the names of its annotations, whether it serves the API
+// gateway and where its credentials are found. A provider is selected by name
+// with --provider, or read from a YAML file, e.g.
+//   name: mycloud
+//   apihost: https://functions.mycloud.com
+//   auth-env: MYCLOUD_AUTH
+//   runtimes:
+//     nodejs:6: nodejs:10
+//   annotations:
+//     require-whisk-auth: require-mycloud-auth
+type Provider struct {
+	Name string `yaml:"name"`
+	// API host used when no API host is configured
+	ApiHost string `yaml:"apihost,omitempty"`
+	// .wskprops file written by the CLI of the provider, "~" is the home
+	// directory, read before the .wskprops file of OpenWhisk
+	Wskprops string `yaml:"wskprops,omitempty"`
+	// variables the auth key and namespace are read from
+	AuthEnv      string `yaml:"auth-env,omitempty"`
+	NamespaceEnv string `yaml:"namespace-env,omitempty"`
+	// the runtime kinds of OpenWhisk the provider does not serve, and the
+	// kinds the actions are deployed with instead
+	RuntimeKinds map[string]string `yaml:"runtimes,omitempty"`
+	// the keys of annotations of OpenWhisk the provider names differently
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+	// the provider serves the API gateway of OpenWhisk, APIs are not deployed
+	// otherwise
+	ApiGateway bool `yaml:"api-gateway"`
+}
+
+// Providers are the providers selected by name with --provider
+var Providers = map[string]Provider{
+	PROVIDER_OPENWHISK: {Name: PROVIDER_OPENWHISK, ApiGateway: true},
+	PROVIDER_ADOBE_IO_RUNTIME: {
+		Name:         PROVIDER_ADOBE_IO_RUNTIME,
+		ApiHost:      "https://adobeioruntime.net",
+		AuthEnv:      "AIO_RUNTIME_AUTH",
+		NamespaceEnv: "AIO_RUNTIME_NAMESPACE",
+		RuntimeKinds: map[string]string{"nodejs:6": "nodejs:10", "nodejs:8": "nodejs:10"},
+		ApiGateway:   true,
+	},
+	PROVIDER_NIMBELLA: {
+		Name:         PROVIDER_NIMBELLA,
+		ApiHost:      "https://apigcp.nimbella.io",
+		Wskprops:     "~/.nimbella/wskprops",
+		RuntimeKinds: map[string]string{"nodejs:6": "nodejs:10", "nodejs:8": "nodejs:10"},
+		ApiGateway:   false,
+	},
+}
+
+var currentProvider struct {
+	sync.Mutex
+	name     string
+	provider *Provider
+}
+
+// ReadProvider returns the provider of the given name, or reads it from a
+// YAML file or URL
+func ReadProvider(nameOrPath string) (*Provider, error) {
+	if provider, ok := Providers[nameOrPath]; ok {
+		return &provider, nil
+	}
+	if !FileExists(nameOrPath) && !strings.HasPrefix(nameOrPath, "http") {
+		names := make([]string, 0, len(Providers))
+		for name := range Providers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, wskderrors.NewCommandError("--provider", wski18n.T(wski18n.ID_ERR_PROVIDER_UNKNOWN_X_name_X_names_X,
+			map[string]interface{}{wski18n.KEY_NAME: nameOrPath, wski18n.KEY_NAMES: strings.Join(names, ", ")}))
+	}
+	content, err := Read(nameOrPath)
+	if err != nil {
+		return nil, wskderrors.NewFileReadError(nameOrPath, err.Error())
+	}
+	provider := &Provider{ApiGateway: true}
+	if err := yaml.Unmarshal(content, provider); err != nil {
+		return nil, wskderrors.NewYAMLFileFormatError(nameOrPath, err.Error())
+	}
+	if len(provider.Name) == 0 {
+		provider.Name = strings.TrimSuffix(filepath.Base(nameOrPath), filepath.Ext(nameOrPath))
+	}
+	return provider, nil
+}
+
+// GetProvider returns the provider selected with --provider, it is read once,
+// nil if there is none
+func (flags *WskdeployFlags) GetProvider() (*Provider, error) {
+	currentProvider.Lock()
+	defer currentProvider.Unlock()
+	if len(flags.Provider) == 0 {
+		return nil, nil
+	}
+	if currentProvider.name != flags.Provider {
+		provider, err := ReadProvider(flags.Provider)
+		if err != nil {
+			return nil, err
+		}
+		currentProvider.name, currentProvider.provider = flags.Provider, provider
+	}
+	return currentProvider.provider, nil
+}
+
+// RuntimeKind returns the kind the provider serves instead of the given kind,
+// the kind itself if the provider serves it
+func (provider *Provider) RuntimeKind(kind string) string {
+	if replacement, ok := provider.RuntimeKinds[kind]; ok {
+		return replacement
+	}
+	return kind
+}
+
+// RenameAnnotations returns the annotations with the keys the provider names
+// differently renamed
+func (provider *Provider) RenameAnnotations(annotations whisk.KeyValueArr) whisk.KeyValueArr {
+	if len(provider.Annotations) == 0 {
+		return annotations
+	}
+	renamed := make(whisk.KeyValueArr, 0, len(annotations))
+	for _, annotation := range annotations {
+		if key, ok := provider.Annotations[annotation.Key]; ok {
+			annotation.Key = key
+		}
+		renamed = append(renamed, annotation)
+	}
+	return renamed
+}
+
+// WskpropsPath returns the path of the .wskprops file of the provider, an
+// empty string if it has none
+func (provider *Provider) WskpropsPath() string {
+	if strings.HasPrefix(provider.Wskprops, "~") {
+		return filepath.Join(GetHomeDirectory(), strings.TrimPrefix(provider.Wskprops, "~"))
+	}
+	return provider.Wskprops
+}
@@ -0,0 +1,72 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadProvider(t *testing.T) {
+	provider, err := ReadProvider(PROVIDER_NIMBELLA)
+	assert.Nil(t, err)
+	assert.Equal(t, PROVIDER_NIMBELLA, provider.Name)
+	assert.False(t, provider.ApiGateway)
+	assert.Equal(t, filepath.Join(GetHomeDirectory(), ".nimbella", "wskprops"), provider.WskpropsPath())
+
+	_, err = ReadProvider("unknown")
+	assert.IsType(t, &wskderrors.CommandError{}, err)
+
+	dir, err := ioutil.TempDir("", "provider")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	providerPath := filepath.Join(dir, "mycloud.yaml")
+	content := "apihost: https://functions.mycloud.com\nruntimes:\n  python:2: python:3\nannotations:\n  require-whisk-auth: require-mycloud-auth\n"
+	assert.Nil(t, ioutil.WriteFile(providerPath, []byte(content), 0644))
+	provider, err = ReadProvider(providerPath)
+	assert.Nil(t, err)
+	assert.Equal(t, "mycloud", provider.Name, "the name of the file is the name of the provider")
+	assert.Equal(t, "https://functions.mycloud.com", provider.ApiHost)
+	assert.True(t, provider.ApiGateway, "providers serve the API gateway unless told otherwise")
+	assert.Equal(t, "python:3", provider.RuntimeKind("python:2"))
+	assert.Equal(t, "nodejs:10", provider.RuntimeKind("nodejs:10"))
+
+	annotations := provider.RenameAnnotations(whisk.KeyValueArr{
+		{Key: "require-whisk-auth", Value: "secret"}, {Key: "web-export", Value: true}})
+	assert.Equal(t, whisk.KeyValueArr{{Key: "require-mycloud-auth", Value: "secret"}, {Key: "web-export", Value: true}}, annotations)
+}
+
+func TestGetProvider(t *testing.T) {
+	flags := &WskdeployFlags{}
+	provider, err := flags.GetProvider()
+	assert.Nil(t, err)
+	assert.Nil(t, provider)
+
+	flags.Provider = PROVIDER_ADOBE_IO_RUNTIME
+	provider, err = flags.GetProvider()
+	assert.Nil(t, err)
+	assert.Equal(t, "AIO_RUNTIME_AUTH", provider.AuthEnv)
+}
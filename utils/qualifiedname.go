@@ -21,6 +21,8 @@ package utils
 import (
 	"errors"
 	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
 )
 
 type QualifiedName struct {
@@ -53,13 +55,13 @@ func ParseQualifiedName(name string, defaultNamespace string) (QualifiedName, er
 		qualifiedName.Namespace = parts[1]
 
 		if len(parts) < 2 || len(parts) > 4 {
-			err := errors.New("A valid qualified name was not detected")
+			err := errors.New(wski18n.T(wski18n.ID_ERR_QUALIFIED_NAME_INVALID))
 			return qualifiedName, err
 		}
 
 		for i := 1; i < len(parts); i++ {
 			if len(parts[i]) == 0 || parts[i] == "." {
-				err := errors.New("A valid qualified name was not detected")
+				err := errors.New(wski18n.T(wski18n.ID_ERR_QUALIFIED_NAME_INVALID))
 				return qualifiedName, err
 			}
 		}
@@ -67,7 +69,7 @@ func ParseQualifiedName(name string, defaultNamespace string) (QualifiedName, er
 		qualifiedName.EntityName = strings.Join(parts[2:], "/")
 	} else {
 		if len(name) == 0 || name == "." {
-			err := errors.New("A valid qualified name was not detected")
+			err := errors.New(wski18n.T(wski18n.ID_ERR_QUALIFIED_NAME_INVALID))
 			return qualifiedName, err
 		}
 
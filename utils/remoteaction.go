@@ -0,0 +1,101 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// IsRemoteActionCode returns true for the http(s) URL of the code of an
+// action, e.g. function: https://artifacts.example.com/hello/1.2.0/hello.zip
+func IsRemoteActionCode(function string) bool {
+	return strings.HasPrefix(function, "http://") || strings.HasPrefix(function, "https://")
+}
+
+// DownloadActionCode downloads the code of an action to a temporary directory
+// and returns the directory, to be removed once the code is read, along with
+// the path of the file. The file is named after the last element of the path
+// of the URL, so that its extension selects the runtime of the action. When a
+// sha256 checksum is given, the code must match it.
+func DownloadActionCode(action string, codeURL string, checksum string) (string, string, error) {
+	wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_ACTION_DOWNLOAD_X_action_X_url_X,
+		map[string]interface{}{wski18n.KEY_ACTION: action, wski18n.KEY_URL: codeURL}))
+
+	downloadError := func(err error) error {
+		return wskderrors.NewFileReadError(codeURL, wski18n.T(wski18n.ID_ERR_ACTION_DOWNLOAD_X_action_X_url_X_err_X,
+			map[string]interface{}{wski18n.KEY_ACTION: action, wski18n.KEY_URL: codeURL, wski18n.KEY_ERR: err.Error()}))
+	}
+	parsed, err := url.Parse(codeURL)
+	if err != nil {
+		return "", "", downloadError(err)
+	}
+	name := path.Base(parsed.Path)
+	if name == "/" || name == "." {
+		name = "code"
+	}
+
+	response, err := http.Get(codeURL)
+	if err != nil {
+		return "", "", downloadError(err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", "", downloadError(fmt.Errorf("%s", response.Status))
+	}
+
+	dir, err := ioutil.TempDir("", "wskdeploy")
+	if err != nil {
+		return "", "", downloadError(err)
+	}
+	filePath := filepath.Join(dir, name)
+	file, err := os.Create(filePath)
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", "", downloadError(err)
+	}
+	hash := sha256.New()
+	_, err = io.Copy(io.MultiWriter(file, hash), response.Body)
+	file.Close()
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", "", downloadError(err)
+	}
+
+	actual := hex.EncodeToString(hash.Sum(nil))
+	if expected := strings.ToLower(strings.TrimSpace(checksum)); len(expected) > 0 && expected != actual {
+		os.RemoveAll(dir)
+		return "", "", wskderrors.NewFileReadError(codeURL, wski18n.T(wski18n.ID_ERR_ACTION_CHECKSUM_X_action_X_url_X_expected_X_value_X,
+			map[string]interface{}{wski18n.KEY_ACTION: action, wski18n.KEY_URL: codeURL,
+				wski18n.KEY_EXPECTED: expected, wski18n.KEY_VALUE: actual}))
+	}
+	return dir, filePath, nil
+}
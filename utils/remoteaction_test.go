@@ -0,0 +1,70 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRemoteActionCode(t *testing.T) {
+	assert.True(t, IsRemoteActionCode("https://artifacts.example.com/hello/hello.zip"))
+	assert.True(t, IsRemoteActionCode("http://localhost:8080/hello.js"))
+	assert.False(t, IsRemoteActionCode("actions/hello.js"))
+	assert.False(t, IsRemoteActionCode("httpactions/hello.js"))
+}
+
+func TestDownloadActionCode(t *testing.T) {
+	code := "function main() { return {}; }"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/builds/42/hello.js" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(code))
+	}))
+	defer server.Close()
+	sum := sha256.Sum256([]byte(code))
+	checksum := hex.EncodeToString(sum[:])
+
+	dir, filePath, err := DownloadActionCode("hello/greeting", server.URL+"/builds/42/hello.js?token=abc", checksum)
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Equal(t, "hello.js", filepath.Base(filePath), "the extension of the URL selects the runtime")
+	content, _ := ioutil.ReadFile(filePath)
+	assert.Equal(t, code, string(content))
+
+	_, _, err = DownloadActionCode("hello/greeting", server.URL+"/builds/42/hello.js", "0123")
+	assert.IsType(t, &wskderrors.FileReadError{}, err)
+	assert.Contains(t, err.Error(), checksum)
+
+	_, _, err = DownloadActionCode("hello/greeting", server.URL+"/builds/43/hello.js", "")
+	assert.IsType(t, &wskderrors.FileReadError{}, err)
+	assert.Contains(t, err.Error(), "404")
+}
@@ -0,0 +1,214 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// remote projects, e.g. git+https://github.com/org/catalog#v1.0/blueprints/hello
+// or https://example.com/blueprints/hello.zip#manifest.yaml
+const (
+	GIT_URL_PREFIX       = "git+"
+	DEFAULT_GIT_REF      = "master"
+	REMOTE_PATH_FRAGMENT = "#"
+)
+
+// IsRemoteProject returns true for the git+https URL of a repository, or the
+// http(s) URL of a zip file, whose tree is fetched before it is deployed. The
+// http(s) URLs of manifest and deployment files are read as they are.
+func IsRemoteProject(location string) bool {
+	if strings.HasPrefix(location, GIT_URL_PREFIX+"http") {
+		return true
+	}
+	archiveURL := strings.SplitN(location, REMOTE_PATH_FRAGMENT, 2)[0]
+	return strings.HasPrefix(archiveURL, "http") && strings.HasSuffix(strings.ToLower(archiveURL), "."+ZIP_FILE_EXTENSION)
+}
+
+// RemoteWorkspace is the temporary directory remote projects are fetched to,
+// each zip file (or ref of a repository) is fetched once
+type RemoteWorkspace struct {
+	Dir   string
+	trees map[string]string // directory of the tree of each zip file URL
+}
+
+func NewRemoteWorkspace() (*RemoteWorkspace, error) {
+	dir, err := ioutil.TempDir("", "wskdeploy")
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteWorkspace{Dir: dir, trees: make(map[string]string)}, nil
+}
+
+// Remove removes the workspace along with the projects fetched to it
+func (workspace *RemoteWorkspace) Remove() {
+	os.RemoveAll(workspace.Dir)
+}
+
+// Fetch fetches the tree of a remote project, see IsRemoteProject(), and
+// returns the local path of the file or directory given by the fragment of
+// the URL, e.g. "blueprints/hello/manifest.yaml". The ref of a repository is
+// the first element of the fragment, master if there is no fragment.
+func (workspace *RemoteWorkspace) Fetch(location string) (string, error) {
+	archiveURL, subPath, isGit := parseRemoteProject(location)
+	tree, ok := workspace.trees[archiveURL]
+	if !ok {
+		tree = filepath.Join(workspace.Dir, fmt.Sprintf("project%d", len(workspace.trees)))
+		if err := fetchZip(archiveURL, tree, isGit); err != nil {
+			return "", wskderrors.NewFileReadError(location,
+				wski18n.T(wski18n.ID_ERR_REMOTE_PROJECT_FETCH_X_url_X_err_X,
+					map[string]interface{}{wski18n.KEY_URL: archiveURL, wski18n.KEY_ERR: err.Error()}))
+		}
+		workspace.trees[archiveURL] = tree
+		whisk.Debug(whisk.DbgInfo, wski18n.T(wski18n.ID_MSG_REMOTE_PROJECT_FETCHED_X_url_X_path_X,
+			map[string]interface{}{wski18n.KEY_URL: archiveURL, wski18n.KEY_PATH: tree}))
+	}
+
+	localPath := filepath.Join(tree, filepath.FromSlash(subPath))
+	if !isWithin(tree, localPath) || !FileExists(localPath) {
+		return "", wskderrors.NewFileReadError(location,
+			wski18n.T(wski18n.ID_ERR_REMOTE_PROJECT_PATH_NOT_FOUND_X_path_X_url_X,
+				map[string]interface{}{wski18n.KEY_PATH: subPath, wski18n.KEY_URL: archiveURL}))
+	}
+	return localPath, nil
+}
+
+// parseRemoteProject returns the URL of the zip file of a remote project, and
+// the path given by the fragment of its URL. Repositories are fetched as the
+// zip file of their ref, the way dependencies are.
+func parseRemoteProject(location string) (archiveURL string, subPath string, isGit bool) {
+	parts := strings.SplitN(location, REMOTE_PATH_FRAGMENT, 2)
+	archiveURL = parts[0]
+	if len(parts) == 2 {
+		subPath = strings.Trim(parts[1], "/")
+	}
+	if !strings.HasPrefix(archiveURL, GIT_URL_PREFIX) {
+		return archiveURL, subPath, false
+	}
+
+	ref := DEFAULT_GIT_REF
+	if len(subPath) > 0 {
+		refAndPath := strings.SplitN(subPath, "/", 2)
+		ref, subPath = refAndPath[0], ""
+		if len(refAndPath) == 2 {
+			subPath = refAndPath[1]
+		}
+	}
+	repo := strings.TrimSuffix(strings.TrimPrefix(archiveURL, GIT_URL_PREFIX), ".git")
+	return strings.TrimSuffix(repo, "/") + "/zipball/" + ref, subPath, true
+}
+
+// fetchZip downloads and extracts a zip file to the directory, the root
+// directory of the zip file of a repository is left out
+func fetchZip(archiveURL string, dir string, stripRoot bool) error {
+	response, err := http.Get(archiveURL)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s", response.Status)
+	}
+
+	zipFile, err := ioutil.TempFile("", "wskdeploy.zip.")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(zipFile.Name())
+	_, err = io.Copy(zipFile, response.Body)
+	zipFile.Close()
+	if err != nil {
+		return err
+	}
+	return unzip(zipFile.Name(), dir, stripRoot)
+}
+
+func unzip(zipPath string, dir string, stripRoot bool) error {
+	zipReader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return err
+	}
+	defer zipReader.Close()
+
+	for _, file := range zipReader.File {
+		name := file.Name
+		if stripRoot {
+			parts := strings.SplitN(name, "/", 2)
+			if len(parts) < 2 {
+				continue
+			}
+			name = parts[1]
+		}
+		if len(name) == 0 {
+			continue
+		}
+
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		// entries may not be extracted outside of the directory
+		if !isWithin(dir, target) {
+			return errors.New(wski18n.T(wski18n.ID_ERR_ARCHIVE_PATH_INVALID_X_path_X,
+				map[string]interface{}{wski18n.KEY_PATH: file.Name}))
+		}
+		if file.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, os.ModePerm); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := extractFile(file, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractFile(file *zip.File, target string) error {
+	if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+		return err
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	writer, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode()|0600)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+	_, err = io.Copy(writer, reader)
+	return err
+}
+
+func isWithin(dir string, target string) bool {
+	relative, err := filepath.Rel(dir, target)
+	return err == nil && relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,115 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsRemoteProject(t *testing.T) {
+	assert.True(t, IsRemoteProject("git+https://github.com/org/catalog#v1.0/blueprints/hello"))
+	assert.True(t, IsRemoteProject("https://example.com/blueprints/hello.zip"))
+	assert.True(t, IsRemoteProject("https://example.com/blueprints/hello.ZIP#hello/manifest.yaml"))
+	assert.False(t, IsRemoteProject("https://example.com/blueprints/manifest.yaml"), "read as it is")
+	assert.False(t, IsRemoteProject("blueprints/hello.zip"))
+	assert.False(t, IsRemoteProject("manifest.yaml"))
+}
+
+func TestParseRemoteProject(t *testing.T) {
+	archiveURL, subPath, isGit := parseRemoteProject("git+https://github.com/org/catalog.git#v1.0/blueprints/hello/")
+	assert.Equal(t, "https://github.com/org/catalog/zipball/v1.0", archiveURL)
+	assert.Equal(t, "blueprints/hello", subPath)
+	assert.True(t, isGit)
+
+	archiveURL, subPath, _ = parseRemoteProject("git+https://github.com/org/catalog")
+	assert.Equal(t, "https://github.com/org/catalog/zipball/"+DEFAULT_GIT_REF, archiveURL)
+	assert.Equal(t, "", subPath)
+
+	archiveURL, subPath, isGit = parseRemoteProject("https://example.com/hello.zip#manifest.yaml")
+	assert.Equal(t, "https://example.com/hello.zip", archiveURL)
+	assert.Equal(t, "manifest.yaml", subPath)
+	assert.False(t, isGit)
+}
+
+func newTestZip(t *testing.T, files map[string]string) []byte {
+	buffer := new(bytes.Buffer)
+	writer := zip.NewWriter(buffer)
+	for name, content := range files {
+		file, err := writer.Create(name)
+		assert.Nil(t, err)
+		file.Write([]byte(content))
+	}
+	assert.Nil(t, writer.Close())
+	return buffer.Bytes()
+}
+
+func TestRemoteWorkspace_Fetch(t *testing.T) {
+	repo := newTestZip(t, map[string]string{
+		"org-catalog-1234567/blueprints/hello/manifest.yaml": "packages:\n",
+		"org-catalog-1234567/blueprints/hello/hello.js":      "function main() {}",
+	})
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/org/catalog/zipball/v1.0" {
+			w.Write(repo)
+			return
+		}
+		if r.URL.Path == "/evil.zip" {
+			w.Write(newTestZip(t, map[string]string{"../evil.js": ""}))
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	workspace, err := NewRemoteWorkspace()
+	assert.Nil(t, err)
+	defer workspace.Remove()
+
+	manifestPath, err := workspace.Fetch("git+" + server.URL + "/org/catalog#v1.0/blueprints/hello/manifest.yaml")
+	assert.Nil(t, err)
+	content, err := ioutil.ReadFile(filepath.Join(filepath.Dir(manifestPath), "hello.js"))
+	assert.Nil(t, err, "the tree of the repository is fetched")
+	assert.Equal(t, "function main() {}", string(content))
+
+	projectPath, err := workspace.Fetch("git+" + server.URL + "/org/catalog#v1.0/blueprints/hello")
+	assert.Nil(t, err)
+	assert.Equal(t, filepath.Dir(manifestPath), projectPath)
+	assert.Equal(t, 1, requests, "a ref is fetched once")
+
+	_, err = workspace.Fetch("git+" + server.URL + "/org/catalog#v1.0/blueprints/missing")
+	assert.IsType(t, &wskderrors.FileReadError{}, err)
+
+	_, err = workspace.Fetch("git+" + server.URL + "/org/catalog#v2.0")
+	assert.IsType(t, &wskderrors.FileReadError{}, err, "unknown ref")
+
+	_, err = workspace.Fetch(server.URL + "/evil.zip")
+	assert.IsType(t, &wskderrors.FileReadError{}, err, "entries may not be extracted outside of the workspace")
+}
@@ -20,10 +20,14 @@ package utils
 import (
 	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"github.com/apache/incubator-openwhisk-client-go/whisk"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
 	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -68,16 +72,95 @@ type OpenWhiskInfo struct {
 var FileExtensionRuntimeKindMap map[string]string
 var SupportedRunTimes map[string][]string
 var DefaultRunTimes map[string]string
+// deprecated kinds of each runtime, which SupportedRunTimes leaves out
+var DeprecatedRunTimes map[string][]string
 
 
-// We could get the openwhisk info from bluemix through running the command
-// `curl -k https://openwhisk.ng.bluemix.net`
-// hard coding it here in case of network unavailable or failure.
+// path of the endpoint of an OpenWhisk deployment which describes its runtimes
+const RUNTIMES_API_PATH = "/api/v1"
+
+// runtimes fetched from a host are cached, a cached copy more recent than
+// RUNTIMES_CACHE_TTL is used without contacting the host
+const RUNTIMES_CACHE_TTL = 1 * time.Hour
+
+// directory of the cached runtimes, one file per host
+var RuntimesCacheDir = filepath.Join(GetHomeDirectory(), ".wskdeploy", "runtimes")
+
+// ParseOpenWhisk reads the runtimes supported by the OpenWhisk deployment at
+// apiHost, so that runtimes added to the platform can be used without a new
+// release of wskdeploy. The runtimes are read from (in order):
+//   (1) the cache, if it was refreshed within RUNTIMES_CACHE_TTL
+//   (2) the /api/v1 endpoint of the host, refreshing the cache
+//   (3) the cache, however old it is, when the host cannot be reached
+//   (4) the runtimes compiled into wskdeploy (RUNTIME_DETAILS)
 func ParseOpenWhisk(apiHost string) (op OpenWhiskInfo, err error) {
+	cachePath := runtimesCachePath(apiHost)
+	if len(cachePath) > 0 {
+		if info, err := os.Stat(cachePath); err == nil && time.Since(info.ModTime()) < RUNTIMES_CACHE_TTL {
+			if op, err = readRuntimesCache(cachePath); err == nil {
+				return op, nil
+			}
+		}
+	}
+
+	b, err := fetchRuntimes(apiHost)
+	if err == nil {
+		if err = json.Unmarshal(b, &op); err == nil && len(op.Runtimes) > 0 {
+			stdout := wski18n.T(wski18n.ID_MSG_UNMARSHAL_NETWORK)
+			whisk.Debug(whisk.DbgInfo, stdout)
+			writeRuntimesCache(cachePath, b)
+			return op, nil
+		}
+	}
+
+	if len(apiHost) > 0 {
+		// TODO() create an error
+		errMessage := "no runtimes found"
+		if err != nil {
+			errMessage = err.Error()
+		}
+		errString := wski18n.T(wski18n.ID_ERR_GET_RUNTIMES_X_err_X,
+			map[string]interface{}{"err": errMessage})
+		whisk.Debug(whisk.DbgWarn, errString)
+	}
+
+	if len(cachePath) > 0 {
+		if op, err = readRuntimesCache(cachePath); err == nil {
+			return op, nil
+		}
+	}
+
+	stdout := wski18n.T(wski18n.ID_MSG_UNMARSHAL_LOCAL)
+	whisk.Debug(whisk.DbgInfo, stdout)
+	op = OpenWhiskInfo{}
+	err = json.Unmarshal(RUNTIME_DETAILS, &op)
+	return
+}
+
+// RefreshRuntimes replaces the supported runtimes with the ones of the
+// OpenWhisk deployment at apiHost
+func RefreshRuntimes(apiHost string) error {
+	op, err := ParseOpenWhisk(apiHost)
+	if err != nil {
+		return err
+	}
+	SupportedRunTimes = ConvertToMap(op)
+	DefaultRunTimes = DefaultRuntimes(op)
+	DeprecatedRunTimes = DeprecatedRuntimes(op)
+	FileExtensionRuntimeKindMap = FileExtensionRuntimes(op)
+	return nil
+}
+
+func fetchRuntimes(apiHost string) ([]byte, error) {
+	if len(apiHost) == 0 {
+		return nil, errors.New(wski18n.T(wski18n.ID_ERR_RUNTIMES_NO_API_HOST))
+	}
+	req, err := http.NewRequest("GET", ApiHostURL(apiHost, RUNTIMES_API_PATH), nil)
+	if err != nil {
+		return nil, err
+	}
 	// TODO() create HTTP header constants and use them
-	ct := "application/json; charset=UTF-8"
-	req, _ := http.NewRequest("GET", "https://"+apiHost, nil)
-	req.Header.Set("Content-Type", ct)
+	req.Header.Set("Accept", "application/json")
 	tlsConfig := &tls.Config{
 		InsecureSkipVerify: true,
 	}
@@ -93,33 +176,59 @@ func ParseOpenWhisk(apiHost string) (op OpenWhiskInfo, err error) {
 
 	res, err := netClient.Do(req)
 	if err != nil {
-		// TODO() create an error
-		errString := wski18n.T(wski18n.ID_ERR_GET_RUNTIMES_X_err_X,
-			map[string]interface{}{"err": err.Error()})
-		whisk.Debug(whisk.DbgWarn, errString)
+		return nil, err
 	}
+	defer res.Body.Close()
 
-	if res != nil {
-		defer res.Body.Close()
+	// Local openwhisk deployment sometimes only returns "application/json" as the content type
+	if res.StatusCode != http.StatusOK || !strings.Contains(res.Header.Get("Content-Type"), "application/json") {
+		return nil, errors.New(res.Status)
 	}
+	return ioutil.ReadAll(res.Body)
+}
 
-	// Local openwhisk deployment sometimes only returns "application/json" as the content type
-	// TODO() create HTTP header constants and use them
-	if err != nil || !strings.Contains(ct, res.Header.Get("Content-Type")) {
-		stdout := wski18n.T(wski18n.ID_MSG_UNMARSHAL_LOCAL)
-		whisk.Debug(whisk.DbgInfo, stdout)
-		err = json.Unmarshal(RUNTIME_DETAILS, &op)
-	} else {
-		b, _ := ioutil.ReadAll(res.Body)
-		if b != nil && len(b) > 0 {
-			stdout := wski18n.T(wski18n.ID_MSG_UNMARSHAL_NETWORK)
-			whisk.Debug(whisk.DbgInfo, stdout)
-			err = json.Unmarshal(b, &op)
+// runtimesCachePath returns the path of the cached runtimes of the host, an
+// empty path if the runtimes of the host are not cached
+func runtimesCachePath(apiHost string) string {
+	if len(apiHost) == 0 || len(RuntimesCacheDir) == 0 {
+		return ""
+	}
+	host := strings.TrimPrefix(strings.TrimPrefix(ApiHostURL(apiHost, ""), "https://"), "http://")
+	name := strings.Map(func(r rune) rune {
+		if r == '.' || r == '-' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			return r
 		}
+		return '_'
+	}, strings.TrimSuffix(host, "/"))
+	return filepath.Join(RuntimesCacheDir, name+".json")
+}
+
+func readRuntimesCache(cachePath string) (op OpenWhiskInfo, err error) {
+	b, err := ioutil.ReadFile(cachePath)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(b, &op); err == nil && len(op.Runtimes) == 0 {
+		err = errors.New(wski18n.T(wski18n.ID_ERR_RUNTIMES_NONE_FOUND))
+	}
+	if err == nil {
+		stdout := wski18n.T(wski18n.ID_MSG_UNMARSHAL_CACHE_X_path_X,
+			map[string]interface{}{"path": cachePath})
+		whisk.Debug(whisk.DbgInfo, stdout)
 	}
 	return
 }
 
+// a cache which cannot be written only means the runtimes are fetched again
+func writeRuntimesCache(cachePath string, b []byte) {
+	if len(cachePath) == 0 {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(cachePath), 0755); err == nil {
+		ioutil.WriteFile(cachePath, b, 0644)
+	}
+}
+
 func ConvertToMap(op OpenWhiskInfo) (rt map[string][]string) {
 	rt = make(map[string][]string)
 	for k, v := range op.Runtimes {
@@ -147,6 +256,97 @@ func DefaultRuntimes(op OpenWhiskInfo) (rt map[string]string) {
 	return
 }
 
+func DeprecatedRuntimes(op OpenWhiskInfo) (rt map[string][]string) {
+	rt = make(map[string][]string)
+	for k, v := range op.Runtimes {
+		for i := range v {
+			if v[i].Deprecated {
+				rt[k] = append(rt[k], v[i].Kind)
+			}
+		}
+	}
+	return
+}
+
+// ResolveRuntimeKind returns the kind of the runtime of an action. A
+// supported kind, e.g. "nodejs:8", is pinned and kept as it is. The name of
+// a runtime, e.g. "nodejs", is resolved to its newest kind whose version is
+// the given version or starts with it, e.g. "swift:3.1.1" for "swift" and
+// version "3", or its newest kind if there is no version. Deprecated kinds
+// are only resolved to if no other kind matches, deprecated is then true.
+// The kind is empty if the runtime has no kind of that version, kinds the
+// server does not know are kept as they are.
+func ResolveRuntimeKind(runtime string, version string) (kind string, deprecated bool) {
+	_, isName := SupportedRunTimes[runtime]
+	if !isName {
+		_, isName = DeprecatedRunTimes[runtime]
+	}
+	if len(version) == 0 {
+		if CheckExistRuntime(runtime, SupportedRunTimes) {
+			return runtime, false
+		}
+		if !isName {
+			return runtime, CheckExistRuntime(runtime, DeprecatedRunTimes)
+		}
+	}
+	if !isName {
+		return "", false
+	}
+	if kind = newestRuntimeKind(SupportedRunTimes[runtime], version); len(kind) > 0 {
+		return kind, false
+	}
+	kind = newestRuntimeKind(DeprecatedRunTimes[runtime], version)
+	return kind, len(kind) > 0
+}
+
+func newestRuntimeKind(kinds []string, version string) string {
+	newest := ""
+	for _, kind := range kinds {
+		kindVersion := runtimeKindVersion(kind)
+		if len(version) > 0 && kindVersion != version && !strings.HasPrefix(kindVersion, version+".") {
+			continue
+		}
+		if len(newest) == 0 || compareRuntimeVersions(kindVersion, runtimeKindVersion(newest)) > 0 {
+			newest = kind
+		}
+	}
+	return newest
+}
+
+// the version of a kind, e.g. "3.1.1" for "swift:3.1.1"
+func runtimeKindVersion(kind string) string {
+	if index := strings.Index(kind, ":"); index >= 0 {
+		return kind[index+1:]
+	}
+	return ""
+}
+
+// compareRuntimeVersions compares the versions of two kinds number by number,
+// e.g. "10" is newer than "8" and "3.1.1" newer than "3"
+func compareRuntimeVersions(a string, b string) int {
+	partsA, partsB := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(partsA) || i < len(partsB); i++ {
+		if i >= len(partsA) || len(partsA[i]) == 0 {
+			return -1
+		}
+		if i >= len(partsB) || len(partsB[i]) == 0 {
+			return 1
+		}
+		numberA, errA := strconv.Atoi(partsA[i])
+		numberB, errB := strconv.Atoi(partsB[i])
+		switch {
+		case errA == nil && errB == nil && numberA != numberB:
+			if numberA > numberB {
+				return 1
+			}
+			return -1
+		case (errA != nil || errB != nil) && partsA[i] != partsB[i]:
+			return strings.Compare(partsA[i], partsB[i])
+		}
+	}
+	return 0
+}
+
 func FileExtensionRuntimes(op OpenWhiskInfo) (ext map[string]string) {
 	ext = make(map[string]string)
 	for k := range op.Runtimes {
@@ -0,0 +1,85 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// exit codes of the --scanner command, any other exit code rejects the action
+const (
+	SCANNER_EXIT_PASSED  = 0
+	SCANNER_EXIT_WARNING = 1
+)
+
+// environment variables the --scanner command is run with
+const (
+	SCANNER_ENV_ACTION   = "WSKDEPLOY_ACTION"
+	SCANNER_ENV_ARTIFACT = "WSKDEPLOY_ARTIFACT"
+)
+
+// ScanActionArtifact runs the --scanner command against the zip or source file
+// of an action before it is deployed, the path of the file is the last argument
+// of the command. The action is rejected when the scanner exits with a code
+// other than 0 or 1, a warning is printed when it exits with 1 unless in strict
+// mode where warnings reject the action as well.
+func (flags *WskdeployFlags) ScanActionArtifact(action string, artifact string) error {
+	args := strings.Fields(flags.Scanner)
+	if len(args) == 0 {
+		return nil
+	}
+	whisk.Debug(whisk.DbgInfo, wski18n.T(wski18n.ID_MSG_SCANNER_RUN_X_action_X_path_X,
+		map[string]interface{}{wski18n.KEY_ACTION: action, wski18n.KEY_PATH: artifact}))
+
+	command := exec.Command(args[0], append(args[1:], artifact)...)
+	command.Env = append(os.Environ(), SCANNER_ENV_ACTION+"="+action, SCANNER_ENV_ARTIFACT+"="+artifact)
+	output, err := command.CombinedOutput()
+	if err == nil {
+		return nil
+	}
+
+	exitCode := -1
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			exitCode = status.ExitStatus()
+		}
+	}
+	if exitCode < 0 {
+		return wskderrors.NewCommandError(flags.Scanner,
+			wski18n.T(wski18n.ID_ERR_SCANNER_RUN_X_command_X_err_X,
+				map[string]interface{}{wski18n.KEY_COMMAND: flags.Scanner, wski18n.KEY_ERR: err.Error()}))
+	}
+
+	result := strings.TrimSpace(string(output))
+	if exitCode == SCANNER_EXIT_WARNING && !flags.Strict {
+		wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_SCANNER_X_action_X_path_X_output_X,
+			map[string]interface{}{wski18n.KEY_ACTION: action, wski18n.KEY_PATH: artifact, wski18n.KEY_OUTPUT: result}))
+		return nil
+	}
+	return wskderrors.NewActionScanError(wski18n.T(wski18n.ID_ERR_SCANNER_REJECTED_X_action_X_path_X_code_X_output_X,
+		map[string]interface{}{wski18n.KEY_ACTION: action, wski18n.KEY_PATH: artifact,
+			wski18n.KEY_CODE: exitCode, wski18n.KEY_OUTPUT: result}), action, artifact, exitCode)
+}
@@ -0,0 +1,70 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanActionArtifact(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scanner")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	// the scanner exits with the code given by the environment
+	scanner := filepath.Join(dir, "scan.sh")
+	script := "#!/bin/sh\necho \"$WSKDEPLOY_ACTION $WSKDEPLOY_ARTIFACT\"\nexit $SCANNER_TEST_EXIT\n"
+	assert.Nil(t, ioutil.WriteFile(scanner, []byte(script), 0755))
+	defer os.Unsetenv("SCANNER_TEST_EXIT")
+
+	flags := &WskdeployFlags{}
+	assert.Nil(t, flags.ScanActionArtifact("hello/world", "hello.js"), "no scanner")
+
+	flags.Scanner = scanner + " --quiet"
+	os.Setenv("SCANNER_TEST_EXIT", "0")
+	assert.Nil(t, flags.ScanActionArtifact("hello/world", "hello.js"))
+
+	os.Setenv("SCANNER_TEST_EXIT", "1")
+	assert.Nil(t, flags.ScanActionArtifact("hello/world", "hello.js"), "warnings do not fail")
+	flags.Strict = true
+	err = flags.ScanActionArtifact("hello/world", "hello.js")
+	assert.IsType(t, &wskderrors.ActionScanError{}, err, "warnings fail in strict mode")
+	flags.Strict = false
+
+	os.Setenv("SCANNER_TEST_EXIT", "2")
+	err = flags.ScanActionArtifact("hello/world", "hello.js")
+	if assert.IsType(t, &wskderrors.ActionScanError{}, err) {
+		scanErr := err.(*wskderrors.ActionScanError)
+		assert.Equal(t, "hello/world", scanErr.Action)
+		assert.Equal(t, "hello.js", scanErr.Artifact)
+		assert.Equal(t, 2, scanErr.ExitCode)
+		assert.Contains(t, scanErr.Error(), "hello/world hello.js", "the output of the scanner is reported")
+	}
+
+	flags.Scanner = filepath.Join(dir, "missing.sh")
+	err = flags.ScanActionArtifact("hello/world", "hello.js")
+	assert.IsType(t, &wskderrors.CommandError{}, err)
+}
@@ -58,17 +58,12 @@ type LicenseItem struct {
 
 var license_json = LicenseJSON{}
 
-//Check if the license is valid
-//Check local data record at first
-//Then check remote json data
+//Check if the license is a valid SPDX license identifier or expression, which
+//is allowed by the license allow-list of Flags if any, see ValidateLicense()
+//A warning is displayed if it is not
 func CheckLicense(license string) bool {
-	// TODO(#673) Strict flag should cause an error to be generatd
-	if !LicenseLocalValidation(license) && !LicenseRemoteValidation(license) {
-		warningString := wski18n.T(
-			wski18n.ID_WARN_KEYVALUE_INVALID,
-			map[string]interface{}{
-				wski18n.KEY_KEY: license})
-		wskprint.PrintlnOpenWhiskWarning(warningString)
+	if err := Flags.ValidateLicense(license); err != nil {
+		wskprint.PrintlnOpenWhiskWarning(err.Error())
 		return false
 	}
 	return true
@@ -0,0 +1,133 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+)
+
+// version of wskdeploy used when the binary was not built with a release
+// version, i.e. main.Version is "unset" or a build timestamp
+const WSKDEPLOY_VERSION = "0.9.8"
+
+// version of the manifest and deployment file specification implemented
+const SPEC_VERSION = "0.9.1"
+
+// release versions of wskdeploy, as given to main.Version by tagged builds
+var releaseVersionRegex = regexp.MustCompile(`^v?[0-9]+\.[0-9]+(\.[0-9]+)?([-+].*)?$`)
+
+// GetWskdeployVersion returns the release version of the running binary
+func GetWskdeployVersion() string {
+	if releaseVersionRegex.MatchString(Flags.CliVersion) {
+		return strings.TrimPrefix(Flags.CliVersion, "v")
+	}
+	return WSKDEPLOY_VERSION
+}
+
+// ParseVersion parses a version of the form [v]major[.minor[.patch]], a pre-release
+// or build suffix (e.g. "-rc1" or "+incubating") is ignored
+func ParseVersion(version string) ([]int, error) {
+	v := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	parts := strings.Split(v, ".")
+	if len(v) == 0 || len(parts) > 3 {
+		return nil, errors.New(wski18n.T(wski18n.ID_ERR_VERSION_INVALID_X_version_X,
+			map[string]interface{}{wski18n.KEY_VERSION: version}))
+	}
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return nil, errors.New(wski18n.T(wski18n.ID_ERR_VERSION_INVALID_X_version_X,
+				map[string]interface{}{wski18n.KEY_VERSION: version}))
+		}
+		numbers[i] = n
+	}
+	return numbers, nil
+}
+
+// CompareVersions returns -1, 0 or 1 if version a is lower than, equal to or
+// greater than version b, missing minor and patch numbers are 0
+func CompareVersions(a string, b string) (int, error) {
+	va, err := ParseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	vb, err := ParseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	for i := range va {
+		if va[i] < vb[i] {
+			return -1, nil
+		}
+		if va[i] > vb[i] {
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// SatisfiesVersion checks a version against a constraint, a comma separated
+// list of comparisons which must all hold, e.g. ">=0.9.8, <2.0". A version
+// without an operator is a minimum version.
+func SatisfiesVersion(version string, constraint string) (bool, error) {
+	comparisons := strings.Split(constraint, ",")
+	for _, comparison := range comparisons {
+		comparison = strings.TrimSpace(comparison)
+		required := strings.TrimLeft(comparison, "<>=!")
+		operator := comparison[:len(comparison)-len(required)]
+		required = strings.TrimSpace(required)
+
+		result, err := CompareVersions(version, required)
+		if err != nil {
+			return false, err
+		}
+
+		var ok bool
+		switch operator {
+		case "", ">=":
+			ok = result >= 0
+		case ">":
+			ok = result > 0
+		case "<=":
+			ok = result <= 0
+		case "<":
+			ok = result < 0
+		case "=", "==":
+			ok = result == 0
+		case "!=":
+			ok = result != 0
+		default:
+			return false, errors.New(wski18n.T(wski18n.ID_ERR_VERSION_OPERATOR_INVALID_X_operator_X_constraint_X,
+				map[string]interface{}{wski18n.KEY_OPERATOR: operator, wski18n.KEY_CONSTRAINT: constraint}))
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,61 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSatisfiesVersion(t *testing.T) {
+	expected := map[string]bool{
+		"0.9.8":           true,
+		">=0.9.8":         true,
+		">0.9.8":          false,
+		">= 0.9, < 1.0":   true,
+		">=0.9.8, <0.9.8": false,
+		"<=v0.9.8-rc1":    true,
+		"=0.9.8":          true,
+		"!=0.9.8":         false,
+		"1":               false,
+		"<1":              true,
+	}
+	for constraint, satisfied := range expected {
+		ok, err := SatisfiesVersion("0.9.8", constraint)
+		assert.Nil(t, err, constraint)
+		assert.Equal(t, satisfied, ok, constraint)
+	}
+
+	for _, constraint := range []string{"", ">=", "~>0.9", ">=0.9.8,", "1.x", "1.2.3.4"} {
+		_, err := SatisfiesVersion("0.9.8", constraint)
+		assert.NotNil(t, err, constraint)
+	}
+}
+
+func TestGetWskdeployVersion(t *testing.T) {
+	defer func(version string) { Flags.CliVersion = version }(Flags.CliVersion)
+
+	// development builds are versioned with a timestamp
+	Flags.CliVersion = "2018-03-01T10:00:00"
+	assert.Equal(t, WSKDEPLOY_VERSION, GetWskdeployVersion())
+	Flags.CliVersion = "v1.0.0"
+	assert.Equal(t, "1.0.0", GetWskdeployVersion())
+}
@@ -0,0 +1,146 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// files of a Python action packaged with its dependencies, the OpenWhisk
+// Python runtime activates virtualenv/bin/activate_this.py of the zip file
+const (
+	PYTHON_REQUIREMENTS_FILE = "requirements.txt"
+	PYTHON_VIRTUALENV_DIR    = "virtualenv"
+)
+
+// directory the action is mounted to in the --build-image container
+const BUILD_IMAGE_DIR = "/tmp/action"
+
+// NeedsPythonVirtualenv reports whether the directory of a Python action lists
+// dependencies in requirements.txt which are not installed in a virtualenv
+// directory yet
+func NeedsPythonVirtualenv(dir string, runtime string) bool {
+	return strings.HasPrefix(runtime, "python") &&
+		FileExists(filepath.Join(dir, PYTHON_REQUIREMENTS_FILE)) &&
+		!IsDirectory(filepath.Join(dir, PYTHON_VIRTUALENV_DIR))
+}
+
+// VirtualenvBuilder installs the dependencies of the requirements.txt of a
+// Python action in a virtualenv directory next to its sources, in the
+// --build-image container if any so that native dependencies match the runtime
+type VirtualenvBuilder struct{}
+
+func (VirtualenvBuilder) Language() string {
+	return "python"
+}
+
+func (VirtualenvBuilder) Detect(source string) bool {
+	return NeedsPythonVirtualenv(source, "python")
+}
+
+func (VirtualenvBuilder) Build(build CodeBuild) (string, error) {
+	if err := copyDirectory(build.Source, build.Dir); err != nil {
+		return "", err
+	}
+
+	wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_VIRTUALENV_BUILD_X_action_X_path_X,
+		map[string]interface{}{wski18n.KEY_ACTION: build.Action, wski18n.KEY_PATH: filepath.Join(build.Source, PYTHON_REQUIREMENTS_FILE)}))
+	for _, args := range virtualenvCommands(build.Dir, build.Image) {
+		command := exec.Command(args[0], args[1:]...)
+		command.Dir = build.Dir
+		if output, err := command.CombinedOutput(); err != nil {
+			commandLine := strings.Join(args, " ")
+			return "", wskderrors.NewCommandError(commandLine,
+				wski18n.T(wski18n.ID_ERR_VIRTUALENV_BUILD_X_action_X_command_X_err_X_output_X,
+					map[string]interface{}{wski18n.KEY_ACTION: build.Action, wski18n.KEY_COMMAND: commandLine,
+						wski18n.KEY_ERR: err.Error(), wski18n.KEY_OUTPUT: strings.TrimSpace(string(output))}))
+		}
+	}
+	return build.Dir, nil
+}
+
+// virtualenvCommands returns the commands which build the virtualenv in dir,
+// with the virtualenv and pip installed locally unless an image is given with
+// --build-image
+func virtualenvCommands(dir string, image string) [][]string {
+	install := []string{"install", "--no-cache-dir", "-r", PYTHON_REQUIREMENTS_FILE}
+	if len(image) == 0 {
+		return [][]string{
+			{"virtualenv", PYTHON_VIRTUALENV_DIR},
+			append([]string{filepath.Join(dir, PYTHON_VIRTUALENV_DIR, "bin", "pip")}, install...),
+		}
+	}
+	docker := []string{"docker", "run", "--rm", "-v", dir + ":" + BUILD_IMAGE_DIR, "-w", BUILD_IMAGE_DIR}
+	// the files are owned by the user, so that they can be removed once zipped
+	if uid, gid := os.Getuid(), os.Getgid(); uid >= 0 && gid >= 0 {
+		docker = append(docker, "-u", strconv.Itoa(uid)+":"+strconv.Itoa(gid), "-e", "HOME="+BUILD_IMAGE_DIR)
+	}
+	script := "virtualenv " + PYTHON_VIRTUALENV_DIR + " && " + PYTHON_VIRTUALENV_DIR + "/bin/pip " + strings.Join(install, " ")
+	return [][]string{append(docker, image, "sh", "-c", script)}
+}
+
+// copyDirectory copies the regular files of src to dest, keeping their
+// permissions, version control directories are left out
+func copyDirectory(src string, dest string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, rel)
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return os.MkdirAll(target, 0755)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		return copyFile(path, target, info.Mode().Perm())
+	})
+}
+
+func copyFile(src string, dest string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,81 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNeedsPythonVirtualenv(t *testing.T) {
+	dir, err := ioutil.TempDir("", "virtualenv")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.False(t, NeedsPythonVirtualenv(dir, "python:3"), "no requirements.txt")
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, PYTHON_REQUIREMENTS_FILE), []byte("requests\n"), 0644))
+	assert.True(t, NeedsPythonVirtualenv(dir, "python:3"))
+	assert.False(t, NeedsPythonVirtualenv(dir, "nodejs:6"))
+	assert.Nil(t, os.Mkdir(filepath.Join(dir, PYTHON_VIRTUALENV_DIR), 0755))
+	assert.False(t, NeedsPythonVirtualenv(dir, "python:3"), "the virtualenv is already built")
+}
+
+func TestVirtualenvCommands(t *testing.T) {
+	commands := virtualenvCommands("/tmp/build", "")
+	assert.Equal(t, 2, len(commands))
+	assert.Equal(t, []string{"virtualenv", PYTHON_VIRTUALENV_DIR}, commands[0])
+	assert.Equal(t, filepath.Join("/tmp/build", PYTHON_VIRTUALENV_DIR, "bin", "pip"), commands[1][0])
+
+	commands = virtualenvCommands("/tmp/build", "openwhisk/python3action")
+	assert.Equal(t, 1, len(commands))
+	command := commands[0]
+	assert.Equal(t, "docker", command[0])
+	assert.Contains(t, command, "/tmp/build:"+BUILD_IMAGE_DIR)
+	assert.Equal(t, []string{"openwhisk/python3action", "sh", "-c",
+		"virtualenv virtualenv && virtualenv/bin/pip install --no-cache-dir -r requirements.txt"}, command[len(command)-4:])
+}
+
+func TestCopyDirectory(t *testing.T) {
+	src, err := ioutil.TempDir("", "virtualenv-src")
+	assert.Nil(t, err)
+	defer os.RemoveAll(src)
+	dest, err := ioutil.TempDir("", "virtualenv-dest")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dest)
+
+	assert.Nil(t, os.MkdirAll(filepath.Join(src, "lib"), 0755))
+	assert.Nil(t, os.MkdirAll(filepath.Join(src, ".git"), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(src, "__main__.py"), []byte("def main(args):\n    return args\n"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(src, "lib", "run.sh"), []byte("#!/bin/sh\n"), 0755))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(src, ".git", "HEAD"), []byte("ref: refs/heads/master\n"), 0644))
+
+	assert.Nil(t, copyDirectory(src, dest))
+	content, err := ioutil.ReadFile(filepath.Join(dest, "__main__.py"))
+	assert.Nil(t, err)
+	assert.Equal(t, "def main(args):\n    return args\n", string(content))
+	info, err := os.Stat(filepath.Join(dest, "lib", "run.sh"))
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	assert.False(t, FileExists(filepath.Join(dest, ".git")))
+}
@@ -18,15 +18,21 @@
 package utils
 
 import (
+	"crypto/rand"
 	"errors"
-	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"fmt"
+	"math/big"
+	"strconv"
 	"strings"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
 )
 
 //for web action support, code from wsk cli with tiny adjustments
 const WEB_EXPORT_ANNOT = "web-export"
 const RAW_HTTP_ANNOT = "raw-http"
 const FINAL_ANNOT = "final"
+const REQUIRE_WHISK_AUTH_ANNOT = "require-whisk-auth"
 
 func WebAction(webMode string, annotations whisk.KeyValueArr, fetch bool) (whisk.KeyValueArr, error) {
 	switch strings.ToLower(webMode) {
@@ -92,3 +98,47 @@ func deleteWebAnnotationKeys(annotations whisk.KeyValueArr) whisk.KeyValueArr {
 
 	return annotations
 }
+
+// WebSecure sets the "require-whisk-auth" annotation of a web action, as the
+// --web-secure flag of the wsk CLI: true generates a secret, false removes the
+// annotation, any other string or number is the secret. The secret is returned
+// along with whether it was generated.
+func WebSecure(webSecure interface{}, annotations whisk.KeyValueArr) (whisk.KeyValueArr, interface{}, bool, error) {
+	annotations = deleteKey(REQUIRE_WHISK_AUTH_ANNOT, annotations)
+
+	var secret interface{}
+	generated := false
+	switch value := webSecure.(type) {
+	case bool:
+		if !value {
+			return annotations, nil, false, nil
+		}
+		// numbers are decoded as float64 by the client, a secret below 2^53
+		// is read back unchanged from the deployed action
+		n, err := rand.Int(rand.Reader, big.NewInt(1<<53))
+		if err != nil {
+			return nil, nil, false, err
+		}
+		secret, generated = n.Int64(), true
+	case int, int64, uint64:
+		secret = value
+	case string:
+		switch strings.ToLower(value) {
+		case "true":
+			return WebSecure(true, annotations)
+		case "false":
+			return WebSecure(false, annotations)
+		case "":
+			return nil, nil, false, errors.New(value)
+		}
+		if n, err := strconv.Atoi(value); err == nil {
+			secret = n
+		} else {
+			secret = value
+		}
+	default:
+		return nil, nil, false, errors.New(fmt.Sprint(webSecure))
+	}
+
+	return addKeyValue(REQUIRE_WHISK_AUTH_ANNOT, secret, annotations), secret, generated, nil
+}
@@ -0,0 +1,661 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wskdeploy
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/deployers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/parsers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskenv"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// Deploy deploys the project given by utils.Flags, i.e. the command line of
+// wskdeploy. The manifest and deployment files are looked up in the project
+// path when they are not given.
+func Deploy(ctx context.Context) (Report, error) {
+	return deploy(ctx, &utils.Flags)
+}
+
+func deploy(ctx context.Context, flags *utils.WskdeployFlags) (report Report, err error) {
+	workspace, err := fetchRemoteProject(flags)
+	if err != nil {
+		return Report{}, err
+	}
+	if workspace != nil {
+		defer workspace.Remove()
+	}
+
+	projectPath := resolveProjectPath(flags)
+	if err := findProjectFiles(projectPath, wski18n.ID_MSG_MANIFEST_DEPLOY_X_path_X, flags); err != nil {
+		return Report{}, err
+	}
+	if err := LoadEnvFile(projectPath, flags); err != nil {
+		return Report{}, err
+	}
+
+	if !utils.MayExists(flags.ManifestPath) {
+		errString := wski18n.T(wski18n.ID_MSG_MANIFEST_FILE_NOT_FOUND_X_path_X,
+			map[string]interface{}{wski18n.KEY_PATH: flags.ManifestPath})
+		whisk.Debug(whisk.DbgError, errString)
+		return Report{}, wskderrors.NewErrorManifestFileNotFound(flags.ManifestPath, errString)
+	}
+
+	// report the deprecated keys found in the project files once deployment ends
+	defer parsers.Deprecations.Print()
+
+	deployer := newDeployer(ctx, projectPath, flags)
+	// master record of any dependency that has been downloaded
+	deployer.DependencyMaster = make(map[string]utils.DependencyRecord)
+
+	if flags.Preview {
+		return newReport(deployer, nil), previewDeployment(deployer)
+	}
+	if len(flags.ResultsFile) > 0 {
+		start := time.Now()
+		defer func() {
+			err = writeResults(deployer, time.Since(start), err)
+		}()
+	}
+
+	if err := SetDeployerClient(deployer); err != nil {
+		return Report{}, err
+	}
+	if !flags.SkipPreflight {
+		if err := deployer.Preflight(); err != nil {
+			return Report{}, err
+		}
+	}
+
+	if flags.Resume {
+		if err := deployer.LoadCheckpoint(); err != nil {
+			return Report{}, err
+		}
+	}
+
+	if err := deployer.ConstructDeploymentPlan(); err != nil {
+		return newReport(deployer, nil), err
+	}
+	if err := deployer.CheckExpectedTarget(); err != nil {
+		return newReport(deployer, nil), err
+	}
+	if err := deployer.CheckImmutableVersions(); err != nil {
+		return newReport(deployer, nil), err
+	}
+	suppressVerboseTraces()
+	if err := ctx.Err(); err != nil {
+		return newReport(deployer, nil), err
+	}
+
+	err = deployer.Deploy()
+	if err == nil && len(flags.OutputsFile) > 0 {
+		if err = deployer.DeployedOutputs.WriteFile(flags.OutputsFile); err == nil {
+			wskprint.PrintlnOpenWhiskSuccess(wski18n.T(wski18n.ID_MSG_OUTPUTS_WRITTEN_X_path_X,
+				map[string]interface{}{wski18n.KEY_PATH: flags.OutputsFile}))
+		}
+	}
+	entities := deployer.Checkpoint.Entities()
+	err = renderReport(deployer, deployers.NOTIFICATION_EVENT_DEPLOY, deployer.Deployment, entities, err)
+	if err == nil && flags.Tail {
+		err = deployer.TailActivations(ctx, deployers.DEFAULT_TAIL_INTERVAL)
+	}
+	return newReport(deployer, entities), err
+}
+
+// Undeploy undeploys the project given by utils.Flags, see Deploy()
+func Undeploy(ctx context.Context) (Report, error) {
+	return undeploy(ctx, &utils.Flags)
+}
+
+func undeploy(ctx context.Context, flags *utils.WskdeployFlags) (Report, error) {
+	workspace, err := fetchRemoteProject(flags)
+	if err != nil {
+		return Report{}, err
+	}
+	if workspace != nil {
+		defer workspace.Remove()
+	}
+
+	projectPath := resolveProjectPath(flags)
+	if len(flags.Snapshot) > 0 {
+		return undeploySnapshot(ctx, projectPath, flags)
+	}
+	deploymentPath := flags.DeploymentPath
+	if err := findProjectFiles(projectPath, wski18n.ID_MSG_MANIFEST_UNDEPLOY_X_path_X, flags); err != nil {
+		return Report{}, err
+	}
+	if len(deploymentPath) == 0 && len(flags.DeploymentPath) > 0 {
+		whisk.Debug(whisk.DbgInfo, wski18n.T(wski18n.ID_MSG_MANIFEST_UNDEPLOY_X_path_X,
+			map[string]interface{}{wski18n.KEY_PATH: flags.DeploymentPath}))
+	}
+	if err := LoadEnvFile(projectPath, flags); err != nil {
+		return Report{}, err
+	}
+
+	if !utils.FileExists(flags.ManifestPath) {
+		errString := wski18n.T(wski18n.ID_MSG_MANIFEST_FILE_NOT_FOUND_X_path_X,
+			map[string]interface{}{wski18n.KEY_PATH: flags.ManifestPath})
+		return Report{}, wskderrors.NewErrorManifestFileNotFound(flags.ManifestPath, errString)
+	}
+
+	// report the deprecated keys found in the project files once undeployment ends
+	defer parsers.Deprecations.Print()
+
+	deployer := newDeployer(ctx, projectPath, flags)
+	if err := SetDeployerClient(deployer); err != nil {
+		return Report{}, err
+	}
+	if !flags.SkipPreflight {
+		if err := deployer.Preflight(); err != nil {
+			return Report{}, err
+		}
+	}
+
+	verifiedPlan, err := deployer.ConstructUnDeploymentPlan()
+	if err != nil {
+		return newReport(deployer, nil), err
+	}
+	if err := deployer.CheckExpectedTarget(); err != nil {
+		return newReport(deployer, nil), err
+	}
+	suppressVerboseTraces()
+	if err := ctx.Err(); err != nil {
+		return newReport(deployer, nil), err
+	}
+
+	err = deployer.UnDeploy(verifiedPlan)
+	entities := verifiedPlan.Entities()
+	err = renderReport(deployer, deployers.NOTIFICATION_EVENT_UNDEPLOY, verifiedPlan, entities, err)
+	return newReport(deployer, entities), err
+}
+
+// undeploySnapshot undeploys the entities recorded by the last successful
+// deployment of the project, see deployers.DeploymentSnapshot. The manifest is
+// not read, it may have changed or been removed since. The snapshot is removed
+// once its entities are undeployed.
+func undeploySnapshot(ctx context.Context, projectPath string, flags *utils.WskdeployFlags) (Report, error) {
+	snapshotPath := flags.Snapshot
+	if snapshotPath == utils.SNAPSHOT_DEFAULT {
+		snapshotPath = deployers.GetSnapshotFilePath(projectPath)
+	}
+	if err := LoadEnvFile(projectPath, flags); err != nil {
+		return Report{}, err
+	}
+	wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_SNAPSHOT_UNDEPLOY_X_path_X,
+		map[string]interface{}{wski18n.KEY_PATH: snapshotPath}))
+
+	deployer := newDeployer(ctx, projectPath, flags)
+	if err := SetDeployerClient(deployer); err != nil {
+		return Report{}, err
+	}
+	if !flags.SkipPreflight {
+		if err := deployer.Preflight(); err != nil {
+			return Report{}, err
+		}
+	}
+
+	verifiedPlan, err := deployer.ConstructSnapshotUnDeploymentPlan(snapshotPath)
+	if err != nil {
+		return newReport(deployer, nil), err
+	}
+	suppressVerboseTraces()
+	if err := ctx.Err(); err != nil {
+		return newReport(deployer, nil), err
+	}
+
+	err = deployer.UnDeploy(verifiedPlan)
+	if err == nil && (!deployer.IsInteractive || deployer.InteractiveChoice) {
+		if removeErr := os.Remove(snapshotPath); removeErr != nil {
+			wskprint.PrintlnOpenWhiskWarning(removeErr.Error())
+		}
+	}
+	entities := verifiedPlan.Entities()
+	err = renderReport(deployer, deployers.NOTIFICATION_EVENT_UNDEPLOY, verifiedPlan, entities, err)
+	return newReport(deployer, entities), err
+}
+
+// Verify constructs the deployment plan of the project given by utils.Flags
+// and compares it with the entities deployed, see ServiceDeployer.VerifyDeployment().
+// Nothing is deployed.
+func Verify(ctx context.Context) ([]deployers.VerifyMismatch, error) {
+	return verify(ctx, &utils.Flags)
+}
+
+func verify(ctx context.Context, flags *utils.WskdeployFlags) ([]deployers.VerifyMismatch, error) {
+	workspace, err := fetchRemoteProject(flags)
+	if err != nil {
+		return nil, err
+	}
+	if workspace != nil {
+		defer workspace.Remove()
+	}
+
+	projectPath := resolveProjectPath(flags)
+	if err := findProjectFiles(projectPath, wski18n.ID_MSG_MANIFEST_DEPLOY_X_path_X, flags); err != nil {
+		return nil, err
+	}
+	if err := LoadEnvFile(projectPath, flags); err != nil {
+		return nil, err
+	}
+	if !utils.FileExists(flags.ManifestPath) {
+		errString := wski18n.T(wski18n.ID_MSG_MANIFEST_FILE_NOT_FOUND_X_path_X,
+			map[string]interface{}{wski18n.KEY_PATH: flags.ManifestPath})
+		return nil, wskderrors.NewErrorManifestFileNotFound(flags.ManifestPath, errString)
+	}
+
+	deployer := newDeployer(ctx, projectPath, flags)
+	deployer.IsInteractive = false
+	if err := SetDeployerClient(deployer); err != nil {
+		return nil, err
+	}
+	if !flags.SkipPreflight {
+		if err := deployer.Preflight(); err != nil {
+			return nil, err
+		}
+	}
+	if err := deployer.ConstructDeploymentPlan(); err != nil {
+		return nil, err
+	}
+	suppressVerboseTraces()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return deployer.VerifyDeployment()
+}
+
+// Compose constructs the deployment plan of the project given by utils.Flags
+// the way --preview does and returns it, with the inputs and annotations of
+// the deployment file bound. Nothing is sent to OpenWhisk, see previewDeployment().
+func Compose(ctx context.Context) (*deployers.DeploymentProject, error) {
+	return compose(ctx, &utils.Flags)
+}
+
+func compose(ctx context.Context, flags *utils.WskdeployFlags) (*deployers.DeploymentProject, error) {
+	workspace, err := fetchRemoteProject(flags)
+	if err != nil {
+		return nil, err
+	}
+	if workspace != nil {
+		defer workspace.Remove()
+	}
+
+	projectPath := resolveProjectPath(flags)
+	if err := findProjectFiles(projectPath, wski18n.ID_MSG_MANIFEST_DEPLOY_X_path_X, flags); err != nil {
+		return nil, err
+	}
+	if err := LoadEnvFile(projectPath, flags); err != nil {
+		return nil, err
+	}
+	if !utils.MayExists(flags.ManifestPath) {
+		errString := wski18n.T(wski18n.ID_MSG_MANIFEST_FILE_NOT_FOUND_X_path_X,
+			map[string]interface{}{wski18n.KEY_PATH: flags.ManifestPath})
+		return nil, wskderrors.NewErrorManifestFileNotFound(flags.ManifestPath, errString)
+	}
+
+	deployer := newDeployer(ctx, projectPath, flags)
+	deployer.DependencyMaster = make(map[string]utils.DependencyRecord)
+	if err := composeOffline(deployer); err != nil {
+		return nil, err
+	}
+	return deployer.Deployment, nil
+}
+
+// Validate checks the manifest and the deployment file of the project without
+// OpenWhisk and returns the problems found, see ServiceDeployer.Validate(). The
+// error is returned when the project cannot be validated, e.g. it has no
+// manifest file.
+func Validate(ctx context.Context) ([]error, error) {
+	return validate(ctx, &utils.Flags)
+}
+
+func validate(ctx context.Context, flags *utils.WskdeployFlags) ([]error, error) {
+	workspace, err := fetchRemoteProject(flags)
+	if err != nil {
+		return nil, err
+	}
+	if workspace != nil {
+		defer workspace.Remove()
+	}
+
+	projectPath := resolveProjectPath(flags)
+	if err := findProjectFiles(projectPath, wski18n.ID_MSG_MANIFEST_DEPLOY_X_path_X, flags); err != nil {
+		return nil, err
+	}
+	if err := LoadEnvFile(projectPath, flags); err != nil {
+		return nil, err
+	}
+	if !utils.MayExists(flags.ManifestPath) {
+		errString := wski18n.T(wski18n.ID_MSG_MANIFEST_FILE_NOT_FOUND_X_path_X,
+			map[string]interface{}{wski18n.KEY_PATH: flags.ManifestPath})
+		return nil, wskderrors.NewErrorManifestFileNotFound(flags.ManifestPath, errString)
+	}
+
+	deployer := newDeployer(ctx, projectPath, flags)
+	setOfflineConfig(deployer)
+	return deployer.Validate(), nil
+}
+
+// renderReport renders the --report-template, if any, once the project is
+// deployed or undeployed. The error of the deployment takes precedence over
+// the one of the template.
+func renderReport(deployer *deployers.ServiceDeployer, event string, plan *deployers.DeploymentProject, entities map[string][]string, err error) error {
+	if len(deployer.Flags.ReportTemplate) == 0 {
+		return err
+	}
+	report := deployer.NewDeploymentReport(event, plan, entities, err)
+	if renderErr := report.Render(deployer.Flags.ReportTemplate, deployer.Flags.ReportOutput); renderErr != nil {
+		if err != nil {
+			wskprint.PrintOpenWhiskError(renderErr.Error())
+			return err
+		}
+		return renderErr
+	}
+	return err
+}
+
+// writeResults writes the outcome of the deployment as a JUnit report to the
+// --results-file and returns the error of the deployment, if any, or the one of
+// the report
+func writeResults(deployer *deployers.ServiceDeployer, duration time.Duration, err error) error {
+	project := deployer.ProjectName
+	if len(project) == 0 {
+		project = deployer.ProjectPath
+		if projectPath, absErr := filepath.Abs(project); absErr == nil {
+			project = filepath.Base(projectPath)
+		}
+	}
+	if writeErr := deployer.Results.WriteJUnitFile(deployer.Flags.ResultsFile, project, duration, err); writeErr != nil {
+		if err != nil {
+			wskprint.PrintOpenWhiskError(writeErr.Error())
+			return err
+		}
+		return writeErr
+	}
+	wskprint.PrintlnOpenWhiskSuccess(wski18n.T(wski18n.ID_MSG_RESULTS_WRITTEN_X_path_X,
+		map[string]interface{}{wski18n.KEY_PATH: deployer.Flags.ResultsFile}))
+	return err
+}
+
+// fetchRemoteProject fetches the project path, manifest and deployment files
+// given by a git+https URL or the URL of a zip file into a workspace, their
+// flags are set to the fetched files so that relative paths of the project
+// are resolved against the fetched tree. It returns nil if nothing is remote.
+func fetchRemoteProject(flags *utils.WskdeployFlags) (*utils.RemoteWorkspace, error) {
+	paths := []*string{&flags.ProjectPath, &flags.ManifestPath, &flags.DeploymentPath}
+	var workspace *utils.RemoteWorkspace
+	for _, p := range paths {
+		if !utils.IsRemoteProject(*p) {
+			continue
+		}
+		if workspace == nil {
+			var err error
+			if workspace, err = utils.NewRemoteWorkspace(); err != nil {
+				return nil, wskderrors.NewFileReadError(*p, err.Error())
+			}
+		}
+		localPath, err := workspace.Fetch(*p)
+		if err != nil {
+			workspace.Remove()
+			return nil, err
+		}
+		*p = localPath
+	}
+
+	// the manifest or deployment file of a fetched directory, if it has one
+	if workspace != nil {
+		if manifestPath := flags.ManifestPath; utils.IsDirectory(manifestPath) {
+			if manifestPath = findProjectFile(manifestPath, utils.ManifestFileNameYaml, utils.ManifestFileNameYml); len(manifestPath) > 0 {
+				flags.ManifestPath = manifestPath
+			}
+		}
+		if deploymentPath := flags.DeploymentPath; utils.IsDirectory(deploymentPath) {
+			if deploymentPath = findProjectFile(deploymentPath, utils.DeploymentFileNameYaml, utils.DeploymentFileNameYml); len(deploymentPath) > 0 {
+				flags.DeploymentPath = deploymentPath
+			}
+		}
+	}
+	return workspace, nil
+}
+
+func newDeployer(ctx context.Context, projectPath string, flags *utils.WskdeployFlags) *deployers.ServiceDeployer {
+	deployer := deployers.NewServiceDeployer()
+	deployer.Flags = flags
+	deployer.ProjectPath = projectPath
+	deployer.ManifestPath = flags.ManifestPath
+	deployer.DeploymentPath = flags.DeploymentPath
+	deployer.IsDefault = flags.UseDefaults
+	deployer.IsInteractive = flags.UseInteractive
+	deployer.Context = ctx
+	return deployer
+}
+
+func resolveProjectPath(flags *utils.WskdeployFlags) string {
+	whisk.SetVerbose(flags.Verbose)
+	// Verbose mode is the only mode for wskdeploy to turn on all the debug messages,
+	// so set Verbose mode (and also debug mode) to true.
+	whisk.SetDebug(flags.Verbose)
+
+	projectPath := strings.TrimSpace(flags.ProjectPath)
+	if len(projectPath) == 0 {
+		projectPath = utils.DEFAULT_PROJECT_PATH
+	}
+	projectPath, _ = filepath.Abs(projectPath)
+	return projectPath
+}
+
+// findProjectFiles sets the manifest and deployment files of the flags to the
+// ones of the project path when they are not given, the manifest must exist
+func findProjectFiles(projectPath string, messageId string, flags *utils.WskdeployFlags) error {
+	if len(flags.ManifestPath) == 0 {
+		flags.ManifestPath = findProjectFile(projectPath, utils.ManifestFileNameYaml, utils.ManifestFileNameYml)
+		if len(flags.ManifestPath) == 0 {
+			errString := wski18n.T(wski18n.ID_MSG_MANIFEST_FILE_NOT_FOUND_X_path_X,
+				map[string]interface{}{wski18n.KEY_PATH: projectPath})
+			return wskderrors.NewErrorManifestFileNotFound(projectPath, errString)
+		}
+		whisk.Debug(whisk.DbgInfo, wski18n.T(messageId,
+			map[string]interface{}{wski18n.KEY_PATH: flags.ManifestPath}))
+	}
+
+	if len(flags.DeploymentPath) == 0 {
+		flags.DeploymentPath = findProjectFile(projectPath, utils.DeploymentFileNameYaml, utils.DeploymentFileNameYml)
+	}
+	return nil
+}
+
+func findProjectFile(projectPath string, names ...string) string {
+	for _, name := range names {
+		if candidate := path.Join(projectPath, name); utils.FileExists(candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// ResolveClientConfig returns the API host, auth key, namespace, client key
+// and cert of the project along with where each of them was read from. The
+// manifest and deployment files are optional, the values may all come from the
+// command line, the environment or .wskprops.
+func ResolveClientConfig() (*deployers.WhiskConfigValues, error) {
+	flags := &utils.Flags
+	projectPath := resolveProjectPath(flags)
+	if err := findProjectFiles(projectPath, wski18n.ID_MSG_MANIFEST_DEPLOY_X_path_X, flags); err != nil {
+		whisk.Debug(whisk.DbgInfo, err.Error())
+	}
+	if err := LoadEnvFile(projectPath, flags); err != nil {
+		return nil, err
+	}
+	return deployers.ResolveWhiskConfig(flags.CfgFile, flags.DeploymentPath, flags.ManifestPath, false, flags)
+}
+
+// SetDeployerClient creates the client of the deployer from the credentials,
+// API host and namespace of the command line, the project files or .wskprops
+func SetDeployerClient(deployer *deployers.ServiceDeployer) error {
+	clientConfig, err := deployers.NewWhiskConfig(deployer.Flags.CfgFile, deployer.DeploymentPath, deployer.ManifestPath, deployer.IsInteractive, deployer.Flags)
+	if err != nil {
+		return err
+	}
+
+	whiskClient, err := deployers.CreateNewClient(clientConfig)
+	if err != nil {
+		return err
+	}
+
+	deployer.Client = whiskClient
+	deployer.ClientConfig = clientConfig
+
+	apigwConfig, err := deployers.NewApigwConfig(clientConfig, deployer.Flags.CfgFile, deployer.DeploymentPath, deployer.ManifestPath, deployer.Flags)
+	if err != nil {
+		return err
+	}
+	if apigwConfig != clientConfig {
+		if deployer.ApigwClient, err = deployers.CreateNewClient(apigwConfig); err != nil {
+			return err
+		}
+	}
+	if deployer.ApigwAuth, err = deployers.NewApigwAuthProvider(apigwConfig, deployer.Flags); err != nil {
+		return err
+	}
+
+	// The auth, apihost and namespace have been chosen, so that we can check the supported runtimes here.
+	utils.RefreshRuntimes(clientConfig.Host)
+	return nil
+}
+
+// LoadEnvFile loads the variables of the --env-file or, when it is not given, of
+// the .env file of the project, before the manifest and deployment files are read.
+// With --env, the variables of the .env.<env> file next to it are loaded as well
+// and replace the ones of the .env file. The variables of the --secrets-file are
+// loaded last, their values are masked in the output.
+func LoadEnvFile(projectPath string, flags *utils.WskdeployFlags) error {
+	if err := loadEnvFiles(projectPath, flags); err != nil {
+		return err
+	}
+	if len(flags.SecretsFile) == 0 {
+		return nil
+	}
+	whisk.Debug(whisk.DbgInfo, wski18n.T(wski18n.ID_MSG_SECRETS_FILE_LOAD_X_path_X,
+		map[string]interface{}{wski18n.KEY_PATH: flags.SecretsFile}))
+	return wskenv.LoadSecretsFile(flags.SecretsFile)
+}
+
+func loadEnvFiles(projectPath string, flags *utils.WskdeployFlags) error {
+	dirs := []string{projectPath, filepath.Dir(flags.ManifestPath)}
+	envFile := flags.EnvFile
+	if len(envFile) == 0 {
+		envFile = findEnvFile(dirs, wskenv.DOTENV_FILE_NAME)
+	}
+	if err := loadEnvFile(envFile); err != nil {
+		return err
+	}
+
+	env := flags.Env
+	if len(env) == 0 {
+		return nil
+	}
+	if !envNameRegex.MatchString(env) {
+		return wskderrors.NewCommandError("--env",
+			wski18n.T(wski18n.ID_ERR_ENV_NAME_INVALID_X_name_X,
+				map[string]interface{}{wski18n.KEY_NAME: env}))
+	}
+
+	name := wskenv.DOTENV_FILE_NAME + "." + env
+	if len(flags.EnvFile) > 0 {
+		// e.g. config/.env.prod for --env-file config/.env
+		dirs = []string{filepath.Dir(flags.EnvFile)}
+		name = filepath.Base(flags.EnvFile) + "." + env
+	}
+	envFile = findEnvFile(dirs, name)
+	if len(envFile) == 0 {
+		wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_ENV_FILE_NOT_FOUND_X_name_X_path_X,
+			map[string]interface{}{wski18n.KEY_NAME: name, wski18n.KEY_PATH: dirs[0]}))
+		return nil
+	}
+	return loadEnvFile(envFile)
+}
+
+// names of environments, they are part of the name of their .env file
+var envNameRegex = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]*$`)
+
+func findEnvFile(dirs []string, name string) string {
+	for _, dir := range dirs {
+		if candidate := path.Join(dir, name); utils.FileExists(candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// previewDeployment writes the entities of the project as they would be
+// deployed to the standard output, see ServiceDeployer.Preview(). No
+// credentials are needed, the namespace is the one given by --namespace or
+// the default namespace, and the runtimes are the ones known to wskdeploy.
+func previewDeployment(deployer *deployers.ServiceDeployer) error {
+	if err := composeOffline(deployer); err != nil {
+		return err
+	}
+	return deployer.Preview(os.Stdout)
+}
+
+// composeOffline constructs the deployment plan without a client
+func composeOffline(deployer *deployers.ServiceDeployer) error {
+	setOfflineConfig(deployer)
+	return deployer.ConstructDeploymentPlan()
+}
+
+// setOfflineConfig sets the client configuration of a deployer which does not
+// contact OpenWhisk, with the default namespace unless given and the runtimes
+// compiled into wskdeploy
+func setOfflineConfig(deployer *deployers.ServiceDeployer) {
+	namespace := deployer.Flags.Namespace
+	if len(namespace) == 0 {
+		namespace = whisk.DEFAULT_NAMESPACE
+	}
+	deployer.ClientConfig = &whisk.Config{Namespace: namespace, Host: deployer.Flags.ApiHost}
+	deployer.IsInteractive = false
+	utils.RefreshRuntimes("")
+}
+
+// suppressVerboseTraces turns the HTTP traces of the client off once the project
+// has secrets, the requests it traces hold the values of the secret inputs
+func suppressVerboseTraces() {
+	if wskprint.HasSecrets() && whisk.IsVerbose() {
+		whisk.SetVerbose(false)
+		wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_SECRETS_VERBOSE_TRACES_OFF))
+	}
+}
+
+func loadEnvFile(envFile string) error {
+	if len(envFile) == 0 {
+		return nil
+	}
+	whisk.Debug(whisk.DbgInfo, wski18n.T(wski18n.ID_MSG_ENV_FILE_LOAD_X_path_X,
+		map[string]interface{}{wski18n.KEY_PATH: envFile}))
+	return wskenv.LoadEnvFile(envFile)
+}
@@ -0,0 +1,218 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wskdeploy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/deployers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+	"github.com/fsnotify/fsnotify"
+)
+
+// time the files must stop changing for before the project is redeployed, so
+// that saving several files or a build is deployed once
+const DEFAULT_WATCH_DEBOUNCE = 500 * time.Millisecond
+
+// Watch deploys the project given by utils.Flags, then watches its manifest,
+// deployment and .env files and the sources of its actions, and redeploys the
+// entities which changed once the files stop changing for debounce. Entities
+// removed from the project are not undeployed, unless --managed is given in
+// which case the whole project is redeployed. Watch returns when ctx is done.
+func Watch(ctx context.Context, debounce time.Duration) error {
+	flags := &utils.Flags
+	for _, location := range []string{flags.ProjectPath, flags.ManifestPath, flags.DeploymentPath} {
+		if utils.IsRemoteProject(location) {
+			return wskderrors.NewCommandError("watch", wski18n.T(wski18n.ID_ERR_WATCH_REMOTE_PROJECT_X_path_X,
+				map[string]interface{}{wski18n.KEY_PATH: location}))
+		}
+	}
+	projectPath := resolveProjectPath(flags)
+	if err := findProjectFiles(projectPath, wski18n.ID_MSG_MANIFEST_DEPLOY_X_path_X, flags); err != nil {
+		return err
+	}
+	if debounce <= 0 {
+		debounce = DEFAULT_WATCH_DEBOUNCE
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	w := &projectWatcher{watcher: watcher, flags: flags, dirs: make(map[string]bool), ignored: make(map[string]bool)}
+	w.deploy(ctx, projectPath)
+
+	var redeploy <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-watcher.Events:
+			if w.isIgnored(event.Name) {
+				continue
+			}
+			whisk.Debug(whisk.DbgInfo, wski18n.T(wski18n.ID_MSG_WATCH_CHANGED_X_path_X,
+				map[string]interface{}{wski18n.KEY_PATH: event.Name}))
+			redeploy = time.After(debounce)
+		case err := <-watcher.Errors:
+			wskprint.PrintlnOpenWhiskWarning(err.Error())
+		case <-redeploy:
+			redeploy = nil
+			w.deploy(ctx, projectPath)
+		}
+	}
+}
+
+type projectWatcher struct {
+	watcher *fsnotify.Watcher
+	flags   *utils.WskdeployFlags
+	// entities of the last successful deployment, nil until the project is deployed
+	deployed *deployers.HistoryDeployment
+	dirs     map[string]bool
+	// zip files written when the directories of actions are zipped
+	ignored map[string]bool
+}
+
+func (w *projectWatcher) deploy(ctx context.Context, projectPath string) {
+	if err := w.redeploy(ctx, projectPath); err != nil {
+		wskprint.PrintlnOpenWhiskError(err.Error())
+	}
+	wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_WATCH_WAITING_X_path_X,
+		map[string]interface{}{wski18n.KEY_PATH: projectPath}))
+}
+
+// redeploy composes the project again and deploys the entities which changed
+// since the last successful deployment, all of them the first time
+func (w *projectWatcher) redeploy(ctx context.Context, projectPath string) error {
+	// the project files are watched even if they cannot be parsed yet
+	w.add(projectPath)
+	w.add(filepath.Dir(w.flags.ManifestPath))
+	if len(w.flags.DeploymentPath) > 0 {
+		w.add(filepath.Dir(w.flags.DeploymentPath))
+	}
+
+	if err := LoadEnvFile(projectPath, w.flags); err != nil {
+		return err
+	}
+	deployer := newDeployer(ctx, projectPath, w.flags)
+	deployer.DependencyMaster = make(map[string]utils.DependencyRecord)
+	if err := SetDeployerClient(deployer); err != nil {
+		return err
+	}
+	if w.deployed == nil && !w.flags.SkipPreflight {
+		if err := deployer.Preflight(); err != nil {
+			return err
+		}
+	}
+	if err := deployer.ConstructDeploymentPlan(); err != nil {
+		return err
+	}
+	w.watchSources(deployer)
+	if err := deployer.CheckImmutableVersions(); err != nil {
+		return err
+	}
+
+	current := deployer.NewHistoryDeployment(time.Now().UTC())
+	if w.deployed != nil && !w.flags.Managed {
+		changes := deployer.RetainChanges(*w.deployed, current)
+		if len(changes) == 0 {
+			wskprint.PrintlnOpenWhiskStatus(wski18n.T(wski18n.ID_MSG_WATCH_NO_CHANGES))
+			return nil
+		}
+		for _, change := range changes {
+			if change.Change == deployers.HISTORY_CHANGE_REMOVED {
+				wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_WATCH_REMOVED_X_key_X_name_X,
+					map[string]interface{}{wski18n.KEY_KEY: change.Entity, wski18n.KEY_NAME: change.Name}))
+			} else {
+				wskprint.PrintlnOpenWhiskStatus(change.String())
+			}
+		}
+	}
+	suppressVerboseTraces()
+	if err := deployer.Deploy(); err != nil {
+		return err
+	}
+	w.deployed = &current
+	return nil
+}
+
+// watchSources watches the files and directories of the code of the actions,
+// relative to the manifest unless they are found as they are
+func (w *projectWatcher) watchSources(deployer *deployers.ServiceDeployer) {
+	manifestDir := filepath.Dir(deployer.ManifestPath)
+	for _, pack := range deployer.Deployment.Packages {
+		for _, record := range pack.Actions {
+			source := record.Filepath
+			if len(source) == 0 || strings.HasPrefix(source, "http") {
+				continue
+			}
+			if !utils.FileExists(source) {
+				source = filepath.Join(manifestDir, source)
+			}
+			if !utils.IsDirectory(source) {
+				w.add(filepath.Dir(source))
+				continue
+			}
+			if zipName, err := filepath.Abs(filepath.Clean(source) + ".zip"); err == nil {
+				w.ignored[zipName] = true
+			}
+			filepath.Walk(source, func(path string, info os.FileInfo, err error) error {
+				if err != nil || !info.IsDir() {
+					return nil
+				}
+				if info.Name() == ".git" {
+					return filepath.SkipDir
+				}
+				w.add(path)
+				return nil
+			})
+		}
+	}
+}
+
+func (w *projectWatcher) add(dir string) {
+	dir, err := filepath.Abs(dir)
+	if err != nil || w.dirs[dir] {
+		return
+	}
+	if err := w.watcher.Add(dir); err != nil {
+		wskprint.PrintlnOpenWhiskWarning(err.Error())
+		return
+	}
+	w.dirs[dir] = true
+}
+
+// isIgnored reports the files written by wskdeploy itself, i.e. its
+// checkpoint and history and the zip files of actions
+func (w *projectWatcher) isIgnored(name string) bool {
+	if strings.HasPrefix(filepath.Base(name), ".wskdeploy") {
+		return true
+	}
+	name, err := filepath.Abs(name)
+	return err == nil && w.ignored[name]
+}
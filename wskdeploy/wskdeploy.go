@@ -0,0 +1,281 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package wskdeploy deploys and undeploys projects from other Go programs, the
+// way the wskdeploy command does, e.g.
+//
+//	report, err := wskdeploy.DeployProject(ctx, wskdeploy.ProjectConfig{
+//		ProjectPath: "./myproject",
+//		ApiHost:     "openwhisk.example.com",
+//		Auth:        auth,
+//		Namespace:   "guest",
+//	})
+//
+// Errors are returned, never printed with os.Exit, the messages of the
+// deployment are still printed to the standard output. ComposeProject()
+// returns the entities of a project without deploying them.
+package wskdeploy
+
+import (
+	"context"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/apache/incubator-openwhisk-wskdeploy/deployers"
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskenv"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// ProjectConfig is the project to deploy or undeploy and how, its fields are
+// the flags of the wskdeploy command
+type ProjectConfig struct {
+	ProjectPath    string // the current directory if empty
+	ManifestPath   string // manifest.yaml (or .yml) of the project path if empty
+	DeploymentPath string // deployment.yaml (or .yml) of the project path if any
+	ProjectName    string // project selected when the manifest defines several projects
+	EnvFile        string // the .env file of the project if empty
+	Env            string // environment whose .env.<env> file is loaded as well
+	SecretsFile    string // .env file of variables whose values are masked in the output
+
+	// credentials, the ones of the project files, the profile or the config
+	// file (~/.wskprops by default) are used for those which are empty
+	ApiHost    string
+	Auth       string
+	Namespace  string
+	ApiVersion string
+	Key        string
+	Cert       string
+	ConfigFile string
+	Profile    string
+
+	ApigwAccessToken string
+	ApigwHost        string
+	IamApiKey        string
+
+	Managed             bool
+	Strict              bool
+	UseDefaults         bool
+	Verbose             bool
+	Resume              bool
+	ContinueOnError     bool
+	Parallel            int           // number of actions deployed concurrently, 1 if 0
+	ParallelFetches     int           // number of dependencies fetched concurrently, utils.DEFAULT_PARALLEL_FETCHES if 0
+	RateLimit           float64       // requests per second sent to the OpenWhisk server, no limit if 0, see deployers.Throttle
+	Burst               int           // requests sent at once within the rate limit, the rate rounded up if 0
+	ConcurrentRequests  int           // most requests in flight per namespace, no limit if 0
+	ReuseDependencies   bool          // dependencies already deployed from the same location and version are skipped, see deployers.DEPENDENCY_REF_ANNOT
+	EntityTimeout       time.Duration // time allowed to deploy an entity, no limit if 0
+	Packages            []string      // names or globs of the packages, all packages if empty
+	Overlays            []string      // YAML files merged over the manifest, see parsers.ApplyManifestOverlays()
+	Set                 []string      // values set at paths of the manifest, see parsers.ApplyManifestOverrides()
+	Params              []string      // parameters set on entities, see deployers.ServiceDeployer.ApplyEntityOverrides()
+	Annotations         []string      // annotations set on entities, see deployers.ServiceDeployer.ApplyEntityOverrides()
+	DeployAs            string        // suffix of the packages and triggers deployed side by side, see deployers.ServiceDeployer.ApplyDeployAs()
+	Rollback            bool          // the rules and APIs are switched back to the previous DeployAs
+	Tail                bool          // the activations are printed once deployed until the context is done, see deployers.ServiceDeployer.TailActivations()
+	ExcludePackages     []string
+	LicenseAllowList    string
+	ReportTemplate      string // Go text/template the deployment is reported with
+	ReportOutput        string // file of the report, the standard output if empty
+	Scanner             string // command run against the code of each action, see utils.ScanActionArtifact()
+	SecretsFromEnv      bool   // secret inputs may only be set from variables, see parsers.RegisterSecretParameter()
+	OutputsFile         string // JSON file the Outputs of the deployment are written to, if any
+	ResultsFile         string // JUnit report of the entities deployed, see deployers.EntityResults.JUnit()
+	OverrideTarget      bool   // the project is deployed even if the credentials do not match its expected-target
+	SkipPreflight       bool   // the API host is not checked before the project is deployed, see ServiceDeployer.Preflight()
+	Preview             bool   // the entities are written to the standard output rather than deployed, see ServiceDeployer.Preview()
+	AllowDepSideEffects bool   // dependencies may deploy triggers, rules and APIs, see ServiceDeployer.CheckDependencyPolicy()
+	MaxCodeSize         int64  // size of the code of an action, utils.DEFAULT_MAX_ACTION_CODE_SIZE if 0
+	History             bool   // the entities deployed are recorded in the history of the project, see deployers.ReadHistory()
+	NamingConventions   string // file or URL of the patterns the names of entities must match, see utils.ReadNamingConventions()
+	ImmutableVersions   bool   // the versions of packages may not be deployed again with another content, see ServiceDeployer.CheckImmutableVersions()
+	BuildImage          string // docker image the virtualenv of Python actions is built in, see utils.VirtualenvBuilder
+	Provider            string // name or file of the distribution of OpenWhisk deployed to, see utils.ReadProvider()
+	AllowEmpty          bool   // manifests without packages and packages without entities are deployed, see deployers.ValidateNotEmpty()
+	EncryptionProvider  string // provider the inputs declared encrypted are encrypted by, see utils.EncryptInput()
+	Snapshot            string // snapshot undeployed instead of the manifest, utils.SNAPSHOT_DEFAULT for the one of the project path
+}
+
+// Report is the result of a deployment or undeployment
+type Report struct {
+	ProjectName    string
+	ManifestPath   string
+	DeploymentPath string
+	ApiHost        string
+	Namespace      string
+	// entities deployed (or undeployed) keyed by type, e.g. "action": ["hello/world"]
+	Entities map[string][]string
+	// entities which failed when the deployment continues on errors
+	Failures []string
+	// values known once entities are deployed, e.g. the URLs of web actions,
+	// see deployers.DeployedOutputs.Tree()
+	Outputs map[string]interface{}
+}
+
+// the runtimes of the API host, see utils.RefreshRuntimes(), the variables of
+// .env files and the secrets masked in the output are shared by the process,
+// projects are deployed one at a time and the variables and secrets of each
+// are cleared once it is done, see endProject()
+var projectMt sync.Mutex
+
+func endProject() {
+	wskenv.ClearEnvFiles()
+	wskprint.ClearSecrets()
+	projectMt.Unlock()
+}
+
+// DeployProject deploys the project of the configuration. The deployment stops
+// before the next entity once the context is done, the entities deployed so far
+// are reported along with the error of the context.
+func DeployProject(ctx context.Context, config ProjectConfig) (Report, error) {
+	projectMt.Lock()
+	defer endProject()
+	return deploy(ctx, newFlags(config))
+}
+
+// UndeployProject undeploys the project of the configuration, see DeployProject()
+func UndeployProject(ctx context.Context, config ProjectConfig) (Report, error) {
+	projectMt.Lock()
+	defer endProject()
+	return undeploy(ctx, newFlags(config))
+}
+
+// VerifyProject compares the entities deployed with the project of the
+// configuration, see DeployProject()
+func VerifyProject(ctx context.Context, config ProjectConfig) ([]deployers.VerifyMismatch, error) {
+	projectMt.Lock()
+	defer endProject()
+	return verify(ctx, newFlags(config))
+}
+
+// ComposeProject returns the deployment plan of the project of the
+// configuration: its packages, actions, sequences, triggers, rules and APIs,
+// with the inputs and annotations of the deployment file bound, e.g. to check
+// them in the tests of the project. Nothing is sent to OpenWhisk and no
+// credentials are needed, the namespace is the default one unless given.
+func ComposeProject(ctx context.Context, config ProjectConfig) (*deployers.DeploymentProject, error) {
+	projectMt.Lock()
+	defer endProject()
+	return compose(ctx, newFlags(config))
+}
+
+// ValidateProject checks the project of the configuration without OpenWhisk and
+// returns the problems found, see Validate()
+func ValidateProject(ctx context.Context, config ProjectConfig) ([]error, error) {
+	projectMt.Lock()
+	defer endProject()
+	return validate(ctx, newFlags(config))
+}
+
+// newFlags returns the flags of the configuration, the deployers and parsers
+// of the project read them rather than utils.Flags
+func newFlags(config ProjectConfig) *utils.WskdeployFlags {
+	flags := &utils.WskdeployFlags{}
+	flags.ProjectPath = config.ProjectPath
+	flags.ManifestPath = config.ManifestPath
+	flags.DeploymentPath = config.DeploymentPath
+	flags.ProjectName = config.ProjectName
+	flags.EnvFile = config.EnvFile
+	flags.Env = config.Env
+	flags.SecretsFile = config.SecretsFile
+	flags.ApiHost = config.ApiHost
+	flags.Auth = config.Auth
+	flags.Namespace = config.Namespace
+	flags.ApiVersion = config.ApiVersion
+	flags.Key = config.Key
+	flags.Cert = config.Cert
+	flags.CfgFile = config.ConfigFile
+	if len(flags.CfgFile) == 0 {
+		flags.CfgFile = path.Join(utils.GetHomeDirectory(), whisk.DEFAULT_LOCAL_CONFIG)
+	}
+	flags.Profile = config.Profile
+	flags.ApigwAccessToken = config.ApigwAccessToken
+	flags.ApigwHost = config.ApigwHost
+	flags.IamApiKey = config.IamApiKey
+	flags.Managed = config.Managed
+	flags.Strict = config.Strict
+	flags.UseDefaults = config.UseDefaults
+	flags.UseInteractive = false
+	flags.Verbose = config.Verbose
+	flags.Resume = config.Resume
+	flags.ContinueOnError = config.ContinueOnError
+	flags.Parallel = config.Parallel
+	if flags.Parallel <= 0 {
+		flags.Parallel = 1
+	}
+	flags.ParallelFetches = config.ParallelFetches
+	flags.ReuseDependencies = config.ReuseDependencies
+	if flags.ParallelFetches <= 0 {
+		flags.ParallelFetches = utils.DEFAULT_PARALLEL_FETCHES
+	}
+	flags.RateLimit = config.RateLimit
+	flags.Burst = config.Burst
+	flags.MaxConcurrentRequests = config.ConcurrentRequests
+	flags.EntityTimeout = config.EntityTimeout
+	flags.Packages = config.Packages
+	flags.ExcludePackages = config.ExcludePackages
+	flags.LicenseAllowList = config.LicenseAllowList
+	flags.ReportTemplate = config.ReportTemplate
+	flags.ReportOutput = config.ReportOutput
+	flags.Scanner = config.Scanner
+	flags.SecretsFromEnv = config.SecretsFromEnv
+	flags.OutputsFile = config.OutputsFile
+	flags.ResultsFile = config.ResultsFile
+	flags.OverrideTarget = config.OverrideTarget
+	flags.SkipPreflight = config.SkipPreflight
+	flags.Preview = config.Preview
+	flags.AllowDepSideEffects = config.AllowDepSideEffects
+	flags.MaxCodeSize = config.MaxCodeSize
+	flags.History = config.History
+	flags.NamingConventions = config.NamingConventions
+	flags.ImmutableVersions = config.ImmutableVersions
+	flags.BuildImage = config.BuildImage
+	flags.Overlays = config.Overlays
+	flags.Set = config.Set
+	flags.Params = config.Params
+	flags.Annotations = config.Annotations
+	flags.DeployAs = config.DeployAs
+	flags.Rollback = config.Rollback
+	flags.Tail = config.Tail
+	flags.Provider = config.Provider
+	flags.AllowEmpty = config.AllowEmpty
+	flags.Snapshot = config.Snapshot
+	flags.EncryptionProvider = config.EncryptionProvider
+	return flags
+}
+
+func newReport(deployer *deployers.ServiceDeployer, entities map[string][]string) Report {
+	report := Report{
+		ProjectName:    deployer.ProjectName,
+		ManifestPath:   deployer.ManifestPath,
+		DeploymentPath: deployer.DeploymentPath,
+		Entities:       entities,
+		Failures:       deployer.Failures.List(),
+		Outputs:        deployer.DeployedOutputs.Tree(),
+	}
+	if report.Entities == nil {
+		report.Entities = make(map[string][]string)
+	}
+	if deployer.ClientConfig != nil {
+		report.ApiHost = deployer.ClientConfig.Host
+		report.Namespace = deployer.ClientConfig.Namespace
+	}
+	return report
+}
@@ -0,0 +1,162 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wskdeploy
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/utils"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskenv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeployProject_ManifestNotFound(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wskdeploy")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	utils.Flags.ProjectPath = "cli_project_path"
+	defer func() { utils.Flags.ProjectPath = "" }()
+
+	for _, run := range []func(context.Context, ProjectConfig) (Report, error){DeployProject, UndeployProject} {
+		_, err := run(context.Background(), ProjectConfig{ProjectPath: dir, Parallel: 4})
+		assert.NotNil(t, err)
+		_, ok := err.(*wskderrors.ErrorManifestFileNotFound)
+		assert.True(t, ok, "the error is returned, not printed")
+
+		assert.Equal(t, "cli_project_path", utils.Flags.ProjectPath, "the flags of the command line are left as they are")
+		assert.Empty(t, utils.Flags.ManifestPath)
+		assert.Equal(t, 0, utils.Flags.Parallel)
+	}
+}
+
+func TestLoadEnvFile_Env(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wskdeploy")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, ".env"), []byte("WSKDEPLOY_TEST_HOST=dev.example.com\nWSKDEPLOY_TEST_NAME=hello\n"), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, ".env.prod"), []byte("WSKDEPLOY_TEST_HOST=example.com\n"), 0644))
+	defer wskenv.ClearEnvFiles()
+
+	flags := &utils.WskdeployFlags{Env: "prod"}
+	assert.Nil(t, LoadEnvFile(dir, flags))
+	assert.Equal(t, "example.com", wskenv.Getenv("WSKDEPLOY_TEST_HOST"))
+	assert.Equal(t, "hello", wskenv.Getenv("WSKDEPLOY_TEST_NAME"))
+
+	wskenv.ClearEnvFiles()
+	flags.Env = "staging"
+	assert.Nil(t, LoadEnvFile(dir, flags), "an environment without variables is not an error")
+	assert.Equal(t, "dev.example.com", wskenv.Getenv("WSKDEPLOY_TEST_HOST"))
+
+	flags.Env = "../prod"
+	err = LoadEnvFile(dir, flags)
+	assert.NotNil(t, err)
+	_, ok := err.(*wskderrors.CommandError)
+	assert.True(t, ok)
+}
+
+func TestComposeProject(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wskdeploy")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	manifest := `project:
+  name: hello
+  packages:
+    hello:
+      actions:
+        greeting:
+          function: greeting.js
+          runtime: nodejs:6
+          inputs:
+            name: World
+      triggers:
+        everyHour:
+      rules:
+        greetingRule:
+          trigger: everyHour
+          action: greeting
+`
+	deployment := `project:
+  name: hello
+  packages:
+    hello:
+      actions:
+        greeting:
+          inputs:
+            name: Bernie
+`
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "manifest.yaml"), []byte(manifest), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "deployment.yaml"), []byte(deployment), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "greeting.js"), []byte("function main() { return {} }"), 0644))
+
+	plan, err := ComposeProject(context.Background(), ProjectConfig{ProjectPath: dir, Namespace: "guest"})
+	assert.Nil(t, err)
+	greeting := plan.Packages["hello"].Actions["greeting"].Action
+	assert.Equal(t, "Bernie", greeting.Parameters.GetValue("name"), "the deployment file is bound")
+	assert.Equal(t, "nodejs:6", greeting.Exec.Kind)
+	assert.NotNil(t, plan.Triggers["everyHour"])
+	assert.Equal(t, "hello/greeting", plan.Rules["greetingRule"].Action)
+	assert.Empty(t, utils.Flags.ManifestPath, "the flags of the command line are left as they are")
+}
+
+func TestComposeProject_Concurrent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wskdeploy")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	manifest := `project:
+  name: hello
+  packages:
+    hello:
+      actions:
+        greeting:
+          function: greeting.js
+          runtime: nodejs:6
+    goodbye:
+      actions:
+        farewell:
+          function: greeting.js
+          runtime: nodejs:6
+`
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "manifest.yaml"), []byte(manifest), 0644))
+	assert.Nil(t, ioutil.WriteFile(filepath.Join(dir, "greeting.js"), []byte("function main() { return {} }"), 0644))
+
+	// each project is composed with the flags of its own configuration, not
+	// the ones of the others or of utils.Flags
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		pkg := []string{"hello", "goodbye"}[i%2]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			plan, err := ComposeProject(context.Background(), ProjectConfig{ProjectPath: dir, Packages: []string{pkg}})
+			if assert.Nil(t, err) {
+				assert.Equal(t, 1, len(plan.Packages))
+				assert.NotNil(t, plan.Packages[pkg])
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,151 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wskderrors
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Error categories, the exit code of wskdeploy tells them apart, see ExitCode()
+const (
+	CATEGORY_PARSE      = "parse"      // a file could not be read or parsed
+	CATEGORY_VALIDATION = "validation" // the files or flags are invalid
+	CATEGORY_NETWORK    = "network"    // OpenWhisk could not be reached or failed
+	CATEGORY_AUTH       = "auth"       // the credentials are missing or refused
+	CATEGORY_CONFLICT   = "conflict"   // an entity conflicts with a deployed one
+	CATEGORY_LIMIT      = "limit"      // a limit of OpenWhisk is exceeded
+)
+
+// Exit codes of wskdeploy by category, EXIT_CODE_ERROR for errors of no category
+const (
+	EXIT_CODE_ERROR      = 1
+	EXIT_CODE_PARSE      = 2
+	EXIT_CODE_VALIDATION = 3
+	EXIT_CODE_NETWORK    = 4
+	EXIT_CODE_AUTH       = 5
+	EXIT_CODE_CONFLICT   = 6
+	EXIT_CODE_LIMIT      = 7
+)
+
+var categoryExitCodes = map[string]int{
+	CATEGORY_PARSE:      EXIT_CODE_PARSE,
+	CATEGORY_VALIDATION: EXIT_CODE_VALIDATION,
+	CATEGORY_NETWORK:    EXIT_CODE_NETWORK,
+	CATEGORY_AUTH:       EXIT_CODE_AUTH,
+	CATEGORY_CONFLICT:   EXIT_CODE_CONFLICT,
+	CATEGORY_LIMIT:      EXIT_CODE_LIMIT,
+}
+
+// ErrorCode is the stable numeric code of a type of error and its category,
+// the hundreds of the code are the ones of its category
+type ErrorCode struct {
+	Code     int
+	Category string
+}
+
+// the codes of the types of errors, the codes may not be changed once released
+var errorCodes = map[string]ErrorCode{
+	ERROR_FILE_READ_ERROR:              {101, CATEGORY_PARSE},
+	ERROR_MANIFEST_FILE_NOT_FOUND:      {102, CATEGORY_PARSE},
+	ERROR_YAML_PARSER_ERROR:            {103, CATEGORY_PARSE},
+	ERROR_COMMAND_FAILED:               {201, CATEGORY_VALIDATION},
+	ERROR_YAML_FILE_FORMAT_ERROR:       {202, CATEGORY_VALIDATION},
+	ERROR_YAML_PARAMETER_TYPE_MISMATCH: {203, CATEGORY_VALIDATION},
+	ERROR_YAML_INVALID_PARAMETER_TYPE:  {204, CATEGORY_VALIDATION},
+	ERROR_YAML_INVALID_RUNTIME:         {205, CATEGORY_VALIDATION},
+	ERROR_ACTION_SCAN_FAILED:           {206, CATEGORY_VALIDATION},
+	ERROR_DEPENDENCY_POLICY:            {207, CATEGORY_VALIDATION},
+	ERROR_NAMING_CONVENTION:            {208, CATEGORY_VALIDATION},
+	ERROR_WHISK_CLIENT_ERROR:           {301, CATEGORY_NETWORK},
+	ERROR_ENTITY_TIMEOUT:               {302, CATEGORY_NETWORK},
+	ERROR_PARTIAL_DEPLOYMENT:           {303, CATEGORY_NETWORK}, // the entities which failed were refused by OpenWhisk
+	ERROR_PREFLIGHT_FAILED:             {304, CATEGORY_NETWORK},
+	ERROR_WHISK_CLIENT_INVALID_CONFIG:  {401, CATEGORY_AUTH},
+	ERROR_IMMUTABLE_VERSION:            {501, CATEGORY_CONFLICT},
+	ERROR_ACTION_CODE_SIZE:             {601, CATEGORY_LIMIT},
+}
+
+// the codes of the errors of OpenWhisk by HTTP status, other statuses are
+// the ones of ERROR_WHISK_CLIENT_ERROR
+var whiskStatusCodes = map[int]ErrorCode{
+	http.StatusUnauthorized:          {402, CATEGORY_AUTH},
+	http.StatusForbidden:             {403, CATEGORY_AUTH},
+	http.StatusConflict:              {502, CATEGORY_CONFLICT},
+	http.StatusRequestEntityTooLarge: {602, CATEGORY_LIMIT},
+	http.StatusTooManyRequests:       {603, CATEGORY_LIMIT},
+}
+
+// GetErrorCode returns the code and category of the error
+func (e *WskDeployBaseErr) GetErrorCode() ErrorCode {
+	return errorCodes[e.ErrorType]
+}
+
+// GetErrorCode returns the code and category of the HTTP status of the
+// response of OpenWhisk, if any
+func (e *WhiskClientError) GetErrorCode() ErrorCode {
+	if code, ok := whiskStatusCodes[e.HTTPStatus]; ok {
+		return code
+	}
+	return e.WskDeployBaseErr.GetErrorCode()
+}
+
+// CodedError is an error with a code and a category, every error of wskdeploy is
+type CodedError interface {
+	error
+	GetErrorCode() ErrorCode
+}
+
+// GetErrorCodeOf returns the code and category of an error of wskdeploy, the
+// zero ErrorCode for other errors
+func GetErrorCodeOf(err error) ErrorCode {
+	if coded, ok := err.(CodedError); ok {
+		return coded.GetErrorCode()
+	}
+	return ErrorCode{}
+}
+
+// ExitCode returns the exit code of wskdeploy for the category of the error
+func ExitCode(err error) int {
+	if exitCode, ok := categoryExitCodes[GetErrorCodeOf(err).Category]; ok {
+		return exitCode
+	}
+	return EXIT_CODE_ERROR
+}
+
+// ErrorReport is the JSON form of an error, e.g. {"code": 202, "category":
+// "validation", "type": "ERROR_YAML_FILE_FORMAT_ERROR", "exit_code": 3, ...}
+type ErrorReport struct {
+	Code     int    `json:"code,omitempty"`
+	Category string `json:"category,omitempty"`
+	Type     string `json:"type,omitempty"`
+	ExitCode int    `json:"exit_code"`
+	Message  string `json:"message"`
+}
+
+// NewErrorReport returns the JSON form of an error
+func NewErrorReport(err error) ErrorReport {
+	code := GetErrorCodeOf(err)
+	report := ErrorReport{Code: code.Code, Category: code.Category, ExitCode: ExitCode(err), Message: strings.TrimSpace(err.Error())}
+	if typed, ok := err.(interface {
+		GetErrorType() string
+	}); ok {
+		report.Type = typed.GetErrorType()
+	}
+	return report
+}
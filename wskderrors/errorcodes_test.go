@@ -0,0 +1,67 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wskderrors
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	assert.Equal(t, EXIT_CODE_PARSE, ExitCode(NewYAMLParserErr("manifest.yaml", "bad")))
+	assert.Equal(t, EXIT_CODE_PARSE, ExitCode(NewFileReadError("manifest.yaml", "missing")))
+	assert.Equal(t, EXIT_CODE_VALIDATION, ExitCode(NewYAMLFileFormatError("manifest.yaml", "invalid")))
+	assert.Equal(t, EXIT_CODE_VALIDATION, ExitCode(NewCommandError("--set", "invalid")))
+	assert.Equal(t, EXIT_CODE_NETWORK, ExitCode(NewPreflightError("unreachable", "https://openwhisk.example.com")))
+	assert.Equal(t, EXIT_CODE_AUTH, ExitCode(NewWhiskClientInvalidConfigError("no auth")))
+	assert.Equal(t, EXIT_CODE_CONFLICT, ExitCode(NewImmutableVersionError("changed", nil)))
+	assert.Equal(t, EXIT_CODE_LIMIT, ExitCode(NewActionCodeSizeError("too large", "hello.zip", 2, 1)))
+	assert.Equal(t, EXIT_CODE_ERROR, ExitCode(errors.New("unknown")))
+}
+
+func TestWhiskClientErrorCode(t *testing.T) {
+	newResponse := func(status int) *http.Response {
+		return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: http.NoBody}
+	}
+	assert.Equal(t, ErrorCode{301, CATEGORY_NETWORK}, NewWhiskClientError("failed", 1, nil).GetErrorCode())
+	assert.Equal(t, ErrorCode{301, CATEGORY_NETWORK}, NewWhiskClientError("failed", 1, newResponse(http.StatusBadGateway)).GetErrorCode())
+	assert.Equal(t, ErrorCode{402, CATEGORY_AUTH}, NewWhiskClientError("failed", 1, newResponse(http.StatusUnauthorized)).GetErrorCode())
+	assert.Equal(t, ErrorCode{502, CATEGORY_CONFLICT}, NewWhiskClientError("failed", 1, newResponse(http.StatusConflict)).GetErrorCode())
+	assert.Equal(t, EXIT_CODE_LIMIT, ExitCode(NewWhiskClientError("failed", 1, newResponse(http.StatusTooManyRequests))))
+}
+
+func TestNewErrorReport(t *testing.T) {
+	content, err := json.Marshal(NewErrorReport(NewYAMLFileFormatError("manifest.yaml", "invalid")))
+	assert.Nil(t, err)
+	report := map[string]interface{}{}
+	assert.Nil(t, json.Unmarshal(content, &report))
+	assert.Equal(t, float64(202), report["code"])
+	assert.Equal(t, CATEGORY_VALIDATION, report["category"])
+	assert.Equal(t, ERROR_YAML_FILE_FORMAT_ERROR, report["type"])
+	assert.Equal(t, float64(EXIT_CODE_VALIDATION), report["exit_code"])
+	assert.Contains(t, report["message"], "invalid")
+
+	report2 := NewErrorReport(errors.New("unknown"))
+	assert.Equal(t, ErrorReport{ExitCode: EXIT_CODE_ERROR, Message: "unknown"}, report2)
+}
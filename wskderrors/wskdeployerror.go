@@ -36,6 +36,7 @@ const (
 	STR_TYPE = "Type"
 	STR_EXPECTED = "Expected"
 	STR_ACTUAL = "Actual"
+	STR_VALUE = "Value"
 	STR_NEWLINE = "\n"
 	STR_ACTION = "Action"
 	STR_RUNTIME = "Runtime"
@@ -57,6 +58,14 @@ const (
 	ERROR_YAML_PARAMETER_TYPE_MISMATCH = "ERROR_YAML_PARAMETER_TYPE_MISMATCH"
 	ERROR_YAML_INVALID_PARAMETER_TYPE = "ERROR_YAML_INVALID_PARAMETER_TYPE"
 	ERROR_YAML_INVALID_RUNTIME = "ERROR_YAML_INVALID_RUNTIME"
+	ERROR_ENTITY_TIMEOUT = "ERROR_ENTITY_TIMEOUT"
+	ERROR_PARTIAL_DEPLOYMENT = "ERROR_PARTIAL_DEPLOYMENT"
+	ERROR_ACTION_SCAN_FAILED = "ERROR_ACTION_SCAN_FAILED"
+	ERROR_PREFLIGHT_FAILED = "ERROR_PREFLIGHT_FAILED"
+	ERROR_DEPENDENCY_POLICY = "ERROR_DEPENDENCY_POLICY"
+	ERROR_ACTION_CODE_SIZE = "ERROR_ACTION_CODE_SIZE"
+	ERROR_NAMING_CONVENTION = "ERROR_NAMING_CONVENTION"
+	ERROR_IMMUTABLE_VERSION = "ERROR_IMMUTABLE_VERSION"
 )
 
 /*
@@ -96,6 +105,10 @@ func (e *WskDeployBaseErr) SetErrorType(errorType string) {
 	e.ErrorType = errorType
 }
 
+func (e *WskDeployBaseErr) GetErrorType() string {
+	return e.ErrorType
+}
+
 func (e *WskDeployBaseErr) SetMessageFormat(fmt string) {
 	e.MessageFormat = fmt
 }
@@ -175,6 +188,8 @@ func NewCommandError(cmd string, errorMessage string) *CommandError {
 type WhiskClientError struct {
 	WskDeployBaseErr
 	ErrorCode int
+	// the HTTP status of the response of OpenWhisk, 0 without response
+	HTTPStatus int
 }
 
 func NewWhiskClientError(errorMessage string, code int, response *http.Response) *WhiskClientError {
@@ -186,6 +201,7 @@ func NewWhiskClientError(errorMessage string, code int, response *http.Response)
 	err.SetMessageFormat("%s: %d: %s")
 	var str = fmt.Sprintf(err.MessageFormat, STR_ERROR_CODE, code, errorMessage)
 	if response != nil {
+		err.HTTPStatus = response.StatusCode
 		responseData, _ := ioutil.ReadAll(response.Body)
 		err.SetMessageFormat("%s: %d: %s: %s: %s %s: %s")
 		str = fmt.Sprintf(err.MessageFormat, STR_ERROR_CODE, code, errorMessage, STR_HTTP_STATUS, response.Status, STR_HTTP_BODY, string(responseData))
@@ -319,6 +335,28 @@ func NewParameterTypeMismatchError(fpath string, param string, expectedType stri
 	return err
 }
 
+// NewParameterValueConversionError reports a parameter value which cannot be converted to its declared type
+func NewParameterValueConversionError(fpath string, param string, expectedType string, actualType string, value interface{}) *ParameterTypeMismatchError {
+	var err = &ParameterTypeMismatchError{
+		Parameter: param,
+		ExpectedType: expectedType,
+		ActualType: actualType,
+	}
+
+	err.SetErrorType(ERROR_YAML_PARAMETER_TYPE_MISMATCH)
+	err.SetCallerByStackFrameSkip(2)
+	err.SetErrorFilePath(fpath)
+	err.SetMessageFormat("%s [%s]: %s %s: [%s], %s: [%s], %s: [%v]")
+	str := fmt.Sprintf(err.MessageFormat,
+		STR_PARAMETER, param,
+		STR_TYPE,
+		STR_EXPECTED, expectedType,
+		STR_ACTUAL, actualType,
+		STR_VALUE, value)
+	err.SetMessage(str)
+	return err
+}
+
 /*
  * InvalidParameterType
  */
@@ -389,6 +427,186 @@ func NewInvalidRuntimeError(errMessage string, fpath string, action string, runt
 	return err
 }
 
+/*
+ * EntityTimeoutError
+ */
+type EntityTimeoutError struct {
+	WskDeployBaseErr
+	Entity	string
+	Name	string
+}
+
+func NewEntityTimeoutError(errorMessage string, entity string, name string) *EntityTimeoutError {
+	var err = &EntityTimeoutError{
+		Entity: entity,
+		Name: name,
+	}
+	err.SetErrorType(ERROR_ENTITY_TIMEOUT)
+	err.SetCallerByStackFrameSkip(2)
+	err.SetMessage(errorMessage)
+	return err
+}
+
+/*
+ * PartialDeploymentError
+ */
+type PartialDeploymentError struct {
+	WskDeployBaseErr
+	// the entities which failed, along with their error
+	Failures	[]string
+}
+
+func NewPartialDeploymentError(errorMessage string, failures []string) *PartialDeploymentError {
+	var err = &PartialDeploymentError{
+		Failures: failures,
+	}
+	err.SetErrorType(ERROR_PARTIAL_DEPLOYMENT)
+	err.SetCallerByStackFrameSkip(2)
+	str := errorMessage
+	for _, failure := range failures {
+		str += STR_NEWLINE + STR_INDENT_1 + " " + failure
+	}
+	err.SetMessage(str)
+	return err
+}
+
+/*
+ * ActionScanError
+ */
+type ActionScanError struct {
+	WskDeployBaseErr
+	Action		string
+	// the zip or source file of the action which was scanned
+	Artifact	string
+	ExitCode	int
+}
+
+func NewActionScanError(errorMessage string, action string, artifact string, exitCode int) *ActionScanError {
+	var err = &ActionScanError{
+		Action: action,
+		Artifact: artifact,
+		ExitCode: exitCode,
+	}
+	err.SetErrorType(ERROR_ACTION_SCAN_FAILED)
+	err.SetCallerByStackFrameSkip(2)
+	err.SetMessage(errorMessage)
+	return err
+}
+
+/*
+ * PreflightError
+ */
+type PreflightError struct {
+	WskDeployBaseErr
+	// the API host which failed the preflight check
+	Host	string
+}
+
+func NewPreflightError(errorMessage string, host string) *PreflightError {
+	var err = &PreflightError{
+		Host: host,
+	}
+	err.SetErrorType(ERROR_PREFLIGHT_FAILED)
+	err.SetCallerByStackFrameSkip(2)
+	err.SetMessage(errorMessage)
+	return err
+}
+
+/*
+ * DependencyPolicyError
+ */
+type DependencyPolicyError struct {
+	WskDeployBaseErr
+	Dependency	string
+	// the entities of the dependency which the policy refuses
+	Violations	[]string
+}
+
+func NewDependencyPolicyError(errorMessage string, dependency string, violations []string) *DependencyPolicyError {
+	var err = &DependencyPolicyError{
+		Dependency: dependency,
+		Violations: violations,
+	}
+	err.SetErrorType(ERROR_DEPENDENCY_POLICY)
+	err.SetCallerByStackFrameSkip(2)
+	str := errorMessage
+	for _, violation := range violations {
+		str += STR_NEWLINE + STR_INDENT_1 + " " + violation
+	}
+	err.SetMessage(str)
+	return err
+}
+
+/*
+ * ActionCodeSizeError
+ */
+type ActionCodeSizeError struct {
+	WskDeployBaseErr
+	// the file of the code
+	Path	string
+	// size of the code, base64 encoded if binary, and the largest size allowed
+	Size	int64
+	Max	int64
+}
+
+func NewActionCodeSizeError(errorMessage string, path string, size int64, max int64) *ActionCodeSizeError {
+	var err = &ActionCodeSizeError{
+		Path: path,
+		Size: size,
+		Max: max,
+	}
+	err.SetErrorType(ERROR_ACTION_CODE_SIZE)
+	err.SetCallerByStackFrameSkip(2)
+	err.SetMessage(errorMessage)
+	return err
+}
+
+/*
+ * NamingConventionError
+ */
+type NamingConventionError struct {
+	WskDeployBaseErr
+	// type and name of the entity, and the pattern its name does not match
+	Entity	string
+	Name	string
+	Pattern	string
+}
+
+func NewNamingConventionError(errorMessage string, entity string, name string, pattern string) *NamingConventionError {
+	var err = &NamingConventionError{
+		Entity: entity,
+		Name: name,
+		Pattern: pattern,
+	}
+	err.SetErrorType(ERROR_NAMING_CONVENTION)
+	err.SetCallerByStackFrameSkip(2)
+	err.SetMessage(errorMessage)
+	return err
+}
+
+/*
+ * ImmutableVersionError
+ */
+type ImmutableVersionError struct {
+	WskDeployBaseErr
+	// the packages whose version is deployed with another content
+	Violations	[]string
+}
+
+func NewImmutableVersionError(errorMessage string, violations []string) *ImmutableVersionError {
+	var err = &ImmutableVersionError{
+		Violations: violations,
+	}
+	err.SetErrorType(ERROR_IMMUTABLE_VERSION)
+	err.SetCallerByStackFrameSkip(2)
+	str := errorMessage
+	for _, violation := range violations {
+		str += STR_NEWLINE + STR_INDENT_1 + " " + violation
+	}
+	err.SetMessage(str)
+	return err
+}
+
 func IsCustomError( err error ) bool {
 
 	switch err.(type) {
@@ -403,6 +621,14 @@ func IsCustomError( err error ) bool {
 	case *ParameterTypeMismatchError:
 	case *InvalidParameterTypeError:
 	case *YAMLParserError:
+	case *EntityTimeoutError:
+	case *PartialDeploymentError:
+	case *PreflightError:
+	case *DependencyPolicyError:
+	case *ActionCodeSizeError:
+	case *NamingConventionError:
+	case *ImmutableVersionError:
+	case *ActionScanError:
 		return true
 	}
 	return false
@@ -151,6 +151,31 @@ func TestCustomErrorOutputFormat(t *testing.T) {
 		msg8)
 	assert.Equal(t, expectedResult, actualResult)
 
+	/*
+	 * ParameterValueConversionError
+	 */
+	err8b := NewParameterValueConversionError(
+		TEST_EXISTANT_MANIFEST_FILE,
+		TEST_PARAM_NAME,
+		TEST_PARAM_TYPE_INT,
+		TEST_PARAM_TYPE_FLOAT,
+		80.8)
+	actualResult = strings.TrimSpace(err8b.Error())
+	msg8b := fmt.Sprintf("%s [%s]: %s %s: [%s], %s: [%s], %s: [%v]",
+		STR_PARAMETER, TEST_PARAM_NAME,
+		STR_TYPE,
+		STR_EXPECTED, TEST_PARAM_TYPE_INT,
+		STR_ACTUAL, TEST_PARAM_TYPE_FLOAT,
+		STR_VALUE, 80.8)
+	expectedResult = fmt.Sprintf("%s [%d]: [%s]: %s: [%s]: %s",
+		packageName,
+		err8b.LineNum,
+		ERROR_YAML_PARAMETER_TYPE_MISMATCH,
+		STR_FILE,
+		filepath.Base(TEST_EXISTANT_MANIFEST_FILE),
+		msg8b)
+	assert.Equal(t, expectedResult, actualResult)
+
 	/*
 	 * InvalidParameterType
 	 */
@@ -0,0 +1,201 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wskenv
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskderrors"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
+)
+
+// name of the file of a project which variables are loaded from
+const DOTENV_FILE_NAME = ".env"
+
+var dotenvNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// Variables loaded from .env files. They are used to interpolate the variables
+// which are not set in the environment, so that a variable exported in the
+// shell (e.g. by a CI system) always takes precedence over the .env file.
+var dotenvVariables = make(map[string]string)
+var dotenvMt sync.RWMutex
+
+// LookupEnv returns the value of the variable from the environment, or from
+// the .env files loaded if it is not set in the environment
+func LookupEnv(name string) (string, bool) {
+	if value, ok := os.LookupEnv(name); ok {
+		return value, true
+	}
+	dotenvMt.RLock()
+	defer dotenvMt.RUnlock()
+	value, ok := dotenvVariables[name]
+	return value, ok
+}
+
+// Getenv returns the value of the variable, see LookupEnv()
+func Getenv(name string) string {
+	value, _ := LookupEnv(name)
+	return value
+}
+
+// LoadEnvFile adds the variables of a .env file to the variables used for
+// interpolation, a variable loaded before is replaced
+func LoadEnvFile(filePath string) error {
+	variables, err := readEnvFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	dotenvMt.Lock()
+	defer dotenvMt.Unlock()
+	for name, value := range variables {
+		dotenvVariables[name] = value
+	}
+	return nil
+}
+
+// LoadSecretsFile loads a .env file like LoadEnvFile() does, the values of its
+// variables are masked in everything printed, see wskprint.MaskSecrets()
+func LoadSecretsFile(filePath string) error {
+	variables, err := readEnvFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	dotenvMt.Lock()
+	defer dotenvMt.Unlock()
+	for name, value := range variables {
+		wskprint.AddSecret(value)
+		dotenvVariables[name] = value
+	}
+	return nil
+}
+
+func readEnvFile(filePath string) (map[string]string, error) {
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	variables, err := ParseEnvFile(content)
+	if err != nil {
+		return nil, wskderrors.NewFileReadError(filePath, err.Error())
+	}
+	return variables, nil
+}
+
+// ClearEnvFiles forgets the variables loaded from .env files
+func ClearEnvFiles() {
+	dotenvMt.Lock()
+	defer dotenvMt.Unlock()
+	dotenvVariables = make(map[string]string)
+}
+
+// ParseEnvFile parses the content of a .env file, i.e. lines of NAME=value
+// which may start with "export". Values may be quoted, variables are expanded
+// in unquoted and double quoted values but not in single quoted values.
+func ParseEnvFile(content []byte) (map[string]string, error) {
+	variables := make(map[string]string)
+	expand := func(name string) string {
+		if value, ok := os.LookupEnv(name); ok {
+			return value
+		}
+		return variables[name]
+	}
+
+	lines := strings.Split(strings.Replace(string(content), "\r\n", "\n", -1), "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(strings.TrimPrefix(line, "\ufeff"))
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimSpace(strings.TrimPrefix(line, "export "))
+
+		separator := strings.Index(line, "=")
+		if separator < 0 {
+			return nil, errors.New(wski18n.T(wski18n.ID_ERR_LINE_MISSING_SEPARATOR_X_line_X_value_X,
+				map[string]interface{}{wski18n.KEY_LINE: i + 1, wski18n.KEY_VALUE: line}))
+		}
+		name := strings.TrimSpace(line[:separator])
+		if !dotenvNameRegex.MatchString(name) {
+			return nil, errors.New(wski18n.T(wski18n.ID_ERR_DOTENV_NAME_INVALID_X_line_X_name_X,
+				map[string]interface{}{wski18n.KEY_LINE: i + 1, wski18n.KEY_NAME: name}))
+		}
+
+		value, quote, err := parseEnvValue(strings.TrimSpace(line[separator+1:]))
+		if err != nil {
+			return nil, errors.New(wski18n.T(wski18n.ID_ERR_LINE_X_line_X_err_X,
+				map[string]interface{}{wski18n.KEY_LINE: i + 1, wski18n.KEY_ERR: err.Error()}))
+		}
+		if quote != '\'' {
+			value = os.Expand(value, expand)
+		}
+		variables[name] = value
+	}
+	return variables, nil
+}
+
+// parseEnvValue returns the value without its quotes and trailing comment,
+// along with the quote character used if any
+func parseEnvValue(value string) (string, byte, error) {
+	if len(value) == 0 {
+		return "", 0, nil
+	}
+
+	quote := value[0]
+	if quote != '"' && quote != '\'' {
+		if i := strings.Index(value, " #"); i >= 0 {
+			value = value[:i]
+		}
+		return strings.TrimSpace(value), 0, nil
+	}
+
+	var result []byte
+	for i := 1; i < len(value); i++ {
+		c := value[i]
+		if c == quote {
+			rest := strings.TrimSpace(value[i+1:])
+			if len(rest) > 0 && !strings.HasPrefix(rest, "#") {
+				return "", quote, errors.New(wski18n.T(wski18n.ID_ERR_DOTENV_AFTER_QUOTE_X_value_X,
+					map[string]interface{}{wski18n.KEY_VALUE: rest}))
+			}
+			return string(result), quote, nil
+		}
+		if c == '\\' && quote == '"' && i+1 < len(value) {
+			i++
+			switch value[i] {
+			case 'n':
+				c = '\n'
+			case 't':
+				c = '\t'
+			case 'r':
+				c = '\r'
+			default:
+				c = value[i]
+			}
+		}
+		result = append(result, c)
+	}
+	return "", quote, errors.New(wski18n.T(wski18n.ID_ERR_DOTENV_MISSING_QUOTE_X_value_X,
+		map[string]interface{}{wski18n.KEY_VALUE: value}))
+}
@@ -0,0 +1,79 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wskenv
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const TEST_ENV_FILE = "\ufeff# credentials of the test project\r\n" +
+	"export FIRSTNAME=Sam\r\n" +
+	"TOWN = \"the Shire\" # comment\n" +
+	"COUNTRY='Middle-earth'\n" +
+	"\n" +
+	"PLACE=${TOWN}, $COUNTRY\n" +
+	"LITERAL='${TOWN}'\n" +
+	"MULTILINE=\"first\\nsecond\"\n" +
+	"EMPTY=\n"
+
+func TestParseEnvFile(t *testing.T) {
+	variables, err := ParseEnvFile([]byte(TEST_ENV_FILE))
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"FIRSTNAME": "Sam",
+		"TOWN":      "the Shire",
+		"COUNTRY":   "Middle-earth",
+		"PLACE":     "the Shire, Middle-earth",
+		"LITERAL":   "${TOWN}",
+		"MULTILINE": "first\nsecond",
+		"EMPTY":     "",
+	}, variables)
+
+	for _, content := range []string{"FIRSTNAME", "1NAME=Sam", "NAME=\"Sam", "NAME='Sam' Gamgee"} {
+		_, err := ParseEnvFile([]byte(content))
+		assert.NotNil(t, err, content)
+	}
+}
+
+func TestLoadEnvFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "wskdeploy")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+	defer ClearEnvFiles()
+
+	envFile := filepath.Join(dir, DOTENV_FILE_NAME)
+	assert.Nil(t, ioutil.WriteFile(envFile, []byte(TEST_ENV_FILE), 0644))
+	assert.Nil(t, LoadEnvFile(envFile))
+	assert.NotNil(t, LoadEnvFile(filepath.Join(dir, "missing.env")))
+
+	// variables of the environment take precedence over the .env file
+	os.Setenv("FIRSTNAME", "Frodo")
+	defer os.Unsetenv("FIRSTNAME")
+	assert.Equal(t, "Hello, Frodo from the Shire, Middle-earth", GetEnvVar("Hello, ${FIRSTNAME} from ${PLACE}"))
+
+	ClearEnvFiles()
+	_, ok := LookupEnv("PLACE")
+	assert.False(t, ok)
+}
@@ -19,11 +19,15 @@ package wskenv
 
 import (
 	"strings"
-	"os"
 	"reflect"
+	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wskprint"
 )
 
+// References of the form ${deployed.<path>} are not environment variables,
+// they are resolved by the deployer once the referenced entities are deployed.
+const DEPLOYED_REFERENCE_PREFIX = "deployed."
+
 // Test if a string
 func isValidEnvironmentVar(value string) bool {
 
@@ -42,6 +46,35 @@ func isValidEnvironmentVar(value string) bool {
 	return false
 }
 
+// IsEnvVarReference returns true for a string value which refers to an env.
+// variable, e.g. "$TOKEN" or "Bearer ${TOKEN}", see GetEnvVar()
+func IsEnvVarReference(value interface{}) bool {
+	str, ok := value.(string)
+	return ok && isValidEnvironmentVar(str)
+}
+
+// EnvVarNames returns the names of the env. variables a string value refers
+// to, e.g. TOKEN for "Bearer ${TOKEN}", see GetEnvVar()
+func EnvVarNames(value interface{}) []string {
+	names := make([]string, 0)
+	if !IsEnvVarReference(value) {
+		return names
+	}
+	str := value.(string)
+	f := func(c rune) bool {
+		return c == '$' || c == '{' || c == '}'
+	}
+	for _, substr := range strings.FieldsFunc(str, f) {
+		if strings.HasPrefix(substr, DEPLOYED_REFERENCE_PREFIX) {
+			continue
+		}
+		if strings.Contains(str, "$"+substr) || strings.Contains(str, "${"+substr+"}") {
+			names = append(names, substr)
+		}
+	}
+	return names
+}
+
 // Get the env variable value by key.
 // Get the env variable if the key is start by $
 func GetEnvVar(key interface{}) interface{} {
@@ -62,20 +95,23 @@ func GetEnvVar(key interface{}) interface{} {
 				return c == '$' || c == '{' || c == '}'
 			}
 			for _, substr := range strings.FieldsFunc(keystr, f) {
+				if strings.HasPrefix(substr, DEPLOYED_REFERENCE_PREFIX) {
+					continue
+				}
 				//if the substr is a $ENV_VAR
 				if strings.Contains(keystr, "$"+substr) {
-					thisValue = os.Getenv(substr)
+					thisValue = Getenv(substr)
 					if thisValue == "" {
-						// TODO() i18n
-						wskprint.PrintlnOpenWhiskWarning("Missing Environment Variable " + substr + ".")
+						wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_ENV_VARIABLE_MISSING_X_key_X,
+							map[string]interface{}{wski18n.KEY_KEY: substr}))
 					}
 					keystr = strings.Replace(keystr, "$"+substr, thisValue, -1)
 					//if the substr is a ${ENV_VAR}
 				} else if strings.Contains(keystr, "${"+substr+"}") {
-					thisValue = os.Getenv(substr)
+					thisValue = Getenv(substr)
 					if thisValue == "" {
-						// TODO() i18n
-						wskprint.PrintlnOpenWhiskWarning("Missing Environment Variable " + substr + ".")
+						wskprint.PrintlnOpenWhiskWarning(wski18n.T(wski18n.ID_WARN_ENV_VARIABLE_MISSING_X_key_X,
+							map[string]interface{}{wski18n.KEY_KEY: substr}))
 					}
 					keystr = strings.Replace(keystr, "${"+substr+"}", thisValue, -1)
 				}
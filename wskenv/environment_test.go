@@ -43,4 +43,14 @@ assert.Equal(t, "ddd.ccc.oh, dollars!", GetEnvVar("ddd.ccc.${WithDollar}"), "Str
 assert.Equal(t, "", GetEnvVar("$WithDollarAgain.ccc.aaa"), "String concatenation fail")
 assert.Equal(t, "ddd..aaa", GetEnvVar("ddd.${WithDollarAgain}.aaa"), "String concatenation fail")
 assert.Equal(t, "oh, dollars!NO dollar.NO dollar", GetEnvVar("${WithDollar}${NoDollar}.${NoDollar}"), "String concatenation fail")
+assert.Equal(t, "${deployed.packages.pkg.actions.act.url}", GetEnvVar("${deployed.packages.pkg.actions.act.url}"), "Deployed references should be no change.")
+assert.Equal(t, "${deployed.packages.pkg.name}/NO dollar", GetEnvVar("${deployed.packages.pkg.name}/${NoDollar}"), "Deployed references should be no change.")
+}
+
+func TestEnvVarNames(t *testing.T) {
+	assert.Equal(t, []string{"DB_URL"}, EnvVarNames("$DB_URL"))
+	assert.Equal(t, []string{"USER", "PASSWORD"}, EnvVarNames("${USER}:${PASSWORD}@db"))
+	assert.Equal(t, []string{"HOST"}, EnvVarNames("${deployed.packages.pkg.name}/${HOST}"))
+	assert.Empty(t, EnvVarNames("no variable"))
+	assert.Empty(t, EnvVarNames(80))
 }
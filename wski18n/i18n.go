@@ -18,16 +18,24 @@
 package wski18n
 
 import (
+    "fmt"
+    "io/ioutil"
+    "os"
     "path/filepath"
     "strings"
 
     goi18n "github.com/nicksnyder/go-i18n/i18n"
+    "github.com/nicksnyder/go-i18n/i18n/language"
 )
 
 const (
     DEFAULT_LOCALE = "en_US"
 )
 
+// variable naming a message catalog loaded over the messages of wskdeploy, see
+// LoadCatalog()
+const ENV_MESSAGE_CATALOG = "WSKDEPLOY_MESSAGE_CATALOG"
+
 var SUPPORTED_LOCALES = []string{
     "de_DE",
     "en_US",
@@ -58,6 +66,12 @@ var curLocale string
 
 func init() {
     curLocale = Init(new(JibberJabberDetector))
+    if catalog := os.Getenv(ENV_MESSAGE_CATALOG); len(catalog) > 0 {
+        if err := LoadCatalog(catalog); err != nil {
+            fmt.Fprintln(os.Stderr, T(ID_WARN_MESSAGE_CATALOG_X_path_X_err_X,
+                map[string]interface{}{KEY_PATH: catalog, KEY_ERR: err.Error()}))
+        }
+    }
 }
 
 // TODO() when are these used?
@@ -96,6 +110,40 @@ func InitWithLocale(locale string) {
     T = goi18n.MustTfunc(locale)
 }
 
+// LoadCatalog loads a message catalog, e.g. a translation maintained outside
+// of wskdeploy, in the JSON format of the files of wski18n/resources, i.e. a
+// list of {"id": ..., "translation": ...}. The messages of the catalog replace
+// the ones of the current locale, the messages it lacks are left as they are.
+// The locale of the catalog is read from its file name, e.g. fr_FR.all.json,
+// the current locale otherwise.
+func LoadCatalog(path string) error {
+    bytes, err := ioutil.ReadFile(path)
+    if err != nil {
+        return err
+    }
+    locale := curLocale
+    if langs := language.Parse(filepath.Base(path)); len(langs) == 1 {
+        locale = langs[0].Tag
+    }
+    if err := goi18n.ParseTranslationFileBytes(locale+filepath.Ext(path), bytes); err != nil {
+        return err
+    }
+    catalog, err := goi18n.Tfunc(locale)
+    if err != nil {
+        return err
+    }
+    // the message of the current locale is kept when the catalog lacks it,
+    // go-i18n returns the ID of such messages
+    current := T
+    T = func(translationID string, args ...interface{}) string {
+        if message := catalog(translationID, args...); message != translationID {
+            return message
+        }
+        return current(translationID, args...)
+    }
+    return nil
+}
+
 func loadFromAsset(locale string) (err error) {
     assetName := locale + ".all.json"
     assetKey := filepath.Join(resourcePath, assetName)
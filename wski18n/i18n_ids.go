@@ -81,6 +81,11 @@ const(
 	ID_MSG_MANAGED_UNDEPLOYMENT_FAILED 			= "msg_undeployment_managed_failed"
 	ID_MSG_MANAGED_FOUND_DELETED_X_key_X_name_X_project_X	= "msg_managed_found_deleted_entity"
 
+	// Resumable deployments
+	ID_MSG_CHECKPOINT_SAVED_X_path_X			= "msg_checkpoint_saved"
+	ID_MSG_CHECKPOINT_RESUMING_X_path_X			= "msg_checkpoint_resuming"
+	ID_MSG_CHECKPOINT_SKIPPED_X_key_X_name_X		= "msg_checkpoint_skipped"
+
 	// Interactive (prompts)
 	ID_MSG_PROMPT_DEPLOY					= "msg_prompt_deploy"
 	ID_MSG_PROMPT_UNDEPLOY					= "msg_prompt_undeploy"
@@ -101,6 +106,8 @@ const(
 	ID_WARN_LIMITS_MEMORY_SIZE				= "msg_warn_limits_memory_size"
 	ID_WARN_LIMITS_LOG_SIZE					= "msg_warn_limits_memory_log_size"
 	ID_WARN_LIMIT_UNCHANGEABLE_X_name_X			= "msg_warn_limit_changeable"
+	ID_WARN_CHECKPOINT_NOT_FOUND_X_path_X			= "msg_warn_checkpoint_not_found"
+	ID_WARN_CHECKPOINT_MANIFEST_MISMATCH_X_path_X_mpath_X	= "msg_warn_checkpoint_manifest_mismatch"
 
 	// Errors
 	ID_ERR_GET_RUNTIMES_X_err_X 				= "msg_err_get_runtimes"
@@ -109,6 +116,323 @@ const(
 	ID_ERR_CREATE_ENTITY_X_key_X_err_X_code_X		= "msg_err_create_entity"
 	ID_ERR_DELETE_ENTITY_X_key_X_err_X_code_X		= "msg_err_delete_entity"
 	ID_ERR_FEED_INVOKE_X_err_X_code_X			= "msg_err_feed_invoke"
+	ID_ERR_RECURSIVE_INCLUDE_X_path_X_chain_X		= "msg_err_recursive_include"
+	ID_ERR_DEPLOYED_REFERENCE_UNRESOLVED_X_reference_X_key_X_name_X = "msg_err_deployed_reference_unresolved"
+	ID_ERR_ENTITY_EXISTS_X_key_X_name_X	= "msg_err_entity_exists"
+	ID_MSG_MANIFEST_ENTITY_ADDED_X_key_X_name_X_path_X	= "msg_manifest_entity_added"
+	ID_ERR_ADD_PACKAGE_REQUIRED_X_path_X	= "msg_err_add_package_required"
+	ID_WARN_DEPRECATION_REPORT_X_count_X	= "msg_warn_deprecation_report"
+	ID_MSG_DEPRECATED_KEY_X_location_X_oldkey_X_newkey_X	= "msg_deprecated_key"
+	ID_MSG_MIGRATE_UP_TO_DATE_X_path_X	= "msg_migrate_up_to_date"
+	ID_MSG_MIGRATE_FILE_X_path_X_count_X	= "msg_migrate_file"
+	ID_MSG_MIGRATE_BACKUP_X_path_X_backup_X	= "msg_migrate_backup"
+	ID_MSG_NOTIFICATION_X_event_X_entity_X_namespace_X	= "msg_notification"
+	ID_ERR_NOTIFICATION_INVALID_X_key_X_value_X	= "msg_err_notification_invalid"
+	ID_WARN_NOTIFICATION_FAILED_X_host_X_err_X	= "msg_warn_notification_failed"
+	ID_MSG_UNMARSHAL_CACHE_X_path_X	= "msg_unmarshall_cache"
+	ID_ERR_INVALID_API_HOST_X_host_X_err_X	= "msg_err_invalid_api_host"
+	ID_MSG_WEB_ACTION_URL_X_name_X_url_X	= "msg_web_action_url"
+	ID_MSG_ENV_FILE_LOAD_X_path_X	= "msg_env_file_load"
+	ID_MSG_RUNTIMES_X_host_X	= "msg_runtimes"
+	ID_ERR_RUNTIME_KIND_UNKNOWN_X_runtime_X_runtimes_X	= "msg_err_runtime_kind_unknown"
+	ID_MSG_RUNTIMES_BUILTIN	= "msg_runtimes_builtin"
+	ID_ERR_SEQUENCE_COMPONENT_INVALID_X_sequence_X_action_X	= "msg_err_sequence_component_invalid"
+	ID_ERR_SEQUENCE_COMPONENT_NOT_FOUND_X_sequence_X_action_X	= "msg_err_sequence_component_not_found"
+	ID_ERR_VERSION_REQUIREMENT_X_key_X_value_X_version_X	= "msg_err_version_requirement"
+	ID_ERR_VERSION_REQUIREMENT_INVALID_X_key_X_value_X_err_X	= "msg_err_version_requirement_invalid"
+	ID_ERR_TRIGGER_NOT_IN_PROJECT_X_name_X_triggers_X	= "msg_err_trigger_not_in_project"
+	ID_MSG_TRIGGER_FIRED_X_name_X_id_X	= "msg_trigger_fired"
+	ID_ERR_TRIGGER_PARAM_VALUE_MISSING_X_key_X	= "msg_err_trigger_param_value_missing"
+	ID_MSG_PARALLEL_DEPLOY_STATUS_X_done_X_total_X_running_X	= "msg_parallel_deploy_status"
+	ID_ERR_ENTITY_TIMEOUT_X_key_X_name_X_timeout_X	= "msg_err_entity_timeout"
+	ID_MSG_ENTITY_SKIPPED_X_key_X_name_X	= "msg_entity_skipped"
+	ID_ERR_PARTIAL_DEPLOYMENT_X_count_X	= "msg_err_partial_deployment"
+	ID_ERR_PROFILE_NOT_FOUND_X_name_X_path_X_profiles_X	= "msg_err_profile_not_found"
+	ID_ERR_PROFILE_INVALID_X_path_X_err_X	= "msg_err_profile_invalid"
+	ID_MSG_PROFILE_SOURCE_X_name_X	= "msg_profile_source"
+	ID_MSG_CONFIG_INFO_APIGW_HOST_X_host_X_source_X	= "msg_config_apigw_host_info"
+	ID_MSG_CONFIG_INFO_APIGW_ACCESS_TOKEN_X_source_X	= "msg_config_apigw_access_token_info"
+	ID_WARN_FILE_BOM_X_path_X_encoding_X	= "msg_warn_file_bom"
+	ID_WARN_FILE_CRLF_X_path_X	= "msg_warn_file_crlf"
+	ID_ERR_FILE_ENCODING_INVALID_X_path_X_line_X	= "msg_err_file_encoding_invalid"
+	ID_MSG_WEB_ACTION_SECRET_X_name_X_secret_X	= "msg_web_action_secret"
+	ID_ERR_WEB_SECURE_REQUIRES_WEB_X_action_X	= "msg_err_web_secure_requires_web"
+	ID_ERR_WEB_SECURE_INVALID_X_action_X_value_X	= "msg_err_web_secure_invalid"
+	ID_ERR_INPUTS_SCOPE_INVALID_X_value_X	= "msg_err_inputs_scope_invalid"
+	ID_ERR_LICENSE_INVALID_X_license_X_err_X	= "msg_err_license_invalid"
+	ID_ERR_LICENSE_UNKNOWN_X_license_X	= "msg_err_license_unknown"
+	ID_ERR_LICENSE_NOT_ALLOWED_X_license_X_path_X	= "msg_err_license_not_allowed"
+	ID_ERR_PACKAGE_PATTERN_INVALID_X_value_X_err_X	= "msg_err_package_pattern_invalid"
+	ID_WARN_NO_PACKAGE_SELECTED_X_path_X	= "msg_warn_no_package_selected"
+	ID_ERR_PROJECT_DUPLICATE_X_project_X_path_X	= "msg_err_project_duplicate"
+	ID_ERR_PROJECT_AMBIGUOUS_X_path_X_projects_X	= "msg_err_project_ambiguous"
+	ID_ERR_PROJECT_NOT_FOUND_X_project_X_path_X_projects_X	= "msg_err_project_not_found"
+	ID_ERR_ENV_NAME_INVALID_X_name_X	= "msg_err_env_name_invalid"
+	ID_WARN_ENV_FILE_NOT_FOUND_X_name_X_path_X	= "msg_warn_env_file_not_found"
+	ID_ERR_REPORT_TEMPLATE_X_path_X_err_X	= "msg_err_report_template"
+	ID_ERR_SWAGGER_INVALID_X_path_X_err_X	= "msg_err_swagger_invalid"
+	ID_ERR_SWAGGER_ACTION_NOT_FOUND_X_action_X_method_X_path_X	= "msg_err_swagger_action_not_found"
+	ID_ERR_SWAGGER_WITH_ROUTES	= "msg_err_swagger_with_routes"
+	ID_MSG_SCANNER_RUN_X_action_X_path_X	= "msg_scanner_run"
+	ID_WARN_SCANNER_X_action_X_path_X_output_X	= "msg_warn_scanner"
+	ID_ERR_SCANNER_REJECTED_X_action_X_path_X_code_X_output_X	= "msg_err_scanner_rejected"
+	ID_ERR_SCANNER_RUN_X_command_X_err_X	= "msg_err_scanner_run"
+	ID_ERR_REMOTE_PROJECT_FETCH_X_url_X_err_X	= "msg_err_remote_project_fetch"
+	ID_ERR_REMOTE_PROJECT_PATH_NOT_FOUND_X_path_X_url_X	= "msg_err_remote_project_path_not_found"
+	ID_MSG_REMOTE_PROJECT_FETCHED_X_url_X_path_X	= "msg_remote_project_fetched"
+	ID_ERR_FEED_ACTION_NOT_ANNOTATED_X_trigger_X_action_X_key_X	= "msg_err_feed_action_not_annotated"
+	ID_ERR_EXPORT_FORMAT_UNKNOWN_X_format_X_formats_X	= "msg_err_export_format_unknown"
+	ID_MSG_EXPORT_WRITTEN_X_path_X	= "msg_export_written"
+	ID_ERR_SECRET_PARAMETER_NOT_FROM_ENV_X_key_X	= "msg_err_secret_parameter_not_from_env"
+	ID_MSG_SECRETS_FILE_LOAD_X_path_X	= "msg_secrets_file_load"
+	ID_WARN_SECRETS_VERBOSE_TRACES_OFF	= "msg_warn_secrets_verbose_traces_off"
+	ID_ERR_ANNOTATION_SET_AND_DELETED_X_key_X_action_X	= "msg_err_annotation_set_and_deleted"
+	ID_MSG_ANNOTATION_REMOVED_X_key_X_action_X	= "msg_annotation_removed"
+	ID_MSG_ACTION_RECREATED_X_action_X	= "msg_action_recreated"
+	ID_ERR_INHERIT_ANNOTATIONS_INVALID_X_name_X_value_X	= "msg_err_inherit_annotations_invalid"
+	ID_MSG_OUTPUTS_WRITTEN_X_path_X	= "msg_outputs_written"
+	ID_MSG_DEPENDENCY_KEPT_X_name_X	= "msg_dependency_kept"
+	ID_ERR_UNEXPECTED_TARGET_X_key_X_value_X_expected_X	= "ID_ERR_UNEXPECTED_TARGET_X_key_X_value_X_expected_X"
+	ID_WARN_UNEXPECTED_TARGET_OVERRIDDEN_X_key_X_value_X	= "ID_WARN_UNEXPECTED_TARGET_OVERRIDDEN_X_key_X_value_X"
+	ID_ERR_ENTITY_REFERENCE_UNRESOLVED_X_reference_X	= "msg_err_entity_reference_unresolved"
+	ID_ERR_ENTITY_REFERENCE_CYCLE_X_reference_X_chain_X	= "msg_err_entity_reference_cycle"
+	ID_ERR_PREFLIGHT_UNREACHABLE_X_host_X_err_X	= "msg_err_preflight_unreachable"
+	ID_ERR_PREFLIGHT_TLS_X_host_X_err_X	= "msg_err_preflight_tls"
+	ID_ERR_PREFLIGHT_STATUS_X_host_X_url_X_status_X	= "msg_err_preflight_status"
+	ID_ERR_PREFLIGHT_NOT_OPENWHISK_X_host_X_url_X	= "msg_err_preflight_not_openwhisk"
+	ID_ERR_PREFLIGHT_API_VERSION_X_host_X_version_X_value_X	= "msg_err_preflight_api_version"
+	ID_MSG_PREFLIGHT_PROXY_X_proxy_X	= "msg_preflight_proxy"
+	ID_MSG_PREFLIGHT_NO_PROXY	= "msg_preflight_no_proxy"
+	ID_MSG_PREFLIGHT_OK_X_host_X_version_X	= "msg_preflight_ok"
+	ID_ERR_INPUT_MERGE_INVALID_X_key_X_value_X_merges_X	= "msg_err_input_merge_invalid"
+	ID_ERR_INPUT_MERGE_NOT_LIST_X_key_X_value_X	= "msg_err_input_merge_not_list"
+	ID_ERR_YAML_LIMIT_EXCEEDED_X_limit_X_value_X_max_X	= "msg_err_yaml_limit_exceeded"
+	ID_ERR_YAML_INVALID_X_err_X	= "msg_err_yaml_invalid"
+	ID_ERR_RUNTIME_VERSION_WITHOUT_RUNTIME_X_action_X	= "msg_err_runtime_version_without_runtime"
+	ID_ERR_RUNTIME_VERSION_WITH_KIND_X_runtime_X_action_X	= "msg_err_runtime_version_with_kind"
+	ID_ERR_RUNTIME_VERSION_NOT_FOUND_X_runtime_X_version_X_action_X	= "msg_err_runtime_version_not_found"
+	ID_WARN_RUNTIME_DEPRECATED_X_runtime_X_action_X	= "msg_warn_runtime_deprecated"
+	ID_MSG_RUNTIME_RESOLVED_X_runtime_X_kind_X_action_X	= "msg_runtime_resolved"
+	ID_ERR_DEPENDENCY_POLICY_X_name_X	= "msg_err_dependency_policy"
+	ID_MSG_DEPENDENCY_POLICY_SIDE_EFFECT_X_key_X_name_X	= "msg_dependency_policy_side_effect"
+	ID_MSG_DEPENDENCY_POLICY_NAMESPACE_X_key_X_name_X_namespace_X	= "msg_dependency_policy_namespace"
+	ID_MSG_DEPENDENCY_POLICY_PACKAGE_X_name_X	= "msg_dependency_policy_package"
+	ID_ERR_LINT_RULE_UNKNOWN_X_rule_X_rules_X	= "msg_err_lint_rule_unknown"
+	ID_ERR_LINT_SEVERITY_INVALID_X_rule_X_value_X_severities_X	= "msg_err_lint_severity_invalid"
+	ID_ERR_LINT_FAILED_X_path_X_errors_X	= "msg_err_lint_failed"
+	ID_MSG_LINT_SUMMARY_X_path_X_count_X_errors_X	= "msg_lint_summary"
+	ID_MSG_LINT_DUPLICATE_KEY_X_key_X_path_X	= "msg_lint_duplicate_key"
+	ID_MSG_LINT_UNUSED_INPUT_X_key_X_action_X_path_X	= "msg_lint_unused_input"
+	ID_MSG_LINT_UNREFERENCED_ACTION_X_action_X	= "msg_lint_unreferenced_action"
+	ID_MSG_LINT_LONG_TIMEOUT_X_action_X_value_X_max_X	= "msg_lint_long_timeout"
+	ID_MSG_LINT_MISSING_DESCRIPTION_X_key_X_name_X	= "msg_lint_missing_description"
+	ID_MSG_VERIFY_NOT_DEPLOYED_X_key_X_name_X	= "msg_verify_not_deployed"
+	ID_MSG_VERIFY_MISMATCH_X_key_X_name_X_field_X_expected_X_value_X	= "msg_verify_mismatch"
+	ID_MSG_VERIFY_MATCH_X_path_X	= "msg_verify_match"
+	ID_ERR_VERIFY_FAILED_X_path_X_mismatches_X	= "msg_err_verify_failed"
+	ID_ERR_DEPENDENCY_LOCATION_INVALID_X_name_X_location_X	= "msg_err_dependency_location_invalid"
+	ID_ERR_DEPENDENCY_CYCLE_X_name_X_chain_X	= "msg_err_dependency_cycle"
+	ID_ERR_DEPENDENCY_DEPTH_X_name_X_chain_X_max_X	= "msg_err_dependency_depth"
+	ID_ERR_DEPENDENCY_MANIFEST_NOT_FOUND_X_name_X_location_X_path_X	= "msg_err_dependency_manifest_not_found"
+	ID_ERR_ACTION_CODE_SIZE_X_path_X_value_X_max_X	= "msg_err_action_code_size"
+	ID_MSG_HISTORY_RECORDED_X_path_X	= "msg_history_recorded_X_path_X"
+	ID_MSG_HISTORY_NOT_FOUND_X_path_X	= "msg_history_not_found_X_path_X"
+	ID_MSG_HISTORY_CHANGELOG_X_old_X_new_X	= "msg_history_changelog_X_old_X_new_X"
+	ID_MSG_HISTORY_NO_CHANGES	= "msg_history_no_changes"
+	ID_ERR_NAMING_CONVENTION_X_key_X_name_X_pattern_X	= "msg_err_naming_convention_X_key_X_name_X_pattern_X"
+	ID_ERR_NAMING_CONVENTIONS_INVALID_X_path_X_err_X	= "msg_err_naming_conventions_invalid_X_path_X_err_X"
+	ID_ERR_NAMING_SEVERITY_INVALID_X_value_X	= "msg_err_naming_severity_invalid_X_value_X"
+	ID_ERR_NAMING_ENTITY_UNKNOWN_X_key_X_entities_X	= "msg_err_naming_entity_unknown_X_key_X_entities_X"
+	ID_ERR_DEPLOYMENT_ENTITY_NOT_IN_MANIFEST_X_key_X_name_X	= "msg_err_deployment_entity_not_in_manifest_X_key_X_name_X"
+	ID_ERR_RULE_STATUS_INVALID_X_name_X_value_X	= "msg_err_rule_status_invalid_X_name_X_value_X"
+	ID_MSG_WATCH_WAITING_X_path_X	= "msg_watch_waiting_X_path_X"
+	ID_MSG_WATCH_CHANGED_X_path_X	= "msg_watch_changed_X_path_X"
+	ID_MSG_WATCH_NO_CHANGES	= "msg_watch_no_changes"
+	ID_WARN_WATCH_REMOVED_X_key_X_name_X	= "msg_warn_watch_removed_X_key_X_name_X"
+	ID_ERR_WATCH_REMOTE_PROJECT_X_path_X	= "msg_err_watch_remote_project_X_path_X"
+	ID_ERR_IMMUTABLE_VERSIONS	= "msg_err_immutable_versions"
+	ID_MSG_IMMUTABLE_VERSION_CHANGED_X_name_X_version_X	= "msg_immutable_version_changed_X_name_X_version_X"
+	ID_MSG_PACKAGE_VERSION_BUMPED_X_name_X_old_X_new_X	= "msg_package_version_bumped_X_name_X_old_X_new_X"
+	ID_MSG_METRICS_RECORDED_X_path_X	= "msg_metrics_recorded_X_path_X"
+	ID_MSG_METRICS_NOT_FOUND_X_path_X	= "msg_metrics_not_found_X_path_X"
+	ID_MSG_METRICS_DEPLOYMENTS_X_count_X_failures_X_rate_X	= "msg_metrics_deployments_X_count_X_failures_X_rate_X"
+	ID_MSG_METRICS_DURATIONS_X_last_X_average_X_min_X_max_X	= "msg_metrics_durations_X_last_X_average_X_min_X_max_X"
+	ID_MSG_METRICS_ENTITIES_X_old_X_new_X	= "msg_metrics_entities_X_old_X_new_X"
+	ID_MSG_METRICS_SLOWEST	= "msg_metrics_slowest"
+	ID_MSG_VIRTUALENV_BUILD_X_action_X_path_X	= "msg_virtualenv_build_X_action_X_path_X"
+	ID_ERR_VIRTUALENV_BUILD_X_action_X_command_X_err_X_output_X	= "msg_err_virtualenv_build_X_action_X_command_X_err_X_output_X"
+	ID_MSG_ACTION_BUILD_X_action_X_command_X	= "msg_action_build_X_action_X_command_X"
+	ID_ERR_ACTION_BUILD_X_action_X_command_X_err_X_output_X	= "msg_err_action_build_X_action_X_command_X_err_X_output_X"
+	ID_ERR_JAVA_MAIN_MISSING_X_action_X_path_X	= "msg_err_java_main_missing_X_action_X_path_X"
+	ID_ERR_JAVA_MAIN_NOT_FOUND_X_action_X_main_X_path_X	= "msg_err_java_main_not_found_X_action_X_main_X_path_X"
+	ID_ERR_SET_INVALID_X_value_X	= "msg_err_set_invalid_X_value_X"
+	ID_ERR_SET_PATH_X_value_X_key_X	= "msg_err_set_path_X_value_X_key_X"
+	ID_ERR_PROVIDER_UNKNOWN_X_name_X_names_X	= "msg_err_provider_unknown_X_name_X_names_X"
+	ID_MSG_PROVIDER_SOURCE_X_name_X	= "msg_provider_source_X_name_X"
+	ID_MSG_PROVIDER_RUNTIME_X_action_X_kind_X_runtime_X_name_X	= "msg_provider_runtime_X_action_X_kind_X_runtime_X_name_X"
+	ID_WARN_PROVIDER_NO_API_GATEWAY_X_name_X_count_X	= "msg_warn_provider_no_api_gateway_X_name_X_count_X"
+	ID_ERR_GRAPH_INVALID_X_count_X	= "msg_err_graph_invalid"
+	ID_ERR_GRAPH_RULE_TRIGGER_NOT_FOUND_X_rule_X_trigger_X	= "msg_err_graph_rule_trigger_not_found"
+	ID_ERR_GRAPH_RULE_ACTION_NOT_FOUND_X_rule_X_action_X	= "msg_err_graph_rule_action_not_found"
+	ID_ERR_GRAPH_SEQUENCE_ACTION_NOT_FOUND_X_sequence_X_action_X	= "msg_err_graph_sequence_action_not_found"
+	ID_ERR_GRAPH_API_NOT_WEB_X_api_X_action_X	= "msg_err_graph_api_not_web"
+	ID_MSG_CONFIG_VALUE_X_key_X_value_X_source_X	= "msg_config_value"
+	ID_MSG_CONFIG_VALUE_NOT_SET_X_key_X	= "msg_config_value_not_set"
+	ID_MSG_DEPENDENCY_FETCH_STATUS_X_done_X_total_X_running_X	= "msg_dependency_fetch_status"
+	ID_MSG_DEPENDENCY_FETCHED_X_count_X_duration_X	= "msg_dependency_fetched"
+	ID_MSG_DEPENDENCY_REUSED_X_name_X_location_X_version_X	= "msg_dependency_reused"
+	ID_ERR_FEED_INPUT_MISSING_X_trigger_X_feed_X_input_X_hint_X	= "msg_err_feed_input_missing"
+	ID_ERR_FEED_INPUTS_INVALID_X_count_X	= "msg_err_feed_inputs_invalid"
+	ID_MSG_FEED_HINT_ALARM_CRON	= "msg_feed_hint_alarm_cron"
+	ID_MSG_FEED_HINT_ALARM_ONCE	= "msg_feed_hint_alarm_once"
+	ID_MSG_FEED_HINT_ALARM_INTERVAL	= "msg_feed_hint_alarm_interval"
+	ID_MSG_FEED_HINT_CLOUDANT_DBNAME	= "msg_feed_hint_cloudant_dbname"
+	ID_MSG_FEED_HINT_MESSAGING_TOPIC	= "msg_feed_hint_messaging_topic"
+	ID_MSG_FEED_HINT_GITHUB_WEBHOOK	= "msg_feed_hint_github_webhook"
+	ID_ERR_MANIFEST_EMPTY_X_path_X	= "msg_err_manifest_empty"
+	ID_ERR_PACKAGES_EMPTY_X_path_X_names_X	= "msg_err_packages_empty"
+	ID_WARN_MANIFEST_EMPTY_X_path_X	= "msg_warn_manifest_empty"
+	ID_WARN_PACKAGES_EMPTY_X_path_X_names_X	= "msg_warn_packages_empty"
+	ID_MSG_RESULTS_WRITTEN_X_path_X	= "msg_results_written"
+	ID_ERR_KEYCHAIN_EMPTY	= "msg_err_keychain_empty"
+	ID_ERR_KEYCHAIN_READ_X_account_X_err_X	= "msg_err_keychain_read"
+	ID_ERR_KEYCHAIN_WRITE_X_account_X_err_X	= "msg_err_keychain_write"
+	ID_ERR_KEYCHAIN_DELETE_X_account_X_err_X	= "msg_err_keychain_delete"
+	ID_MSG_KEYCHAIN_SOURCE_X_source_X_account_X	= "msg_keychain_source"
+	ID_MSG_KEYCHAIN_PROMPT_AUTHKEY	= "msg_keychain_prompt_authkey"
+	ID_MSG_KEYCHAIN_STORED_X_account_X	= "msg_keychain_stored"
+	ID_MSG_KEYCHAIN_DELETED_X_account_X	= "msg_keychain_deleted"
+	ID_ERR_ENCRYPTION_X_entity_X_input_X_provider_X_err_X	= "msg_err_encryption"
+	ID_ERR_ENCRYPTION_PROVIDER_UNKNOWN	= "msg_err_encryption_provider_unknown"
+	ID_ERR_ENCRYPTION_CIPHERTEXT_INVALID	= "msg_err_encryption_ciphertext_invalid"
+	ID_ERR_ENCRYPTION_KEY_INVALID_X_name_X	= "msg_err_encryption_key_invalid"
+	ID_ERR_ENCRYPTION_PROVIDER_MISSING_X_entity_X_inputs_X	= "msg_err_encryption_provider_missing"
+	ID_ERR_DEPLOYMENT_ANNOTATION_NOT_IN_MANIFEST_X_key_X_name_X_annotation_X	= "msg_err_deployment_annotation_not_in_manifest_X_key_X_name_X_annotation_X"
+	ID_WARN_DEPLOYMENT_ENTITY_NOT_IN_MANIFEST_X_key_X_name_X	= "msg_warn_deployment_entity_not_in_manifest_X_key_X_name_X"
+	ID_ERR_SEQUENCE_WEB_INVALID_X_sequence_X_value_X	= "msg_err_sequence_web_invalid_X_sequence_X_value_X"
+	ID_MSG_VERIFY_PROVENANCE_X_key_X_name_X_provenance_X	= "msg_verify_provenance_X_key_X_name_X_provenance_X"
+	ID_ERR_ENTITY_OVERRIDE_INVALID_X_flag_X_value_X	= "msg_err_entity_override_invalid_X_flag_X_value_X"
+	ID_ERR_ENTITY_OVERRIDE_NOT_FOUND_X_flag_X_entity_X_value_X	= "msg_err_entity_override_not_found_X_flag_X_entity_X_value_X"
+	ID_ERR_ENTITY_OVERRIDE_NO_PARAMETERS_X_entity_X_value_X	= "msg_err_entity_override_no_parameters_X_entity_X_value_X"
+	ID_ERR_DEPLOY_AS_INVALID_X_suffix_X	= "msg_err_deploy_as_invalid_X_suffix_X"
+	ID_ERR_DEPLOY_AS_NOT_DEPLOYED_X_suffix_X_names_X	= "msg_err_deploy_as_not_deployed_X_suffix_X_names_X"
+	ID_ERR_DEPLOY_AS_LIVE_X_suffix_X_path_X	= "msg_err_deploy_as_live_X_suffix_X_path_X"
+	ID_ERR_ROLLBACK_NO_PREVIOUS_X_path_X	= "msg_err_rollback_no_previous_X_path_X"
+	ID_MSG_DEPLOY_AS_VERIFIED_X_suffix_X_count_X	= "msg_deploy_as_verified_X_suffix_X_count_X"
+	ID_MSG_DEPLOY_AS_SWITCHED_X_suffix_X_previous_X	= "msg_deploy_as_switched_X_suffix_X_previous_X"
+	ID_MSG_TAIL_STARTED_X_count_X	= "msg_tail_started_X_count_X"
+	ID_MSG_TAIL_ACTIVATION_X_name_X_id_X_status_X_duration_X	= "msg_tail_activation_X_name_X_id_X_status_X_duration_X"
+	ID_ERR_BINDING_PACKAGE_MISSING_X_binding_X_package_X	= "msg_err_binding_package_missing_X_binding_X_package_X"
+	ID_ERR_BINDING_NAME_CONFLICT_X_binding_X_package_X	= "msg_err_binding_name_conflict_X_binding_X_package_X"
+	ID_ERR_ACTION_FUNCTION_NOT_FOUND_X_action_X_path_X	= "msg_err_action_function_not_found_X_action_X_path_X"
+	ID_MSG_VALIDATE_VALID_X_path_X	= "msg_validate_valid_X_path_X"
+	ID_ERR_VALIDATE_FAILED_X_path_X_count_X	= "msg_err_validate_failed_X_path_X_count_X"
+	ID_MSG_CONFIG_INFO_IAM_API_KEY_X_source_X	= "msg_config_iam_api_key_info"
+	ID_ERR_IAM_TOKEN_X_url_X_err_X	= "msg_err_iam_token_X_url_X_err_X"
+	ID_ERR_HOOK_INVALID_X_name_X	= "msg_err_hook_invalid"
+	ID_ERR_HOOK_INPUTS_WITH_RUN_X_name_X	= "msg_err_hook_inputs_with_run"
+	ID_ERR_HOOK_ON_FAILURE_INVALID_X_name_X_value_X	= "msg_err_hook_on_failure_invalid"
+	ID_MSG_HOOK_RUN_X_name_X_command_X	= "msg_hook_run"
+	ID_MSG_HOOK_INVOKE_X_name_X_action_X	= "msg_hook_invoke"
+	ID_ERR_HOOK_FAILED_X_name_X_err_X_output_X	= "msg_err_hook_failed"
+	ID_WARN_HOOK_FAILED_X_name_X_err_X	= "msg_warn_hook_failed"
+	ID_ERR_SERVERLESS_PROVIDER_X_provider_X	= "msg_err_serverless_provider"
+	ID_WARN_SERVERLESS_LEFT_OUT_X_key_X_name_X	= "msg_warn_serverless_left_out"
+	ID_WARN_SERVERLESS_HANDLER_X_name_X_function_X	= "msg_warn_serverless_handler"
+	ID_ERR_SERVERLESS_NOT_FOUND_X_path_X	= "msg_err_serverless_not_found"
+	ID_MSG_IMPORT_WRITTEN_X_path_X_source_X	= "msg_import_written"
+	ID_ERR_REQUIRED_INPUT_MISSING_X_entity_X_input_X_sources_X	= "msg_err_required_input_missing"
+	ID_ERR_REQUIRED_INPUTS_INVALID_X_count_X	= "msg_err_required_inputs_invalid"
+	ID_MSG_REQUIRED_INPUT_SOURCE_ENV_X_name_X	= "msg_required_input_source_env"
+	ID_MSG_REQUIRED_INPUT_SOURCE_DEPLOYMENT_X_key_X	= "msg_required_input_source_deployment"
+	ID_MSG_REQUIRED_INPUT_SOURCE_PARAM_X_value_X	= "msg_required_input_source_param"
+	ID_MSG_ACTION_DOWNLOAD_X_action_X_url_X	= "msg_action_download"
+	ID_ERR_ACTION_DOWNLOAD_X_action_X_url_X_err_X	= "msg_err_action_download"
+	ID_ERR_ACTION_CHECKSUM_X_action_X_url_X_expected_X_value_X	= "msg_err_action_checksum"
+	ID_ERR_OVERLAY_NOT_MAP_X_path_X	= "msg_err_overlay_not_map"
+	ID_MSG_FEED_UNCHANGED_X_trigger_X	= "msg_feed_unchanged"
+	ID_MSG_FEED_UPDATED_X_trigger_X	= "msg_feed_updated"
+	ID_WARN_FEED_UPDATE_FAILED_X_trigger_X_err_X	= "msg_warn_feed_update_failed"
+	ID_ERR_PLUGIN_INVALID_X_name_X	= "msg_err_plugin_invalid"
+	ID_ERR_PLUGIN_KEY_CONFLICT_X_key_X_name_X_value_X	= "msg_err_plugin_key_conflict"
+	ID_ERR_KEY_UNKNOWN_X_key_X_entity_X	= "msg_err_key_unknown"
+	ID_MSG_PLUGIN_RUN_X_name_X_key_X_entity_X_command_X	= "msg_plugin_run"
+	ID_MSG_PLUGIN_STEP_X_name_X_step_X	= "msg_plugin_step"
+	ID_ERR_PLUGIN_FAILED_X_name_X_key_X_entity_X_err_X	= "msg_err_plugin_failed"
+	ID_ERR_SNAPSHOT_VERSION_X_version_X	= "msg_err_snapshot_version"
+	ID_ERR_SNAPSHOT_TARGET_X_path_X_key_X_value_X_expected_X	= "msg_err_snapshot_target"
+	ID_MSG_SNAPSHOT_RECORDED_X_path_X	= "msg_snapshot_recorded"
+	ID_MSG_SNAPSHOT_UNDEPLOY_X_path_X	= "msg_snapshot_undeploy"
+	ID_ERR_SEQUENCE_CYCLE_X_sequence_X_chain_X	= "msg_err_sequence_cycle"
+	ID_ERR_RUNTIME_NOT_DISCOVERED_X_action_X	= "msg_err_runtime_not_discovered"
+	ID_ERR_RUNTIME_MISSING_ZIP_X_action_X	= "msg_err_runtime_missing_zip"
+	ID_ERR_RUNTIME_UNSUPPORTED_ZIP_X_runtime_X_action_X	= "msg_err_runtime_unsupported_zip"
+	ID_MSG_RUNTIME_NOT_SPECIFIED	= "msg_runtime_not_specified"
+	ID_ERR_DEPENDENCY_TYPE_UNKNOWN_X_name_X_location_X	= "msg_err_dependency_type_unknown"
+	ID_ERR_PARAMETER_NOT_SINGLE_LINE_X_key_X	= "msg_err_parameter_not_single_line"
+	ID_ERR_PARAMETER_TYPE_INVALID_X_key_X_value_X	= "msg_err_parameter_type_invalid"
+	ID_ERR_PARAMETER_NOT_MULTILINE_X_key_X	= "msg_err_parameter_not_multiline"
+	ID_ERR_PARAMETER_NOT_JSON_X_key_X	= "msg_err_parameter_not_json"
+	ID_ERR_PACKAGE_EXISTS_X_package_X	= "msg_err_package_exists"
+	ID_ERR_ACTION_SOURCE_CONFLICT_X_action_X_source_X_path_X	= "msg_err_action_source_conflict"
+	ID_ERR_ACTION_SOURCE_LOCATION_MISSING_X_action_X	= "msg_err_action_source_location_missing"
+	ID_ERR_ACTION_KIND_MISSING_X_action_X	= "msg_err_action_kind_missing"
+	ID_ERR_ACTION_SOURCE_CODE_MISSING_X_action_X	= "msg_err_action_source_code_missing"
+	ID_ERR_SEQUENCE_NAME_USED_BY_ACTION_X_sequence_X	= "msg_err_sequence_name_used_by_action"
+	ID_MSG_INSPECTING_PROJECT_DIRECTORY	= "msg_inspecting_project_directory"
+	ID_MSG_SEARCHING_DIRECTORY_X_path_X	= "msg_searching_directory"
+	ID_WARN_ENV_VARIABLE_MISSING_X_key_X	= "msg_warn_env_variable_missing"
+	ID_MSG_DEPLOYMENT_STATUS_HEADER	= "msg_deployment_status_header"
+	ID_WARN_CONFIG_FILE_INVALID_X_path_X	= "msg_warn_config_file_invalid"
+	ID_MSG_VERSION_CHECK_X_version_X_spec_X	= "msg_version_check"
+	ID_MSG_RUNTIMES_HEADER	= "msg_runtimes_header"
+	ID_MSG_RUNTIMES_EXTENSIONS_HEADER	= "msg_runtimes_extensions_header"
+	ID_WARN_MESSAGE_CATALOG_X_path_X_err_X	= "msg_warn_message_catalog"
+	ID_CMD_FLAG_ADD_MANIFEST	= "msg_cmd_flag_add_manifest"
+	ID_CMD_FLAG_ADD_PACKAGE	= "msg_cmd_flag_add_package"
+	ID_CMD_FLAG_ADD_FUNCTION	= "msg_cmd_flag_add_function"
+	ID_CMD_FLAG_ADD_RUNTIME_X_runtime_X	= "msg_cmd_flag_add_runtime_X_runtime_X"
+	ID_CMD_FLAG_ADD_MAIN	= "msg_cmd_flag_add_main"
+	ID_CMD_FLAG_ADD_WEB	= "msg_cmd_flag_add_web"
+	ID_CMD_FLAG_ADD_FEED	= "msg_cmd_flag_add_feed"
+	ID_CMD_FLAG_ADD_TRIGGER	= "msg_cmd_flag_add_trigger"
+	ID_CMD_FLAG_ADD_ACTION	= "msg_cmd_flag_add_action"
+	ID_ERR_PROFILE_NAME_INVALID_X_line_X_value_X	= "msg_err_profile_name_invalid_X_line_X_value_X"
+	ID_ERR_PROFILE_DUPLICATE_X_line_X_name_X	= "msg_err_profile_duplicate_X_line_X_name_X"
+	ID_ERR_LINE_MISSING_SEPARATOR_X_line_X_value_X	= "msg_err_line_missing_separator_X_line_X_value_X"
+	ID_ERR_PROFILE_KEY_WITHOUT_PROFILE_X_line_X_value_X	= "msg_err_profile_key_without_profile_X_line_X_value_X"
+	ID_ERR_DOTENV_NAME_INVALID_X_line_X_name_X	= "msg_err_dotenv_name_invalid_X_line_X_name_X"
+	ID_ERR_LINE_X_line_X_err_X	= "msg_err_line_X_line_X_err_X"
+	ID_ERR_DOTENV_AFTER_QUOTE_X_value_X	= "msg_err_dotenv_after_quote_X_value_X"
+	ID_ERR_DOTENV_MISSING_QUOTE_X_value_X	= "msg_err_dotenv_missing_quote_X_value_X"
+	ID_ERR_SWAGGER_NOT_OBJECT	= "msg_err_swagger_not_object"
+	ID_ERR_SWAGGER_NO_OPERATION	= "msg_err_swagger_no_operation"
+	ID_ERR_SWAGGER_OPERATION_NOT_BOUND_X_method_X_path_X	= "msg_err_swagger_operation_not_bound_X_method_X_path_X"
+	ID_ERR_SWAGGER_OPERATION_NO_ACTION_X_method_X_path_X	= "msg_err_swagger_operation_no_action_X_method_X_path_X"
+	ID_ERR_API_HOST_EMPTY	= "msg_err_api_host_empty"
+	ID_ERR_API_HOST_NO_HOSTNAME_X_host_X	= "msg_err_api_host_no_hostname_X_host_X"
+	ID_ERR_VERSION_INVALID_X_version_X	= "msg_err_version_invalid_X_version_X"
+	ID_ERR_VERSION_OPERATOR_INVALID_X_operator_X_constraint_X	= "msg_err_version_operator_invalid_X_operator_X_constraint_X"
+	ID_ERR_LICENSE_EXPRESSION_EMPTY	= "msg_err_license_expression_empty"
+	ID_ERR_LICENSE_EXPRESSION_UNEXPECTED_X_value_X	= "msg_err_license_expression_unexpected_X_value_X"
+	ID_ERR_LICENSE_EXPRESSION_MISSING_LICENSE	= "msg_err_license_expression_missing_license"
+	ID_ERR_LICENSE_EXPRESSION_MISSING_PARENTHESIS	= "msg_err_license_expression_missing_parenthesis"
+	ID_ERR_LICENSE_EXPRESSION_MISSING_EXCEPTION_X_value_X	= "msg_err_license_expression_missing_exception_X_value_X"
+	ID_ERR_QUALIFIED_NAME_INVALID	= "msg_err_qualified_name_invalid"
+	ID_ERR_RUNTIMES_NO_API_HOST	= "msg_err_runtimes_no_api_host"
+	ID_ERR_RUNTIMES_NONE_FOUND	= "msg_err_runtimes_none_found"
+	ID_ERR_ARCHIVE_PATH_INVALID_X_path_X	= "msg_err_archive_path_invalid_X_path_X"
+	ID_ERR_ACTION_TYPE_UNSUPPORTED	= "msg_err_action_type_unsupported"
+	ID_MSG_RETRY_X_attempt_X_err_X	= "msg_retry_X_attempt_X_err_X"
+	ID_WARN_THROTTLED_X_duration_X_max_X	= "msg_warn_throttled_X_duration_X_max_X"
+	ID_MSG_VERSION_X_build_X_version_X	= "msg_version_X_build_X_version_X"
+	ID_MSG_REGISTRY_URL_NOT_FOUND	= "msg_registry_url_not_found"
+	ID_ERR_REGISTRY_URL_MALFORMED	= "msg_err_registry_url_malformed"
+	ID_ERR_MANIFEST_REPOSITORY_URL_MALFORMED_X_url_X	= "msg_err_manifest_repository_url_malformed_X_url_X"
+	ID_ERR_MANIFEST_REPOSITORY_URL_MISSING	= "msg_err_manifest_repository_url_missing"
+	ID_WARN_WHISK_PROPS_READ_X_path_X_err_X	= "msg_warn_whisk_props_read_X_path_X_err_X"
+	ID_WARN_WHISK_PROPS_CREATE_X_path_X_err_X	= "msg_warn_whisk_props_create_X_path_X_err_X"
+	ID_MSG_PARAMETER_DUMP_X_description_X_type_X_actual_X_value_X_default_X	= "msg_parameter_dump_X_description_X_type_X_actual_X_value_X_default_X"
 )
 
 // Known keys used for text replacement in i18n translated strings
@@ -121,6 +445,7 @@ const(
 	KEY_PROJECT		= "project"
 	KEY_ACTION		= "action"
 	KEY_LIMIT		= "limit"
+	KEY_MAX			= "max"
 	KEY_HOST		= "host"
 	KEY_SOURCE		= "source"
 	KEY_NAMESPACE		= "namespace"
@@ -134,6 +459,82 @@ const(
 	KEY_OLD			= "oldkey"
 	KEY_NEW			= "newkey"
 	KEY_FILE_TYPE		= "filetype"
+	KEY_CHAIN		= "chain"
+	KEY_REFERENCE		= "reference"
+	KEY_COUNT		= "count"
+	KEY_LOCATION		= "location"
+	KEY_BACKUP		= "backup"
+	KEY_EVENT		= "event"
+	KEY_ENTITY		= "entity"
+	KEY_SOURCES		= "sources"
+	KEY_STEP		= "step"
+	KEY_URL			= "url"
+	KEY_RUNTIMES		= "runtimes"
+	KEY_SEQUENCE		= "sequence"
+	KEY_VERSION		= "version"
+	KEY_TRIGGERS		= "triggers"
+	KEY_ID			= "id"
+	KEY_DONE		= "done"
+	KEY_TOTAL		= "total"
+	KEY_RUNNING		= "running"
+	KEY_TIMEOUT		= "timeout"
+	KEY_PROFILES		= "profiles"
+	KEY_ENCODING		= "encoding"
+	KEY_LINE		= "line"
+	KEY_SECRET		= "secret"
+	KEY_LICENSE		= "license"
+	KEY_PROJECTS		= "projects"
+	KEY_MERGES		= "merges"
+	KEY_PROXY		= "proxy"
+	KEY_STATUS		= "status"
+	KEY_EXPECTED		= "expected"
+	KEY_FORMAT		= "format"
+	KEY_BINDING		= "binding"
+	KEY_PACKAGE		= "package"
+	KEY_SUFFIX		= "suffix"
+	KEY_PREVIOUS		= "previous"
+	KEY_FLAG		= "flag"
+	KEY_PROVENANCE	= "provenance"
+	KEY_ANNOTATION	= "annotation"
+	KEY_INPUTS		= "inputs"
+	KEY_PROVIDER		= "provider"
+	KEY_ACCOUNT		= "account"
+	KEY_HINT		= "hint"
+	KEY_INPUT		= "input"
+	KEY_FEED		= "feed"
+	KEY_DURATION		= "duration"
+	KEY_API		= "api"
+	KEY_NAMES		= "names"
+	KEY_MAIN		= "main"
+	KEY_MIN		= "min"
+	KEY_AVERAGE		= "average"
+	KEY_LAST		= "last"
+	KEY_RATE		= "rate"
+	KEY_FAILURES		= "failures"
+	KEY_PATTERN		= "pattern"
+	KEY_ENTITIES		= "entities"
+	KEY_FIELD		= "field"
+	KEY_MISMATCHES		= "mismatches"
+	KEY_RULE		= "rule"
+	KEY_RULES		= "rules"
+	KEY_SEVERITIES		= "severities"
+	KEY_ERRORS		= "errors"
+	KEY_KIND		= "kind"
+	KEY_FORMATS		= "formats"
+	KEY_TRIGGER		= "trigger"
+	KEY_OUTPUT		= "output"
+	KEY_COMMAND		= "command"
+	KEY_METHOD		= "method"
+	KEY_OPERATOR		= "operator"
+	KEY_CONSTRAINT		= "constraint"
+	KEY_ATTEMPT		= "attempt"
+	KEY_FUNCTION		= "function"
+	KEY_SPEC	= "spec"
+	KEY_BUILD	= "build"
+	KEY_DESCRIPTION	= "description"
+	KEY_TYPE	= "type"
+	KEY_ACTUAL	= "actual"
+	KEY_DEFAULT	= "default"
 )
 
 var I18N_ID_SET = [](string){
@@ -180,6 +581,9 @@ var I18N_ID_SET = [](string){
 	ID_MSG_DEPENDENCY_UNDEPLOYMENT_FAILURE_X_name_X,
 	ID_MSG_MANAGED_UNDEPLOYMENT_FAILED,
 	ID_MSG_MANAGED_FOUND_DELETED_X_key_X_name_X_project_X,
+	ID_MSG_CHECKPOINT_SAVED_X_path_X,
+	ID_MSG_CHECKPOINT_RESUMING_X_path_X,
+	ID_MSG_CHECKPOINT_SKIPPED_X_key_X_name_X,
 	ID_MSG_PROMPT_DEPLOY,
 	ID_MSG_PROMPT_UNDEPLOY,
 	ID_MSG_PROMPT_AUTHKEY,
@@ -195,9 +599,328 @@ var I18N_ID_SET = [](string){
 	ID_WARN_LIMITS_MEMORY_SIZE,
 	ID_WARN_LIMITS_LOG_SIZE,
 	ID_WARN_LIMIT_UNCHANGEABLE_X_name_X,
+	ID_WARN_CHECKPOINT_NOT_FOUND_X_path_X,
+	ID_WARN_CHECKPOINT_MANIFEST_MISMATCH_X_path_X_mpath_X,
 	ID_ERR_GET_RUNTIMES_X_err_X,
 	ID_ERR_MISSING_MANDATORY_KEY_X_key_X,
 	ID_ERR_MISMATCH_NAME_X_key_X_dname_X_dpath_X_mname_X_moath_X,
 	ID_ERR_CREATE_ENTITY_X_key_X_err_X_code_X,
 	ID_ERR_DELETE_ENTITY_X_key_X_err_X_code_X,
+	ID_ERR_RECURSIVE_INCLUDE_X_path_X_chain_X,
+	ID_ERR_DEPLOYED_REFERENCE_UNRESOLVED_X_reference_X_key_X_name_X,
+	ID_ERR_ENTITY_EXISTS_X_key_X_name_X,
+	ID_MSG_MANIFEST_ENTITY_ADDED_X_key_X_name_X_path_X,
+	ID_ERR_ADD_PACKAGE_REQUIRED_X_path_X,
+	ID_WARN_DEPRECATION_REPORT_X_count_X,
+	ID_MSG_DEPRECATED_KEY_X_location_X_oldkey_X_newkey_X,
+	ID_MSG_MIGRATE_UP_TO_DATE_X_path_X,
+	ID_MSG_MIGRATE_FILE_X_path_X_count_X,
+	ID_MSG_MIGRATE_BACKUP_X_path_X_backup_X,
+	ID_MSG_NOTIFICATION_X_event_X_entity_X_namespace_X,
+	ID_ERR_NOTIFICATION_INVALID_X_key_X_value_X,
+	ID_WARN_NOTIFICATION_FAILED_X_host_X_err_X,
+	ID_MSG_UNMARSHAL_CACHE_X_path_X,
+	ID_ERR_INVALID_API_HOST_X_host_X_err_X,
+	ID_MSG_WEB_ACTION_URL_X_name_X_url_X,
+	ID_MSG_ENV_FILE_LOAD_X_path_X,
+	ID_MSG_RUNTIMES_X_host_X,
+	ID_ERR_RUNTIME_KIND_UNKNOWN_X_runtime_X_runtimes_X,
+	ID_MSG_RUNTIMES_BUILTIN,
+	ID_ERR_SEQUENCE_COMPONENT_INVALID_X_sequence_X_action_X,
+	ID_ERR_SEQUENCE_COMPONENT_NOT_FOUND_X_sequence_X_action_X,
+	ID_ERR_VERSION_REQUIREMENT_X_key_X_value_X_version_X,
+	ID_ERR_VERSION_REQUIREMENT_INVALID_X_key_X_value_X_err_X,
+	ID_ERR_TRIGGER_NOT_IN_PROJECT_X_name_X_triggers_X,
+	ID_MSG_TRIGGER_FIRED_X_name_X_id_X,
+	ID_ERR_TRIGGER_PARAM_VALUE_MISSING_X_key_X,
+	ID_MSG_PARALLEL_DEPLOY_STATUS_X_done_X_total_X_running_X,
+	ID_ERR_ENTITY_TIMEOUT_X_key_X_name_X_timeout_X,
+	ID_MSG_ENTITY_SKIPPED_X_key_X_name_X,
+	ID_ERR_PARTIAL_DEPLOYMENT_X_count_X,
+	ID_ERR_PROFILE_NOT_FOUND_X_name_X_path_X_profiles_X,
+	ID_ERR_PROFILE_INVALID_X_path_X_err_X,
+	ID_MSG_PROFILE_SOURCE_X_name_X,
+	ID_MSG_CONFIG_INFO_APIGW_HOST_X_host_X_source_X,
+	ID_MSG_CONFIG_INFO_APIGW_ACCESS_TOKEN_X_source_X,
+	ID_WARN_FILE_BOM_X_path_X_encoding_X,
+	ID_WARN_FILE_CRLF_X_path_X,
+	ID_ERR_FILE_ENCODING_INVALID_X_path_X_line_X,
+	ID_MSG_WEB_ACTION_SECRET_X_name_X_secret_X,
+	ID_ERR_WEB_SECURE_REQUIRES_WEB_X_action_X,
+	ID_ERR_WEB_SECURE_INVALID_X_action_X_value_X,
+	ID_ERR_INPUTS_SCOPE_INVALID_X_value_X,
+	ID_ERR_LICENSE_INVALID_X_license_X_err_X,
+	ID_ERR_LICENSE_UNKNOWN_X_license_X,
+	ID_ERR_LICENSE_NOT_ALLOWED_X_license_X_path_X,
+	ID_ERR_PACKAGE_PATTERN_INVALID_X_value_X_err_X,
+	ID_WARN_NO_PACKAGE_SELECTED_X_path_X,
+	ID_ERR_PROJECT_DUPLICATE_X_project_X_path_X,
+	ID_ERR_PROJECT_AMBIGUOUS_X_path_X_projects_X,
+	ID_ERR_PROJECT_NOT_FOUND_X_project_X_path_X_projects_X,
+	ID_ERR_ENV_NAME_INVALID_X_name_X,
+	ID_WARN_ENV_FILE_NOT_FOUND_X_name_X_path_X,
+	ID_ERR_REPORT_TEMPLATE_X_path_X_err_X,
+	ID_ERR_SWAGGER_INVALID_X_path_X_err_X,
+	ID_ERR_SWAGGER_ACTION_NOT_FOUND_X_action_X_method_X_path_X,
+	ID_ERR_SWAGGER_WITH_ROUTES,
+	ID_MSG_SCANNER_RUN_X_action_X_path_X,
+	ID_WARN_SCANNER_X_action_X_path_X_output_X,
+	ID_ERR_SCANNER_REJECTED_X_action_X_path_X_code_X_output_X,
+	ID_ERR_SCANNER_RUN_X_command_X_err_X,
+	ID_ERR_REMOTE_PROJECT_FETCH_X_url_X_err_X,
+	ID_ERR_REMOTE_PROJECT_PATH_NOT_FOUND_X_path_X_url_X,
+	ID_MSG_REMOTE_PROJECT_FETCHED_X_url_X_path_X,
+	ID_ERR_FEED_ACTION_NOT_ANNOTATED_X_trigger_X_action_X_key_X,
+	ID_ERR_EXPORT_FORMAT_UNKNOWN_X_format_X_formats_X,
+	ID_MSG_EXPORT_WRITTEN_X_path_X,
+	ID_ERR_SECRET_PARAMETER_NOT_FROM_ENV_X_key_X,
+	ID_MSG_SECRETS_FILE_LOAD_X_path_X,
+	ID_WARN_SECRETS_VERBOSE_TRACES_OFF,
+	ID_ERR_ANNOTATION_SET_AND_DELETED_X_key_X_action_X,
+	ID_MSG_ANNOTATION_REMOVED_X_key_X_action_X,
+	ID_MSG_ACTION_RECREATED_X_action_X,
+	ID_ERR_INHERIT_ANNOTATIONS_INVALID_X_name_X_value_X,
+	ID_MSG_OUTPUTS_WRITTEN_X_path_X,
+	ID_MSG_DEPENDENCY_KEPT_X_name_X,
+	ID_ERR_UNEXPECTED_TARGET_X_key_X_value_X_expected_X,
+	ID_WARN_UNEXPECTED_TARGET_OVERRIDDEN_X_key_X_value_X,
+	ID_ERR_ENTITY_REFERENCE_UNRESOLVED_X_reference_X,
+	ID_ERR_ENTITY_REFERENCE_CYCLE_X_reference_X_chain_X,
+	ID_ERR_PREFLIGHT_UNREACHABLE_X_host_X_err_X,
+	ID_ERR_PREFLIGHT_TLS_X_host_X_err_X,
+	ID_ERR_PREFLIGHT_STATUS_X_host_X_url_X_status_X,
+	ID_ERR_PREFLIGHT_NOT_OPENWHISK_X_host_X_url_X,
+	ID_ERR_PREFLIGHT_API_VERSION_X_host_X_version_X_value_X,
+	ID_MSG_PREFLIGHT_PROXY_X_proxy_X,
+	ID_MSG_PREFLIGHT_NO_PROXY,
+	ID_MSG_PREFLIGHT_OK_X_host_X_version_X,
+	ID_ERR_INPUT_MERGE_INVALID_X_key_X_value_X_merges_X,
+	ID_ERR_INPUT_MERGE_NOT_LIST_X_key_X_value_X,
+	ID_ERR_YAML_LIMIT_EXCEEDED_X_limit_X_value_X_max_X,
+	ID_ERR_YAML_INVALID_X_err_X,
+	ID_ERR_RUNTIME_VERSION_WITHOUT_RUNTIME_X_action_X,
+	ID_ERR_RUNTIME_VERSION_WITH_KIND_X_runtime_X_action_X,
+	ID_ERR_RUNTIME_VERSION_NOT_FOUND_X_runtime_X_version_X_action_X,
+	ID_WARN_RUNTIME_DEPRECATED_X_runtime_X_action_X,
+	ID_MSG_RUNTIME_RESOLVED_X_runtime_X_kind_X_action_X,
+	ID_ERR_DEPENDENCY_POLICY_X_name_X,
+	ID_MSG_DEPENDENCY_POLICY_SIDE_EFFECT_X_key_X_name_X,
+	ID_MSG_DEPENDENCY_POLICY_NAMESPACE_X_key_X_name_X_namespace_X,
+	ID_MSG_DEPENDENCY_POLICY_PACKAGE_X_name_X,
+	ID_ERR_LINT_RULE_UNKNOWN_X_rule_X_rules_X,
+	ID_ERR_LINT_SEVERITY_INVALID_X_rule_X_value_X_severities_X,
+	ID_ERR_LINT_FAILED_X_path_X_errors_X,
+	ID_MSG_LINT_SUMMARY_X_path_X_count_X_errors_X,
+	ID_MSG_LINT_DUPLICATE_KEY_X_key_X_path_X,
+	ID_MSG_LINT_UNUSED_INPUT_X_key_X_action_X_path_X,
+	ID_MSG_LINT_UNREFERENCED_ACTION_X_action_X,
+	ID_MSG_LINT_LONG_TIMEOUT_X_action_X_value_X_max_X,
+	ID_MSG_LINT_MISSING_DESCRIPTION_X_key_X_name_X,
+	ID_MSG_VERIFY_NOT_DEPLOYED_X_key_X_name_X,
+	ID_MSG_VERIFY_MISMATCH_X_key_X_name_X_field_X_expected_X_value_X,
+	ID_MSG_VERIFY_MATCH_X_path_X,
+	ID_ERR_VERIFY_FAILED_X_path_X_mismatches_X,
+	ID_ERR_DEPENDENCY_LOCATION_INVALID_X_name_X_location_X,
+	ID_ERR_DEPENDENCY_CYCLE_X_name_X_chain_X,
+	ID_ERR_DEPENDENCY_DEPTH_X_name_X_chain_X_max_X,
+	ID_ERR_DEPENDENCY_MANIFEST_NOT_FOUND_X_name_X_location_X_path_X,
+	ID_ERR_ACTION_CODE_SIZE_X_path_X_value_X_max_X,
+	ID_MSG_HISTORY_RECORDED_X_path_X,
+	ID_MSG_HISTORY_NOT_FOUND_X_path_X,
+	ID_MSG_HISTORY_CHANGELOG_X_old_X_new_X,
+	ID_MSG_HISTORY_NO_CHANGES,
+	ID_ERR_NAMING_CONVENTION_X_key_X_name_X_pattern_X,
+	ID_ERR_NAMING_CONVENTIONS_INVALID_X_path_X_err_X,
+	ID_ERR_NAMING_SEVERITY_INVALID_X_value_X,
+	ID_ERR_NAMING_ENTITY_UNKNOWN_X_key_X_entities_X,
+	ID_ERR_DEPLOYMENT_ENTITY_NOT_IN_MANIFEST_X_key_X_name_X,
+	ID_ERR_RULE_STATUS_INVALID_X_name_X_value_X,
+	ID_MSG_WATCH_WAITING_X_path_X,
+	ID_MSG_WATCH_CHANGED_X_path_X,
+	ID_MSG_WATCH_NO_CHANGES,
+	ID_WARN_WATCH_REMOVED_X_key_X_name_X,
+	ID_ERR_WATCH_REMOTE_PROJECT_X_path_X,
+	ID_ERR_IMMUTABLE_VERSIONS,
+	ID_MSG_IMMUTABLE_VERSION_CHANGED_X_name_X_version_X,
+	ID_MSG_PACKAGE_VERSION_BUMPED_X_name_X_old_X_new_X,
+	ID_MSG_METRICS_RECORDED_X_path_X,
+	ID_MSG_METRICS_NOT_FOUND_X_path_X,
+	ID_MSG_METRICS_DEPLOYMENTS_X_count_X_failures_X_rate_X,
+	ID_MSG_METRICS_DURATIONS_X_last_X_average_X_min_X_max_X,
+	ID_MSG_METRICS_ENTITIES_X_old_X_new_X,
+	ID_MSG_METRICS_SLOWEST,
+	ID_MSG_VIRTUALENV_BUILD_X_action_X_path_X,
+	ID_ERR_VIRTUALENV_BUILD_X_action_X_command_X_err_X_output_X,
+	ID_MSG_ACTION_BUILD_X_action_X_command_X,
+	ID_ERR_ACTION_BUILD_X_action_X_command_X_err_X_output_X,
+	ID_ERR_JAVA_MAIN_MISSING_X_action_X_path_X,
+	ID_ERR_JAVA_MAIN_NOT_FOUND_X_action_X_main_X_path_X,
+	ID_ERR_SET_INVALID_X_value_X,
+	ID_ERR_SET_PATH_X_value_X_key_X,
+	ID_ERR_PROVIDER_UNKNOWN_X_name_X_names_X,
+	ID_MSG_PROVIDER_SOURCE_X_name_X,
+	ID_MSG_PROVIDER_RUNTIME_X_action_X_kind_X_runtime_X_name_X,
+	ID_WARN_PROVIDER_NO_API_GATEWAY_X_name_X_count_X,
+	ID_ERR_GRAPH_INVALID_X_count_X,
+	ID_ERR_GRAPH_RULE_TRIGGER_NOT_FOUND_X_rule_X_trigger_X,
+	ID_ERR_GRAPH_RULE_ACTION_NOT_FOUND_X_rule_X_action_X,
+	ID_ERR_GRAPH_SEQUENCE_ACTION_NOT_FOUND_X_sequence_X_action_X,
+	ID_ERR_GRAPH_API_NOT_WEB_X_api_X_action_X,
+	ID_MSG_CONFIG_VALUE_X_key_X_value_X_source_X,
+	ID_MSG_CONFIG_VALUE_NOT_SET_X_key_X,
+	ID_MSG_DEPENDENCY_FETCH_STATUS_X_done_X_total_X_running_X,
+	ID_MSG_DEPENDENCY_FETCHED_X_count_X_duration_X,
+	ID_MSG_DEPENDENCY_REUSED_X_name_X_location_X_version_X,
+	ID_ERR_FEED_INPUT_MISSING_X_trigger_X_feed_X_input_X_hint_X,
+	ID_ERR_FEED_INPUTS_INVALID_X_count_X,
+	ID_MSG_FEED_HINT_ALARM_CRON,
+	ID_MSG_FEED_HINT_ALARM_ONCE,
+	ID_MSG_FEED_HINT_ALARM_INTERVAL,
+	ID_MSG_FEED_HINT_CLOUDANT_DBNAME,
+	ID_MSG_FEED_HINT_MESSAGING_TOPIC,
+	ID_MSG_FEED_HINT_GITHUB_WEBHOOK,
+	ID_ERR_MANIFEST_EMPTY_X_path_X,
+	ID_ERR_PACKAGES_EMPTY_X_path_X_names_X,
+	ID_WARN_MANIFEST_EMPTY_X_path_X,
+	ID_WARN_PACKAGES_EMPTY_X_path_X_names_X,
+	ID_MSG_RESULTS_WRITTEN_X_path_X,
+	ID_ERR_KEYCHAIN_EMPTY,
+	ID_ERR_KEYCHAIN_READ_X_account_X_err_X,
+	ID_ERR_KEYCHAIN_WRITE_X_account_X_err_X,
+	ID_ERR_KEYCHAIN_DELETE_X_account_X_err_X,
+	ID_MSG_KEYCHAIN_SOURCE_X_source_X_account_X,
+	ID_MSG_KEYCHAIN_PROMPT_AUTHKEY,
+	ID_MSG_KEYCHAIN_STORED_X_account_X,
+	ID_MSG_KEYCHAIN_DELETED_X_account_X,
+	ID_ERR_ENCRYPTION_X_entity_X_input_X_provider_X_err_X,
+	ID_ERR_ENCRYPTION_PROVIDER_UNKNOWN,
+	ID_ERR_ENCRYPTION_CIPHERTEXT_INVALID,
+	ID_ERR_ENCRYPTION_KEY_INVALID_X_name_X,
+	ID_ERR_ENCRYPTION_PROVIDER_MISSING_X_entity_X_inputs_X,
+	ID_ERR_DEPLOYMENT_ANNOTATION_NOT_IN_MANIFEST_X_key_X_name_X_annotation_X,
+	ID_WARN_DEPLOYMENT_ENTITY_NOT_IN_MANIFEST_X_key_X_name_X,
+	ID_ERR_SEQUENCE_WEB_INVALID_X_sequence_X_value_X,
+	ID_MSG_VERIFY_PROVENANCE_X_key_X_name_X_provenance_X,
+	ID_ERR_ENTITY_OVERRIDE_INVALID_X_flag_X_value_X,
+	ID_ERR_ENTITY_OVERRIDE_NOT_FOUND_X_flag_X_entity_X_value_X,
+	ID_ERR_ENTITY_OVERRIDE_NO_PARAMETERS_X_entity_X_value_X,
+	ID_ERR_DEPLOY_AS_INVALID_X_suffix_X,
+	ID_ERR_DEPLOY_AS_NOT_DEPLOYED_X_suffix_X_names_X,
+	ID_ERR_DEPLOY_AS_LIVE_X_suffix_X_path_X,
+	ID_ERR_ROLLBACK_NO_PREVIOUS_X_path_X,
+	ID_MSG_DEPLOY_AS_VERIFIED_X_suffix_X_count_X,
+	ID_MSG_DEPLOY_AS_SWITCHED_X_suffix_X_previous_X,
+	ID_MSG_TAIL_STARTED_X_count_X,
+	ID_MSG_TAIL_ACTIVATION_X_name_X_id_X_status_X_duration_X,
+	ID_ERR_BINDING_PACKAGE_MISSING_X_binding_X_package_X,
+	ID_ERR_BINDING_NAME_CONFLICT_X_binding_X_package_X,
+	ID_ERR_ACTION_FUNCTION_NOT_FOUND_X_action_X_path_X,
+	ID_MSG_VALIDATE_VALID_X_path_X,
+	ID_ERR_VALIDATE_FAILED_X_path_X_count_X,
+	ID_MSG_CONFIG_INFO_IAM_API_KEY_X_source_X,
+	ID_ERR_IAM_TOKEN_X_url_X_err_X,
+	ID_ERR_HOOK_INVALID_X_name_X,
+	ID_ERR_HOOK_INPUTS_WITH_RUN_X_name_X,
+	ID_ERR_HOOK_ON_FAILURE_INVALID_X_name_X_value_X,
+	ID_MSG_HOOK_RUN_X_name_X_command_X,
+	ID_MSG_HOOK_INVOKE_X_name_X_action_X,
+	ID_ERR_HOOK_FAILED_X_name_X_err_X_output_X,
+	ID_WARN_HOOK_FAILED_X_name_X_err_X,
+	ID_ERR_SERVERLESS_PROVIDER_X_provider_X,
+	ID_WARN_SERVERLESS_LEFT_OUT_X_key_X_name_X,
+	ID_WARN_SERVERLESS_HANDLER_X_name_X_function_X,
+	ID_ERR_SERVERLESS_NOT_FOUND_X_path_X,
+	ID_MSG_IMPORT_WRITTEN_X_path_X_source_X,
+	ID_ERR_REQUIRED_INPUT_MISSING_X_entity_X_input_X_sources_X,
+	ID_ERR_REQUIRED_INPUTS_INVALID_X_count_X,
+	ID_MSG_REQUIRED_INPUT_SOURCE_ENV_X_name_X,
+	ID_MSG_REQUIRED_INPUT_SOURCE_DEPLOYMENT_X_key_X,
+	ID_MSG_REQUIRED_INPUT_SOURCE_PARAM_X_value_X,
+	ID_MSG_ACTION_DOWNLOAD_X_action_X_url_X,
+	ID_ERR_ACTION_DOWNLOAD_X_action_X_url_X_err_X,
+	ID_ERR_ACTION_CHECKSUM_X_action_X_url_X_expected_X_value_X,
+	ID_ERR_OVERLAY_NOT_MAP_X_path_X,
+	ID_MSG_FEED_UNCHANGED_X_trigger_X,
+	ID_MSG_FEED_UPDATED_X_trigger_X,
+	ID_WARN_FEED_UPDATE_FAILED_X_trigger_X_err_X,
+	ID_ERR_PLUGIN_INVALID_X_name_X,
+	ID_ERR_PLUGIN_KEY_CONFLICT_X_key_X_name_X_value_X,
+	ID_ERR_KEY_UNKNOWN_X_key_X_entity_X,
+	ID_MSG_PLUGIN_RUN_X_name_X_key_X_entity_X_command_X,
+	ID_MSG_PLUGIN_STEP_X_name_X_step_X,
+	ID_ERR_PLUGIN_FAILED_X_name_X_key_X_entity_X_err_X,
+	ID_ERR_SNAPSHOT_VERSION_X_version_X,
+	ID_ERR_SNAPSHOT_TARGET_X_path_X_key_X_value_X_expected_X,
+	ID_MSG_SNAPSHOT_RECORDED_X_path_X,
+	ID_MSG_SNAPSHOT_UNDEPLOY_X_path_X,
+	ID_ERR_SEQUENCE_CYCLE_X_sequence_X_chain_X,
+	ID_ERR_RUNTIME_NOT_DISCOVERED_X_action_X,
+	ID_ERR_RUNTIME_MISSING_ZIP_X_action_X,
+	ID_ERR_RUNTIME_UNSUPPORTED_ZIP_X_runtime_X_action_X,
+	ID_MSG_RUNTIME_NOT_SPECIFIED,
+	ID_ERR_DEPENDENCY_TYPE_UNKNOWN_X_name_X_location_X,
+	ID_ERR_PARAMETER_NOT_SINGLE_LINE_X_key_X,
+	ID_ERR_PARAMETER_TYPE_INVALID_X_key_X_value_X,
+	ID_ERR_PARAMETER_NOT_MULTILINE_X_key_X,
+	ID_ERR_PARAMETER_NOT_JSON_X_key_X,
+	ID_ERR_PACKAGE_EXISTS_X_package_X,
+	ID_ERR_ACTION_SOURCE_CONFLICT_X_action_X_source_X_path_X,
+	ID_ERR_ACTION_SOURCE_LOCATION_MISSING_X_action_X,
+	ID_ERR_ACTION_KIND_MISSING_X_action_X,
+	ID_ERR_ACTION_SOURCE_CODE_MISSING_X_action_X,
+	ID_ERR_SEQUENCE_NAME_USED_BY_ACTION_X_sequence_X,
+	ID_MSG_INSPECTING_PROJECT_DIRECTORY,
+	ID_MSG_SEARCHING_DIRECTORY_X_path_X,
+	ID_WARN_ENV_VARIABLE_MISSING_X_key_X,
+	ID_MSG_DEPLOYMENT_STATUS_HEADER,
+	ID_WARN_CONFIG_FILE_INVALID_X_path_X,
+	ID_MSG_VERSION_CHECK_X_version_X_spec_X,
+	ID_MSG_RUNTIMES_HEADER,
+	ID_MSG_RUNTIMES_EXTENSIONS_HEADER,
+	ID_WARN_MESSAGE_CATALOG_X_path_X_err_X,
+	ID_CMD_FLAG_ADD_MANIFEST,
+	ID_CMD_FLAG_ADD_PACKAGE,
+	ID_CMD_FLAG_ADD_FUNCTION,
+	ID_CMD_FLAG_ADD_RUNTIME_X_runtime_X,
+	ID_CMD_FLAG_ADD_MAIN,
+	ID_CMD_FLAG_ADD_WEB,
+	ID_CMD_FLAG_ADD_FEED,
+	ID_CMD_FLAG_ADD_TRIGGER,
+	ID_CMD_FLAG_ADD_ACTION,
+	ID_ERR_PROFILE_NAME_INVALID_X_line_X_value_X,
+	ID_ERR_PROFILE_DUPLICATE_X_line_X_name_X,
+	ID_ERR_LINE_MISSING_SEPARATOR_X_line_X_value_X,
+	ID_ERR_PROFILE_KEY_WITHOUT_PROFILE_X_line_X_value_X,
+	ID_ERR_DOTENV_NAME_INVALID_X_line_X_name_X,
+	ID_ERR_LINE_X_line_X_err_X,
+	ID_ERR_DOTENV_AFTER_QUOTE_X_value_X,
+	ID_ERR_DOTENV_MISSING_QUOTE_X_value_X,
+	ID_ERR_SWAGGER_NOT_OBJECT,
+	ID_ERR_SWAGGER_NO_OPERATION,
+	ID_ERR_SWAGGER_OPERATION_NOT_BOUND_X_method_X_path_X,
+	ID_ERR_SWAGGER_OPERATION_NO_ACTION_X_method_X_path_X,
+	ID_ERR_API_HOST_EMPTY,
+	ID_ERR_API_HOST_NO_HOSTNAME_X_host_X,
+	ID_ERR_VERSION_INVALID_X_version_X,
+	ID_ERR_VERSION_OPERATOR_INVALID_X_operator_X_constraint_X,
+	ID_ERR_LICENSE_EXPRESSION_EMPTY,
+	ID_ERR_LICENSE_EXPRESSION_UNEXPECTED_X_value_X,
+	ID_ERR_LICENSE_EXPRESSION_MISSING_LICENSE,
+	ID_ERR_LICENSE_EXPRESSION_MISSING_PARENTHESIS,
+	ID_ERR_LICENSE_EXPRESSION_MISSING_EXCEPTION_X_value_X,
+	ID_ERR_QUALIFIED_NAME_INVALID,
+	ID_ERR_RUNTIMES_NO_API_HOST,
+	ID_ERR_RUNTIMES_NONE_FOUND,
+	ID_ERR_ARCHIVE_PATH_INVALID_X_path_X,
+	ID_ERR_ACTION_TYPE_UNSUPPORTED,
+	ID_MSG_RETRY_X_attempt_X_err_X,
+	ID_WARN_THROTTLED_X_duration_X_max_X,
+	ID_MSG_VERSION_X_build_X_version_X,
+	ID_MSG_REGISTRY_URL_NOT_FOUND,
+	ID_ERR_REGISTRY_URL_MALFORMED,
+	ID_ERR_MANIFEST_REPOSITORY_URL_MALFORMED_X_url_X,
+	ID_ERR_MANIFEST_REPOSITORY_URL_MISSING,
+	ID_WARN_WHISK_PROPS_READ_X_path_X_err_X,
+	ID_WARN_WHISK_PROPS_CREATE_X_path_X_err_X,
+	ID_MSG_PARAMETER_DUMP_X_description_X_type_X_actual_X_value_X_default_X,
 }
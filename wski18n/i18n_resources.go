@@ -97,7 +97,7 @@ func wski18nResourcesDe_deAllJson() (*asset, error) {
 	return a, nil
 }
 
-var _wski18nResourcesEn_usAllJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x59\x51\x6f\xdb\x38\x12\x7e\xcf\xaf\x18\xe4\xa5\x77\x40\xe0\x6b\x7b\x38\xe0\xd0\x97\xc5\x62\xd3\xc5\x66\xbb\x6d\x8a\xa6\xdd\x62\xd1\x16\x0a\x23\x8e\x64\xd6\x14\x29\x90\x94\x5d\xd7\xf0\x7f\x5f\x0c\x29\x4a\x76\x12\x4a\xb2\x93\x62\xf3\xc4\x98\xc3\xf9\x66\x86\xc3\x99\x8f\xd4\xa7\x13\x80\xcd\x09\x00\xc0\xa9\xe0\xa7\x2f\xe0\xb4\xb2\x65\x56\x1b\x2c\xc4\xb7\x0c\x8d\xd1\xe6\xf4\x2c\xcc\x3a\xc3\x94\x95\xcc\x09\xad\x48\xec\xa5\x9f\x3b\x01\xd8\x9e\x0d\x68\x58\x31\xa3\x84\x2a\x13\x3a\x3e\xb6\xb3\x63\x5a\x6c\x93\xe7\x68\x6d\x42\xcb\x55\x3b\x3b\xa6\x45\xa8\x42\x27\x54\x5c\xd0\x54\x72\xfd\x57\xab\x55\x56\x09\x6b\x85\x2a\xb3\xbc\xe2\xd9\x02\xd7\x09\x45\xbf\x5f\x5d\xbe\x01\xa1\xea\xc6\x01\x67\x8e\xc1\xeb\xb0\x0a\x9e\xe4\x15\x7f\x02\xb4\x2e\x89\x42\x8a\x0b\xc9\xca\x4c\xb1\x0a\x6d\xcd\x72\x4c\x60\xf4\xf3\xe3\xba\x58\xe3\xe6\x03\xe6\xd2\xb4\x36\xe2\xbb\xff\x01\xae\x5f\xbd\xfc\xeb\x7a\x8a\xd2\x5a\x64\x73\x6d\x5d\x42\xe9\x6a\x2e\xec\x02\x7e\x7e\x7b\x01\xd7\xbf\x5d\x5e\xbd\x9f\xaa\x71\x89\xc6\x92\x86\x51\xa5\x7f\xbe\x7c\x77\x75\x71\xf9\x66\x8a\xde\x05\xae\xb3\x42\xc8\x54\x24\x6b\xe6\xe6\xa0\x0b\x70\x73\x84\xd9\x02\xd7\xe0\x65\xc7\xd5\xe6\x68\xdc\x64\xbd\x24\x3c\xa2\xb8\x36\xba\xaa\x5d\xc6\xb1\x96\x3a\xb5\x55\xe7\x1a\xd6\xba\x01\x83\x4c\xca\x35\xac\x98\x72\xe0\x34\x84\x25\xe0\xe6\xc2\xfe\x04\xff\x5a\xff\xe7\xcd\xbf\x5f\xc0\x28\x4e\xa3\x8e\x40\x8a\x8b\x0e\xc4\xa2\x0c\x4b\xe7\xdf\x67\xf5\x56\x22\xb3\x08\xb5\xd1\x4b\xc1\x11\x98\x02\x5a\x81\xca\x89\x3c\x24\xa5\xd3\x0b\x54\x53\x80\x6a\x31\x90\x93\x77\x80\x68\x6b\x48\x9e\x0e\x13\x14\xda\xc0\x65\x8d\xea\x23\x25\xd9\x04\xac\xb1\x13\x7a\xd7\x2d\xe8\x96\xc0\x27\x8e\x05\x6b\xa4\x83\x25\x93\x0d\x82\xb0\x50\x36\x68\xdd\x97\x21\xdc\x8a\x29\x51\xa0\x75\x99\xd2\x2e\x2b\x74\xa3\x78\x02\xf9\x75\x2b\xe8\x13\x0e\x94\x76\xe0\xa5\x81\x39\xf0\x49\xf9\x69\xb3\x99\xd1\x60\xbb\xfd\x32\xfb\xac\xd2\x80\x8d\xaf\x75\x1d\xec\x60\xbe\x7c\xf0\x15\x6e\x47\xb3\x8f\x67\x58\x52\xa1\x72\x87\x00\x8d\xa4\xe6\xfd\x50\x71\xd1\x28\x98\x69\x94\x13\x15\x52\x2d\xaf\x98\xcb\xe7\x09\x94\x77\x41\xcc\xe3\xb4\x4b\x08\xca\xd6\x98\x8b\x42\x20\x07\xa1\x20\x5a\x0c\x5c\xa3\xf5\x81\xf6\x1a\x61\x25\xdc\x1c\x58\xee\x53\xd7\xea\xc6\xe4\x18\xb6\x02\xbf\x39\x54\x54\xdf\xbc\x56\xfc\xe6\xa2\xf1\xad\x2c\xfd\x1a\x86\x63\x5b\x13\x9d\xc8\xe7\x4c\x95\x98\x4a\x84\xe8\x43\x2b\x45\x27\xf8\x96\x3b\x37\xcc\x22\x07\x3a\x61\x73\x1c\xb6\xf8\x41\x66\x36\xca\x36\x75\xad\x8d\x1b\x35\x75\x52\xb8\x45\x08\x76\xa7\xd3\x1b\xb7\xe3\xc1\x74\x03\x83\x54\x26\x45\x25\x5c\x26\x4a\xa5\x4d\xd2\xc2\x0b\xb5\x64\x52\xf0\x88\xe1\x97\x78\x24\x3f\x22\x63\x6f\x99\xd8\xaa\x1b\xc4\xcf\xb5\x2a\x44\xd9\xf1\x8a\xe1\x42\xf9\x9e\x3c\xdc\x2f\x8c\xd4\xaf\xda\x68\x04\x55\xcd\xa1\x88\x83\x15\x93\x10\xa9\xdd\x92\xc8\xc3\x70\xc6\xaa\x25\x21\xf5\xe5\xf1\x28\xa8\xd6\x95\x21\x8a\x77\xdb\x9f\xcd\x66\x46\xc3\xed\xf6\x0c\x0a\xa3\x2b\xfa\x3f\x64\xff\x76\x3b\x09\x31\x6c\xd7\x18\x22\x89\xc5\x9d\xb2\xe8\x8e\xc3\xea\x82\x33\x86\xb6\x17\xc5\xcd\x66\xd6\xfd\x7f\xb0\x97\x68\x4c\x56\xa2\x8b\xa7\x38\x45\xbd\x7f\x65\x42\x86\xe2\x52\xa2\xf3\xc7\xb0\x3f\x98\x71\x69\x00\xee\xda\x2b\x58\x34\x4b\x91\xe3\x0b\xb2\x05\x8d\x19\x31\xa4\x51\x15\x33\x76\xce\xa4\xcc\xa4\xce\x99\x4c\x35\x86\x28\xb6\x03\x44\xc1\x0a\xe0\x7e\x65\xe8\xb7\x76\x2a\x9a\x42\xb7\xd2\x66\x71\x14\x9e\x50\x0e\x8d\xc2\xe1\x66\xd4\xf7\xac\x70\xbf\x41\x9e\xac\x3f\xe7\x9d\x28\xe4\xba\xaa\x25\x52\x7c\xdb\x4b\x51\xd1\x48\xb9\x9e\x0a\x54\xf8\xfd\x1a\x47\xe1\x82\xb7\xa7\x30\xa0\x11\x58\x87\x05\xef\x1a\x05\xd7\x2b\xbb\x68\x09\x61\x6c\xbf\xd7\x94\x07\x06\x2b\xbd\x44\xa8\x99\x71\xc2\xf3\xc7\x30\x87\x1c\x98\xb5\xe8\x86\xc3\xbf\x63\x69\xce\x54\x8e\x32\x6d\xec\xe5\xab\x19\xfc\x12\x64\x88\x12\x4c\x65\x1b\xea\x80\xa8\x7f\xd8\x11\x3e\x26\xee\x7b\x60\x83\x91\xdf\x43\x1a\x8c\xfd\x64\xbc\x03\xe3\x37\x99\x42\xed\x81\x54\x4c\xb1\x12\xf9\x01\xce\xe9\x02\x38\x86\x38\x52\x2b\x73\x02\xed\xa0\xc3\xc0\x1b\x43\xf6\xb5\x48\xbb\xfb\xfc\xe3\xd2\x70\xc5\x8c\xca\xfc\x85\x93\x08\x7f\x8d\x86\xcc\x1c\x28\xbb\xc4\x04\x16\xb8\x26\x1e\x40\xa5\x7e\xc5\x2c\x18\x74\x46\xe0\x92\xf8\x09\x15\x04\xaf\x6c\xd6\x2b\xa3\x1f\x3c\x59\x94\x12\xac\xd6\x0a\x6e\x90\x2c\x34\x98\x33\x0a\x4d\x1d\x6e\x0f\x5c\xfb\xb8\x34\x16\x41\x38\xd0\x8d\xb3\x74\x97\xd0\x05\xbc\x37\x6c\x29\x2c\xdc\x34\x42\xf2\x09\xae\x50\x9f\xea\xb5\x67\x06\x6b\xc9\xf2\xe4\x7e\x45\x8f\xb4\xe4\x3b\x4e\x89\xc0\x13\x37\x9b\x19\x91\x43\xb7\xae\x71\xbb\x0d\x3c\x31\xe1\xc4\x59\xf4\x82\xcc\x77\xad\x4e\x85\xab\x3d\x9d\xd6\x21\xdb\x6f\xf0\xb7\x9b\x50\x24\x11\x15\x53\x9c\x39\x6d\xd6\x03\xaf\x19\x64\x79\x27\xe7\x11\x76\x76\x46\x58\x68\x75\x25\xf1\x7c\xb0\x1e\x0d\xd0\xce\x75\x23\x39\x05\xc5\xa2\x9b\x41\xb8\xba\xec\xdf\xfd\x48\xda\x8f\x88\xab\xce\x46\x1b\x72\xbc\xb6\x78\x42\x40\xa9\xf9\x15\xf3\x21\xfa\x16\x6d\xf1\xbc\x80\x7b\x34\x4e\xc3\x96\xb0\xee\x1c\x4b\xbf\x91\x7e\x3e\xde\xab\x6e\x5d\x6b\x5c\xcb\x2e\xbc\x50\xb5\xa3\xa4\xda\xbb\x70\xfa\xd9\x78\xbf\x1c\xab\xf3\x14\x65\x8e\x35\x2a\x8e\x2a\x4f\x3e\x46\x44\x51\xe8\x45\x43\x2a\x05\x1b\x66\xb3\x01\x9c\x58\x12\x26\x21\x7d\xe8\x85\x8f\xc1\xea\x97\xdc\xe9\xec\xc9\x97\xcb\xf3\x7b\x61\x60\xce\x2c\xdc\x20\xaa\xbd\x56\xd3\x55\xb0\xb1\x0e\x7a\x8f\x15\x54\x9f\x1b\x93\xe2\xdf\xe7\xb7\xca\xf3\xbd\x36\xfd\x73\x8c\x20\xfa\x73\xb7\x77\x3f\x4e\x5c\xa3\xde\xe9\x91\xbd\xd3\xd8\xd3\xb1\xbd\xdb\xfc\x0e\x8f\xee\x90\x55\x5d\x07\xd6\x8d\xe2\x59\xdb\x5a\x33\xdf\x5a\xd3\x27\x4a\xa2\xa3\x24\xef\xca\xc3\xae\x25\x6d\x63\xf2\x2d\x8c\xf6\xad\x6d\x60\x74\xfe\xf3\xc6\x18\x72\x23\xf6\xe2\xb6\x00\x85\xe7\x98\x30\x26\x0d\xcc\xfa\xbd\x26\x6f\x27\xb3\x0a\xaa\x6e\xb9\x41\xe6\x70\xd8\x76\xff\xd1\x01\xbc\xe4\x9e\x07\xfe\xd5\xc5\x7f\xad\x80\x0a\xad\x65\xe5\xce\xf5\x02\x98\xe2\xed\x5c\xae\x79\x98\xa0\xc1\x84\x1b\x50\x88\xe7\x14\x93\xf8\x9d\xa0\xfe\x08\x93\xbc\x1d\x7d\xf5\x1c\x2d\x99\xf7\xee\xf0\x60\x15\x6b\x21\x76\x0a\xe7\x84\x6a\x79\x34\x4c\x3c\x78\x23\xc7\xf9\x5e\xfd\x0f\x28\x92\xb7\x9c\x7c\x4c\xfc\x89\xc5\x84\x92\xab\x40\xe4\x99\x50\x4b\xbd\x48\x15\x8f\xfe\x76\x1d\xc4\xfc\x29\xa4\x65\xb0\x9a\xa3\xea\x73\xce\x19\x51\x96\x68\xda\xa9\xc7\xcf\xbb\x8e\x44\x7a\xae\xe2\xdf\xa0\x2d\x5b\x0e\x12\xc8\xc0\x6f\x0a\x6d\xee\xa1\x61\xfe\xfd\x8e\xd6\x47\x52\x19\x0b\x4b\xfb\x05\x88\x2a\x47\xd7\x4b\xc6\x0d\x13\xe1\x71\xae\x37\xf0\x01\x66\x79\x4d\xe3\x90\xfe\xd9\xcf\x66\x15\x56\xc4\x0f\xad\xf8\x9e\xc2\x0c\x12\x57\xe2\xbb\xa7\xed\x61\xd9\x1e\x6b\xea\x49\x22\x53\xfe\xd9\x80\xf6\xf1\x06\xdd\x8a\x32\xeb\xd9\xf3\xff\xfb\x1d\xfb\xdf\xb3\xe7\x93\x6d\x72\xa2\x42\xdd\xa4\x58\x61\x3b\x7b\x94\x31\x4f\x9f\x7a\x63\xfe\xfb\x94\xfe\x0e\x8d\x91\xd4\xe5\x50\x9c\xa4\x2e\x8f\x0d\x52\xb0\xea\xd9\x54\x8b\xda\x67\x73\x76\x93\xfc\x78\xf7\x47\xf7\xba\xdb\xd1\x5c\x1b\x53\x54\xae\x43\x9b\xee\x74\xcc\xe0\xa2\x54\xda\x5f\x51\x29\xab\x94\x5e\xcd\x7a\x22\x7f\xf2\xe5\xe4\xef\x00\x00\x00\xff\xff\x29\x1e\x68\xe0\x5e\x1f\x00\x00")
+var _wski18nResourcesEn_usAllJson = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xcd\x3d\x69\x93\x1b\xb9\x75\xdf\xfd\x2b\xba\x54\x95\x5a\x29\x21\x29\x69\x1d\xbb\x92\xa9\xdd\x4d\x29\x92\xd6\x96\xad\xab\x34\x23\xef\x38\x1a\x15\xb7\x87\x04\x39\xbd\x6a\x76\xd3\x8d\xe6\x1c\x76\x29\xbf\x3d\xef\xc2\xd1\xcd\x6e\x00\xa4\x64\x3b\x9b\x43\x1c\x12\xc0\x7b\x78\x00\x1e\xde\x8d\x0f\xbf\xca\xb2\xbf\xc1\xff\x65\xd9\xbd\x62\x79\xef\x24\xbb\xb7\xd1\xeb\xf9\xb6\x51\xab\xe2\x76\xae\x9a\xa6\x6e\xee\x4d\xf8\xd7\xb6\xc9\x2b\x5d\xe6\x6d\x51\x57\xd8\xec\x39\xfd\x06\x3f\x7d\x9e\x04\x46\xb8\xc9\x9b\xaa\xa8\xd6\x23\x63\xfc\x24\xbf\xc6\x46\xd1\xbb\xc5\x42\x69\x3d\x32\xca\xa9\xfc\x1a\x1b\xa5\xa8\x56\xf5\xc8\x10\x2f\xf0\xa7\xd1\xfe\xbf\xe8\xba\x9a\x6f\x0a\xad\x01\xd7\xf9\x62\xb3\x9c\x7f\x52\x77\x23\x03\xfd\xe1\xf4\xcd\xeb\xac\xa8\xb6\xbb\x36\x5b\xe6\x6d\x9e\xbd\xe2\x5e\xd9\x37\xd0\xed\x9b\x0c\xfb\x8d\x42\xc1\x81\x57\x65\xbe\x9e\x57\xf9\x46\xe9\x6d\xbe\x50\x23\x30\xdc\xef\xf1\xb1\xf2\x5d\x7b\x15\x40\x17\x7f\xae\x9b\xe2\xaf\xf4\x45\xf6\xf3\x1f\x9f\xff\xf9\xe7\x94\x41\xb7\xc5\xfc\xaa\xd6\xed\xc8\xa0\x37\x57\x85\xfe\x94\x3d\x79\xfb\x22\xfb\xf9\xf7\x6f\x4e\xcf\x52\x47\xbc\x56\x8d\xc6\x11\xa2\x83\xfe\xe9\xf9\xbb\xd3\x17\x6f\x5e\xa7\x8c\x0b\x33\x9f\xaf\x8a\x72\x8c\x92\xdb\xbc\xbd\xca\xea\x55\xd6\x5e\xa9\x6c\x06\x6d\x33\x6a\x1b\x1f\x76\xa1\x9a\x36\x79\x5c\x6c\x1c\x19\x78\xdb\xd4\x9b\x6d\x3b\x5f\xaa\x6d\x59\x8f\x2d\xd5\xb3\x3a\xbb\xab\x77\x59\xa3\xf2\xb2\xbc\xcb\x6e\xf2\xaa\xcd\xda\x3a\xe3\x2e\x00\xa8\xd0\xff\x95\xdd\xbf\x7b\xf8\xfa\x01\x34\x8d\xc1\xd9\x55\x47\x40\x32\x9d\x0e\x84\x85\x3b\x6c\x7c\xff\x5d\x54\x6f\x4b\x95\x6b\x95\x41\xeb\xeb\x62\xa9\xb2\xbc\xca\xb0\x87\xaa\xda\x62\xc1\x9b\xb2\xad\x3f\xa9\x2a\x05\xd0\xb6\x08\xec\xc9\x3d\x40\xb8\x34\xd8\x1e\x0f\x53\xb6\xaa\x9b\xec\xcd\x56\x55\x3f\xe1\x26\x4b\x80\x15\x3b\xa1\xfb\xd3\xca\x6c\x97\xec\xc3\x52\xad\xf2\x5d\xd9\x66\xd7\x79\xb9\x53\x59\xa1\xb3\xf5\x4e\xe9\xf6\x63\x08\xee\x26\xaf\x8a\x15\x34\x9a\x57\x35\x6c\xbc\x1a\xd6\x62\x04\xf2\x2b\x69\x48\x1b\x2e\x83\xd6\x19\xb5\xce\xf2\x36\xa3\x4d\xf9\xe1\x6f\x7f\x9b\xe1\x87\xcf\x9f\x3f\xce\x2e\xaa\x71\x80\x3b\xe2\x75\x16\x6c\x70\xbf\xbc\x27\x0e\xe7\x8d\x4c\xf4\xe4\x2e\x1b\x58\xc9\x43\x00\x45\xb6\xe6\x30\x28\xd3\x29\x0a\xac\xd9\xc1\xbe\xda\x28\xe4\xe5\x9b\xbc\x5d\x5c\x8d\x40\x79\xc7\xcd\x08\x8e\x74\x41\x50\x7a\xab\x16\xc5\xaa\x50\x4b\x60\xf0\x99\xc1\x38\x5b\xd6\x4a\x13\xa1\x69\xc4\xec\xa6\x00\x2a\xe7\x0b\xda\xba\xba\xde\x35\xb0\xe0\xb4\x14\xea\xb6\x55\x15\xf2\x37\x1a\x15\xfe\x32\xc8\x4b\x5b\xfc\x96\x3f\xc6\x96\xc6\x4c\x62\x71\x95\x57\x6b\xb5\x8c\xcc\x41\x5a\xe1\x09\xee\x4d\xe7\x12\x36\xe8\x32\xc3\x13\x06\x47\x21\x88\xf1\x17\xa1\xb9\xab\xf4\x6e\xbb\xad\x9b\x36\x8a\x6a\x12\xb9\x0b\x26\xb6\x1d\x93\x90\xf3\x66\x90\x8e\x20\xb7\x9a\x97\xc5\xa6\x68\xe7\xc5\xba\xaa\x9b\x51\x0c\x5f\x54\x70\x56\x8b\xa5\x81\x41\x5d\x08\x12\x7d\x42\x64\x7b\x28\xca\x70\x41\xf8\x8b\xba\x5a\x15\x6b\x2b\x57\x84\x19\xe5\x19\xce\xb0\xcb\x18\xf1\xbe\x12\x6a\xf0\x50\xbb\x43\x21\x06\x39\x26\x42\xc4\xeb\x16\x9b\x7c\x19\x9c\x18\xb7\x44\x48\x8e\x3d\x1e\x05\x4a\xa6\x12\x12\xf1\xfa\xf3\x81\xd5\xc3\x8f\x9f\x3f\x4f\xb2\x15\x70\x75\xfc\x9b\x77\xff\xe7\xcf\x49\x10\x79\xb9\x62\x10\xb1\x99\x59\x29\xad\xda\xe3\x60\x59\xe2\xc4\xa0\x75\xa8\x08\x40\xec\xdf\x07\xcf\x12\x24\xff\xf9\x5a\xb5\xe6\x14\x8f\x89\xde\x3f\xe6\xc0\x29\x88\xb9\x40\x63\x3a\x86\xee\x60\x9a\xae\x0c\xd8\x5e\xaf\x40\x86\xe6\xba\x58\xa8\x13\xc4\x05\xc0\x44\x10\xd9\x55\x9b\xbc\xd1\x57\x20\x8a\xcc\xcb\x7a\x91\x97\x63\x17\x83\x69\xe6\x01\x42\x62\x31\x70\xea\xc9\xf7\xad\x4e\x85\x56\xa9\xf6\xa6\x6e\x3e\x1d\x05\xaf\xa8\x5a\xd5\xc0\x00\x41\x58\xee\xce\x62\xfd\x46\x2d\x47\xf9\xcf\x33\xdb\x14\xce\xc5\x66\x5b\x2a\xa4\xaf\x28\x45\xab\x1d\x48\x69\xa9\x80\x56\xb4\x5e\x71\x28\x4b\x60\x76\x7c\x0a\x19\x1a\x02\xb3\xb0\x32\x60\xd8\xd9\xcf\x37\xfa\x93\x08\x84\xe6\xfa\xfd\x19\xf7\x41\xa3\x36\xf5\x35\x08\x3e\x79\xd3\x16\x24\x3f\xf2\x6f\x80\x6f\xae\xe1\x00\xe8\x54\x4c\x17\x79\xb5\x50\xe5\x38\xb2\x6f\xfe\x38\xcb\x9e\x72\x1b\x14\x09\x52\xa5\x8d\xea\x00\xaa\xbf\xf7\x1a\x1f\x43\xf7\x0e\xb0\x20\xe5\x3b\x90\x82\xb4\x4f\x86\x77\x20\xfd\x92\x45\xa8\x0e\x10\xb8\xf2\x72\x10\x2e\x0e\x98\x1c\x28\x45\x4b\xc5\x74\xc4\xab\xac\x2d\x80\x3f\x84\x26\x9c\x2d\x77\x0d\xe2\x27\x90\xfc\x75\xfe\xfb\x6d\x43\x34\x5a\xcc\x49\xe1\x44\x81\x7f\x0b\xfa\x5b\x31\xca\x01\x91\xed\xa2\x24\x00\x3c\x1e\xe5\x00\x64\xf5\x37\xb9\x06\xf8\x6d\x53\xa8\x6b\x94\x4f\x90\x21\xd0\x60\x33\x37\x18\x7e\x41\xc2\x62\x59\x82\xcc\x05\x97\xf9\xa5\x42\x0c\x1b\x05\x77\x3b\xf4\xd9\xb2\xf6\xb0\xac\x89\x2e\x3b\xf8\x08\xf2\x46\xbd\x6b\x35\xea\x12\x40\xc2\xb3\x26\xbf\x06\x0e\x7f\xb9\x2b\xca\x65\xc2\x54\xf0\x9e\x72\xa3\xcf\x1b\x20\x05\xdc\x09\xcb\xc8\x8c\xea\x72\xe9\x4d\xaa\x60\x39\x11\xbe\x47\xe1\xb0\xbd\xdb\xc2\x0d\xc2\x72\xe2\xc8\x24\x26\x66\x16\x88\x7e\x2b\x63\x56\xea\xa6\x33\xa6\x6e\x55\xde\xbd\xe0\xfb\x97\x90\x11\x22\x60\x03\x2c\xf3\xb6\x6e\xee\xe6\x61\x21\xc9\xb6\x23\x08\xde\xca\x00\xbd\x64\xac\x51\x78\x44\xac\xaf\x06\x50\x5f\xd5\xbb\x72\x89\x44\x81\x0d\x37\xcb\x58\x75\xe9\xea\x7e\xd8\x9a\x3e\xa1\xac\x3a\x8b\x5e\xc8\x46\x6d\x21\x81\x00\xb7\xe6\x2f\x6a\x11\x12\xdf\x0c\x2e\x24\x17\x2c\x09\xda\x12\x3f\x8a\xc0\xea\x1d\x4b\x5a\x48\xfa\xdd\xe8\x55\x3d\xb5\xa6\x15\xe9\x82\x1a\x6d\xbc\x41\x36\x1d\x85\x93\x7e\x35\xfa\x65\x8c\xcf\x23\x95\xe1\x93\x82\x73\x5b\x2d\xee\x82\x97\x92\xb0\x78\x69\xca\x5b\x89\x71\x00\xb2\xc5\x99\x55\x12\xa4\xf7\xae\xf1\x31\xb0\x5c\x97\xbd\x9b\x7d\xd4\x72\xf9\x6c\x10\x4c\x76\x05\x0c\xe4\x52\xa9\xaa\x73\xd5\x58\x0e\x16\xbb\x41\x07\xb0\x40\xfe\x0c\xa2\x74\xfc\xde\x27\xf6\x3c\x88\xd3\x3f\x4f\x22\x30\xf3\xd9\xbf\xbb\xbf\x0e\x5d\xcd\xb8\xe9\x94\xdd\xbb\xd8\xc7\x69\xbb\x7f\xf9\x1d\x4e\xdd\x10\x56\xf6\x06\x46\x2b\xcf\x5c\xae\xd6\x39\x5d\xad\xe3\x27\x0a\x1a\xe1\x26\xb7\xec\xc1\xc7\x44\x2e\x26\xba\xc2\x70\xdd\xe4\x02\xc3\xf3\xbf\xd8\x35\x0d\x4e\xc3\xdc\xc5\xc2\x80\xd8\x1c\xc3\x9f\x71\x04\xe8\x8a\x6b\x8d\xb3\x4d\x96\x2a\x90\xbb\x2d\x1a\x05\xf7\x46\x18\x77\x72\x3a\x64\xd4\xb2\x33\x03\xb2\xba\x90\xb7\x22\x03\x8d\x43\x03\x7a\x4e\xbd\xc8\x80\x41\xcb\x6f\x8b\x7a\xc9\x3f\xe0\x87\x04\x0d\x88\xe9\x99\x82\xd2\x72\x8f\xa8\x7f\x0f\x94\x08\x0f\xc7\x3d\xa3\x2c\x73\x70\x85\x83\x5c\x4c\x40\x78\x8c\x33\x81\x5b\x1e\x0d\xc6\x1c\xbc\xc8\x71\x1e\x1c\xff\x0b\x98\x64\x6f\x92\x5f\x13\x7e\x22\x33\xc1\xcd\xb5\x02\xdd\x03\x14\xfa\xeb\xfa\x93\x8a\x6a\xd7\xdc\x8c\x4e\x21\x76\x83\x53\xaa\x2a\xb7\xe7\x40\xd4\x5c\xaf\x55\x23\x3f\x7d\xfd\x7d\x67\x85\x48\x92\x55\xc8\x06\xad\xf3\xeb\xa0\x00\xc9\xf2\x0d\xda\xe6\xf6\xc5\x30\xb2\xdf\x61\x7f\x23\x54\x1a\xc6\x22\x1e\x20\xe4\x1c\xf6\x2e\x89\x23\x56\xb0\x71\xce\x21\xf8\x05\x68\xd1\x48\x71\x90\x64\xf6\xd3\xf3\x0d\x70\x48\x90\x0f\x75\xf1\xd7\x31\x98\xdc\xe2\x14\x1a\xe0\xa4\xb8\x5b\x47\x6a\x72\x42\x62\x5e\x91\xd9\x00\xd7\xf1\x52\xb5\x37\xb8\xb3\x1e\x7f\xfb\x1f\xb4\x62\xbf\x79\xfc\x6d\x32\x4e\x68\x72\x01\x4d\x61\x04\x1f\xf9\xf5\x28\x64\x1e\x3d\x22\x64\x7e\xfd\x08\xff\x3b\x94\x46\x65\xbd\x0e\xd1\x09\x7e\x3e\x96\x48\x8c\xd5\xe3\x54\x8c\xc4\x6c\x9e\x5f\x8e\x3a\xef\x5e\x5a\xeb\xae\x15\x73\xb5\xd9\xa2\x70\xc2\xe9\x9a\xb6\x63\xcc\xb2\x17\x68\xea\xc5\x53\x88\xbb\xaa\xaa\x6f\x66\x11\x41\x7e\x71\xa5\x16\x9f\xb6\x75\x51\x85\x0f\x91\x27\x94\xc1\xdd\xba\x6e\xe0\x28\xd3\xad\xcc\x07\x47\xac\xf9\x46\xd2\x26\xf9\xcb\x89\x5f\xf9\x3a\x07\xf2\x11\x23\x98\x4e\xa1\xe7\x0e\xe4\x76\xe8\xb1\xa8\x81\xef\x55\xb8\xff\x59\x25\x55\x0d\xe9\x95\xba\xad\xb7\xdb\x98\x99\xd5\x21\x4d\xe3\x8d\xdf\x0b\xef\xe4\xe7\x8e\x76\x81\xf0\xdc\x10\xc9\x4e\x28\x9f\x54\x9f\x0a\x44\x72\x2c\x02\x00\x7f\x1d\xbb\x89\x26\x38\x49\x24\x9d\x95\x3b\x2f\x15\xac\x15\x73\x53\xd0\x56\xaf\x8b\x7a\xa7\xd1\x5a\x99\x44\x09\xda\x49\x1e\x62\x31\x87\xdc\xeb\xda\xa7\x84\x47\x04\xeb\x97\xf3\xa8\x31\xc9\xdc\xa5\x0a\xa2\xb2\x35\x91\x1c\x84\x91\xf5\xa5\x45\xbc\x5c\xcf\x06\xd1\xf2\x7d\x6b\x48\x34\x96\xca\xd8\xcd\x62\x0f\xa4\xaf\xe6\x4d\xd8\xd9\x81\x28\x17\x71\x21\xaf\x51\x70\x92\x74\x71\x8d\xa6\xec\x45\xb9\x5b\x8e\x5e\x7d\x46\x9b\x34\xb8\xa0\x53\x85\x7b\x2c\x33\x3b\x48\x79\xc7\x57\xd8\x15\xec\x77\xb8\xc3\x62\xc2\x9c\x5c\xf6\x8d\x5a\xc1\xd6\xaf\x16\xe8\x9b\x82\xdd\x5c\x97\xd7\x01\xdb\x15\x1e\x72\xd6\x62\xa8\x21\x3b\xa9\xcc\x00\x88\x98\xfd\x03\xf6\xd5\x1d\xed\x29\x0a\xff\xd0\xc8\xcb\x86\xb6\x63\x04\x4b\x91\x4d\xd4\x6d\xa1\x5b\x9d\xa2\xdb\xfb\x8c\x2a\x2f\x61\xb5\x96\x77\x19\xf7\x36\xd7\xab\x59\xb6\x59\x82\x7f\x59\xc0\xe7\xcb\x71\xb3\xe8\x13\xfc\x6d\x18\x7e\x8f\x2d\x85\x67\x0a\x30\xe6\xdb\x7c\xf1\x09\x24\x14\x58\x92\xbf\xec\x8a\x26\x28\x51\x74\x36\x9f\xb5\x52\xa8\x45\x99\xc3\xd2\x64\x1b\x3e\xd0\x70\x3f\xd4\x15\xea\x9a\x34\xec\xc4\xda\x9e\xa6\x53\xf9\x2a\xc3\xf8\x0d\xc4\x53\x83\xf0\xb4\x60\x97\x85\xfc\x34\x8b\x1c\x31\x63\xda\x42\xa7\x61\xa3\xd0\xc9\x31\xb6\x77\xe9\x64\x93\x68\xb5\xab\x40\x25\xf2\x2d\x7b\x40\xb3\xfb\xfa\xc1\xc4\xb7\xff\xe1\x85\x72\xe9\x3b\x4e\x60\x1b\xad\x76\x2d\xe8\x94\x46\x20\xd2\x5d\x89\x28\x93\xe0\x82\xdd\x76\x09\x63\x0a\x1b\x63\x55\x0c\x8d\x30\x1a\x35\xb0\x55\x5d\x96\xf5\x8d\x9e\x64\x70\x6c\x91\xb5\x5d\xdc\x73\xd7\xc3\xa6\x58\x37\xd0\xf1\xe2\x1e\x85\x75\xd8\x41\x36\x27\x41\xe5\xd7\x58\x0f\xc7\xad\x61\xf8\x1d\xfa\x44\x6b\x26\xd2\xe7\xcf\x27\x99\x98\x1a\x7b\xf6\x44\xba\x99\x3a\xe6\xc0\xc0\xce\x64\x64\xe7\xbb\xed\xbc\xad\xe7\x88\x6b\x60\x8f\xac\xfa\x5c\xc3\x1c\x08\xd8\x07\x9a\x08\x05\xed\x49\xa2\x00\x8e\xb7\xc9\x27\xf8\x55\x63\x5c\x8e\x57\x24\x4a\xd7\x86\x3c\xb3\x38\x4e\x81\x08\xa0\x57\xdc\x24\xbc\x0d\x70\x59\x3d\x6c\x4f\xe2\x10\x2f\x61\xab\xee\xb6\x87\x50\x00\x79\x38\xaf\xf1\x92\xa6\x0b\x1b\xa2\x58\x17\x55\x5e\x72\xd3\xc2\x48\x14\xd0\x0c\xbb\x31\x80\xf0\xe1\x05\x5a\x15\x2b\xf1\x42\x8f\x45\x6b\xd9\xcd\x86\xaa\xc7\xb5\xc2\xf9\xb3\x1a\x42\xfc\x05\x88\x01\xbc\xc9\x0b\x89\xe9\xfa\x2a\x3f\x86\x19\x87\x0f\xdf\x48\xff\x11\xc7\xbd\xdf\xa5\xcb\xba\xac\xf9\x35\x72\xfa\x3b\x40\x83\xfe\x0e\xa7\xb5\x69\x05\x7c\x80\x2c\xa7\x3e\x78\x61\x92\xec\x7c\xfe\xe8\x94\xb3\x24\xaf\xe4\x22\x87\x9d\x7b\x94\x4f\x92\x14\x2d\xec\x9d\x2c\x7e\x21\xad\x8d\x72\x15\x09\xf9\x33\x74\xb6\x0e\xf6\x03\x67\x78\xa3\x2e\x4d\x3c\xc6\xae\x19\xf3\xf1\xfe\xa4\x2e\xfd\x28\x0f\x4f\x3a\xcf\xaf\x81\xe6\x74\x53\x8b\x3c\x05\x83\x44\x2e\xa0\xea\x9a\x8e\x2f\x28\x26\xf9\xd8\x42\xbe\x84\x9f\x90\x27\x5c\xe7\x4d\x81\x83\x6b\x47\x48\xd8\xc7\xd7\x7b\x67\x6d\x16\x0d\x86\xd1\xe1\x08\x18\xdd\xbd\x04\x7c\x1a\x46\xa4\x2a\x89\xb5\xf9\x54\x54\x4b\xd8\x2d\x9f\x40\x0d\xa9\x46\x37\x09\xfd\x0a\x8c\xb0\x5a\xef\xf0\x42\x44\x5d\x18\xba\xf5\xa2\x6f\x26\x3d\x67\x3e\x36\x01\x3a\x37\x9d\x28\x1d\x9d\x36\xe9\x39\xfa\xa9\x40\xf3\x18\x97\x90\xfd\xb8\x0c\x17\xf8\x41\x38\xc0\x3d\x97\x8b\xac\x6e\x03\x0a\x68\x3c\x54\x04\x6b\x77\x2b\x46\x28\xa4\x41\xc0\x20\x91\x0f\x2d\xac\x20\x22\x54\x6d\x22\xe7\x18\x0a\x2b\x42\xe6\x65\x06\xa4\x5f\xcc\x1f\x44\x38\x0c\x61\xe4\x4e\x85\x36\x02\x0a\xf3\x57\xfe\x1a\x9a\x7c\x10\x91\xe3\xa1\x7c\x83\x8b\xf0\xe1\xa1\xe5\x80\x0f\x7b\x3f\xcf\x0e\x9e\x5b\x4c\x2b\x79\x32\x34\x2b\xb8\x8d\xc6\x66\x45\x57\xa4\x2a\xf0\xba\x74\x53\xea\x89\x97\xc0\xe5\x1a\x67\x7f\x0b\xa3\x2c\x82\x8d\x91\xfb\x50\x09\x89\x5d\x6a\xd2\x54\x3b\xf6\x6d\xcc\x45\x3e\x1b\x87\xbd\xd1\x9a\xcd\x82\xa1\xe5\x9e\x56\x2c\xb1\x98\xba\xdb\x8f\x3f\xd3\xc2\x79\xfe\xca\xdc\xeb\xd7\x28\xfe\x9e\x45\x36\x0d\x98\xe9\x55\x21\xe2\x84\x87\xff\xe1\x33\x4e\xdc\x81\x06\x5d\xaf\x67\x77\xca\xfb\xe6\x2c\x2f\xb6\x26\x8c\x95\x58\x0e\x69\xbf\x14\x55\xcc\xa5\x28\x66\xc6\x1e\xf3\x45\xf9\x75\x6c\x4f\x30\x1b\x11\x28\xda\x84\x44\x1b\x69\xd5\xb0\x13\xf3\x7b\x98\x9d\x18\x5c\x57\x21\x45\x61\x00\x45\x6a\x3f\xa1\x33\x79\x9d\xdb\x6d\x5f\x2c\xe3\x1a\x8a\x81\xb8\xcd\x9b\x7c\x23\xc6\x4f\x71\x0f\x8f\x8a\x7d\x1c\xee\xcf\x76\x46\x98\x2e\x75\x55\xad\xa0\xc4\xab\x33\x71\xdf\x32\x4b\x5d\x83\x2a\x5b\x11\x87\x40\x3d\x05\x7e\xa2\xe5\xa4\x31\x98\x35\x78\x5f\x7f\xcf\x5f\x07\x30\xc7\xa6\x65\xa9\x4a\x51\x78\xe7\xba\xcd\xdb\x9d\x0e\x1a\x01\x8c\x73\x18\x98\xc7\xe7\xcf\x0f\x71\x45\xea\x36\x2f\x49\x80\x26\xee\xa0\x7d\xc3\x84\x5c\x00\x78\xba\x62\x3e\x51\x4f\xa1\x0d\xdb\x25\x47\x35\x5a\x14\x5f\x79\x83\x09\x9e\xa8\x3b\x14\xbc\x84\x32\x64\xec\xa2\x27\xf0\x61\xfb\xd1\x53\xb6\x8c\x91\x02\x70\xa5\x7c\x83\x0d\x82\xab\x85\xa5\x1c\xa1\xcd\x8b\xd3\xd3\xf3\xc5\x06\x08\x30\x14\x6d\x34\x21\x86\xf6\xc1\x69\x11\x1f\x5d\xdc\xcc\xca\x0a\x9a\x49\x57\x20\x9c\x3a\x92\x78\x62\x77\xc3\x5b\x6e\xd7\x59\x06\x17\x48\x2e\xb4\xb7\xc6\x1f\x39\xcf\xa2\x78\xca\x81\x36\x5f\x24\x10\x48\x90\x4a\x63\x85\x16\x50\x5f\xf4\x4a\x91\x31\x0d\x28\x8e\x7f\x1c\xcb\xdc\xd8\x9f\x7c\x4a\xf0\xe9\xfa\x66\x9e\x1a\x7f\xba\x06\x55\xec\x26\xbf\xfb\x6a\x71\xa8\x04\x3c\x27\x17\xd4\x9c\x72\x25\x0e\x41\x82\xfb\x71\x8e\xc5\x71\x21\xaa\xa4\x1c\x11\x5d\x2f\xeb\xcd\x21\x8a\x29\xb0\xa5\xa6\xd5\x12\x2f\xcf\xaa\xe1\xa2\x5e\x12\x53\x01\xe1\xb7\x45\xc1\x74\xa9\xd0\xe6\xd8\x7c\xb2\x16\x5c\x98\x33\xdc\x86\x2d\x6f\xfa\xf7\x67\x3f\x4e\xff\xc3\x1e\xd0\x5e\x17\x63\xe3\x85\x03\x48\x21\x3f\x29\x13\x58\x34\xe5\xea\x90\x19\xa0\x07\xf0\x27\x90\x8b\xeb\x1b\x9d\xdd\x7f\xfa\xee\xe5\x8f\x0f\xb2\xb2\xa8\x14\x1c\x50\x9c\x86\xa6\xb3\x71\x97\xdd\xa0\x85\xa1\x83\xf8\xcb\x1f\xd3\xb1\x23\x47\x21\x22\x67\xa8\x13\x39\x29\x83\x88\xca\x25\x4d\x43\xf0\x1d\x4d\xb4\x9b\x64\x32\x16\xfa\x33\x1a\xe0\xf4\x40\x3b\xd0\x9f\x68\x0e\x1c\xdc\x5e\x11\x8b\xcb\x4e\xf3\x6b\xf1\x3d\xe2\xc8\x30\x6b\xea\x3e\x4b\x52\xe7\xb4\x5a\x34\xaa\x3d\x4c\xa3\xb3\xa2\x1e\xe9\x20\x34\x80\x08\xa4\xf8\x51\x04\x70\x0a\x29\x3b\x9f\xbe\xe3\xb6\x53\x52\x77\xa7\x4f\x76\xed\x15\x2c\x8c\xca\x61\x1f\x44\xa8\x8a\x38\x6a\x34\x24\x5b\xeb\xa3\xc6\xef\x0e\x11\x98\x71\x03\x10\x1a\xd0\x6f\xca\x63\x71\x60\x1b\xf2\x6c\x21\x3a\x48\x92\x76\x92\x13\x6a\x79\x02\xf2\x10\x5e\xec\x85\x36\x13\x5d\xa6\xa3\x9a\x28\x32\xee\x45\x97\x91\xa9\xc9\x47\x73\x2c\xa7\x63\x92\xa9\xdb\x2d\x08\x67\xb8\x55\x01\x4d\xe0\x06\x79\xa9\x49\x4b\xcc\x65\x29\x66\x31\x8b\x01\x5a\xbf\xe7\x7a\x51\x6f\xbf\x10\x5d\x7f\xa4\x8f\x36\xcf\x43\x84\x47\x0f\x4f\xa3\x4d\x69\x16\x96\x40\xf8\x89\xdd\x3a\x65\xb1\x50\x95\x8e\xa1\xf7\x92\x5b\xc9\x59\xa0\xcf\xde\x69\xca\xd9\x59\x9c\x9d\xbe\x7d\x76\x9e\xc9\xcf\x88\x13\x7a\xea\x60\x80\x94\x1b\xc9\x47\x25\xac\xb5\xef\x8c\xd6\x2e\x70\x40\x8f\xa9\xd0\xa4\x24\x72\xa5\xc3\x2e\x0d\x18\x8a\x00\x39\x1a\x88\xd5\x91\x73\xe7\xbe\xc6\xe1\x61\xb0\xa2\xaf\xa7\x65\xd1\x35\xd2\x47\x45\x24\x76\x01\x40\x6b\x0c\x9a\x4f\x95\x04\xc4\x9c\x4f\x31\x89\xb0\xea\xeb\xb2\xbe\xec\xec\xa0\x24\xab\x13\x1b\xf6\x2c\x0a\xec\x13\x50\xe3\xae\xbc\x4a\x59\x15\x46\xb6\x5c\xcf\x84\xcb\x77\x28\x8f\x82\xd4\xb1\x7e\x07\x4d\x5e\xea\xe9\x54\xdd\x92\x0f\x6b\x1a\xf7\x39\x88\x74\x84\x7b\x7d\xbe\xdc\x6d\x4b\x34\x1f\xaa\x71\x91\x6d\x28\x12\x8b\xec\x0f\x2b\xe0\xe2\xcb\x8e\x7f\x04\xd3\x43\xaa\x43\x56\x48\xb0\xc8\x37\x97\xc5\x7a\x57\x8f\xea\x12\x5d\xc7\x0c\xc2\x45\x62\xc0\xbd\x97\x97\xe6\xd4\x6a\x1f\x45\x4d\xec\x46\x1c\x31\x8e\xb6\x1b\xe3\xb9\x96\x66\x53\x5c\xe3\x44\x14\x13\x64\xdb\x11\x42\xb1\x92\xc1\xc4\x1a\x91\x71\x79\x02\xa6\x91\x27\xeb\x9a\xc9\x44\x35\xa1\x6b\x8e\xdc\x4d\xdb\xe2\xd0\xbc\x68\xea\x8a\xf4\x01\x1b\x7a\xeb\xfb\xb4\x37\x20\xc0\xd5\x55\x79\x47\x8e\x7d\xf4\xf8\x83\xc6\x80\x3a\x25\x28\x6b\xc5\xba\x68\xe1\xdf\x8b\x7b\xf3\x8b\x7b\xf8\xcf\xf4\xe2\x1e\x6d\xc0\x8b\x7b\x33\xf8\xdf\xc8\x89\xb0\xb6\xd1\x04\xdf\x76\x57\xd1\x2e\xd5\x88\x96\x40\x68\x92\xf7\x81\x4c\x48\xce\xa2\x8a\x54\xdc\xe9\xe8\x0d\xc8\xfe\xb6\x79\xab\x40\x2d\x1a\x3f\x06\x4f\xf3\x0a\x97\xb1\xc1\x08\xcb\x46\xec\x33\xd8\x2f\x33\xfd\x0e\x55\x19\xc8\xba\x76\x93\x93\x11\x20\x6d\xd1\xd0\xf2\x8e\x02\xf6\xb2\x5e\xec\xac\xa5\xe6\x48\x88\x22\x41\x1d\x6b\xcb\x23\x72\x6f\xe1\xf4\xd9\x9f\x37\x0a\x64\xe5\x25\xc8\xd7\xfb\xb2\xa1\xb7\xf5\x13\x5d\xc6\x3e\xa6\x78\x60\xe7\x0d\x88\xe1\xa3\x16\x6e\xa0\x09\xf1\xca\xdc\x72\x6e\x5c\x79\x03\x55\x2c\x8b\xc0\x30\x79\x10\xe4\xe8\xf0\x07\x48\x1c\x0c\xc0\x92\x73\xc2\xde\x52\xd8\x45\x01\xcc\xf4\x02\xf6\x81\x22\xab\xf8\x58\xbc\x08\xb6\x30\xda\x3e\x0a\xc5\x84\xda\x10\x1d\xef\x5b\x52\x3d\x88\x1d\x1b\x01\x1b\x10\xcc\xa5\x85\xec\x4a\x34\x66\x70\xfd\x0b\x6d\x85\x9b\x54\x5c\x4e\x2e\x2a\xf4\xa8\xee\xda\x2d\xda\x3f\x22\x8b\x64\xc8\xa1\x7e\x09\xdd\x6e\x5d\x04\x7f\x11\x11\xf0\x00\x9c\x24\xf2\xf0\xb6\x68\xb9\xcb\x07\x1b\x5c\xf8\xf1\x28\x74\x47\x57\xcf\xc7\x94\x81\x6c\x30\x09\x03\xd1\x59\x50\xa0\x98\x78\xd4\x61\x84\xd4\x23\x87\xb1\xce\xad\x4d\xa9\x98\xaf\xd4\x78\xd8\xcc\x99\x67\xc0\x74\xae\xa6\x2e\x64\xea\xaf\x96\x47\x42\x47\x7a\x46\x4f\x3d\xa1\xd1\xcb\xe8\x77\x49\x1b\x14\x00\x62\x0e\xf3\x3e\xb6\x21\xa7\xcd\x00\x25\x82\x7b\x66\x80\x16\xa8\xaa\x4b\xc7\xc3\x42\x42\x28\x24\xd6\x63\x7b\xc4\xcf\xf3\xf0\x9e\xa5\xa0\xd7\x7d\xe6\xe7\x19\x82\xe5\xb3\xf1\x15\x5a\xf7\x8c\xf0\x48\xeb\xbf\x60\x03\xbf\x11\x71\x0d\x68\xd4\x77\x73\x82\x32\xc9\xf2\x25\x1f\x09\xf9\xd1\x1c\x07\xb2\x0a\x1a\xb5\x0e\x26\xec\xd2\xd1\x63\x12\xc1\x2d\x5d\x6b\x70\xfa\x37\x79\x1b\x51\x01\x70\xae\xdc\x3e\xe3\xf6\x04\x9a\x3f\xfa\x81\xb5\xc6\x65\x37\xe9\xe6\xc8\x43\x2b\x67\x9f\x93\xbf\x23\x0b\xc2\xc8\xdd\x34\x05\x48\x15\x55\xc2\x0e\xc0\x65\xe7\x4e\x87\xae\x3b\x2b\x96\x73\x6b\x16\xe7\xdd\xdf\xd4\x1b\x94\x45\xa2\xe1\xbc\xb2\x8e\x62\x28\xe0\xe2\x3b\x5e\x68\xef\x66\xa7\x5b\xc9\xc2\x62\xd3\x16\xec\x00\x5f\xb6\x32\xc2\x48\x26\x3c\x78\x3a\xe5\x91\xf4\x14\x05\x9a\xd0\x3d\xc3\xcd\x92\xfd\xc8\x0e\xc9\xbe\xda\x10\xbd\x5a\x04\x12\xc8\xd2\x97\x35\xe8\x6f\x00\x60\xa1\xf4\xbc\x5e\x85\xec\x55\xbf\x3f\x3b\x7b\x4b\x16\x06\xa5\x65\xe9\x71\x7f\x50\x57\xba\xe7\x65\x30\x50\x0d\x96\x64\xd4\xf1\x59\x05\x5a\x36\x7c\x7a\xea\x58\x2c\x97\x3d\x10\x80\x2b\x9e\x5b\x9b\x8b\x32\x26\x0f\x0c\x9c\xa0\x8f\xa3\xb7\x0c\xe6\x3a\xc2\x9d\x4f\x4b\x88\x62\x2c\xaa\x98\x3c\x09\x80\xe2\x01\x0f\xa1\xe9\xa1\x28\x99\x2d\xa3\x11\xac\xf0\x2b\x45\x60\x0e\xe2\xc8\x5b\x68\xa8\xd8\x44\xb4\xd4\x44\xa3\x24\x9a\x72\x14\xb2\xcd\x6c\x19\x24\x03\x72\xa2\xb2\xcc\x30\x3c\xda\x9b\x33\x2d\xad\x4c\x29\x6a\x9b\x01\x31\xab\x68\x7d\x8a\x7d\xa9\x89\x86\x06\x9c\x7a\x03\xb2\xa5\xa6\xa3\xab\x8c\x5b\x94\xc8\x56\x80\xab\xee\x48\x4d\x5e\xf0\xc0\x3c\x58\x8a\xd0\x09\x7c\x49\x5a\x1a\xfe\xe0\xb9\x57\x90\x62\xd2\x3f\x9d\x51\x79\x09\x60\x9f\xd4\xb6\x3d\x2c\xf5\x0c\x76\x30\x76\x22\xbd\x0d\x3e\xa3\xca\x83\x12\xae\xb5\x0e\xf0\xdd\x63\x0e\xa9\x97\x45\x32\x8c\xcf\x8b\x67\xf3\xe7\xef\xde\xcd\xdf\xbf\x7e\x7e\xfe\xf6\xf9\xd3\xb3\xe7\xcf\xe6\x67\x4f\xde\xfd\xee\xf9\xd9\xfc\x9c\xd2\x20\xce\xc5\x59\x79\x3e\x37\xa4\x9f\x9f\xa7\x7a\xde\xfc\xf5\x25\xf1\xaf\x51\x64\x6c\x82\x45\x73\x77\xa3\x5d\xd2\x69\x9b\x37\x58\xfa\xa1\xe7\xd9\xe5\x1a\x37\xdc\x84\xb6\x00\x3a\xd5\xa7\x53\xd8\xa2\x4d\x53\x2c\x95\xe9\xe5\x15\xb0\xaa\x91\x32\x79\x75\x77\x93\xdf\x8d\xcf\xf9\xa7\x27\xef\x5e\x0f\x4c\xfa\xcd\x9f\x80\x18\x2f\x9e\x3d\x7b\xfe\xba\x3f\xff\x7f\xe4\xa4\x27\xd9\xba\xa6\xa3\x8b\xe6\x67\x3c\xab\xfb\xf3\x65\x0f\x4b\x9a\xc3\xf4\xab\x46\x29\xd3\xbe\xb3\xd2\x21\xfd\x82\xcd\xe9\x26\x44\x68\x7c\x1a\x3b\xd7\x69\xa2\x0a\xb8\x87\xed\xe2\x6e\x51\x86\x62\x34\x6d\xcb\x91\x50\x6a\x60\xf5\x70\x28\x78\x43\x68\x55\xae\x0e\x88\xf0\xc6\x3a\x7f\x65\xb1\xbe\x6a\x89\x64\x39\x74\x1a\xcf\xf2\xf0\x69\x96\x4b\x82\x73\x38\x7a\x6d\x96\x3d\xc5\x30\xf9\x6e\xcb\x81\xfd\x92\x9b\xa0\x3f\x2e\x20\x82\xd6\x99\x4a\xa5\x48\x83\x0e\xfd\xb6\x0c\x85\x7e\x9f\xbd\x3c\xf5\x06\x35\x02\xe7\x10\xf2\xe2\x22\x1e\x9a\x43\xde\x76\x7b\xd1\xd6\x6c\x30\x12\x14\x37\x2d\x09\x0f\xa7\x13\x3b\x17\xac\x61\xc7\x11\x8c\x8a\xbe\x43\x27\xc7\xfe\xd4\x61\x97\x21\x2b\xbf\x4b\x9e\x67\x30\x34\xe1\x6c\x6c\x52\xd0\x0a\x9d\x6a\x2c\xf5\xf3\x10\x5e\xf0\xb9\x68\x38\x63\x13\x9d\x48\x1a\x01\xe7\x2b\x68\xd2\xa1\x26\x38\x7b\x32\x97\xb0\x11\x12\x8e\x85\x8b\xa0\xf4\x32\x58\x53\xa7\x85\xd2\x6b\x0d\x03\x50\xd5\x87\x43\x67\x67\x4f\xe9\x52\xe9\x45\x53\x5c\xb2\xe7\xcd\xe1\x83\x9d\xba\x51\x8e\xff\xcc\xa9\xc6\x0b\x37\x8e\x4e\x14\xd4\xf3\xb1\x58\x2c\xb3\xb7\x3a\xb3\x9e\x74\x62\xb2\xc4\x43\x38\x18\x03\x06\xcc\x0c\xad\x7d\x21\x0f\xa0\x9b\x01\x70\xef\xdb\xbb\x20\xbf\x12\x09\x7a\x8d\xe7\xac\xa9\x77\xeb\x2b\xc3\xf5\x6f\xef\x8c\x05\xf8\x96\x2b\x3e\x28\xf4\x43\xf3\xd9\x99\xbf\x7d\xf7\xe6\xfc\xcf\x13\xfa\x83\x3f\x23\x5a\xaf\xdf\xf0\xe7\x24\xcc\xd0\x33\x11\x40\xee\x75\x2d\x38\x18\xbf\x3d\x82\xf7\x60\xe3\x61\xec\x1f\x71\xb2\xc3\x5a\xd6\x68\xe7\x93\xf3\x48\x49\x58\xd5\x9f\xfe\xde\x0b\x9d\xe2\x60\x9c\x6f\x14\xdc\xa8\x51\xe1\xb5\xa7\x0a\xa2\x5a\x43\x29\x84\x2c\xd4\xd2\x18\x9d\xad\xc3\xb6\x7e\xfe\x9e\xc8\xa5\x8c\xa6\x46\xdf\x25\x18\xf9\x7d\xec\x90\x0f\xa0\x84\x9b\x8a\x1e\x96\x28\xc1\x8e\x4b\x97\x21\xd1\x89\x6b\xc4\x43\x2c\x45\x23\x7b\xa1\x97\xa2\xba\xf6\x2b\x7a\x58\x57\x25\x62\x11\x41\xfc\x2e\xdf\x94\x92\x22\xa9\x6e\x83\x75\x91\x44\x7a\x92\xda\x77\x66\x09\x0d\xc0\x2e\x39\x9d\xdf\x89\xf1\xbd\x2d\x36\xbb\x8d\xa5\x69\x7e\x1b\x27\x28\xe1\x95\x18\xf4\xd0\x73\xcd\xfa\xe4\xe9\x91\x26\xd9\x34\x27\x91\xd5\x26\x7c\x53\xc2\x4d\xcc\xf7\x21\xbe\xd1\xed\x39\xaa\xdb\x76\x82\x1d\xd8\x9d\xb9\xa2\x95\x96\x01\x40\x7d\x9a\xad\x67\xe6\xaf\x13\x98\xe0\x52\xfd\x12\xd3\xc7\x87\xd0\xa6\xe8\xf0\x38\xc2\xfd\x32\x8c\x63\x78\x9b\xd4\x9a\x6d\x81\x2a\xa8\x39\xdf\x13\x63\xcb\x37\x99\x57\x66\x46\x5e\x00\x37\xef\xee\x3d\xfa\xf0\x16\xa6\x58\xf4\xbc\x84\x93\x77\xe0\x14\x63\x06\x53\x50\x11\xde\xbc\x3b\xc9\x80\x6b\x8e\xb3\xa2\x03\x49\x50\xf4\x02\xf6\xbb\x9c\x8c\xc4\xa9\x26\x66\xda\x31\xd3\x70\xc9\x41\x5f\x6f\x89\xc8\xff\x6b\x73\x8e\x46\x10\x9c\xe0\x0a\x62\x81\x5a\x75\x83\x9e\x39\xb7\x5b\xbd\x15\x8b\x07\xf9\xcf\x23\x2a\xca\x71\xd8\x9b\x41\x8d\x6c\x87\x9b\x23\xce\x31\x3c\x45\x7d\x5b\x97\xc5\xe2\x2e\x1c\x73\x39\xa2\xae\xfb\x51\xa7\x13\x96\x9f\x44\xb9\x45\xbf\xab\xfb\xf5\x24\xc9\x62\xc0\x88\xcc\xb1\x80\xd7\x5c\xad\x56\xe3\x41\xd6\xc3\x19\xcc\x76\x24\x8c\xfb\xa4\x4b\xdc\xe8\xcd\x12\x3a\x3d\x01\xea\x96\x0a\xfe\x41\x47\x1b\x86\xb2\xef\xd6\x74\x2e\xe1\x30\x5d\xd5\xf5\x27\x6d\x7c\xea\x1c\xa2\x01\x9d\xa7\x88\xca\x94\x51\xd1\x87\x4c\x21\x56\xcd\x73\x2c\x31\x74\x3c\xdb\x2b\x34\xbd\xda\x32\x11\xee\xdb\x55\xb9\x0f\xc1\x5b\x4c\x2d\xa3\x15\xbb\xd9\x2b\xd9\xa1\xba\x24\x69\x92\x67\xc7\x64\x32\x7a\xc1\x1f\xc9\xc8\x70\xe8\x0d\xe6\xce\xc3\x1a\x25\x98\xf9\xb1\x2d\xad\xa7\x1c\x95\xd2\xec\x49\xe9\x3a\x91\xb3\xe9\x87\xdc\xd2\x5f\xf1\xb3\x41\x68\x50\x50\x06\x6a\xed\xf1\x6b\xd5\x34\x1d\xb4\x92\x8c\xe2\x29\xe3\x4a\x12\x11\x0f\x51\x78\xc8\xba\xaf\x12\x31\x0e\x26\xdc\x8d\x66\x07\x5f\x49\x52\x23\x55\x3c\x21\x1d\x91\x3e\xdd\xd7\x21\x5f\x2e\x53\x68\xb7\xd9\xe4\xcd\xdd\x68\x70\x54\x65\x9c\xa3\x43\x70\x4f\xba\xf1\xda\xab\x82\xe2\x41\x29\xed\xf7\x38\x6c\x6c\xf8\x4f\xa4\x14\xdd\x7e\x4d\x13\x9b\x97\x11\x8c\xff\xf1\xe2\x33\xca\x9c\x15\x85\x84\x3c\x1e\x42\x6d\x57\xa1\x29\x93\xa5\xde\x00\x66\x7b\x4e\x19\xd9\x41\x83\x8c\xdf\x6a\xc0\xf9\x76\xab\xf2\x06\x91\x45\xf6\xbb\xda\x55\xae\x75\xdc\x5c\x2b\xe8\xb9\xf4\x7c\xb1\xc2\x87\x8a\xf5\x8e\x5c\x43\x26\xf3\xc9\x8f\xe5\xa4\x6c\xa7\x6e\xee\x7f\x4e\x67\x61\x42\x81\x92\x92\x46\x85\x66\xb5\x2a\xa2\xd3\x10\xa2\x20\xf0\xac\x13\x72\x24\x4c\xfd\x96\xce\x69\xdc\xe8\x20\x39\x61\x02\x38\x3a\x45\xc4\xe4\x95\x27\x78\x43\xc7\x18\x5a\xa6\x18\x22\xdb\x22\xb6\x11\xfa\x79\xeb\xdb\xaf\x94\x54\xd5\xd9\xc5\x3d\x6f\x14\x8a\x47\x32\x36\xff\x00\x16\xc8\x27\x56\x77\x24\xdc\x99\x2d\x79\x38\x02\xbd\xdb\x3c\x0e\x2e\x52\x39\xe3\xcc\x14\xc2\x54\xe5\xd2\x29\x40\xe3\xc0\xbb\x2a\x91\x8b\x5b\xed\x1a\xc9\x13\xd0\x8a\xe0\x64\x93\x64\x6c\x8a\x88\x2b\xde\xd8\x29\xd6\x96\xe4\x94\x15\xa0\x51\xd6\xbb\x0f\x75\x59\xac\xd0\xc0\x6c\xb3\x65\x07\x60\x1b\x0e\x64\x28\x4d\x37\x41\x46\x57\x6c\x98\x21\xf6\x04\x3c\x53\x6c\x20\xe1\x2a\x33\x4d\x39\xa6\xd5\x16\x29\xf8\xe8\xf9\x87\x02\xa2\x60\x9e\xfd\xae\x68\x7f\xbf\xbb\xa4\xe0\x1d\x5d\x60\xc1\x4f\xd1\xcc\xd6\xc0\x1c\x76\x97\x18\x85\xf2\xf0\xbb\xba\x59\xff\xf0\xf0\x3b\x6c\xf2\xc3\x87\x87\xdf\xe1\x5c\x7f\x38\x40\x5a\x8d\x99\xce\xc7\x8a\x07\xd2\xd7\x28\x38\x59\x93\xf9\x07\x67\x33\x3f\x00\x3e\x7c\x6c\xaf\x8e\x13\x96\x15\x39\x64\xdd\x2d\xe3\x71\x99\x12\x2e\xfb\x12\x6f\x14\xb5\x3d\x16\xb1\xe4\xe7\x2f\x02\x58\x0a\x17\xea\xd6\x2b\x15\x43\xaa\xb7\x1b\x26\xb0\x4f\xea\x4f\x30\x97\xdd\xf6\xb0\x28\x59\xf1\xf1\x62\xc4\x53\xa8\xd2\xd5\x99\x1f\x51\x65\x43\x51\xe8\xa8\xf4\xe2\x88\xbb\xe6\x9f\xbb\x16\xa5\xfb\x12\xfd\x48\x8d\x33\xa8\x78\x64\xa6\x16\x9e\x76\x87\xa9\x3d\x5b\x0c\x02\xd5\x0a\x5d\x6f\xd0\x6a\x8a\x70\xa7\x88\x5b\x60\x2a\xd0\x97\x8a\xde\x82\xd6\x88\xd9\x34\xcb\xf9\x39\xc7\x23\x9d\xa7\x25\xae\x71\xe1\x48\xee\x6a\xac\x54\x32\x64\x22\x2d\x0d\x02\x76\xa9\x63\x18\x74\x2b\x2c\x15\x5d\xf8\x03\xc5\x95\x3a\x2c\x49\xd4\x22\x01\x9a\x80\x16\x97\xfe\xc2\x72\x66\xe7\xf3\xba\x44\xe4\x40\x71\x1e\xc5\xed\x29\xb5\xd6\xb6\x58\x59\xd7\x48\x67\xc3\x40\xea\x72\xc9\x8e\x8d\xa5\x29\x8b\x12\xce\xf9\x77\x34\x12\x7c\xf4\x38\x6d\xc4\xc1\x87\x0b\x43\x55\x7d\x26\xf6\x49\x10\x12\x60\x52\xc2\x06\xe0\x08\xd1\xcb\x57\x98\xc4\x54\xd1\x2e\x37\x6e\x56\x0a\x67\x3e\xb7\xb1\xfb\xe7\x91\xb7\x09\x3a\x07\x72\xff\xda\x1c\xae\x39\x8c\xee\x0b\x07\xda\xac\x28\xc2\x8b\x1f\xca\x3d\xcc\x6d\xbc\x83\xdd\x55\xd4\x2e\x82\x78\x17\x05\xdd\x2d\x31\x83\x05\x64\x78\xcc\x54\xa3\xa2\x60\xd5\x57\xc3\x92\xdc\xd6\xc3\x0a\x99\x27\xa4\x7e\x20\xad\xe2\x23\xc9\xa7\x1f\x24\xc0\x34\x91\x4c\xb6\x2e\x26\x69\x99\x76\x91\xcd\xbd\x1e\xc4\x6b\xb8\x9e\x62\x9e\x61\xa5\x70\x5c\x6a\x1e\xdb\x93\x7e\x3c\xdb\xba\x01\x20\xbe\x9b\x0f\x66\x8e\x1f\x93\x2a\x7a\x51\x2a\xbd\xa0\x2e\x79\xec\xf6\xbe\xe8\xee\xd3\xc3\x25\xc7\xfd\xd0\x11\xdf\xce\x3c\x12\x34\x9d\x45\xe2\xc6\xd8\x7a\x89\x99\xa8\xe4\xbb\xf4\x96\x5f\x8e\x53\xc2\x2e\xa0\x9e\x83\x4a\xb9\xd5\xc7\x3b\xd7\xb3\xec\x0d\x4a\x82\x57\xb2\x39\x8a\x4a\xfe\x0c\xda\x28\xb1\xde\xf8\x4d\x5e\x60\x54\x52\x8c\x13\xff\x84\x8d\x4d\xa4\xdb\x90\xd0\x87\x91\x41\xc2\xb0\x26\x19\x65\x4a\x65\x4f\xdb\xa6\xfc\xb7\xa7\x54\x2d\xa7\xad\xb7\x51\x4c\x84\x77\xa5\xdc\x4a\x7b\x69\x90\xd2\x37\x0a\xe3\x00\xae\x2a\x43\x4e\x6c\xfd\xa8\x34\xd5\x99\x1f\x18\x20\x60\xc2\x81\xbf\x78\xa7\x0e\x56\x6c\xb6\x91\x29\x54\xe6\x31\xbf\xf3\x6a\x20\xa2\xfd\xb5\xec\x2c\x14\xd9\x97\xec\xef\xb0\x52\x84\xa0\x71\x46\xa1\x04\x41\x65\x9f\x63\xb9\x8a\x76\x56\x5e\x14\x71\xc2\x6a\x0d\xea\x08\x2e\x8c\x98\xa3\xee\xb2\xf7\xef\x5e\x8a\xb1\x82\x9f\x74\xb1\x59\x39\x14\xd1\xc5\xf8\xc6\x1c\x74\x9b\xcd\xae\x45\xef\xa7\xf1\x1c\x8c\xad\xf2\x5b\x9b\xb9\xd5\x28\xeb\xed\xe8\xd4\x21\x60\xf3\x16\xde\x6b\xc6\x6c\x8e\x37\x78\x5e\x71\x92\x0b\x66\xe5\x50\xca\xc2\xe5\x6e\xb3\xc5\xa6\x85\x33\xaf\xf7\x38\x46\xe0\xaa\xdf\x43\xd7\x3b\x02\x86\x5d\xc8\x0f\xe7\x41\x91\x93\x90\xe9\xa5\xaf\x75\x76\x90\x11\x0b\xd0\xd3\x88\xdc\x8d\xbc\x8d\x83\xae\x92\x60\xf1\x09\x4e\xa5\x33\x38\xe1\xdc\x7d\x5c\xe3\x22\x13\xc5\xf5\x76\xbd\x10\x43\xd8\xd2\xf3\x17\x38\xb6\x93\x9d\x45\x8a\x12\x5f\x01\x0b\x51\xa1\x2a\x6e\xf8\x44\xc7\x42\x1f\x24\xe9\x4a\x9f\x91\x90\xc2\x11\xc1\x33\x01\x87\x43\x84\x5d\x83\x43\x00\x62\x82\xa8\xcb\x91\x4f\xd8\x9b\x72\xee\x12\x70\xf4\xe4\x56\xc0\x92\xcc\x9b\xf0\xaf\x94\xbf\xc7\xaf\xa8\x42\xdd\x79\xb4\xda\xa8\x3e\xf1\x8a\xe2\x4d\xfc\x10\x25\x33\xd6\xe7\xcf\x94\x57\x82\xe3\x7d\xfe\xfc\x2f\x0f\x12\x50\xdb\x35\x12\xcd\x7a\x3e\x47\x0b\x26\xfc\x93\x63\xde\xe1\x1a\xb7\x1c\x88\x36\xf8\xff\xf3\xdb\x71\xdc\xa4\xfb\x09\x9b\x3f\x51\x21\xcc\xb9\x22\x83\x8c\x82\x5f\xc9\x47\xfc\x16\x46\xcc\xc8\x76\x51\xd1\x5f\xf9\x6d\x66\xd4\xb0\x38\xaa\x4e\x98\x4a\x38\x0b\xcf\xa5\x31\x51\x87\x36\xf4\x24\x33\x1b\xdd\xf0\x90\x55\xd1\xe8\xd6\xdf\x89\x66\x4f\xc4\x71\xd1\x98\xc5\x3b\x1a\x9e\x70\xca\xbf\x3a\xb3\xce\x7d\x21\xc1\x83\x00\xbb\xba\x2e\x9a\x76\x97\x97\x98\x42\x48\xaf\xd3\xe0\x4a\x2c\x44\x65\x08\x6e\xec\xff\xc6\xd6\x46\x76\x70\xa3\x04\x2d\x9b\x7d\xad\x39\x66\xd0\x0a\xe0\x26\x39\x44\x46\x1d\x90\x28\xe3\x30\x93\x4a\x43\xb2\x93\x18\x44\x95\xcb\x26\x92\x56\x45\x10\xfb\x19\x4c\xfd\x88\xbd\xc4\xcc\x29\x99\xc8\xf8\xbc\x52\xc8\x3e\x88\xbf\x0d\x45\x71\x48\xa6\x59\x42\xbe\x06\x8d\x69\x8c\x31\x52\x05\xb7\xc6\x71\x64\x44\xc4\x7e\xc9\xaf\x73\x60\x17\x85\x7b\x0a\x28\x75\x0f\x23\xc6\x7f\x80\xde\xc3\x28\x59\x03\x14\x1c\xdc\x05\x30\x18\xcd\x11\x5b\x78\xcd\xd2\x77\x12\x00\xf1\x0a\x3e\x4f\x9f\xe2\xef\x7b\x09\x4a\xc9\x49\x23\xdd\x69\xf8\x97\x8b\x9d\x08\xfd\x92\x72\xe3\x59\x74\xc5\xd8\x54\xf8\x36\xd3\xf1\xd9\x8a\x8a\x74\x90\x09\x0d\x53\x47\x0e\xd1\x85\x4d\x78\xf5\xbe\x2e\x5c\x5b\x49\x07\x53\x9d\x32\xb6\xc4\x7e\xff\x1d\xb5\xf9\x41\xec\xb6\x26\xf6\x7e\x76\xa5\xca\xb2\x16\xd4\xf5\xec\xa6\x6e\xca\x25\x07\x37\xe9\x99\xab\xdf\xff\x3d\x16\xe1\x8f\xa3\x2f\x36\x05\x13\x7e\x4f\x32\xfd\xc1\x33\x58\x70\x1e\x33\xe7\x2c\x31\xb7\xe8\xa9\xd7\x12\x22\x44\x09\x80\x1d\x07\xd5\x26\xdf\x92\x72\xc7\x75\xa8\x97\xea\x56\xec\x8c\x45\xab\x36\x9c\x7f\x9b\x10\x0a\x26\x95\xf2\x1a\xcf\x12\x20\xe2\x1b\x39\xe2\x63\x72\x3c\xf5\x1d\x53\x41\x3d\xb5\x9f\x06\xe3\xea\x52\x88\x7a\x44\x6b\xb6\x48\x71\x59\xa2\x98\xaa\x34\x84\x47\xc2\xe0\x26\x9c\xc5\x3b\x29\x54\x54\xf3\xdc\xfb\x25\xaa\xa2\x05\x82\x71\x7a\xca\xc3\x48\x48\x0c\x88\x23\x57\xbe\xbf\x4e\xe2\x5e\x4c\x44\x42\x3b\x46\x67\x6b\x40\xa3\xd0\x9e\x98\x02\x6a\x27\x0d\x0a\x2f\xc6\xf1\x4a\x51\x28\xb7\xda\x22\xe3\x1d\xba\xda\x5d\x2c\x6c\x0c\xaa\x0c\x3f\xb1\x56\x3f\xeb\x21\x37\xc9\xe1\xfd\xda\x80\x89\x4e\x3b\x7a\xab\xb4\xc9\xb7\x57\x09\x4e\x20\xcb\x4b\x91\x1b\x7b\xb5\x99\xad\x27\x17\xcb\x32\xcb\x6b\xe7\xe2\x3a\xa7\x07\xa6\x77\x9a\x02\x66\x8d\x2c\xe4\x14\x7e\xff\x61\x81\x93\x14\x1c\xc9\xf2\xe3\xd7\x5d\x0c\xf9\x33\xde\xf5\x83\x2b\x24\x45\x02\xe3\x62\x86\x33\x5c\x3b\x49\xac\x63\x65\x19\x67\xc9\x88\x26\xd6\x20\x08\xe0\x39\x2c\x54\x7c\x35\x2c\x6d\xf1\xd3\x44\x4c\x4f\xc7\x2a\x9c\xfe\xc3\x30\xc6\xa3\x86\x58\x06\x8a\x4d\xc1\x69\x21\xe8\xdb\x82\xdc\xf4\xf9\x36\x11\xaf\x6e\xb1\x29\x94\x2e\xfc\x82\x53\x9d\xa7\xbe\x67\xd1\xca\x72\xa1\xb7\x74\x5c\xd2\xb3\xbb\xb7\xb2\xfb\xfd\xc2\x71\x0f\xd2\x60\xf0\x83\x42\xaa\x8d\xc2\x62\x9e\x92\x16\xf5\x45\x86\xa3\x70\x82\xc9\x8f\x62\x5b\x1a\x29\x7d\xe9\x07\xa5\x4d\xd8\x10\x75\x60\xf9\xcb\x3e\x3a\xe3\x25\xc3\x05\x93\x6e\xb1\x78\x17\x12\xc7\xfa\xa6\xd1\x72\x83\x79\x50\x1e\xcc\x46\x61\x6c\xce\xc1\x79\x8a\x7b\xa6\x2e\xab\x66\xf9\x4e\xf3\xbc\x1d\xad\xa3\x5b\xb4\xfd\x88\x0b\x7e\x91\x66\x96\xf4\x1e\x16\x85\xcf\x07\x8b\xac\x9e\x8d\xe6\xf6\x4b\x3f\xf7\x0a\x07\x97\x7a\xad\x48\xea\xb7\xa1\xd6\x36\x14\xd6\x96\x0e\xc0\x0f\x84\x3a\x66\x2d\x14\x55\x54\x45\x70\xa8\xea\x2f\xb8\x73\x0c\x07\xa7\x81\xf0\xde\x31\xe8\x4b\x35\x90\xa2\x91\xb2\x03\x07\xde\x35\x84\x1d\x4e\x63\x9e\x03\x97\xda\xcc\x17\xcd\x68\xd4\x4e\x9e\xe1\x8f\x6d\x7e\xe9\x55\x2e\xa3\xc7\x26\xae\xc4\x59\x69\x9f\x16\xc3\x10\x75\x11\x9c\xb1\xcb\x49\x76\x71\xef\x5f\x1f\x3e\x7e\x94\xfd\x2b\xff\xcf\xc5\x3d\xc2\x1a\x1d\x37\x77\x19\x7c\xbd\x29\x2a\x2c\xe4\x32\x4b\xc7\x12\xe3\xd2\xc6\x5e\xad\x42\x53\x9b\x79\xea\xa2\x83\x11\x45\xb3\x09\x5a\xd8\x02\xd1\xfa\xf6\xd1\xe3\xff\x9c\x3e\x7a\x3c\xfd\xf5\xe3\xb3\x6f\x7f\x7d\xf2\x9b\xff\x3c\x79\xf4\x68\xf6\xe8\xd1\xa3\xff\x09\x16\x3e\xea\x63\x43\x2f\x78\x5f\x8f\x3e\x37\x4e\xae\xc9\xdd\xe6\x12\x05\xda\x95\x99\xac\xf3\xf2\xde\xd4\x88\x1e\x55\x76\x11\xb1\x46\xb0\x16\x54\xa5\xc3\x49\xf6\xf8\x37\x49\x38\x2d\xca\x7a\xb7\xcc\x31\x12\xf0\x12\x0f\x6a\x98\x4c\xf9\x25\x57\xab\xc6\xdc\x7e\xf1\x63\x10\xb1\xba\x78\xf4\xb3\x16\x31\xa8\x19\x0d\x14\x54\xbe\x49\xc2\x6e\x0d\x58\x6b\x80\xb5\x72\xab\x7b\xe2\xa6\xa0\x92\x58\x86\x97\x24\xcd\x86\x9f\xa5\x43\xc5\xba\xad\xb7\xc5\x22\x30\x1b\xfa\x5d\xa6\x22\x8f\xd9\x8d\xcd\xe5\xb2\xa9\x3f\x51\x3d\x65\x40\x3f\x36\x2f\x8b\xc0\x57\x9e\x18\x47\x02\xe1\xc5\x8e\x31\xd7\x81\x79\x49\x0b\x50\x8a\xd4\x92\x13\x3f\x80\x53\x37\xe4\x21\x27\x0f\x02\x55\x65\x3d\xa3\xa2\xac\xa4\xb3\x49\xe8\x11\x36\x9a\xd8\xba\x56\x1c\x84\x64\x53\x34\xe9\x95\x0d\x93\x48\xbe\x4f\x23\xda\x77\xdc\xe6\x24\xdb\xee\xf4\x55\x84\x1b\xbb\x17\x81\x36\xdb\xf6\xee\x98\xc8\xdb\xaa\xb6\x2a\xf6\x84\x5f\x98\xe2\x14\x45\xaf\x5c\x23\x85\x0a\xe3\x52\x91\x43\x8a\xf4\x08\x91\xff\xc9\x11\x2c\xf9\x8c\xb0\x0b\x38\x88\xa8\x6f\x10\xa1\xd7\x6d\x38\xb3\x9c\xe3\xda\x09\x57\x2f\xab\x5c\x18\x67\x5a\x05\x42\x1d\x9d\xaa\xcd\xd6\xef\x68\xaf\x7d\x2b\x4d\x97\x0e\xd7\xa8\xc6\xb8\x32\xda\x46\xe2\xc4\xea\xb0\x43\xa1\xfb\x92\xaa\xe4\x09\x1e\x0b\x9b\x74\xcc\xa4\xca\xbd\xda\x35\x70\x43\x38\x8d\x24\x42\x0b\xaa\xac\xc7\x65\x3e\xee\x50\xbb\x8a\x69\x87\x5f\x79\x03\x1c\xe1\x20\xfd\xff\xbc\x2e\xc1\x0a\x4a\x7a\x57\x26\x15\xa8\x90\x96\x5f\xab\x40\x05\xee\x65\x90\x94\x29\xbc\x2e\x48\x33\xef\xd5\xa3\x2c\xdf\x01\xe7\xc3\xb0\xf2\xb4\x61\x51\x34\x0c\x59\x3e\x64\x34\x67\x9b\x25\x61\x47\x74\x16\xab\xf0\x3b\x03\x17\x8e\xe7\xfc\xd5\x06\x8c\x9f\xb0\x7e\xda\xd6\xfc\x4e\x21\xf1\x68\x97\x02\x6c\xda\x92\x9a\xe3\x0f\x9f\x4a\x21\xa4\xaf\xfa\x9a\x73\x41\xbf\x9a\xd3\x09\x07\xe6\x92\x88\x18\x57\xce\xf9\xba\x54\xee\x05\x06\x1c\x84\x9c\x45\x2c\x58\x39\xdd\xd3\xfb\x26\x6e\x71\x0c\x6a\x3e\x66\x09\x90\xe0\x16\x80\xfd\x3b\xc7\x89\x8e\xa7\x3c\x3c\x31\x64\xb8\x6f\x79\x1d\x2d\x81\xcd\x6f\x77\x8f\x76\xba\x47\x65\x1e\x40\xc7\x84\x99\xd2\x52\xa6\x2c\x01\xe6\x00\x0e\xae\xbb\x29\xa9\x34\xbc\x38\xac\x9d\x3f\x79\x7f\xf6\xfb\xef\xed\x5a\xf8\x0d\x70\xb4\x19\x6c\x76\x20\xc4\x96\x79\x3b\xf0\x75\x81\xb9\xdf\x1a\x33\xfd\x75\x8b\x47\x49\x76\x04\xb4\x4a\x59\xd0\x70\x8d\xa6\x03\xb6\x5a\xa1\xc7\xf7\x58\xb4\x80\xc8\xa2\xb9\x8b\x65\x16\x0c\x28\x73\x7e\xec\xd8\x5d\x77\xbb\xcb\x90\x4e\xd1\xeb\x98\x29\xcd\x1f\x07\x54\xe4\x74\x38\xee\xd9\xc6\x03\x52\x9e\x85\x6a\x2a\x90\xe5\x4a\x4f\xd7\x8b\x4d\x46\xcf\x2f\x93\x1b\xeb\xe4\x3b\xf9\xf0\x43\x32\x02\x8b\x62\x7b\x85\xa5\xe4\x6f\x63\xef\xc7\x90\x04\x6f\x1b\xe3\x12\xf1\x31\x41\xe5\xb2\xae\xf1\x51\xdd\xa6\x4d\x86\x8a\x8e\x8c\x38\x38\x5b\x4a\xcd\x37\x29\xf8\xf5\xd7\xb8\xe6\xcc\x93\xe7\xa7\x66\x4f\x3d\xfe\xed\x24\xfb\xf6\xdf\x11\xa7\x5f\x7f\x6b\x82\x9c\x51\x7f\xf9\xed\xbf\x9b\x72\xf5\x87\xaf\x4c\xc4\x7a\xe0\xa4\x7c\xbb\x9f\xf4\xe0\x86\xe2\x0a\xa5\x62\xe9\xb3\x7b\x6a\xd2\x79\x3a\xd2\x2c\x31\x8b\xdd\xd2\x48\x77\xca\x18\x3b\x14\xa7\xa6\x79\x7a\x5c\xa3\x57\xb5\x2c\x1c\xdb\xe8\xb7\x0c\xfa\x26\xba\x45\xcd\xdc\x9f\xc3\x21\xb9\x3d\xdb\x90\xde\xaa\x05\x96\x1d\xb7\xdc\xae\x1f\x19\x89\xc1\x43\xc3\x55\x28\x53\xa3\x23\xcd\x5b\x9b\xff\x9c\xc8\xce\x5e\x08\x72\x5e\xdd\x1d\x13\xdd\x69\x8d\xd2\x58\xb9\xdf\x79\x34\xed\xd7\x29\xce\x4d\xb4\xe4\x0e\xc5\x77\x86\x9e\xe8\xb2\x79\x97\x58\x1d\x5a\x8e\x5d\xaf\xe6\x5a\x93\xdf\xc4\x13\x8d\x70\x9f\xaa\x2a\x67\x54\xbb\x91\xde\xde\x2f\xc7\x05\x29\x76\xcd\x8a\xc2\x8f\x79\xc8\xa8\x88\x24\x7b\xc1\x78\x4d\x3d\xd2\xe2\xb3\xae\x49\x64\x1d\x2e\x64\x87\x36\x40\x18\x01\xef\xdd\x01\xef\x31\x83\xfd\x61\xf6\x1d\x4c\xc9\xf3\x22\xf3\x0b\xda\xa4\x7b\x73\x34\x28\x1a\x4f\xdd\xdb\xb3\xf6\xe3\x43\x63\x90\xcf\xad\xf1\x8a\x1c\x9e\x9c\x2d\x48\x8a\x39\xb9\xa0\x1f\xae\x1b\xa5\x30\xce\x96\xe8\xf5\xfd\x7f\xab\xa6\x2a\xd4\x81\x14\xf1\x7d\xfd\x42\x13\x69\x92\x42\x1c\x99\x47\x97\x0d\x76\xc8\x33\x16\x75\xee\xcf\xdb\x15\x58\x6d\xbc\x4c\xc8\x55\x97\x36\x86\x12\x95\x25\x45\xcf\x03\x78\xf0\xc4\x5d\xf9\x50\x7d\xd8\xac\x6d\xc4\xb4\x9b\xb3\x55\x5f\xcd\x88\x93\x3d\x0f\x3d\x87\xa1\xca\xbd\x66\xb2\xeb\x53\x52\x1a\x1d\x87\x9f\xe7\x7e\xf0\xb7\xde\xad\x56\xc5\x6d\x38\xec\x9b\x9a\xf0\xc9\xa7\x8f\xb2\x3e\xd3\x29\x0f\x38\xcd\x75\x42\x55\xf8\x29\xd9\x8c\xe6\xc9\x28\xfa\xc9\x97\x1e\x9e\x09\xc1\x00\x92\xa2\x5e\x2d\x59\x87\x36\x3e\x5d\x0d\xe4\xf2\x8b\x03\xdb\xb9\x98\x17\x1f\x6d\xc8\x59\xdf\x0b\xcc\x81\x70\xac\xe1\x27\xe3\x5f\xe2\x73\xe3\x1e\xde\xd1\x98\x97\x1e\xda\xfc\x30\xba\xd8\x0e\x2d\x6a\x22\x2e\xb8\x75\x40\x71\xb8\x59\xca\x23\xc2\x9d\x60\x4c\x9e\x2f\x0b\x05\x24\x01\x71\x80\xb0\xac\xa6\xbc\x05\x64\x62\xaf\xc5\x7f\xe1\x51\x21\x96\x4a\x50\x97\x25\x96\xcc\xe3\x7a\x51\xfc\x9c\x7d\xc2\x2c\xad\x05\xc0\xf4\xf1\xef\x42\x0c\x0e\x85\x61\x5d\x29\xbe\x5e\x78\x29\xbe\x58\x27\x2f\x84\xd2\x1c\xe4\xe8\x5a\xe2\xa0\x2a\x26\x93\xae\xcd\xe1\xb0\x5b\x34\xec\xbd\x92\x45\xa3\x9b\xa8\xe8\x6e\xb8\x78\x3c\x82\xe7\x5d\xd9\x37\xed\xb0\xa9\xd4\x7b\x8b\xb0\xb7\x7e\x8d\xf2\x0a\x7e\x30\xfa\xc6\x99\xd4\xdb\x11\xbc\x17\x36\x49\x13\x31\x9b\xbd\xb3\x03\xed\x32\x1d\xb1\x0b\x7b\x27\x86\x55\x1a\x1e\xcf\xab\x34\x4a\xee\x98\xe9\xd4\x6c\x8e\xd0\xbb\x8a\x79\x51\xce\xe9\x35\x2e\x42\x32\x42\x64\x68\xec\x87\x0b\x5e\x4b\x0d\x5a\xd9\x00\xfb\xf4\xa7\x09\xb8\x25\xe8\x97\xcc\x74\x49\x20\xd3\x94\x24\x10\xc2\xd5\xc1\x75\x72\x09\x73\x50\xce\xa7\x39\xb7\xf1\xc0\xa3\xd3\xb0\x32\x49\x26\x4f\x41\x66\x5e\x29\xc1\xae\xaf\x75\xa3\xc3\xc7\xef\x92\xcb\x3b\xd8\xc8\x77\x17\x34\x68\x7e\x39\xb7\xbf\x05\x43\x1d\xb9\x35\xbf\xc2\xcd\x9f\x3d\xa9\xcf\x0f\x81\x97\xcf\x9d\x50\x1b\xf2\x1d\xf8\x0d\x41\x02\xbc\xa4\x37\x7b\xe9\xf4\xd9\x8b\xd7\x8f\x7c\x3b\xc9\x1e\x52\x85\xc2\x99\xbe\xd3\xad\xda\x3c\x34\xee\x9e\x59\xda\x84\x89\xf2\x68\x58\x29\x0b\x4a\xf1\xf8\x07\x4c\xd7\x3c\xb8\x65\x8a\x4a\xb9\xd7\x24\x7c\xf3\xec\xdd\xa0\x9f\x80\xe2\xe0\x98\xf1\x0a\xbc\xb4\x30\x56\x53\x76\x62\x38\x8c\x32\x9e\x85\x34\x50\xb5\x22\xad\xfa\x05\x69\x4f\x21\x59\x1d\xe5\x06\x0c\xb9\xef\xe6\x33\x26\xe4\xd8\xf4\x2d\xe3\x43\x0f\xc6\xd1\xa0\xb1\x80\x6a\x83\x01\x47\xdb\xba\x94\xca\xa4\xc0\xb1\x64\x54\x90\x18\xa2\xd7\x74\xc2\xc6\x60\x8c\xcb\x52\x6d\xd0\x73\x4e\xeb\x12\x0f\x69\x29\xf2\x0d\x85\xdf\xb0\x35\xe3\xe8\x57\x12\xd5\xad\xc9\x95\x31\xef\x76\xbc\x78\xf2\x8a\x7a\xa0\x55\xe3\xa0\xe7\x13\x29\x23\x09\xb0\xe2\x97\x1b\xcf\xf1\xf5\xf3\x48\x4a\xaa\x7b\x5e\xde\xa0\xb1\x8f\x01\x97\x1d\xe9\xa0\xdd\x29\x19\x9a\x6a\xf7\x42\x27\x65\x42\x80\x03\xf9\x32\xf7\x4c\x3e\xa2\x19\x34\x3b\xac\x2e\x66\x42\xb8\x89\x1b\x5d\xdc\x83\x2f\x2f\xee\xe1\xa9\x84\xc1\x01\x3d\x4f\x67\x90\x06\xfc\x57\xd0\x65\xef\x21\xc8\x05\xc1\xe9\x11\x9a\xe0\xab\x21\x7b\x88\x52\x69\x4a\x8b\x9d\x57\x07\x47\x8a\x66\x71\x70\x55\x1f\xc7\x36\xff\xa4\xd2\xea\xe2\x13\x7e\xc8\x44\x38\xb3\x25\x81\x96\xae\xf1\xa0\xf6\xbf\x37\x83\x9e\xd6\x8f\xbb\x93\xe9\x7e\x71\x0f\xc7\x61\x2a\x5f\xdc\x5b\xf0\x0b\xb7\x2a\x48\x51\xc2\x76\x9c\x82\xef\x76\xee\xd1\x9c\x3e\x1e\x92\xd3\x23\xb1\xf9\x11\x10\x4c\xd0\xa3\xa0\x50\xd7\xb1\x02\xf9\x29\x8b\x11\x2d\x67\xb2\x47\xe1\x23\x53\x09\xc8\x8e\xf5\x45\x20\x27\x7d\x0b\x95\x59\x44\x9d\x81\x84\x81\x55\x9c\xd0\x77\xe8\xf6\x0b\xac\xbd\xbf\xd0\xc9\xcf\x4d\x51\x19\xc0\x12\xdf\x8f\x35\xf6\xc9\x94\x88\x5f\x6b\x8e\x32\xbd\x67\x77\x9b\xd2\xd4\xad\xf0\x6c\xec\xfe\xe3\x5f\x95\x93\x00\x6d\x7d\x64\x37\x62\xee\x3f\x94\x1a\x7f\x12\xc3\x62\x5d\xaa\x55\x3b\x1f\xaf\x9b\xf4\x12\x7e\xce\xf6\xde\x70\xf6\x62\xd0\xbd\xc8\x6a\xd1\xfb\x31\x4e\xec\x1a\x53\x7b\xac\xf5\xd2\x3d\xe0\x9a\x62\xbe\xf4\x90\x03\x0e\xbd\x2c\x83\x14\xed\x48\x08\xe6\x8f\xfd\xc2\x88\x7b\xf5\x68\x50\xca\x84\xcb\x92\xaf\x19\x42\x6b\x92\xa1\x1e\xa0\x6e\xf8\xec\x30\x60\x13\xca\x62\x06\x9e\x25\x6f\x86\x94\x5a\x2b\xbd\xd5\xf7\x2f\x6e\xf3\x72\x77\xec\x6a\x2e\x36\x89\xcf\xcc\x58\x19\xc1\x5b\x0b\x13\x99\x68\xee\x5a\x06\x7b\x94\xe7\xdc\x04\x07\x26\x85\x20\xbe\x33\x91\x84\x29\x8e\x29\xd9\x54\xc4\xca\x4d\xce\x0f\xdf\x73\x16\x73\x1d\x7f\x22\xca\xc7\x4e\x7f\x51\xa4\xbb\x8f\x3a\x0a\x4f\xe6\x09\xe5\xdc\xa0\x78\x60\xbc\x61\x8f\x72\x92\x9f\x31\xfe\x72\x0f\x5b\x0a\x06\x1e\xe0\x49\x4a\xd5\x18\x86\x16\x7d\x60\x7d\xbf\xf6\x5c\xcf\xf4\x7f\x28\x44\xb2\x0d\x8e\x00\xc3\x67\x3f\xe1\x57\x2f\x38\xda\x58\x8d\xa3\x19\x7c\xcb\xfa\xa6\x0a\xbc\x25\xf4\x4c\x7e\x4e\x7a\xbc\xce\x1e\x8f\xe8\xab\x5f\x9e\xbe\x13\x41\xc0\x89\x9f\xa6\xe1\xc1\x78\xa4\x5e\x4c\xa6\xa2\x12\x06\x40\xe9\xdd\x26\xa5\xa0\xd2\xb0\x42\xc5\x78\xfa\xfc\x42\x1e\x2a\x33\xfa\xa4\xbe\xca\xbf\xfd\xcd\x6f\x33\x03\xe9\xcb\x0b\xb6\x21\xfa\x68\x9a\x2e\x73\xf6\x5c\x6d\xf2\x6d\x24\x0b\x0c\x5a\x0e\xe9\x3d\x9c\xd1\xe5\xbc\xfa\xfa\x80\xe7\x39\x28\x30\x11\xd8\x3e\xeb\x2a\xc1\xf8\x29\x63\xe6\xb6\x7e\x0f\x8c\x8c\x1e\x7f\x46\x8d\x5e\xed\xac\xe2\xf5\x34\x18\x83\xed\x32\xe5\x09\xb7\x71\x68\xc8\x86\x64\x10\x66\x9b\x78\x9f\x55\x70\xcd\x39\xbc\xa3\xef\xbb\x3b\x44\xe2\xe2\x58\x04\x9d\x4e\x42\xaf\xe0\x35\xe9\x84\x26\xc3\xda\xc9\x43\x4f\x1c\xf8\x9e\xba\xe1\xb9\x6a\x6f\x84\xb7\xbf\xa5\x46\xfb\xea\x16\x85\xb4\x39\x45\x0b\x2d\xb8\xbb\xca\x46\x87\xd2\xd6\x29\x5a\x91\x0a\x62\x6a\x8b\x20\x82\x6a\xb2\x31\xf0\x8c\x60\xf3\xc7\xbd\xb2\x3f\x0c\x81\x42\x2f\xe8\xcd\x2e\x53\x8a\xd8\x47\xd8\x24\xf3\x9a\x33\x16\x8f\x8c\x8a\x84\x5b\xbc\x97\xf7\xb1\xf7\xd3\x24\x3b\x57\xf1\x84\xec\xdd\x4c\x40\xa1\x04\x10\x25\x94\x74\xc8\x84\x88\xeb\x44\xdb\xfd\x55\x81\x15\xf0\x75\xa2\x10\x5e\x71\x0c\x74\xab\xb6\xc9\x1b\xe2\x84\xad\x99\x6a\x9b\xb8\xe1\x82\x27\x62\x60\xbf\x71\xfb\x6c\xef\xed\xb6\xce\x9c\x92\xd5\x8f\x2a\xdf\xea\x2b\xe0\x91\xe1\x67\x44\x4e\xa5\xd9\x58\xf5\xf6\xfd\xe7\x0f\xfd\x6c\x42\xe3\x0c\xef\x26\xa4\x90\x73\x02\xa3\x00\xb9\xfc\x79\x66\x50\x99\x25\x62\xcc\xaf\x3a\xc5\x10\xee\x0b\xc5\x16\x07\xde\x20\xae\x58\x76\xe7\xc1\x2c\x7a\xab\xaf\x4e\x3d\x25\x16\x25\x5b\xfc\x21\x5c\x50\xc2\x21\x71\x78\xcd\x12\x0b\xcb\x78\xac\x46\xcf\x64\xc7\xa1\xe5\x9c\x43\x87\x83\xec\xc4\x54\xc4\xaa\x64\x8e\x07\x48\xd0\x6b\x38\x74\xe4\x0f\x7c\x58\xca\xe4\xfe\x92\x27\xb4\xd0\x0b\xbc\xb0\x93\x4a\xdd\x07\x0b\x43\xd8\x78\x35\x37\x24\x4b\x28\x78\xbf\x79\x6a\x9d\x9e\x65\x6f\x4b\x85\x49\x1d\x1c\x7e\x73\x47\x61\x29\x46\xff\xb6\x0f\x10\x08\x4c\x7d\x60\x52\xa2\x99\x9b\xf1\x5b\xfc\xb5\xd8\xa6\x4f\x0c\x1a\x8f\x66\x3b\xcb\x80\xff\x10\xe4\x77\x95\x1d\x2a\x71\x02\xfb\x8f\x10\xc4\xe6\xd3\x61\x2f\xa3\xcf\x3d\xfc\xbd\x66\xeb\x6f\x41\x1b\x85\x35\x5a\x24\xa8\xcd\x4e\xfd\x48\xad\x57\x46\x17\xfc\xf3\x93\x57\x2f\x93\xab\xae\x62\xe9\xbe\x84\x6a\xf5\xa6\xc2\x5f\xa0\x86\xee\x7e\x99\x55\xaf\x9a\xfd\xcc\x0b\xea\x26\x53\x91\xd0\x49\x77\xdd\x53\xc6\x6b\x43\xa1\xf8\xae\xe6\xae\x2b\xe3\x10\x4b\xf2\xf0\xdf\x96\x65\xdb\x09\xd6\xb8\x56\xa3\xd5\xbf\xa4\xfd\x40\x55\x43\xee\x3d\xc5\xde\xf2\xb4\x6e\x32\x70\xa2\x69\x44\xc0\x1b\x82\xdc\x7b\xb9\xe8\x0c\x86\x99\x75\x2e\x87\x43\x66\xbf\xd9\x95\x6d\x71\xe4\xdc\x6d\xdf\x43\x67\x8e\x80\x7f\xd1\xa3\xf7\x7b\x08\xe6\x1f\x4e\xdf\xbc\x4e\x05\xc7\x9e\x47\xf2\x9d\x45\x0a\xbb\xf5\x9d\x8b\xdc\xc7\xa4\xbf\xa6\x29\xcb\x62\x0b\x88\x08\xc9\x4f\xe5\x67\x38\x1e\x2d\x6b\x92\xe4\xa1\x59\xd8\xf7\x4e\x7b\x0f\x58\xcf\x2e\xaa\x1f\x39\x5f\xed\xa6\xb6\x65\xab\xf9\x91\x78\xef\x5a\x38\xe9\x59\xbe\x8c\x50\x2d\xd7\xe9\x21\xf8\xdb\x2a\xda\x61\xab\xd7\x93\x40\x59\x1b\x41\x8c\x34\x71\x5b\x69\x3b\xfe\xd8\xa5\xe0\x41\x35\x36\x8e\x07\x4e\x8f\x06\x25\x03\xb3\x8b\xb6\x54\x5f\x67\xc2\xa9\x92\x0b\x79\xcc\xe9\xbd\x84\xcb\xbb\xf0\x6b\x04\xb6\x4a\x01\xb7\xfa\x46\xbb\xf2\xb9\xbd\x90\x4f\x93\xad\x6d\x5e\x94\xb5\x5e\xaa\x90\xc9\xb5\xc2\x1b\x84\x2a\x7a\x9a\x0a\x8d\xcb\x02\xa4\x32\xcc\x27\x1c\x7d\xab\xcc\x74\xb1\x32\xb5\xed\xe2\xed\x66\x3d\x0b\x26\xc4\x6b\x95\x37\x14\x54\x13\x85\x77\x6a\x5a\x7a\x60\xec\xde\xf6\x8f\x4f\xda\x32\x90\x11\x00\x0b\x7f\x19\x5b\x63\x64\xe1\x5f\x49\x26\xf6\x73\xcf\x4c\xf9\x27\x63\xa6\x34\xfc\x29\x1a\xfe\x43\xf1\xcb\x12\x94\x82\x8f\xc2\x8e\xba\x01\xa6\xf0\xdf\xf7\xf0\x9f\x27\x4e\xb8\x7a\x79\xd9\x29\x17\x7b\xc5\x06\xd8\x30\x32\x4b\x71\x75\xd3\xa3\xe4\x69\x6f\x3b\x73\x0f\x89\x7f\x16\x0e\x35\x01\xc2\xe2\x76\x5a\xaa\x55\x0e\x1c\x5f\x5c\x8b\x12\xc2\x6f\x17\x22\x18\x48\xac\xad\xf1\x6e\x04\xbe\x91\x7a\xb4\xd8\xa4\xc9\xad\xe7\x84\x01\xfb\x32\x18\xf9\x85\x70\x1c\xa3\xb7\x69\xa7\x05\xfa\x4a\x20\x87\x1a\xb1\xdc\x23\x0c\xc8\x6b\x86\x3f\x84\x30\x36\x32\x59\x78\xa5\x5e\x3e\x79\xfd\xbb\xf7\x4f\x7e\xf7\xfc\xa2\xfd\xe3\x8b\xd7\xcf\x2e\xda\x67\xcf\x7f\x7c\xf2\xfe\xe5\x19\x7e\x78\xfb\xee\xf9\xd3\x27\x67\xcf\xe1\xcb\x17\xaf\xa0\x45\x02\x24\x75\xdb\xaa\x8a\x4a\x90\x86\x81\x3e\x3f\x3f\x7b\xfe\xfa\xf4\xc5\x9b\xd7\x17\x6d\x17\x7e\x2c\x85\x93\x53\xa6\xe7\x40\x8c\xbc\xac\xd7\xc1\xba\x96\xd4\x32\x93\x96\xdd\xb2\xb9\x56\x4f\x61\x2b\xaa\x9f\x9c\xc7\xd5\xdf\x79\x8b\xd8\x04\x6d\xb2\x0e\xea\xa0\x61\x70\xb1\x59\x72\x78\x72\xbe\x5c\xda\x98\xfe\xd1\xa7\x99\x5a\x0a\x23\xec\xc4\x88\x24\x0e\x1d\x7e\xf4\xc9\x7f\x9b\xce\x04\x12\xf5\xc2\xb9\xb1\x62\x3a\x00\x4f\x04\x67\x9c\x6c\xa1\x99\x74\x54\xc2\x6f\x74\xdf\x7f\x87\xad\xaf\x95\xcd\x54\x37\xa4\x49\x83\xef\x6a\x54\xd9\x4f\x23\xa8\x0c\x2a\xa8\x12\x1e\xe6\x6b\x46\xc9\x4b\x58\x54\x09\x44\xb6\x93\x06\x0a\x03\x43\xe7\x90\x46\x4b\xb4\x34\x50\xe3\x65\x73\xd4\x2d\xaa\x0d\xbe\xc2\x8d\x42\xb3\x57\x12\x27\x75\x15\xd5\xa8\x66\x65\x4d\xc3\x7b\xa9\xf5\xdd\x50\x3a\x2a\x22\xa1\xf9\x9f\x44\xa8\x32\x5c\x02\x15\x8d\x99\xd9\x56\xfb\xce\xb5\xae\x17\x45\xde\x2a\x9d\x08\x2b\x28\x7b\xec\x2f\xd8\x61\x90\xa4\x82\x1c\x5d\x40\x24\xf1\xb8\x10\x77\xd4\x1b\xa2\x21\xf9\xa4\x5c\xd0\xfb\xa2\x58\x92\xe7\xc4\xea\x3c\x32\x26\x4b\x42\x4e\xf3\x49\x43\xc4\x3d\xa1\x65\xb1\x08\x66\x0e\xf5\x91\xb0\xfd\x2d\x1a\x49\xce\x4a\x79\xc0\xcc\x59\x57\xb4\x42\x8d\x08\xc4\x99\x63\xa9\x61\x6a\xc4\x7c\xf3\xfd\x37\xc6\x7e\x96\xa8\x02\x0a\x25\xd0\x98\x6e\x5e\x31\x35\xdf\x1d\x89\x4b\x27\x40\xc9\xc6\x2d\x5e\x2a\x7c\x6e\x8a\x02\xdd\x0d\xb1\x22\x26\x87\xba\x45\xd1\x6c\x78\xaf\x1c\xb4\x4a\x66\xab\x58\x8f\x72\xff\xd1\x89\x84\x95\xb2\x90\x43\x41\x78\x7d\xc0\x69\xa6\x6e\x99\x68\xbe\x42\x7d\xf8\x2f\xbb\xba\x8d\x93\x7c\x57\x75\xbc\x8f\x96\xda\x34\x86\x29\xd2\x42\x1b\x82\xc6\x4b\x82\x6f\xf6\x62\x1a\x06\x66\xc3\x75\xe0\x1c\xb6\xf5\xf4\x4d\x6e\xab\xdd\xd5\x97\xbf\x8c\x3f\x7e\x49\x26\xa4\x7a\xb1\x33\x2f\xb9\x90\x07\xb4\xca\xa4\x4f\x22\x0c\x7c\x7a\x9d\x83\xb7\xc7\x18\x5c\x9d\xd9\x26\x5c\x8f\x82\x9f\x71\xe0\x0a\x24\x78\x49\xeb\x34\x58\x76\x14\x9a\xd9\xa5\xc4\x06\x6f\x14\x1c\xae\x68\x3c\xae\xc3\x80\x5f\x97\xc6\x3e\x1c\x7a\xde\x29\xa1\x21\xc1\x7c\xb7\x53\x17\x2f\x85\x19\x53\xb6\xf7\x8b\xe5\xe1\xa8\x7a\x45\x60\x93\x50\xf5\x81\xc3\xbd\x90\x88\x79\x1d\xbd\x74\x49\x25\xdf\x16\x73\x7c\x27\x3c\x58\xaf\x82\xf3\x96\xcd\xdb\xe2\x89\x03\xc2\x3c\xf1\x5f\xc9\x0f\xa0\xaf\x62\x5b\x9c\x5e\x2e\x27\x96\x21\xdb\x5b\x5e\x30\x8f\xbe\x93\xa6\xbd\x17\xc8\x12\x0a\xf2\x5b\x36\x35\xe8\xca\x4a\x83\xc6\xab\x50\x37\x1e\x58\xfb\x15\x06\x60\x83\x92\xd4\xe4\x45\x20\x0a\xdb\x20\x61\x7a\x71\x09\x7d\xf9\xc3\x7b\xa1\xcd\x0d\x95\xc0\x3f\x17\xa0\xcf\xa0\xf5\xcd\xd4\x12\x4b\x58\x56\xe9\xe4\x15\x20\x3b\x18\x88\x63\x92\xc7\xf2\xd3\x83\x41\x1a\x1e\x2a\x3f\x45\x36\x96\x69\x75\x2c\x14\x90\x19\x80\x23\x5e\x29\x5d\xe8\x08\xa4\x0f\x0f\x8e\x9f\x0b\x3e\xfb\xbe\x15\xce\x90\x76\x27\xd8\x1e\x72\x1f\xa5\x93\xf4\x2f\x3b\xd8\x7c\x94\xdf\xe5\x5f\xfb\x63\x66\x38\x79\xcf\xdd\x76\xe2\x43\x6a\x22\x0c\x8d\xcd\x22\xc9\x55\xa4\x4d\xb5\x5b\x62\x25\xa3\x17\x44\x1a\xb3\xf1\xc6\x04\x91\x21\x14\x2c\x59\xd5\xce\xe9\xc3\xed\xc2\x5c\x0c\x8d\x5f\xd7\x8a\xf9\x72\xff\xc5\xaa\xc8\x81\x26\x2d\xf3\x50\x43\xb0\xf8\x7a\xac\x8b\x6a\xd4\xa7\xeb\x9c\x58\x46\x37\x40\x57\x44\x28\x78\x0e\x34\x3d\xcc\x98\x69\x5b\x3c\xef\x11\xb9\xea\x1d\xb6\x36\x69\x41\xd2\xc5\x89\x3b\xf4\xb2\x55\x8a\xb0\x45\xd6\x8f\xf6\xaa\xa9\xdb\x96\xf3\x52\x5c\x42\x58\xf0\x21\x88\x33\xd3\xc5\xb8\xf7\xcc\x13\xe9\x57\xb5\xab\x92\x8f\xc6\x2a\xb2\x5a\xa1\x15\xb2\x93\x2a\x36\x41\x47\xd7\xa6\xe6\x27\x24\xe8\x3d\x08\x64\xa2\xab\xb2\x58\x5f\xb5\x71\x53\xd9\xb9\xad\x98\x1f\xbb\x3f\xec\x75\x3c\x75\x9e\x33\xfb\xb2\x0c\x05\x7b\xd2\x48\x9f\x3f\x4f\xa7\xfe\xcd\x12\x5a\xa4\x75\xa1\x71\x9d\x30\xfd\x24\x5a\xeb\x57\x1a\xe3\x63\x3c\x2e\xc2\x17\x67\xfa\xbf\xb3\x87\xa6\x64\xcd\x2c\x7b\xe5\x95\xfd\x90\x14\xd7\xed\xee\xb2\x2c\x74\x42\x45\xb6\x0e\x3e\x9b\xbc\x44\xdf\xcf\xe8\x9e\x7c\x65\x7e\xf7\xab\xe2\x01\x6e\xb3\xec\x0c\xcb\xe3\xa1\xc1\x30\xb1\xd4\x9d\xeb\xdf\x05\x6c\xf2\x72\x46\x43\x22\xdb\xbc\x34\x9b\x73\x33\x82\x0d\xd2\xa7\x9b\xe5\x74\x62\xe2\x10\x8f\xc4\x2e\x68\xc0\xee\xe2\x24\xec\x3a\x82\x51\xf4\xc1\x2a\xdc\x72\x73\x5a\x5e\xaa\x03\x96\xf6\x84\xde\x4f\xfc\x04\xdd\x49\xf6\xbe\x22\x95\x8c\xde\x9d\xc9\x97\x99\x0d\xfe\x07\x91\x83\xc2\x42\x88\x28\xdf\x58\xbe\xf5\x4d\x76\x9f\xbe\xc1\xcd\xde\x3f\xf9\x0f\x0e\xc0\x94\x23\xf1\x8e\xc6\x95\xbb\x27\x63\x2b\xcd\xd3\xf1\x75\x0e\xd2\xe5\x6e\xb3\x45\x56\xe5\x1e\x49\x86\xbf\x88\x2f\x53\xbe\x21\xdc\x7d\xde\xc3\x02\x62\x74\x1d\x9d\xc9\x45\x7b\xd1\x5a\x97\xea\xec\x99\x1b\x93\xb5\x76\x0f\x08\xb0\xd7\x8b\xaa\xdb\x1c\xbd\xcb\xdc\x0e\xc1\xdb\x06\xd3\xe9\x0f\xd9\x13\x42\x24\x73\x2d\x18\xb3\x81\x41\xfe\x84\x98\x76\x6d\x04\xfd\x26\xcf\x78\x12\x06\x25\xfa\xc3\x5c\x58\xbf\xfa\xf8\xab\xff\x03\x1d\x20\x6f\xa7\x9a\xde\x00\x00")
 
 func wski18nResourcesEn_usAllJsonBytes() ([]byte, error) {
 	return bindataRead(
@@ -112,7 +112,7 @@ func wski18nResourcesEn_usAllJson() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "wski18n/resources/en_US.all.json", size: 8030, mode: os.FileMode(420), modTime: time.Unix(1515789236, 0)}
+	info := bindataFileInfo{name: "wski18n/resources/en_US.all.json", size: 56986, mode: os.FileMode(420), modTime: time.Unix(1515789236, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
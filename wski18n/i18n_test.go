@@ -0,0 +1,146 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wski18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+
+	goi18n "github.com/nicksnyder/go-i18n/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadCatalog(t *testing.T) {
+	defer func(t goi18n.TranslateFunc) { T = t }(T)
+	dir, err := ioutil.TempDir("", "wski18n_catalog_")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	catalog := filepath.Join(dir, "catalog.json")
+	content := `[{"id": "msg_warn_env_variable_missing", "translation": "Variable d'environnement {{.key}} manquante."}]`
+	assert.Nil(t, ioutil.WriteFile(catalog, []byte(content), 0644))
+	assert.Nil(t, LoadCatalog(catalog))
+
+	assert.Equal(t, "Variable d'environnement HOME manquante.",
+		T(ID_WARN_ENV_VARIABLE_MISSING_X_key_X, map[string]interface{}{KEY_KEY: "HOME"}))
+	assert.Equal(t, "Package [hello] exists already.",
+		T(ID_ERR_PACKAGE_EXISTS_X_package_X, map[string]interface{}{KEY_PACKAGE: "hello"}),
+		"the messages missing from the catalog are the default ones")
+
+	assert.NotNil(t, LoadCatalog(filepath.Join(dir, "missing.json")))
+	invalid := filepath.Join(dir, "invalid.json")
+	assert.Nil(t, ioutil.WriteFile(invalid, []byte("{"), 0644))
+	assert.NotNil(t, LoadCatalog(invalid))
+}
+
+// the functions which print messages to the user, or return errors which
+// reach the user, by package, their text must be a message of the catalog,
+// see T()
+var translatedCalls = map[string]map[string]bool{
+	"wskprint": {
+		"PrintOpenWhiskError":     true,
+		"PrintlnOpenWhiskError":   true,
+		"PrintOpenWhiskWarning":   true,
+		"PrintlnOpenWhiskWarning": true,
+		"PrintOpenWhiskStatus":    true,
+		"PrintlnOpenWhiskStatus":  true,
+		"PrintOpenWhiskSuccess":   true,
+		"PrintlnOpenWhiskSuccess": true,
+	},
+	"fmt": {
+		"Errorf":   true,
+		"Print":    true,
+		"Printf":   true,
+		"Println":  true,
+		"Fprint":   true,
+		"Fprintf":  true,
+		"Fprintln": true,
+	},
+	"errors": {"New": true},
+}
+
+func TestNoUntranslatedMessages(t *testing.T) {
+	packages := []string{"cmd", "deployers", "parsers", "utils", "wskderrors", "wskdeploy", "wskenv"}
+	fset := token.NewFileSet()
+	for _, pkg := range packages {
+		files, err := filepath.Glob(filepath.Join("..", pkg, "*.go"))
+		assert.Nil(t, err)
+		for _, file := range files {
+			if strings.HasSuffix(file, "_test.go") {
+				continue
+			}
+			parsed, err := parser.ParseFile(fset, file, nil, 0)
+			assert.Nil(t, err)
+			ast.Inspect(parsed, func(node ast.Node) bool {
+				call, ok := node.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				selector, ok := call.Fun.(*ast.SelectorExpr)
+				if !ok {
+					return true
+				}
+				if pkg, ok := selector.X.(*ast.Ident); !ok || !translatedCalls[pkg.Name][selector.Sel.Name] {
+					return true
+				}
+				for _, arg := range call.Args {
+					if literal := untranslatedLiteral(arg); len(literal) > 0 {
+						t.Errorf("%s uses the untranslated text %s, add it to wski18n", fset.Position(call.Pos()), literal)
+					}
+				}
+				return true
+			})
+		}
+	}
+}
+
+// matches the verbs of a format, e.g. "%s" or "%-10v", which are not words
+var formatVerbRegex = regexp.MustCompile(`%[-+# 0-9.*\[\]]*[a-zA-Z%]`)
+
+// untranslatedLiteral returns a string literal with words in the expression,
+// outside of the calls of the expression, e.g. wski18n.T(), "" otherwise
+func untranslatedLiteral(expr ast.Expr) string {
+	literal := ""
+	ast.Inspect(expr, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.CallExpr:
+			return false
+		case *ast.BasicLit:
+			if n.Kind != token.STRING {
+				break
+			}
+			value, err := strconv.Unquote(n.Value)
+			if err == nil && strings.IndexFunc(formatVerbRegex.ReplaceAllString(value, ""), unicode.IsLetter) >= 0 {
+				literal = n.Value
+			}
+		}
+		return len(literal) == 0
+	})
+	return literal
+}
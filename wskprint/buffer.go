@@ -0,0 +1,168 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wskprint
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/apache/incubator-openwhisk-client-go/whisk"
+	"github.com/mattn/go-colorable"
+	"github.com/mattn/go-isatty"
+)
+
+// ANSI sequence which moves the cursor to the start of the line and erases it
+const STR_ERASE_LINE = "\r\033[K"
+
+// console serializes the messages printed by entities deployed concurrently,
+// so that they are never interleaved, and keeps the status line (if any) below
+// the messages
+var console struct {
+	sync.Mutex
+	status string
+	// the status line is only displayed on terminals
+	live bool
+	out  io.Writer
+}
+
+func init() {
+	console.live = isatty.IsTerminal(os.Stdout.Fd())
+	console.out = colorable.NewColorableStdout()
+}
+
+func writeConsole(w io.Writer, text string) {
+	console.Lock()
+	defer console.Unlock()
+	eraseStatusLine()
+	fmt.Fprint(w, MaskSecrets(text))
+	drawStatusLine()
+}
+
+// SetStatusLine displays a line below the messages printed, e.g. the progress
+// of a parallel deployment, which is replaced by the next status line
+func SetStatusLine(status string) {
+	console.Lock()
+	defer console.Unlock()
+	eraseStatusLine()
+	console.status = status
+	drawStatusLine()
+}
+
+// ClearStatusLine erases the status line
+func ClearStatusLine() {
+	SetStatusLine("")
+}
+
+func eraseStatusLine() {
+	if console.live && len(console.status) > 0 {
+		fmt.Fprint(console.out, STR_ERASE_LINE)
+	}
+}
+
+func drawStatusLine() {
+	if console.live && len(console.status) > 0 {
+		fmt.Fprint(console.out, console.status)
+	}
+}
+
+// Buffer collects the messages of an entity deployed concurrently with other
+// entities, they are printed together once the entity is deployed (see Flush)
+// so that the output of each entity remains readable. The messages of a nil
+// Buffer are printed right away.
+type Buffer struct {
+	mt       sync.Mutex
+	messages []func()
+}
+
+func NewBuffer() *Buffer {
+	return &Buffer{messages: make([]func(), 0)}
+}
+
+func (buffer *Buffer) add(message func()) {
+	buffer.mt.Lock()
+	defer buffer.mt.Unlock()
+	buffer.messages = append(buffer.messages, message)
+}
+
+func (buffer *Buffer) write(w io.Writer, text string) {
+	text = MaskSecrets(text)
+	buffer.add(func() { fmt.Fprint(w, text) })
+}
+
+func (buffer *Buffer) PrintlnOpenWhiskError(message string) {
+	if buffer == nil {
+		PrintlnOpenWhiskError(message)
+		return
+	}
+	buffer.write(colorable.NewColorableStderr(), formatError(message+"\n"))
+}
+
+func (buffer *Buffer) PrintlnOpenWhiskWarning(message string) {
+	if buffer == nil {
+		PrintlnOpenWhiskWarning(message)
+		return
+	}
+	buffer.write(colorable.NewColorableStdout(), formatWarning(message+"\n"))
+}
+
+func (buffer *Buffer) PrintlnOpenWhiskSuccess(message string) {
+	if buffer == nil {
+		PrintlnOpenWhiskSuccess(message)
+		return
+	}
+	buffer.write(colorable.NewColorableStdout(), formatSuccess(message+"\n"))
+}
+
+func (buffer *Buffer) PrintlnOpenWhiskStatus(message string) {
+	if buffer == nil {
+		PrintlnOpenWhiskStatus(message)
+		return
+	}
+	buffer.write(colorable.NewColorableStdout(), formatStatus(message+"\n"))
+}
+
+// Debug prints the message in verbose mode, see whisk.Debug()
+func (buffer *Buffer) Debug(level string, message string) {
+	message = MaskSecrets(message)
+	if buffer == nil {
+		whisk.Debug(level, message)
+		return
+	}
+	buffer.add(func() { whisk.Debug(level, message) })
+}
+
+// Flush prints the messages collected so far at once
+func (buffer *Buffer) Flush() {
+	if buffer == nil {
+		return
+	}
+	buffer.mt.Lock()
+	messages := buffer.messages
+	buffer.messages = make([]func(), 0)
+	buffer.mt.Unlock()
+
+	console.Lock()
+	defer console.Unlock()
+	eraseStatusLine()
+	for _, message := range messages {
+		message()
+	}
+	drawStatusLine()
+}
@@ -0,0 +1,84 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wskprint
+
+import (
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func useTestConsole(out io.Writer) func() {
+	live, previous := console.live, console.out
+	console.live, console.out = true, out
+	return func() {
+		console.live, console.out, console.status = live, previous, ""
+	}
+}
+
+func TestStatusLine(t *testing.T) {
+	var out bytes.Buffer
+	defer useTestConsole(&out)()
+
+	SetStatusLine("deploying [hello]")
+	writeConsole(&out, "hello deployed\n")
+	ClearStatusLine()
+	writeConsole(&out, "done\n")
+
+	// the status line is erased before a message and drawn again below it
+	assert.Equal(t, "deploying [hello]"+STR_ERASE_LINE+"hello deployed\n"+"deploying [hello]"+
+		STR_ERASE_LINE+"done\n", out.String())
+}
+
+func TestBuffer_Flush(t *testing.T) {
+	var out bytes.Buffer
+	defer useTestConsole(&out)()
+
+	buffers := []*Buffer{NewBuffer(), NewBuffer()}
+	var wg sync.WaitGroup
+	for i, buffer := range buffers {
+		wg.Add(1)
+		go func(i int, buffer *Buffer) {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				buffer.add(func() { out.WriteString(string('a' + rune(i))) })
+			}
+		}(i, buffer)
+	}
+	wg.Wait()
+	assert.Equal(t, 0, out.Len(), "messages are only printed when flushed")
+
+	for _, buffer := range buffers {
+		buffer.Flush()
+	}
+	// the messages of a buffer are printed together
+	assert.Equal(t, "aaaaaaaaaabbbbbbbbbb", out.String())
+
+	buffers[0].Flush()
+	assert.Equal(t, 20, out.Len(), "a buffer is emptied when flushed")
+
+	// the messages of a nil buffer are printed right away
+	var buffer *Buffer
+	buffer.Debug("info", "not verbose")
+	buffer.Flush()
+}
@@ -19,6 +19,7 @@ package wskprint
 
 import (
 	"fmt"
+	"os"
 	"github.com/apache/incubator-openwhisk-client-go/whisk"
 	"github.com/apache/incubator-openwhisk-wskdeploy/wski18n"
 	"github.com/fatih/color"
@@ -31,8 +32,12 @@ const(
 
 func PrintOpenWhiskError(message string) {
 	outputStream := colorable.NewColorableStderr()
+	writeConsole(outputStream, formatError(message))
+}
+
+func formatError(message string) string {
 	fmsg := fmt.Sprintf( STR_PREFIXED_MESSAGE, wski18n.T(wski18n.ID_MSG_PREFIX_ERROR), message)
-	fmt.Fprintf(outputStream, color.RedString(fmsg))
+	return color.RedString(fmsg)
 }
 
 func PrintlnOpenWhiskError(message string) {
@@ -45,8 +50,12 @@ func PrintOpenWhiskFromError(err error) {
 
 func PrintOpenWhiskWarning(message string) {
 	outputStream := colorable.NewColorableStdout()
+	writeConsole(outputStream, formatWarning(message))
+}
+
+func formatWarning(message string) string {
 	fmsg := fmt.Sprintf( STR_PREFIXED_MESSAGE, wski18n.T(wski18n.ID_MSG_PREFIX_WARNING), message)
-	fmt.Fprintf(outputStream, color.YellowString(fmsg))
+	return color.YellowString(fmsg)
 }
 
 func PrintlnOpenWhiskWarning(message string) {
@@ -55,8 +64,12 @@ func PrintlnOpenWhiskWarning(message string) {
 
 func PrintOpenWhiskSuccess(message string) {
 	outputStream := colorable.NewColorableStdout()
+	writeConsole(outputStream, formatSuccess(message))
+}
+
+func formatSuccess(message string) string {
 	fmsg := fmt.Sprintf( STR_PREFIXED_MESSAGE, wski18n.T(wski18n.ID_MSG_PREFIX_SUCCESS), message)
-	fmt.Fprintf(outputStream, color.GreenString(fmsg))
+	return color.GreenString(fmsg)
 }
 
 func PrintlnOpenWhiskSuccess(message string) {
@@ -65,8 +78,12 @@ func PrintlnOpenWhiskSuccess(message string) {
 
 func PrintOpenWhiskStatus(message string) {
 	outputStream := colorable.NewColorableStdout()
+	writeConsole(outputStream, formatStatus(message))
+}
+
+func formatStatus(message string) string {
 	fmsg := fmt.Sprintf( STR_PREFIXED_MESSAGE, wski18n.T(wski18n.ID_MSG_PREFIX_INFO), message)
-	fmt.Fprintf(outputStream, color.CyanString(fmsg))
+	return color.CyanString(fmsg)
 }
 
 func PrintlnOpenWhiskStatus(message string) {
@@ -74,9 +91,9 @@ func PrintlnOpenWhiskStatus(message string) {
 }
 
 func PrintlnOpenWhiskOutput(message string) {
-   	fmt.Println(message)
+	writeConsole(os.Stdout, message + "\n")
 }
 
 func PrintOpenWhiskDebugInfo(message string) {
-	whisk.Debug(whisk.DbgInfo, message)
+	whisk.Debug(whisk.DbgInfo, MaskSecrets(message))
 }
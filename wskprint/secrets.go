@@ -0,0 +1,94 @@
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wskprint
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// replaces the values of secret inputs in the messages printed
+const STR_SECRET_MASK = "******"
+
+// secrets holds the values of the inputs marked as secret, they are masked in
+// every message printed through wskprint and in the reports
+var secrets struct {
+	sync.RWMutex
+	values   map[string]bool
+	replacer *strings.Replacer
+}
+
+// AddSecret registers a value which is masked from now on
+func AddSecret(value string) {
+	if len(value) == 0 {
+		return
+	}
+	secrets.Lock()
+	defer secrets.Unlock()
+	if secrets.values == nil {
+		secrets.values = make(map[string]bool)
+	}
+	if secrets.values[value] {
+		return
+	}
+	secrets.values[value] = true
+
+	// the longest values are replaced first, so that a secret which contains
+	// another one is not partially masked
+	values := make([]string, 0, len(secrets.values))
+	for v := range secrets.values {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if len(values[i]) != len(values[j]) {
+			return len(values[i]) > len(values[j])
+		}
+		return values[i] < values[j]
+	})
+	pairs := make([]string, 0, 2*len(values))
+	for _, v := range values {
+		pairs = append(pairs, v, STR_SECRET_MASK)
+	}
+	secrets.replacer = strings.NewReplacer(pairs...)
+}
+
+// HasSecrets returns true once a secret is registered
+func HasSecrets() bool {
+	secrets.RLock()
+	defer secrets.RUnlock()
+	return len(secrets.values) > 0
+}
+
+// ClearSecrets forgets the secrets registered so far
+func ClearSecrets() {
+	secrets.Lock()
+	defer secrets.Unlock()
+	secrets.values = nil
+	secrets.replacer = nil
+}
+
+// MaskSecrets returns the text with the secrets it contains masked
+func MaskSecrets(text string) string {
+	secrets.RLock()
+	defer secrets.RUnlock()
+	if secrets.replacer == nil {
+		return text
+	}
+	return secrets.replacer.Replace(text)
+}
@@ -0,0 +1,43 @@
+// +build unit
+
+/*
+ * Licensed to the Apache Software Foundation (ASF) under one or more
+ * contributor license agreements.  See the NOTICE file distributed with
+ * this work for additional information regarding copyright ownership.
+ * The ASF licenses this file to You under the Apache License, Version 2.0
+ * (the "License"); you may not use this file except in compliance with
+ * the License.  You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package wskprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskSecrets(t *testing.T) {
+	defer ClearSecrets()
+	assert.False(t, HasSecrets())
+	assert.Equal(t, "token: s3cr3t", MaskSecrets("token: s3cr3t"))
+
+	AddSecret("s3cr3t")
+	AddSecret("s3cr3t-and-more")
+	AddSecret("")
+	assert.True(t, HasSecrets())
+	assert.Equal(t, "token: "+STR_SECRET_MASK, MaskSecrets("token: s3cr3t"))
+	assert.Equal(t, "key: \""+STR_SECRET_MASK+"\"", MaskSecrets("key: \"s3cr3t-and-more\""), "the longest secret is masked first")
+
+	ClearSecrets()
+	assert.False(t, HasSecrets())
+	assert.Equal(t, "token: s3cr3t", MaskSecrets("token: s3cr3t"))
+}